@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/awsiamauth"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+type applyIAMMappingsOptions struct {
+	iamMappingsOptions
+	fileName string
+}
+
+var aim = &applyIAMMappingsOptions{}
+
+var applyIAMMappingsCmd = &cobra.Command{
+	Use:          "iam-mappings",
+	Aliases:      []string{"iam-mapping"},
+	Short:        "Apply AWS IAM Authenticator role and user mappings to a cluster",
+	Long:         "Replaces the IAM role and user mappings in the AWSIamConfig of a cluster. The EKS Anywhere controller updates them in the cluster without upgrading it",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := aim.applyIAMMappings(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to apply iam mappings: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	applyCmd.AddCommand(applyIAMMappingsCmd)
+	aim.bindFlags(applyIAMMappingsCmd)
+	applyIAMMappingsCmd.Flags().StringVarP(&aim.fileName, "filename", "f", "", "Filename that contains the mapRoles and mapUsers lists")
+	if err := applyIAMMappingsCmd.MarkFlagRequired("filename"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (o *applyIAMMappingsOptions) applyIAMMappings(ctx context.Context) error {
+	mappings, err := awsiamauth.ReadMappingsFile(o.fileName)
+	if err != nil {
+		return err
+	}
+
+	client, closer, err := o.managementClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, closer)
+
+	if err := awsiamauth.ApplyMappings(ctx, client, o.clusterName, o.namespace, *mappings); err != nil {
+		return err
+	}
+
+	logger.Info("IAM mappings applied, the cluster will be updated in the background", "cluster", o.clusterName)
+
+	return nil
+}
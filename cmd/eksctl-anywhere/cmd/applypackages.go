@@ -7,7 +7,9 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/aws/eks-anywhere-packages/pkg/artifacts"
 	"github.com/aws/eks-anywhere/pkg/curatedpackages"
+	"github.com/aws/eks-anywhere/pkg/curatedpackages/oras"
 	"github.com/aws/eks-anywhere/pkg/kubeconfig"
 )
 
@@ -65,8 +67,13 @@ func applyPackages(ctx context.Context) error {
 	}
 	packages := curatedpackages.NewPackageClient(
 		deps.Kubectl,
+		curatedpackages.WithSchemaFetcher(oras.NewSchemaFetcher(artifacts.NewRegistryPuller(deps.Logger))),
 	)
 
+	if err := packages.ValidatePackagesFile(ctx, apo.fileName, kubeConfig); err != nil {
+		return err
+	}
+
 	curatedpackages.PrintLicense()
 	err = packages.ApplyPackages(ctx, apo.fileName, kubeConfig)
 	if err != nil {
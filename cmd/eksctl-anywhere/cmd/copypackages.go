@@ -20,6 +20,7 @@ import (
 
 	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/curatedpackages"
+	curatedoras "github.com/aws/eks-anywhere/pkg/curatedpackages/oras"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/registry"
 )
@@ -79,6 +80,8 @@ type copyPackagesConfig struct {
 	dstPlainHTTP     bool
 	dstInsecure      bool
 	dryRun           bool
+	// archive, when set, is the destination of the artifacts instead of destRegistry.
+	archive *curatedoras.Archive
 }
 
 func runCopyPackages(_ *cobra.Command, args []string) error {
@@ -217,7 +220,11 @@ func getChartValues(chartURL string) (map[string]interface{}, error) {
 }
 
 func orasCopy(ctx context.Context, repo, srcRegistry, srcRef, dstRegistry, dstRef string) (ocispec.Descriptor, error) {
-	logger.V(0).Info("Copying artifact", "from", srcRegistry+"/"+repo, "to", dstRegistry+"/"+repo, "dstRef", dstRef)
+	if cpc.archive != nil {
+		logger.V(0).Info("Downloading artifact", "from", srcRegistry+"/"+repo, "dstRef", dstRef)
+	} else {
+		logger.V(0).Info("Copying artifact", "from", srcRegistry+"/"+repo, "to", dstRegistry+"/"+repo, "dstRef", dstRef)
+	}
 
 	if cpc.dryRun {
 		return ocispec.Descriptor{}, nil
@@ -228,15 +235,31 @@ func orasCopy(ctx context.Context, repo, srcRegistry, srcRef, dstRegistry, dstRe
 		return ocispec.Descriptor{}, err
 	}
 
-	dst, err := remote.NewRepository(dstRegistry + "/" + repo)
+	if cpc.archive != nil {
+		return oras.Copy(ctx, src, srcRef, cpc.archive.Target(), curatedoras.ArchiveRef(repo, dstRef), oras.DefaultCopyOptions)
+	}
+
+	dst, err := registryRepository(dstRegistry, &cpc)(repo)
 	if err != nil {
 		return ocispec.Descriptor{}, err
 	}
-	setUpDstRepo(dst, &cpc)
 
 	return oras.Copy(ctx, src, srcRef, dst, dstRef, oras.DefaultCopyOptions)
 }
 
+// registryRepository returns a builder for the repositories of a destination registry.
+func registryRepository(registry string, c *copyPackagesConfig) curatedoras.RepositoryBuilder {
+	return func(repo string) (oras.Target, error) {
+		dst, err := remote.NewRepository(registry + "/" + repo)
+		if err != nil {
+			return nil, err
+		}
+		setUpDstRepo(dst, c)
+
+		return dst, nil
+	}
+}
+
 func setUpDstRepo(dst *remote.Repository, c *copyPackagesConfig) {
 	dst.PlainHTTP = c.dstPlainHTTP
 	dst.Client = &auth.Client{
@@ -182,7 +182,7 @@ func (cc *createClusterOptions) createCluster(cmd *cobra.Command, _ []string) er
 		WithCliConfig(cliConfig).
 		WithClusterManager(clusterSpec.Cluster, clusterManagerTimeoutOpts).
 		WithProvider(cc.fileName, clusterSpec.Cluster, cc.skipIpCheck, cc.hardwareCSVPath, cc.forceClean, cc.tinkerbellBootstrapIP, skippedValidations, cc.providerOptions).
-		WithGitOpsFlux(clusterSpec.Cluster, clusterSpec.FluxConfig, cliConfig).
+		WithGitOps(clusterSpec, cliConfig).
 		WithWriter().
 		WithEksdInstaller().
 		WithPackageManager(clusterSpec, cc.installPackages, cc.managementKubeconfig).
@@ -255,7 +255,7 @@ func (cc *createClusterOptions) createCluster(cmd *cobra.Command, _ []string) er
 		createWorkloadCluster := workload.NewCreate(
 			deps.Provider,
 			deps.ClusterManager,
-			deps.GitOpsFlux,
+			deps.GitOpsManager,
 			deps.Writer,
 			deps.EksdInstaller,
 			deps.PackageManager,
@@ -272,7 +272,7 @@ func (cc *createClusterOptions) createCluster(cmd *cobra.Command, _ []string) er
 			deps.UnAuthKubeClient,
 			deps.Provider,
 			deps.ClusterManager,
-			deps.GitOpsFlux,
+			deps.GitOpsManager,
 			deps.Writer,
 			deps.EksdInstaller,
 			deps.PackageManager,
@@ -119,7 +119,7 @@ func (dc *deleteClusterOptions) deleteCluster(ctx context.Context) error {
 		WithCliConfig(cliConfig).
 		WithClusterManager(clusterSpec.Cluster, nil).
 		WithProvider(dc.fileName, clusterSpec.Cluster, cc.skipIpCheck, dc.hardwareFileName, false, dc.tinkerbellBootstrapIP, map[string]bool{}, dc.providerOptions).
-		WithGitOpsFlux(clusterSpec.Cluster, clusterSpec.FluxConfig, cliConfig).
+		WithGitOps(clusterSpec, cliConfig).
 		WithWriter().
 		WithDeleteClusterDefaulter(deleteCLIConfig).
 		WithClusterDeleter().
@@ -152,10 +152,10 @@ func (dc *deleteClusterOptions) deleteCluster(ctx context.Context) error {
 	}
 
 	if clusterSpec.Cluster.IsManaged() {
-		deleteWorkload := workload.NewDelete(deps.Provider, deps.Writer, deps.ClusterManager, deps.ClusterDeleter, deps.GitOpsFlux)
+		deleteWorkload := workload.NewDelete(deps.Provider, deps.Writer, deps.ClusterManager, deps.ClusterDeleter, deps.GitOpsManager)
 		err = deleteWorkload.Run(ctx, cluster, clusterSpec)
 	} else {
-		deleteManagement := management.NewDelete(deps.Bootstrapper, deps.Provider, deps.Writer, deps.ClusterManager, deps.GitOpsFlux, deps.ClusterDeleter, deps.EksdInstaller, deps.EksaInstaller, deps.UnAuthKubeClient, deps.ClusterMover)
+		deleteManagement := management.NewDelete(deps.Bootstrapper, deps.Provider, deps.Writer, deps.ClusterManager, deps.GitOpsManager, deps.ClusterDeleter, deps.EksdInstaller, deps.EksaInstaller, deps.UnAuthKubeClient, deps.ClusterMover)
 		err = deleteManagement.Run(ctx, cluster, clusterSpec)
 	}
 	cleanup(deps, &err)
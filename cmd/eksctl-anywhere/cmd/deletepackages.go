@@ -58,10 +58,22 @@ func deleteResources(ctx context.Context, args []string) error {
 	if err != nil {
 		return fmt.Errorf("unable to initialize executables: %v", err)
 	}
+
+	b := curatedpackages.NewBundleReader(kubeConfig, delPkgOpts.clusterName, deps.Kubectl, nil, nil)
+	bundle, err := b.GetLatestBundle(ctx, "")
+	if err != nil {
+		return fmt.Errorf("getting the active package bundle: %v", err)
+	}
+
 	packages := curatedpackages.NewPackageClient(
 		deps.Kubectl,
+		curatedpackages.WithBundle(bundle),
 	)
 
+	if err := packages.ValidateDeletion(ctx, args, kubeConfig, delPkgOpts.clusterName); err != nil {
+		return err
+	}
+
 	err = packages.DeletePackages(ctx, args, kubeConfig, delPkgOpts.clusterName)
 	if err != nil {
 		return err
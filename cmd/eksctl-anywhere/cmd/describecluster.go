@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+type describeClusterOptions struct {
+	clusterName string
+	namespace   string
+	kubeConfig  string
+	output      string
+}
+
+var dco = &describeClusterOptions{}
+
+var describeClusterCmd = &cobra.Command{
+	Use:          "cluster",
+	Aliases:      []string{"clusters"},
+	Short:        "Describe the status of a cluster",
+	Long:         "Shows the conditions of an EKS-A cluster, including the state of the curated packages installed in it",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := dco.describeCluster(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to describe cluster: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	describeCmd.AddCommand(describeClusterCmd)
+	describeClusterCmd.Flags().StringVar(&dco.clusterName, "cluster-name", "", "Name of the cluster")
+	describeClusterCmd.Flags().StringVarP(&dco.namespace, "namespace", "n", "default", "Namespace of the cluster")
+	describeClusterCmd.Flags().StringVar(&dco.kubeConfig, "kubeconfig", "", "Management cluster kubeconfig file")
+	describeClusterCmd.Flags().StringVarP(&dco.output, outputFlagName, "o", outputDefault, "Output format: text|json")
+	if err := describeClusterCmd.MarkFlagRequired("cluster-name"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (o *describeClusterOptions) describeCluster(ctx context.Context) error {
+	client, closer, err := managementKubeClient(ctx, o.clusterName, o.kubeConfig)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, closer)
+
+	cluster := &v1alpha1.Cluster{}
+	if err := client.Get(ctx, o.clusterName, o.namespace, cluster); err != nil {
+		return fmt.Errorf("getting cluster %s: %v", o.clusterName, err)
+	}
+
+	report, err := serializeClusterStatus(cluster, o.output)
+	if err != nil {
+		return err
+	}
+
+	logger.V(0).Info(report)
+
+	return nil
+}
+
+func serializeClusterStatus(cluster *v1alpha1.Cluster, outputFormat string) (string, error) {
+	switch outputFormat {
+	case outputText:
+		return serializeClusterStatusToText(cluster)
+	case outputJson:
+		return serializeClusterStatusToJson(cluster)
+	default:
+		return "", fmt.Errorf("invalid output format [%s]", outputFormat)
+	}
+}
+
+func serializeClusterStatusToText(cluster *v1alpha1.Cluster) (string, error) {
+	buffer := bytes.Buffer{}
+	fmt.Fprintf(&buffer, "Cluster: %s\n", cluster.Name)
+	if cluster.Status.FailureMessage != nil {
+		fmt.Fprintf(&buffer, "Failure: %s\n", *cluster.Status.FailureMessage)
+	}
+	if len(cluster.Status.Conditions) == 0 {
+		fmt.Fprintln(&buffer, "No conditions reported")
+		return buffer.String(), nil
+	}
+
+	fmt.Fprintln(&buffer)
+	w := tabwriter.NewWriter(&buffer, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(w, "CONDITION\tSTATUS\tREASON\tMESSAGE")
+	for _, c := range cluster.Status.Conditions {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", c.Type, c.Status, c.Reason, c.Message)
+	}
+	if err := w.Flush(); err != nil {
+		return "", fmt.Errorf("failed flushing table writer: %v", err)
+	}
+
+	return buffer.String(), nil
+}
+
+func serializeClusterStatusToJson(cluster *v1alpha1.Cluster) (string, error) {
+	b, err := json.Marshal(cluster.Status)
+	if err != nil {
+		return "", fmt.Errorf("failed serializing the cluster status to json: %v", err)
+	}
+
+	return string(b), nil
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/curatedpackages"
+	curatedoras "github.com/aws/eks-anywhere/pkg/curatedpackages/oras"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+// downloadPackagesCmd is the context for the download packages command.
+var downloadPackagesCmd = &cobra.Command{
+	Use:   "packages",
+	Short: "Download curated package images, charts and bundle to a local archive",
+	Long: `Download all the EKS Anywhere curated package images, helm charts and the package bundle for a kubernetes version into a tarball.
+Use this command in conjunction with import packages to run curated packages in clusters without access to the package registries.`,
+	SilenceUsage: true,
+	RunE:         runDownloadPackages,
+}
+
+func init() {
+	downloadCmd.AddCommand(downloadPackagesCmd)
+
+	downloadPackagesCmd.Flags().StringVarP(&dpc.outputFile, "output", "o", "", "Output tarball containing the package bundle, charts and images")
+	if err := downloadPackagesCmd.MarkFlagRequired("output"); err != nil {
+		logger.Fatal(err, "Cannot mark flag as required")
+	}
+	downloadPackagesCmd.Flags().StringVar(&cpc.srcImageRegistry, "src-image-registry", "", "The source registry that stores container images")
+	if err := downloadPackagesCmd.MarkFlagRequired("src-image-registry"); err != nil {
+		logger.Fatal(err, "Cannot mark flag as required")
+	}
+	downloadPackagesCmd.Flags().StringVar(&cpc.kubeVersion, "kube-version", "", "The kubernetes version of the package bundle to download")
+	if err := downloadPackagesCmd.MarkFlagRequired("kube-version"); err != nil {
+		logger.Fatal(err, "Cannot mark flag as required")
+	}
+	downloadPackagesCmd.Flags().StringVar(&cpc.srcChartRegistry, "src-chart-registry", "", "The source registry that stores helm charts (default src-image-registry)")
+}
+
+var dpc = downloadPackagesConfig{}
+
+type downloadPackagesConfig struct {
+	outputFile string
+}
+
+func runDownloadPackages(cmd *cobra.Command, _ []string) error {
+	if cpc.srcChartRegistry == "" {
+		cpc.srcChartRegistry = cpc.srcImageRegistry
+	}
+	ctx := cmd.Context()
+
+	dir, err := os.MkdirTemp("", "eksa-packages-archive")
+	if err != nil {
+		return fmt.Errorf("creating packages archive folder: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	cpc.archive, err = curatedoras.NewArchive(dir)
+	if err != nil {
+		return err
+	}
+
+	bundle, err := getPackageBundle(ctx, cpc.srcChartRegistry, cpc.kubeVersion)
+	if err != nil {
+		return fmt.Errorf("cannot fetch package bundle: %w", err)
+	}
+	if err := copyArtifacts(ctx, bundle); err != nil {
+		return err
+	}
+
+	tag := getPackageBundleTag(cpc.kubeVersion)
+	if _, err := orasCopy(ctx, curatedpackages.ImageRepositoryName, cpc.srcChartRegistry, tag, "", tag); err != nil {
+		return err
+	}
+	if err := cpc.archive.WriteBundle(bundle); err != nil {
+		return err
+	}
+
+	if err := packagerForFile(dpc.outputFile).Package(dir, dpc.outputFile); err != nil {
+		return fmt.Errorf("packaging packages archive: %v", err)
+	}
+	logger.Info("Packages archive created", "file", dpc.outputFile, "bundle", bundle.Name)
+
+	return nil
+}
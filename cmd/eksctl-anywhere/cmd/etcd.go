@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/etcdbackup"
+	"github.com/aws/eks-anywhere/pkg/executables"
+)
+
+var etcdCmd = &cobra.Command{
+	Use:   "etcd",
+	Short: "Etcd commands",
+	Long:  "Use eksctl anywhere etcd to inspect and maintain the etcd members of a cluster",
+}
+
+func init() {
+	rootCmd.AddCommand(etcdCmd)
+}
+
+type etcdOptions struct {
+	fileName    string
+	sshKey      string
+	sshUsername string
+	nodeIPs     []string
+	kubeConfig  string
+}
+
+func (o *etcdOptions) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&o.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration")
+	cmd.Flags().StringVar(&o.sshKey, "ssh-key", "", "Private key used to SSH into the etcd machines")
+	cmd.Flags().StringVar(&o.sshUsername, "ssh-username", "ec2-user", "Username used to SSH into the etcd machines")
+	cmd.Flags().StringSliceVar(&o.nodeIPs, "node-ips", nil, "IPs of the etcd machines. By default, they are read from the management cluster")
+	cmd.Flags().StringVar(&o.kubeConfig, "kubeconfig", "", "Management cluster kubeconfig file")
+	for _, flag := range []string{"filename", "ssh-key"} {
+		if err := cmd.MarkFlagRequired(flag); err != nil {
+			log.Fatalf("Error marking flag as required: %v", err)
+		}
+	}
+}
+
+// etcdMembers returns the cluster and the IPs of its etcd machines, checking etcdctl can be run on them over SSH.
+func (o *etcdOptions) etcdMembers(ctx context.Context) (*v1alpha1.Cluster, []string, error) {
+	clusterConfig, err := v1alpha1.GetClusterConfig(o.fileName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	client, closer, err := managementKubeClient(ctx, clusterConfig.ManagedBy(), o.kubeConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer close(ctx, closer)
+
+	cluster := &v1alpha1.Cluster{}
+	if err := client.Get(ctx, clusterConfig.Name, clusterConfig.Namespace, cluster); err != nil {
+		return nil, nil, fmt.Errorf("getting cluster %s: %v", clusterConfig.Name, err)
+	}
+
+	if err := etcdbackup.ValidateMaintenance(ctx, client, cluster); err != nil {
+		return nil, nil, err
+	}
+
+	if len(o.nodeIPs) > 0 {
+		return cluster, o.nodeIPs, nil
+	}
+
+	ips, err := etcdbackup.MemberIPs(ctx, client, cluster)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return cluster, ips, nil
+}
+
+func (o *etcdOptions) maintainer() *etcdbackup.Maintainer {
+	ssh := executables.NewLocalExecutablesBuilder().BuildSSHExecutable()
+	return etcdbackup.NewMaintainer(ssh, o.sshKey, o.sshUsername)
+}
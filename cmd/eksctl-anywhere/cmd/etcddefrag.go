@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+var etd = &etcdOptions{}
+
+var etcdDefragCmd = &cobra.Command{
+	Use:   "defrag",
+	Short: "Defragment the etcd members of a cluster",
+	Long: "This command defragments the etcd members of a cluster one at a time, the leader last, to reclaim the space of their databases. " +
+		"Once all the members are defragmented, the NOSPACE alarms are disarmed. All the members must be healthy",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := etd.etcdDefrag(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to defragment etcd: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	etcdCmd.AddCommand(etcdDefragCmd)
+	etd.addFlags(etcdDefragCmd)
+}
+
+func (o *etcdOptions) etcdDefrag(ctx context.Context) error {
+	cluster, ips, err := o.etcdMembers(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := o.maintainer().Defrag(ctx, cluster.Spec.ExternalEtcdConfiguration != nil, ips); err != nil {
+		return err
+	}
+
+	logger.MarkSuccess("Etcd defragmented!")
+	return nil
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+var erm = &etcdOptions{}
+
+var etcdRemoveMemberCmd = &cobra.Command{
+	Use:   "remove-member member-name|member-id",
+	Short: "Remove an unhealthy etcd member from a cluster",
+	Long: "This command removes an unhealthy etcd member from the etcd cluster, so a replacement machine can join it. " +
+		"Healthy members and members whose removal would lose the etcd quorum are not removed",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	Args:         cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := erm.etcdRemoveMember(cmd.Context(), args[0]); err != nil {
+			return fmt.Errorf("failed to remove etcd member: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	etcdCmd.AddCommand(etcdRemoveMemberCmd)
+	erm.addFlags(etcdRemoveMemberCmd)
+}
+
+func (o *etcdOptions) etcdRemoveMember(ctx context.Context, member string) error {
+	cluster, ips, err := o.etcdMembers(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := o.maintainer().RemoveMember(ctx, cluster.Spec.ExternalEtcdConfiguration != nil, ips, member); err != nil {
+		return err
+	}
+
+	logger.MarkSuccess("Etcd member removed!")
+	return nil
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/etcdbackup"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+type etcdStatusOptions struct {
+	etcdOptions
+	output string
+}
+
+var ets = &etcdStatusOptions{}
+
+var etcdStatusCmd = &cobra.Command{
+	Use:          "status",
+	Short:        "Show the health of the etcd members of a cluster",
+	Long:         "This command shows the health, database size and alarms of each etcd member of a cluster, running etcdctl on the etcd machines over SSH",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := ets.etcdStatus(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to get etcd status: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	etcdCmd.AddCommand(etcdStatusCmd)
+	ets.addFlags(etcdStatusCmd)
+	etcdStatusCmd.Flags().StringVarP(&ets.output, outputFlagName, "o", outputDefault, "Output format: text|json")
+}
+
+func (o *etcdStatusOptions) etcdStatus(ctx context.Context) error {
+	cluster, ips, err := o.etcdMembers(ctx)
+	if err != nil {
+		return err
+	}
+
+	statuses, err := o.maintainer().Status(ctx, cluster.Spec.ExternalEtcdConfiguration != nil, ips)
+	if err != nil {
+		return err
+	}
+
+	report, err := serializeEtcdStatus(statuses, o.output)
+	if err != nil {
+		return err
+	}
+
+	logger.V(0).Info(report)
+
+	return nil
+}
+
+func serializeEtcdStatus(statuses []etcdbackup.MemberStatus, outputFormat string) (string, error) {
+	switch outputFormat {
+	case outputText:
+		return serializeEtcdStatusToText(statuses)
+	case outputJson:
+		return serializeEtcdStatusToJson(statuses)
+	default:
+		return "", fmt.Errorf("invalid output format [%s]", outputFormat)
+	}
+}
+
+func serializeEtcdStatusToText(statuses []etcdbackup.MemberStatus) (string, error) {
+	buffer := bytes.Buffer{}
+	w := tabwriter.NewWriter(&buffer, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(w, "NAME\tIP\tID\tHEALTHY\tLEADER\tVERSION\tDB SIZE\tDB SIZE IN USE\tALARMS\tERROR")
+	for _, s := range statuses {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%t\t%t\t%s\t%s\t%s\t%s\t%s\n", s.Name, s.IP, s.ID, s.Healthy, s.Leader, s.Version,
+			formatEtcdDBSize(s.DBSize), formatEtcdDBSize(s.DBSizeInUse), strings.Join(s.Alarms, ","), s.Error)
+	}
+	if err := w.Flush(); err != nil {
+		return "", fmt.Errorf("failed flushing table writer: %v", err)
+	}
+
+	return buffer.String(), nil
+}
+
+func serializeEtcdStatusToJson(statuses []etcdbackup.MemberStatus) (string, error) {
+	b, err := json.Marshal(statuses)
+	if err != nil {
+		return "", fmt.Errorf("failed serializing the etcd status to json: %v", err)
+	}
+
+	return string(b), nil
+}
+
+func formatEtcdDBSize(size int64) string {
+	if size == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("%.1f MiB", float64(size)/(1<<20))
+}
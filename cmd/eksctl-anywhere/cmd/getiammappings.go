@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/awsiamauth"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+type iamMappingsOptions struct {
+	clusterName string
+	namespace   string
+	kubeConfig  string
+}
+
+type getIAMMappingsOptions struct {
+	iamMappingsOptions
+	output string
+}
+
+var gim = &getIAMMappingsOptions{}
+
+var getIAMMappingsCmd = &cobra.Command{
+	Use:          "iam-mappings",
+	Aliases:      []string{"iam-mapping"},
+	Short:        "Get the AWS IAM Authenticator role and user mappings of a cluster",
+	Long:         "Shows the IAM roles and users mapped to kubernetes users and groups in the AWSIamConfig of a cluster",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := gim.getIAMMappings(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to get iam mappings: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	getCmd.AddCommand(getIAMMappingsCmd)
+	gim.bindFlags(getIAMMappingsCmd)
+	getIAMMappingsCmd.Flags().StringVarP(&gim.output, outputFlagName, "o", outputDefault, "Output format: text|json")
+}
+
+func (o *iamMappingsOptions) bindFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&o.clusterName, "cluster-name", "", "Name of the cluster")
+	cmd.Flags().StringVarP(&o.namespace, "namespace", "n", "default", "Namespace of the cluster")
+	cmd.Flags().StringVar(&o.kubeConfig, "kubeconfig", "", "Management cluster kubeconfig file")
+	if err := cmd.MarkFlagRequired("cluster-name"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+// managementClient builds a client for the management cluster of the cluster the mappings belong to.
+func (o *iamMappingsOptions) managementClient(ctx context.Context) (kubernetes.Client, types.Closer, error) {
+	return managementKubeClient(ctx, o.clusterName, o.kubeConfig)
+}
+
+// managementKubeClient builds a client for the management cluster of a cluster, using the cluster
+// kubeconfig in the working directory when no kubeconfig is given.
+func managementKubeClient(ctx context.Context, clusterName, kubeConfig string) (kubernetes.Client, types.Closer, error) {
+	kubeconfigPath := getKubeconfigPath(clusterName, kubeConfig)
+	if err := kubeconfig.ValidateFilename(kubeconfigPath); err != nil {
+		return nil, nil, err
+	}
+
+	kubeconfigDir, err := filepath.Abs(filepath.Dir(kubeconfigPath))
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting kubeconfig directory: %v", err)
+	}
+
+	deps, err := dependencies.NewFactory().
+		WithExecutableMountDirs(kubeconfigDir).
+		WithExecutableBuilder().
+		WithUnAuthKubeClient().
+		Build(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	client, err := deps.UnAuthKubeClient.BuildClientFromKubeconfig(kubeconfigPath)
+	if err != nil {
+		close(ctx, deps)
+		return nil, nil, err
+	}
+
+	return client, deps, nil
+}
+
+func (o *getIAMMappingsOptions) getIAMMappings(ctx context.Context) error {
+	client, closer, err := o.managementClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, closer)
+
+	iamConfig, err := awsiamauth.GetClusterAWSIamConfig(ctx, client, o.clusterName, o.namespace)
+	if err != nil {
+		return err
+	}
+
+	report, err := serializeIAMMappings(awsiamauth.MappingsFromConfig(iamConfig), o.output)
+	if err != nil {
+		return err
+	}
+
+	logger.V(0).Info(report)
+
+	return nil
+}
+
+func serializeIAMMappings(mappings awsiamauth.Mappings, outputFormat string) (string, error) {
+	switch outputFormat {
+	case outputText:
+		return serializeIAMMappingsToText(mappings)
+	case outputJson:
+		return serializeIAMMappingsToJson(mappings)
+	default:
+		return "", fmt.Errorf("invalid output format [%s]", outputFormat)
+	}
+}
+
+func serializeIAMMappingsToText(mappings awsiamauth.Mappings) (string, error) {
+	if len(mappings.MapRoles) == 0 && len(mappings.MapUsers) == 0 {
+		return "No iam mappings configured", nil
+	}
+
+	buffer := bytes.Buffer{}
+	w := tabwriter.NewWriter(&buffer, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(w, "TYPE\tARN\tUSERNAME\tGROUPS")
+	for _, r := range mappings.MapRoles {
+		fmt.Fprintf(w, "role\t%s\t%s\t%s\n", r.RoleARN, r.Username, strings.Join(r.Groups, ","))
+	}
+	for _, u := range mappings.MapUsers {
+		fmt.Fprintf(w, "user\t%s\t%s\t%s\n", u.UserARN, u.Username, strings.Join(u.Groups, ","))
+	}
+	if err := w.Flush(); err != nil {
+		return "", fmt.Errorf("failed flushing table writer: %v", err)
+	}
+
+	return buffer.String(), nil
+}
+
+func serializeIAMMappingsToJson(mappings awsiamauth.Mappings) (string, error) {
+	b, err := json.Marshal(mappings)
+	if err != nil {
+		return "", fmt.Errorf("failed serializing the iam mappings to json: %v", err)
+	}
+
+	return string(b), nil
+}
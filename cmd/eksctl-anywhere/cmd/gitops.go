@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var gitopsCmd = &cobra.Command{
+	Use:   "gitops",
+	Short: "GitOps commands",
+	Long:  "Use eksctl anywhere gitops to inspect the GitOps configuration of a cluster",
+}
+
+func init() {
+	rootCmd.AddCommand(gitopsCmd)
+}
@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/gitops/drift"
+	"github.com/aws/eks-anywhere/pkg/gitops/flux"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+type gitOpsStatusOptions struct {
+	clusterOptions
+	output string
+}
+
+var gos = &gitOpsStatusOptions{}
+
+var gitOpsStatusCmd = &cobra.Command{
+	Use:          "status",
+	Short:        "Reports drift between the GitOps repository and the cluster",
+	Long:         "Compares the cluster configuration stored in the GitOps repository with the EKS-A objects in the management cluster and reports the fields that differ",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := gos.gitOpsStatus(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to get gitops status: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	gitopsCmd.AddCommand(gitOpsStatusCmd)
+	gitOpsStatusCmd.Flags().StringVarP(&gos.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration")
+	gitOpsStatusCmd.Flags().StringVar(&gos.bundlesOverride, "bundles-override", "", "Override default Bundles manifest (not recommended)")
+	gitOpsStatusCmd.Flags().StringVar(&gos.managementKubeconfig, "kubeconfig", "", "Management cluster kubeconfig file")
+	gitOpsStatusCmd.Flags().StringVarP(&gos.output, outputFlagName, "o", outputDefault, "Output format: text|json")
+	if err := gitOpsStatusCmd.MarkFlagRequired("filename"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (o *gitOpsStatusOptions) gitOpsStatus(ctx context.Context) error {
+	clusterSpec, err := newClusterSpec(o.clusterOptions)
+	if err != nil {
+		return err
+	}
+
+	if clusterSpec.Cluster.Spec.GitOpsRef == nil {
+		return fmt.Errorf("cluster %s doesn't have GitOps enabled", clusterSpec.Cluster.Name)
+	}
+
+	clusterConfigPath, branch := gitOpsRepositoryLayout(clusterSpec)
+
+	deps, err := dependencies.ForSpec(clusterSpec).
+		WithGitOps(clusterSpec, buildCliConfig(clusterSpec)).
+		WithUnAuthKubeClient().
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	if deps.Git == nil {
+		return errors.New("no git repository configured for GitOps")
+	}
+
+	logger.V(1).Info("Cloning GitOps repository", "branch", branch)
+	if err = deps.Git.Client.Clone(ctx); err != nil {
+		return fmt.Errorf("cloning git repository: %v", err)
+	}
+	if err = deps.Git.Client.Branch(branch); err != nil {
+		return fmt.Errorf("switching to git branch %s: %v", branch, err)
+	}
+
+	gitConfig, err := drift.ReadGitConfig(filepath.Join(deps.Git.Writer.Dir(), flux.ClusterConfigFilePath(clusterConfigPath, clusterSpec.Cluster.Name)))
+	if err != nil {
+		return err
+	}
+
+	managementCluster := &types.Cluster{
+		Name:           clusterSpec.Cluster.Name,
+		KubeconfigFile: getKubeconfigPath(clusterSpec.Cluster.Name, o.managementKubeconfig),
+	}
+	if clusterSpec.ManagementCluster != nil {
+		managementCluster = clusterSpec.ManagementCluster
+	}
+
+	client, err := deps.UnAuthKubeClient.BuildClientFromKubeconfig(managementCluster.KubeconfigFile)
+	if err != nil {
+		return err
+	}
+
+	liveCluster := &v1alpha1.Cluster{}
+	if err = client.Get(ctx, clusterSpec.Cluster.Name, clusterSpec.Cluster.Namespace, liveCluster); err != nil {
+		return fmt.Errorf("getting cluster %s: %v", clusterSpec.Cluster.Name, err)
+	}
+
+	liveConfig, err := cluster.NewDefaultConfigClientBuilder().Build(ctx, client, liveCluster)
+	if err != nil {
+		return err
+	}
+
+	fields, err := drift.Compare(gitConfig, liveConfig)
+	if err != nil {
+		return err
+	}
+
+	report, err := serializeDrift(clusterSpec.Cluster.Name, fields, o.output)
+	if err != nil {
+		return err
+	}
+
+	logger.V(0).Info(report)
+
+	return nil
+}
+
+// gitOpsRepositoryLayout returns the path in the repository where the cluster configurations are stored
+// and the branch they are committed to, for the GitOps engine configured for the cluster.
+func gitOpsRepositoryLayout(clusterSpec *cluster.Spec) (clusterConfigPath, branch string) {
+	if clusterSpec.ArgoCDConfig != nil {
+		return clusterSpec.ArgoCDConfig.Spec.ClusterConfigPath, clusterSpec.ArgoCDConfig.Spec.Branch
+	}
+	if clusterSpec.FluxConfig != nil {
+		return clusterSpec.FluxConfig.Spec.ClusterConfigPath, clusterSpec.FluxConfig.Spec.Branch
+	}
+	return "", ""
+}
+
+func serializeDrift(clusterName string, fields []drift.Field, outputFormat string) (string, error) {
+	switch outputFormat {
+	case outputText:
+		return serializeDriftToText(clusterName, fields)
+	case outputJson:
+		return serializeDriftToJson(fields)
+	default:
+		return "", fmt.Errorf("invalid output format [%s]", outputFormat)
+	}
+}
+
+func serializeDriftToText(clusterName string, fields []drift.Field) (string, error) {
+	if len(fields) == 0 {
+		return fmt.Sprintf("Cluster %s is in sync with the GitOps repository", clusterName), nil
+	}
+
+	buffer := bytes.Buffer{}
+	w := tabwriter.NewWriter(&buffer, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(w, "KIND\tNAME\tFIELD\tGIT\tCLUSTER")
+	for _, f := range fields {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", f.Kind, f.Name, f.Path, f.Git, f.Live)
+	}
+	if err := w.Flush(); err != nil {
+		return "", fmt.Errorf("failed flushing table writer: %v", err)
+	}
+
+	return buffer.String(), nil
+}
+
+func serializeDriftToJson(fields []drift.Field) (string, error) {
+	if fields == nil {
+		fields = []drift.Field{}
+	}
+
+	b, err := json.Marshal(fields)
+	if err != nil {
+		return "", fmt.Errorf("failed serializing the gitops drift to json: %v", err)
+	}
+
+	return string(b), nil
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/curatedpackages"
+	curatedoras "github.com/aws/eks-anywhere/pkg/curatedpackages/oras"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+// importPackagesCmd is the context for the import packages command.
+var importPackagesCmd = &cobra.Command{
+	Use:   "packages",
+	Short: "Import curated package images, charts and bundle to a registry from a local archive",
+	Long: `Import the EKS Anywhere curated package images, helm charts and package bundle from a tarball into a registry mirror.
+Use this command in conjunction with download packages, passing its output tarball as input to this command.
+When a cluster is given, the imported bundle is also made the active package bundle of the cluster.`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runImportPackages(cmd.Context())
+	},
+}
+
+func init() {
+	importCmd.AddCommand(importPackagesCmd)
+
+	importPackagesCmd.Flags().StringVarP(&ipkgc.inputFile, "input", "i", "", "Input tarball containing the package bundle, charts and images to import")
+	if err := importPackagesCmd.MarkFlagRequired("input"); err != nil {
+		logger.Fatal(err, "Cannot mark flag as required")
+	}
+	importPackagesCmd.Flags().StringVarP(&ipkgc.registry, "registry", "r", "", "Registry where to import the package bundle, charts and images")
+	if err := importPackagesCmd.MarkFlagRequired("registry"); err != nil {
+		logger.Fatal(err, "Cannot mark flag as required")
+	}
+	importPackagesCmd.Flags().BoolVar(&ipkgc.dstPlainHTTP, "dst-plain-http", false, "Whether or not to use plain http for destination registry")
+	importPackagesCmd.Flags().BoolVar(&ipkgc.dstInsecure, "dst-insecure", false, "Skip TLS verification against the destination registry")
+	importPackagesCmd.Flags().StringVar(&ipkgc.clusterName, "cluster", "", "Cluster to activate the imported package bundle on")
+	importPackagesCmd.Flags().StringVar(&ipkgc.kubeConfig, "kubeconfig", "", "Path to an optional kubeconfig file to use.")
+}
+
+var ipkgc = importPackagesConfig{}
+
+type importPackagesConfig struct {
+	copyPackagesConfig
+	inputFile   string
+	registry    string
+	clusterName string
+	kubeConfig  string
+}
+
+func runImportPackages(ctx context.Context) error {
+	bundle, err := importPackagesArchive(ctx, ipkgc.inputFile, ipkgc.registry, &ipkgc.copyPackagesConfig)
+	if err != nil {
+		return err
+	}
+
+	if ipkgc.clusterName == "" {
+		logger.Info("Package bundle imported", "bundle", bundle.Name)
+		return nil
+	}
+
+	return activatePackageBundle(ctx, bundle, ipkgc.kubeConfig, ipkgc.clusterName)
+}
+
+// importPackagesArchive extracts a packages archive created with download packages and pushes
+// its charts, images and bundle to a registry. It returns the package bundle in the archive.
+func importPackagesArchive(ctx context.Context, inputFile, registry string, c *copyPackagesConfig) (*packagesv1.PackageBundle, error) {
+	dir, err := os.MkdirTemp("", "eksa-packages-archive")
+	if err != nil {
+		return nil, fmt.Errorf("creating packages archive folder: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := packagerForFile(inputFile).UnPackage(inputFile, dir); err != nil {
+		return nil, fmt.Errorf("extracting packages archive: %v", err)
+	}
+
+	archive, err := curatedoras.NewArchive(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle, err := archive.ReadBundle()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := archive.Push(ctx, registryRepository(registry, c)); err != nil {
+		return nil, err
+	}
+
+	return bundle, nil
+}
+
+// activatePackageBundle makes an imported package bundle the active bundle of a cluster.
+func activatePackageBundle(ctx context.Context, bundle *packagesv1.PackageBundle, kubeConfigPath, clusterName string) error {
+	kubeConfig, err := kubeconfig.ResolveAndValidateFilename(kubeConfigPath, "")
+	if err != nil {
+		return err
+	}
+
+	deps, err := NewDependenciesForPackages(ctx, WithMountPaths(kubeConfig))
+	if err != nil {
+		return fmt.Errorf("unable to initialize executables: %v", err)
+	}
+
+	b := curatedpackages.NewBundleReader(kubeConfig, clusterName, deps.Kubectl, nil, nil)
+	if err := b.ActivateBundle(ctx, bundle); err != nil {
+		return err
+	}
+	logger.Info("Package bundle activated", "bundle", bundle.Name, "cluster", clusterName)
+
+	return nil
+}
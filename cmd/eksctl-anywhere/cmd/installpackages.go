@@ -7,7 +7,9 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/aws/eks-anywhere-packages/pkg/artifacts"
 	"github.com/aws/eks-anywhere/pkg/curatedpackages"
+	"github.com/aws/eks-anywhere/pkg/curatedpackages/oras"
 	"github.com/aws/eks-anywhere/pkg/kubeconfig"
 )
 
@@ -98,6 +100,7 @@ func installPackages(ctx context.Context, args []string) error {
 		deps.Kubectl,
 		curatedpackages.WithBundle(bundle),
 		curatedpackages.WithCustomConfigs(ipo.customConfigs),
+		curatedpackages.WithSchemaFetcher(oras.NewSchemaFetcher(artifacts.NewRegistryPuller(deps.Logger))),
 	)
 
 	p, err := packages.GetPackageFromBundle(args[0])
@@ -105,6 +108,10 @@ func installPackages(ctx context.Context, args []string) error {
 		return err
 	}
 
+	if err := packages.WaitForDependencies(ctx, p, kubeConfig, ipo.clusterName); err != nil {
+		return err
+	}
+
 	curatedpackages.PrintLicense()
 	err = packages.InstallPackage(ctx, p, ipo.packageName, ipo.clusterName, kubeConfig)
 	if err != nil {
@@ -110,8 +110,7 @@ func readAndValidateClusterSpec(clusterConfigPath string, cliVersion version.Inf
 	return clusterSpec, nil
 }
 
-func newClusterSpec(options clusterOptions) (*cluster.Spec, error) {
-	var opts []cluster.FileSpecBuilderOpt
+func newClusterSpec(options clusterOptions, opts ...cluster.FileSpecBuilderOpt) (*cluster.Spec, error) {
 	if options.bundlesOverride != "" {
 		opts = append(opts, cluster.WithOverrideBundlesManifest(options.bundlesOverride))
 	}
@@ -257,7 +256,7 @@ func newBasicClusterSpec(options clusterOptions) (*cluster.Spec, error) {
 
 func buildCliConfig(clusterSpec *cluster.Spec) *config.CliConfig {
 	cliConfig := &config.CliConfig{}
-	if clusterSpec.FluxConfig != nil && clusterSpec.FluxConfig.Spec.Git != nil {
+	if (clusterSpec.FluxConfig != nil && clusterSpec.FluxConfig.Spec.Git != nil) || clusterSpec.ArgoCDConfig != nil {
 		cliConfig.GitSshKeyPassphrase = os.Getenv(config.EksaGitPassphraseTokenEnv)
 		cliConfig.GitPrivateKeyFile = os.Getenv(config.EksaGitPrivateKeyTokenEnv)
 		cliConfig.GitKnownHostsFile = os.Getenv(config.EksaGitKnownHostsFileEnv)
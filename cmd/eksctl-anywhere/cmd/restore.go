@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Restore resources",
+	Long:  "Use eksctl anywhere restore to restore resources, such as etcd, from a backup",
+}
+
+func init() {
+	rootCmd.AddCommand(restoreCmd)
+}
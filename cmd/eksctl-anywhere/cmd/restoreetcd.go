@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/etcdbackup"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+type restoreEtcdOptions struct {
+	fileName     string
+	snapshot     string
+	snapshotFile string
+	sshKey       string
+	sshUsername  string
+	nodeIPs      []string
+	kubeConfig   string
+}
+
+var re = &restoreEtcdOptions{}
+
+var restoreEtcdCmd = &cobra.Command{
+	Use:   "etcd",
+	Short: "Restore the etcd of a cluster from a snapshot",
+	Long: "This command restores all the etcd members of a cluster from a snapshot taken by the etcd backups of the cluster, " +
+		"or from a local snapshot file. The cluster is paused and its etcd members are stopped during the restore",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := re.restoreEtcd(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to restore etcd: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	restoreCmd.AddCommand(restoreEtcdCmd)
+	restoreEtcdCmd.Flags().StringVarP(&re.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration")
+	restoreEtcdCmd.Flags().StringVar(&re.snapshot, "snapshot", "", "Name of the snapshot in the S3 etcd backups of the cluster, or latest for the newest one")
+	restoreEtcdCmd.Flags().StringVar(&re.snapshotFile, "snapshot-file", "", "Local etcd snapshot file")
+	restoreEtcdCmd.Flags().StringVar(&re.sshKey, "ssh-key", "", "Private key used to SSH into the etcd machines")
+	restoreEtcdCmd.Flags().StringVar(&re.sshUsername, "ssh-username", "ec2-user", "Username used to SSH into the etcd machines")
+	restoreEtcdCmd.Flags().StringSliceVar(&re.nodeIPs, "node-ips", nil, "IPs of the etcd machines. By default, they are read from the management cluster")
+	restoreEtcdCmd.Flags().StringVar(&re.kubeConfig, "kubeconfig", "", "Management cluster kubeconfig file")
+	restoreEtcdCmd.MarkFlagsMutuallyExclusive("snapshot", "snapshot-file")
+	for _, flag := range []string{"filename", "ssh-key"} {
+		if err := restoreEtcdCmd.MarkFlagRequired(flag); err != nil {
+			log.Fatalf("Error marking flag as required: %v", err)
+		}
+	}
+}
+
+func (o *restoreEtcdOptions) restoreEtcd(ctx context.Context) error {
+	if o.snapshot == "" && o.snapshotFile == "" {
+		return fmt.Errorf("one of --snapshot or --snapshot-file is required")
+	}
+
+	clusterConfig, err := v1alpha1.GetClusterConfig(o.fileName)
+	if err != nil {
+		return err
+	}
+
+	client, closer, err := managementKubeClient(ctx, clusterConfig.ManagedBy(), o.kubeConfig)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, closer)
+
+	cluster := &v1alpha1.Cluster{}
+	if err := client.Get(ctx, clusterConfig.Name, clusterConfig.Namespace, cluster); err != nil {
+		return fmt.Errorf("getting cluster %s: %v", clusterConfig.Name, err)
+	}
+
+	if err := etcdbackup.ValidateRestore(ctx, client, cluster); err != nil {
+		return err
+	}
+
+	ips := o.nodeIPs
+	if len(ips) == 0 {
+		if ips, err = etcdbackup.MemberIPs(ctx, client, cluster); err != nil {
+			return err
+		}
+	}
+
+	snapshot, err := o.readSnapshot(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("Pausing cluster reconciliation", "cluster", cluster.Name)
+	if err := etcdbackup.SetClusterPaused(ctx, client, cluster, true); err != nil {
+		return err
+	}
+
+	ssh := executables.NewLocalExecutablesBuilder().BuildSSHExecutable()
+	restorer := etcdbackup.NewRestorer(ssh, o.sshKey, o.sshUsername)
+	logger.Info("Restoring etcd", "cluster", cluster.Name, "members", ips)
+	if err := restorer.Restore(ctx, snapshot, cluster.Spec.ExternalEtcdConfiguration != nil, ips); err != nil {
+		// The cluster is left paused, so the control plane isn't remediated before etcd is fixed.
+		return err
+	}
+
+	logger.Info("Resuming cluster reconciliation", "cluster", cluster.Name)
+	if err := etcdbackup.SetClusterPaused(ctx, client, cluster, false); err != nil {
+		return err
+	}
+
+	logger.MarkSuccess("Etcd restored!")
+	return nil
+}
+
+func (o *restoreEtcdOptions) readSnapshot(ctx context.Context, cluster *v1alpha1.Cluster) ([]byte, error) {
+	if o.snapshotFile != "" {
+		snapshot, err := os.ReadFile(o.snapshotFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading etcd snapshot file: %v", err)
+		}
+		return snapshot, nil
+	}
+
+	if cluster.Spec.EtcdBackup == nil || cluster.Spec.EtcdBackup.S3 == nil {
+		return nil, fmt.Errorf("cluster %s doesn't have S3 etcd backups, use --snapshot-file instead", cluster.Name)
+	}
+
+	s3 := cluster.Spec.EtcdBackup.S3
+	store, err := etcdbackup.NewS3Store(etcdbackup.S3Config{
+		Endpoint:       s3.Endpoint,
+		Region:         s3.Region,
+		Bucket:         s3.Bucket,
+		Prefix:         s3.Prefix,
+		ForcePathStyle: s3.ForcePathStyle,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return etcdbackup.Load(ctx, store, cluster.Name, o.snapshot)
+}
@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var rollbackCmd = &cobra.Command{
+	Use:   "rollback",
+	Short: "Rollback resources",
+	Long:  "Use eksctl anywhere rollback to rollback resources, such as clusters, after a failed upgrade",
+}
+
+func init() {
+	rootCmd.AddCommand(rollbackCmd)
+}
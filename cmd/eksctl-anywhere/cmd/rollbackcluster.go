@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clustermanager"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+type rollbackClusterOptions struct {
+	snapshotDir string
+	kubeConfig  string
+}
+
+var rbc = &rollbackClusterOptions{}
+
+var rollbackClusterCmd = &cobra.Command{
+	Use:          "cluster [cluster-name]",
+	Short:        "Rollback a cluster after a failed upgrade",
+	Long:         "This command restores the cluster spec saved before an upgrade by eksctl anywhere upgrade cluster. The control plane, etcd and machine deployments are pointed back to the machine templates they used before the upgrade, so only the machines the upgrade replaced are rolled out again. Changes that can't be reverted, like etcd and EKS Anywhere version upgrades, are rejected",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	Args:         cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := rbc.rollbackCluster(cmd.Context(), args); err != nil {
+			return fmt.Errorf("failed to rollback cluster: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rollbackCmd.AddCommand(rollbackClusterCmd)
+	rollbackClusterCmd.Flags().StringVar(&rbc.snapshotDir, "snapshot-dir", "", "Folder with the cluster snapshot saved by the failed upgrade")
+	rollbackClusterCmd.Flags().StringVar(&rbc.kubeConfig, "kubeconfig", "", "Management cluster kubeconfig file")
+	if err := rollbackClusterCmd.MarkFlagRequired("snapshot-dir"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (o *rollbackClusterOptions) rollbackCluster(ctx context.Context, args []string) error {
+	snapshot, err := cluster.ReadSnapshot(o.snapshotDir)
+	if err != nil {
+		return err
+	}
+
+	snapshotCluster := snapshot.Config.Cluster
+	if len(args) == 1 && args[0] != snapshotCluster.Name {
+		return fmt.Errorf("snapshot in %s is for cluster %s, not %s", o.snapshotDir, snapshotCluster.Name, args[0])
+	}
+
+	managementClusterName := snapshotCluster.ManagedBy()
+	client, closer, err := managementKubeClient(ctx, managementClusterName, o.kubeConfig)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, closer)
+
+	currentCluster := &v1alpha1.Cluster{}
+	if err := client.Get(ctx, snapshotCluster.Name, snapshotCluster.Namespace, currentCluster); err != nil {
+		return fmt.Errorf("getting cluster %s: %v", snapshotCluster.Name, err)
+	}
+
+	currentSpec, err := cluster.BuildSpec(ctx, client, currentCluster)
+	if err != nil {
+		return err
+	}
+
+	if err := clustermanager.ValidateRollback(ctx, client, snapshot, currentSpec); err != nil {
+		return fmt.Errorf("cluster can't be rolled back: %v", err)
+	}
+
+	clusterSpec, err := cluster.BuildSpecFromConfig(ctx, client, snapshot.Config)
+	if err != nil {
+		return err
+	}
+
+	managementCluster := &types.Cluster{
+		Name:           managementClusterName,
+		KubeconfigFile: getKubeconfigPath(managementClusterName, o.kubeConfig),
+	}
+
+	kubeconfigDir, err := filepath.Abs(filepath.Dir(managementCluster.KubeconfigFile))
+	if err != nil {
+		return fmt.Errorf("getting kubeconfig directory: %v", err)
+	}
+
+	factory := dependencies.ForSpec(clusterSpec).WithExecutableMountDirs(kubeconfigDir).
+		WithClusterApplier()
+	if snapshotCluster.IsSelfManaged() {
+		factory.WithClusterManager(snapshotCluster, nil)
+	}
+
+	deps, err := factory.Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	logger.Info("Rolling back cluster to the snapshot taken before the upgrade", "cluster", snapshotCluster.Name, "snapshot", o.snapshotDir)
+	logger.Info("Only the machines the upgrade replaced will be rolled out again")
+	if err := clustermanager.RestoreSnapshot(ctx, client, snapshot); err != nil {
+		return fmt.Errorf("restoring cluster snapshot: %v", err)
+	}
+
+	if err := deps.ClusterApplier.Run(ctx, clusterSpec, *managementCluster); err != nil {
+		return err
+	}
+
+	if snapshotCluster.IsSelfManaged() {
+		// Upgrading a management cluster pauses its workload clusters, which stay paused if the upgrade fails.
+		logger.V(3).Info("Resuming workload clusters")
+		if err := deps.ClusterManager.ResumeCAPIWorkloadClusters(ctx, managementCluster); err != nil {
+			return err
+		}
+	}
+
+	logger.MarkSuccess("Cluster rolled back!")
+	return nil
+}
@@ -4,13 +4,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/aws/eks-anywhere/cmd/eksctl-anywhere/cmd/aflag"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/config"
 	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
 	"github.com/aws/eks-anywhere/pkg/kubeconfig"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
@@ -30,8 +35,21 @@ type upgradeClusterOptions struct {
 	tinkerbellBootstrapIP string
 	skipValidations       []string
 	providerOptions       *dependencies.ProviderOptions
+	// gitOpsPullRequest proposes the changes to the GitOps repository through a pull request.
+	gitOpsPullRequest        bool
+	gitOpsPullRequestTimeout time.Duration
+	// targetVersion is the Kubernetes version to upgrade the cluster to, one minor version at a time.
+	targetVersion string
+	// stepConfigs are the cluster config files, by Kubernetes version, with the machine configs
+	// for the intermediate steps of an upgrade to targetVersion.
+	stepConfigs map[string]string
 }
 
+const (
+	defaultGitOpsPullRequestTimeout      = 2 * time.Hour
+	defaultGitOpsPullRequestPollInterval = 30 * time.Second
+)
+
 var uc = &upgradeClusterOptions{
 	providerOptions: &dependencies.ProviderOptions{
 		Tinkerbell: &dependencies.TinkerbellOptions{
@@ -55,7 +73,12 @@ var upgradeClusterCmd = &cobra.Command{
 			return errors.New("please remove the --force-cleanup flag")
 		}
 
-		if err := uc.upgradeCluster(cmd, args); err != nil {
+		upgrade := uc.upgradeCluster
+		if uc.targetVersion != "" {
+			upgrade = uc.upgradeClusterToTargetVersion
+		}
+
+		if err := upgrade(cmd, args); err != nil {
 			return fmt.Errorf("failed to upgrade cluster: %v", err)
 		}
 		return nil
@@ -71,6 +94,10 @@ func init() {
 	upgradeClusterCmd.Flags().BoolVar(&uc.forceClean, "force-cleanup", false, "Force deletion of previously created bootstrap cluster")
 	hideForceCleanup(upgradeClusterCmd.Flags())
 	upgradeClusterCmd.Flags().StringArrayVar(&uc.skipValidations, "skip-validations", []string{}, fmt.Sprintf("Bypass upgrade validations by name. Valid arguments you can pass are --skip-validations=%s", strings.Join(upgradevalidations.SkippableValidations[:], ",")))
+	upgradeClusterCmd.Flags().BoolVar(&uc.gitOpsPullRequest, "gitops-pull-request", false, "Open a pull request with the cluster config changes instead of pushing them to the GitOps branch, and wait for it to be merged before upgrading the cluster. Only supported for management clusters")
+	upgradeClusterCmd.Flags().DurationVar(&uc.gitOpsPullRequestTimeout, "gitops-pull-request-timeout", defaultGitOpsPullRequestTimeout, "Maximum time to wait for the GitOps pull request to be merged")
+	upgradeClusterCmd.Flags().StringVar(&uc.targetVersion, targetVersionFlagName, "", "Kubernetes version to upgrade the cluster to, overriding the one in the cluster config. The cluster is upgraded one minor version at a time until it's reached")
+	upgradeClusterCmd.Flags().StringToStringVar(&uc.stepConfigs, stepConfigFlagName, nil, "Cluster config file with the machine configs for an intermediate step of an upgrade with --target-version, as <kubernetes version>=<file>. Required when the machine configs need OS images for the Kubernetes version of the step")
 	aflag.MarkRequired(createClusterCmd.Flags(), aflag.ClusterConfig.Name)
 	tinkerbellFlags(upgradeClusterCmd.Flags(), uc.providerOptions.Tinkerbell.BMCOptions.RPC)
 }
@@ -123,6 +150,10 @@ func (uc *upgradeClusterOptions) upgradeCluster(cmd *cobra.Command, args []strin
 	}
 
 	cliConfig := buildCliConfig(clusterSpec)
+	if cliConfig.GitOpsPullRequest, err = uc.gitOpsPullRequestConfig(clusterSpec); err != nil {
+		return err
+	}
+
 	dirs, err := uc.directoriesToMount(clusterSpec, cliConfig)
 	if err != nil {
 		return err
@@ -152,7 +183,7 @@ func (uc *upgradeClusterOptions) upgradeCluster(cmd *cobra.Command, args []strin
 		WithClusterManager(clusterSpec.Cluster, clusterManagerTimeoutOpts).
 		WithClusterApplier().
 		WithProvider(uc.fileName, clusterSpec.Cluster, cc.skipIpCheck, uc.hardwareCSVPath, uc.forceClean, uc.tinkerbellBootstrapIP, skippedValidations, uc.providerOptions).
-		WithGitOpsFlux(clusterSpec.Cluster, clusterSpec.FluxConfig, cliConfig).
+		WithGitOps(clusterSpec, cliConfig).
 		WithWriter().
 		WithCAPIManager().
 		WithEksdUpgrader().
@@ -211,7 +242,7 @@ func (uc *upgradeClusterOptions) upgradeCluster(cmd *cobra.Command, args []strin
 			deps.Provider,
 			deps.CAPIManager,
 			deps.ClusterManager,
-			deps.GitOpsFlux,
+			deps.GitOpsManager,
 			deps.Writer,
 			deps.EksdUpgrader,
 			deps.EksdInstaller,
@@ -227,7 +258,7 @@ func (uc *upgradeClusterOptions) upgradeCluster(cmd *cobra.Command, args []strin
 			deps.UnAuthKubeClient,
 			deps.Provider,
 			deps.ClusterManager,
-			deps.GitOpsFlux,
+			deps.GitOpsManager,
 			deps.Writer,
 			deps.ClusterApplier,
 			deps.EksdInstaller,
@@ -241,6 +272,153 @@ func (uc *upgradeClusterOptions) upgradeCluster(cmd *cobra.Command, args []strin
 	return err
 }
 
+// upgradeClusterToTargetVersion upgrades the cluster to the target Kubernetes version by running
+// one regular upgrade per minor version. The cluster config for each step is written to the cluster
+// folder before running it. If a step fails, running the same command again resumes the upgrade
+// from the last version the cluster was successfully upgraded to.
+func (uc *upgradeClusterOptions) upgradeClusterToTargetVersion(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if _, err := uc.commonValidations(ctx); err != nil {
+		return fmt.Errorf("common validations failed due to: %v", err)
+	}
+
+	clusterSpec, err := newClusterSpec(uc.clusterOptions, uc.specBuilderOpts()...)
+	if err != nil {
+		return err
+	}
+
+	steps, err := uc.planKubernetesUpgrade(ctx, clusterSpec)
+	if err != nil {
+		return fmt.Errorf("planning upgrade to kubernetes version %s: %v", uc.targetVersion, err)
+	}
+
+	config, err := os.ReadFile(uc.fileName)
+	if err != nil {
+		return fmt.Errorf("reading cluster config file: %v", err)
+	}
+
+	stepConfigs, err := uc.readStepConfigs(steps, clusterSpec)
+	if err != nil {
+		return err
+	}
+
+	writer, err := filewriter.NewWriter(clusterSpec.Cluster.Name)
+	if err != nil {
+		return err
+	}
+
+	originalFileName := uc.fileName
+	defer func() { uc.fileName = originalFileName }()
+
+	for i, step := range steps {
+		kubeVersion := step.Cluster.Spec.KubernetesVersion
+		logger.Info(fmt.Sprintf("Upgrading cluster to kubernetes version %s (step %d of %d)", kubeVersion, i+1, len(steps)))
+
+		baseConfig := config
+		if c, ok := stepConfigs[kubeVersion]; ok {
+			baseConfig = c
+		}
+
+		stepConfig, err := cluster.ReplaceClusterInConfig(baseConfig, step.Cluster)
+		if err != nil {
+			return err
+		}
+
+		uc.fileName, err = writer.Write(fmt.Sprintf("%s-eks-a-cluster-%s.yaml", clusterSpec.Cluster.Name, kubeVersion), stepConfig)
+		if err != nil {
+			return err
+		}
+
+		if err := uc.upgradeCluster(cmd, args); err != nil {
+			return fmt.Errorf("upgrading to kubernetes version %s (step %d of %d), run the same command again to resume from the last completed step: %v", kubeVersion, i+1, len(steps), err)
+		}
+
+		logger.MarkSuccess(fmt.Sprintf("Checkpoint %d of %d: cluster upgraded to kubernetes version %s", i+1, len(steps), kubeVersion))
+	}
+
+	return nil
+}
+
+// readStepConfigs reads the cluster configs passed for the intermediate steps of the upgrade. It fails
+// before upgrading anything if an intermediate step without its own cluster config needs OS images for
+// its Kubernetes versions that the machine configs in the cluster config don't have.
+func (uc *upgradeClusterOptions) readStepConfigs(steps []cluster.KubernetesUpgradeStep, clusterSpec *cluster.Spec) (map[v1alpha1.KubernetesVersion][]byte, error) {
+	intermediate := map[v1alpha1.KubernetesVersion]cluster.KubernetesUpgradeStep{}
+	for _, step := range steps[:len(steps)-1] {
+		intermediate[step.Cluster.Spec.KubernetesVersion] = step
+	}
+
+	configs := map[v1alpha1.KubernetesVersion][]byte{}
+	for kubeVersion, file := range uc.stepConfigs {
+		if _, ok := intermediate[v1alpha1.KubernetesVersion(kubeVersion)]; !ok {
+			// When resuming an upgrade, the steps already completed are not in the plan anymore.
+			logger.Info("Ignoring cluster config, kubernetes version is not an intermediate step of the upgrade", "kubernetesVersion", kubeVersion, "file", file)
+			continue
+		}
+
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("reading cluster config for kubernetes version %s: %v", kubeVersion, err)
+		}
+		configs[v1alpha1.KubernetesVersion(kubeVersion)] = content
+	}
+
+	var missing []string
+	for _, step := range steps[:len(steps)-1] {
+		kubeVersion := step.Cluster.Spec.KubernetesVersion
+		if _, ok := configs[kubeVersion]; ok {
+			continue
+		}
+		for _, m := range cluster.MissingMachineImages(clusterSpec.Config, step.Cluster, clusterSpec.Cluster) {
+			missing = append(missing, fmt.Sprintf("%s needs an OS image for kubernetes %s in the step to %s", m.MachineConfig, m.KubernetesVersion, kubeVersion))
+		}
+	}
+
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("the machine configs only have OS images for the target kubernetes versions: %s. "+
+			"Pass a cluster config with machine configs for each of those steps with --%s <kubernetes version>=<file>",
+			strings.Join(missing, "; "), stepConfigFlagName)
+	}
+
+	return configs, nil
+}
+
+// planKubernetesUpgrade computes the upgrades needed to take the current cluster to the Kubernetes version in clusterSpec.
+func (uc *upgradeClusterOptions) planKubernetesUpgrade(ctx context.Context, clusterSpec *cluster.Spec) ([]cluster.KubernetesUpgradeStep, error) {
+	deps, err := dependencies.ForSpec(clusterSpec).
+		WithClusterManager(clusterSpec.Cluster, nil).
+		Build(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer close(ctx, deps)
+
+	managementCluster := &types.Cluster{
+		Name:           clusterSpec.Cluster.Name,
+		KubeconfigFile: getKubeconfigPath(clusterSpec.Cluster.Name, uc.wConfig),
+	}
+
+	if clusterSpec.ManagementCluster != nil {
+		managementCluster = clusterSpec.ManagementCluster
+	}
+
+	currentSpec, err := deps.ClusterManager.GetCurrentClusterSpec(ctx, managementCluster, clusterSpec.Cluster.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return cluster.PlanKubernetesUpgrade(currentSpec.Cluster, clusterSpec.Cluster, clusterSpec.Bundles)
+}
+
+func (uc *upgradeClusterOptions) specBuilderOpts() []cluster.FileSpecBuilderOpt {
+	if uc.targetVersion == "" {
+		return nil
+	}
+
+	return []cluster.FileSpecBuilderOpt{cluster.WithKubernetesVersion(v1alpha1.KubernetesVersion(uc.targetVersion))}
+}
+
 func (uc *upgradeClusterOptions) commonValidations(ctx context.Context) (cluster *v1alpha1.Cluster, err error) {
 	clusterConfig, err := commonValidation(ctx, uc.fileName)
 	if err != nil {
@@ -259,3 +437,27 @@ func (uc *upgradeClusterOptions) commonValidations(ctx context.Context) (cluster
 
 	return clusterConfig, nil
 }
+
+func (uc *upgradeClusterOptions) gitOpsPullRequestConfig(clusterSpec *cluster.Spec) (*config.GitOpsPullRequestConfig, error) {
+	if !uc.gitOpsPullRequest {
+		return nil, nil
+	}
+
+	if !clusterSpec.Cluster.IsSelfManaged() {
+		return nil, errors.New("--gitops-pull-request is only supported for management clusters")
+	}
+
+	fluxConfig := clusterSpec.FluxConfig
+	if fluxConfig == nil || (fluxConfig.Spec.Github == nil && fluxConfig.Spec.Gitlab == nil && fluxConfig.Spec.BitbucketServer == nil) {
+		return nil, errors.New("--gitops-pull-request requires a FluxConfig with a github, gitlab or bitbucketServer provider")
+	}
+
+	if uc.gitOpsPullRequestTimeout <= 0 {
+		return nil, fmt.Errorf("--gitops-pull-request-timeout must be positive, got %s", uc.gitOpsPullRequestTimeout)
+	}
+
+	return &config.GitOpsPullRequestConfig{
+		Timeout:      uc.gitOpsPullRequestTimeout,
+		PollInterval: defaultGitOpsPullRequestPollInterval,
+	}, nil
+}
@@ -54,7 +54,7 @@ var upgradeManagementComponentsCmd = &cobra.Command{
 			WithClusterManager(clusterSpec.Cluster, nil).
 			WithClusterApplier().
 			WithProvider(umco.fileName, clusterSpec.Cluster, false, "", false, "", nil, nil).
-			WithGitOpsFlux(clusterSpec.Cluster, clusterSpec.FluxConfig, cliConfig).
+			WithGitOps(clusterSpec, cliConfig).
 			WithWriter().
 			WithCAPIManager().
 			WithEksdUpgrader().
@@ -73,7 +73,7 @@ var upgradeManagementComponentsCmd = &cobra.Command{
 			deps.Provider,
 			deps.CAPIManager,
 			deps.ClusterManager,
-			deps.GitOpsFlux,
+			deps.GitOpsManager,
 			deps.Writer,
 			deps.EksdUpgrader,
 			deps.EksdInstaller,
@@ -18,6 +18,10 @@ type upgradePackageOptions struct {
 	kubeConfig      string
 	clusterName     string
 	bundlesOverride string
+	// fromArchive is a packages archive to import and upgrade to instead of a bundle version.
+	fromArchive string
+	registry    string
+	copyPackagesConfig
 }
 
 var upo = &upgradePackageOptions{}
@@ -33,12 +37,18 @@ func init() {
 		"", "Cluster to upgrade.")
 	upgradePackagesCommand.Flags().StringVar(&upo.bundlesOverride, "bundles-override", "",
 		"Override default Bundles manifest (not recommended)")
+	upgradePackagesCommand.Flags().StringVar(&upo.fromArchive, "from-archive", "",
+		"Packages archive created with download packages to import and upgrade to.")
+	upgradePackagesCommand.Flags().StringVar(&upo.registry, "registry", "",
+		"Registry where to import the packages archive.")
+	upgradePackagesCommand.Flags().BoolVar(&upo.dstPlainHTTP, "dst-plain-http", false,
+		"Whether or not to use plain http for the registry where to import the packages archive.")
+	upgradePackagesCommand.Flags().BoolVar(&upo.dstInsecure, "dst-insecure", false,
+		"Skip TLS verification against the registry where to import the packages archive.")
 
-	err := upgradePackagesCommand.MarkFlagRequired("bundle-version")
-	if err != nil {
-		log.Fatalf("Error marking flag as required: %v", err)
-	}
-	err = upgradePackagesCommand.MarkFlagRequired("cluster")
+	upgradePackagesCommand.MarkFlagsMutuallyExclusive("bundle-version", "from-archive")
+	upgradePackagesCommand.MarkFlagsRequiredTogether("from-archive", "registry")
+	err := upgradePackagesCommand.MarkFlagRequired("cluster")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %v", err)
 	}
@@ -59,6 +69,17 @@ var upgradePackagesCommand = &cobra.Command{
 }
 
 func upgradePackages(ctx context.Context) error {
+	if upo.fromArchive != "" {
+		bundle, err := importPackagesArchive(ctx, upo.fromArchive, upo.registry, &upo.copyPackagesConfig)
+		if err != nil {
+			return err
+		}
+		return activatePackageBundle(ctx, bundle, upo.kubeConfig, upo.clusterName)
+	}
+	if upo.bundleVersion == "" {
+		return fmt.Errorf("either bundle-version or from-archive must be specified")
+	}
+
 	kubeConfig, err := kubeconfig.ResolveAndValidateFilename(upo.kubeConfig, "")
 	if err != nil {
 		return err
@@ -6,10 +6,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"sort"
+	"strings"
 	"text/tabwriter"
 
 	"github.com/spf13/cobra"
 
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/dependencies"
 	"github.com/aws/eks-anywhere/pkg/eksd"
 	"github.com/aws/eks-anywhere/pkg/logger"
@@ -18,10 +22,12 @@ import (
 )
 
 const (
-	outputFlagName = "output"
-	outputDefault  = outputText
-	outputText     = "text"
-	outputJson     = "json"
+	targetVersionFlagName = "target-version"
+	stepConfigFlagName    = "step-config"
+	outputFlagName        = "output"
+	outputDefault         = outputText
+	outputText            = "text"
+	outputJson            = "json"
 )
 
 var output string
@@ -46,6 +52,7 @@ func init() {
 	upgradePlanClusterCmd.Flags().StringVar(&uc.bundlesOverride, "bundles-override", "", "Override default Bundles manifest (not recommended)")
 	upgradePlanClusterCmd.Flags().StringVarP(&output, outputFlagName, "o", outputDefault, "Output format: text|json")
 	upgradePlanClusterCmd.Flags().StringVar(&uc.managementKubeconfig, "kubeconfig", "", "Management cluster kubeconfig file")
+	upgradePlanClusterCmd.Flags().StringVar(&uc.targetVersion, targetVersionFlagName, "", "Kubernetes version to upgrade the cluster to, overriding the one in the cluster config. Lists the intermediate minor version upgrades needed to reach it")
 	err := upgradePlanClusterCmd.MarkFlagRequired("filename")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %v", err)
@@ -57,7 +64,7 @@ func (uc *upgradeClusterOptions) upgradePlanCluster(ctx context.Context) error {
 		return fmt.Errorf("common validations failed due to: %v", err)
 	}
 
-	newClusterSpec, err := newClusterSpec(uc.clusterOptions)
+	newClusterSpec, err := newClusterSpec(uc.clusterOptions, uc.specBuilderOpts()...)
 	if err != nil {
 		return err
 	}
@@ -99,16 +106,154 @@ func (uc *upgradeClusterOptions) upgradePlanCluster(ctx context.Context) error {
 	componentChangeDiffs.Append(cilium.ChangeDiff(currentSpec, newClusterSpec))
 	componentChangeDiffs.Append(eksd.ChangeDiff(currentSpec, newClusterSpec))
 
-	serializedDiff, err := serialize(componentChangeDiffs, output)
+	if uc.targetVersion == "" {
+		serializedDiff, err := serialize(componentChangeDiffs, output)
+		if err != nil {
+			return err
+		}
+
+		logger.V(0).Info(serializedDiff)
+		return nil
+	}
+
+	steps, err := cluster.PlanKubernetesUpgrade(currentSpec.Cluster, newClusterSpec.Cluster, newClusterSpec.Bundles)
+	if err != nil {
+		return fmt.Errorf("planning upgrade to kubernetes version %s: %v", uc.targetVersion, err)
+	}
+
+	serializedPlan, err := serializeWithUpgradeSteps(componentChangeDiffs, newUpgradeStepReports(steps, newClusterSpec.Config, newClusterSpec.Cluster), output)
 	if err != nil {
 		return err
 	}
 
-	logger.V(0).Info(serializedDiff)
+	logger.V(0).Info(serializedPlan)
 
 	return nil
 }
 
+// upgradeStepReport describes one of the upgrades needed to reach the target Kubernetes version.
+type upgradeStepReport struct {
+	Step              int                                   `json:"step"`
+	KubernetesVersion v1alpha1.KubernetesVersion            `json:"kubernetesVersion"`
+	EksDRelease       string                                `json:"eksdRelease"`
+	WorkerNodeGroups  map[string]v1alpha1.KubernetesVersion `json:"workerNodeGroups,omitempty"`
+	MachineImages     []machineImageReport                  `json:"machineImages,omitempty"`
+}
+
+// machineImageReport is the OS image a machine config needs in an upgrade step. Missing is set for the
+// intermediate steps when the machine configs in the cluster config only have images for the target versions.
+type machineImageReport struct {
+	MachineConfig     string                     `json:"machineConfig"`
+	KubernetesVersion v1alpha1.KubernetesVersion `json:"kubernetesVersion"`
+	Image             string                     `json:"image,omitempty"`
+	Missing           bool                       `json:"missing,omitempty"`
+}
+
+func newUpgradeStepReports(steps []cluster.KubernetesUpgradeStep, config *cluster.Config, desired *v1alpha1.Cluster) []upgradeStepReport {
+	reports := make([]upgradeStepReport, 0, len(steps))
+	for i, step := range steps {
+		report := upgradeStepReport{
+			Step:              i + 1,
+			KubernetesVersion: step.Cluster.Spec.KubernetesVersion,
+			EksDRelease:       step.EksD.Name,
+			WorkerNodeGroups:  map[string]v1alpha1.KubernetesVersion{},
+		}
+		for _, w := range step.Cluster.Spec.WorkerNodeGroupConfigurations {
+			report.WorkerNodeGroups[w.Name] = step.Cluster.Spec.KubernetesVersion
+			if w.KubernetesVersion != nil {
+				report.WorkerNodeGroups[w.Name] = *w.KubernetesVersion
+			}
+		}
+
+		missing := map[cluster.MachineImage]bool{}
+		if i < len(steps)-1 {
+			for _, m := range cluster.MissingMachineImages(config, step.Cluster, desired) {
+				missing[m] = true
+			}
+		}
+		for _, m := range cluster.MachineImages(config, step.Cluster) {
+			image := machineImageReport{
+				MachineConfig:     m.MachineConfig,
+				KubernetesVersion: m.KubernetesVersion,
+				Image:             m.Image,
+			}
+			if missing[m] {
+				image.Image = ""
+				image.Missing = true
+			}
+			report.MachineImages = append(report.MachineImages, image)
+		}
+		reports = append(reports, report)
+	}
+
+	return reports
+}
+
+func serializeWithUpgradeSteps(componentChangeDiffs *types.ChangeDiff, steps []upgradeStepReport, outputFormat string) (string, error) {
+	switch outputFormat {
+	case outputText:
+		serializedDiff, err := serializeToText(componentChangeDiffs)
+		if err != nil {
+			return "", err
+		}
+		serializedSteps, err := serializeUpgradeStepsToText(steps)
+		if err != nil {
+			return "", err
+		}
+		return serializedDiff + "\n" + serializedSteps, nil
+	case outputJson:
+		if componentChangeDiffs == nil {
+			componentChangeDiffs = &types.ChangeDiff{ComponentReports: []types.ComponentChangeDiff{}}
+		}
+		plan := struct {
+			*types.ChangeDiff
+			UpgradeSteps []upgradeStepReport `json:"upgradeSteps"`
+		}{
+			ChangeDiff:   componentChangeDiffs,
+			UpgradeSteps: steps,
+		}
+		jsonPlan, err := json.Marshal(plan)
+		if err != nil {
+			return "", fmt.Errorf("failed serializing the upgrade plan to json: %v", err)
+		}
+		return string(jsonPlan), nil
+	default:
+		return "", fmt.Errorf("invalid output format [%s]", outputFormat)
+	}
+}
+
+func serializeUpgradeStepsToText(steps []upgradeStepReport) (string, error) {
+	buffer := bytes.Buffer{}
+	w := tabwriter.NewWriter(&buffer, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(w, "STEP\tKUBERNETES VERSION\tEKS-D RELEASE\tWORKER NODE GROUPS\tMISSING MACHINE IMAGES")
+	anyMissing := false
+	for _, step := range steps {
+		workers := make([]string, 0, len(step.WorkerNodeGroups))
+		for name, version := range step.WorkerNodeGroups {
+			workers = append(workers, fmt.Sprintf("%s=%s", name, version))
+		}
+		sort.Strings(workers)
+		var missing []string
+		for _, m := range step.MachineImages {
+			if m.Missing {
+				missing = append(missing, fmt.Sprintf("%s=%s", m.MachineConfig, m.KubernetesVersion))
+			}
+		}
+		anyMissing = anyMissing || len(missing) > 0
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", step.Step, step.KubernetesVersion, step.EksDRelease, strings.Join(workers, ","), strings.Join(missing, ","))
+	}
+	if err := w.Flush(); err != nil {
+		return "", fmt.Errorf("failed flushing table writer: %v", err)
+	}
+
+	if anyMissing {
+		fmt.Fprintf(&buffer, "\nThe machine configs only have OS images for the target kubernetes versions. Pass a cluster config with machine configs "+
+			"for each step with missing machine images to upgrade cluster with --%s <kubernetes version>=<file>\n", stepConfigFlagName)
+	}
+
+	return buffer.String(), nil
+}
+
 func serialize(componentChangeDiffs *types.ChangeDiff, outputFormat string) (string, error) {
 	switch outputFormat {
 	case outputText:
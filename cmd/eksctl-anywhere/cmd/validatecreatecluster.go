@@ -85,7 +85,7 @@ func (valOpt *validateOptions) validateCreateCluster(cmd *cobra.Command, _ []str
 		WithDocker().
 		WithKubectl().
 		WithProvider(valOpt.fileName, clusterSpec.Cluster, false, valOpt.hardwareCSVPath, true, valOpt.tinkerbellBootstrapIP, map[string]bool{}, valOpt.providerOptions).
-		WithGitOpsFlux(clusterSpec.Cluster, clusterSpec.FluxConfig, cliConfig).
+		WithGitOps(clusterSpec, cliConfig).
 		WithUnAuthKubeClient().
 		WithValidatorClients().
 		WithManifestReader().
@@ -110,7 +110,7 @@ func (valOpt *validateOptions) validateCreateCluster(cmd *cobra.Command, _ []str
 	}
 	createValidations := createvalidations.New(validationOpts)
 
-	commandVal := createcluster.NewValidations(clusterSpec, deps.Provider, deps.GitOpsFlux, createValidations, deps.DockerClient)
+	commandVal := createcluster.NewValidations(clusterSpec, deps.Provider, deps.GitOpsManager, createValidations, deps.DockerClient)
 	err = commandVal.Validate(ctx)
 
 	cleanupDirectory(tmpPath)
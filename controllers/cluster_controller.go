@@ -7,6 +7,7 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
@@ -48,6 +49,12 @@ type ClusterReconciler struct {
 	clusterValidator           ClusterValidator
 	packagesClient             PackagesClient
 	machineHealthCheck         MachineHealthCheckReconciler
+	registryMirrorCredentials  RegistryMirrorCredentialsReconciler
+	authenticationConfig       AuthenticationConfigReconciler
+	awsIamMappings             AWSIamMappingsReconciler
+	nodeConfig                 NodeConfigReconciler
+	upgradeCanary              UpgradeCanaryReconciler
+	etcdBackup                 EtcdBackupReconciler
 }
 
 // PackagesClient handles curated packages operations from within the cluster
@@ -74,6 +81,38 @@ type MachineHealthCheckReconciler interface {
 	Reconcile(ctx context.Context, logger logr.Logger, cluster *anywherev1.Cluster) error
 }
 
+// RegistryMirrorCredentialsReconciler rotates the registry mirror credentials on the nodes of an eks-a cluster.
+type RegistryMirrorCredentialsReconciler interface {
+	Reconcile(ctx context.Context, logger logr.Logger, cluster *anywherev1.Cluster) (controller.Result, error)
+}
+
+// AuthenticationConfigReconciler updates the structured authentication config on the control plane nodes of an eks-a cluster.
+type AuthenticationConfigReconciler interface {
+	Reconcile(ctx context.Context, logger logr.Logger, cluster *anywherev1.Cluster) (controller.Result, error)
+}
+
+// NodeConfigReconciler applies the worker node configuration changes that don't require
+// replacing the machines to the running nodes of an eks-a cluster.
+type NodeConfigReconciler interface {
+	Reconcile(ctx context.Context, logger logr.Logger, cluster *anywherev1.Cluster) (controller.Result, error)
+}
+
+// UpgradeCanaryReconciler runs the health check of the canary node of a worker nodes upgrade
+// of an eks-a cluster with an upgrade policy.
+type UpgradeCanaryReconciler interface {
+	Reconcile(ctx context.Context, logger logr.Logger, cluster *anywherev1.Cluster) (controller.Result, error)
+}
+
+// EtcdBackupReconciler manages the scheduled etcd snapshots of an eks-a cluster.
+type EtcdBackupReconciler interface {
+	Reconcile(ctx context.Context, logger logr.Logger, cluster *anywherev1.Cluster) (controller.Result, error)
+}
+
+// AWSIamMappingsReconciler updates the aws-iam-authenticator role and user mappings of an eks-a cluster.
+type AWSIamMappingsReconciler interface {
+	ReconcileMappings(ctx context.Context, logger logr.Logger, cluster *anywherev1.Cluster) (controller.Result, error)
+}
+
 // ClusterValidator runs cluster level preflight validations before it goes to provider reconciler.
 type ClusterValidator interface {
 	ValidateManagementClusterName(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) error
@@ -82,6 +121,54 @@ type ClusterValidator interface {
 // ClusterReconcilerOption allows to configure the ClusterReconciler.
 type ClusterReconcilerOption func(*ClusterReconciler)
 
+// WithRegistryMirrorCredentialsReconciler configures the reconciler used to rotate
+// the registry mirror credentials on the cluster nodes without rolling them out.
+func WithRegistryMirrorCredentialsReconciler(registryMirrorCredentials RegistryMirrorCredentialsReconciler) ClusterReconcilerOption {
+	return func(c *ClusterReconciler) {
+		c.registryMirrorCredentials = registryMirrorCredentials
+	}
+}
+
+// WithAuthenticationConfigReconciler configures the reconciler used to update the
+// structured authentication config on the control plane nodes without rolling them out.
+func WithAuthenticationConfigReconciler(authenticationConfig AuthenticationConfigReconciler) ClusterReconcilerOption {
+	return func(c *ClusterReconciler) {
+		c.authenticationConfig = authenticationConfig
+	}
+}
+
+// WithNodeConfigReconciler configures the reconciler used to update the labels, taints,
+// kubelet configuration, NTP servers and cert bundles of the worker nodes without rolling them out.
+func WithNodeConfigReconciler(nodeConfig NodeConfigReconciler) ClusterReconcilerOption {
+	return func(c *ClusterReconciler) {
+		c.nodeConfig = nodeConfig
+	}
+}
+
+// WithUpgradeCanaryReconciler configures the reconciler used to health check the canary
+// node before upgrading the rest of the worker nodes.
+func WithUpgradeCanaryReconciler(upgradeCanary UpgradeCanaryReconciler) ClusterReconcilerOption {
+	return func(c *ClusterReconciler) {
+		c.upgradeCanary = upgradeCanary
+	}
+}
+
+// WithEtcdBackupReconciler configures the reconciler used to manage the CronJob that
+// takes the scheduled etcd snapshots of the cluster.
+func WithEtcdBackupReconciler(etcdBackup EtcdBackupReconciler) ClusterReconcilerOption {
+	return func(c *ClusterReconciler) {
+		c.etcdBackup = etcdBackup
+	}
+}
+
+// WithAWSIamMappingsReconciler configures the reconciler used to update the
+// aws-iam-authenticator mappings without a full cluster reconciliation.
+func WithAWSIamMappingsReconciler(awsIamMappings AWSIamMappingsReconciler) ClusterReconcilerOption {
+	return func(c *ClusterReconciler) {
+		c.awsIamMappings = awsIamMappings
+	}
+}
+
 // NewClusterReconciler constructs a new ClusterReconciler.
 func NewClusterReconciler(client client.Client, registry ProviderClusterReconcilerRegistry, awsIamAuth AWSIamConfigReconciler, clusterValidator ClusterValidator, pkgs PackagesClient, machineHealthCheck MachineHealthCheckReconciler, opts ...ClusterReconcilerOption) *ClusterReconciler {
 	c := &ClusterReconciler{
@@ -122,6 +209,10 @@ func (r *ClusterReconciler) SetupWithManager(mgr ctrl.Manager, log logr.Logger)
 			&anywherev1.FluxConfig{},
 			handler.EnqueueRequestsFromMapFunc(childObjectHandler),
 		).
+		Watches(
+			&anywherev1.ArgoCDConfig{},
+			handler.EnqueueRequestsFromMapFunc(childObjectHandler),
+		).
 		Watches(
 			&anywherev1.VSphereDatacenterConfig{},
 			handler.EnqueueRequestsFromMapFunc(childObjectHandler),
@@ -166,6 +257,10 @@ func (r *ClusterReconciler) SetupWithManager(mgr ctrl.Manager, log logr.Logger)
 			&anywherev1.NutanixMachineConfig{},
 			handler.EnqueueRequestsFromMapFunc(childObjectHandler),
 		).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(handlers.RegistryCredentialsToClusters(log, r.client)),
+		).
 		Complete(r)
 }
 
@@ -173,9 +268,10 @@ func (r *ClusterReconciler) SetupWithManager(mgr ctrl.Manager, log logr.Logger)
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;delete;update;patch
 // +kubebuilder:rbac:groups="",namespace=eksa-system,resources=secrets,verbs=patch;update
 // +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;create;delete
-// +kubebuilder:rbac:groups="",resources=nodes,verbs=list
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=list;patch
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;create;delete
 // +kubebuilder:rbac:groups=addons.cluster.x-k8s.io,resources=clusterresourcesets,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=clusters;gitopsconfigs;snowmachineconfigs;snowdatacenterconfigs;snowippools;vspheredatacenterconfigs;vspheremachineconfigs;dockerdatacenterconfigs;tinkerbellmachineconfigs;tinkerbelltemplateconfigs;tinkerbelldatacenterconfigs;cloudstackdatacenterconfigs;cloudstackmachineconfigs;nutanixdatacenterconfigs;nutanixmachineconfigs;awsiamconfigs;oidcconfigs;awsiamconfigs;fluxconfigs,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=clusters;gitopsconfigs;snowmachineconfigs;snowdatacenterconfigs;snowippools;vspheredatacenterconfigs;vspheremachineconfigs;dockerdatacenterconfigs;tinkerbellmachineconfigs;tinkerbelltemplateconfigs;tinkerbelldatacenterconfigs;cloudstackdatacenterconfigs;cloudstackmachineconfigs;nutanixdatacenterconfigs;nutanixmachineconfigs;awsiamconfigs;oidcconfigs;awsiamconfigs;fluxconfigs;argocdconfigs,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=clusters/status;snowmachineconfigs/status;snowippools/status;vspheredatacenterconfigs/status;vspheremachineconfigs/status;dockerdatacenterconfigs/status;tinkerbelldatacenterconfigs/status;tinkerbellmachineconfigs/status;tinkerbelltemplateconfigs/status;cloudstackdatacenterconfigs/status;cloudstackmachineconfigs/status;awsiamconfigs/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=bundles,verbs=get;list;watch
 // +kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=clusters/finalizers;snowmachineconfigs/finalizers;snowippools/finalizers;vspheredatacenterconfigs/finalizers;vspheremachineconfigs/finalizers;cloudstackdatacenterconfigs/finalizers;cloudstackmachineconfigs/finalizers;dockerdatacenterconfigs/finalizers;bundles/finalizers;awsiamconfigs/finalizers;tinkerbelldatacenterconfigs/finalizers;tinkerbellmachineconfigs/finalizers;tinkerbelltemplateconfigs/finalizers,verbs=update
@@ -277,6 +373,30 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (re
 		return ctrl.Result{}, err
 	}
 
+	clusters.UpdateClusterStatusForGitOps(cluster)
+
+	credentialsResult, err := r.reconcileRegistryMirrorCredentials(ctx, log, cluster)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	authenticationResult, err := r.reconcileAuthenticationConfig(ctx, log, cluster)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	mappingsResult, err := r.reconcileAWSIamMappings(ctx, log, cluster)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	canaryResult, err := r.reconcileUpgradeCanary(ctx, log, cluster)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	inPlaceResult := soonestResult(credentialsResult, authenticationResult, mappingsResult, canaryResult)
+
 	aggregatedGeneration := aggregatedGeneration(config)
 
 	// If there is no difference between the aggregated generation and childrenReconciledGeneration,
@@ -297,10 +417,123 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (re
 			cluster.ClearFailure()
 		}
 
-		return ctrl.Result{}, nil
+		nodeConfigResult, err := r.reconcileNodeConfig(ctx, log, cluster)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		etcdBackupResult, err := r.reconcileEtcdBackup(ctx, log, cluster)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		packagesResult, err := r.refreshPackagesReady(ctx, log, cluster)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		return soonestResult(inPlaceResult, nodeConfigResult, etcdBackupResult, packagesResult).ToCtrlResult(), nil
+	}
+
+	result, err = r.reconcile(ctx, log, cluster, aggregatedGeneration)
+	if err != nil || !result.IsZero() {
+		return result, err
+	}
+
+	nodeConfigResult, err := r.reconcileNodeConfig(ctx, log, cluster)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	etcdBackupResult, err := r.reconcileEtcdBackup(ctx, log, cluster)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return soonestResult(inPlaceResult, nodeConfigResult, etcdBackupResult).ToCtrlResult(), nil
+}
+
+// reconcileRegistryMirrorCredentials runs independently of the cluster generation since
+// changing the registry credentials secret doesn't modify the cluster spec.
+func (r *ClusterReconciler) reconcileRegistryMirrorCredentials(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) (controller.Result, error) {
+	if r.registryMirrorCredentials == nil {
+		return controller.Result{}, nil
+	}
+
+	return r.registryMirrorCredentials.Reconcile(ctx, log, cluster)
+}
+
+// reconcileAuthenticationConfig runs independently of the cluster generation since it needs
+// to keep updating the control plane nodes after the OIDCConfigs have been reconciled.
+// It also creates the secret the control plane machines are bootstrapped with, so it needs to
+// run before the provider reconciler.
+func (r *ClusterReconciler) reconcileAuthenticationConfig(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) (controller.Result, error) {
+	if r.authenticationConfig == nil {
+		return controller.Result{}, nil
+	}
+
+	return r.authenticationConfig.Reconcile(ctx, log, cluster)
+}
+
+// reconcileNodeConfig runs after the provider reconciler, once it has updated the worker
+// KubeadmConfigTemplates in place, and independently of the cluster generation since it needs
+// to keep updating the nodes until all of them have the new config.
+func (r *ClusterReconciler) reconcileNodeConfig(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) (controller.Result, error) {
+	if r.nodeConfig == nil {
+		return controller.Result{}, nil
+	}
+
+	return r.nodeConfig.Reconcile(ctx, log, cluster)
+}
+
+// reconcileUpgradeCanary runs independently of the cluster generation since the health check
+// needs to keep running after the provider reconciler has paused the worker nodes upgrade.
+func (r *ClusterReconciler) reconcileUpgradeCanary(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) (controller.Result, error) {
+	if r.upgradeCanary == nil {
+		return controller.Result{}, nil
+	}
+
+	return r.upgradeCanary.Reconcile(ctx, log, cluster)
+}
+
+// reconcileEtcdBackup runs after the provider reconciler, once the control plane exists, and
+// independently of the cluster generation since it needs to wait for the control plane to be available.
+func (r *ClusterReconciler) reconcileEtcdBackup(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) (controller.Result, error) {
+	if r.etcdBackup == nil {
+		return controller.Result{}, nil
+	}
+
+	return r.etcdBackup.Reconcile(ctx, log, cluster)
+}
+
+// reconcileAWSIamMappings runs independently of the cluster generation so role and user mappings
+// can be updated without going through a cluster upgrade.
+func (r *ClusterReconciler) reconcileAWSIamMappings(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) (controller.Result, error) {
+	if r.awsIamMappings == nil || !cluster.HasAWSIamConfig() {
+		return controller.Result{}, nil
+	}
+
+	return r.awsIamMappings.ReconcileMappings(ctx, log, cluster)
+}
+
+// soonestResult returns the result that requeues the request the soonest, or the first
+// one interrupting the reconciliation if none of them requeues after a delay.
+func soonestResult(results ...controller.Result) controller.Result {
+	soonest := controller.Result{}
+	for _, r := range results {
+		if !r.Return() {
+			continue
+		}
+		if !soonest.Return() {
+			soonest = r
+			continue
+		}
+		if after := r.Result.RequeueAfter; after > 0 && (soonest.Result.RequeueAfter == 0 || after < soonest.Result.RequeueAfter) {
+			soonest = r
+		}
 	}
 
-	return r.reconcile(ctx, log, cluster, aggregatedGeneration)
+	return soonest
 }
 
 func (r *ClusterReconciler) reconcile(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster, aggregatedGeneration int64) (ctrl.Result, error) {
@@ -318,6 +551,11 @@ func (r *ClusterReconciler) reconcile(ctx context.Context, log logr.Logger, clus
 		return reconcileResult.ToCtrlResult(), nil
 	}
 
+	deferredUntil, err := r.reconcileMaintenanceWindow(ctx, log, cluster, clusterProviderReconciler)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
 	reconcileResult, err = clusterProviderReconciler.Reconcile(ctx, log, cluster)
 	if err != nil {
 		return ctrl.Result{}, err
@@ -336,6 +574,13 @@ func (r *ClusterReconciler) reconcile(ctx context.Context, log logr.Logger, clus
 		return reconcileResult.ToCtrlResult(), nil
 	}
 
+	// Disruptive changes deferred by the maintenance window are not reconciled yet, so we
+	// requeue until the window opens instead of marking the generation as reconciled.
+	if !deferredUntil.IsZero() {
+		log.Info("Disruptive changes are deferred until the maintenance window opens", "nextOpen", deferredUntil)
+		return ctrl.Result{RequeueAfter: time.Until(deferredUntil)}, nil
+	}
+
 	// At the end of the reconciliation, if there have been no requeues or errors, we update the cluster's status.
 	// NOTE: This update must be the last step in the reconciliation process to denote the complete reconciliation.
 	// No other mutating changes or reconciliations must happen in this loop after this step, so all such changes must
@@ -423,16 +668,84 @@ func (r *ClusterReconciler) postClusterProviderReconcile(ctx context.Context, lo
 	return controller.Result{}, nil
 }
 
+// reconcileMaintenanceWindow sets the DisruptiveChangesApplied condition before the provider reconciles the
+// cluster. While the maintenance window is closed, the changes that roll machines, as reported by the provider's
+// UpgradeNeeded, are deferred and it returns when the window opens next. It returns a zero time otherwise.
+func (r *ClusterReconciler) reconcileMaintenanceWindow(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster, providerReconciler clusters.ProviderClusterReconciler) (time.Time, error) {
+	if cluster.Spec.MaintenanceWindow == nil {
+		conditions.Delete(cluster, anywherev1.DisruptiveChangesAppliedCondition)
+		return time.Time{}, nil
+	}
+
+	window, err := cluster.Spec.MaintenanceWindow.Window()
+	if err != nil {
+		log.Error(err, "Invalid maintenance window")
+		conditions.MarkFalse(cluster, anywherev1.DisruptiveChangesAppliedCondition, anywherev1.InvalidMaintenanceWindowReason, clusterv1.ConditionSeverityError, "%s", err.Error())
+		return time.Time{}, errors.Wrap(err, "reading maintenance window")
+	}
+
+	now := time.Now()
+	if window.IsOpen(now) {
+		conditions.MarkTrue(cluster, anywherev1.DisruptiveChangesAppliedCondition)
+		return time.Time{}, nil
+	}
+
+	upgradeNeeded, err := providerReconciler.UpgradeNeeded(ctx, log, cluster)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "checking if the cluster needs an upgrade")
+	}
+
+	if !upgradeNeeded {
+		conditions.MarkTrue(cluster, anywherev1.DisruptiveChangesAppliedCondition)
+		return time.Time{}, nil
+	}
+
+	nextOpen := window.NextOpen(now)
+	conditions.MarkFalse(cluster, anywherev1.DisruptiveChangesAppliedCondition, anywherev1.WaitingForMaintenanceWindowReason, clusterv1.ConditionSeverityInfo,
+		"Changes that roll machines are deferred until the maintenance window opens at %s", nextOpen.Format(time.RFC3339))
+
+	return nextOpen, nil
+}
+
 func (r *ClusterReconciler) packagesReconcile(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) (controller.Result, error) {
 	// Self-managed clusters can support curated packages, but that support
 	// comes from the CLI at this time.
-	if cluster.IsManaged() && cluster.IsPackagesEnabled() {
-		if err := r.packagesClient.Reconcile(ctx, log, r.client, cluster); err != nil {
-			return controller.Result{}, err
-		}
+	if !cluster.IsManaged() || !cluster.IsPackagesEnabled() {
+		conditions.Delete(cluster, anywherev1.PackagesReadyCondition)
+		return controller.Result{}, nil
 	}
 
-	return controller.Result{}, nil
+	if err := r.packagesClient.Reconcile(ctx, log, r.client, cluster); err != nil {
+		return controller.Result{}, err
+	}
+
+	return packagesReadyResult(log, cluster), nil
+}
+
+// refreshPackagesReady runs independently of the cluster generation since packages are installed
+// asynchronously by the package controller, after the cluster generation has been reconciled. It only
+// updates the PackagesReady condition from the state of the packages, without reinstalling them.
+func (r *ClusterReconciler) refreshPackagesReady(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) (controller.Result, error) {
+	if !cluster.IsManaged() || !cluster.IsPackagesEnabled() {
+		conditions.Delete(cluster, anywherev1.PackagesReadyCondition)
+		return controller.Result{}, nil
+	}
+
+	if err := curatedpackages.ReconcilePackagesReady(ctx, r.client, cluster); err != nil {
+		return controller.Result{}, err
+	}
+
+	return packagesReadyResult(log, cluster), nil
+}
+
+// packagesReadyResult keeps checking the state of the packages until they are all ready.
+func packagesReadyResult(log logr.Logger, cluster *anywherev1.Cluster) controller.Result {
+	if conditions.IsFalse(cluster, anywherev1.PackagesReadyCondition) {
+		log.Info("Curated packages are not ready, requeueing", "reason", conditions.GetReason(cluster, anywherev1.PackagesReadyCondition))
+		return controller.ResultWithRequeue(defaultRequeueTime)
+	}
+
+	return controller.Result{}
 }
 
 func (r *ClusterReconciler) updateStatus(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) error {
@@ -570,6 +883,14 @@ func patchCluster(ctx context.Context, patchHelper *patch.Helper, cluster *anywh
 			anywherev1.ControlPlaneReadyCondition,
 			anywherev1.WorkersReadyCondition,
 			anywherev1.DefaultCNIConfiguredCondition,
+			anywherev1.RegistryMirrorCredentialsRotatedCondition,
+			anywherev1.AuthenticationConfigUpdatedCondition,
+			anywherev1.NodeConfigUpdatedCondition,
+			anywherev1.WorkerUpgradeWavesCompletedCondition,
+			anywherev1.DisruptiveChangesAppliedCondition,
+			anywherev1.GitOpsInSyncCondition,
+			anywherev1.PackagesReadyCondition,
+			anywherev1.EtcdHealthyCondition,
 		}},
 	}, patchOpts...)
 
@@ -24,6 +24,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
 	"github.com/aws/eks-anywhere/controllers"
 	"github.com/aws/eks-anywhere/controllers/mocks"
 	"github.com/aws/eks-anywhere/internal/test"
@@ -196,6 +197,174 @@ func TestClusterReconcilerReconcileSelfManagedCluster(t *testing.T) {
 	g.Expect(result).To(Equal(ctrl.Result{}))
 }
 
+func TestClusterReconcilerReconcileMaintenanceWindowDeferred(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	version := test.DevEksaVersion()
+
+	selfManagedCluster := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "my-management-cluster",
+			Generation: 2,
+		},
+		Spec: anywherev1.ClusterSpec{
+			KubernetesVersion: anywherev1.Kube132,
+			EksaVersion:       &version,
+			ClusterNetwork: anywherev1.ClusterNetwork{
+				CNIConfig: &anywherev1.CNIConfig{
+					Cilium: &anywherev1.CiliumConfig{},
+				},
+			},
+			MachineHealthCheck: &anywherev1.MachineHealthCheck{
+				UnhealthyMachineTimeout: &metav1.Duration{
+					Duration: constants.DefaultUnhealthyMachineTimeout,
+				},
+				NodeStartupTimeout: &metav1.Duration{
+					Duration: constants.DefaultNodeStartupTimeout,
+				},
+			},
+			MaintenanceWindow: &anywherev1.MaintenanceWindow{
+				Schedule: fmt.Sprintf("0 %d * * *", (time.Now().UTC().Hour()+12)%24),
+				Duration: metav1.Duration{Duration: time.Hour},
+			},
+		},
+		Status: anywherev1.ClusterStatus{
+			ReconciledGeneration: 1,
+		},
+	}
+
+	kcp := testKubeadmControlPlaneFromCluster(selfManagedCluster)
+
+	mockCtrl := gomock.NewController(t)
+	providerReconciler := mocks.NewMockProviderClusterReconciler(mockCtrl)
+	iam := mocks.NewMockAWSIamConfigReconciler(mockCtrl)
+	mhcReconciler := mocks.NewMockMachineHealthCheckReconciler(mockCtrl)
+
+	clusterValidator := mocks.NewMockClusterValidator(mockCtrl)
+	registry := newRegistryMock(providerReconciler)
+	eksaRelease := test.EKSARelease()
+	bundles := createBundle()
+	c := fake.NewClientBuilder().WithRuntimeObjects(selfManagedCluster, kcp, eksaRelease, bundles).
+		WithStatusSubresource(selfManagedCluster).
+		Build()
+	mockPkgs := mocks.NewMockPackagesClient(mockCtrl)
+	providerReconciler.EXPECT().UpgradeNeeded(ctx, gomock.AssignableToTypeOf(logr.Logger{}), sameName(selfManagedCluster)).Return(true, nil)
+	providerReconciler.EXPECT().Reconcile(ctx, gomock.AssignableToTypeOf(logr.Logger{}), sameName(selfManagedCluster)).
+		DoAndReturn(func(_ context.Context, _ logr.Logger, cluster *anywherev1.Cluster) (controller.Result, error) {
+			g.Expect(conditions.GetReason(cluster, anywherev1.DisruptiveChangesAppliedCondition)).To(Equal(anywherev1.WaitingForMaintenanceWindowReason))
+			return controller.Result{}, nil
+		})
+	mhcReconciler.EXPECT().Reconcile(ctx, gomock.AssignableToTypeOf(logr.Logger{}), sameName(selfManagedCluster)).Return(nil)
+
+	r := controllers.NewClusterReconciler(c, registry, iam, clusterValidator, mockPkgs, mhcReconciler)
+	result, err := r.Reconcile(ctx, clusterRequest(selfManagedCluster))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(BeNumerically(">", 11*time.Hour))
+	g.Expect(result.RequeueAfter).To(BeNumerically("<=", 12*time.Hour))
+
+	api := envtest.NewAPIExpecter(t, c)
+	api.ShouldEventuallyMatch(ctx, selfManagedCluster, func(g Gomega) {
+		g.Expect(selfManagedCluster.Status.ReconciledGeneration).To(Equal(int64(1)))
+		g.Expect(conditions.GetReason(selfManagedCluster, anywherev1.DisruptiveChangesAppliedCondition)).To(Equal(anywherev1.WaitingForMaintenanceWindowReason))
+	})
+}
+
+func maintenanceWindowTestCluster(window *anywherev1.MaintenanceWindow) *anywherev1.Cluster {
+	version := test.DevEksaVersion()
+	return &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "my-management-cluster",
+			Generation: 2,
+		},
+		Spec: anywherev1.ClusterSpec{
+			KubernetesVersion: anywherev1.Kube132,
+			EksaVersion:       &version,
+			ClusterNetwork: anywherev1.ClusterNetwork{
+				CNIConfig: &anywherev1.CNIConfig{
+					Cilium: &anywherev1.CiliumConfig{},
+				},
+			},
+			MachineHealthCheck: &anywherev1.MachineHealthCheck{
+				UnhealthyMachineTimeout: &metav1.Duration{
+					Duration: constants.DefaultUnhealthyMachineTimeout,
+				},
+				NodeStartupTimeout: &metav1.Duration{
+					Duration: constants.DefaultNodeStartupTimeout,
+				},
+			},
+			MaintenanceWindow: window,
+		},
+		Status: anywherev1.ClusterStatus{
+			ReconciledGeneration: 1,
+		},
+	}
+}
+
+func TestClusterReconcilerReconcileMaintenanceWindowNoUpgradeNeeded(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	selfManagedCluster := maintenanceWindowTestCluster(&anywherev1.MaintenanceWindow{
+		Schedule: fmt.Sprintf("0 %d * * *", (time.Now().UTC().Hour()+12)%24),
+		Duration: metav1.Duration{Duration: time.Hour},
+	})
+	kcp := testKubeadmControlPlaneFromCluster(selfManagedCluster)
+
+	mockCtrl := gomock.NewController(t)
+	providerReconciler := mocks.NewMockProviderClusterReconciler(mockCtrl)
+	iam := mocks.NewMockAWSIamConfigReconciler(mockCtrl)
+	mhcReconciler := mocks.NewMockMachineHealthCheckReconciler(mockCtrl)
+	clusterValidator := mocks.NewMockClusterValidator(mockCtrl)
+	registry := newRegistryMock(providerReconciler)
+	c := fake.NewClientBuilder().WithRuntimeObjects(selfManagedCluster, kcp, test.EKSARelease(), createBundle()).
+		WithStatusSubresource(selfManagedCluster).
+		Build()
+	mockPkgs := mocks.NewMockPackagesClient(mockCtrl)
+	providerReconciler.EXPECT().UpgradeNeeded(ctx, gomock.AssignableToTypeOf(logr.Logger{}), sameName(selfManagedCluster)).Return(false, nil)
+	providerReconciler.EXPECT().Reconcile(ctx, gomock.AssignableToTypeOf(logr.Logger{}), sameName(selfManagedCluster)).Return(controller.Result{}, nil)
+	mhcReconciler.EXPECT().Reconcile(ctx, gomock.AssignableToTypeOf(logr.Logger{}), sameName(selfManagedCluster)).Return(nil)
+
+	r := controllers.NewClusterReconciler(c, registry, iam, clusterValidator, mockPkgs, mhcReconciler)
+	_, err := r.Reconcile(ctx, clusterRequest(selfManagedCluster))
+	g.Expect(err).ToNot(HaveOccurred())
+
+	api := envtest.NewAPIExpecter(t, c)
+	api.ShouldEventuallyMatch(ctx, selfManagedCluster, func(g Gomega) {
+		g.Expect(selfManagedCluster.Status.ReconciledGeneration).To(Equal(int64(2)))
+		g.Expect(conditions.IsTrue(selfManagedCluster, anywherev1.DisruptiveChangesAppliedCondition)).To(BeTrue())
+	})
+}
+
+func TestClusterReconcilerReconcileMaintenanceWindowInvalid(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	selfManagedCluster := maintenanceWindowTestCluster(&anywherev1.MaintenanceWindow{
+		Schedule: "invalid",
+		Duration: metav1.Duration{Duration: time.Hour},
+	})
+	kcp := testKubeadmControlPlaneFromCluster(selfManagedCluster)
+
+	mockCtrl := gomock.NewController(t)
+	providerReconciler := mocks.NewMockProviderClusterReconciler(mockCtrl)
+	iam := mocks.NewMockAWSIamConfigReconciler(mockCtrl)
+	mhcReconciler := mocks.NewMockMachineHealthCheckReconciler(mockCtrl)
+	clusterValidator := mocks.NewMockClusterValidator(mockCtrl)
+	registry := newRegistryMock(providerReconciler)
+	c := fake.NewClientBuilder().WithRuntimeObjects(selfManagedCluster, kcp, test.EKSARelease(), createBundle()).
+		WithStatusSubresource(selfManagedCluster).
+		Build()
+	mockPkgs := mocks.NewMockPackagesClient(mockCtrl)
+
+	r := controllers.NewClusterReconciler(c, registry, iam, clusterValidator, mockPkgs, mhcReconciler)
+	_, err := r.Reconcile(ctx, clusterRequest(selfManagedCluster))
+	g.Expect(err).To(MatchError(ContainSubstring("reading maintenance window")))
+
+	api := envtest.NewAPIExpecter(t, c)
+	api.ShouldEventuallyMatch(ctx, selfManagedCluster, func(g Gomega) {
+		g.Expect(selfManagedCluster.Status.ReconciledGeneration).To(Equal(int64(1)))
+		g.Expect(conditions.GetReason(selfManagedCluster, anywherev1.DisruptiveChangesAppliedCondition)).To(Equal(anywherev1.InvalidMaintenanceWindowReason))
+	})
+}
+
 func TestClusterReconcilerReconcileUnclearedClusterFailure(t *testing.T) {
 	config, bundles := baseTestVsphereCluster()
 	version := test.DevEksaVersion()
@@ -249,6 +418,259 @@ func TestClusterReconcilerReconcileUnclearedClusterFailure(t *testing.T) {
 	})
 }
 
+func TestClusterReconcilerReconcileRegistryMirrorCredentialsRequeue(t *testing.T) {
+	config, bundles := baseTestVsphereCluster()
+	version := test.DevEksaVersion()
+	config.Cluster.Spec.EksaVersion = &version
+	config.Cluster.Generation = 1
+
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	objs := []runtime.Object{config.Cluster, bundles, test.EKSARelease(), testKubeadmControlPlaneFromCluster(config.Cluster)}
+	for _, o := range config.ChildObjects() {
+		objs = append(objs, o)
+	}
+
+	client := fake.NewClientBuilder().WithRuntimeObjects(objs...).
+		WithStatusSubresource(config.Cluster).
+		Build()
+	mockCtrl := gomock.NewController(t)
+	providerReconciler := mocks.NewMockProviderClusterReconciler(mockCtrl)
+	iam := mocks.NewMockAWSIamConfigReconciler(mockCtrl)
+	clusterValidator := mocks.NewMockClusterValidator(mockCtrl)
+	registry := newRegistryMock(providerReconciler)
+	mockPkgs := mocks.NewMockPackagesClient(mockCtrl)
+	mhcReconciler := mocks.NewMockMachineHealthCheckReconciler(mockCtrl)
+	credentialsReconciler := mocks.NewMockRegistryMirrorCredentialsReconciler(mockCtrl)
+
+	// Generations match, so only the registry mirror credentials should be reconciled
+	providerReconciler.EXPECT().Reconcile(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	credentialsReconciler.EXPECT().Reconcile(ctx, gomock.AssignableToTypeOf(logr.Logger{}), sameName(config.Cluster)).
+		Return(controller.ResultWithRequeue(10*time.Second), nil)
+
+	r := controllers.NewClusterReconciler(client, registry, iam, clusterValidator, mockPkgs, mhcReconciler,
+		controllers.WithRegistryMirrorCredentialsReconciler(credentialsReconciler),
+	)
+
+	result, err := r.Reconcile(ctx, clusterRequest(config.Cluster))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result).To(Equal(ctrl.Result{RequeueAfter: 10 * time.Second}))
+}
+
+func TestClusterReconcilerReconcileAuthenticationConfigRequeue(t *testing.T) {
+	config, bundles := baseTestVsphereCluster()
+	version := test.DevEksaVersion()
+	config.Cluster.Spec.EksaVersion = &version
+	config.Cluster.Generation = 1
+
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	objs := []runtime.Object{config.Cluster, bundles, test.EKSARelease(), testKubeadmControlPlaneFromCluster(config.Cluster)}
+	for _, o := range config.ChildObjects() {
+		objs = append(objs, o)
+	}
+
+	client := fake.NewClientBuilder().WithRuntimeObjects(objs...).
+		WithStatusSubresource(config.Cluster).
+		Build()
+	mockCtrl := gomock.NewController(t)
+	providerReconciler := mocks.NewMockProviderClusterReconciler(mockCtrl)
+	iam := mocks.NewMockAWSIamConfigReconciler(mockCtrl)
+	clusterValidator := mocks.NewMockClusterValidator(mockCtrl)
+	registry := newRegistryMock(providerReconciler)
+	mockPkgs := mocks.NewMockPackagesClient(mockCtrl)
+	mhcReconciler := mocks.NewMockMachineHealthCheckReconciler(mockCtrl)
+	credentialsReconciler := mocks.NewMockRegistryMirrorCredentialsReconciler(mockCtrl)
+	authenticationReconciler := mocks.NewMockAuthenticationConfigReconciler(mockCtrl)
+
+	// Generations match, so only the in place updates should be reconciled
+	providerReconciler.EXPECT().Reconcile(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	credentialsReconciler.EXPECT().Reconcile(ctx, gomock.AssignableToTypeOf(logr.Logger{}), sameName(config.Cluster)).
+		Return(controller.ResultWithRequeue(time.Minute), nil)
+	authenticationReconciler.EXPECT().Reconcile(ctx, gomock.AssignableToTypeOf(logr.Logger{}), sameName(config.Cluster)).
+		Return(controller.ResultWithRequeue(10*time.Second), nil)
+
+	r := controllers.NewClusterReconciler(client, registry, iam, clusterValidator, mockPkgs, mhcReconciler,
+		controllers.WithRegistryMirrorCredentialsReconciler(credentialsReconciler),
+		controllers.WithAuthenticationConfigReconciler(authenticationReconciler),
+	)
+
+	result, err := r.Reconcile(ctx, clusterRequest(config.Cluster))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result).To(Equal(ctrl.Result{RequeueAfter: 10 * time.Second}))
+}
+
+func TestClusterReconcilerReconcileNodeConfigRequeue(t *testing.T) {
+	config, bundles := baseTestVsphereCluster()
+	version := test.DevEksaVersion()
+	config.Cluster.Spec.EksaVersion = &version
+	config.Cluster.Generation = 1
+
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	objs := []runtime.Object{config.Cluster, bundles, test.EKSARelease(), testKubeadmControlPlaneFromCluster(config.Cluster)}
+	for _, o := range config.ChildObjects() {
+		objs = append(objs, o)
+	}
+
+	client := fake.NewClientBuilder().WithRuntimeObjects(objs...).
+		WithStatusSubresource(config.Cluster).
+		Build()
+	mockCtrl := gomock.NewController(t)
+	providerReconciler := mocks.NewMockProviderClusterReconciler(mockCtrl)
+	iam := mocks.NewMockAWSIamConfigReconciler(mockCtrl)
+	clusterValidator := mocks.NewMockClusterValidator(mockCtrl)
+	registry := newRegistryMock(providerReconciler)
+	mockPkgs := mocks.NewMockPackagesClient(mockCtrl)
+	mhcReconciler := mocks.NewMockMachineHealthCheckReconciler(mockCtrl)
+	nodeConfigReconciler := mocks.NewMockNodeConfigReconciler(mockCtrl)
+
+	// Generations match, so the node config is reconciled without the provider
+	providerReconciler.EXPECT().Reconcile(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	nodeConfigReconciler.EXPECT().Reconcile(ctx, gomock.AssignableToTypeOf(logr.Logger{}), sameName(config.Cluster)).
+		Return(controller.ResultWithRequeue(10*time.Second), nil)
+
+	r := controllers.NewClusterReconciler(client, registry, iam, clusterValidator, mockPkgs, mhcReconciler,
+		controllers.WithNodeConfigReconciler(nodeConfigReconciler),
+	)
+
+	result, err := r.Reconcile(ctx, clusterRequest(config.Cluster))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result).To(Equal(ctrl.Result{RequeueAfter: 10 * time.Second}))
+}
+
+func TestClusterReconcilerReconcileUpgradeCanaryRequeue(t *testing.T) {
+	config, bundles := baseTestVsphereCluster()
+	version := test.DevEksaVersion()
+	config.Cluster.Spec.EksaVersion = &version
+	config.Cluster.Generation = 1
+
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	objs := []runtime.Object{config.Cluster, bundles, test.EKSARelease(), testKubeadmControlPlaneFromCluster(config.Cluster)}
+	for _, o := range config.ChildObjects() {
+		objs = append(objs, o)
+	}
+
+	client := fake.NewClientBuilder().WithRuntimeObjects(objs...).
+		WithStatusSubresource(config.Cluster).
+		Build()
+	mockCtrl := gomock.NewController(t)
+	providerReconciler := mocks.NewMockProviderClusterReconciler(mockCtrl)
+	iam := mocks.NewMockAWSIamConfigReconciler(mockCtrl)
+	clusterValidator := mocks.NewMockClusterValidator(mockCtrl)
+	registry := newRegistryMock(providerReconciler)
+	mockPkgs := mocks.NewMockPackagesClient(mockCtrl)
+	mhcReconciler := mocks.NewMockMachineHealthCheckReconciler(mockCtrl)
+	upgradeCanaryReconciler := mocks.NewMockUpgradeCanaryReconciler(mockCtrl)
+
+	// Generations match, so the canary health check runs without the provider
+	providerReconciler.EXPECT().Reconcile(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	upgradeCanaryReconciler.EXPECT().Reconcile(ctx, gomock.AssignableToTypeOf(logr.Logger{}), sameName(config.Cluster)).
+		Return(controller.ResultWithRequeue(10*time.Second), nil)
+
+	r := controllers.NewClusterReconciler(client, registry, iam, clusterValidator, mockPkgs, mhcReconciler,
+		controllers.WithUpgradeCanaryReconciler(upgradeCanaryReconciler),
+	)
+
+	result, err := r.Reconcile(ctx, clusterRequest(config.Cluster))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result).To(Equal(ctrl.Result{RequeueAfter: 10 * time.Second}))
+}
+
+func TestClusterReconcilerReconcileEtcdBackupRequeue(t *testing.T) {
+	config, bundles := baseTestVsphereCluster()
+	version := test.DevEksaVersion()
+	config.Cluster.Spec.EksaVersion = &version
+	config.Cluster.Generation = 1
+
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	objs := []runtime.Object{config.Cluster, bundles, test.EKSARelease(), testKubeadmControlPlaneFromCluster(config.Cluster)}
+	for _, o := range config.ChildObjects() {
+		objs = append(objs, o)
+	}
+
+	client := fake.NewClientBuilder().WithRuntimeObjects(objs...).
+		WithStatusSubresource(config.Cluster).
+		Build()
+	mockCtrl := gomock.NewController(t)
+	providerReconciler := mocks.NewMockProviderClusterReconciler(mockCtrl)
+	iam := mocks.NewMockAWSIamConfigReconciler(mockCtrl)
+	clusterValidator := mocks.NewMockClusterValidator(mockCtrl)
+	registry := newRegistryMock(providerReconciler)
+	mockPkgs := mocks.NewMockPackagesClient(mockCtrl)
+	mhcReconciler := mocks.NewMockMachineHealthCheckReconciler(mockCtrl)
+	etcdBackupReconciler := mocks.NewMockEtcdBackupReconciler(mockCtrl)
+
+	// Generations match, so the etcd backup is reconciled without the provider
+	providerReconciler.EXPECT().Reconcile(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	etcdBackupReconciler.EXPECT().Reconcile(ctx, gomock.AssignableToTypeOf(logr.Logger{}), sameName(config.Cluster)).
+		Return(controller.ResultWithRequeue(30*time.Second), nil)
+
+	r := controllers.NewClusterReconciler(client, registry, iam, clusterValidator, mockPkgs, mhcReconciler,
+		controllers.WithEtcdBackupReconciler(etcdBackupReconciler),
+	)
+
+	result, err := r.Reconcile(ctx, clusterRequest(config.Cluster))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result).To(Equal(ctrl.Result{RequeueAfter: 30 * time.Second}))
+}
+
+func TestClusterReconcilerReconcileAWSIamMappingsRequeue(t *testing.T) {
+	config, bundles := baseTestVsphereCluster()
+	version := test.DevEksaVersion()
+	config.Cluster.Spec.EksaVersion = &version
+	config.Cluster.Generation = 1
+	config.Cluster.Spec.IdentityProviderRefs = []anywherev1.Ref{
+		{Kind: anywherev1.AWSIamConfigKind, Name: "aws-iam"},
+	}
+	iamConfig := &anywherev1.AWSIamConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "aws-iam",
+			Namespace: config.Cluster.Namespace,
+		},
+	}
+
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	objs := []runtime.Object{config.Cluster, bundles, test.EKSARelease(), testKubeadmControlPlaneFromCluster(config.Cluster), iamConfig}
+	for _, o := range config.ChildObjects() {
+		objs = append(objs, o)
+	}
+
+	client := fake.NewClientBuilder().WithRuntimeObjects(objs...).
+		WithStatusSubresource(config.Cluster).
+		Build()
+	mockCtrl := gomock.NewController(t)
+	providerReconciler := mocks.NewMockProviderClusterReconciler(mockCtrl)
+	iam := mocks.NewMockAWSIamConfigReconciler(mockCtrl)
+	clusterValidator := mocks.NewMockClusterValidator(mockCtrl)
+	registry := newRegistryMock(providerReconciler)
+	mockPkgs := mocks.NewMockPackagesClient(mockCtrl)
+	mhcReconciler := mocks.NewMockMachineHealthCheckReconciler(mockCtrl)
+	mappingsReconciler := mocks.NewMockAWSIamMappingsReconciler(mockCtrl)
+
+	// The mappings are reconciled before checking the generations
+	providerReconciler.EXPECT().Reconcile(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	mappingsReconciler.EXPECT().ReconcileMappings(ctx, gomock.AssignableToTypeOf(logr.Logger{}), sameName(config.Cluster)).
+		Return(controller.ResultWithRequeue(30*time.Second), nil)
+
+	r := controllers.NewClusterReconciler(client, registry, iam, clusterValidator, mockPkgs, mhcReconciler,
+		controllers.WithAWSIamMappingsReconciler(mappingsReconciler),
+	)
+
+	result, err := r.Reconcile(ctx, clusterRequest(config.Cluster))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result).To(Equal(ctrl.Result{RequeueAfter: 30 * time.Second}))
+}
+
 func TestClusterReconcilerReconcileConditions(t *testing.T) {
 	testCases := []struct {
 		testName                string
@@ -1469,6 +1891,72 @@ func TestClusterReconcilerPackagesInstall(s *testing.T) {
 			t.Errorf("expected nil error, got %s", err)
 		}
 	})
+
+	s.Run("requeues while packages are not ready", func(t *testing.T) {
+		g := NewWithT(t)
+		ctx := context.Background()
+		log := testr.New(t)
+		logCtx := ctrl.LoggerInto(ctx, log)
+		cluster := newTestCluster()
+		ctrl := gomock.NewController(t)
+		bundles := createBundle()
+
+		secret := &apiv1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: constants.EksaSystemNamespace,
+				Name:      cluster.Name + "-kubeconfig",
+			},
+		}
+		mgmt := cluster.DeepCopy()
+		mgmt.Name = "my-management-cluster"
+		objs := []runtime.Object{cluster, bundles, secret, mgmt, test.EKSARelease()}
+		fakeClient := fake.NewClientBuilder().WithRuntimeObjects(objs...).
+			WithStatusSubresource(cluster).
+			Build()
+		nullRegistry := newRegistryForDummyProviderReconciler()
+		mockIAM := mocks.NewMockAWSIamConfigReconciler(ctrl)
+		mockValid := mocks.NewMockClusterValidator(ctrl)
+		mockValid.EXPECT().ValidateManagementClusterName(logCtx, gomock.AssignableToTypeOf(logr.Logger{}), gomock.Any()).Return(nil)
+		mockPkgs := mocks.NewMockPackagesClient(ctrl)
+		mhcReconciler := mocks.NewMockMachineHealthCheckReconciler(ctrl)
+
+		mhcReconciler.EXPECT().Reconcile(logCtx, gomock.AssignableToTypeOf(logr.Logger{}), sameName(cluster)).Return(nil)
+		mockPkgs.EXPECT().Reconcile(logCtx, gomock.Any(), gomock.Any(), sameName(cluster)).
+			DoAndReturn(func(_ context.Context, _ logr.Logger, _ client.Client, c *anywherev1.Cluster) error {
+				conditions.MarkFalse(c, anywherev1.PackagesReadyCondition, anywherev1.PackagesFailedReason, clusterv1.ConditionSeverityError, "Packages failed: my-harbor")
+				return nil
+			})
+
+		r := controllers.NewClusterReconciler(fakeClient, nullRegistry, mockIAM, mockValid, mockPkgs, mhcReconciler)
+		result, err := r.Reconcile(logCtx, clusterRequest(cluster))
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(result.RequeueAfter).To(Equal(time.Minute))
+
+		api := envtest.NewAPIExpecter(t, fakeClient)
+		c := envtest.CloneNameNamespace(cluster)
+		api.ShouldEventuallyMatch(logCtx, c, func(g Gomega) {
+			condition := conditions.Get(c, anywherev1.PackagesReadyCondition)
+			g.Expect(condition).NotTo(BeNil())
+			g.Expect(condition.Message).To(Equal("Packages failed: my-harbor"))
+		})
+
+		// The generation has been reconciled, so the next reconcile only refreshes the condition
+		// from the packages without reinstalling them.
+		harbor := &packagesv1.Package{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-harbor", Namespace: "eksa-packages-" + cluster.Name},
+			Status:     packagesv1.PackageStatus{State: packagesv1.StateInstalled},
+		}
+		g.Expect(fakeClient.Create(logCtx, harbor)).To(Succeed())
+
+		result, err = r.Reconcile(logCtx, clusterRequest(cluster))
+		g.Expect(err).NotTo(HaveOccurred())
+		// The cluster is not ready, so it's still requeued, but not to wait for packages.
+		g.Expect(result.RequeueAfter).To(Equal(10 * time.Second))
+
+		api.ShouldEventuallyMatch(logCtx, c, func(g Gomega) {
+			g.Expect(conditions.IsTrue(c, anywherev1.PackagesReadyCondition)).To(BeTrue())
+		})
+	})
 }
 
 func TestClusterReconcilerValidateManagementEksaVersionFail(t *testing.T) {
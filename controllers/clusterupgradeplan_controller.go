@@ -0,0 +1,392 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/patch"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+// clusterUpgradePlanRequeueAfter is how often an in progress plan checks the state of its clusters.
+const clusterUpgradePlanRequeueAfter = 30 * time.Second
+
+// ClusterUpgradePlanReconciler reconciles a ClusterUpgradePlan object.
+type ClusterUpgradePlanReconciler struct {
+	client client.Client
+	log    logr.Logger
+}
+
+// NewClusterUpgradePlanReconciler returns a new instance of ClusterUpgradePlanReconciler.
+func NewClusterUpgradePlanReconciler(client client.Client) *ClusterUpgradePlanReconciler {
+	return &ClusterUpgradePlanReconciler{
+		client: client,
+		log:    ctrl.Log.WithName("ClusterUpgradePlanController"),
+	}
+}
+
+//+kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=clusterupgradeplans,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=clusterupgradeplans/status,verbs=get;update;patch
+
+// Reconcile upgrades the workload clusters selected by a ClusterUpgradePlan, batch by batch.
+func (r *ClusterUpgradePlanReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, reterr error) {
+	log := r.log.WithValues("ClusterUpgradePlan", req.NamespacedName)
+
+	plan := &anywherev1.ClusterUpgradePlan{}
+	if err := r.client.Get(ctx, req.NamespacedName, plan); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	if !plan.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	// A completed plan doesn't upgrade clusters that match its batches afterwards, unless its spec changes.
+	if plan.Status.Phase == anywherev1.ClusterUpgradePlanCompleted && plan.Status.ObservedGeneration == plan.Generation {
+		return ctrl.Result{}, nil
+	}
+
+	patchHelper, err := patch.NewHelper(plan, r.client)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	defer func() {
+		patchOpts := []patch.Option{}
+		if reterr == nil {
+			patchOpts = append(patchOpts, patch.WithStatusObservedGeneration{})
+		}
+		if err := patchHelper.Patch(ctx, plan, patchOpts...); err != nil {
+			reterr = kerrors.NewAggregate([]error{reterr, err})
+		}
+	}()
+
+	log.Info("Reconciling cluster upgrade plan")
+	return r.reconcile(ctx, log, plan)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ClusterUpgradePlanReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&anywherev1.ClusterUpgradePlan{}).
+		Watches(
+			&anywherev1.Cluster{},
+			handler.EnqueueRequestsFromMapFunc(r.clusterToUpgradePlans),
+		).
+		Complete(r)
+}
+
+// clusterToUpgradePlans enqueues the plans in progress in the namespace of a cluster when it changes.
+func (r *ClusterUpgradePlanReconciler) clusterToUpgradePlans(ctx context.Context, o client.Object) []reconcile.Request {
+	plans := &anywherev1.ClusterUpgradePlanList{}
+	if err := r.client.List(ctx, plans, client.InNamespace(o.GetNamespace())); err != nil {
+		r.log.Error(err, "Listing cluster upgrade plans", "namespace", o.GetNamespace())
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(plans.Items))
+	for _, p := range plans.Items {
+		if p.Status.Phase == anywherev1.ClusterUpgradePlanCompleted {
+			continue
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: p.Namespace, Name: p.Name}})
+	}
+
+	return requests
+}
+
+func (r *ClusterUpgradePlanReconciler) reconcile(ctx context.Context, log logr.Logger, plan *anywherev1.ClusterUpgradePlan) (ctrl.Result, error) {
+	if err := plan.Validate(); err != nil {
+		log.Error(err, "Invalid cluster upgrade plan")
+		message := err.Error()
+		plan.Status.FailureMessage = &message
+		return ctrl.Result{}, nil
+	}
+	plan.Status.FailureMessage = nil
+
+	clusters, err := r.planClusters(ctx, plan)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if _, ok := plan.Annotations[anywherev1.RetryFailedClustersAnnotation]; ok {
+		for i := range plan.Status.Clusters {
+			s := &plan.Status.Clusters[i]
+			if s.Phase == anywherev1.ClusterUpgradeFailed {
+				log.Info("Retrying failed cluster upgrade", "cluster", s.Name)
+				s.Phase = anywherev1.ClusterUpgradePending
+				s.StartTime = nil
+				s.Message = ""
+			}
+		}
+		delete(plan.Annotations, anywherev1.RetryFailedClustersAnnotation)
+	}
+
+	plan.Status.Clusters = clusterUpgradeStatuses(plan, clusters)
+
+	timeout := plan.ClusterUpgradeTimeout()
+	var failed []string
+	upgrading, started := 0, 0
+	for i := range plan.Status.Clusters {
+		s := &plan.Status.Clusters[i]
+		updateClusterUpgradeStatus(plan, s, clusters[s.Name], timeout)
+		switch s.Phase {
+		case anywherev1.ClusterUpgradeFailed:
+			failed = append(failed, s.Name)
+		case anywherev1.ClusterUpgradeUpgrading:
+			upgrading++
+		}
+		if s.Phase != anywherev1.ClusterUpgradePending {
+			started++
+		}
+	}
+
+	if len(failed) > 0 {
+		haltClusterUpgradePlan(plan, failed)
+		log.Info("Cluster upgrade plan halted", "failedClusters", failed)
+		if upgrading > 0 {
+			return ctrl.Result{RequeueAfter: clusterUpgradePlanRequeueAfter}, nil
+		}
+		return ctrl.Result{}, nil
+	}
+
+	batch := currentUpgradeBatch(plan)
+	if batch == nil {
+		plan.Status.Phase = anywherev1.ClusterUpgradePlanCompleted
+		plan.Status.CurrentBatch = ""
+		plan.Status.Message = ""
+		log.Info("All clusters in the plan have been upgraded")
+		return ctrl.Result{}, nil
+	}
+	plan.Status.CurrentBatch = batch.Name
+
+	if started > 0 {
+		plan.Status.Phase = anywherev1.ClusterUpgradePlanInProgress
+	} else {
+		plan.Status.Phase = anywherev1.ClusterUpgradePlanPending
+	}
+	plan.Status.Message = ""
+
+	batchUpgrading := 0
+	var pending []*anywherev1.ClusterUpgradeStatus
+	for i := range plan.Status.Clusters {
+		s := &plan.Status.Clusters[i]
+		if s.Batch != batch.Name {
+			continue
+		}
+		switch s.Phase {
+		case anywherev1.ClusterUpgradeUpgrading:
+			batchUpgrading++
+		case anywherev1.ClusterUpgradePending:
+			pending = append(pending, s)
+		}
+	}
+
+	available := batch.Concurrency() - batchUpgrading
+	if available <= 0 || len(pending) == 0 {
+		return ctrl.Result{RequeueAfter: clusterUpgradePlanRequeueAfter}, nil
+	}
+
+	if plan.Spec.MaintenanceWindow != nil {
+		// The window has already been validated.
+		window, _ := plan.Spec.MaintenanceWindow.Window()
+		now := time.Now()
+		if !window.IsOpen(now) {
+			nextOpen := window.NextOpen(now)
+			plan.Status.Message = fmt.Sprintf("Waiting for the maintenance window, it opens at %s", nextOpen.Format(time.RFC3339))
+			log.Info("Maintenance window is closed, not starting new cluster upgrades", "nextOpen", nextOpen)
+			if upgrading > 0 {
+				return ctrl.Result{RequeueAfter: clusterUpgradePlanRequeueAfter}, nil
+			}
+			return ctrl.Result{RequeueAfter: nextOpen.Sub(now)}, nil
+		}
+	}
+
+	for _, s := range pending {
+		if available == 0 {
+			break
+		}
+		log.Info("Starting cluster upgrade", "cluster", s.Name, "batch", s.Batch)
+		if err := r.startClusterUpgrade(ctx, plan, clusters[s.Name]); err != nil {
+			if !apierrors.IsInvalid(err) && !apierrors.IsForbidden(err) {
+				return ctrl.Result{}, err
+			}
+			log.Error(err, "Cluster spec update rejected, halting cluster upgrade plan", "cluster", s.Name)
+			s.Phase = anywherev1.ClusterUpgradeFailed
+			s.Message = fmt.Sprintf("Updating cluster spec: %v", err)
+			haltClusterUpgradePlan(plan, []string{s.Name})
+			return ctrl.Result{RequeueAfter: clusterUpgradePlanRequeueAfter}, nil
+		}
+		now := metav1.Now()
+		s.Phase = anywherev1.ClusterUpgradeUpgrading
+		s.StartTime = &now
+		s.Message = ""
+		available--
+	}
+	plan.Status.Phase = anywherev1.ClusterUpgradePlanInProgress
+
+	return ctrl.Result{RequeueAfter: clusterUpgradePlanRequeueAfter}, nil
+}
+
+// planClusters returns the workload clusters in the plan namespace, by name.
+func (r *ClusterUpgradePlanReconciler) planClusters(ctx context.Context, plan *anywherev1.ClusterUpgradePlan) (map[string]*anywherev1.Cluster, error) {
+	list := &anywherev1.ClusterList{}
+	if err := r.client.List(ctx, list, client.InNamespace(plan.Namespace)); err != nil {
+		return nil, fmt.Errorf("listing clusters: %v", err)
+	}
+
+	clusters := make(map[string]*anywherev1.Cluster, len(list.Items))
+	for i := range list.Items {
+		c := &list.Items[i]
+		if c.IsManaged() && c.DeletionTimestamp.IsZero() {
+			clusters[c.Name] = c
+		}
+	}
+
+	return clusters, nil
+}
+
+// startClusterUpgrade updates the cluster spec to the versions of the plan so the cluster controller upgrades it.
+func (r *ClusterUpgradePlanReconciler) startClusterUpgrade(ctx context.Context, plan *anywherev1.ClusterUpgradePlan, cluster *anywherev1.Cluster) error {
+	if clusterAtPlanVersions(plan, cluster) {
+		return nil
+	}
+
+	patch := client.MergeFrom(cluster.DeepCopy())
+	if plan.Spec.EksaVersion != nil {
+		v := *plan.Spec.EksaVersion
+		cluster.Spec.EksaVersion = &v
+		// bundlesRef is deprecated and can't be set together with eksaVersion.
+		cluster.Spec.BundlesRef = nil
+	}
+	if plan.Spec.KubernetesVersion != nil {
+		cluster.Spec.KubernetesVersion = *plan.Spec.KubernetesVersion
+	}
+
+	return r.client.Patch(ctx, cluster, patch)
+}
+
+// clusterUpgradeStatuses returns the status of the clusters selected by the plan, sorted by batch and name,
+// keeping the status of the clusters already in the plan.
+func clusterUpgradeStatuses(plan *anywherev1.ClusterUpgradePlan, clusters map[string]*anywherev1.Cluster) []anywherev1.ClusterUpgradeStatus {
+	current := make(map[string]anywherev1.ClusterUpgradeStatus, len(plan.Status.Clusters))
+	for _, s := range plan.Status.Clusters {
+		current[s.Name] = s
+	}
+
+	names := make([]string, 0, len(clusters))
+	for name := range clusters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	assigned := make(map[string]bool, len(clusters))
+	statuses := make([]anywherev1.ClusterUpgradeStatus, 0, len(clusters))
+	for _, b := range plan.Spec.Batches {
+		// Selectors have already been validated.
+		selector, _ := metav1.LabelSelectorAsSelector(&b.ClusterSelector)
+		for _, name := range names {
+			if assigned[name] || !selector.Matches(labels.Set(clusters[name].Labels)) {
+				continue
+			}
+			assigned[name] = true
+
+			s, ok := current[name]
+			if !ok || s.Batch != b.Name {
+				s = anywherev1.ClusterUpgradeStatus{
+					Name:  name,
+					Batch: b.Name,
+					Phase: anywherev1.ClusterUpgradePending,
+				}
+			}
+			statuses = append(statuses, s)
+		}
+	}
+
+	return statuses
+}
+
+// updateClusterUpgradeStatus updates the upgrade phase of a cluster from the status reported by the cluster controller.
+func updateClusterUpgradeStatus(plan *anywherev1.ClusterUpgradePlan, s *anywherev1.ClusterUpgradeStatus, cluster *anywherev1.Cluster, timeout time.Duration) {
+	switch s.Phase {
+	case anywherev1.ClusterUpgradePending:
+		if clusterAtPlanVersions(plan, cluster) && clusterUpgraded(cluster) {
+			s.Phase = anywherev1.ClusterUpgradeUpgraded
+			s.Message = "Cluster already at the plan versions"
+		}
+	case anywherev1.ClusterUpgradeUpgrading:
+		switch {
+		case cluster.Status.FailureMessage != nil:
+			s.Phase = anywherev1.ClusterUpgradeFailed
+			s.Message = *cluster.Status.FailureMessage
+		case clusterAtPlanVersions(plan, cluster) && clusterUpgraded(cluster):
+			s.Phase = anywherev1.ClusterUpgradeUpgraded
+			s.Message = ""
+		case s.StartTime != nil && time.Since(s.StartTime.Time) > timeout:
+			s.Phase = anywherev1.ClusterUpgradeFailed
+			s.Message = fmt.Sprintf("Cluster upgrade didn't finish in %s", timeout)
+			if c := conditions.Get(cluster, clusterv1.ReadyCondition); c != nil && c.Message != "" {
+				s.Message = fmt.Sprintf("%s: %s", s.Message, c.Message)
+			}
+		default:
+			if c := conditions.Get(cluster, clusterv1.ReadyCondition); c != nil {
+				s.Message = c.Message
+			}
+		}
+	}
+}
+
+func haltClusterUpgradePlan(plan *anywherev1.ClusterUpgradePlan, failed []string) {
+	plan.Status.Phase = anywherev1.ClusterUpgradePlanHalted
+	plan.Status.Message = fmt.Sprintf("Upgrade of clusters %v failed, no more cluster upgrades will be started. "+
+		"Add the %s annotation to retry them", failed, anywherev1.RetryFailedClustersAnnotation)
+}
+
+// currentUpgradeBatch returns the first batch with clusters that haven't been upgraded yet.
+func currentUpgradeBatch(plan *anywherev1.ClusterUpgradePlan) *anywherev1.ClusterUpgradeBatch {
+	for i := range plan.Spec.Batches {
+		b := &plan.Spec.Batches[i]
+		for _, s := range plan.Status.Clusters {
+			if s.Batch == b.Name && s.Phase != anywherev1.ClusterUpgradeUpgraded {
+				return b
+			}
+		}
+	}
+
+	return nil
+}
+
+func clusterAtPlanVersions(plan *anywherev1.ClusterUpgradePlan, cluster *anywherev1.Cluster) bool {
+	if plan.Spec.EksaVersion != nil && !plan.Spec.EksaVersion.Equal(cluster.Spec.EksaVersion) {
+		return false
+	}
+
+	return plan.Spec.KubernetesVersion == nil || *plan.Spec.KubernetesVersion == cluster.Spec.KubernetesVersion
+}
+
+// clusterUpgraded returns true if the cluster controller has finished reconciling the latest cluster spec
+// and the cluster is ready.
+func clusterUpgraded(cluster *anywherev1.Cluster) bool {
+	return cluster.Status.FailureMessage == nil &&
+		cluster.Status.ObservedGeneration == cluster.Generation &&
+		cluster.Status.ReconciledGeneration == cluster.Generation &&
+		conditions.IsTrue(cluster, clusterv1.ReadyCondition)
+}
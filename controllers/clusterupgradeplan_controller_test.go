@@ -0,0 +1,452 @@
+package controllers_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/aws/eks-anywhere/controllers"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+)
+
+const (
+	upgradePlanOldVersion = anywherev1.EksaVersion("v0.18.0")
+	upgradePlanNewVersion = anywherev1.EksaVersion("v0.19.0")
+)
+
+func TestClusterUpgradePlanReconcileStartsFirstBatch(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	plan := clusterUpgradePlan()
+	plan.Spec.Batches[0].MaxConcurrency = 2
+	c := upgradePlanClient(plan,
+		upgradePlanCluster("dev-1", "dev"), upgradePlanCluster("dev-2", "dev"), upgradePlanCluster("dev-3", "dev"),
+		upgradePlanCluster("prod-1", "prod"),
+	)
+	r := controllers.NewClusterUpgradePlanReconciler(c)
+
+	result, err := r.Reconcile(ctx, clusterUpgradePlanRequest(plan))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(Equal(30 * time.Second))
+
+	plan = getClusterUpgradePlan(g, c, plan)
+	g.Expect(plan.Status.Phase).To(Equal(anywherev1.ClusterUpgradePlanInProgress))
+	g.Expect(plan.Status.CurrentBatch).To(Equal("dev"))
+	g.Expect(clusterUpgradePhases(plan)).To(Equal(map[string]anywherev1.ClusterUpgradePhase{
+		"dev-1":  anywherev1.ClusterUpgradeUpgrading,
+		"dev-2":  anywherev1.ClusterUpgradeUpgrading,
+		"dev-3":  anywherev1.ClusterUpgradePending,
+		"prod-1": anywherev1.ClusterUpgradePending,
+	}))
+	g.Expect(plan.Status.Clusters[0].StartTime).NotTo(BeNil())
+
+	g.Expect(getUpgradePlanCluster(g, c, "dev-1").Spec.EksaVersion).To(HaveValue(Equal(upgradePlanNewVersion)))
+	g.Expect(getUpgradePlanCluster(g, c, "dev-2").Spec.EksaVersion).To(HaveValue(Equal(upgradePlanNewVersion)))
+	g.Expect(getUpgradePlanCluster(g, c, "dev-3").Spec.EksaVersion).To(HaveValue(Equal(upgradePlanOldVersion)))
+	g.Expect(getUpgradePlanCluster(g, c, "prod-1").Spec.EksaVersion).To(HaveValue(Equal(upgradePlanOldVersion)))
+}
+
+func TestClusterUpgradePlanReconcileIgnoresManagementClusters(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	plan := clusterUpgradePlan()
+	mgmt := upgradePlanCluster("mgmt", "dev")
+	mgmt.Spec.ManagementCluster.Name = "mgmt"
+	c := upgradePlanClient(plan, mgmt, upgradePlanCluster("dev-1", "dev"))
+	r := controllers.NewClusterUpgradePlanReconciler(c)
+
+	_, err := r.Reconcile(ctx, clusterUpgradePlanRequest(plan))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	plan = getClusterUpgradePlan(g, c, plan)
+	g.Expect(clusterUpgradePhases(plan)).To(Equal(map[string]anywherev1.ClusterUpgradePhase{
+		"dev-1": anywherev1.ClusterUpgradeUpgrading,
+	}))
+	g.Expect(getUpgradePlanCluster(g, c, "mgmt").Spec.EksaVersion).To(HaveValue(Equal(upgradePlanOldVersion)))
+}
+
+func TestClusterUpgradePlanReconcileReplacesBundlesRef(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	plan := clusterUpgradePlan()
+	kubeVersion := anywherev1.Kube129
+	plan.Spec.KubernetesVersion = &kubeVersion
+	cluster := upgradePlanCluster("dev-1", "dev")
+	cluster.Spec.EksaVersion = nil
+	cluster.Spec.BundlesRef = &anywherev1.BundlesRef{Name: "bundles-1", Namespace: "eksa-system"}
+	c := upgradePlanClient(plan, cluster)
+	r := controllers.NewClusterUpgradePlanReconciler(c)
+
+	_, err := r.Reconcile(ctx, clusterUpgradePlanRequest(plan))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	cluster = getUpgradePlanCluster(g, c, "dev-1")
+	g.Expect(cluster.Spec.EksaVersion).To(HaveValue(Equal(upgradePlanNewVersion)))
+	g.Expect(cluster.Spec.BundlesRef).To(BeNil())
+	g.Expect(cluster.Spec.KubernetesVersion).To(Equal(anywherev1.Kube129))
+}
+
+func TestClusterUpgradePlanReconcileWaitsForBatch(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	plan := clusterUpgradePlan()
+	plan.Status.Clusters = []anywherev1.ClusterUpgradeStatus{
+		upgradingClusterStatus("dev-1", "dev", time.Now()),
+	}
+	dev := upgradePlanCluster("dev-1", "dev")
+	dev.Spec.EksaVersion = upgradePlanEksaVersion(upgradePlanNewVersion)
+	dev.Generation = 2
+	conditions.MarkFalse(dev, clusterv1.ReadyCondition, anywherev1.ControlPlaneNotReadyReason, clusterv1.ConditionSeverityInfo, "Control plane not ready")
+	c := upgradePlanClient(plan, dev, upgradePlanCluster("prod-1", "prod"))
+	r := controllers.NewClusterUpgradePlanReconciler(c)
+
+	_, err := r.Reconcile(ctx, clusterUpgradePlanRequest(plan))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	plan = getClusterUpgradePlan(g, c, plan)
+	g.Expect(plan.Status.CurrentBatch).To(Equal("dev"))
+	g.Expect(clusterUpgradePhases(plan)).To(Equal(map[string]anywherev1.ClusterUpgradePhase{
+		"dev-1":  anywherev1.ClusterUpgradeUpgrading,
+		"prod-1": anywherev1.ClusterUpgradePending,
+	}))
+	g.Expect(plan.Status.Clusters[0].Message).To(Equal("Control plane not ready"))
+	g.Expect(getUpgradePlanCluster(g, c, "prod-1").Spec.EksaVersion).To(HaveValue(Equal(upgradePlanOldVersion)))
+}
+
+func TestClusterUpgradePlanReconcileStartsNextBatch(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	plan := clusterUpgradePlan()
+	plan.Status.Clusters = []anywherev1.ClusterUpgradeStatus{
+		upgradingClusterStatus("dev-1", "dev", time.Now()),
+	}
+	dev := upgradedPlanCluster("dev-1", "dev")
+	c := upgradePlanClient(plan, dev, upgradePlanCluster("prod-1", "prod"))
+	r := controllers.NewClusterUpgradePlanReconciler(c)
+
+	_, err := r.Reconcile(ctx, clusterUpgradePlanRequest(plan))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	plan = getClusterUpgradePlan(g, c, plan)
+	g.Expect(plan.Status.CurrentBatch).To(Equal("prod"))
+	g.Expect(clusterUpgradePhases(plan)).To(Equal(map[string]anywherev1.ClusterUpgradePhase{
+		"dev-1":  anywherev1.ClusterUpgradeUpgraded,
+		"prod-1": anywherev1.ClusterUpgradeUpgrading,
+	}))
+	g.Expect(getUpgradePlanCluster(g, c, "prod-1").Spec.EksaVersion).To(HaveValue(Equal(upgradePlanNewVersion)))
+}
+
+func TestClusterUpgradePlanReconcileClusterAlreadyUpgraded(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	plan := clusterUpgradePlan()
+	c := upgradePlanClient(plan, upgradedPlanCluster("dev-1", "dev"), upgradePlanCluster("prod-1", "prod"))
+	r := controllers.NewClusterUpgradePlanReconciler(c)
+
+	_, err := r.Reconcile(ctx, clusterUpgradePlanRequest(plan))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	plan = getClusterUpgradePlan(g, c, plan)
+	g.Expect(plan.Status.CurrentBatch).To(Equal("prod"))
+	g.Expect(clusterUpgradePhases(plan)).To(Equal(map[string]anywherev1.ClusterUpgradePhase{
+		"dev-1":  anywherev1.ClusterUpgradeUpgraded,
+		"prod-1": anywherev1.ClusterUpgradeUpgrading,
+	}))
+}
+
+func TestClusterUpgradePlanReconcileCompleted(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	plan := clusterUpgradePlan()
+	plan.Status.Clusters = []anywherev1.ClusterUpgradeStatus{
+		{Name: "dev-1", Batch: "dev", Phase: anywherev1.ClusterUpgradeUpgraded},
+		upgradingClusterStatus("prod-1", "prod", time.Now()),
+	}
+	c := upgradePlanClient(plan, upgradedPlanCluster("dev-1", "dev"), upgradedPlanCluster("prod-1", "prod"))
+	r := controllers.NewClusterUpgradePlanReconciler(c)
+
+	result, err := r.Reconcile(ctx, clusterUpgradePlanRequest(plan))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result).To(Equal(reconcile.Result{}))
+
+	plan = getClusterUpgradePlan(g, c, plan)
+	g.Expect(plan.Status.Phase).To(Equal(anywherev1.ClusterUpgradePlanCompleted))
+	g.Expect(plan.Status.CurrentBatch).To(BeEmpty())
+
+	// Completed plans don't upgrade new clusters.
+	g.Expect(c.Create(ctx, upgradePlanCluster("dev-2", "dev"))).To(Succeed())
+	_, err = r.Reconcile(ctx, clusterUpgradePlanRequest(plan))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(getUpgradePlanCluster(g, c, "dev-2").Spec.EksaVersion).To(HaveValue(Equal(upgradePlanOldVersion)))
+}
+
+func TestClusterUpgradePlanReconcileHaltsOnClusterFailure(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	plan := clusterUpgradePlan()
+	plan.Spec.Batches[0].MaxConcurrency = 2
+	plan.Status.Clusters = []anywherev1.ClusterUpgradeStatus{
+		upgradingClusterStatus("dev-1", "dev", time.Now()),
+	}
+	dev := upgradePlanCluster("dev-1", "dev")
+	dev.Spec.EksaVersion = upgradePlanEksaVersion(upgradePlanNewVersion)
+	dev.Status.FailureMessage = ptr.String("invalid machine config")
+	c := upgradePlanClient(plan, dev, upgradePlanCluster("dev-2", "dev"))
+	r := controllers.NewClusterUpgradePlanReconciler(c)
+
+	result, err := r.Reconcile(ctx, clusterUpgradePlanRequest(plan))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result).To(Equal(reconcile.Result{}))
+
+	plan = getClusterUpgradePlan(g, c, plan)
+	g.Expect(plan.Status.Phase).To(Equal(anywherev1.ClusterUpgradePlanHalted))
+	g.Expect(plan.Status.Message).To(ContainSubstring("Upgrade of clusters [dev-1] failed"))
+	g.Expect(clusterUpgradePhases(plan)).To(Equal(map[string]anywherev1.ClusterUpgradePhase{
+		"dev-1": anywherev1.ClusterUpgradeFailed,
+		"dev-2": anywherev1.ClusterUpgradePending,
+	}))
+	g.Expect(plan.Status.Clusters[0].Message).To(Equal("invalid machine config"))
+	g.Expect(getUpgradePlanCluster(g, c, "dev-2").Spec.EksaVersion).To(HaveValue(Equal(upgradePlanOldVersion)))
+}
+
+func TestClusterUpgradePlanReconcileHaltsOnTimeout(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	plan := clusterUpgradePlan()
+	plan.Spec.ClusterTimeout = &metav1.Duration{Duration: time.Hour}
+	plan.Status.Clusters = []anywherev1.ClusterUpgradeStatus{
+		upgradingClusterStatus("dev-1", "dev", time.Now().Add(-2*time.Hour)),
+	}
+	dev := upgradePlanCluster("dev-1", "dev")
+	dev.Spec.EksaVersion = upgradePlanEksaVersion(upgradePlanNewVersion)
+	dev.Generation = 2
+	conditions.MarkFalse(dev, clusterv1.ReadyCondition, anywherev1.MachineDeploymentNotReadyReason, clusterv1.ConditionSeverityInfo, "Worker nodes not ready")
+	c := upgradePlanClient(plan, dev)
+	r := controllers.NewClusterUpgradePlanReconciler(c)
+
+	_, err := r.Reconcile(ctx, clusterUpgradePlanRequest(plan))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	plan = getClusterUpgradePlan(g, c, plan)
+	g.Expect(plan.Status.Phase).To(Equal(anywherev1.ClusterUpgradePlanHalted))
+	g.Expect(plan.Status.Clusters[0].Phase).To(Equal(anywherev1.ClusterUpgradeFailed))
+	g.Expect(plan.Status.Clusters[0].Message).To(Equal("Cluster upgrade didn't finish in 1h0m0s: Worker nodes not ready"))
+}
+
+func TestClusterUpgradePlanReconcileRetryFailedClusters(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	plan := clusterUpgradePlan()
+	plan.Annotations = map[string]string{anywherev1.RetryFailedClustersAnnotation: "true"}
+	plan.Status.Phase = anywherev1.ClusterUpgradePlanHalted
+	plan.Status.Clusters = []anywherev1.ClusterUpgradeStatus{
+		{Name: "dev-1", Batch: "dev", Phase: anywherev1.ClusterUpgradeFailed, Message: "timed out"},
+	}
+	c := upgradePlanClient(plan, upgradePlanCluster("dev-1", "dev"))
+	r := controllers.NewClusterUpgradePlanReconciler(c)
+
+	_, err := r.Reconcile(ctx, clusterUpgradePlanRequest(plan))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	plan = getClusterUpgradePlan(g, c, plan)
+	g.Expect(plan.Annotations).NotTo(HaveKey(anywherev1.RetryFailedClustersAnnotation))
+	g.Expect(plan.Status.Phase).To(Equal(anywherev1.ClusterUpgradePlanInProgress))
+	g.Expect(plan.Status.Clusters[0].Phase).To(Equal(anywherev1.ClusterUpgradeUpgrading))
+	g.Expect(plan.Status.Clusters[0].Message).To(BeEmpty())
+	g.Expect(getUpgradePlanCluster(g, c, "dev-1").Spec.EksaVersion).To(HaveValue(Equal(upgradePlanNewVersion)))
+}
+
+func TestClusterUpgradePlanReconcileMaintenanceWindowClosed(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	plan := clusterUpgradePlan()
+	plan.Spec.MaintenanceWindow = &anywherev1.MaintenanceWindow{
+		Schedule: fmt.Sprintf("0 %d * * *", (time.Now().UTC().Hour()+12)%24),
+		Duration: metav1.Duration{Duration: time.Hour},
+	}
+	c := upgradePlanClient(plan, upgradePlanCluster("dev-1", "dev"))
+	r := controllers.NewClusterUpgradePlanReconciler(c)
+
+	result, err := r.Reconcile(ctx, clusterUpgradePlanRequest(plan))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(BeNumerically(">", 10*time.Hour))
+
+	plan = getClusterUpgradePlan(g, c, plan)
+	g.Expect(plan.Status.Phase).To(Equal(anywherev1.ClusterUpgradePlanPending))
+	g.Expect(plan.Status.Message).To(HavePrefix("Waiting for the maintenance window"))
+	g.Expect(plan.Status.Clusters[0].Phase).To(Equal(anywherev1.ClusterUpgradePending))
+	g.Expect(getUpgradePlanCluster(g, c, "dev-1").Spec.EksaVersion).To(HaveValue(Equal(upgradePlanOldVersion)))
+}
+
+func TestClusterUpgradePlanReconcileMaintenanceWindowOpen(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	plan := clusterUpgradePlan()
+	plan.Spec.MaintenanceWindow = &anywherev1.MaintenanceWindow{
+		Schedule: "0 * * * *",
+		Duration: metav1.Duration{Duration: time.Hour},
+	}
+	c := upgradePlanClient(plan, upgradePlanCluster("dev-1", "dev"))
+	r := controllers.NewClusterUpgradePlanReconciler(c)
+
+	_, err := r.Reconcile(ctx, clusterUpgradePlanRequest(plan))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	plan = getClusterUpgradePlan(g, c, plan)
+	g.Expect(plan.Status.Clusters[0].Phase).To(Equal(anywherev1.ClusterUpgradeUpgrading))
+}
+
+func TestClusterUpgradePlanReconcileInvalid(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	plan := clusterUpgradePlan()
+	plan.Spec.EksaVersion = nil
+	c := upgradePlanClient(plan, upgradePlanCluster("dev-1", "dev"))
+	r := controllers.NewClusterUpgradePlanReconciler(c)
+
+	_, err := r.Reconcile(ctx, clusterUpgradePlanRequest(plan))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	plan = getClusterUpgradePlan(g, c, plan)
+	g.Expect(plan.Status.FailureMessage).To(HaveValue(Equal("must specify eksaVersion or kubernetesVersion")))
+	g.Expect(plan.Status.Clusters).To(BeEmpty())
+}
+
+func TestClusterUpgradePlanReconcileNotFound(t *testing.T) {
+	g := NewWithT(t)
+	c := fake.NewClientBuilder().Build()
+	r := controllers.NewClusterUpgradePlanReconciler(c)
+
+	_, err := r.Reconcile(context.Background(), clusterUpgradePlanRequest(clusterUpgradePlan()))
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+func clusterUpgradePlan() *anywherev1.ClusterUpgradePlan {
+	return &anywherev1.ClusterUpgradePlan{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "fleet",
+			Namespace: "default",
+		},
+		Spec: anywherev1.ClusterUpgradePlanSpec{
+			EksaVersion: upgradePlanEksaVersion(upgradePlanNewVersion),
+			Batches: []anywherev1.ClusterUpgradeBatch{
+				{
+					Name:            "dev",
+					ClusterSelector: metav1.LabelSelector{MatchLabels: map[string]string{"env": "dev"}},
+				},
+				{
+					Name:            "prod",
+					ClusterSelector: metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+				},
+			},
+		},
+	}
+}
+
+func upgradePlanCluster(name, env string) *anywherev1.Cluster {
+	cluster := &anywherev1.Cluster{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       anywherev1.ClusterKind,
+			APIVersion: anywherev1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       name,
+			Namespace:  "default",
+			Labels:     map[string]string{"env": env},
+			Generation: 1,
+		},
+		Spec: anywherev1.ClusterSpec{
+			KubernetesVersion: anywherev1.Kube128,
+			EksaVersion:       upgradePlanEksaVersion(upgradePlanOldVersion),
+			ManagementCluster: anywherev1.ManagementCluster{Name: "mgmt"},
+		},
+		Status: anywherev1.ClusterStatus{
+			ObservedGeneration:   1,
+			ReconciledGeneration: 1,
+		},
+	}
+	conditions.MarkTrue(cluster, clusterv1.ReadyCondition)
+
+	return cluster
+}
+
+func upgradedPlanCluster(name, env string) *anywherev1.Cluster {
+	cluster := upgradePlanCluster(name, env)
+	cluster.Spec.EksaVersion = upgradePlanEksaVersion(upgradePlanNewVersion)
+
+	return cluster
+}
+
+func upgradingClusterStatus(name, batch string, start time.Time) anywherev1.ClusterUpgradeStatus {
+	return anywherev1.ClusterUpgradeStatus{
+		Name:      name,
+		Batch:     batch,
+		Phase:     anywherev1.ClusterUpgradeUpgrading,
+		StartTime: &metav1.Time{Time: start},
+	}
+}
+
+func upgradePlanClient(plan *anywherev1.ClusterUpgradePlan, clusters ...*anywherev1.Cluster) client.Client {
+	objs := []runtime.Object{plan}
+	for _, c := range clusters {
+		objs = append(objs, c)
+	}
+
+	return fake.NewClientBuilder().WithRuntimeObjects(objs...).WithStatusSubresource(plan).Build()
+}
+
+func clusterUpgradePlanRequest(plan *anywherev1.ClusterUpgradePlan) reconcile.Request {
+	return reconcile.Request{NamespacedName: types.NamespacedName{Name: plan.Name, Namespace: plan.Namespace}}
+}
+
+func getClusterUpgradePlan(g *WithT, c client.Client, plan *anywherev1.ClusterUpgradePlan) *anywherev1.ClusterUpgradePlan {
+	p := &anywherev1.ClusterUpgradePlan{}
+	g.Expect(c.Get(context.Background(), types.NamespacedName{Name: plan.Name, Namespace: plan.Namespace}, p)).To(Succeed())
+
+	return p
+}
+
+func getUpgradePlanCluster(g *WithT, c client.Client, name string) *anywherev1.Cluster {
+	cluster := &anywherev1.Cluster{}
+	g.Expect(c.Get(context.Background(), types.NamespacedName{Name: name, Namespace: "default"}, cluster)).To(Succeed())
+
+	return cluster
+}
+
+func clusterUpgradePhases(plan *anywherev1.ClusterUpgradePlan) map[string]anywherev1.ClusterUpgradePhase {
+	phases := make(map[string]anywherev1.ClusterUpgradePhase, len(plan.Status.Clusters))
+	for _, s := range plan.Status.Clusters {
+		phases[s.Name] = s.Phase
+	}
+
+	return phases
+}
+
+func upgradePlanEksaVersion(v anywherev1.EksaVersion) *anywherev1.EksaVersion {
+	return &v
+}
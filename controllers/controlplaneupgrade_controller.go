@@ -35,6 +35,7 @@ import (
 	"sigs.k8s.io/cluster-api/controllers/external"
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -73,7 +74,7 @@ func NewControlPlaneUpgradeReconciler(client client.Client, remoteClientRegistry
 //+kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=controlplaneupgrades/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=controlplaneupgrades/finalizers,verbs=update
 //+kubebuilder:rbac:groups=bootstrap.cluster.x-k8s.io,resources=kubeadmconfigs,verbs=get;list;watch;update;patch
-//+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=tinkerbellmachines;vspheremachines,verbs=get;list;update;patch
+//+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=tinkerbellmachines;vspheremachines;nutanixmachines,verbs=get;list;update;patch
 
 // Reconcile reconciles a ControlPlaneUpgrade object.
 // nolint:gocyclo
@@ -170,6 +171,10 @@ func (r *ControlPlaneUpgradeReconciler) reconcile(ctx context.Context, log logr.
 		nodeUpgrade := nodeUpgrader(machineRef, cpUpgrade.Spec.KubernetesVersion, cpUpgrade.Spec.EtcdVersion, firstControlPlane)
 		if err := r.client.Get(ctx, GetNamespacedNameType(nodeUpgraderName(machineRef.Name), constants.EksaSystemNamespace), nodeUpgrade); err != nil {
 			if apierrors.IsNotFound(err) {
+				if isUpgradePaused(cpUpgrade) {
+					log.Info("Upgrade is paused, skipping creation of node upgrader", "Machine", machineRef.Name)
+					return ctrl.Result{}, nil
+				}
 				if err := r.client.Create(ctx, nodeUpgrade); client.IgnoreAlreadyExists(err) != nil {
 					return ctrl.Result{}, fmt.Errorf("failed to create node upgrader for machine %s:  %v", machineRef.Name, err)
 				}
@@ -177,6 +182,10 @@ func (r *ControlPlaneUpgradeReconciler) reconcile(ctx context.Context, log logr.
 			}
 			return ctrl.Result{}, fmt.Errorf("getting node upgrader for machine %s: %v", machineRef.Name, err)
 		}
+		if conditions.IsTrue(nodeUpgrade, anywherev1.RolledBack) {
+			log.Info("Node upgrade was rolled back, halting control plane upgrade", "Machine", machineRef.Name)
+			return ctrl.Result{}, nil
+		}
 		if !nodeUpgrade.Status.Completed {
 			return ctrl.Result{}, nil
 		}
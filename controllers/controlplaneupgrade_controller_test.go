@@ -10,6 +10,7 @@ import (
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -204,6 +205,43 @@ func TestCPUpgradeReconcileNodeUpgraderCreate(t *testing.T) {
 	g.Expect(err).ToNot(HaveOccurred())
 }
 
+func TestCPUpgradeReconcilePaused(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	clientRegistry := mocks.NewMockRemoteClientRegistry(ctrl)
+	testObjs := getObjectsForCPUpgradeTest()
+	testObjs.cpUpgrade.Annotations = map[string]string{anywherev1.UpgradePausedAnnotation: "true"}
+	testObjs.nodeUpgrades[0].Name = fmt.Sprintf("%s-node-upgrader", testObjs.machines[0].Name)
+	testObjs.nodeUpgrades[0].Status = anywherev1.NodeUpgradeStatus{
+		Completed: true,
+	}
+	objs := []runtime.Object{
+		testObjs.cluster, testObjs.machines[0], testObjs.machines[1], testObjs.nodes[0], testObjs.nodes[1], testObjs.cpUpgrade,
+		testObjs.nodeUpgrades[0], testObjs.kubeadmConfigs[0], testObjs.kubeadmConfigs[1], testObjs.infraMachines[0], testObjs.infraMachines[1],
+	}
+	client := fake.NewClientBuilder().WithRuntimeObjects(objs...).
+		WithStatusSubresource(testObjs.cpUpgrade).
+		Build()
+	kcp := testObjs.cpUpgrade.Spec.ControlPlane
+	clientRegistry.EXPECT().GetClient(ctx, types.NamespacedName{Name: kcp.Name, Namespace: kcp.Namespace}).Return(client, nil)
+
+	r := controllers.NewControlPlaneUpgradeReconciler(client, clientRegistry)
+	req := cpUpgradeRequest(testObjs.cpUpgrade)
+	_, err := r.Reconcile(ctx, req)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	n := &anywherev1.NodeUpgrade{}
+	nodeUpgradeName := fmt.Sprintf("%s-node-upgrader", testObjs.machines[1].Name)
+	err = client.Get(ctx, types.NamespacedName{Name: nodeUpgradeName, Namespace: constants.EksaSystemNamespace}, n)
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+	cpu := &anywherev1.ControlPlaneUpgrade{}
+	g.Expect(client.Get(ctx, req.NamespacedName, cpu)).To(Succeed())
+	g.Expect(cpu.Status.Upgraded).To(BeEquivalentTo(1))
+	g.Expect(cpu.Status.Ready).To(BeFalse())
+}
+
 func TestCPUpgradeReconcileNodeUpgraderInvalidKCPSpec(t *testing.T) {
 	g := NewWithT(t)
 	ctx := context.Background()
@@ -11,6 +11,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	authenticationreconciler "github.com/aws/eks-anywhere/pkg/authentication/reconciler"
 	awsiamconfigreconciler "github.com/aws/eks-anywhere/pkg/awsiamauth/reconciler"
 	anywhereCluster "github.com/aws/eks-anywhere/pkg/cluster"
 	mhcreconciler "github.com/aws/eks-anywhere/pkg/clusterapi/machinehealthcheck/reconciler"
@@ -19,12 +20,16 @@ import (
 	"github.com/aws/eks-anywhere/pkg/crypto"
 	"github.com/aws/eks-anywhere/pkg/curatedpackages"
 	"github.com/aws/eks-anywhere/pkg/dependencies"
+	etcdbackupreconciler "github.com/aws/eks-anywhere/pkg/etcdbackup/reconciler"
 	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/executables/cmk"
 	"github.com/aws/eks-anywhere/pkg/helm"
 	"github.com/aws/eks-anywhere/pkg/networking/cilium"
 	ciliumreconciler "github.com/aws/eks-anywhere/pkg/networking/cilium/reconciler"
+	customcni "github.com/aws/eks-anywhere/pkg/networking/custom"
+	"github.com/aws/eks-anywhere/pkg/networking/networkpolicy"
 	cnireconciler "github.com/aws/eks-anywhere/pkg/networking/reconciler"
+	nodeconfigreconciler "github.com/aws/eks-anywhere/pkg/nodeconfig/reconciler"
 	"github.com/aws/eks-anywhere/pkg/providers/cloudstack"
 	cloudstackreconciler "github.com/aws/eks-anywhere/pkg/providers/cloudstack/reconciler"
 	dockerreconciler "github.com/aws/eks-anywhere/pkg/providers/docker/reconciler"
@@ -33,6 +38,8 @@ import (
 	snowreconciler "github.com/aws/eks-anywhere/pkg/providers/snow/reconciler"
 	tinkerbellreconciler "github.com/aws/eks-anywhere/pkg/providers/tinkerbell/reconciler"
 	vspherereconciler "github.com/aws/eks-anywhere/pkg/providers/vsphere/reconciler"
+	registrymirrorreconciler "github.com/aws/eks-anywhere/pkg/registrymirror/reconciler"
+	upgradecanaryreconciler "github.com/aws/eks-anywhere/pkg/upgradecanary/reconciler"
 )
 
 type Manager = manager.Manager
@@ -76,6 +83,7 @@ type Reconcilers struct {
 	ControlPlaneUpgradeReconciler      *ControlPlaneUpgradeReconciler
 	MachineDeploymentUpgradeReconciler *MachineDeploymentUpgradeReconciler
 	NodeUpgradeReconciler              *NodeUpgradeReconciler
+	ClusterUpgradePlanReconciler       *ClusterUpgradePlanReconciler
 }
 
 type buildStep func(ctx context.Context) error
@@ -134,7 +142,14 @@ func (f *Factory) WithClusterReconciler(capiProviders []clusterctlv1.Provider, o
 			clusters.NewClusterValidator(f.manager.GetClient()),
 			f.packageControllerClient,
 			f.machineHealthCheckReconciler,
-			opts...,
+			append([]ClusterReconcilerOption{
+				WithRegistryMirrorCredentialsReconciler(registrymirrorreconciler.New(f.manager.GetClient(), f.tracker)),
+				WithAuthenticationConfigReconciler(authenticationreconciler.New(f.manager.GetClient(), f.tracker)),
+				WithNodeConfigReconciler(nodeconfigreconciler.New(f.manager.GetClient(), f.tracker)),
+				WithUpgradeCanaryReconciler(upgradecanaryreconciler.New(f.manager.GetClient(), f.tracker)),
+				WithEtcdBackupReconciler(etcdbackupreconciler.New(f.manager.GetClient(), f.tracker)),
+				WithAWSIamMappingsReconciler(f.awsIamConfigReconciler),
+			}, opts...)...,
 		)
 
 		return nil
@@ -512,7 +527,11 @@ func (f *Factory) withCNIReconciler(providerNamespace string) *Factory {
 			return nil
 		}
 
-		f.cniReconciler = cnireconciler.New(ciliumreconciler.New(f.ciliumTemplater, []string{providerNamespace}))
+		f.cniReconciler = cnireconciler.New(
+			ciliumreconciler.New(f.ciliumTemplater, []string{providerNamespace}),
+			customcni.New(f.manager.GetClient(), f.helmClientFactory),
+			networkpolicy.New(),
+		)
 
 		return nil
 	})
@@ -684,6 +703,23 @@ func (f *Factory) WithNodeUpgradeReconciler() *Factory {
 	return f
 }
 
+// WithClusterUpgradePlanReconciler builds the ClusterUpgradePlan reconciler.
+func (f *Factory) WithClusterUpgradePlanReconciler() *Factory {
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.reconcilers.ClusterUpgradePlanReconciler != nil {
+			return nil
+		}
+
+		f.reconcilers.ClusterUpgradePlanReconciler = NewClusterUpgradePlanReconciler(
+			f.manager.GetClient(),
+		)
+
+		return nil
+	})
+
+	return f
+}
+
 func (f *Factory) getProviderNamespace(providerName string) string {
 	var providerNamespace string
 	switch providerName {
@@ -331,3 +331,23 @@ func TestFactoryWithMachineDeploymentUpgradeReconciler(t *testing.T) {
 	g.Expect(err).NotTo(HaveOccurred())
 	g.Expect(reconcilers.MachineDeploymentUpgradeReconciler).NotTo(BeNil())
 }
+
+func TestFactoryWithClusterUpgradePlanReconciler(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	logger := nullLog()
+	ctrl := gomock.NewController(t)
+	manager := mocks.NewMockManager(ctrl)
+	manager.EXPECT().GetClient().AnyTimes()
+	manager.EXPECT().GetScheme().AnyTimes()
+
+	f := controllers.NewFactory(logger, manager).
+		WithClusterUpgradePlanReconciler()
+
+	// testing idempotence
+	f.WithClusterUpgradePlanReconciler()
+
+	reconcilers, err := f.Build(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(reconcilers.ClusterUpgradePlanReconciler).NotTo(BeNil())
+}
@@ -217,10 +217,16 @@ func machineDeploymentUpgrade(md *clusterv1.MachineDeployment, machines []corev1
 	if err != nil {
 		return nil, fmt.Errorf("marshaling Machine spec: %v", err)
 	}
+	var annotations map[string]string
+	if isUpgradeCanary(md) {
+		// The canary MachineDeploymentUpgrade stops after the first node until the health check passes.
+		annotations = map[string]string{anywherev1.UpgradeCanaryAnnotation: "true"}
+	}
 	return &anywherev1.MachineDeploymentUpgrade{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      mdUpgradeName(md.ObjectMeta.Name),
-			Namespace: constants.EksaSystemNamespace,
+			Name:        mdUpgradeName(md.ObjectMeta.Name),
+			Namespace:   constants.EksaSystemNamespace,
+			Annotations: annotations,
 			OwnerReferences: []metav1.OwnerReference{{
 				APIVersion: clusterv1.GroupVersion.String(),
 				Kind:       machineDeploymentKind,
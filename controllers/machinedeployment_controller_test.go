@@ -104,6 +104,25 @@ func TestMDReconcileCreateMachineDeploymentUpgrade(t *testing.T) {
 	g.Expect(mhc.Annotations).To(HaveKey(capiPausedAnnotation))
 }
 
+func TestMDReconcileCreateCanaryMachineDeploymentUpgrade(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	mdObjs := getObjectsForMD()
+	mdObjs.md.Annotations[anywherev1.UpgradeCanaryAnnotation] = "true"
+
+	runtimeObjs := []runtime.Object{mdObjs.machine, mdObjs.md, mdObjs.mhc}
+	client := fake.NewClientBuilder().WithRuntimeObjects(runtimeObjs...).Build()
+	r := controllers.NewMachineDeploymentReconciler(client, client)
+	req := mdRequest(mdObjs.md)
+	_, err := r.Reconcile(ctx, req)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	mdu := &anywherev1.MachineDeploymentUpgrade{}
+	err = client.Get(ctx, types.NamespacedName{Name: mdObjs.mdUpgrade.Name, Namespace: constants.EksaSystemNamespace}, mdu)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(mdu.Annotations).To(HaveKeyWithValue(anywherev1.UpgradeCanaryAnnotation, "true"))
+}
+
 func TestMDReconcileMDAndMachineDeploymentUpgradeReady(t *testing.T) {
 	g := NewWithT(t)
 	ctx := context.Background()
@@ -30,6 +30,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -145,10 +146,19 @@ func (r *MachineDeploymentUpgradeReconciler) SetupWithManager(mgr ctrl.Manager)
 
 func (r *MachineDeploymentUpgradeReconciler) reconcile(ctx context.Context, log logr.Logger, mdUpgrade *anywherev1.MachineDeploymentUpgrade) (ctrl.Result, error) {
 	log.Info("Upgrading all worker nodes")
+	upgraded := 0
 	for _, machineRef := range mdUpgrade.Spec.MachinesRequireUpgrade {
 		nodeUpgrade, err := getNodeUpgrade(ctx, r.client, nodeUpgraderName(machineRef.Name))
 		if err != nil {
 			if apierrors.IsNotFound(err) {
+				if isUpgradePaused(mdUpgrade) {
+					log.Info("Upgrade is paused, skipping creation of node upgrader", "Machine", machineRef.Name)
+					return ctrl.Result{}, nil
+				}
+				if upgraded > 0 && isUpgradeCanary(mdUpgrade) {
+					log.Info("Canary node upgraded, waiting for its health check before upgrading the rest of the nodes", "Machine", machineRef.Name)
+					return ctrl.Result{}, nil
+				}
 				nodeUpgrade = mdNodeUpgrader(machineRef, mdUpgrade.Spec.KubernetesVersion)
 				if err := r.client.Create(ctx, nodeUpgrade); err != nil {
 					return ctrl.Result{}, fmt.Errorf("failed to create node upgrader for machine %s:  %v", machineRef.Name, err)
@@ -157,9 +167,14 @@ func (r *MachineDeploymentUpgradeReconciler) reconcile(ctx context.Context, log
 			}
 			return ctrl.Result{}, fmt.Errorf("getting node upgrader for machine %s: %v", machineRef.Name, err)
 		}
+		if conditions.IsTrue(nodeUpgrade, anywherev1.RolledBack) {
+			log.Info("Node upgrade was rolled back, halting worker nodes upgrade", "Machine", machineRef.Name)
+			return ctrl.Result{}, nil
+		}
 		if !nodeUpgrade.Status.Completed {
 			return ctrl.Result{}, nil
 		}
+		upgraded++
 	}
 
 	return ctrl.Result{}, nil
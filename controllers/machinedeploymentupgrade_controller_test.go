@@ -10,6 +10,7 @@ import (
 
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -141,6 +142,66 @@ func TestMDUpgradeReconcileNodeUpgraderCreate(t *testing.T) {
 	g.Expect(err).ToNot(HaveOccurred())
 }
 
+func TestMDUpgradeReconcilePaused(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cluster, machines, nodes, mdUpgrade, _, md, ms := getObjectsForMDUpgradeTest()
+	mdUpgrade.Annotations = map[string]string{anywherev1.UpgradePausedAnnotation: "true"}
+	client := fake.NewClientBuilder().WithRuntimeObjects(cluster, machines[0], machines[1], nodes[0], nodes[1], mdUpgrade, md, ms).
+		WithStatusSubresource(mdUpgrade).
+		Build()
+
+	r := controllers.NewMachineDeploymentUpgradeReconciler(client)
+	req := mdUpgradeRequest(mdUpgrade)
+	_, err := r.Reconcile(ctx, req)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	n := &anywherev1.NodeUpgrade{}
+	nodeUpgradeName := fmt.Sprintf("%s-node-upgrader", machines[0].Name)
+	err = client.Get(ctx, types.NamespacedName{Name: nodeUpgradeName, Namespace: constants.EksaSystemNamespace}, n)
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+}
+
+func TestMDUpgradeReconcileCanaryStopsAfterFirstNode(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cluster, machines, nodes, mdUpgrade, nodeUpgrades, md, ms := getObjectsForMDUpgradeTest()
+	mdUpgrade.Annotations = map[string]string{anywherev1.UpgradeCanaryAnnotation: "true"}
+	client := fake.NewClientBuilder().WithRuntimeObjects(cluster, machines[0], machines[1], nodes[0], nodes[1], mdUpgrade, nodeUpgrades[0], md, ms).
+		WithStatusSubresource(mdUpgrade).
+		Build()
+
+	r := controllers.NewMachineDeploymentUpgradeReconciler(client)
+	req := mdUpgradeRequest(mdUpgrade)
+	_, err := r.Reconcile(ctx, req)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	n := &anywherev1.NodeUpgrade{}
+	nodeUpgradeName := fmt.Sprintf("%s-node-upgrader", machines[1].Name)
+	err = client.Get(ctx, types.NamespacedName{Name: nodeUpgradeName, Namespace: constants.EksaSystemNamespace}, n)
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+}
+
+func TestMDUpgradeReconcileCanaryCreatesFirstNodeUpgrader(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cluster, machines, nodes, mdUpgrade, _, md, ms := getObjectsForMDUpgradeTest()
+	mdUpgrade.Annotations = map[string]string{anywherev1.UpgradeCanaryAnnotation: "true"}
+	client := fake.NewClientBuilder().WithRuntimeObjects(cluster, machines[0], machines[1], nodes[0], nodes[1], mdUpgrade, md, ms).
+		WithStatusSubresource(mdUpgrade).
+		Build()
+
+	r := controllers.NewMachineDeploymentUpgradeReconciler(client)
+	req := mdUpgradeRequest(mdUpgrade)
+	_, err := r.Reconcile(ctx, req)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	n := &anywherev1.NodeUpgrade{}
+	nodeUpgradeName := fmt.Sprintf("%s-node-upgrader", machines[0].Name)
+	err = client.Get(ctx, types.NamespacedName{Name: nodeUpgradeName, Namespace: constants.EksaSystemNamespace}, n)
+	g.Expect(err).ToNot(HaveOccurred())
+}
+
 func TestMDUpgradeObjectDoesNotExist(t *testing.T) {
 	g := NewWithT(t)
 	ctx := context.Background()
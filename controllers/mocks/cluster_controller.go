@@ -230,6 +230,234 @@ func (mr *MockMachineHealthCheckReconcilerMockRecorder) Reconcile(ctx, logger, c
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockMachineHealthCheckReconciler)(nil).Reconcile), ctx, logger, cluster)
 }
 
+// MockRegistryMirrorCredentialsReconciler is a mock of RegistryMirrorCredentialsReconciler interface.
+type MockRegistryMirrorCredentialsReconciler struct {
+	ctrl     *gomock.Controller
+	recorder *MockRegistryMirrorCredentialsReconcilerMockRecorder
+}
+
+// MockRegistryMirrorCredentialsReconcilerMockRecorder is the mock recorder for MockRegistryMirrorCredentialsReconciler.
+type MockRegistryMirrorCredentialsReconcilerMockRecorder struct {
+	mock *MockRegistryMirrorCredentialsReconciler
+}
+
+// NewMockRegistryMirrorCredentialsReconciler creates a new mock instance.
+func NewMockRegistryMirrorCredentialsReconciler(ctrl *gomock.Controller) *MockRegistryMirrorCredentialsReconciler {
+	mock := &MockRegistryMirrorCredentialsReconciler{ctrl: ctrl}
+	mock.recorder = &MockRegistryMirrorCredentialsReconcilerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRegistryMirrorCredentialsReconciler) EXPECT() *MockRegistryMirrorCredentialsReconcilerMockRecorder {
+	return m.recorder
+}
+
+// Reconcile mocks base method.
+func (m *MockRegistryMirrorCredentialsReconciler) Reconcile(ctx context.Context, logger logr.Logger, cluster *v1alpha1.Cluster) (controller.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reconcile", ctx, logger, cluster)
+	ret0, _ := ret[0].(controller.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reconcile indicates an expected call of Reconcile.
+func (mr *MockRegistryMirrorCredentialsReconcilerMockRecorder) Reconcile(ctx, logger, cluster interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockRegistryMirrorCredentialsReconciler)(nil).Reconcile), ctx, logger, cluster)
+}
+
+// MockAuthenticationConfigReconciler is a mock of AuthenticationConfigReconciler interface.
+type MockAuthenticationConfigReconciler struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthenticationConfigReconcilerMockRecorder
+}
+
+// MockAuthenticationConfigReconcilerMockRecorder is the mock recorder for MockAuthenticationConfigReconciler.
+type MockAuthenticationConfigReconcilerMockRecorder struct {
+	mock *MockAuthenticationConfigReconciler
+}
+
+// NewMockAuthenticationConfigReconciler creates a new mock instance.
+func NewMockAuthenticationConfigReconciler(ctrl *gomock.Controller) *MockAuthenticationConfigReconciler {
+	mock := &MockAuthenticationConfigReconciler{ctrl: ctrl}
+	mock.recorder = &MockAuthenticationConfigReconcilerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthenticationConfigReconciler) EXPECT() *MockAuthenticationConfigReconcilerMockRecorder {
+	return m.recorder
+}
+
+// Reconcile mocks base method.
+func (m *MockAuthenticationConfigReconciler) Reconcile(ctx context.Context, logger logr.Logger, cluster *v1alpha1.Cluster) (controller.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reconcile", ctx, logger, cluster)
+	ret0, _ := ret[0].(controller.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reconcile indicates an expected call of Reconcile.
+func (mr *MockAuthenticationConfigReconcilerMockRecorder) Reconcile(ctx, logger, cluster interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockAuthenticationConfigReconciler)(nil).Reconcile), ctx, logger, cluster)
+}
+
+// MockNodeConfigReconciler is a mock of NodeConfigReconciler interface.
+type MockNodeConfigReconciler struct {
+	ctrl     *gomock.Controller
+	recorder *MockNodeConfigReconcilerMockRecorder
+}
+
+// MockNodeConfigReconcilerMockRecorder is the mock recorder for MockNodeConfigReconciler.
+type MockNodeConfigReconcilerMockRecorder struct {
+	mock *MockNodeConfigReconciler
+}
+
+// NewMockNodeConfigReconciler creates a new mock instance.
+func NewMockNodeConfigReconciler(ctrl *gomock.Controller) *MockNodeConfigReconciler {
+	mock := &MockNodeConfigReconciler{ctrl: ctrl}
+	mock.recorder = &MockNodeConfigReconcilerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNodeConfigReconciler) EXPECT() *MockNodeConfigReconcilerMockRecorder {
+	return m.recorder
+}
+
+// Reconcile mocks base method.
+func (m *MockNodeConfigReconciler) Reconcile(ctx context.Context, logger logr.Logger, cluster *v1alpha1.Cluster) (controller.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reconcile", ctx, logger, cluster)
+	ret0, _ := ret[0].(controller.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reconcile indicates an expected call of Reconcile.
+func (mr *MockNodeConfigReconcilerMockRecorder) Reconcile(ctx, logger, cluster interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockNodeConfigReconciler)(nil).Reconcile), ctx, logger, cluster)
+}
+
+// MockUpgradeCanaryReconciler is a mock of UpgradeCanaryReconciler interface.
+type MockUpgradeCanaryReconciler struct {
+	ctrl     *gomock.Controller
+	recorder *MockUpgradeCanaryReconcilerMockRecorder
+}
+
+// MockUpgradeCanaryReconcilerMockRecorder is the mock recorder for MockUpgradeCanaryReconciler.
+type MockUpgradeCanaryReconcilerMockRecorder struct {
+	mock *MockUpgradeCanaryReconciler
+}
+
+// NewMockUpgradeCanaryReconciler creates a new mock instance.
+func NewMockUpgradeCanaryReconciler(ctrl *gomock.Controller) *MockUpgradeCanaryReconciler {
+	mock := &MockUpgradeCanaryReconciler{ctrl: ctrl}
+	mock.recorder = &MockUpgradeCanaryReconcilerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUpgradeCanaryReconciler) EXPECT() *MockUpgradeCanaryReconcilerMockRecorder {
+	return m.recorder
+}
+
+// Reconcile mocks base method.
+func (m *MockUpgradeCanaryReconciler) Reconcile(ctx context.Context, logger logr.Logger, cluster *v1alpha1.Cluster) (controller.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reconcile", ctx, logger, cluster)
+	ret0, _ := ret[0].(controller.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reconcile indicates an expected call of Reconcile.
+func (mr *MockUpgradeCanaryReconcilerMockRecorder) Reconcile(ctx, logger, cluster interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockUpgradeCanaryReconciler)(nil).Reconcile), ctx, logger, cluster)
+}
+
+// MockEtcdBackupReconciler is a mock of EtcdBackupReconciler interface.
+type MockEtcdBackupReconciler struct {
+	ctrl     *gomock.Controller
+	recorder *MockEtcdBackupReconcilerMockRecorder
+}
+
+// MockEtcdBackupReconcilerMockRecorder is the mock recorder for MockEtcdBackupReconciler.
+type MockEtcdBackupReconcilerMockRecorder struct {
+	mock *MockEtcdBackupReconciler
+}
+
+// NewMockEtcdBackupReconciler creates a new mock instance.
+func NewMockEtcdBackupReconciler(ctrl *gomock.Controller) *MockEtcdBackupReconciler {
+	mock := &MockEtcdBackupReconciler{ctrl: ctrl}
+	mock.recorder = &MockEtcdBackupReconcilerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEtcdBackupReconciler) EXPECT() *MockEtcdBackupReconcilerMockRecorder {
+	return m.recorder
+}
+
+// Reconcile mocks base method.
+func (m *MockEtcdBackupReconciler) Reconcile(ctx context.Context, logger logr.Logger, cluster *v1alpha1.Cluster) (controller.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reconcile", ctx, logger, cluster)
+	ret0, _ := ret[0].(controller.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reconcile indicates an expected call of Reconcile.
+func (mr *MockEtcdBackupReconcilerMockRecorder) Reconcile(ctx, logger, cluster interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockEtcdBackupReconciler)(nil).Reconcile), ctx, logger, cluster)
+}
+
+// MockAWSIamMappingsReconciler is a mock of AWSIamMappingsReconciler interface.
+type MockAWSIamMappingsReconciler struct {
+	ctrl     *gomock.Controller
+	recorder *MockAWSIamMappingsReconcilerMockRecorder
+}
+
+// MockAWSIamMappingsReconcilerMockRecorder is the mock recorder for MockAWSIamMappingsReconciler.
+type MockAWSIamMappingsReconcilerMockRecorder struct {
+	mock *MockAWSIamMappingsReconciler
+}
+
+// NewMockAWSIamMappingsReconciler creates a new mock instance.
+func NewMockAWSIamMappingsReconciler(ctrl *gomock.Controller) *MockAWSIamMappingsReconciler {
+	mock := &MockAWSIamMappingsReconciler{ctrl: ctrl}
+	mock.recorder = &MockAWSIamMappingsReconcilerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAWSIamMappingsReconciler) EXPECT() *MockAWSIamMappingsReconcilerMockRecorder {
+	return m.recorder
+}
+
+// ReconcileMappings mocks base method.
+func (m *MockAWSIamMappingsReconciler) ReconcileMappings(ctx context.Context, logger logr.Logger, cluster *v1alpha1.Cluster) (controller.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileMappings", ctx, logger, cluster)
+	ret0, _ := ret[0].(controller.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReconcileMappings indicates an expected call of ReconcileMappings.
+func (mr *MockAWSIamMappingsReconcilerMockRecorder) ReconcileMappings(ctx, logger, cluster interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileMappings", reflect.TypeOf((*MockAWSIamMappingsReconciler)(nil).ReconcileMappings), ctx, logger, cluster)
+}
+
 // MockClusterValidator is a mock of ClusterValidator interface.
 type MockClusterValidator struct {
 	ctrl     *gomock.Controller
@@ -51,3 +51,18 @@ func (mr *MockProviderClusterReconcilerMockRecorder) Reconcile(ctx, log, cluster
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockProviderClusterReconciler)(nil).Reconcile), ctx, log, cluster)
 }
+
+// UpgradeNeeded mocks base method.
+func (m *MockProviderClusterReconciler) UpgradeNeeded(ctx context.Context, log logr.Logger, cluster *v1alpha1.Cluster) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpgradeNeeded", ctx, log, cluster)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpgradeNeeded indicates an expected call of UpgradeNeeded.
+func (mr *MockProviderClusterReconcilerMockRecorder) UpgradeNeeded(ctx, log, cluster interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpgradeNeeded", reflect.TypeOf((*MockProviderClusterReconciler)(nil).UpgradeNeeded), ctx, log, cluster)
+}
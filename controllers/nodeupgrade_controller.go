@@ -28,6 +28,11 @@ import (
 const (
 	controlPlaneLabel = "node-role.kubernetes.io/control-plane"
 	podDNEMessage     = "Upgrader pod does not exist"
+	pausedMessage     = "Upgrade is paused"
+
+	// upgraderMaxRestarts is the number of times an upgrade container can be restarted
+	// before the upgrade is considered failed and the node components are rolled back.
+	upgraderMaxRestarts = 3
 
 	// nodeUpgradeFinalizerName is the finalizer added to NodeUpgrade objects to handle deletion.
 	nodeUpgradeFinalizerName = "nodeupgrades.anywhere.eks.amazonaws.com/finalizer"
@@ -161,13 +166,41 @@ func (r *NodeUpgradeReconciler) reconcile(ctx context.Context, log logr.Logger,
 		return ctrl.Result{}, nil
 	}
 
-	log.Info("Upgrading node", "Node", node.Name)
-	upgraderPod := &corev1.Pod{}
-	if conditions.IsTrue(nodeUpgrade, anywherev1.UpgraderPodCreated) || upgraderPodExists(ctx, remoteClient, node.Name) {
-		log.Info("Upgrader pod already exists, skipping creation of the pod", "Pod", upgrader.PodName(node.Name))
+	if conditions.IsTrue(nodeUpgrade, anywherev1.RolledBack) {
+		log.Info("Node upgrade was rolled back", "Node", node.Name)
+		return ctrl.Result{}, nil
+	}
+
+	if rollbackPodExists(ctx, remoteClient, node.Name) {
+		log.Info("Rolling back node components", "Node", node.Name)
+		return ctrl.Result{}, nil
+	}
+
+	pod, err := getUpgraderPod(ctx, remoteClient, node.Name)
+	if err == nil {
+		if upgraderPodFailed(pod) {
+			return r.rollback(ctx, log, nodeUpgrade, pod, remoteClient)
+		}
+		log.Info("Upgrader pod already exists, skipping creation of the pod", "Pod", pod.Name)
+		return ctrl.Result{}, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return ctrl.Result{}, fmt.Errorf("getting upgrader pod: %v", err)
+	}
+
+	if conditions.IsTrue(nodeUpgrade, anywherev1.UpgraderPodCreated) {
+		log.Info("Upgrader pod was already created, skipping creation of the pod", "Pod", upgrader.PodName(node.Name))
+		return ctrl.Result{}, nil
+	}
+
+	if isUpgradePaused(nodeUpgrade) {
+		log.Info("Upgrade is paused, skipping creation of the upgrader pod", "Node", node.Name)
 		return ctrl.Result{}, nil
 	}
 
+	log.Info("Upgrading node", "Node", node.Name)
+	upgraderPod := &corev1.Pod{}
+
 	configMap := &corev1.ConfigMap{}
 	if err := r.client.Get(ctx, types.NamespacedName{Name: constants.UpgraderConfigMapName, Namespace: constants.EksaSystemNamespace}, configMap); err != nil {
 		return ctrl.Result{}, err
@@ -197,6 +230,51 @@ func (r *NodeUpgradeReconciler) reconcile(ctx context.Context, log logr.Logger,
 	return ctrl.Result{}, nil
 }
 
+// rollback replaces a failed upgrader pod with a pod that restores the host components snapshotted before the upgrade.
+func (r *NodeUpgradeReconciler) rollback(ctx context.Context, log logr.Logger, nodeUpgrade *anywherev1.NodeUpgrade, upgraderPod *corev1.Pod, remoteClient client.Client) (ctrl.Result, error) {
+	if !conditions.IsTrue(nodeUpgrade, anywherev1.ComponentsSnapshotted) {
+		log.Info("Upgrade failed before the node components were snapshotted, skipping rollback", "Pod", upgraderPod.Name)
+		return ctrl.Result{}, nil
+	}
+
+	if len(upgraderPod.Spec.InitContainers) == 0 {
+		return ctrl.Result{}, fmt.Errorf("upgrader pod %s has no init containers", upgraderPod.Name)
+	}
+	image := upgraderPod.Spec.InitContainers[0].Image
+
+	log.Info("Upgrade failed, rolling back node components", "Node", upgraderPod.Spec.NodeName)
+	if err := remoteClient.Delete(ctx, upgraderPod); client.IgnoreNotFound(err) != nil {
+		return ctrl.Result{}, fmt.Errorf("deleting upgrader pod: %v", err)
+	}
+
+	if err := remoteClient.Create(ctx, upgrader.RollbackPod(upgraderPod.Spec.NodeName, image)); client.IgnoreAlreadyExists(err) != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to create the rollback pod on node %s: %v", upgraderPod.Spec.NodeName, err)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// upgraderPodFailed returns true if the upgrader pod failed or if any of the containers upgrading
+// the node components kept failing after being restarted.
+func upgraderPodFailed(pod *corev1.Pod) bool {
+	if pod.Status.Phase == corev1.PodFailed {
+		return true
+	}
+
+	for _, name := range []string{
+		upgrader.ContainerdUpgraderContainerName,
+		upgrader.CNIPluginsUpgraderContainerName,
+		upgrader.KubeadmUpgraderContainerName,
+		upgrader.KubeletUpgradeContainerName,
+	} {
+		status, err := getContainerStatus(pod, name)
+		if err == nil && status.RestartCount >= upgraderMaxRestarts {
+			return true
+		}
+	}
+	return false
+}
+
 // namespaceOrCreate creates a namespace if it doesn't already exist.
 func namespaceOrCreate(ctx context.Context, client client.Client, log logr.Logger, namespace string) error {
 	ns := &corev1.Namespace{}
@@ -237,6 +315,18 @@ func (r *NodeUpgradeReconciler) reconcileDelete(ctx context.Context, log logr.Lo
 		}
 	}
 
+	rollbackPod, err := getRollbackPod(ctx, remoteClient, nodeName)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, fmt.Errorf("getting rollback pod: %v", err)
+		}
+	} else {
+		log.Info("Deleting rollback pod", "Pod", rollbackPod.Name, "Namespace", rollbackPod.Namespace)
+		if err := remoteClient.Delete(ctx, rollbackPod); err != nil {
+			return ctrl.Result{}, fmt.Errorf("deleting rollback pod: %v", err)
+		}
+	}
+
 	// Remove the finalizer from NodeUpgrade object
 	controllerutil.RemoveFinalizer(nodeUpgrade, nodeUpgradeFinalizerName)
 	return ctrl.Result{}, nil
@@ -252,8 +342,22 @@ func (r *NodeUpgradeReconciler) updateStatus(ctx context.Context, log logr.Logge
 
 	log.Info("Updating NodeUpgrade status")
 
+	rollbackPod, err := getRollbackPod(ctx, remoteClient, nodeName)
+	if err == nil {
+		updateRollbackCondition(rollbackPod, nodeUpgrade)
+		return nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return fmt.Errorf("getting rollback pod: %v", err)
+	}
+
 	pod, err := getUpgraderPod(ctx, remoteClient, nodeName)
 	if err != nil {
+		if apierrors.IsNotFound(err) && isUpgradePaused(nodeUpgrade) && !conditions.IsTrue(nodeUpgrade, anywherev1.UpgraderPodCreated) {
+			markAllConditionsFalse(nodeUpgrade, pausedMessage, clusterv1.ConditionSeverityInfo)
+			conditions.MarkFalse(nodeUpgrade, anywherev1.ReadyCondition, pausedMessage, clusterv1.ConditionSeverityInfo, "")
+			return nil
+		}
 		if apierrors.IsNotFound(err) {
 			markAllConditionsFalse(nodeUpgrade, podDNEMessage, clusterv1.ConditionSeverityInfo)
 		} else {
@@ -270,6 +374,7 @@ func (r *NodeUpgradeReconciler) updateStatus(ctx context.Context, log logr.Logge
 		conditions.WithConditions(
 			anywherev1.UpgraderPodCreated,
 			anywherev1.BinariesCopied,
+			anywherev1.ComponentsSnapshotted,
 			anywherev1.ContainerdUpgraded,
 			anywherev1.CNIPluginsUpgraded,
 			anywherev1.KubeadmUpgraded,
@@ -280,6 +385,30 @@ func (r *NodeUpgradeReconciler) updateStatus(ctx context.Context, log logr.Logge
 	return nil
 }
 
+// updateRollbackCondition sets the RolledBack condition from the state of the rollback pod.
+// The node upgrade is never marked as ready once a rollback has started.
+func updateRollbackCondition(pod *corev1.Pod, nodeUpgrade *anywherev1.NodeUpgrade) {
+	nodeUpgrade.Status.Completed = false
+
+	status, err := getContainerStatus(pod, upgrader.RollbackContainerName)
+	switch {
+	case err != nil:
+		conditions.MarkFalse(nodeUpgrade, anywherev1.RolledBack, "Container status not available yet", clusterv1.ConditionSeverityWarning, "")
+	case status.State.Terminated != nil && status.State.Terminated.ExitCode == 0:
+		conditions.MarkTrue(nodeUpgrade, anywherev1.RolledBack)
+	case status.State.Terminated != nil:
+		conditions.MarkFalse(nodeUpgrade, anywherev1.RolledBack, fmt.Sprintf("Container exited with a non-zero exit code, reason: %s", status.State.Terminated.Reason), clusterv1.ConditionSeverityError, "")
+	default:
+		conditions.MarkFalse(nodeUpgrade, anywherev1.RolledBack, "Rolling back node components", clusterv1.ConditionSeverityInfo, "")
+	}
+
+	if conditions.IsTrue(nodeUpgrade, anywherev1.RolledBack) {
+		conditions.MarkFalse(nodeUpgrade, anywherev1.ReadyCondition, "Node components were rolled back after a failed upgrade", clusterv1.ConditionSeverityWarning, "")
+		return
+	}
+	conditions.MarkFalse(nodeUpgrade, anywherev1.ReadyCondition, "Upgrade failed, rolling back node components", clusterv1.ConditionSeverityWarning, "")
+}
+
 func updateComponentsConditions(pod *corev1.Pod, nodeUpgrade *anywherev1.NodeUpgrade) {
 	containersMap := []struct {
 		name      string
@@ -289,6 +418,10 @@ func updateComponentsConditions(pod *corev1.Pod, nodeUpgrade *anywherev1.NodeUpg
 			name:      upgrader.CopierContainerName,
 			condition: anywherev1.BinariesCopied,
 		},
+		{
+			name:      upgrader.SnapshotContainerName,
+			condition: anywherev1.ComponentsSnapshotted,
+		},
 		{
 			name:      upgrader.ContainerdUpgraderContainerName,
 			condition: anywherev1.ContainerdUpgraded,
@@ -358,6 +491,7 @@ func getContainerStatus(pod *corev1.Pod, containerName string) (*corev1.Containe
 func markAllConditionsFalse(nodeUpgrade *anywherev1.NodeUpgrade, message string, severity clusterv1.ConditionSeverity) {
 	conditions.MarkFalse(nodeUpgrade, anywherev1.UpgraderPodCreated, message, clusterv1.ConditionSeverityError, "")
 	conditions.MarkFalse(nodeUpgrade, anywherev1.BinariesCopied, message, clusterv1.ConditionSeverityError, "")
+	conditions.MarkFalse(nodeUpgrade, anywherev1.ComponentsSnapshotted, message, clusterv1.ConditionSeverityError, "")
 	conditions.MarkFalse(nodeUpgrade, anywherev1.ContainerdUpgraded, message, clusterv1.ConditionSeverityError, "")
 	conditions.MarkFalse(nodeUpgrade, anywherev1.CNIPluginsUpgraded, message, clusterv1.ConditionSeverityError, "")
 	conditions.MarkFalse(nodeUpgrade, anywherev1.KubeadmUpgraded, message, clusterv1.ConditionSeverityError, "")
@@ -384,10 +518,12 @@ func patchNodeUpgrade(ctx context.Context, patchHelper *patch.Helper, nodeUpgrad
 			// Add each condition her that the controller should ignored conflicts for.
 			anywherev1.UpgraderPodCreated,
 			anywherev1.BinariesCopied,
+			anywherev1.ComponentsSnapshotted,
 			anywherev1.ContainerdUpgraded,
 			anywherev1.CNIPluginsUpgraded,
 			anywherev1.KubeadmUpgraded,
 			anywherev1.KubeletUpgraded,
+			anywherev1.RolledBack,
 		}},
 	}, patchOpts...)
 
@@ -395,8 +531,8 @@ func patchNodeUpgrade(ctx context.Context, patchHelper *patch.Helper, nodeUpgrad
 	return patchHelper.Patch(ctx, &nodeUpgrade, options...)
 }
 
-func upgraderPodExists(ctx context.Context, remoteClient client.Client, nodeName string) bool {
-	_, err := getUpgraderPod(ctx, remoteClient, nodeName)
+func rollbackPodExists(ctx context.Context, remoteClient client.Client, nodeName string) bool {
+	_, err := getRollbackPod(ctx, remoteClient, nodeName)
 	return err == nil
 }
 
@@ -408,6 +544,23 @@ func getUpgraderPod(ctx context.Context, remoteClient client.Client, nodeName st
 	return pod, nil
 }
 
+func getRollbackPod(ctx context.Context, remoteClient client.Client, nodeName string) (*corev1.Pod, error) {
+	pod := &corev1.Pod{}
+	if err := remoteClient.Get(ctx, GetNamespacedNameType(upgrader.RollbackPodName(nodeName), constants.EksaSystemNamespace), pod); err != nil {
+		return nil, err
+	}
+	return pod, nil
+}
+
+// isUpgradePaused returns true if the upgrade paused annotation is set on an in-place upgrade object.
+func isUpgradePaused(obj client.Object) bool {
+	return obj.GetAnnotations()[anywherev1.UpgradePausedAnnotation] == "true"
+}
+
+func isUpgradeCanary(obj client.Object) bool {
+	return obj.GetAnnotations()[anywherev1.UpgradeCanaryAnnotation] == "true"
+}
+
 func getNodeUpgrade(ctx context.Context, remoteClient client.Client, nodeUpgradeName string) (*anywherev1.NodeUpgrade, error) {
 	n := &anywherev1.NodeUpgrade{}
 	if err := remoteClient.Get(ctx, GetNamespacedNameType(nodeUpgradeName, constants.EksaSystemNamespace), n); err != nil {
@@ -11,6 +11,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -161,6 +162,118 @@ func TestNodeUpgradeReconcilerReconcileCreateUpgraderPodState(t *testing.T) {
 	g.Expect(err).ToNot(HaveOccurred())
 }
 
+func TestNodeUpgradeReconcilerReconcilePaused(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	clientRegistry := mocks.NewMockRemoteClientRegistry(ctrl)
+
+	cluster, machine, node, nodeUpgrade, configMap := getObjectsForNodeUpgradeTest()
+	nodeUpgrade.Annotations = map[string]string{anywherev1.UpgradePausedAnnotation: "true"}
+	client := fake.NewClientBuilder().WithRuntimeObjects(cluster, machine, node, nodeUpgrade, configMap).
+		WithStatusSubresource(nodeUpgrade).
+		Build()
+
+	clientRegistry.EXPECT().GetClient(ctx, types.NamespacedName{Name: cluster.Name, Namespace: cluster.Namespace}).Return(client, nil).Times(2)
+
+	r := controllers.NewNodeUpgradeReconciler(client, clientRegistry)
+	req := nodeUpgradeRequest(nodeUpgrade)
+	_, err := r.Reconcile(ctx, req)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	pod := &corev1.Pod{}
+	err = client.Get(ctx, types.NamespacedName{Name: upgrader.PodName(node.Name), Namespace: "eksa-system"}, pod)
+	g.Expect(err).To(MatchError("pods \"node01-node-upgrader\" not found"))
+
+	n := &anywherev1.NodeUpgrade{}
+	g.Expect(client.Get(ctx, req.NamespacedName, n)).To(Succeed())
+	g.Expect(conditions.GetReason(n, anywherev1.ReadyCondition)).To(Equal("Upgrade is paused"))
+
+	delete(n.Annotations, anywherev1.UpgradePausedAnnotation)
+	g.Expect(client.Update(ctx, n)).To(Succeed())
+
+	_, err = r.Reconcile(ctx, req)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(client.Get(ctx, types.NamespacedName{Name: upgrader.PodName(node.Name), Namespace: "eksa-system"}, pod)).To(Succeed())
+}
+
+func TestNodeUpgradeReconcilerReconcileRollback(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	clientRegistry := mocks.NewMockRemoteClientRegistry(ctrl)
+
+	cluster, machine, node, nodeUpgrade, configMap := getObjectsForNodeUpgradeTest()
+	client := fake.NewClientBuilder().WithRuntimeObjects(cluster, machine, node, nodeUpgrade, configMap).
+		WithStatusSubresource(nodeUpgrade).
+		Build()
+
+	clientRegistry.EXPECT().GetClient(ctx, types.NamespacedName{Name: cluster.Name, Namespace: cluster.Namespace}).Return(client, nil).Times(4)
+
+	r := controllers.NewNodeUpgradeReconciler(client, clientRegistry)
+	req := nodeUpgradeRequest(nodeUpgrade)
+	_, err := r.Reconcile(ctx, req)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	pod := &corev1.Pod{}
+	g.Expect(client.Get(ctx, types.NamespacedName{Name: upgrader.PodName(node.Name), Namespace: "eksa-system"}, pod)).To(Succeed())
+
+	pod.Status.InitContainerStatuses = []corev1.ContainerStatus{
+		{
+			Name: upgrader.CopierContainerName,
+			State: corev1.ContainerState{
+				Terminated: &corev1.ContainerStateTerminated{ExitCode: 0},
+			},
+		},
+		{
+			Name: upgrader.SnapshotContainerName,
+			State: corev1.ContainerState{
+				Terminated: &corev1.ContainerStateTerminated{ExitCode: 0},
+			},
+		},
+		{
+			Name: upgrader.ContainerdUpgraderContainerName,
+			State: corev1.ContainerState{
+				Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+			},
+			RestartCount: 3,
+		},
+	}
+	g.Expect(client.Status().Update(ctx, pod)).To(Succeed())
+
+	// The first reconcile records the snapshot, the second one starts the rollback.
+	_, err = r.Reconcile(ctx, req)
+	g.Expect(err).ToNot(HaveOccurred())
+	_, err = r.Reconcile(ctx, req)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	err = client.Get(ctx, types.NamespacedName{Name: upgrader.PodName(node.Name), Namespace: "eksa-system"}, pod)
+	g.Expect(err).To(MatchError("pods \"node01-node-upgrader\" not found"))
+
+	rollbackPod := &corev1.Pod{}
+	g.Expect(client.Get(ctx, types.NamespacedName{Name: upgrader.RollbackPodName(node.Name), Namespace: "eksa-system"}, rollbackPod)).To(Succeed())
+	g.Expect(rollbackPod.Spec.Containers[0].Image).To(Equal("test"))
+
+	rollbackPod.Status.ContainerStatuses = []corev1.ContainerStatus{
+		{
+			Name: upgrader.RollbackContainerName,
+			State: corev1.ContainerState{
+				Terminated: &corev1.ContainerStateTerminated{ExitCode: 0},
+			},
+		},
+	}
+	g.Expect(client.Status().Update(ctx, rollbackPod)).To(Succeed())
+
+	_, err = r.Reconcile(ctx, req)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	n := &anywherev1.NodeUpgrade{}
+	g.Expect(client.Get(ctx, req.NamespacedName, n)).To(Succeed())
+	g.Expect(conditions.IsTrue(n, anywherev1.RolledBack)).To(BeTrue())
+	g.Expect(conditions.IsFalse(n, anywherev1.ReadyCondition)).To(BeTrue())
+	g.Expect(n.Status.Completed).To(BeFalse())
+}
+
 func TestNodeUpgradeReconcilerReconcileDelete(t *testing.T) {
 	g := NewWithT(t)
 	ctx := context.Background()
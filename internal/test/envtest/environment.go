@@ -37,6 +37,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	tinkerbellv1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1/thirdparty/tinkerbell/capt/v1beta1"
 	rufiov1alpha1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1/thirdparty/tinkerbell/rufio"
@@ -75,6 +76,7 @@ func init() {
 	utilruntime.Must(tinkerbellv1.AddToScheme(scheme.Scheme))
 	utilruntime.Must(tinkv1alpha1.AddToScheme(scheme.Scheme))
 	utilruntime.Must(rufiov1alpha1.AddToScheme(scheme.Scheme))
+	utilruntime.Must(packagesv1.AddToScheme(scheme.Scheme))
 }
 
 var packages = []moduleWithCRD{
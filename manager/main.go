@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
 	"os"
 
 	eksdv1alpha1 "github.com/aws/eks-distro-build-tooling/release/api/v1alpha1"
@@ -31,12 +32,14 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
 	"github.com/aws/eks-anywhere/controllers"
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	tinkerbellv1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1/thirdparty/tinkerbell/capt/v1beta1"
 	rufiov1alpha1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1/thirdparty/tinkerbell/rufio"
 	"github.com/aws/eks-anywhere/pkg/clusterapi"
 	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
+	"github.com/aws/eks-anywhere/pkg/etcdbackup"
 	"github.com/aws/eks-anywhere/pkg/features"
 	snowv1 "github.com/aws/eks-anywhere/pkg/providers/snow/api/v1beta1"
 	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
@@ -65,6 +68,7 @@ func init() {
 	utilruntime.Must(tinkv1alpha1.AddToScheme(scheme))
 	utilruntime.Must(rufiov1alpha1.AddToScheme(scheme))
 	utilruntime.Must(nutanixv1.AddToScheme(scheme))
+	utilruntime.Must(packagesv1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 }
 
@@ -98,6 +102,15 @@ func initFlags(fs *pflag.FlagSet, config *config) {
 }
 
 func main() {
+	// The etcd backup CronJob runs the controller image to save the etcd snapshots.
+	if len(os.Args) > 1 && os.Args[1] == etcdbackup.StoreCommand {
+		if err := etcdbackup.RunStoreCommand(context.Background(), os.Stdout, os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error saving etcd snapshot: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	config := newConfig()
 	pflag.CommandLine.AddGoFlagSet(flag.CommandLine)
 	initFlags(pflag.CommandLine, config)
@@ -186,7 +199,8 @@ func setupReconcilers(ctx context.Context, setupLog logr.Logger, mgr ctrl.Manage
 		WithMachineDeploymentReconciler().
 		WithControlPlaneUpgradeReconciler().
 		WithMachineDeploymentUpgradeReconciler().
-		WithNodeUpgradeReconciler()
+		WithNodeUpgradeReconciler().
+		WithClusterUpgradePlanReconciler()
 
 	reconcilers, err := factory.Build(ctx)
 	if err != nil {
@@ -254,6 +268,12 @@ func setupReconcilers(ctx context.Context, setupLog logr.Logger, mgr ctrl.Manage
 		failed = true
 	}
 
+	setupLog.Info("Setting up clusterupgradeplan controller")
+	if err := (reconcilers.ClusterUpgradePlanReconciler).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", anywherev1.ClusterUpgradePlanKind)
+		failed = true
+	}
+
 	if failed {
 		if err := factory.Close(ctx); err != nil {
 			setupLog.Error(err, "Failed closing controller factory")
@@ -286,6 +306,10 @@ func setupCoreWebhooks(setupLog logr.Logger, mgr ctrl.Manager) {
 		setupLog.Error(err, "unable to create webhook", WEBHOOK, anywherev1.FluxConfigKind)
 		os.Exit(1)
 	}
+	if err := (&anywherev1.ArgoCDConfig{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", WEBHOOK, anywherev1.ArgoCDConfigKind)
+		os.Exit(1)
+	}
 	if err := (&anywherev1.OIDCConfig{}).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", WEBHOOK, anywherev1.OIDCConfigKind)
 		os.Exit(1)
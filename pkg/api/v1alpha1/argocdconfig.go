@@ -0,0 +1,65 @@
+package v1alpha1
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+const (
+	ArgoCDConfigKind = "ArgoCDConfig"
+
+	// ArgoCDDefaultNamespace is the namespace Argo CD is installed in when none is specified.
+	ArgoCDDefaultNamespace = "argocd"
+	// ArgoCDDefaultVersion is the Argo CD version installed when no install manifest is specified.
+	ArgoCDDefaultVersion = "v2.13.2"
+	// ArgoCDDefaultInstallManifestUrl is the upstream install manifest for ArgoCDDefaultVersion.
+	ArgoCDDefaultInstallManifestUrl = "https://raw.githubusercontent.com/argoproj/argo-cd/" + ArgoCDDefaultVersion + "/manifests/install.yaml"
+)
+
+func validateArgoCDConfig(config *ArgoCDConfig) error {
+	if config.Spec.Git == nil {
+		return errors.New("must specify a provider. Valid options are git")
+	}
+
+	if err := validateGitProviderConfig(*config.Spec.Git); err != nil {
+		return err
+	}
+
+	if len(config.Spec.Branch) > 0 {
+		if err := validateGitBranchName(config.Spec.Branch); err != nil {
+			return err
+		}
+	}
+
+	if len(config.Spec.InstallManifestUrl) > 0 {
+		u, err := url.Parse(config.Spec.InstallManifestUrl)
+		if err != nil {
+			return fmt.Errorf("unable to parse installManifestUrl: %v", err)
+		}
+		if u.Scheme != "https" && u.Scheme != "http" {
+			return fmt.Errorf("invalid installManifestUrl scheme: %v", u.Scheme)
+		}
+	}
+
+	return nil
+}
+
+func setArgoCDConfigDefaults(argo *ArgoCDConfig) {
+	if argo == nil {
+		return
+	}
+
+	c := &argo.Spec
+	if len(c.SystemNamespace) == 0 {
+		c.SystemNamespace = ArgoCDDefaultNamespace
+	}
+
+	if len(c.Branch) == 0 {
+		c.Branch = FluxDefaultBranch
+	}
+
+	if len(c.InstallManifestUrl) == 0 {
+		c.InstallManifestUrl = ArgoCDDefaultInstallManifestUrl
+	}
+}
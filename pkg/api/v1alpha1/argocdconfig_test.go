@@ -0,0 +1,95 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestValidateArgoCDConfig(t *testing.T) {
+	tests := []struct {
+		testName string
+		spec     ArgoCDConfigSpec
+		wantErr  string
+	}{
+		{
+			testName: "valid git",
+			spec: ArgoCDConfigSpec{
+				Git: &GitProviderConfig{
+					RepositoryUrl:   "ssh://git@github.com/username/repo.git",
+					SshKeyAlgorithm: EcdsaAlgorithm,
+				},
+			},
+		},
+		{
+			testName: "no provider",
+			spec:     ArgoCDConfigSpec{},
+			wantErr:  "must specify a provider",
+		},
+		{
+			testName: "invalid repository url",
+			spec: ArgoCDConfigSpec{
+				Git: &GitProviderConfig{
+					RepositoryUrl: "https://github.com/username/repo.git",
+				},
+			},
+			wantErr: "invalid repository url scheme: https",
+		},
+		{
+			testName: "invalid branch",
+			spec: ArgoCDConfigSpec{
+				Branch: "main/../../x",
+				Git: &GitProviderConfig{
+					RepositoryUrl: "ssh://git@github.com/username/repo.git",
+				},
+			},
+			wantErr: "is not a valid git branch name",
+		},
+		{
+			testName: "invalid install manifest url",
+			spec: ArgoCDConfigSpec{
+				InstallManifestUrl: "file:///tmp/install.yaml",
+				Git: &GitProviderConfig{
+					RepositoryUrl: "ssh://git@github.com/username/repo.git",
+				},
+			},
+			wantErr: "invalid installManifestUrl scheme: file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := NewWithT(t)
+			c := &ArgoCDConfig{Spec: tt.spec}
+			err := c.Validate()
+			if tt.wantErr == "" {
+				g.Expect(err).To(Succeed())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestArgoCDConfigSetDefaults(t *testing.T) {
+	g := NewWithT(t)
+	c := &ArgoCDConfig{}
+	c.SetDefaults()
+
+	g.Expect(c.Spec).To(Equal(ArgoCDConfigSpec{
+		SystemNamespace:    ArgoCDDefaultNamespace,
+		Branch:             FluxDefaultBranch,
+		InstallManifestUrl: ArgoCDDefaultInstallManifestUrl,
+	}))
+}
+
+func TestArgoCDConfigSpecEqual(t *testing.T) {
+	g := NewWithT(t)
+	a := &ArgoCDConfigSpec{Branch: "main", Git: &GitProviderConfig{RepositoryUrl: "ssh://git@host/o/r.git"}}
+	b := a.DeepCopy()
+	g.Expect(a.Equal(b)).To(BeTrue())
+
+	b.Git.RepositoryUrl = "ssh://git@host/o/other.git"
+	g.Expect(a.Equal(b)).To(BeFalse())
+	g.Expect(a.Equal(nil)).To(BeFalse())
+}
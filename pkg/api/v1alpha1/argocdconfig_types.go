@@ -0,0 +1,139 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ArgoCDConfigSpec defines the desired state of ArgoCDConfig.
+type ArgoCDConfigSpec struct {
+	// SystemNamespace where Argo CD is installed. Defaults to argocd
+	SystemNamespace string `json:"systemNamespace,omitempty"`
+
+	// ClusterConfigPath relative to the repository root, when specified the cluster sync will be scoped to this path.
+	ClusterConfigPath string `json:"clusterConfigPath,omitempty"`
+
+	// Git branch. Defaults to main.
+	// +kubebuilder:default:="main"
+	Branch string `json:"branch,omitempty"`
+
+	// InstallManifestUrl is the location of the Argo CD install manifest applied to the management cluster.
+	// Defaults to the upstream manifest of the Argo CD version supported by EKS Anywhere.
+	InstallManifestUrl string `json:"installManifestUrl,omitempty"`
+
+	// Used to specify Git provider that will be used to host the git files
+	Git *GitProviderConfig `json:"git,omitempty"`
+}
+
+// ArgoCDConfigStatus defines the observed state of ArgoCDConfig.
+type ArgoCDConfigStatus struct{}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// ArgoCDConfig is the Schema for the argocdconfigs API and defines the configurations of the Argo CD GitOps engine and
+// Git repository it links to.
+type ArgoCDConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ArgoCDConfigSpec   `json:"spec,omitempty"`
+	Status ArgoCDConfigStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:generate=false
+// Same as ArgoCDConfig except stripped down for generation of yaml file while writing to the git repo.
+type ArgoCDConfigGenerate struct {
+	metav1.TypeMeta `json:",inline"`
+	ObjectMeta      `json:"metadata,omitempty"`
+
+	Spec ArgoCDConfigSpec `json:"spec,omitempty"`
+}
+
+// Equal checks if two ArgoCDConfigSpecs are equal.
+func (e *ArgoCDConfigSpec) Equal(n *ArgoCDConfigSpec) bool {
+	if e == n {
+		return true
+	}
+	if e == nil || n == nil {
+		return false
+	}
+	if e.SystemNamespace != n.SystemNamespace {
+		return false
+	}
+	if e.Branch != n.Branch {
+		return false
+	}
+	if e.ClusterConfigPath != n.ClusterConfigPath {
+		return false
+	}
+	if e.InstallManifestUrl != n.InstallManifestUrl {
+		return false
+	}
+	return e.Git.Equal(n.Git)
+}
+
+//+kubebuilder:object:root=true
+
+// ArgoCDConfigList contains a list of ArgoCDConfig.
+type ArgoCDConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ArgoCDConfig `json:"items"`
+}
+
+// Kind returns the kind of the object.
+func (c *ArgoCDConfig) Kind() string {
+	return c.TypeMeta.Kind
+}
+
+// ExpectedKind returns the kind the object should have.
+func (c *ArgoCDConfig) ExpectedKind() string {
+	return ArgoCDConfigKind
+}
+
+// ConvertConfigToConfigGenerateStruct converts an ArgoCDConfig to the struct used to write it to the git repo.
+func (c *ArgoCDConfig) ConvertConfigToConfigGenerateStruct() *ArgoCDConfigGenerate {
+	namespace := defaultEksaNamespace
+	if c.Namespace != "" {
+		namespace = c.Namespace
+	}
+	config := &ArgoCDConfigGenerate{
+		TypeMeta: c.TypeMeta,
+		ObjectMeta: ObjectMeta{
+			Name:        c.Name,
+			Annotations: c.Annotations,
+			Namespace:   namespace,
+		},
+		Spec: c.Spec,
+	}
+
+	return config
+}
+
+// Validate validates the ArgoCDConfig.
+func (c *ArgoCDConfig) Validate() error {
+	return validateArgoCDConfig(c)
+}
+
+// SetDefaults sets the default values of the ArgoCDConfig.
+func (c *ArgoCDConfig) SetDefaults() {
+	setArgoCDConfigDefaults(c)
+}
+
+func init() {
+	SchemeBuilder.Register(&ArgoCDConfig{}, &ArgoCDConfigList{})
+}
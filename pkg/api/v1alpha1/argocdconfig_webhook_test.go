@@ -0,0 +1,73 @@
+package v1alpha1_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+func argoCDConfig() v1alpha1.ArgoCDConfig {
+	return v1alpha1.ArgoCDConfig{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       v1alpha1.ArgoCDConfigKind,
+			APIVersion: v1alpha1.SchemeBuilder.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "argocdconfig",
+			Namespace: "default",
+		},
+		Spec: v1alpha1.ArgoCDConfigSpec{
+			Branch: "main",
+			Git: &v1alpha1.GitProviderConfig{
+				RepositoryUrl: "ssh://git@github.com/username/repo.git",
+			},
+		},
+	}
+}
+
+func TestArgoCDConfigValidateCreate(t *testing.T) {
+	g := NewWithT(t)
+	c := argoCDConfig()
+	g.Expect(c.ValidateCreate()).Error().To(Succeed())
+
+	c.Spec.Git = nil
+	g.Expect(c.ValidateCreate()).Error().To(MatchError(ContainSubstring("must specify a provider")))
+}
+
+func TestArgoCDConfigValidateUpdateRepositoryUrlImmutable(t *testing.T) {
+	g := NewWithT(t)
+	old := argoCDConfig()
+	c := old.DeepCopy()
+	c.Spec.Git.RepositoryUrl = "ssh://git@github.com/username/repo2.git"
+
+	g.Expect(c.ValidateUpdate(&old)).Error().To(MatchError(ContainSubstring("spec.git: Forbidden: field is immutable")))
+}
+
+func TestArgoCDConfigValidateUpdateBranchImmutable(t *testing.T) {
+	g := NewWithT(t)
+	old := argoCDConfig()
+	c := old.DeepCopy()
+	c.Spec.Branch = "dev"
+
+	g.Expect(c.ValidateUpdate(&old)).Error().To(MatchError(ContainSubstring("spec.branch: Forbidden: field is immutable")))
+}
+
+func TestArgoCDConfigValidateUpdateInstallManifestUrlMutable(t *testing.T) {
+	g := NewWithT(t)
+	old := argoCDConfig()
+	c := old.DeepCopy()
+	c.Spec.InstallManifestUrl = "https://mirror.example.com/argo-cd/v2.14.0/install.yaml"
+
+	g.Expect(c.ValidateUpdate(&old)).Error().To(Succeed())
+}
+
+func TestArgoCDConfigValidateUpdateInvalidType(t *testing.T) {
+	g := NewWithT(t)
+	c := argoCDConfig()
+	f := fluxConfig()
+
+	g.Expect(c.ValidateUpdate(&f)).Error().To(MatchError(ContainSubstring("expected an ArgoCDConfig")))
+}
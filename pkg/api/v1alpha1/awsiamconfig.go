@@ -10,6 +10,7 @@ const (
 	AWSIamConfigKind = "AWSIamConfig"
 	eksConfigMap     = "EKSConfigMap"
 	mountedFile      = "MountedFile"
+	crdBackendMode   = "CRD"
 
 	DefaultAWSIamConfigPartition = "aws"
 )
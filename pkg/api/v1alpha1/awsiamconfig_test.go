@@ -135,3 +135,40 @@ func TestGetAndValidateAWSIamConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestAWSIamConfigBackends(t *testing.T) {
+	tests := []struct {
+		name             string
+		backendMode      []string
+		wantCRD          bool
+		wantEKSConfigMap bool
+	}{
+		{
+			name:             "eks configmap",
+			backendMode:      []string{"EKSConfigMap"},
+			wantEKSConfigMap: true,
+		},
+		{
+			name:        "crd",
+			backendMode: []string{"CRD"},
+			wantCRD:     true,
+		},
+		{
+			name:             "both",
+			backendMode:      []string{"CRD", "EKSConfigMap"},
+			wantCRD:          true,
+			wantEKSConfigMap: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &AWSIamConfig{Spec: AWSIamConfigSpec{BackendMode: tt.backendMode}}
+			if got := config.UsesCRDBackend(); got != tt.wantCRD {
+				t.Errorf("UsesCRDBackend() = %v, want %v", got, tt.wantCRD)
+			}
+			if got := config.UsesEKSConfigMapBackend(); got != tt.wantEKSConfigMap {
+				t.Errorf("UsesEKSConfigMapBackend() = %v, want %v", got, tt.wantEKSConfigMap)
+			}
+		})
+	}
+}
@@ -111,6 +111,26 @@ func (c *AWSIamConfig) ConvertConfigToConfigGenerateStruct() *AWSIamConfigGenera
 	return config
 }
 
+// UsesCRDBackend returns true if aws-iam-authenticator sources mappings from IAMIdentityMapping objects.
+func (c *AWSIamConfig) UsesCRDBackend() bool {
+	return c.usesBackend(crdBackendMode)
+}
+
+// UsesEKSConfigMapBackend returns true if aws-iam-authenticator sources mappings from the aws-auth ConfigMap.
+func (c *AWSIamConfig) UsesEKSConfigMapBackend() bool {
+	return c.usesBackend(eksConfigMap)
+}
+
+func (c *AWSIamConfig) usesBackend(backendMode string) bool {
+	for _, b := range c.Spec.BackendMode {
+		if b == backendMode {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (c *AWSIamConfig) Validate() error {
 	return validateAWSIamConfig(c)
 }
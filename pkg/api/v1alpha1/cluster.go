@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"net"
 	"net/url"
 	"os"
@@ -26,6 +27,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/features"
 	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/maintenancewindow"
 	"github.com/aws/eks-anywhere/pkg/networkutils"
 	"github.com/aws/eks-anywhere/pkg/semver"
 )
@@ -181,6 +183,7 @@ var clusterConfigValidations = []func(*Cluster) error{
 	validateControlPlaneReplicas,
 	validateWorkerNodeGroups,
 	validateNetworking,
+	validateKubeProxyReplacement,
 	validateGitOps,
 	validateEtcdReplicas,
 	validateIdentityProviderRefs,
@@ -196,6 +199,9 @@ var clusterConfigValidations = []func(*Cluster) error{
 	validateControlPlaneAPIServerOIDCExtraArgs,
 	validateControlPlaneKubeletConfiguration,
 	validateWorkerNodeKubeletConfiguration,
+	validateUpgradePolicy,
+	validateMaintenanceWindow,
+	validateEtcdBackup,
 }
 
 // GetClusterConfig parses a Cluster object from a multiobject yaml file in disk
@@ -532,6 +538,7 @@ func validateControlPlaneAPIServerOIDCExtraArgs(clusterConfig *Cluster) error {
 		"oidc-required-claim",
 		"oidc-username-claim",
 		"oidc-username-prefix",
+		"authentication-config",
 	}
 	if clusterConfig.Spec.IdentityProviderRefs != nil {
 		for _, ref := range clusterConfig.Spec.IdentityProviderRefs {
@@ -737,40 +744,180 @@ func validateNetworking(clusterConfig *Cluster) error {
 	if len(clusterNetwork.Services.CidrBlocks) <= 0 {
 		return errors.New("services CIDR block not specified or empty")
 	}
-	if len(clusterNetwork.Pods.CidrBlocks) > 1 {
-		return fmt.Errorf("multiple CIDR blocks for Pods are not yet supported")
+	if len(clusterNetwork.Pods.CidrBlocks) > 2 {
+		return errors.New("at most two CIDR blocks for Pods are supported, one IPv4 and one IPv6")
 	}
-	if len(clusterNetwork.Services.CidrBlocks) > 1 {
-		return fmt.Errorf("multiple CIDR blocks for Services are not yet supported")
+	if len(clusterNetwork.Services.CidrBlocks) > 2 {
+		return errors.New("at most two CIDR blocks for Services are supported, one IPv4 and one IPv6")
 	}
-	_, podCIDRIPNet, err := net.ParseCIDR(clusterNetwork.Pods.CidrBlocks[0])
+	podCIDRs, err := parseCIDRBlocks(clusterNetwork.Pods.CidrBlocks)
 	if err != nil {
 		return fmt.Errorf("invalid CIDR block format for Pods: %s. Please specify a valid CIDR block for pod subnet", clusterNetwork.Pods)
 	}
-	_, serviceCIDRIPNet, err := net.ParseCIDR(clusterNetwork.Services.CidrBlocks[0])
+	serviceCIDRs, err := parseCIDRBlocks(clusterNetwork.Services.CidrBlocks)
 	if err != nil {
 		return fmt.Errorf("invalid CIDR block for Services: %s. Please specify a valid CIDR block for service subnet", clusterNetwork.Services)
 	}
 
+	if err := validateIPFamilies(clusterConfig, podCIDRs, serviceCIDRs); err != nil {
+		return err
+	}
+
+	if err := validateCiliumNativeRoutingCIDRs(clusterNetwork); err != nil {
+		return err
+	}
+
 	if clusterConfig.Spec.DatacenterRef.Kind == SnowDatacenterKind {
 		controlPlaneEndpoint := net.ParseIP(clusterConfig.Spec.ControlPlaneConfiguration.Endpoint.Host)
 		if controlPlaneEndpoint == nil {
 			return fmt.Errorf("control plane endpoint %s is invalid", clusterConfig.Spec.ControlPlaneConfiguration.Endpoint.Host)
 		}
-		if podCIDRIPNet.Contains(controlPlaneEndpoint) {
-			return fmt.Errorf("control plane endpoint %s conflicts with pods CIDR block %s", clusterConfig.Spec.ControlPlaneConfiguration.Endpoint.Host, clusterNetwork.Pods.CidrBlocks[0])
+		for i, podCIDRIPNet := range podCIDRs {
+			if podCIDRIPNet.Contains(controlPlaneEndpoint) {
+				return fmt.Errorf("control plane endpoint %s conflicts with pods CIDR block %s", clusterConfig.Spec.ControlPlaneConfiguration.Endpoint.Host, clusterNetwork.Pods.CidrBlocks[i])
+			}
+		}
+		for i, serviceCIDRIPNet := range serviceCIDRs {
+			if serviceCIDRIPNet.Contains(controlPlaneEndpoint) {
+				return fmt.Errorf("control plane endpoint %s conflicts with services CIDR block %s", clusterConfig.Spec.ControlPlaneConfiguration.Endpoint.Host, clusterNetwork.Services.CidrBlocks[i])
+			}
+		}
+	}
+
+	for _, podCIDRIPNet := range podCIDRs {
+		if err := validateNodeCIDRMaskSize(podCIDRIPNet, clusterNetwork.Nodes); err != nil {
+			return err
+		}
+	}
+
+	if err := validateNetworkPolicy(clusterConfig); err != nil {
+		return err
+	}
+
+	return validateCNIPlugin(clusterNetwork)
+}
+
+func validateNetworkPolicy(clusterConfig *Cluster) error {
+	networkPolicy := clusterConfig.Spec.ClusterNetwork.NetworkPolicy
+	if networkPolicy == nil {
+		return nil
+	}
+
+	if clusterConfig.IsManaged() {
+		return errors.New("networkPolicy is only supported for management clusters")
+	}
+
+	for i, rule := range networkPolicy.AllowRules {
+		if len(rule.Ingress) == 0 && len(rule.Egress) == 0 {
+			return fmt.Errorf("networkPolicy allowRules[%d] must specify at least one ingress or egress rule", i)
+		}
+		for _, ns := range rule.Namespaces {
+			if ns == "" {
+				return fmt.Errorf("networkPolicy allowRules[%d] namespaces can't be empty", i)
+			}
+		}
+	}
+
+	return nil
+}
+
+func parseCIDRBlocks(cidrBlocks []string) ([]*net.IPNet, error) {
+	ipNets := make([]*net.IPNet, 0, len(cidrBlocks))
+	for _, b := range cidrBlocks {
+		_, ipNet, err := net.ParseCIDR(b)
+		if err != nil {
+			return nil, err
+		}
+		ipNets = append(ipNets, ipNet)
+	}
+	return ipNets, nil
+}
+
+func isIPv6Net(ipNet *net.IPNet) bool {
+	return ipNet.IP.To4() == nil
+}
+
+// validateIPFamilies checks that dual-stack CIDR blocks contain one block of each IP family,
+// that pods and services agree on the families and their order and that the provider
+// supports IPv6 when it's used.
+func validateIPFamilies(clusterConfig *Cluster, podCIDRs, serviceCIDRs []*net.IPNet) error {
+	if len(podCIDRs) == 2 && isIPv6Net(podCIDRs[0]) == isIPv6Net(podCIDRs[1]) {
+		return errors.New("multiple CIDR blocks for Pods must contain one IPv4 and one IPv6 CIDR block")
+	}
+	if len(serviceCIDRs) == 2 && isIPv6Net(serviceCIDRs[0]) == isIPv6Net(serviceCIDRs[1]) {
+		return errors.New("multiple CIDR blocks for Services must contain one IPv4 and one IPv6 CIDR block")
+	}
+	if len(podCIDRs) != len(serviceCIDRs) {
+		return errors.New("pods and services must have the same number of CIDR blocks")
+	}
+	for i := range podCIDRs {
+		if isIPv6Net(podCIDRs[i]) != isIPv6Net(serviceCIDRs[i]) {
+			return errors.New("pods and services CIDR blocks must use the same IP families in the same order")
+		}
+	}
+
+	clusterNetwork := clusterConfig.Spec.ClusterNetwork
+	if !clusterNetwork.UsesIPv6() {
+		return nil
+	}
+
+	kind := clusterConfig.Spec.DatacenterRef.Kind
+	if kind != DockerDatacenterKind && kind != VSphereDatacenterKind {
+		return fmt.Errorf("IPv6 and dual-stack cluster networks are only supported on Docker and vSphere providers, got %s", kind)
+	}
+
+	// In a single stack cluster, the control plane endpoint needs to be reachable with the only IP family available.
+	if endpoint := clusterConfig.Spec.ControlPlaneConfiguration.Endpoint; endpoint != nil && !clusterNetwork.IsDualStack() {
+		if ip := net.ParseIP(endpoint.Host); ip != nil && ip.To4() != nil {
+			return fmt.Errorf("control plane endpoint %s must be an IPv6 address for IPv6 cluster networks", endpoint.Host)
+		}
+	}
+
+	return nil
+}
+
+func validateCiliumNativeRoutingCIDRs(clusterNetwork ClusterNetwork) error {
+	if clusterNetwork.CNIConfig == nil || clusterNetwork.CNIConfig.Cilium == nil {
+		return nil
+	}
+	cilium := clusterNetwork.CNIConfig.Cilium
+	family := clusterNetwork.IPFamily()
+
+	if cilium.IPv4NativeRoutingCIDR != "" {
+		_, ipNet, err := net.ParseCIDR(cilium.IPv4NativeRoutingCIDR)
+		if err != nil || isIPv6Net(ipNet) {
+			return fmt.Errorf("cilium ipv4NativeRoutingCIDR %s is not a valid IPv4 CIDR block", cilium.IPv4NativeRoutingCIDR)
+		}
+		if family == IPv6 {
+			return errors.New("cilium ipv4NativeRoutingCIDR can't be set for IPv6 cluster networks")
+		}
+	}
+
+	if cilium.IPv6NativeRoutingCIDR != "" {
+		_, ipNet, err := net.ParseCIDR(cilium.IPv6NativeRoutingCIDR)
+		if err != nil || !isIPv6Net(ipNet) {
+			return fmt.Errorf("cilium ipv6NativeRoutingCIDR %s is not a valid IPv6 CIDR block", cilium.IPv6NativeRoutingCIDR)
 		}
-		if serviceCIDRIPNet.Contains(controlPlaneEndpoint) {
-			return fmt.Errorf("control plane endpoint %s conflicts with services CIDR block %s", clusterConfig.Spec.ControlPlaneConfiguration.Endpoint.Host, clusterNetwork.Services.CidrBlocks[0])
+		if family == IPv4 {
+			return errors.New("cilium ipv6NativeRoutingCIDR requires an IPv6 or dual-stack cluster network")
 		}
 	}
 
+	return nil
+}
+
+func validateNodeCIDRMaskSize(podCIDRIPNet *net.IPNet, nodes *Nodes) error {
 	podMaskSize, _ := podCIDRIPNet.Mask.Size()
 	nodeCidrMaskSize := constants.DefaultNodeCidrMaskSize
-
-	if clusterNetwork.Nodes != nil && clusterNetwork.Nodes.CIDRMaskSize != nil {
-		nodeCidrMaskSize = *clusterNetwork.Nodes.CIDRMaskSize
+	if isIPv6Net(podCIDRIPNet) {
+		nodeCidrMaskSize = constants.DefaultNodeCidrMaskSizeIPv6
+		if nodes != nil && nodes.CIDRMaskSizeIPv6 != nil {
+			nodeCidrMaskSize = *nodes.CIDRMaskSizeIPv6
+		}
+	} else if nodes != nil && nodes.CIDRMaskSize != nil {
+		nodeCidrMaskSize = *nodes.CIDRMaskSize
 	}
+
 	// the pod subnet mask needs to allow one or multiple node-masks
 	// i.e. if it has a /24 the node mask must be between 24 and 32 for ipv4
 	// the below validations are run by kubeadm and we are bubbling those up here for better customer experience
@@ -782,7 +929,7 @@ func validateNetworking(clusterConfig *Cluster) error {
 		return fmt.Errorf("pod subnet mask (%d) and node-mask (%d) difference is greater than %d", podMaskSize, nodeCidrMaskSize, podSubnetNodeMaskMaxDiff)
 	}
 
-	return validateCNIPlugin(clusterNetwork)
+	return nil
 }
 
 func validateCNIPlugin(network ClusterNetwork) error {
@@ -817,6 +964,13 @@ func validateCNIConfig(cniConfig *CNIConfig) error {
 		cniPluginSpecified++
 	}
 
+	if cniConfig.Custom != nil {
+		cniPluginSpecified++
+		if err := validateCustomCNIConfig(cniConfig.Custom); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
+
 	if cniPluginSpecified == 0 {
 		allErrs = append(allErrs, fmt.Errorf("no cni plugin specified"))
 	} else if cniPluginSpecified > 1 {
@@ -837,22 +991,120 @@ func validateCiliumConfig(cilium *CiliumConfig) error {
 	}
 
 	if !cilium.IsManaged() {
-		if cilium.PolicyEnforcementMode != "" {
+		if cilium.PolicyEnforcementMode != "" || cilium.Hubble != nil || cilium.Encryption != nil ||
+			cilium.BGPControlPlane != nil || cilium.KubeProxyReplacement {
 			return errors.New("when using skipUpgrades for cilium all other fields must be empty")
 		}
 	}
 
-	if cilium.PolicyEnforcementMode == "" {
+	if cilium.PolicyEnforcementMode != "" && !validCiliumPolicyEnforcementModes[cilium.PolicyEnforcementMode] {
+		return fmt.Errorf("cilium policyEnforcementMode \"%s\" not supported", cilium.PolicyEnforcementMode)
+	}
+
+	if cilium.Hubble != nil && cilium.Hubble.UI && !cilium.Hubble.Relay {
+		return errors.New("cilium hubble ui requires hubble relay to be enabled")
+	}
+
+	if err := validateCiliumEncryption(cilium.Encryption); err != nil {
+		return err
+	}
+
+	return validateCiliumBGPControlPlane(cilium.BGPControlPlane)
+}
+
+// validateKubeProxyReplacement checks the Cilium agents can reach the API server without kube-proxy,
+// through the control plane endpoint.
+func validateKubeProxyReplacement(clusterConfig *Cluster) error {
+	if !clusterConfig.Spec.ClusterNetwork.KubeProxyReplaced() {
 		return nil
 	}
 
-	if !validCiliumPolicyEnforcementModes[cilium.PolicyEnforcementMode] {
-		return fmt.Errorf("cilium policyEnforcementMode \"%s\" not supported", cilium.PolicyEnforcementMode)
+	// Docker clusters reach the API server through a load balancer container, not the endpoint host.
+	if clusterConfig.Spec.DatacenterRef.Kind == DockerDatacenterKind {
+		return errors.New("cilium kubeProxyReplacement is not supported for Docker clusters")
+	}
+
+	if endpoint := clusterConfig.Spec.ControlPlaneConfiguration.Endpoint; endpoint == nil || endpoint.Host == "" {
+		return errors.New("cilium kubeProxyReplacement requires a control plane endpoint host for the cilium agents to reach the API server")
 	}
 
 	return nil
 }
 
+func validateCustomCNIConfig(custom *CustomCNIConfig) error {
+	if custom.ManifestConfigMap != "" && custom.HelmChart != nil {
+		return errors.New("custom cni manifestConfigMap and helmChart are mutually exclusive")
+	}
+
+	if chart := custom.HelmChart; chart != nil {
+		if !strings.HasPrefix(chart.URI, "oci://") {
+			return fmt.Errorf("custom cni helmChart uri \"%s\" is invalid, it must be an oci:// reference", chart.URI)
+		}
+		if chart.Version == "" {
+			return errors.New("custom cni helmChart version is required")
+		}
+	}
+
+	if check := custom.ReadinessCheck; check != nil {
+		for _, w := range append(append([]CustomCNIWorkload{}, check.DaemonSets...), check.Deployments...) {
+			if w.Name == "" || w.Namespace == "" {
+				return errors.New("custom cni readinessCheck workloads require a name and a namespace")
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateCiliumEncryption(encryption *CiliumEncryptionConfig) error {
+	if encryption == nil {
+		return nil
+	}
+
+	if !validCiliumEncryptionTypes[encryption.Type] {
+		return fmt.Errorf("cilium encryption type \"%s\" not supported", encryption.Type)
+	}
+
+	if encryption.NodeEncryption && encryption.Type != CiliumEncryptionWireGuard {
+		return errors.New("cilium encryption nodeEncryption is only supported with wireguard")
+	}
+
+	if encryption.IPsecSecretName != "" && encryption.Type != CiliumEncryptionIPsec {
+		return errors.New("cilium encryption ipsecSecretName is only supported with ipsec")
+	}
+
+	return nil
+}
+
+func validateCiliumBGPControlPlane(bgp *CiliumBGPControlPlaneConfig) error {
+	if bgp == nil {
+		return nil
+	}
+
+	if !isValidASN(bgp.LocalASN) {
+		return fmt.Errorf("cilium bgpControlPlane localASN %d is invalid, must be between 1 and %d", bgp.LocalASN, uint32(math.MaxUint32))
+	}
+
+	if len(bgp.Peers) == 0 {
+		return errors.New("cilium bgpControlPlane requires at least one peer")
+	}
+
+	for _, peer := range bgp.Peers {
+		if net.ParseIP(peer.Address) == nil {
+			return fmt.Errorf("cilium bgpControlPlane peer address \"%s\" is not a valid IP", peer.Address)
+		}
+		if !isValidASN(peer.ASN) {
+			return fmt.Errorf("cilium bgpControlPlane peer %s asn %d is invalid, must be between 1 and %d", peer.Address, peer.ASN, uint32(math.MaxUint32))
+		}
+	}
+
+	return nil
+}
+
+func isValidASN(asn int64) bool {
+	return asn >= 1 && asn <= math.MaxUint32
+}
+
 func validateProxyConfig(clusterConfig *Cluster) error {
 	if clusterConfig.Spec.ProxyConfiguration == nil {
 		return nil
@@ -940,6 +1192,11 @@ func validateIdentityProviderRefs(clusterConfig *Cluster) error {
 			return errors.New("specify a valid name for identityProviderRef")
 		}
 	}
+
+	if len(clusterConfig.OIDCConfigRefs()) > 1 && !SupportsStructuredAuthentication(clusterConfig.Spec.KubernetesVersion) {
+		return fmt.Errorf("multiple OIDCConfig identityProviderRefs are only supported for kubernetes version %s or later", Kube130)
+	}
+
 	return nil
 }
 
@@ -951,8 +1208,8 @@ func validateGitOps(clusterConfig *Cluster) error {
 
 	gitOpsRefKind := gitOpsRef.Kind
 
-	if gitOpsRefKind != GitOpsConfigKind && gitOpsRefKind != FluxConfigKind {
-		return errors.New("only GitOpsConfig or FluxConfig Kind are supported at this time")
+	if gitOpsRefKind != GitOpsConfigKind && gitOpsRefKind != FluxConfigKind && gitOpsRefKind != ArgoCDConfigKind {
+		return errors.New("only GitOpsConfig, FluxConfig or ArgoCDConfig Kind are supported at this time")
 	}
 
 	if gitOpsRef.Name == "" {
@@ -991,17 +1248,11 @@ func validateCPUpgradeRolloutStrategy(clusterConfig *Cluster) error {
 		if cpUpgradeRolloutStrategy.RollingUpdate != nil {
 			return fmt.Errorf("ControlPlaneConfiguration: RollingUpdate field must be empty for 'InPlace' upgrade rollout strategy type")
 		}
-		if clusterConfig.Spec.DatacenterRef.Kind == VSphereDatacenterKind {
-			if !features.IsActive(features.VSphereInPlaceUpgradeEnabled()) {
-				return errors.New("in place upgrades are not supported on vSphere")
-			}
-			if clusterConfig.Spec.ExternalEtcdConfiguration != nil {
-				return errors.New("stacked etcd must be configured when performing in place upgrades")
-			}
-			return nil
+		if !inPlaceUpgradeSupported(clusterConfig.Spec.DatacenterRef.Kind) {
+			return fmt.Errorf("ControlPlaneConfiguration: 'InPlace' upgrade rollout strategy type is only supported on Bare Metal, vSphere and Nutanix")
 		}
-		if clusterConfig.Spec.DatacenterRef.Kind != TinkerbellDatacenterKind {
-			return fmt.Errorf("ControlPlaneConfiguration: 'InPlace' upgrade rollout strategy type is only supported on Bare Metal")
+		if clusterConfig.Spec.ExternalEtcdConfiguration != nil {
+			return errors.New("stacked etcd must be configured when performing in place upgrades")
 		}
 	default:
 		return fmt.Errorf("ControlPlaneConfiguration: only 'RollingUpdate' and 'InPlace' are supported for upgrade rollout strategy type")
@@ -1032,14 +1283,8 @@ func validateMDUpgradeRolloutStrategy(w *WorkerNodeGroupConfiguration, datacente
 		if w.UpgradeRolloutStrategy.RollingUpdate != nil {
 			return fmt.Errorf("WorkerNodeGroupConfiguration: RollingUpdate field must be empty for 'InPlace' upgrade rollout strategy type")
 		}
-		if datacenterRefKind == VSphereDatacenterKind {
-			if features.IsActive(features.VSphereInPlaceUpgradeEnabled()) {
-				return nil
-			}
-			return errors.New("in place upgrades are not supported on vSphere")
-		}
-		if datacenterRefKind != TinkerbellDatacenterKind {
-			return fmt.Errorf("WorkerNodeGroupConfiguration: 'InPlace' upgrade rollout strategy type is only supported on Bare Metal")
+		if !inPlaceUpgradeSupported(datacenterRefKind) {
+			return fmt.Errorf("WorkerNodeGroupConfiguration: 'InPlace' upgrade rollout strategy type is only supported on Bare Metal, vSphere and Nutanix")
 		}
 	default:
 		return fmt.Errorf("WorkerNodeGroupConfiguration: only 'RollingUpdate' and 'InPlace' are supported for upgrade rollout strategy type")
@@ -1048,7 +1293,135 @@ func validateMDUpgradeRolloutStrategy(w *WorkerNodeGroupConfiguration, datacente
 	return nil
 }
 
+// inPlaceUpgradeSupported returns true if the 'InPlace' upgrade rollout strategy type can be used with the provider.
+func inPlaceUpgradeSupported(datacenterRefKind string) bool {
+	switch datacenterRefKind {
+	case TinkerbellDatacenterKind, VSphereDatacenterKind, NutanixDatacenterKind:
+		return true
+	default:
+		return false
+	}
+}
+
+func validateMaintenanceWindow(clusterConfig *Cluster) error {
+	if clusterConfig.Spec.MaintenanceWindow == nil {
+		return nil
+	}
+
+	if _, err := clusterConfig.Spec.MaintenanceWindow.Window(); err != nil {
+		return fmt.Errorf("maintenanceWindow: %v", err)
+	}
+
+	return nil
+}
+
+func validateEtcdBackup(clusterConfig *Cluster) error {
+	backup := clusterConfig.Spec.EtcdBackup
+	if backup == nil {
+		return nil
+	}
+
+	if err := maintenancewindow.ValidateSchedule(backup.Schedule); err != nil {
+		return fmt.Errorf("etcdBackup: %v", err)
+	}
+
+	if backup.Retention < 0 {
+		return fmt.Errorf("etcdBackup: retention can't be negative")
+	}
+
+	if (backup.PersistentVolumeClaim == nil) == (backup.S3 == nil) {
+		return fmt.Errorf("etcdBackup: exactly one of persistentVolumeClaim or s3 must be set")
+	}
+
+	if backup.PersistentVolumeClaim != nil && backup.PersistentVolumeClaim.ClaimName == "" {
+		return fmt.Errorf("etcdBackup: persistentVolumeClaim.claimName is required")
+	}
+
+	if backup.S3 != nil {
+		if backup.S3.Bucket == "" {
+			return fmt.Errorf("etcdBackup: s3.bucket is required")
+		}
+		if backup.S3.CredentialsSecretName == "" {
+			return fmt.Errorf("etcdBackup: s3.credentialsSecretName is required")
+		}
+		if backup.S3.Endpoint != "" {
+			u, err := url.ParseRequestURI(backup.S3.Endpoint)
+			if err != nil {
+				return fmt.Errorf("etcdBackup: invalid s3.endpoint: %v", err)
+			}
+			if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return fmt.Errorf("etcdBackup: invalid s3.endpoint %s: must be an http or https URL", backup.S3.Endpoint)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateUpgradePolicy(clusterConfig *Cluster) error {
+	policy := clusterConfig.Spec.UpgradePolicy
+	if policy == nil {
+		return nil
+	}
+
+	workerNodeGroups := make(map[string]bool, len(clusterConfig.Spec.WorkerNodeGroupConfigurations))
+	for _, w := range clusterConfig.Spec.WorkerNodeGroupConfigurations {
+		workerNodeGroups[w.Name] = true
+	}
+
+	waveNames := make(map[string]bool, len(policy.Waves))
+	inWave := make(map[string]string, len(workerNodeGroups))
+	for _, wave := range policy.Waves {
+		if wave.Name == "" {
+			return errors.New("upgradePolicy: must specify name for waves")
+		}
+		if waveNames[wave.Name] {
+			return fmt.Errorf("upgradePolicy: wave names must be unique, %s is duplicated", wave.Name)
+		}
+		waveNames[wave.Name] = true
+
+		if len(wave.WorkerNodeGroups) == 0 {
+			return fmt.Errorf("upgradePolicy: wave %s must include at least one worker node group", wave.Name)
+		}
+		for _, name := range wave.WorkerNodeGroups {
+			if !workerNodeGroups[name] {
+				return fmt.Errorf("upgradePolicy: wave %s references worker node group %s that doesn't exist", wave.Name, name)
+			}
+			if other, ok := inWave[name]; ok {
+				return fmt.Errorf("upgradePolicy: worker node group %s is in waves %s and %s, it can only be in one", name, other, wave.Name)
+			}
+			inWave[name] = wave.Name
+		}
+	}
+
+	if policy.Canary == nil {
+		return nil
+	}
+
+	healthCheck := policy.Canary.HealthCheck
+	if (healthCheck.Job == nil) == (healthCheck.HTTP == nil) {
+		return errors.New("upgradePolicy: canary health check must specify exactly one of job or http")
+	}
+	if healthCheck.Job != nil && healthCheck.Job.Image == "" {
+		return errors.New("upgradePolicy: canary health check job must specify an image")
+	}
+	if healthCheck.HTTP != nil {
+		u, err := url.ParseRequestURI(healthCheck.HTTP.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return fmt.Errorf("upgradePolicy: canary health check url %s must be a valid http or https url", healthCheck.HTTP.URL)
+		}
+	}
+	if healthCheck.Timeout != nil && healthCheck.Timeout.Duration <= 0 {
+		return errors.New("upgradePolicy: canary health check timeout must be greater than 0")
+	}
+
+	return nil
+}
+
 func validatePackageControllerConfiguration(clusterConfig *Cluster) error {
+	if clusterConfig.Spec.Packages != nil && clusterConfig.Spec.Packages.Offline && clusterConfig.Spec.RegistryMirrorConfiguration == nil {
+		return fmt.Errorf("packages: offline requires a registry mirror to import package bundles, charts and images")
+	}
 	if clusterConfig.IsManaged() {
 		if clusterConfig.Spec.Packages != nil {
 			if clusterConfig.Spec.Packages.Controller != nil {
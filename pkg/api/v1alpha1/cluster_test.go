@@ -8,11 +8,13 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/yaml"
@@ -2520,6 +2522,41 @@ func TestClusterNetworkEquals(t *testing.T) {
 				CNIConfig: &CNIConfig{Cilium: &CiliumConfig{PolicyEnforcementMode: "default"}},
 			},
 		},
+		{
+			name: "previous != new, cilium to custom cni",
+			want: false,
+			prev: &ClusterNetwork{
+				CNIConfig: &CNIConfig{Cilium: &CiliumConfig{}},
+			},
+			new: &ClusterNetwork{
+				CNIConfig: &CNIConfig{Custom: &CustomCNIConfig{}},
+			},
+		},
+		{
+			name: "previous != new, custom cni, diff configuration",
+			want: false,
+			prev: &ClusterNetwork{
+				CNIConfig: &CNIConfig{Custom: &CustomCNIConfig{ManifestConfigMap: "calico"}},
+			},
+			new: &ClusterNetwork{
+				CNIConfig: &CNIConfig{Custom: &CustomCNIConfig{ManifestConfigMap: "calico-v2"}},
+			},
+		},
+		{
+			name: "previous != new, network policy allow rules added",
+			want: false,
+			prev: &ClusterNetwork{
+				CNIConfig: &CNIConfig{Cilium: &CiliumConfig{}},
+			},
+			new: &ClusterNetwork{
+				CNIConfig: &CNIConfig{Cilium: &CiliumConfig{}},
+				NetworkPolicy: &NetworkPolicyConfig{
+					AllowRules: []NetworkPolicyAllowRule{
+						{Ingress: []networkingv1.NetworkPolicyIngressRule{{}}},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -3049,6 +3086,332 @@ func TestValidateNetworking(t *testing.T) {
 	}
 }
 
+func TestValidateNetworkingIPFamilies(t *testing.T) {
+	tests := []struct {
+		name           string
+		datacenterKind string
+		endpoint       string
+		pods           []string
+		services       []string
+		nodes          *Nodes
+		cilium         *CiliumConfig
+		wantErr        string
+	}{
+		{
+			name:           "ipv6 on vsphere",
+			datacenterKind: VSphereDatacenterKind,
+			endpoint:       "fd00::10",
+			pods:           []string{"fd00:10:244::/56"},
+			services:       []string{"fd00:10:96::/108"},
+		},
+		{
+			name:           "dual-stack on docker",
+			datacenterKind: DockerDatacenterKind,
+			pods:           []string{"192.168.0.0/16", "fd00:10:244::/56"},
+			services:       []string{"10.96.0.0/12", "fd00:10:96::/108"},
+		},
+		{
+			name:           "ipv6 primary dual-stack with ipv4 endpoint",
+			datacenterKind: VSphereDatacenterKind,
+			endpoint:       "1.2.3.4",
+			pods:           []string{"fd00:10:244::/56", "192.168.0.0/16"},
+			services:       []string{"fd00:10:96::/108", "10.96.0.0/12"},
+		},
+		{
+			name:           "too many pods CIDR blocks",
+			datacenterKind: DockerDatacenterKind,
+			pods:           []string{"192.168.0.0/16", "fd00:10:244::/56", "10.0.0.0/16"},
+			services:       []string{"10.96.0.0/12"},
+			wantErr:        "at most two CIDR blocks for Pods are supported",
+		},
+		{
+			name:           "too many services CIDR blocks",
+			datacenterKind: DockerDatacenterKind,
+			pods:           []string{"192.168.0.0/16"},
+			services:       []string{"10.96.0.0/12", "fd00:10:96::/108", "10.0.0.0/16"},
+			wantErr:        "at most two CIDR blocks for Services are supported",
+		},
+		{
+			name:           "two ipv4 pods CIDR blocks",
+			datacenterKind: DockerDatacenterKind,
+			pods:           []string{"192.168.0.0/16", "10.0.0.0/16"},
+			services:       []string{"10.96.0.0/12"},
+			wantErr:        "multiple CIDR blocks for Pods must contain one IPv4 and one IPv6 CIDR block",
+		},
+		{
+			name:           "two ipv6 services CIDR blocks",
+			datacenterKind: DockerDatacenterKind,
+			pods:           []string{"192.168.0.0/16", "fd00:10:244::/56"},
+			services:       []string{"fd00:10:96::/108", "fd00:10:97::/108"},
+			wantErr:        "multiple CIDR blocks for Services must contain one IPv4 and one IPv6 CIDR block",
+		},
+		{
+			name:           "dual-stack pods with single stack services",
+			datacenterKind: DockerDatacenterKind,
+			pods:           []string{"192.168.0.0/16", "fd00:10:244::/56"},
+			services:       []string{"10.96.0.0/12"},
+			wantErr:        "pods and services must have the same number of CIDR blocks",
+		},
+		{
+			name:           "different families order",
+			datacenterKind: DockerDatacenterKind,
+			pods:           []string{"192.168.0.0/16", "fd00:10:244::/56"},
+			services:       []string{"fd00:10:96::/108", "10.96.0.0/12"},
+			wantErr:        "pods and services CIDR blocks must use the same IP families in the same order",
+		},
+		{
+			name:           "ipv6 on unsupported provider",
+			datacenterKind: CloudStackDatacenterKind,
+			pods:           []string{"fd00:10:244::/56"},
+			services:       []string{"fd00:10:96::/108"},
+			wantErr:        "IPv6 and dual-stack cluster networks are only supported on Docker and vSphere providers, got CloudStackDatacenterConfig",
+		},
+		{
+			name:           "ipv6 with ipv4 endpoint",
+			datacenterKind: VSphereDatacenterKind,
+			endpoint:       "1.2.3.4",
+			pods:           []string{"fd00:10:244::/56"},
+			services:       []string{"fd00:10:96::/108"},
+			wantErr:        "control plane endpoint 1.2.3.4 must be an IPv6 address for IPv6 cluster networks",
+		},
+		{
+			name:           "ipv6 pod subnet smaller than default node mask",
+			datacenterKind: DockerDatacenterKind,
+			pods:           []string{"fd00:10:244::/64"},
+			services:       []string{"fd00:10:96::/108"},
+			wantErr:        "the size of pod subnet with mask 64 is smaller than or equal to the size of node subnet with mask 64",
+		},
+		{
+			name:           "ipv6 node mask too big for pod subnet",
+			datacenterKind: DockerDatacenterKind,
+			pods:           []string{"192.168.0.0/16", "fd00:10:244::/56"},
+			services:       []string{"10.96.0.0/12", "fd00:10:96::/108"},
+			nodes:          &Nodes{CIDRMaskSizeIPv6: ptr.Int(120)},
+			wantErr:        "pod subnet mask (56) and node-mask (120) difference is greater than 16",
+		},
+		{
+			name:           "cilium ipv6 native routing CIDR with ipv4 network",
+			datacenterKind: DockerDatacenterKind,
+			pods:           []string{"192.168.0.0/16"},
+			services:       []string{"10.96.0.0/12"},
+			cilium:         &CiliumConfig{IPv6NativeRoutingCIDR: "fd00::/48"},
+			wantErr:        "cilium ipv6NativeRoutingCIDR requires an IPv6 or dual-stack cluster network",
+		},
+		{
+			name:           "cilium ipv4 native routing CIDR with ipv6 network",
+			datacenterKind: DockerDatacenterKind,
+			pods:           []string{"fd00:10:244::/56"},
+			services:       []string{"fd00:10:96::/108"},
+			cilium:         &CiliumConfig{IPv4NativeRoutingCIDR: "10.0.0.0/8"},
+			wantErr:        "cilium ipv4NativeRoutingCIDR can't be set for IPv6 cluster networks",
+		},
+		{
+			name:           "cilium invalid ipv6 native routing CIDR",
+			datacenterKind: DockerDatacenterKind,
+			pods:           []string{"192.168.0.0/16", "fd00:10:244::/56"},
+			services:       []string{"10.96.0.0/12", "fd00:10:96::/108"},
+			cilium:         &CiliumConfig{IPv6NativeRoutingCIDR: "10.0.0.0/8"},
+			wantErr:        "cilium ipv6NativeRoutingCIDR 10.0.0.0/8 is not a valid IPv6 CIDR block",
+		},
+		{
+			name:           "cilium native routing CIDRs with dual-stack network",
+			datacenterKind: DockerDatacenterKind,
+			pods:           []string{"192.168.0.0/16", "fd00:10:244::/56"},
+			services:       []string{"10.96.0.0/12", "fd00:10:96::/108"},
+			cilium:         &CiliumConfig{IPv4NativeRoutingCIDR: "10.0.0.0/8", IPv6NativeRoutingCIDR: "fd00::/48"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cilium := tt.cilium
+			if cilium == nil {
+				cilium = &CiliumConfig{}
+			}
+			c := &Cluster{
+				Spec: ClusterSpec{
+					DatacenterRef: Ref{Kind: tt.datacenterKind},
+					ClusterNetwork: ClusterNetwork{
+						Pods:      Pods{CidrBlocks: tt.pods},
+						Services:  Services{CidrBlocks: tt.services},
+						Nodes:     tt.nodes,
+						CNIConfig: &CNIConfig{Cilium: cilium},
+					},
+				},
+			}
+			if tt.endpoint != "" {
+				c.Spec.ControlPlaneConfiguration.Endpoint = &Endpoint{Host: tt.endpoint}
+			}
+
+			err := validateNetworking(c)
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestClusterNetworkIPFamily(t *testing.T) {
+	tests := []struct {
+		name            string
+		pods            []string
+		wantFamily      IPFamily
+		wantIPv6Primary bool
+	}{
+		{name: "empty", wantFamily: IPv4},
+		{name: "ipv4", pods: []string{"192.168.0.0/16"}, wantFamily: IPv4},
+		{name: "ipv6", pods: []string{"fd00:10:244::/56"}, wantFamily: IPv6, wantIPv6Primary: true},
+		{name: "ipv4 primary dual-stack", pods: []string{"192.168.0.0/16", "fd00:10:244::/56"}, wantFamily: DualStack},
+		{name: "ipv6 primary dual-stack", pods: []string{"fd00:10:244::/56", "192.168.0.0/16"}, wantFamily: DualStack, wantIPv6Primary: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			n := &ClusterNetwork{Pods: Pods{CidrBlocks: tt.pods}}
+			g.Expect(n.IPFamily()).To(Equal(tt.wantFamily))
+			g.Expect(n.IsDualStack()).To(Equal(tt.wantFamily == DualStack))
+			g.Expect(n.UsesIPv6()).To(Equal(tt.wantFamily != IPv4))
+			g.Expect(n.IsIPv6Primary()).To(Equal(tt.wantIPv6Primary))
+		})
+	}
+}
+
+func TestValidateIdentityProviderRefs(t *testing.T) {
+	tests := []struct {
+		name        string
+		kubeVersion KubernetesVersion
+		refs        []Ref
+		wantErr     string
+	}{
+		{
+			name:        "single OIDCConfig",
+			kubeVersion: Kube129,
+			refs:        []Ref{{Kind: OIDCConfigKind, Name: "oidc"}},
+		},
+		{
+			name:        "multiple OIDCConfigs",
+			kubeVersion: Kube130,
+			refs: []Ref{
+				{Kind: OIDCConfigKind, Name: "oidc-1"},
+				{Kind: OIDCConfigKind, Name: "oidc-2"},
+				{Kind: AWSIamConfigKind, Name: "aws-iam"},
+			},
+		},
+		{
+			name:        "multiple OIDCConfigs unsupported kubernetes version",
+			kubeVersion: Kube129,
+			refs: []Ref{
+				{Kind: OIDCConfigKind, Name: "oidc-1"},
+				{Kind: OIDCConfigKind, Name: "oidc-2"},
+			},
+			wantErr: "multiple OIDCConfig identityProviderRefs are only supported for kubernetes version 1.30 or later",
+		},
+		{
+			name:        "unsupported kind",
+			kubeVersion: Kube130,
+			refs:        []Ref{{Kind: "AnotherOne", Name: "dj-khaled"}},
+			wantErr:     "kind: AnotherOne for identityProviderRef is not supported",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &Cluster{
+				Spec: ClusterSpec{
+					KubernetesVersion:    tt.kubeVersion,
+					IdentityProviderRefs: tt.refs,
+				},
+			}
+
+			err := validateIdentityProviderRefs(cluster)
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(tt.wantErr))
+			}
+		})
+	}
+}
+
+func TestValidateNetworkPolicy(t *testing.T) {
+	tests := []struct {
+		name              string
+		managementCluster string
+		networkPolicy     *NetworkPolicyConfig
+		wantErr           string
+	}{
+		{
+			name: "not set",
+		},
+		{
+			name: "valid rules",
+			networkPolicy: &NetworkPolicyConfig{
+				AllowRules: []NetworkPolicyAllowRule{
+					{
+						Namespaces: []string{"eksa-system"},
+						Egress:     []networkingv1.NetworkPolicyEgressRule{{}},
+					},
+					{
+						Ingress: []networkingv1.NetworkPolicyIngressRule{{}},
+					},
+				},
+			},
+		},
+		{
+			name:              "workload cluster",
+			managementCluster: "mgmt",
+			networkPolicy:     &NetworkPolicyConfig{},
+			wantErr:           "networkPolicy is only supported for management clusters",
+		},
+		{
+			name: "rule without ingress or egress",
+			networkPolicy: &NetworkPolicyConfig{
+				AllowRules: []NetworkPolicyAllowRule{
+					{
+						Namespaces: []string{"eksa-system"},
+					},
+				},
+			},
+			wantErr: "networkPolicy allowRules[0] must specify at least one ingress or egress rule",
+		},
+		{
+			name: "empty namespace",
+			networkPolicy: &NetworkPolicyConfig{
+				AllowRules: []NetworkPolicyAllowRule{
+					{
+						Namespaces: []string{""},
+						Ingress:    []networkingv1.NetworkPolicyIngressRule{{}},
+					},
+				},
+			},
+			wantErr: "networkPolicy allowRules[0] namespaces can't be empty",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			c := &Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-cluster"},
+				Spec: ClusterSpec{
+					ManagementCluster: ManagementCluster{Name: tt.managementCluster},
+					ClusterNetwork: ClusterNetwork{
+						NetworkPolicy: tt.networkPolicy,
+					},
+				},
+			}
+
+			err := validateNetworkPolicy(c)
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(tt.wantErr))
+			}
+		})
+	}
+}
+
 func TestValidateCNIConfig(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -3107,58 +3470,594 @@ func TestValidateCNIConfig(t *testing.T) {
 			},
 		},
 		{
-			name:    "valid cilium policy enforcement mode",
-			wantErr: nil,
-			clusterNetwork: &ClusterNetwork{
-				CNIConfig: &CNIConfig{
-					Cilium: &CiliumConfig{
-						PolicyEnforcementMode: "always",
-					},
+			name:    "valid cilium policy enforcement mode",
+			wantErr: nil,
+			clusterNetwork: &ClusterNetwork{
+				CNIConfig: &CNIConfig{
+					Cilium: &CiliumConfig{
+						PolicyEnforcementMode: "always",
+					},
+				},
+			},
+		},
+		{
+			name:    "valid cilium policy enforcement mode",
+			wantErr: nil,
+			clusterNetwork: &ClusterNetwork{
+				CNIConfig: &CNIConfig{
+					Cilium: &CiliumConfig{
+						PolicyEnforcementMode: "never",
+					},
+				},
+			},
+		},
+		{
+			name:    "CiliumSkipUpgradeWithoutOtherFields",
+			wantErr: nil,
+			clusterNetwork: &ClusterNetwork{
+				CNIConfig: &CNIConfig{
+					Cilium: &CiliumConfig{
+						SkipUpgrade: ptr.Bool(true),
+					},
+				},
+			},
+		},
+		{
+			name: "CiliumSkipUpgradeWithOtherFields",
+			wantErr: fmt.Errorf("validating cniConfig: when using skipUpgrades for cilium all " +
+				"other fields must be empty"),
+			clusterNetwork: &ClusterNetwork{
+				CNIConfig: &CNIConfig{
+					Cilium: &CiliumConfig{
+						SkipUpgrade:           ptr.Bool(true),
+						PolicyEnforcementMode: "never",
+					},
+				},
+			},
+		},
+		{
+			name: "CiliumSkipUpgradeExplicitFalseWithOtherFields",
+			clusterNetwork: &ClusterNetwork{
+				CNIConfig: &CNIConfig{
+					Cilium: &CiliumConfig{
+						SkipUpgrade:           ptr.Bool(false),
+						PolicyEnforcementMode: "never",
+					},
+				},
+			},
+		},
+		{
+			name:    "cilium and custom CNI plugins specified",
+			wantErr: fmt.Errorf("validating cniConfig: cannot specify more than one cni plugins"),
+			clusterNetwork: &ClusterNetwork{
+				CNIConfig: &CNIConfig{
+					Cilium: &CiliumConfig{},
+					Custom: &CustomCNIConfig{},
+				},
+			},
+		},
+		{
+			name: "valid custom CNI without manifest",
+			clusterNetwork: &ClusterNetwork{
+				CNIConfig: &CNIConfig{
+					Custom: &CustomCNIConfig{},
+				},
+			},
+		},
+		{
+			name: "valid custom CNI with helm chart and readiness check",
+			clusterNetwork: &ClusterNetwork{
+				CNIConfig: &CNIConfig{
+					Custom: &CustomCNIConfig{
+						HelmChart: &CustomCNIHelmChart{URI: "oci://registry.example.com/calico/tigera-operator", Version: "v3.27.0"},
+						ReadinessCheck: &CustomCNIReadinessCheck{
+							DaemonSets:  []CustomCNIWorkload{{Name: "calico-node", Namespace: "calico-system"}},
+							Deployments: []CustomCNIWorkload{{Name: "tigera-operator", Namespace: "tigera-operator"}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "custom CNI with manifest and helm chart",
+			wantErr: fmt.Errorf("validating cniConfig: custom cni manifestConfigMap and helmChart are mutually exclusive"),
+			clusterNetwork: &ClusterNetwork{
+				CNIConfig: &CNIConfig{
+					Custom: &CustomCNIConfig{
+						ManifestConfigMap: "calico",
+						HelmChart:         &CustomCNIHelmChart{URI: "oci://registry.example.com/calico", Version: "v3.27.0"},
+					},
+				},
+			},
+		},
+		{
+			name:    "custom CNI helm chart not oci",
+			wantErr: fmt.Errorf("validating cniConfig: custom cni helmChart uri \"https://charts.example.com/calico\" is invalid, it must be an oci:// reference"),
+			clusterNetwork: &ClusterNetwork{
+				CNIConfig: &CNIConfig{
+					Custom: &CustomCNIConfig{
+						HelmChart: &CustomCNIHelmChart{URI: "https://charts.example.com/calico", Version: "v3.27.0"},
+					},
+				},
+			},
+		},
+		{
+			name:    "custom CNI helm chart without version",
+			wantErr: fmt.Errorf("validating cniConfig: custom cni helmChart version is required"),
+			clusterNetwork: &ClusterNetwork{
+				CNIConfig: &CNIConfig{
+					Custom: &CustomCNIConfig{
+						HelmChart: &CustomCNIHelmChart{URI: "oci://registry.example.com/calico"},
+					},
+				},
+			},
+		},
+		{
+			name:    "custom CNI readiness check without namespace",
+			wantErr: fmt.Errorf("validating cniConfig: custom cni readinessCheck workloads require a name and a namespace"),
+			clusterNetwork: &ClusterNetwork{
+				CNIConfig: &CNIConfig{
+					Custom: &CustomCNIConfig{
+						ReadinessCheck: &CustomCNIReadinessCheck{
+							Deployments: []CustomCNIWorkload{{Name: "tigera-operator"}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "CiliumSkipUpgradeWithKubeProxyReplacement",
+			wantErr: fmt.Errorf("validating cniConfig: when using skipUpgrades for cilium all " +
+				"other fields must be empty"),
+			clusterNetwork: &ClusterNetwork{
+				CNIConfig: &CNIConfig{
+					Cilium: &CiliumConfig{
+						SkipUpgrade:          ptr.Bool(true),
+						KubeProxyReplacement: true,
+					},
+				},
+			},
+		},
+		{
+			name:    "cilium hubble ui without relay",
+			wantErr: fmt.Errorf("validating cniConfig: cilium hubble ui requires hubble relay to be enabled"),
+			clusterNetwork: &ClusterNetwork{
+				CNIConfig: &CNIConfig{
+					Cilium: &CiliumConfig{
+						Hubble: &CiliumHubbleConfig{UI: true},
+					},
+				},
+			},
+		},
+		{
+			name: "valid cilium hubble, wireguard encryption and kube-proxy replacement",
+			clusterNetwork: &ClusterNetwork{
+				CNIConfig: &CNIConfig{
+					Cilium: &CiliumConfig{
+						Hubble:               &CiliumHubbleConfig{Relay: true, UI: true},
+						Encryption:           &CiliumEncryptionConfig{Type: CiliumEncryptionWireGuard, NodeEncryption: true},
+						KubeProxyReplacement: true,
+					},
+				},
+			},
+		},
+		{
+			name:    "invalid cilium encryption type",
+			wantErr: fmt.Errorf("validating cniConfig: cilium encryption type \"aes\" not supported"),
+			clusterNetwork: &ClusterNetwork{
+				CNIConfig: &CNIConfig{
+					Cilium: &CiliumConfig{
+						Encryption: &CiliumEncryptionConfig{Type: "aes"},
+					},
+				},
+			},
+		},
+		{
+			name:    "cilium node encryption with ipsec",
+			wantErr: fmt.Errorf("validating cniConfig: cilium encryption nodeEncryption is only supported with wireguard"),
+			clusterNetwork: &ClusterNetwork{
+				CNIConfig: &CNIConfig{
+					Cilium: &CiliumConfig{
+						Encryption: &CiliumEncryptionConfig{Type: CiliumEncryptionIPsec, NodeEncryption: true},
+					},
+				},
+			},
+		},
+		{
+			name:    "cilium ipsec secret with wireguard",
+			wantErr: fmt.Errorf("validating cniConfig: cilium encryption ipsecSecretName is only supported with ipsec"),
+			clusterNetwork: &ClusterNetwork{
+				CNIConfig: &CNIConfig{
+					Cilium: &CiliumConfig{
+						Encryption: &CiliumEncryptionConfig{Type: CiliumEncryptionWireGuard, IPsecSecretName: "keys"},
+					},
+				},
+			},
+		},
+		{
+			name: "valid cilium bgp control plane",
+			clusterNetwork: &ClusterNetwork{
+				CNIConfig: &CNIConfig{
+					Cilium: &CiliumConfig{
+						BGPControlPlane: &CiliumBGPControlPlaneConfig{
+							LocalASN: 64512,
+							Peers:    []CiliumBGPPeer{{Address: "10.0.0.1", ASN: 64513}, {Address: "fd00::1", ASN: 64513}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "cilium bgp control plane invalid local asn",
+			wantErr: fmt.Errorf("validating cniConfig: cilium bgpControlPlane localASN 0 is invalid, must be between 1 and 4294967295"),
+			clusterNetwork: &ClusterNetwork{
+				CNIConfig: &CNIConfig{
+					Cilium: &CiliumConfig{
+						BGPControlPlane: &CiliumBGPControlPlaneConfig{
+							Peers: []CiliumBGPPeer{{Address: "10.0.0.1", ASN: 64513}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "cilium bgp control plane without peers",
+			wantErr: fmt.Errorf("validating cniConfig: cilium bgpControlPlane requires at least one peer"),
+			clusterNetwork: &ClusterNetwork{
+				CNIConfig: &CNIConfig{
+					Cilium: &CiliumConfig{
+						BGPControlPlane: &CiliumBGPControlPlaneConfig{LocalASN: 64512},
+					},
+				},
+			},
+		},
+		{
+			name:    "cilium bgp control plane invalid peer address",
+			wantErr: fmt.Errorf("validating cniConfig: cilium bgpControlPlane peer address \"router\" is not a valid IP"),
+			clusterNetwork: &ClusterNetwork{
+				CNIConfig: &CNIConfig{
+					Cilium: &CiliumConfig{
+						BGPControlPlane: &CiliumBGPControlPlaneConfig{
+							LocalASN: 64512,
+							Peers:    []CiliumBGPPeer{{Address: "router", ASN: 64513}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "cilium bgp control plane invalid peer asn",
+			wantErr: fmt.Errorf("validating cniConfig: cilium bgpControlPlane peer 10.0.0.1 asn 4294967296 is invalid, must be between 1 and 4294967295"),
+			clusterNetwork: &ClusterNetwork{
+				CNIConfig: &CNIConfig{
+					Cilium: &CiliumConfig{
+						BGPControlPlane: &CiliumBGPControlPlaneConfig{
+							LocalASN: 64512,
+							Peers:    []CiliumBGPPeer{{Address: "10.0.0.1", ASN: 4294967296}},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := validateCNIConfig(tt.clusterNetwork.CNIConfig)
+			if !reflect.DeepEqual(tt.wantErr, got) {
+				t.Errorf("%v got = %v, want %v", tt.name, got, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateMirrorConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr string
+		cluster *Cluster
+	}{
+		{
+			name:    "registry mirror not specified",
+			wantErr: "",
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					RegistryMirrorConfiguration: nil,
+				},
+			},
+		},
+		{
+			name:    "endpoint not specified",
+			wantErr: "no value set for RegistryMirrorConfiguration.Endpoint",
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					RegistryMirrorConfiguration: &RegistryMirrorConfiguration{
+						Endpoint: "",
+					},
+				},
+			},
+		},
+		{
+			name:    "invalid port",
+			wantErr: "registry mirror port 65536 is invalid",
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					RegistryMirrorConfiguration: &RegistryMirrorConfiguration{
+						Endpoint: "1.2.3.4",
+						Port:     "65536",
+					},
+				},
+			},
+		},
+		{
+			name:    "multiple mappings for curated packages",
+			wantErr: "only one registry mirror for curated packages is suppported",
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					RegistryMirrorConfiguration: &RegistryMirrorConfiguration{
+						Endpoint: "1.2.3.4",
+						Port:     "30003",
+						OCINamespaces: []OCINamespace{
+							{
+								Registry:  "783794618700.dkr.ecr.us-west-2.amazonaws.com",
+								Namespace: "",
+							},
+							{
+								Registry:  "783794618700.dkr.ecr.us-east-1.amazonaws.com",
+								Namespace: "",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "empty registry in OCINamespace",
+			wantErr: "registry can't be set to empty in OCINamespaces",
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					RegistryMirrorConfiguration: &RegistryMirrorConfiguration{
+						Endpoint: "1.2.3.4",
+						Port:     "30003",
+						OCINamespaces: []OCINamespace{
+							{
+								Registry:  "",
+								Namespace: "",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "insecureSkipVerify on snow provider",
+			wantErr: "",
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					RegistryMirrorConfiguration: &RegistryMirrorConfiguration{
+						Endpoint:           "1.2.3.4",
+						Port:               "443",
+						InsecureSkipVerify: true,
+					},
+					DatacenterRef: Ref{
+						Kind: SnowDatacenterKind,
+					},
+				},
+			},
+		},
+		{
+			name:    "insecureSkipVerify on nutanix provider",
+			wantErr: "",
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					RegistryMirrorConfiguration: &RegistryMirrorConfiguration{
+						Endpoint:           "1.2.3.4",
+						Port:               "443",
+						InsecureSkipVerify: true,
+					},
+					DatacenterRef: Ref{
+						Kind: NutanixDatacenterKind,
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := validateMirrorConfig(tt.cluster)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestValidatePackageControllerConfiguration(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr string
+		cluster *Cluster
+	}{
+		{
+			name: "no packages configuration",
+			cluster: &Cluster{
+				Spec: ClusterSpec{},
+			},
+		},
+		{
+			name: "offline with registry mirror",
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					Packages: &PackageConfiguration{Offline: true},
+					RegistryMirrorConfiguration: &RegistryMirrorConfiguration{
+						Endpoint: "1.2.3.4",
+					},
+				},
+			},
+		},
+		{
+			name:    "offline without registry mirror",
+			wantErr: "packages: offline requires a registry mirror",
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					Packages: &PackageConfiguration{Offline: true},
+				},
+			},
+		},
+		{
+			name:    "controller on workload cluster",
+			wantErr: "packages: controller should not be specified for a workload cluster",
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					ManagementCluster: ManagementCluster{Name: "mgmt"},
+					Packages: &PackageConfiguration{
+						Controller: &PackageControllerConfiguration{Tag: "v1"},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			tt.cluster.Name = "cluster"
+			err := validatePackageControllerConfiguration(tt.cluster)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestValidateUpgradePolicy(t *testing.T) {
+	workers := []WorkerNodeGroupConfiguration{{Name: "md-0"}, {Name: "md-1"}}
+	tests := []struct {
+		name    string
+		wantErr string
+		policy  *UpgradePolicy
+	}{
+		{
+			name: "no upgrade policy",
+		},
+		{
+			name: "valid waves and job canary",
+			policy: &UpgradePolicy{
+				Waves: []UpgradeWave{
+					{Name: "first", WorkerNodeGroups: []string{"md-0"}},
+					{Name: "second", WorkerNodeGroups: []string{"md-1"}},
+				},
+				Canary: &UpgradeCanary{
+					HealthCheck: UpgradeCanaryHealthCheck{
+						Job:     &UpgradeCanaryJobCheck{Image: "public.ecr.aws/my/check:v1"},
+						Timeout: &metav1.Duration{Duration: 5 * time.Minute},
+					},
+				},
+			},
+		},
+		{
+			name: "valid http canary",
+			policy: &UpgradePolicy{
+				Canary: &UpgradeCanary{
+					HealthCheck: UpgradeCanaryHealthCheck{
+						HTTP: &UpgradeCanaryHTTPCheck{URL: "https://app.example.com/healthz"},
+					},
+				},
+			},
+		},
+		{
+			name:    "wave without name",
+			wantErr: "upgradePolicy: must specify name for waves",
+			policy: &UpgradePolicy{
+				Waves: []UpgradeWave{{WorkerNodeGroups: []string{"md-0"}}},
+			},
+		},
+		{
+			name:    "duplicated wave name",
+			wantErr: "wave names must be unique, first is duplicated",
+			policy: &UpgradePolicy{
+				Waves: []UpgradeWave{
+					{Name: "first", WorkerNodeGroups: []string{"md-0"}},
+					{Name: "first", WorkerNodeGroups: []string{"md-1"}},
+				},
+			},
+		},
+		{
+			name:    "empty wave",
+			wantErr: "wave first must include at least one worker node group",
+			policy: &UpgradePolicy{
+				Waves: []UpgradeWave{{Name: "first"}},
+			},
+		},
+		{
+			name:    "unknown worker node group",
+			wantErr: "wave first references worker node group md-2 that doesn't exist",
+			policy: &UpgradePolicy{
+				Waves: []UpgradeWave{{Name: "first", WorkerNodeGroups: []string{"md-2"}}},
+			},
+		},
+		{
+			name:    "worker node group in two waves",
+			wantErr: "worker node group md-0 is in waves first and second",
+			policy: &UpgradePolicy{
+				Waves: []UpgradeWave{
+					{Name: "first", WorkerNodeGroups: []string{"md-0"}},
+					{Name: "second", WorkerNodeGroups: []string{"md-0", "md-1"}},
 				},
 			},
 		},
 		{
-			name:    "valid cilium policy enforcement mode",
-			wantErr: nil,
-			clusterNetwork: &ClusterNetwork{
-				CNIConfig: &CNIConfig{
-					Cilium: &CiliumConfig{
-						PolicyEnforcementMode: "never",
+			name:    "canary without health check",
+			wantErr: "canary health check must specify exactly one of job or http",
+			policy: &UpgradePolicy{
+				Canary: &UpgradeCanary{},
+			},
+		},
+		{
+			name:    "canary with job and http",
+			wantErr: "canary health check must specify exactly one of job or http",
+			policy: &UpgradePolicy{
+				Canary: &UpgradeCanary{
+					HealthCheck: UpgradeCanaryHealthCheck{
+						Job:  &UpgradeCanaryJobCheck{Image: "public.ecr.aws/my/check:v1"},
+						HTTP: &UpgradeCanaryHTTPCheck{URL: "https://app.example.com/healthz"},
 					},
 				},
 			},
 		},
 		{
-			name:    "CiliumSkipUpgradeWithoutOtherFields",
-			wantErr: nil,
-			clusterNetwork: &ClusterNetwork{
-				CNIConfig: &CNIConfig{
-					Cilium: &CiliumConfig{
-						SkipUpgrade: ptr.Bool(true),
+			name:    "job without image",
+			wantErr: "canary health check job must specify an image",
+			policy: &UpgradePolicy{
+				Canary: &UpgradeCanary{
+					HealthCheck: UpgradeCanaryHealthCheck{
+						Job: &UpgradeCanaryJobCheck{},
 					},
 				},
 			},
 		},
 		{
-			name: "CiliumSkipUpgradeWithOtherFields",
-			wantErr: fmt.Errorf("validating cniConfig: when using skipUpgrades for cilium all " +
-				"other fields must be empty"),
-			clusterNetwork: &ClusterNetwork{
-				CNIConfig: &CNIConfig{
-					Cilium: &CiliumConfig{
-						SkipUpgrade:           ptr.Bool(true),
-						PolicyEnforcementMode: "never",
+			name:    "invalid url",
+			wantErr: "canary health check url tcp://app:80 must be a valid http or https url",
+			policy: &UpgradePolicy{
+				Canary: &UpgradeCanary{
+					HealthCheck: UpgradeCanaryHealthCheck{
+						HTTP: &UpgradeCanaryHTTPCheck{URL: "tcp://app:80"},
 					},
 				},
 			},
 		},
 		{
-			name: "CiliumSkipUpgradeExplicitFalseWithOtherFields",
-			clusterNetwork: &ClusterNetwork{
-				CNIConfig: &CNIConfig{
-					Cilium: &CiliumConfig{
-						SkipUpgrade:           ptr.Bool(false),
-						PolicyEnforcementMode: "never",
+			name:    "invalid timeout",
+			wantErr: "canary health check timeout must be greater than 0",
+			policy: &UpgradePolicy{
+				Canary: &UpgradeCanary{
+					HealthCheck: UpgradeCanaryHealthCheck{
+						HTTP:    &UpgradeCanaryHTTPCheck{URL: "https://app.example.com/healthz"},
+						Timeout: &metav1.Duration{},
 					},
 				},
 			},
@@ -3166,129 +4065,238 @@ func TestValidateCNIConfig(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := validateCNIConfig(tt.clusterNetwork.CNIConfig)
-			if !reflect.DeepEqual(tt.wantErr, got) {
-				t.Errorf("%v got = %v, want %v", tt.name, got, tt.wantErr)
+			g := NewWithT(t)
+			cluster := &Cluster{
+				Spec: ClusterSpec{
+					WorkerNodeGroupConfigurations: workers,
+					UpgradePolicy:                 tt.policy,
+				},
+			}
+			err := validateUpgradePolicy(cluster)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
 			}
 		})
 	}
 }
 
-func TestValidateMirrorConfig(t *testing.T) {
+func TestValidateMaintenanceWindow(t *testing.T) {
 	tests := []struct {
 		name    string
 		wantErr string
-		cluster *Cluster
+		window  *MaintenanceWindow
 	}{
 		{
-			name:    "registry mirror not specified",
-			wantErr: "",
-			cluster: &Cluster{
-				Spec: ClusterSpec{
-					RegistryMirrorConfiguration: nil,
-				},
+			name: "no maintenance window",
+		},
+		{
+			name: "valid",
+			window: &MaintenanceWindow{
+				Schedule: "0 22 * * 5",
+				Duration: metav1.Duration{Duration: 8 * time.Hour},
 			},
 		},
 		{
-			name:    "endpoint not specified",
-			wantErr: "no value set for RegistryMirrorConfiguration.Endpoint",
-			cluster: &Cluster{
-				Spec: ClusterSpec{
-					RegistryMirrorConfiguration: &RegistryMirrorConfiguration{
-						Endpoint: "",
-					},
-				},
+			name:    "invalid schedule",
+			wantErr: "maintenanceWindow: invalid maintenance window schedule",
+			window: &MaintenanceWindow{
+				Schedule: "0 25 * * *",
+				Duration: metav1.Duration{Duration: time.Hour},
 			},
 		},
 		{
-			name:    "invalid port",
-			wantErr: "registry mirror port 65536 is invalid",
-			cluster: &Cluster{
+			name:    "no duration",
+			wantErr: "maintenanceWindow: maintenance window duration must be greater than 0",
+			window: &MaintenanceWindow{
+				Schedule: "0 2 * * *",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &Cluster{
 				Spec: ClusterSpec{
-					RegistryMirrorConfiguration: &RegistryMirrorConfiguration{
-						Endpoint: "1.2.3.4",
-						Port:     "65536",
-					},
+					MaintenanceWindow: tt.window,
 				},
-			},
+			}
+			err := validateMaintenanceWindow(cluster)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestValidateKubeProxyReplacement(t *testing.T) {
+	tests := []struct {
+		name                 string
+		wantErr              string
+		kubeProxyReplacement bool
+		endpoint             *Endpoint
+		datacenterKind       string
+	}{
+		{
+			name: "kube-proxy replacement disabled",
 		},
 		{
-			name:    "multiple mappings for curated packages",
-			wantErr: "only one registry mirror for curated packages is suppported",
-			cluster: &Cluster{
+			name:                 "control plane endpoint host",
+			kubeProxyReplacement: true,
+			endpoint:             &Endpoint{Host: "1.2.3.4"},
+		},
+		{
+			name:                 "no control plane endpoint",
+			wantErr:              "cilium kubeProxyReplacement requires a control plane endpoint host",
+			kubeProxyReplacement: true,
+		},
+		{
+			name:                 "empty control plane endpoint host",
+			wantErr:              "cilium kubeProxyReplacement requires a control plane endpoint host",
+			kubeProxyReplacement: true,
+			endpoint:             &Endpoint{},
+		},
+		{
+			name:                 "docker",
+			wantErr:              "cilium kubeProxyReplacement is not supported for Docker clusters",
+			kubeProxyReplacement: true,
+			endpoint:             &Endpoint{Host: "1.2.3.4"},
+			datacenterKind:       DockerDatacenterKind,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &Cluster{
 				Spec: ClusterSpec{
-					RegistryMirrorConfiguration: &RegistryMirrorConfiguration{
-						Endpoint: "1.2.3.4",
-						Port:     "30003",
-						OCINamespaces: []OCINamespace{
-							{
-								Registry:  "783794618700.dkr.ecr.us-west-2.amazonaws.com",
-								Namespace: "",
-							},
-							{
-								Registry:  "783794618700.dkr.ecr.us-east-1.amazonaws.com",
-								Namespace: "",
-							},
+					ControlPlaneConfiguration: ControlPlaneConfiguration{
+						Endpoint: tt.endpoint,
+					},
+					DatacenterRef: Ref{Kind: tt.datacenterKind},
+					ClusterNetwork: ClusterNetwork{
+						CNIConfig: &CNIConfig{
+							Cilium: &CiliumConfig{KubeProxyReplacement: tt.kubeProxyReplacement},
 						},
 					},
 				},
+			}
+			err := validateKubeProxyReplacement(cluster)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestValidateEtcdBackup(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr string
+		backup  *EtcdBackup
+	}{
+		{
+			name: "no etcd backup",
+		},
+		{
+			name: "valid pvc",
+			backup: &EtcdBackup{
+				Schedule:              "0 */6 * * *",
+				PersistentVolumeClaim: &EtcdBackupPersistentVolumeClaim{ClaimName: "etcd-backups"},
 			},
 		},
 		{
-			name:    "empty registry in OCINamespace",
-			wantErr: "registry can't be set to empty in OCINamespaces",
-			cluster: &Cluster{
-				Spec: ClusterSpec{
-					RegistryMirrorConfiguration: &RegistryMirrorConfiguration{
-						Endpoint: "1.2.3.4",
-						Port:     "30003",
-						OCINamespaces: []OCINamespace{
-							{
-								Registry:  "",
-								Namespace: "",
-							},
-						},
-					},
+			name: "valid s3",
+			backup: &EtcdBackup{
+				Schedule:  "0 2 * * *",
+				Retention: 14,
+				S3: &EtcdBackupS3{
+					Endpoint:              "https://minio.example.com:9000",
+					Bucket:                "etcd",
+					ForcePathStyle:        true,
+					CredentialsSecretName: "etcd-backup-credentials",
 				},
 			},
 		},
 		{
-			name:    "insecureSkipVerify on snow provider",
-			wantErr: "",
-			cluster: &Cluster{
-				Spec: ClusterSpec{
-					RegistryMirrorConfiguration: &RegistryMirrorConfiguration{
-						Endpoint:           "1.2.3.4",
-						Port:               "443",
-						InsecureSkipVerify: true,
-					},
-					DatacenterRef: Ref{
-						Kind: SnowDatacenterKind,
-					},
-				},
+			name:    "invalid schedule",
+			wantErr: "etcdBackup: invalid schedule",
+			backup: &EtcdBackup{
+				Schedule:              "0 25 * * *",
+				PersistentVolumeClaim: &EtcdBackupPersistentVolumeClaim{ClaimName: "etcd-backups"},
 			},
 		},
 		{
-			name:    "insecureSkipVerify on nutanix provider",
-			wantErr: "",
-			cluster: &Cluster{
-				Spec: ClusterSpec{
-					RegistryMirrorConfiguration: &RegistryMirrorConfiguration{
-						Endpoint:           "1.2.3.4",
-						Port:               "443",
-						InsecureSkipVerify: true,
-					},
-					DatacenterRef: Ref{
-						Kind: NutanixDatacenterKind,
-					},
-				},
+			name:    "negative retention",
+			wantErr: "etcdBackup: retention can't be negative",
+			backup: &EtcdBackup{
+				Schedule:              "0 2 * * *",
+				Retention:             -1,
+				PersistentVolumeClaim: &EtcdBackupPersistentVolumeClaim{ClaimName: "etcd-backups"},
+			},
+		},
+		{
+			name:    "no destination",
+			wantErr: "etcdBackup: exactly one of persistentVolumeClaim or s3 must be set",
+			backup: &EtcdBackup{
+				Schedule: "0 2 * * *",
+			},
+		},
+		{
+			name:    "two destinations",
+			wantErr: "etcdBackup: exactly one of persistentVolumeClaim or s3 must be set",
+			backup: &EtcdBackup{
+				Schedule:              "0 2 * * *",
+				PersistentVolumeClaim: &EtcdBackupPersistentVolumeClaim{ClaimName: "etcd-backups"},
+				S3:                    &EtcdBackupS3{Bucket: "etcd", CredentialsSecretName: "creds"},
+			},
+		},
+		{
+			name:    "no claim name",
+			wantErr: "etcdBackup: persistentVolumeClaim.claimName is required",
+			backup: &EtcdBackup{
+				Schedule:              "0 2 * * *",
+				PersistentVolumeClaim: &EtcdBackupPersistentVolumeClaim{},
+			},
+		},
+		{
+			name:    "no bucket",
+			wantErr: "etcdBackup: s3.bucket is required",
+			backup: &EtcdBackup{
+				Schedule: "0 2 * * *",
+				S3:       &EtcdBackupS3{CredentialsSecretName: "creds"},
+			},
+		},
+		{
+			name:    "no credentials",
+			wantErr: "etcdBackup: s3.credentialsSecretName is required",
+			backup: &EtcdBackup{
+				Schedule: "0 2 * * *",
+				S3:       &EtcdBackupS3{Bucket: "etcd"},
+			},
+		},
+		{
+			name:    "invalid endpoint",
+			wantErr: "etcdBackup: invalid s3.endpoint",
+			backup: &EtcdBackup{
+				Schedule: "0 2 * * *",
+				S3:       &EtcdBackupS3{Endpoint: "minio:9000", Bucket: "etcd", CredentialsSecretName: "creds"},
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			g := NewWithT(t)
-			err := validateMirrorConfig(tt.cluster)
+			cluster := &Cluster{
+				Spec: ClusterSpec{
+					EtcdBackup: tt.backup,
+				},
+			}
+			err := validateEtcdBackup(cluster)
 			if tt.wantErr == "" {
 				g.Expect(err).To(BeNil())
 			} else {
@@ -3695,7 +4703,7 @@ func TestValidateCPUpgradeRolloutStrategy(t *testing.T) {
 		},
 		{
 			name:    "in place upgrade - provider not supported",
-			wantErr: "ControlPlaneConfiguration: 'InPlace' upgrade rollout strategy type is only supported on Bare Metal",
+			wantErr: "ControlPlaneConfiguration: 'InPlace' upgrade rollout strategy type is only supported on Bare Metal, vSphere and Nutanix",
 			cluster: &Cluster{
 				Spec: ClusterSpec{
 					ControlPlaneConfiguration: ControlPlaneConfiguration{
@@ -3705,9 +4713,8 @@ func TestValidateCPUpgradeRolloutStrategy(t *testing.T) {
 			},
 		},
 		{
-			name:           "in place upgrade - vsphere, external etcd",
-			wantErr:        "stacked etcd must be configured when performing in place upgrades",
-			featureEnvVars: []string{features.VSphereInPlaceEnvVar},
+			name:    "in place upgrade - vsphere, external etcd",
+			wantErr: "stacked etcd must be configured when performing in place upgrades",
 			cluster: &Cluster{
 				Spec: ClusterSpec{
 					DatacenterRef: Ref{
@@ -3883,7 +4890,7 @@ func TestValidateMDUpgradeRolloutStrategy(t *testing.T) {
 		},
 		{
 			name:    "in place upgrade - provider not supported",
-			wantErr: "WorkerNodeGroupConfiguration: 'InPlace' upgrade rollout strategy type is only supported on Bare Metal",
+			wantErr: "WorkerNodeGroupConfiguration: 'InPlace' upgrade rollout strategy type is only supported on Bare Metal, vSphere and Nutanix",
 			cluster: &Cluster{
 				Spec: ClusterSpec{
 					WorkerNodeGroupConfigurations: []WorkerNodeGroupConfiguration{{
@@ -3917,57 +4924,47 @@ func TestValidateMDUpgradeRolloutStrategy(t *testing.T) {
 	}
 }
 
-func TestValidateVSphereMDInPlaceNotEnabled(t *testing.T) {
-	g := NewWithT(t)
-	cluster := &Cluster{
-		Spec: ClusterSpec{
-			WorkerNodeGroupConfigurations: []WorkerNodeGroupConfiguration{{
-				UpgradeRolloutStrategy: &WorkerNodesUpgradeRolloutStrategy{Type: "InPlace"},
-			}},
-			DatacenterRef: Ref{
-				Kind: VSphereDatacenterKind,
-			},
-		},
-	}
-	err := validateMDUpgradeRolloutStrategy(&cluster.Spec.WorkerNodeGroupConfigurations[0], cluster.Spec.DatacenterRef.Kind)
-	g.Expect(err).To(MatchError(ContainSubstring("in place upgrades are not supported on vSphere")))
-}
-
-func TestValidateVSphereCPInPlaceNotEnabled(t *testing.T) {
-	g := NewWithT(t)
-	cluster := &Cluster{
-		Spec: ClusterSpec{
-			ControlPlaneConfiguration: ControlPlaneConfiguration{
-				UpgradeRolloutStrategy: &ControlPlaneUpgradeRolloutStrategy{Type: "InPlace"},
-			},
-			DatacenterRef: Ref{
-				Kind: VSphereDatacenterKind,
-			},
-		},
+func TestValidateMDInPlaceSupportedProviders(t *testing.T) {
+	for _, kind := range []string{TinkerbellDatacenterKind, VSphereDatacenterKind, NutanixDatacenterKind} {
+		t.Run(kind, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &Cluster{
+				Spec: ClusterSpec{
+					WorkerNodeGroupConfigurations: []WorkerNodeGroupConfiguration{{
+						UpgradeRolloutStrategy: &WorkerNodesUpgradeRolloutStrategy{Type: "InPlace"},
+					}},
+					DatacenterRef: Ref{
+						Kind: kind,
+					},
+				},
+			}
+			err := validateMDUpgradeRolloutStrategy(&cluster.Spec.WorkerNodeGroupConfigurations[0], cluster.Spec.DatacenterRef.Kind)
+			g.Expect(err).To(BeNil())
+		})
 	}
-	err := validateCPUpgradeRolloutStrategy(cluster)
-	g.Expect(err).To(MatchError(ContainSubstring("in place upgrades are not supported on vSphere")))
 }
 
-func TestValidateVSphereMDInPlaceEnabled(t *testing.T) {
-	g := NewWithT(t)
-	cluster := &Cluster{
-		Spec: ClusterSpec{
-			WorkerNodeGroupConfigurations: []WorkerNodeGroupConfiguration{{
-				UpgradeRolloutStrategy: &WorkerNodesUpgradeRolloutStrategy{Type: "InPlace"},
-			}},
-			DatacenterRef: Ref{
-				Kind: VSphereDatacenterKind,
-			},
-		},
+func TestValidateCPInPlaceSupportedProviders(t *testing.T) {
+	for _, kind := range []string{TinkerbellDatacenterKind, VSphereDatacenterKind, NutanixDatacenterKind} {
+		t.Run(kind, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &Cluster{
+				Spec: ClusterSpec{
+					ControlPlaneConfiguration: ControlPlaneConfiguration{
+						UpgradeRolloutStrategy: &ControlPlaneUpgradeRolloutStrategy{Type: "InPlace"},
+					},
+					DatacenterRef: Ref{
+						Kind: kind,
+					},
+				},
+			}
+			err := validateCPUpgradeRolloutStrategy(cluster)
+			g.Expect(err).To(BeNil())
+		})
 	}
-	features.ClearCache()
-	t.Setenv(features.VSphereInPlaceEnvVar, "true")
-	err := validateMDUpgradeRolloutStrategy(&cluster.Spec.WorkerNodeGroupConfigurations[0], cluster.Spec.DatacenterRef.Kind)
-	g.Expect(err).To(BeNil())
 }
 
-func TestValidateVSphereCPInPlaceEnabled(t *testing.T) {
+func TestValidateNutanixCPInPlaceExternalEtcd(t *testing.T) {
 	g := NewWithT(t)
 	cluster := &Cluster{
 		Spec: ClusterSpec{
@@ -3975,14 +4972,15 @@ func TestValidateVSphereCPInPlaceEnabled(t *testing.T) {
 				UpgradeRolloutStrategy: &ControlPlaneUpgradeRolloutStrategy{Type: "InPlace"},
 			},
 			DatacenterRef: Ref{
-				Kind: VSphereDatacenterKind,
+				Kind: NutanixDatacenterKind,
+			},
+			ExternalEtcdConfiguration: &ExternalEtcdConfiguration{
+				Count: 3,
 			},
 		},
 	}
-	features.ClearCache()
-	t.Setenv(features.VSphereInPlaceEnvVar, "true")
 	err := validateCPUpgradeRolloutStrategy(cluster)
-	g.Expect(err).To(BeNil())
+	g.Expect(err).To(MatchError(ContainSubstring("stacked etcd must be configured when performing in place upgrades")))
 }
 
 func TestValidateEksaVersion(t *testing.T) {
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -61,6 +62,10 @@ const (
 	// AllowDeleteWhenPausedAnnotation is an annotation applied to an EKS-A cluster that allows the deletion of the cluster
 	// when paused.
 	AllowDeleteWhenPausedAnnotation = "anywhere.eks.amazonaws.com/allow-delete-when-paused"
+
+	// RetryUpgradeCanaryAnnotation can be applied to an EKS-A cluster to run again the health check of
+	// a canary node that failed it. The controller removes it once the health check is restarted.
+	RetryUpgradeCanaryAnnotation = "anywhere.eks.amazonaws.com/retry-upgrade-canary"
 )
 
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
@@ -88,6 +93,13 @@ type ClusterSpec struct {
 	MachineHealthCheck *MachineHealthCheck `json:"machineHealthCheck,omitempty"`
 	EtcdEncryption     *[]EtcdEncryption   `json:"etcdEncryption,omitempty"`
 	LicenseToken       string              `json:"licenseToken,omitempty"`
+	// UpgradePolicy controls the order in which worker node groups are upgraded.
+	UpgradePolicy *UpgradePolicy `json:"upgradePolicy,omitempty"`
+	// MaintenanceWindow restricts when the controller applies changes that roll machines.
+	// Other changes are applied right away.
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+	// EtcdBackup configures scheduled snapshots of the cluster etcd.
+	EtcdBackup *EtcdBackup `json:"etcdBackup,omitempty"`
 }
 
 // EksaVersion is the semver identifying the release of eks-a used to populate the cluster components.
@@ -124,6 +136,56 @@ func (c *Cluster) HasAWSIamConfig() bool {
 	return false
 }
 
+// AWSIamConfigRef returns the AWSIamConfig identity provider reference of the cluster, or nil if it doesn't have one.
+func (c *Cluster) AWSIamConfigRef() *Ref {
+	for i := range c.Spec.IdentityProviderRefs {
+		if c.Spec.IdentityProviderRefs[i].Kind == AWSIamConfigKind {
+			return &c.Spec.IdentityProviderRefs[i]
+		}
+	}
+
+	return nil
+}
+
+// OIDCConfigRefs returns the names of the OIDCConfigs referenced by the cluster,
+// in the order they appear in identityProviderRefs.
+func (c *Cluster) OIDCConfigRefs() []string {
+	var names []string
+	for _, identityProvider := range c.Spec.IdentityProviderRefs {
+		if identityProvider.Kind == OIDCConfigKind {
+			names = append(names, identityProvider.Name)
+		}
+	}
+
+	return names
+}
+
+// UsesStructuredAuthentication checks if the API server of the cluster reads its OIDC settings
+// from a structured authentication config file instead of flags. The file is reloaded by the
+// API server without a restart, which requires Kubernetes 1.30 or later.
+func (c *Cluster) UsesStructuredAuthentication() bool {
+	if len(c.OIDCConfigRefs()) == 0 {
+		return false
+	}
+
+	return SupportsStructuredAuthentication(c.Spec.KubernetesVersion)
+}
+
+// SupportsStructuredAuthentication checks if a Kubernetes version supports configuring
+// the API server authenticators with a structured authentication config file.
+func SupportsStructuredAuthentication(kubeVersion KubernetesVersion) bool {
+	version, err := KubeVersionToSemver(kubeVersion)
+	if err != nil {
+		return false
+	}
+	kube130, err := KubeVersionToSemver(Kube130)
+	if err != nil {
+		return false
+	}
+
+	return version.Compare(kube130) != -1
+}
+
 // IsPackagesEnabled checks if the user has opted out of curated packages
 // installation.
 func (c *Cluster) IsPackagesEnabled() bool {
@@ -189,6 +251,9 @@ func (n *Cluster) Equal(o *Cluster) bool {
 	if n.Spec.LicenseToken != o.Spec.LicenseToken {
 		return false
 	}
+	if !reflect.DeepEqual(n.Spec.EtcdBackup, o.Spec.EtcdBackup) {
+		return false
+	}
 
 	return true
 }
@@ -330,6 +395,93 @@ type MachineHealthCheck struct {
 	MaxUnhealthy *intstr.IntOrString `json:"maxUnhealthy,omitempty"`
 }
 
+// EtcdBackup configures scheduled snapshots of the cluster etcd, stacked or external.
+// Snapshots are taken by a CronJob in the control plane nodes of the cluster.
+// Exactly one of PersistentVolumeClaim or S3 must be set.
+type EtcdBackup struct {
+	// Schedule is a 5 fields cron expression, evaluated in UTC, that defines when snapshots are taken.
+	Schedule string `json:"schedule"`
+	// Retention is the number of snapshots to keep. Older snapshots are deleted after each new one.
+	// If not configured, 7 snapshots are kept.
+	Retention int `json:"retention,omitempty"`
+	// PersistentVolumeClaim stores the snapshots in a volume of the cluster.
+	PersistentVolumeClaim *EtcdBackupPersistentVolumeClaim `json:"persistentVolumeClaim,omitempty"`
+	// S3 stores the snapshots in an S3 compatible bucket.
+	S3 *EtcdBackupS3 `json:"s3,omitempty"`
+}
+
+// EtcdBackupPersistentVolumeClaim is a volume to store etcd snapshots.
+type EtcdBackupPersistentVolumeClaim struct {
+	// ClaimName is the name of an existing PersistentVolumeClaim in the eksa-system namespace of the cluster.
+	ClaimName string `json:"claimName"`
+}
+
+// EtcdBackupS3 is an S3 compatible bucket to store etcd snapshots.
+type EtcdBackupS3 struct {
+	// Endpoint is the URL of the S3 compatible API. If not set, AWS S3 is used.
+	Endpoint string `json:"endpoint,omitempty"`
+	// Region of the bucket.
+	Region string `json:"region,omitempty"`
+	// Bucket is the name of the bucket.
+	Bucket string `json:"bucket"`
+	// Prefix is prepended to the key of the snapshots in the bucket.
+	Prefix string `json:"prefix,omitempty"`
+	// ForcePathStyle uses path style URLs to access the bucket, as required by most S3 compatible stores.
+	ForcePathStyle bool `json:"forcePathStyle,omitempty"`
+	// CredentialsSecretName is the name of a Secret in the eksa-system namespace of the management cluster
+	// with the AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY keys.
+	CredentialsSecretName string `json:"credentialsSecretName"`
+}
+
+// UpgradePolicy controls the order in which worker node groups are upgraded after the control plane.
+type UpgradePolicy struct {
+	// Waves are the groups of worker node groups that are upgraded together, in order. A wave only starts
+	// once all the worker node groups of the previous waves have been upgraded.
+	// Worker node groups not included in any wave are upgraded in a last wave.
+	Waves []UpgradeWave `json:"waves,omitempty"`
+	// Canary configures the upgrade of a single node of the first worker node group to upgrade before any
+	// other worker node. The remaining waves are paused until the canary node passes the health check.
+	Canary *UpgradeCanary `json:"canary,omitempty"`
+}
+
+// UpgradeWave is a group of worker node groups upgraded together.
+type UpgradeWave struct {
+	// Name identifies the wave.
+	Name string `json:"name"`
+	// WorkerNodeGroups are the names of the worker node groups in the wave.
+	WorkerNodeGroups []string `json:"workerNodeGroups"`
+}
+
+// UpgradeCanary configures the canary node of a worker nodes upgrade.
+type UpgradeCanary struct {
+	// HealthCheck is run once the canary node has been upgraded.
+	HealthCheck UpgradeCanaryHealthCheck `json:"healthCheck"`
+}
+
+// UpgradeCanaryHealthCheck configures how to check the canary node after its upgrade.
+// Exactly one of Job or HTTP must be set.
+type UpgradeCanaryHealthCheck struct {
+	// Job runs a container in the canary node. The health check passes when it completes successfully.
+	Job *UpgradeCanaryJobCheck `json:"job,omitempty"`
+	// HTTP probes a URL from the management cluster. The health check passes when it returns a 2xx status code.
+	HTTP *UpgradeCanaryHTTPCheck `json:"http,omitempty"`
+	// Timeout is how long to wait for the health check to pass before considering it failed.
+	// If not configured, the default value is set to "10m0s" (10 minutes).
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// UpgradeCanaryJobCheck is a container run as a Job in the canary node.
+type UpgradeCanaryJobCheck struct {
+	Image   string   `json:"image"`
+	Command []string `json:"command,omitempty"`
+	Args    []string `json:"args,omitempty"`
+}
+
+// UpgradeCanaryHTTPCheck is a URL probed from the management cluster.
+type UpgradeCanaryHTTPCheck struct {
+	URL string `json:"url"`
+}
+
 func TaintsSliceEqual(s1, s2 []corev1.Taint) bool {
 	if len(s1) != len(s2) {
 		return false
@@ -557,6 +709,9 @@ type ClusterNetwork struct {
 	CNIConfig *CNIConfig `json:"cniConfig,omitempty"`
 	DNS       DNS        `json:"dns,omitempty"`
 	Nodes     *Nodes     `json:"nodes,omitempty"`
+	// NetworkPolicy configures the NetworkPolicy baseline EKS Anywhere reconciles for the
+	// management namespaces. Only supported for management clusters.
+	NetworkPolicy *NetworkPolicyConfig `json:"networkPolicy,omitempty"`
 }
 
 func (n *ClusterNetwork) Equal(o *ClusterNetwork) bool {
@@ -580,7 +735,69 @@ func (n *ClusterNetwork) Equal(o *ClusterNetwork) bool {
 	return n.Pods.Equal(&o.Pods) &&
 		n.Services.Equal(&o.Services) &&
 		n.DNS.Equal(&o.DNS) &&
-		n.Nodes.Equal(o.Nodes)
+		n.Nodes.Equal(o.Nodes) &&
+		reflect.DeepEqual(n.NetworkPolicy, o.NetworkPolicy)
+}
+
+// IPFamily is the IP family used by the cluster network.
+type IPFamily string
+
+const (
+	// IPv4 is a single stack IPv4 cluster network.
+	IPv4 IPFamily = "ipv4"
+	// IPv6 is a single stack IPv6 cluster network.
+	IPv6 IPFamily = "ipv6"
+	// DualStack is a cluster network with both IPv4 and IPv6 CIDR blocks.
+	DualStack IPFamily = "dual"
+)
+
+// IPFamily returns the IP family of the cluster network based on its pods CIDR blocks.
+// It defaults to IPv4 when the CIDR blocks can't be parsed.
+func (n *ClusterNetwork) IPFamily() IPFamily {
+	var hasIPv4, hasIPv6 bool
+	for _, b := range n.Pods.CidrBlocks {
+		if isIPv6CIDR(b) {
+			hasIPv6 = true
+		} else {
+			hasIPv4 = true
+		}
+	}
+
+	switch {
+	case hasIPv4 && hasIPv6:
+		return DualStack
+	case hasIPv6:
+		return IPv6
+	default:
+		return IPv4
+	}
+}
+
+// IsDualStack returns true if the cluster network has both IPv4 and IPv6 CIDR blocks.
+func (n *ClusterNetwork) IsDualStack() bool {
+	return n.IPFamily() == DualStack
+}
+
+// UsesIPv6 returns true if the cluster network is either single stack IPv6 or dual-stack.
+func (n *ClusterNetwork) UsesIPv6() bool {
+	return n.IPFamily() != IPv4
+}
+
+// IsIPv6Primary returns true if the first pods CIDR block is IPv6, which makes IPv6
+// the primary family for node and service addresses.
+func (n *ClusterNetwork) IsIPv6Primary() bool {
+	return len(n.Pods.CidrBlocks) > 0 && isIPv6CIDR(n.Pods.CidrBlocks[0])
+}
+
+// KubeProxyReplaced returns true if Cilium replaces kube-proxy for the service load balancing,
+// in which case kube-proxy is not deployed in the cluster.
+func (n *ClusterNetwork) KubeProxyReplaced() bool {
+	return n.CNIConfig != nil && n.CNIConfig.Cilium != nil && n.CNIConfig.Cilium.KubeProxyReplacement
+}
+
+func isIPv6CIDR(cidr string) bool {
+	ip, _, err := net.ParseCIDR(cidr)
+	return err == nil && ip.To4() == nil
 }
 
 func getCNIConfig(cn *ClusterNetwork) *CNIConfig {
@@ -626,7 +843,7 @@ func (n *CNIConfig) Equal(o *CNIConfig) bool {
 	if !n.Kindnetd.Equal(o.Kindnetd) {
 		return false
 	}
-	return true
+	return reflect.DeepEqual(n.Custom, o.Custom)
 }
 
 func (n *CiliumConfig) Equal(o *CiliumConfig) bool {
@@ -659,7 +876,13 @@ func (n *CiliumConfig) Equal(o *CiliumConfig) bool {
 		return false
 	}
 
-	return true
+	if n.KubeProxyReplacement != o.KubeProxyReplacement {
+		return false
+	}
+
+	return reflect.DeepEqual(n.Hubble, o.Hubble) &&
+		reflect.DeepEqual(n.Encryption, o.Encryption) &&
+		reflect.DeepEqual(n.BGPControlPlane, o.BGPControlPlane)
 }
 
 func (n *KindnetdConfig) Equal(o *KindnetdConfig) bool {
@@ -722,6 +945,9 @@ func CNIPluginSame(n ClusterNetwork, o ClusterNetwork) bool {
 			if (n.CNIConfig.Kindnetd != nil && o.CNIConfig.Kindnetd == nil) || (n.CNIConfig.Kindnetd == nil && o.CNIConfig.Kindnetd != nil) {
 				return false
 			}
+			if n.CNIConfig.IsCustom() != o.CNIConfig.IsCustom() {
+				return false
+			}
 		}
 	}
 
@@ -791,6 +1017,9 @@ type ResolvConf struct {
 type Nodes struct {
 	// CIDRMaskSize defines the mask size for node cidr in the cluster, default for ipv4 is 24. This is an optional field
 	CIDRMaskSize *int `json:"cidrMaskSize,omitempty"`
+	// CIDRMaskSizeIPv6 defines the mask size for IPv6 node cidr in the cluster, default is 64.
+	// Only used when the cluster network has an IPv6 pods CIDR block. This is an optional field
+	CIDRMaskSizeIPv6 *int `json:"cidrMaskSizeIPv6,omitempty"`
 }
 
 // Equal compares two Nodes definitions and return true if the are equivalent.
@@ -802,14 +1031,26 @@ func (n *Nodes) Equal(o *Nodes) bool {
 		return false
 	}
 
-	if n.CIDRMaskSize == o.CIDRMaskSize {
-		return true
-	}
-	if n.CIDRMaskSize == nil || o.CIDRMaskSize == nil {
-		return false
-	}
+	return intPtrEqual(n.CIDRMaskSize, o.CIDRMaskSize) &&
+		intPtrEqual(n.CIDRMaskSizeIPv6, o.CIDRMaskSizeIPv6)
+}
+
+// NetworkPolicyConfig configures the NetworkPolicy baseline for the management namespaces
+// (eksa-system, CAPI, etcdadm and infrastructure provider namespaces).
+type NetworkPolicyConfig struct {
+	// AllowRules are extra rules added to the baseline NetworkPolicies.
+	AllowRules []NetworkPolicyAllowRule `json:"allowRules,omitempty"`
+}
 
-	return *n.CIDRMaskSize == *o.CIDRMaskSize
+// NetworkPolicyAllowRule adds ingress and/or egress rules to the baseline NetworkPolicies.
+type NetworkPolicyAllowRule struct {
+	// Namespaces restricts the baseline NetworkPolicies this rule is added to.
+	// When empty, the rule is added to the policies for all the management namespaces.
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Ingress rules allowed in addition to the baseline.
+	Ingress []networkingv1.NetworkPolicyIngressRule `json:"ingress,omitempty"`
+	// Egress rules allowed in addition to the baseline.
+	Egress []networkingv1.NetworkPolicyEgressRule `json:"egress,omitempty"`
 }
 
 func (n *ResolvConf) Equal(o *ResolvConf) bool {
@@ -857,6 +1098,9 @@ type CiliumRoutingMode string
 type CNIConfig struct {
 	Cilium   *CiliumConfig   `json:"cilium,omitempty"`
 	Kindnetd *KindnetdConfig `json:"kindnetd,omitempty"`
+	// Custom configures a CNI provided by the user instead of the EKS-A Cilium.
+	// +optional
+	Custom *CustomCNIConfig `json:"custom,omitempty"`
 }
 
 // IsManaged indicates if EKS-A is responsible for the CNI installation.
@@ -864,6 +1108,64 @@ func (n *CNIConfig) IsManaged() bool {
 	return n != nil && (n.Kindnetd != nil || n.Cilium != nil && n.Cilium.IsManaged())
 }
 
+// IsCustom indicates if the CNI is provided by the user.
+func (n *CNIConfig) IsCustom() bool {
+	return n != nil && n.Custom != nil
+}
+
+// CustomCNIConfig contains the configuration for a CNI provided by the user. EKS-A doesn't install
+// Cilium and, if configured, applies the user manifest or Helm chart to the cluster. When neither is
+// provided, the cluster is created without a CNI and the user is responsible for installing one.
+type CustomCNIConfig struct {
+	// ManifestConfigMap is the name of a ConfigMap in the Cluster namespace containing the CNI manifest.
+	// The manifests in all the ConfigMap keys are applied to the cluster.
+	// +optional
+	ManifestConfigMap string `json:"manifestConfigMap,omitempty"`
+
+	// HelmChart is the Helm chart used to install the CNI.
+	// +optional
+	HelmChart *CustomCNIHelmChart `json:"helmChart,omitempty"`
+
+	// ReadinessCheck lists the workloads that need to be ready for the CNI to be considered configured.
+	// When not set, the CNI is considered configured as soon as the manifest is applied.
+	// +optional
+	ReadinessCheck *CustomCNIReadinessCheck `json:"readinessCheck,omitempty"`
+}
+
+// CustomCNIHelmChart is a reference to a Helm chart in an OCI registry.
+type CustomCNIHelmChart struct {
+	// URI is the chart location in the form oci://registry/repository.
+	URI string `json:"uri"`
+
+	// Version is the chart version.
+	Version string `json:"version"`
+
+	// Namespace is the namespace the chart is installed in. Defaults to kube-system.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Values contains the chart values in YAML format.
+	// +optional
+	Values string `json:"values,omitempty"`
+}
+
+// CustomCNIReadinessCheck lists the workloads that need to be ready for a custom CNI to be considered configured.
+type CustomCNIReadinessCheck struct {
+	// DaemonSets need to have all their pods scheduled, updated and ready.
+	// +optional
+	DaemonSets []CustomCNIWorkload `json:"daemonSets,omitempty"`
+
+	// Deployments need to have all their replicas updated and ready.
+	// +optional
+	Deployments []CustomCNIWorkload `json:"deployments,omitempty"`
+}
+
+// CustomCNIWorkload is a reference to a namespaced workload in the cluster.
+type CustomCNIWorkload struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
 // CiliumConfig contains configuration specific to the Cilium CNI.
 type CiliumConfig struct {
 	// PolicyEnforcementMode determines communication allowed between pods. Accepted values are default, always, never.
@@ -899,6 +1201,89 @@ type CiliumConfig struct {
 	// If this is not set autoDirectNodeRoutes will be set to true
 	// +optional
 	IPv6NativeRoutingCIDR string `json:"ipv6NativeRoutingCIDR,omitempty"`
+
+	// Hubble enables Hubble, the Cilium observability layer, and optionally Hubble Relay and UI.
+	// +optional
+	Hubble *CiliumHubbleConfig `json:"hubble,omitempty"`
+
+	// Encryption enables transparent encryption of the traffic between pods.
+	// +optional
+	Encryption *CiliumEncryptionConfig `json:"encryption,omitempty"`
+
+	// BGPControlPlane enables the Cilium BGP control plane and configures the peers
+	// the nodes establish BGP sessions with.
+	// +optional
+	BGPControlPlane *CiliumBGPControlPlaneConfig `json:"bgpControlPlane,omitempty"`
+
+	// KubeProxyReplacement enables the Cilium eBPF replacement for the kube-proxy
+	// service load balancing. kube-proxy is not deployed in the cluster. It can only
+	// be set when the cluster is created and requires a control plane endpoint host.
+	// +optional
+	KubeProxyReplacement bool `json:"kubeProxyReplacement,omitempty"`
+}
+
+// CiliumHubbleConfig contains the Hubble configuration for Cilium.
+type CiliumHubbleConfig struct {
+	// Relay enables Hubble Relay, which exposes the flows of all the nodes in the cluster.
+	// +optional
+	Relay bool `json:"relay,omitempty"`
+
+	// UI enables the Hubble UI. It requires Relay to be enabled.
+	// +optional
+	UI bool `json:"ui,omitempty"`
+}
+
+// CiliumEncryptionType is the transparent encryption mechanism used by Cilium.
+type CiliumEncryptionType string
+
+// Encryption types for Cilium.
+const (
+	CiliumEncryptionWireGuard CiliumEncryptionType = "wireguard"
+	CiliumEncryptionIPsec     CiliumEncryptionType = "ipsec"
+)
+
+// CiliumEncryptionConfig contains the transparent encryption configuration for Cilium.
+type CiliumEncryptionConfig struct {
+	// Type is the encryption mechanism. Accepted values are wireguard and ipsec.
+	Type CiliumEncryptionType `json:"type"`
+
+	// NodeEncryption enables the encryption of the traffic between nodes in addition
+	// to the traffic between pods. Only supported for wireguard.
+	// +optional
+	NodeEncryption bool `json:"nodeEncryption,omitempty"`
+
+	// IPsecSecretName is the name of the Secret in the kube-system namespace containing the
+	// IPsec keys. It needs to be created before enabling IPsec. Only supported for ipsec.
+	// Defaults to cilium-ipsec-keys.
+	// +optional
+	IPsecSecretName string `json:"ipsecSecretName,omitempty"`
+}
+
+// CiliumBGPControlPlaneConfig contains the BGP control plane configuration for Cilium.
+type CiliumBGPControlPlaneConfig struct {
+	// LocalASN is the autonomous system number of the cluster nodes.
+	LocalASN int64 `json:"localASN"`
+
+	// Peers are the BGP neighbors the nodes establish sessions with.
+	Peers []CiliumBGPPeer `json:"peers"`
+
+	// NodeSelector limits the BGP sessions to the nodes with these labels.
+	// All nodes establish sessions when empty.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// ExportPodCIDR advertises the pod CIDR of each node to its peers.
+	// +optional
+	ExportPodCIDR bool `json:"exportPodCIDR,omitempty"`
+}
+
+// CiliumBGPPeer is a BGP neighbor for the Cilium BGP control plane.
+type CiliumBGPPeer struct {
+	// Address is the IP address of the peer.
+	Address string `json:"address"`
+
+	// ASN is the autonomous system number of the peer.
+	ASN int64 `json:"asn"`
 }
 
 // IsManaged returns true if SkipUpgrade is nil or false indicating EKS-A is responsible for
@@ -939,6 +1324,11 @@ var validCiliumPolicyEnforcementModes = map[CiliumPolicyEnforcementMode]bool{
 	CiliumPolicyModeNever:   true,
 }
 
+var validCiliumEncryptionTypes = map[CiliumEncryptionType]bool{
+	CiliumEncryptionWireGuard: true,
+	CiliumEncryptionIPsec:     true,
+}
+
 // Routing modes for Cilium.
 const (
 	CiliumRoutingModeOverlay CiliumRoutingMode = "overlay"
@@ -1025,6 +1415,44 @@ type ClusterStatus struct {
 
 	// ObservedGeneration is the latest generation observed by the controller.
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// UpgradeCanary reports the state of the canary node of the current worker nodes upgrade
+	// when the cluster has an upgrade policy with a canary.
+	// +optional
+	UpgradeCanary *UpgradeCanaryStatus `json:"upgradeCanary,omitempty"`
+}
+
+// UpgradeCanaryPhase is the phase of the canary node of a worker nodes upgrade.
+type UpgradeCanaryPhase string
+
+const (
+	// UpgradeCanaryUpgrading means the canary node is being upgraded.
+	UpgradeCanaryUpgrading UpgradeCanaryPhase = "Upgrading"
+	// UpgradeCanaryHealthChecking means the canary node has been upgraded and the health check is running.
+	UpgradeCanaryHealthChecking UpgradeCanaryPhase = "HealthChecking"
+	// UpgradeCanaryPassed means the canary node passed the health check and the upgrade continues.
+	UpgradeCanaryPassed UpgradeCanaryPhase = "Passed"
+	// UpgradeCanaryFailed means the canary node failed the health check and the upgrade is paused.
+	UpgradeCanaryFailed UpgradeCanaryPhase = "Failed"
+)
+
+// UpgradeCanaryStatus is the state of the canary node of a worker nodes upgrade.
+type UpgradeCanaryStatus struct {
+	// MachineDeployment is the name of the MachineDeployment of the canary node.
+	MachineDeployment string `json:"machineDeployment"`
+	// Hash identifies the MachineDeployment spec the canary node is upgraded to.
+	Hash string `json:"hash"`
+	// Phase is the current phase of the canary.
+	Phase UpgradeCanaryPhase `json:"phase"`
+	// NodeName is the name of the canary node, once it has been upgraded.
+	// +optional
+	NodeName string `json:"nodeName,omitempty"`
+	// HealthCheckStartTime is when the health check started.
+	// +optional
+	HealthCheckStartTime *metav1.Time `json:"healthCheckStartTime,omitempty"`
+	// Message describes the result of the health check.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 type EksdReleaseRef struct {
@@ -1102,6 +1530,11 @@ type PackageConfiguration struct {
 
 	// Cronjob for ecr token refresher
 	CronJob *PackageControllerCronJob `json:"cronjob,omitempty"`
+
+	// Offline runs curated packages without reaching the package registries. Package bundles,
+	// charts and images are imported into the registry mirror from a local archive and the
+	// ecr token refresher cron job is disabled. It requires a registry mirror.
+	Offline bool `json:"offline,omitempty"`
 }
 
 // Equal for PackageConfiguration.
@@ -1112,7 +1545,7 @@ func (n *PackageConfiguration) Equal(o *PackageConfiguration) bool {
 	if n == nil || o == nil {
 		return false
 	}
-	return n.Disable == o.Disable && n.Controller.Equal(o.Controller) && n.CronJob.Equal(o.CronJob)
+	return n.Disable == o.Disable && n.Offline == o.Offline && n.Controller.Equal(o.Controller) && n.CronJob.Equal(o.CronJob)
 }
 
 // PackageControllerConfiguration configure aspects of package controller.
@@ -1563,6 +1996,9 @@ func (c *Cluster) ConvertConfigToConfigGenerateStruct() *ClusterGenerate {
 			MachineHealthCheck:            c.Spec.MachineHealthCheck,
 			EtcdEncryption:                c.Spec.EtcdEncryption,
 			LicenseToken:                  c.Spec.LicenseToken,
+			UpgradePolicy:                 c.Spec.UpgradePolicy,
+			MaintenanceWindow:             c.Spec.MaintenanceWindow,
+			EtcdBackup:                    c.Spec.EtcdBackup,
 		},
 	}
 
@@ -2228,6 +2228,76 @@ func TestClusterHasAWSIamConfig(t *testing.T) {
 	}
 }
 
+func TestClusterUsesStructuredAuthentication(t *testing.T) {
+	tests := []struct {
+		name        string
+		kubeVersion v1alpha1.KubernetesVersion
+		refs        []v1alpha1.Ref
+		want        bool
+	}{
+		{
+			name:        "OIDCConfig",
+			kubeVersion: v1alpha1.Kube130,
+			refs:        []v1alpha1.Ref{{Kind: v1alpha1.OIDCConfigKind, Name: "oidc"}},
+			want:        true,
+		},
+		{
+			name:        "OIDCConfig unsupported kubernetes version",
+			kubeVersion: v1alpha1.Kube129,
+			refs:        []v1alpha1.Ref{{Kind: v1alpha1.OIDCConfigKind, Name: "oidc"}},
+			want:        false,
+		},
+		{
+			name:        "no OIDCConfig",
+			kubeVersion: v1alpha1.Kube131,
+			refs:        []v1alpha1.Ref{{Kind: v1alpha1.AWSIamConfigKind, Name: "aws-iam"}},
+			want:        false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &v1alpha1.Cluster{
+				Spec: v1alpha1.ClusterSpec{
+					KubernetesVersion:    tt.kubeVersion,
+					IdentityProviderRefs: tt.refs,
+				},
+			}
+			g.Expect(cluster.UsesStructuredAuthentication()).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestClusterOIDCConfigRefs(t *testing.T) {
+	g := NewWithT(t)
+	cluster := &v1alpha1.Cluster{
+		Spec: v1alpha1.ClusterSpec{
+			IdentityProviderRefs: []v1alpha1.Ref{
+				{Kind: v1alpha1.OIDCConfigKind, Name: "oidc-2"},
+				{Kind: v1alpha1.AWSIamConfigKind, Name: "aws-iam"},
+				{Kind: v1alpha1.OIDCConfigKind, Name: "oidc-1"},
+			},
+		},
+	}
+	g.Expect(cluster.OIDCConfigRefs()).To(Equal([]string{"oidc-2", "oidc-1"}))
+}
+
+func TestClusterAWSIamConfigRef(t *testing.T) {
+	g := NewWithT(t)
+	cluster := &v1alpha1.Cluster{
+		Spec: v1alpha1.ClusterSpec{
+			IdentityProviderRefs: []v1alpha1.Ref{
+				{Kind: v1alpha1.OIDCConfigKind, Name: "oidc"},
+				{Kind: v1alpha1.AWSIamConfigKind, Name: "aws-iam"},
+			},
+		},
+	}
+	g.Expect(cluster.AWSIamConfigRef()).To(Equal(&v1alpha1.Ref{Kind: v1alpha1.AWSIamConfigKind, Name: "aws-iam"}))
+
+	cluster.Spec.IdentityProviderRefs = cluster.Spec.IdentityProviderRefs[:1]
+	g.Expect(cluster.AWSIamConfigRef()).To(BeNil())
+}
+
 func TestPackageConfiguration_Equal(t *testing.T) {
 	same := &v1alpha1.PackageConfiguration{Disable: false}
 	tests := []struct {
@@ -2265,6 +2335,12 @@ func TestPackageConfiguration_Equal(t *testing.T) {
 			pco:  &v1alpha1.PackageConfiguration{Disable: false},
 			want: false,
 		},
+		{
+			name: "not equal offline",
+			pcn:  &v1alpha1.PackageConfiguration{Offline: true},
+			pco:  &v1alpha1.PackageConfiguration{Offline: false},
+			want: false,
+		},
 		{
 			name: "not equal controller",
 			pcn: &v1alpha1.PackageConfiguration{
@@ -2793,6 +2869,34 @@ func TestCiliumConfigEquality(t *testing.T) {
 			B:     &v1alpha1.CiliumConfig{},
 			Equal: true,
 		},
+		{
+			Name:  "DifferentHubble",
+			A:     &v1alpha1.CiliumConfig{Hubble: &v1alpha1.CiliumHubbleConfig{Relay: true}},
+			B:     &v1alpha1.CiliumConfig{Hubble: &v1alpha1.CiliumHubbleConfig{}},
+			Equal: false,
+		},
+		{
+			Name:  "EqualEncryption",
+			A:     &v1alpha1.CiliumConfig{Encryption: &v1alpha1.CiliumEncryptionConfig{Type: v1alpha1.CiliumEncryptionWireGuard}},
+			B:     &v1alpha1.CiliumConfig{Encryption: &v1alpha1.CiliumEncryptionConfig{Type: v1alpha1.CiliumEncryptionWireGuard}},
+			Equal: true,
+		},
+		{
+			Name: "DifferentBGPPeers",
+			A: &v1alpha1.CiliumConfig{BGPControlPlane: &v1alpha1.CiliumBGPControlPlaneConfig{
+				LocalASN: 64512, Peers: []v1alpha1.CiliumBGPPeer{{Address: "10.0.0.1", ASN: 64513}},
+			}},
+			B: &v1alpha1.CiliumConfig{BGPControlPlane: &v1alpha1.CiliumBGPControlPlaneConfig{
+				LocalASN: 64512, Peers: []v1alpha1.CiliumBGPPeer{{Address: "10.0.0.2", ASN: 64513}},
+			}},
+			Equal: false,
+		},
+		{
+			Name:  "DifferentKubeProxyReplacement",
+			A:     &v1alpha1.CiliumConfig{KubeProxyReplacement: true},
+			B:     &v1alpha1.CiliumConfig{},
+			Equal: false,
+		},
 		{
 			Name: "EqualPolicyEnforcement",
 			A: &v1alpha1.CiliumConfig{
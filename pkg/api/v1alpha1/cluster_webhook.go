@@ -317,6 +317,13 @@ func validateImmutableFieldsCluster(new, old *Cluster) field.ErrorList {
 		)
 	}
 
+	// kube-proxy is only skipped when the cluster is created, it's not removed or reinstalled in running clusters.
+	if new.Spec.ClusterNetwork.KubeProxyReplaced() != old.Spec.ClusterNetwork.KubeProxyReplaced() {
+		allErrs = append(
+			allErrs,
+			field.Forbidden(specPath.Child("clusterNetwork", "cniConfig", "cilium", "kubeProxyReplacement"), "field is immutable"))
+	}
+
 	if !new.Spec.ClusterNetwork.Nodes.Equal(old.Spec.ClusterNetwork.Nodes) {
 		allErrs = append(
 			allErrs,
@@ -2000,6 +2000,18 @@ func TestClusterValidateUpdateSkipUpgradeImmutability(t *testing.T) {
 	}
 }
 
+func TestClusterValidateUpdateKubeProxyReplacementImmutable(t *testing.T) {
+	g := NewWithT(t)
+	cOld := baseCluster(func(c *v1alpha1.Cluster) {
+		c.Spec.ControlPlaneConfiguration.Endpoint = &v1alpha1.Endpoint{Host: "1.2.3.4"}
+	})
+	cNew := cOld.DeepCopy()
+	cNew.Spec.ClusterNetwork.CNIConfig.Cilium.KubeProxyReplacement = true
+
+	_, err := cNew.ValidateUpdate(cOld)
+	g.Expect(err).To(MatchError(ContainSubstring("spec.clusterNetwork.cniConfig.cilium.kubeProxyReplacement: Forbidden: field is immutable")))
+}
+
 func TestClusterValidateUpdateVersionSkew(t *testing.T) {
 	features.ClearCache()
 	cOld := baseCluster()
@@ -0,0 +1,91 @@
+package v1alpha1
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/pkg/maintenancewindow"
+	"github.com/aws/eks-anywhere/pkg/semver"
+)
+
+// DefaultClusterUpgradeTimeout is the default time a cluster in a ClusterUpgradePlan can take to be upgraded.
+const DefaultClusterUpgradeTimeout = 2 * time.Hour
+
+// Validate validates the ClusterUpgradePlan spec.
+func (p *ClusterUpgradePlan) Validate() error {
+	if p.Spec.EksaVersion == nil && p.Spec.KubernetesVersion == nil {
+		return errors.New("must specify eksaVersion or kubernetesVersion")
+	}
+
+	if p.Spec.EksaVersion != nil {
+		if _, err := semver.New(string(*p.Spec.EksaVersion)); err != nil {
+			return fmt.Errorf("eksaVersion is not a valid semver")
+		}
+	}
+
+	if p.Spec.KubernetesVersion != nil {
+		if _, err := KubeVersionToSemver(*p.Spec.KubernetesVersion); err != nil {
+			return fmt.Errorf("kubernetesVersion is not valid: %v", err)
+		}
+	}
+
+	if len(p.Spec.Batches) == 0 {
+		return errors.New("must specify at least one batch")
+	}
+
+	names := make(map[string]bool, len(p.Spec.Batches))
+	for _, b := range p.Spec.Batches {
+		if b.Name == "" {
+			return errors.New("must specify name for batches")
+		}
+		if names[b.Name] {
+			return fmt.Errorf("batch names must be unique, %s is duplicated", b.Name)
+		}
+		names[b.Name] = true
+
+		if b.MaxConcurrency < 0 {
+			return fmt.Errorf("batch %s maxConcurrency can't be negative", b.Name)
+		}
+		if _, err := metav1.LabelSelectorAsSelector(&b.ClusterSelector); err != nil {
+			return fmt.Errorf("batch %s clusterSelector is invalid: %v", b.Name, err)
+		}
+	}
+
+	if p.Spec.ClusterTimeout != nil && p.Spec.ClusterTimeout.Duration <= 0 {
+		return errors.New("clusterTimeout must be greater than 0")
+	}
+
+	if p.Spec.MaintenanceWindow != nil {
+		if _, err := p.Spec.MaintenanceWindow.Window(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ClusterUpgradeTimeout returns the time a cluster in the plan can take to be upgraded.
+func (p *ClusterUpgradePlan) ClusterUpgradeTimeout() time.Duration {
+	if p.Spec.ClusterTimeout == nil {
+		return DefaultClusterUpgradeTimeout
+	}
+
+	return p.Spec.ClusterTimeout.Duration
+}
+
+// Concurrency returns the maximum number of clusters in the batch upgraded at the same time.
+func (b *ClusterUpgradeBatch) Concurrency() int {
+	if b.MaxConcurrency <= 0 {
+		return 1
+	}
+
+	return b.MaxConcurrency
+}
+
+// Window parses the maintenance window schedule.
+func (w *MaintenanceWindow) Window() (*maintenancewindow.Window, error) {
+	return maintenancewindow.New(w.Schedule, w.Duration.Duration)
+}
@@ -0,0 +1,158 @@
+package v1alpha1_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+func TestClusterUpgradePlanValidate(t *testing.T) {
+	eksaVersion := anywherev1.EksaVersion("v0.19.0")
+	invalidEksaVersion := anywherev1.EksaVersion("invalid")
+	kubeVersion := anywherev1.Kube129
+	invalidKubeVersion := anywherev1.KubernetesVersion("latest")
+
+	testCases := []struct {
+		name    string
+		spec    anywherev1.ClusterUpgradePlanSpec
+		wantErr string
+	}{
+		{
+			name: "valid",
+			spec: anywherev1.ClusterUpgradePlanSpec{
+				EksaVersion:       &eksaVersion,
+				KubernetesVersion: &kubeVersion,
+				Batches: []anywherev1.ClusterUpgradeBatch{
+					{Name: "dev", MaxConcurrency: 2},
+					{Name: "prod", ClusterSelector: metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}}},
+				},
+				MaintenanceWindow: &anywherev1.MaintenanceWindow{
+					Schedule: "0 2 * * 6",
+					Duration: metav1.Duration{Duration: 4 * time.Hour},
+				},
+				ClusterTimeout: &metav1.Duration{Duration: time.Hour},
+			},
+		},
+		{
+			name: "no versions",
+			spec: anywherev1.ClusterUpgradePlanSpec{
+				Batches: []anywherev1.ClusterUpgradeBatch{{Name: "dev"}},
+			},
+			wantErr: "must specify eksaVersion or kubernetesVersion",
+		},
+		{
+			name: "invalid eksa version",
+			spec: anywherev1.ClusterUpgradePlanSpec{
+				EksaVersion: &invalidEksaVersion,
+				Batches:     []anywherev1.ClusterUpgradeBatch{{Name: "dev"}},
+			},
+			wantErr: "eksaVersion is not a valid semver",
+		},
+		{
+			name: "invalid kubernetes version",
+			spec: anywherev1.ClusterUpgradePlanSpec{
+				KubernetesVersion: &invalidKubeVersion,
+				Batches:           []anywherev1.ClusterUpgradeBatch{{Name: "dev"}},
+			},
+			wantErr: "kubernetesVersion is not valid",
+		},
+		{
+			name: "no batches",
+			spec: anywherev1.ClusterUpgradePlanSpec{
+				EksaVersion: &eksaVersion,
+			},
+			wantErr: "must specify at least one batch",
+		},
+		{
+			name: "batch without name",
+			spec: anywherev1.ClusterUpgradePlanSpec{
+				EksaVersion: &eksaVersion,
+				Batches:     []anywherev1.ClusterUpgradeBatch{{}},
+			},
+			wantErr: "must specify name for batches",
+		},
+		{
+			name: "duplicated batch",
+			spec: anywherev1.ClusterUpgradePlanSpec{
+				EksaVersion: &eksaVersion,
+				Batches:     []anywherev1.ClusterUpgradeBatch{{Name: "dev"}, {Name: "dev"}},
+			},
+			wantErr: "batch names must be unique, dev is duplicated",
+		},
+		{
+			name: "negative concurrency",
+			spec: anywherev1.ClusterUpgradePlanSpec{
+				EksaVersion: &eksaVersion,
+				Batches:     []anywherev1.ClusterUpgradeBatch{{Name: "dev", MaxConcurrency: -1}},
+			},
+			wantErr: "batch dev maxConcurrency can't be negative",
+		},
+		{
+			name: "invalid selector",
+			spec: anywherev1.ClusterUpgradePlanSpec{
+				EksaVersion: &eksaVersion,
+				Batches: []anywherev1.ClusterUpgradeBatch{
+					{
+						Name: "dev",
+						ClusterSelector: metav1.LabelSelector{
+							MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "env", Operator: "Bad"}},
+						},
+					},
+				},
+			},
+			wantErr: "batch dev clusterSelector is invalid",
+		},
+		{
+			name: "invalid timeout",
+			spec: anywherev1.ClusterUpgradePlanSpec{
+				EksaVersion:    &eksaVersion,
+				Batches:        []anywherev1.ClusterUpgradeBatch{{Name: "dev"}},
+				ClusterTimeout: &metav1.Duration{},
+			},
+			wantErr: "clusterTimeout must be greater than 0",
+		},
+		{
+			name: "invalid maintenance window",
+			spec: anywherev1.ClusterUpgradePlanSpec{
+				EksaVersion: &eksaVersion,
+				Batches:     []anywherev1.ClusterUpgradeBatch{{Name: "dev"}},
+				MaintenanceWindow: &anywherev1.MaintenanceWindow{
+					Schedule: "0 2 * *",
+					Duration: metav1.Duration{Duration: time.Hour},
+				},
+			},
+			wantErr: "invalid maintenance window schedule",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			plan := &anywherev1.ClusterUpgradePlan{Spec: tt.spec}
+			err := plan.Validate()
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestClusterUpgradePlanDefaults(t *testing.T) {
+	g := NewWithT(t)
+	plan := &anywherev1.ClusterUpgradePlan{}
+	batch := &anywherev1.ClusterUpgradeBatch{}
+
+	g.Expect(plan.ClusterUpgradeTimeout()).To(Equal(anywherev1.DefaultClusterUpgradeTimeout))
+	g.Expect(batch.Concurrency()).To(Equal(1))
+
+	plan.Spec.ClusterTimeout = &metav1.Duration{Duration: time.Hour}
+	batch.MaxConcurrency = 3
+	g.Expect(plan.ClusterUpgradeTimeout()).To(Equal(time.Hour))
+	g.Expect(batch.Concurrency()).To(Equal(3))
+}
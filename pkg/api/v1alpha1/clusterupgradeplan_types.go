@@ -0,0 +1,163 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterUpgradePlanKind stores the Kind for ClusterUpgradePlan.
+const ClusterUpgradePlanKind = "ClusterUpgradePlan"
+
+// RetryFailedClustersAnnotation can be added to a halted ClusterUpgradePlan to retry the upgrade
+// of its failed clusters and resume the plan.
+const RetryFailedClustersAnnotation = "anywhere.eks.amazonaws.com/retry-failed-clusters"
+
+// ClusterUpgradePlanSpec defines the desired state of ClusterUpgradePlan.
+type ClusterUpgradePlanSpec struct {
+	// EksaVersion is the eks-a version to upgrade the clusters to.
+	EksaVersion *EksaVersion `json:"eksaVersion,omitempty"`
+
+	// KubernetesVersion is the Kubernetes version to upgrade the clusters control plane to.
+	KubernetesVersion *KubernetesVersion `json:"kubernetesVersion,omitempty"`
+
+	// Batches are the groups of workload clusters to upgrade, in order. A batch only
+	// starts once all the clusters in the previous one have been upgraded.
+	// +kubebuilder:validation:MinItems=1
+	Batches []ClusterUpgradeBatch `json:"batches"`
+
+	// MaintenanceWindow restricts when the plan can start new cluster upgrades.
+	// Upgrades already in progress continue when the window closes.
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+
+	// ClusterTimeout is the maximum time a cluster can take to be upgraded before it's
+	// considered failed. Defaults to 2h.
+	ClusterTimeout *metav1.Duration `json:"clusterTimeout,omitempty"`
+}
+
+// ClusterUpgradeBatch is a group of workload clusters upgraded together.
+type ClusterUpgradeBatch struct {
+	// Name identifies the batch.
+	Name string `json:"name"`
+
+	// ClusterSelector selects the workload clusters in the batch by their labels.
+	// Clusters matching more than one batch belong to the first one.
+	ClusterSelector metav1.LabelSelector `json:"clusterSelector"`
+
+	// MaxConcurrency is the maximum number of clusters in the batch upgraded at the same time.
+	// Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	MaxConcurrency int `json:"maxConcurrency,omitempty"`
+}
+
+// MaintenanceWindow is a recurrent period of time when disruptive changes can be applied.
+type MaintenanceWindow struct {
+	// Schedule is a 5 fields cron expression, evaluated in UTC, that defines when the window opens.
+	Schedule string `json:"schedule"`
+
+	// Duration is how long the window stays open.
+	Duration metav1.Duration `json:"duration"`
+}
+
+// ClusterUpgradePlanPhase is the phase of a ClusterUpgradePlan.
+type ClusterUpgradePlanPhase string
+
+const (
+	// ClusterUpgradePlanPending means no cluster upgrade has been started yet.
+	ClusterUpgradePlanPending ClusterUpgradePlanPhase = "Pending"
+
+	// ClusterUpgradePlanInProgress means cluster upgrades are in progress.
+	ClusterUpgradePlanInProgress ClusterUpgradePlanPhase = "InProgress"
+
+	// ClusterUpgradePlanHalted means a cluster upgrade failed and no more upgrades will be started.
+	ClusterUpgradePlanHalted ClusterUpgradePlanPhase = "Halted"
+
+	// ClusterUpgradePlanCompleted means all the clusters have been upgraded.
+	ClusterUpgradePlanCompleted ClusterUpgradePlanPhase = "Completed"
+)
+
+// ClusterUpgradePhase is the upgrade phase of a cluster in a ClusterUpgradePlan.
+type ClusterUpgradePhase string
+
+const (
+	// ClusterUpgradePending means the cluster upgrade hasn't started.
+	ClusterUpgradePending ClusterUpgradePhase = "Pending"
+
+	// ClusterUpgradeUpgrading means the cluster spec has been updated and the cluster is being upgraded.
+	ClusterUpgradeUpgrading ClusterUpgradePhase = "Upgrading"
+
+	// ClusterUpgradeUpgraded means the cluster has been upgraded and is ready.
+	ClusterUpgradeUpgraded ClusterUpgradePhase = "Upgraded"
+
+	// ClusterUpgradeFailed means the cluster upgrade failed or timed out.
+	ClusterUpgradeFailed ClusterUpgradePhase = "Failed"
+)
+
+// ClusterUpgradeStatus is the upgrade status of a cluster in a ClusterUpgradePlan.
+type ClusterUpgradeStatus struct {
+	// Name of the cluster.
+	Name string `json:"name"`
+
+	// Batch is the name of the batch the cluster belongs to.
+	Batch string `json:"batch"`
+
+	// Phase is the upgrade phase of the cluster.
+	Phase ClusterUpgradePhase `json:"phase"`
+
+	// StartTime is when the cluster upgrade started.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// Message gives details about the cluster upgrade.
+	Message string `json:"message,omitempty"`
+}
+
+// ClusterUpgradePlanStatus defines the observed state of ClusterUpgradePlan.
+type ClusterUpgradePlanStatus struct {
+	// Phase is the phase of the plan.
+	Phase ClusterUpgradePlanPhase `json:"phase,omitempty"`
+
+	// CurrentBatch is the name of the batch being upgraded.
+	CurrentBatch string `json:"currentBatch,omitempty"`
+
+	// Clusters is the upgrade status of each cluster selected by the plan.
+	Clusters []ClusterUpgradeStatus `json:"clusters,omitempty"`
+
+	// Message gives details about the state of the plan, like why it's halted or waiting.
+	Message string `json:"message,omitempty"`
+
+	// FailureMessage indicates that the plan spec is invalid and can't be executed.
+	FailureMessage *string `json:"failureMessage,omitempty"`
+
+	// ObservedGeneration is the latest generation observed by the controller.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:path=clusterupgradeplans,shortName=cup,scope=Namespaced,singular=clusterupgradeplan
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="Phase of the plan"
+//+kubebuilder:printcolumn:name="Batch",type="string",JSONPath=".status.currentBatch",description="Batch being upgraded"
+//+kubebuilder:printcolumn:name="EksaVersion",type="string",JSONPath=".spec.eksaVersion",description="Requested eks-a version"
+//+kubebuilder:printcolumn:name="KubernetesVersion",type="string",JSONPath=".spec.kubernetesVersion",description="Requested Kubernetes version"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation of Cluster Upgrade Plan"
+
+// ClusterUpgradePlan is the Schema for the clusterupgradeplans API. It upgrades workload
+// clusters selected by label in ordered batches.
+type ClusterUpgradePlan struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterUpgradePlanSpec   `json:"spec,omitempty"`
+	Status ClusterUpgradePlanStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ClusterUpgradePlanList contains a list of ClusterUpgradePlan.
+type ClusterUpgradePlanList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterUpgradePlan `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterUpgradePlan{}, &ClusterUpgradePlanList{})
+}
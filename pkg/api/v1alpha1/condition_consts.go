@@ -77,4 +77,113 @@ const (
 	// upgrades for the default cni. The default cni may still be installed, for example to successfully
 	// create a cluster.
 	SkipUpgradesForDefaultCNIConfiguredReason = "SkipUpgradesForDefaultCNIConfigured"
+
+	// CustomCNINotReadyReason used when the workloads in the readiness check of a custom CNI are not ready.
+	CustomCNINotReadyReason = "CustomCNINotReady"
+)
+
+const (
+	// RegistryMirrorCredentialsRotatedCondition reports whether the registry mirror credentials stored in the
+	// registry-credentials secret have been propagated to all the nodes of the cluster.
+	RegistryMirrorCredentialsRotatedCondition ConditionType = "RegistryMirrorCredentialsRotated"
+
+	// RegistryMirrorCredentialsRotationInProgressReason reports that the registry mirror credentials are being
+	// updated in place on the cluster nodes.
+	RegistryMirrorCredentialsRotationInProgressReason = "RegistryMirrorCredentialsRotationInProgress"
+
+	// RegistryMirrorCredentialsRotationFailedReason reports that updating the registry mirror credentials
+	// on one or more nodes failed.
+	RegistryMirrorCredentialsRotationFailedReason = "RegistryMirrorCredentialsRotationFailed"
+)
+
+const (
+	// AuthenticationConfigUpdatedCondition reports whether the structured authentication config generated
+	// from the OIDCConfigs of the cluster has been written to all the control plane nodes.
+	AuthenticationConfigUpdatedCondition ConditionType = "AuthenticationConfigUpdated"
+
+	// AuthenticationConfigUpdateInProgressReason reports that the authentication config is being
+	// updated in place on the control plane nodes.
+	AuthenticationConfigUpdateInProgressReason = "AuthenticationConfigUpdateInProgress"
+
+	// AuthenticationConfigUpdateFailedReason reports that updating the authentication config
+	// on one or more control plane nodes failed.
+	AuthenticationConfigUpdateFailedReason = "AuthenticationConfigUpdateFailed"
+)
+
+const (
+	// NodeConfigUpdatedCondition reports whether the worker node configuration changes that don't require
+	// replacing the machines (labels, taints, kubelet configuration, NTP servers and cert bundles)
+	// have been applied to all the running worker nodes.
+	NodeConfigUpdatedCondition ConditionType = "NodeConfigUpdated"
+
+	// NodeConfigUpdateInProgressReason reports that the worker node configuration is being
+	// updated in place on the running nodes.
+	NodeConfigUpdateInProgressReason = "NodeConfigUpdateInProgress"
+
+	// NodeConfigUpdateFailedReason reports that updating the worker node configuration
+	// on one or more nodes failed.
+	NodeConfigUpdateFailedReason = "NodeConfigUpdateFailed"
+)
+
+const (
+	// WorkerUpgradeWavesCompletedCondition reports whether all the worker node groups have been upgraded
+	// following the waves and canary of the cluster upgrade policy.
+	WorkerUpgradeWavesCompletedCondition ConditionType = "WorkerUpgradeWavesCompleted"
+
+	// WorkerUpgradeWaveInProgressReason reports that a wave of worker node groups is being upgraded
+	// and the following ones are paused.
+	WorkerUpgradeWaveInProgressReason = "WorkerUpgradeWaveInProgress"
+
+	// UpgradeCanaryInProgressReason reports that the canary node is being upgraded or health checked
+	// and all the other worker node groups are paused.
+	UpgradeCanaryInProgressReason = "UpgradeCanaryInProgress"
+
+	// UpgradeCanaryFailedReason reports that the canary node failed the health check and all the other
+	// worker node groups are paused.
+	UpgradeCanaryFailedReason = "UpgradeCanaryFailed"
+)
+
+const (
+	// GitOpsInSyncCondition reports whether the cluster spec matches the configuration applied by the GitOps engine.
+	// It's only set for clusters with GitOps enabled.
+	GitOpsInSyncCondition ConditionType = "GitOpsInSync"
+
+	// GitOpsDriftDetectedReason reports that the cluster spec was modified outside of the GitOps repository
+	// after the GitOps engine last applied it.
+	GitOpsDriftDetectedReason = "GitOpsDriftDetected"
+)
+
+const (
+	// PackagesReadyCondition reports whether all the curated packages targeting the cluster are installed.
+	// It's only set for clusters with curated packages enabled.
+	PackagesReadyCondition ConditionType = "PackagesReady"
+
+	// PackagesInstallingReason reports that one or more curated packages are being installed or upgraded.
+	PackagesInstallingReason = "PackagesInstalling"
+
+	// PackagesFailedReason reports that one or more curated packages failed to install or upgrade.
+	PackagesFailedReason = "PackagesFailed"
+)
+
+const (
+	// DisruptiveChangesAppliedCondition reports whether the changes that roll machines have been applied or
+	// are deferred until the cluster maintenance window opens. It's only set for clusters with a maintenance window.
+	DisruptiveChangesAppliedCondition ConditionType = "DisruptiveChangesApplied"
+
+	// WaitingForMaintenanceWindowReason reports that changes that roll machines are deferred until the
+	// maintenance window opens.
+	WaitingForMaintenanceWindowReason = "WaitingForMaintenanceWindow"
+
+	// InvalidMaintenanceWindowReason reports that the cluster maintenance window can't be parsed, so the changes
+	// are not applied.
+	InvalidMaintenanceWindowReason = "InvalidMaintenanceWindow"
+)
+
+const (
+	// EtcdHealthyCondition reports whether all the etcd members of the cluster pass their health checks, as reported by the
+	// kubeadm control plane for stacked etcd and by the etcdadm cluster for external etcd.
+	EtcdHealthyCondition ConditionType = "EtcdHealthy"
+
+	// EtcdUnhealthyReason reports that one or more etcd members are not healthy.
+	EtcdUnhealthyReason = "EtcdUnhealthy"
 )
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"strings"
 
 	"github.com/aws/eks-anywhere/pkg/logger"
 )
@@ -13,14 +14,23 @@ const (
 	RsaAlgorithm     = "rsa"
 	EcdsaAlgorithm   = "ecdsa"
 	Ed25519Algorithm = "ed25519"
+
+	// GitlabDefaultHostname is the hostname used for the GitLab provider when none is specified.
+	GitlabDefaultHostname = "gitlab.com"
 )
 
 func validateFluxConfig(config *FluxConfig) error {
-	if config.Spec.Git != nil && config.Spec.Github != nil {
+	providers := 0
+	for _, configured := range []bool{config.Spec.Git != nil, config.Spec.Github != nil, config.Spec.Gitlab != nil, config.Spec.BitbucketServer != nil} {
+		if configured {
+			providers++
+		}
+	}
+	if providers > 1 {
 		return errors.New("must specify only one provider")
 	}
-	if config.Spec.Git == nil && config.Spec.Github == nil {
-		return errors.New("must specify a provider. Valid options are git and github")
+	if providers == 0 {
+		return errors.New("must specify a provider. Valid options are git, github, gitlab and bitbucketServer")
 	}
 	if config.Spec.Github != nil {
 		err := validateGithubProviderConfig(*config.Spec.Github)
@@ -34,6 +44,18 @@ func validateFluxConfig(config *FluxConfig) error {
 			return err
 		}
 	}
+	if config.Spec.Gitlab != nil {
+		err := validateGitlabProviderConfig(*config.Spec.Gitlab)
+		if err != nil {
+			return err
+		}
+	}
+	if config.Spec.BitbucketServer != nil {
+		err := validateBitbucketServerProviderConfig(*config.Spec.BitbucketServer)
+		if err != nil {
+			return err
+		}
+	}
 
 	if len(config.Spec.Branch) > 0 {
 		err := validateGitBranchName(config.Spec.Branch)
@@ -74,6 +96,44 @@ func validateGithubProviderConfig(config GithubProviderConfig) error {
 	return nil
 }
 
+func validateGitlabProviderConfig(config GitlabProviderConfig) error {
+	if len(config.Owner) <= 0 {
+		return errors.New("'owner' is not set or empty in gitlabProviderConfig; owner is a required field")
+	}
+	if len(config.Repository) <= 0 {
+		return errors.New("'repository' is not set or empty in gitlabProviderConfig; repository is a required field")
+	}
+	if err := validateGitRepoName(config.Repository); err != nil {
+		return err
+	}
+	return validateHostname(config.Hostname)
+}
+
+func validateBitbucketServerProviderConfig(config BitbucketServerProviderConfig) error {
+	if len(config.Owner) <= 0 {
+		return errors.New("'owner' is not set or empty in bitbucketServerProviderConfig; owner is a required field")
+	}
+	if len(config.Repository) <= 0 {
+		return errors.New("'repository' is not set or empty in bitbucketServerProviderConfig; repository is a required field")
+	}
+	if len(config.Hostname) <= 0 {
+		return errors.New("'hostname' is not set or empty in bitbucketServerProviderConfig; hostname is a required field")
+	}
+	if err := validateGitRepoName(config.Repository); err != nil {
+		return err
+	}
+	return validateHostname(config.Hostname)
+}
+
+// validateHostname checks the hostname doesn't include a scheme or path, since providers build
+// their API and repository urls from it.
+func validateHostname(hostname string) error {
+	if strings.Contains(hostname, "/") {
+		return fmt.Errorf("invalid hostname %s: hostname must not include a scheme or a path", hostname)
+	}
+	return nil
+}
+
 func validateRepositoryUrl(repositoryUrl string) error {
 	url, err := url.Parse(repositoryUrl)
 	if err != nil {
@@ -105,4 +165,8 @@ func setFluxConfigDefaults(flux *FluxConfig) {
 	if len(c.Branch) == 0 {
 		c.Branch = FluxDefaultBranch
 	}
+
+	if c.Gitlab != nil && len(c.Gitlab.Hostname) == 0 {
+		c.Gitlab.Hostname = GitlabDefaultHostname
+	}
 }
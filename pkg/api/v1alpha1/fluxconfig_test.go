@@ -188,6 +188,156 @@ func TestValidateFluxConfig(t *testing.T) {
 			gitProvider: true,
 			error:       nil,
 		},
+		{
+			testName: "valid fluxconfig gitlab",
+			fluxConfig: &FluxConfig{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       FluxConfigKind,
+					APIVersion: SchemeBuilder.GroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-flux",
+					Namespace: "default",
+				},
+				Spec: FluxConfigSpec{
+					Gitlab: &GitlabProviderConfig{
+						Owner:      "janedoe",
+						Repository: "flux-fleet",
+						Hostname:   "gitlab.example.com",
+					},
+				},
+			},
+			wantErr: false,
+			error:   nil,
+		},
+		{
+			testName: "gitlab empty owner",
+			fluxConfig: &FluxConfig{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       FluxConfigKind,
+					APIVersion: SchemeBuilder.GroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-flux",
+					Namespace: "default",
+				},
+				Spec: FluxConfigSpec{
+					Gitlab: &GitlabProviderConfig{
+						Repository: "flux-fleet",
+					},
+				},
+			},
+			wantErr: true,
+			error:   errors.New("'owner' is not set or empty in gitlabProviderConfig; owner is a required field"),
+		},
+		{
+			testName: "gitlab invalid hostname",
+			fluxConfig: &FluxConfig{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       FluxConfigKind,
+					APIVersion: SchemeBuilder.GroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-flux",
+					Namespace: "default",
+				},
+				Spec: FluxConfigSpec{
+					Gitlab: &GitlabProviderConfig{
+						Owner:      "janedoe",
+						Repository: "flux-fleet",
+						Hostname:   "https://gitlab.example.com",
+					},
+				},
+			},
+			wantErr: true,
+			error:   errors.New("invalid hostname https://gitlab.example.com: hostname must not include a scheme or a path"),
+		},
+		{
+			testName: "valid fluxconfig bitbucket server",
+			fluxConfig: &FluxConfig{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       FluxConfigKind,
+					APIVersion: SchemeBuilder.GroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-flux",
+					Namespace: "default",
+				},
+				Spec: FluxConfigSpec{
+					BitbucketServer: &BitbucketServerProviderConfig{
+						Owner:      "EKSA",
+						Repository: "flux-fleet",
+						Hostname:   "bitbucket.example.com",
+					},
+				},
+			},
+			wantErr: false,
+			error:   nil,
+		},
+		{
+			testName: "bitbucket server empty hostname",
+			fluxConfig: &FluxConfig{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       FluxConfigKind,
+					APIVersion: SchemeBuilder.GroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-flux",
+					Namespace: "default",
+				},
+				Spec: FluxConfigSpec{
+					BitbucketServer: &BitbucketServerProviderConfig{
+						Owner:      "EKSA",
+						Repository: "flux-fleet",
+					},
+				},
+			},
+			wantErr: true,
+			error:   errors.New("'hostname' is not set or empty in bitbucketServerProviderConfig; hostname is a required field"),
+		},
+		{
+			testName: "multiple providers",
+			fluxConfig: &FluxConfig{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       FluxConfigKind,
+					APIVersion: SchemeBuilder.GroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-flux",
+					Namespace: "default",
+				},
+				Spec: FluxConfigSpec{
+					Github: &GithubProviderConfig{
+						Owner:      "janedoe",
+						Repository: "flux-fleet",
+					},
+					Gitlab: &GitlabProviderConfig{
+						Owner:      "janedoe",
+						Repository: "flux-fleet",
+					},
+				},
+			},
+			wantErr: true,
+			error:   errors.New("must specify only one provider"),
+		},
+		{
+			testName: "no provider",
+			fluxConfig: &FluxConfig{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       FluxConfigKind,
+					APIVersion: SchemeBuilder.GroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-flux",
+					Namespace: "default",
+				},
+				Spec: FluxConfigSpec{
+					Branch: "main",
+				},
+			},
+			wantErr: true,
+			error:   errors.New("must specify a provider. Valid options are git, github, gitlab and bitbucketServer"),
+		},
 	}
 
 	for _, tt := range tests {
@@ -35,6 +35,12 @@ type FluxConfigSpec struct {
 
 	// Used to specify Git provider that will be used to host the git files
 	Git *GitProviderConfig `json:"git,omitempty"`
+
+	// Used to specify GitLab provider to host the Git repo and host the git files
+	Gitlab *GitlabProviderConfig `json:"gitlab,omitempty"`
+
+	// Used to specify Bitbucket Server provider to host the Git repo and host the git files
+	BitbucketServer *BitbucketServerProviderConfig `json:"bitbucketServer,omitempty"`
 }
 
 type GithubProviderConfig struct {
@@ -48,6 +54,34 @@ type GithubProviderConfig struct {
 	Personal bool `json:"personal,omitempty"`
 }
 
+type GitlabProviderConfig struct {
+	// Owner is the user or group name of the GitLab provider. Subgroups can be specified with a / separator.
+	Owner string `json:"owner"`
+
+	// Repository name.
+	Repository string `json:"repository"`
+
+	// Hostname of the GitLab instance. Defaults to gitlab.com.
+	Hostname string `json:"hostname,omitempty"`
+
+	// if true, the owner is assumed to be a GitLab user; otherwise a group.
+	Personal bool `json:"personal,omitempty"`
+}
+
+type BitbucketServerProviderConfig struct {
+	// Owner is the project key or the user name of the Bitbucket Server provider.
+	Owner string `json:"owner"`
+
+	// Repository name.
+	Repository string `json:"repository"`
+
+	// Hostname of the Bitbucket Server instance.
+	Hostname string `json:"hostname"`
+
+	// if true, the owner is assumed to be a Bitbucket Server user; otherwise a project.
+	Personal bool `json:"personal,omitempty"`
+}
+
 type GitProviderConfig struct {
 	// Repository URL for the repository to be used with flux. Can be either an SSH or HTTPS url.
 	RepositoryUrl string `json:"repositoryUrl"`
@@ -97,7 +131,27 @@ func (e *FluxConfigSpec) Equal(n *FluxConfigSpec) bool {
 	if e.ClusterConfigPath != n.ClusterConfigPath {
 		return false
 	}
-	return e.Git.Equal(n.Git) && e.Github.Equal(n.Github)
+	return e.Git.Equal(n.Git) && e.Github.Equal(n.Github) && e.Gitlab.Equal(n.Gitlab) && e.BitbucketServer.Equal(n.BitbucketServer)
+}
+
+func (e *GitlabProviderConfig) Equal(n *GitlabProviderConfig) bool {
+	if e == n {
+		return true
+	}
+	if e == nil || n == nil {
+		return false
+	}
+	return *e == *n
+}
+
+func (e *BitbucketServerProviderConfig) Equal(n *BitbucketServerProviderConfig) bool {
+	if e == n {
+		return true
+	}
+	if e == nil || n == nil {
+		return false
+	}
+	return *e == *n
 }
 
 func (e *GithubProviderConfig) Equal(n *GithubProviderConfig) bool {
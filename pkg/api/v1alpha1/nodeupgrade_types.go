@@ -16,6 +16,9 @@ const (
 	// BinariesCopied reports whether the binaries have been copied over by the component copier container.
 	BinariesCopied ConditionType = "BinariesCopied"
 
+	// ComponentsSnapshotted reports whether the current host components have been snapshotted before the upgrade.
+	ComponentsSnapshotted ConditionType = "ComponentsSnapshotted"
+
 	// ContainerdUpgraded reports whether containerd has been upgraded.
 	ContainerdUpgraded ConditionType = "ContainerdUpgraded"
 
@@ -30,6 +33,17 @@ const (
 
 	// PostUpgradeCleanupCompleted reports whether the post upgrade operations have been completed.
 	PostUpgradeCleanupCompleted ConditionType = "PostUpgradeCleanupCompleted"
+
+	// RolledBack reports whether the host components have been restored from the snapshot after a failed upgrade.
+	RolledBack ConditionType = "RolledBack"
+
+	// UpgradePausedAnnotation can be applied to ControlPlaneUpgrade, MachineDeploymentUpgrade and NodeUpgrade
+	// objects to stop the controllers from starting the upgrade of any more nodes. Removing it resumes the upgrade.
+	UpgradePausedAnnotation = "anywhere.eks.amazonaws.com/upgrade-paused"
+
+	// UpgradeCanaryAnnotation is applied to the MachineDeployment of the canary node of a worker nodes
+	// upgrade and copied to its MachineDeploymentUpgrade, which then stops after upgrading the first node.
+	UpgradeCanaryAnnotation = "anywhere.eks.amazonaws.com/upgrade-canary"
 )
 
 // NodeUpgradeSpec defines the desired state of NodeUpgrade.
@@ -51,9 +51,6 @@ func validateOIDCConfig(config *OIDCConfig) field.ErrorList {
 	if config.Spec.ClientId == "" {
 		errs = append(errs, field.Invalid(field.NewPath("spec", "clientId"), config.Spec.ClientId, "OIDCConfig clientId is required"))
 	}
-	if len(config.Spec.RequiredClaims) > 1 {
-		errs = append(errs, field.Invalid(field.NewPath("spec", "requiredClaims"), config.Spec.RequiredClaims, "only one OIDConfig requiredClaim is supported at this time"))
-	}
 	if config.Spec.IssuerUrl == "" {
 		errs = append(errs, field.Invalid(field.NewPath("spec", "issuerUrl"), config.Spec.IssuerUrl, "OIDCConfig issuerUrl is required"))
 		return errs
@@ -5,7 +5,6 @@ import (
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -43,21 +42,13 @@ func (r *OIDCConfig) ValidateCreate() (admission.Warnings, error) {
 func (r *OIDCConfig) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
 	oidcconfiglog.Info("validate update", "name", r.Name)
 
-	oldOIDCConfig, ok := old.(*OIDCConfig)
-	if !ok {
+	if _, ok := old.(*OIDCConfig); !ok {
 		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a OIDCConfig but got a %T", old))
 	}
 
-	if oldOIDCConfig.IsManaged() {
-		clusterlog.Info("OIDC config is associated with workload cluster", "name", oldOIDCConfig.Name)
-		return nil, nil
-	}
-
-	clusterlog.Info("OIDC config is associated with management cluster", "name", oldOIDCConfig.Name)
-
-	var allErrs field.ErrorList
-
-	allErrs = append(allErrs, validateImmutableOIDCFields(r, oldOIDCConfig)...)
+	// OIDC settings can be updated for both management and workload clusters. They are reloaded in place
+	// when the cluster uses a structured authentication config and rolled out to the control plane otherwise.
+	allErrs := r.Validate()
 
 	if len(allErrs) == 0 {
 		return nil, nil
@@ -72,16 +63,3 @@ func (r *OIDCConfig) ValidateDelete() (admission.Warnings, error) {
 
 	return nil, nil
 }
-
-func validateImmutableOIDCFields(new, old *OIDCConfig) field.ErrorList {
-	var allErrs field.ErrorList
-
-	if !new.Spec.Equal(&old.Spec) {
-		allErrs = append(
-			allErrs,
-			field.Forbidden(field.NewPath(OIDCConfigKind), "config is immutable"),
-		)
-	}
-
-	return allErrs
-}
@@ -63,26 +63,6 @@ func TestClusterValidateCreateInvalidOIDCConfig(t *testing.T) {
 			},
 			err: "issuerUrl should have HTTPS scheme",
 		},
-		{
-			name: "Extra required claims",
-			config: v1alpha1.OIDCConfig{
-				Spec: v1alpha1.OIDCConfigSpec{
-					ClientId:  "test",
-					IssuerUrl: "https://test.com",
-					RequiredClaims: []v1alpha1.OIDCConfigRequiredClaim{
-						{
-							Claim: "claim1",
-							Value: "val1",
-						},
-						{
-							Claim: "claim2",
-							Value: "val2",
-						},
-					},
-				},
-			},
-			err: "only one OIDConfig requiredClaim is supported at this time",
-		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -99,7 +79,7 @@ func TestValidateUpdateOIDCClientIdMgmtCluster(t *testing.T) {
 
 	c.Spec.ClientId = "test2"
 	o := NewWithT(t)
-	o.Expect(c.ValidateUpdate(&ocOld)).Error().To(MatchError(ContainSubstring("OIDCConfig: Forbidden: config is immutable")))
+	o.Expect(c.ValidateUpdate(&ocOld)).Error().To(Succeed())
 }
 
 func TestValidateUpdateOIDCGroupsClaimMgmtCluster(t *testing.T) {
@@ -109,7 +89,7 @@ func TestValidateUpdateOIDCGroupsClaimMgmtCluster(t *testing.T) {
 
 	c.Spec.GroupsClaim = "test2"
 	o := NewWithT(t)
-	o.Expect(c.ValidateUpdate(&ocOld)).Error().To(MatchError(ContainSubstring("OIDCConfig: Forbidden: config is immutable")))
+	o.Expect(c.ValidateUpdate(&ocOld)).Error().To(Succeed())
 }
 
 func TestValidateUpdateOIDCGroupsPrefixMgmtCluster(t *testing.T) {
@@ -119,17 +99,17 @@ func TestValidateUpdateOIDCGroupsPrefixMgmtCluster(t *testing.T) {
 
 	c.Spec.GroupsPrefix = "test2"
 	o := NewWithT(t)
-	o.Expect(c.ValidateUpdate(&ocOld)).Error().To(MatchError(ContainSubstring("OIDCConfig: Forbidden: config is immutable")))
+	o.Expect(c.ValidateUpdate(&ocOld)).Error().To(Succeed())
 }
 
 func TestValidateUpdateOIDCIssuerUrlMgmtCluster(t *testing.T) {
 	ocOld := oidcConfig()
-	ocOld.Spec.IssuerUrl = "test"
+	ocOld.Spec.IssuerUrl = "https://test.com"
 	c := ocOld.DeepCopy()
 
-	c.Spec.IssuerUrl = "test2"
+	c.Spec.IssuerUrl = "https://test2.com"
 	o := NewWithT(t)
-	o.Expect(c.ValidateUpdate(&ocOld)).Error().To(MatchError(ContainSubstring("OIDCConfig: Forbidden: config is immutable")))
+	o.Expect(c.ValidateUpdate(&ocOld)).Error().To(Succeed())
 }
 
 func TestValidateUpdateOIDCUsernameClaimMgmtCluster(t *testing.T) {
@@ -139,7 +119,7 @@ func TestValidateUpdateOIDCUsernameClaimMgmtCluster(t *testing.T) {
 
 	c.Spec.UsernameClaim = "test2"
 	o := NewWithT(t)
-	o.Expect(c.ValidateUpdate(&ocOld)).Error().To(MatchError(ContainSubstring("OIDCConfig: Forbidden: config is immutable")))
+	o.Expect(c.ValidateUpdate(&ocOld)).Error().To(Succeed())
 }
 
 func TestValidateUpdateOIDCUsernamePrefixMgmtCluster(t *testing.T) {
@@ -149,7 +129,7 @@ func TestValidateUpdateOIDCUsernamePrefixMgmtCluster(t *testing.T) {
 
 	c.Spec.UsernamePrefix = "test2"
 	o := NewWithT(t)
-	o.Expect(c.ValidateUpdate(&ocOld)).Error().To(MatchError(ContainSubstring("OIDCConfig: Forbidden: config is immutable")))
+	o.Expect(c.ValidateUpdate(&ocOld)).Error().To(Succeed())
 }
 
 func TestValidateUpdateOIDCRequiredClaimsMgmtCluster(t *testing.T) {
@@ -159,7 +139,7 @@ func TestValidateUpdateOIDCRequiredClaimsMgmtCluster(t *testing.T) {
 
 	c.Spec.RequiredClaims = []v1alpha1.OIDCConfigRequiredClaim{{Claim: "test", Value: "value2"}}
 	o := NewWithT(t)
-	o.Expect(c.ValidateUpdate(&ocOld)).Error().To(MatchError(ContainSubstring("OIDCConfig: Forbidden: config is immutable")))
+	o.Expect(c.ValidateUpdate(&ocOld)).Error().To(Succeed())
 }
 
 func TestValidateUpdateOIDCRequiredClaimsMultipleMgmtCluster(t *testing.T) {
@@ -172,7 +152,7 @@ func TestValidateUpdateOIDCRequiredClaimsMultipleMgmtCluster(t *testing.T) {
 		Value: "value2",
 	})
 	o := NewWithT(t)
-	o.Expect(c.ValidateUpdate(&ocOld)).Error().To(MatchError(ContainSubstring("OIDCConfig: Forbidden: config is immutable")))
+	o.Expect(c.ValidateUpdate(&ocOld)).Error().To(Succeed())
 }
 
 func TestClusterValidateUpdateOIDCclientIdMutableUpdateNameWorkloadCluster(t *testing.T) {
@@ -224,12 +204,12 @@ func TestValidateUpdateOIDCGroupsPrefixWorkloadCluster(t *testing.T) {
 
 func TestValidateUpdateOIDCIssuerUrlWorkloadCluster(t *testing.T) {
 	ocOld := oidcConfig()
-	ocOld.Spec.IssuerUrl = "test"
+	ocOld.Spec.IssuerUrl = "https://test.com"
 	ocOld.SetManagedBy("test")
 
 	c := ocOld.DeepCopy()
 
-	c.Spec.IssuerUrl = "test2"
+	c.Spec.IssuerUrl = "https://test2.com"
 	o := NewWithT(t)
 	o.Expect(c.ValidateUpdate(&ocOld)).Error().To(Succeed())
 }
@@ -285,11 +265,23 @@ func TestValidateUpdateOIDCRequiredClaimsMultipleWorkloadCluster(t *testing.T) {
 	o.Expect(c.ValidateUpdate(&ocOld)).Error().To(Succeed())
 }
 
+func TestValidateUpdateOIDCConfigInvalid(t *testing.T) {
+	ocOld := oidcConfig()
+	c := ocOld.DeepCopy()
+
+	c.Spec.IssuerUrl = "http://test.com"
+	o := NewWithT(t)
+	o.Expect(c.ValidateUpdate(&ocOld)).Error().To(MatchError(ContainSubstring("issuerUrl should have HTTPS scheme")))
+}
+
 func oidcConfig() v1alpha1.OIDCConfig {
 	return v1alpha1.OIDCConfig{
 		TypeMeta:   metav1.TypeMeta{},
 		ObjectMeta: metav1.ObjectMeta{Annotations: make(map[string]string, 1)},
-		Spec:       v1alpha1.OIDCConfigSpec{},
-		Status:     v1alpha1.OIDCConfigStatus{},
+		Spec: v1alpha1.OIDCConfigSpec{
+			ClientId:  "client",
+			IssuerUrl: "https://issuer.com",
+		},
+		Status: v1alpha1.OIDCConfigStatus{},
 	}
 }
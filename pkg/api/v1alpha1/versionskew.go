@@ -26,3 +26,37 @@ func ValidateVersionSkew(oldVersion, newVersion *version.Version) error {
 
 	return nil
 }
+
+// KubernetesUpgradePath returns the Kubernetes versions a cluster needs to go through to be upgraded
+// from current to target without exceeding the supported minor version skew. The result excludes
+// current and ends with target. It's empty if both versions have the same minor.
+func KubernetesUpgradePath(current, target KubernetesVersion) ([]KubernetesVersion, error) {
+	currentVersion, err := version.ParseGeneric(string(current))
+	if err != nil {
+		return nil, fmt.Errorf("parsing current kubernetes version %s: %v", current, err)
+	}
+
+	targetVersion, err := version.ParseGeneric(string(target))
+	if err != nil {
+		return nil, fmt.Errorf("parsing target kubernetes version %s: %v", target, err)
+	}
+
+	if currentVersion.Major() != targetVersion.Major() {
+		return nil, fmt.Errorf("kubernetes major version upgrade is not supported (%s) -> (%s)", current, target)
+	}
+
+	if targetVersion.Minor() < currentVersion.Minor() {
+		return nil, fmt.Errorf("kubernetes version downgrade is not supported (%s) -> (%s)", current, target)
+	}
+
+	var path []KubernetesVersion
+	for minor := currentVersion.Minor() + SupportedMinorVersionIncrement; minor < targetVersion.Minor(); minor += SupportedMinorVersionIncrement {
+		path = append(path, KubernetesVersion(fmt.Sprintf("%d.%d", currentVersion.Major(), minor)))
+	}
+
+	if targetVersion.Minor() != currentVersion.Minor() {
+		path = append(path, target)
+	}
+
+	return path, nil
+}
@@ -3,6 +3,7 @@ package v1alpha1_test
 import (
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	"k8s.io/apimachinery/pkg/util/version"
@@ -55,3 +56,61 @@ func TestValidateVersionSkew(t *testing.T) {
 		})
 	}
 }
+
+func TestKubernetesUpgradePath(t *testing.T) {
+	tests := []struct {
+		name    string
+		current v1alpha1.KubernetesVersion
+		target  v1alpha1.KubernetesVersion
+		want    []v1alpha1.KubernetesVersion
+		wantErr string
+	}{
+		{
+			name:    "same version",
+			current: v1alpha1.Kube127,
+			target:  v1alpha1.Kube127,
+			want:    nil,
+		},
+		{
+			name:    "one minor version",
+			current: v1alpha1.Kube127,
+			target:  v1alpha1.Kube128,
+			want:    []v1alpha1.KubernetesVersion{v1alpha1.Kube128},
+		},
+		{
+			name:    "multiple minor versions",
+			current: v1alpha1.Kube127,
+			target:  v1alpha1.Kube130,
+			want:    []v1alpha1.KubernetesVersion{v1alpha1.Kube128, v1alpha1.Kube129, v1alpha1.Kube130},
+		},
+		{
+			name:    "downgrade",
+			current: v1alpha1.Kube129,
+			target:  v1alpha1.Kube127,
+			wantErr: "kubernetes version downgrade is not supported (1.29) -> (1.27)",
+		},
+		{
+			name:    "invalid target",
+			current: v1alpha1.Kube129,
+			target:  "latest",
+			wantErr: "parsing target kubernetes version latest",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := v1alpha1.KubernetesUpgradePath(tt.current, tt.target)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("KubernetesUpgradePath() error = %v, wantErr = %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("KubernetesUpgradePath() unexpected error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("KubernetesUpgradePath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -22,6 +22,7 @@ package v1alpha1
 import (
 	snowapiv1beta1 "github.com/aws/eks-anywhere/pkg/providers/snow/api/v1beta1"
 	"k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -226,6 +227,100 @@ func (in *AWSIamConfigStatus) DeepCopy() *AWSIamConfigStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDConfig) DeepCopyInto(out *ArgoCDConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDConfig.
+func (in *ArgoCDConfig) DeepCopy() *ArgoCDConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ArgoCDConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDConfigList) DeepCopyInto(out *ArgoCDConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ArgoCDConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDConfigList.
+func (in *ArgoCDConfigList) DeepCopy() *ArgoCDConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ArgoCDConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDConfigSpec) DeepCopyInto(out *ArgoCDConfigSpec) {
+	*out = *in
+	if in.Git != nil {
+		in, out := &in.Git, &out.Git
+		*out = new(GitProviderConfig)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDConfigSpec.
+func (in *ArgoCDConfigSpec) DeepCopy() *ArgoCDConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDConfigStatus) DeepCopyInto(out *ArgoCDConfigStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDConfigStatus.
+func (in *ArgoCDConfigStatus) DeepCopy() *ArgoCDConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AutoScalingConfiguration) DeepCopyInto(out *AutoScalingConfiguration) {
 	*out = *in
@@ -241,6 +336,21 @@ func (in *AutoScalingConfiguration) DeepCopy() *AutoScalingConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BitbucketServerProviderConfig) DeepCopyInto(out *BitbucketServerProviderConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BitbucketServerProviderConfig.
+func (in *BitbucketServerProviderConfig) DeepCopy() *BitbucketServerProviderConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(BitbucketServerProviderConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BottlerocketConfiguration) DeepCopyInto(out *BottlerocketConfiguration) {
 	*out = *in
@@ -299,6 +409,11 @@ func (in *CNIConfig) DeepCopyInto(out *CNIConfig) {
 		*out = new(KindnetdConfig)
 		**out = **in
 	}
+	if in.Custom != nil {
+		in, out := &in.Custom, &out.Custom
+		*out = new(CustomCNIConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNIConfig.
@@ -311,6 +426,48 @@ func (in *CNIConfig) DeepCopy() *CNIConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CiliumBGPControlPlaneConfig) DeepCopyInto(out *CiliumBGPControlPlaneConfig) {
+	*out = *in
+	if in.Peers != nil {
+		in, out := &in.Peers, &out.Peers
+		*out = make([]CiliumBGPPeer, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CiliumBGPControlPlaneConfig.
+func (in *CiliumBGPControlPlaneConfig) DeepCopy() *CiliumBGPControlPlaneConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CiliumBGPControlPlaneConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CiliumBGPPeer) DeepCopyInto(out *CiliumBGPPeer) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CiliumBGPPeer.
+func (in *CiliumBGPPeer) DeepCopy() *CiliumBGPPeer {
+	if in == nil {
+		return nil
+	}
+	out := new(CiliumBGPPeer)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CiliumConfig) DeepCopyInto(out *CiliumConfig) {
 	*out = *in
@@ -319,6 +476,21 @@ func (in *CiliumConfig) DeepCopyInto(out *CiliumConfig) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Hubble != nil {
+		in, out := &in.Hubble, &out.Hubble
+		*out = new(CiliumHubbleConfig)
+		**out = **in
+	}
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(CiliumEncryptionConfig)
+		**out = **in
+	}
+	if in.BGPControlPlane != nil {
+		in, out := &in.BGPControlPlane, &out.BGPControlPlane
+		*out = new(CiliumBGPControlPlaneConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CiliumConfig.
@@ -331,6 +503,36 @@ func (in *CiliumConfig) DeepCopy() *CiliumConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CiliumEncryptionConfig) DeepCopyInto(out *CiliumEncryptionConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CiliumEncryptionConfig.
+func (in *CiliumEncryptionConfig) DeepCopy() *CiliumEncryptionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CiliumEncryptionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CiliumHubbleConfig) DeepCopyInto(out *CiliumHubbleConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CiliumHubbleConfig.
+func (in *CiliumHubbleConfig) DeepCopy() *CiliumHubbleConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CiliumHubbleConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CloudStackAvailabilityZone) DeepCopyInto(out *CloudStackAvailabilityZone) {
 	*out = *in
@@ -700,6 +902,11 @@ func (in *ClusterNetwork) DeepCopyInto(out *ClusterNetwork) {
 		*out = new(Nodes)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(NetworkPolicyConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterNetwork.
@@ -787,6 +994,21 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 			}
 		}
 	}
+	if in.UpgradePolicy != nil {
+		in, out := &in.UpgradePolicy, &out.UpgradePolicy
+		*out = new(UpgradePolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindow)
+		**out = **in
+	}
+	if in.EtcdBackup != nil {
+		in, out := &in.EtcdBackup, &out.EtcdBackup
+		*out = new(EtcdBackup)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSpec.
@@ -824,6 +1046,11 @@ func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.UpgradeCanary != nil {
+		in, out := &in.UpgradeCanary, &out.UpgradeCanary
+		*out = new(UpgradeCanaryStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStatus.
@@ -836,6 +1063,169 @@ func (in *ClusterStatus) DeepCopy() *ClusterStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterUpgradeBatch) DeepCopyInto(out *ClusterUpgradeBatch) {
+	*out = *in
+	in.ClusterSelector.DeepCopyInto(&out.ClusterSelector)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUpgradeBatch.
+func (in *ClusterUpgradeBatch) DeepCopy() *ClusterUpgradeBatch {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterUpgradeBatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterUpgradePlan) DeepCopyInto(out *ClusterUpgradePlan) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUpgradePlan.
+func (in *ClusterUpgradePlan) DeepCopy() *ClusterUpgradePlan {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterUpgradePlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterUpgradePlan) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterUpgradePlanList) DeepCopyInto(out *ClusterUpgradePlanList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterUpgradePlan, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUpgradePlanList.
+func (in *ClusterUpgradePlanList) DeepCopy() *ClusterUpgradePlanList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterUpgradePlanList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterUpgradePlanList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterUpgradePlanSpec) DeepCopyInto(out *ClusterUpgradePlanSpec) {
+	*out = *in
+	if in.EksaVersion != nil {
+		in, out := &in.EksaVersion, &out.EksaVersion
+		*out = new(EksaVersion)
+		**out = **in
+	}
+	if in.KubernetesVersion != nil {
+		in, out := &in.KubernetesVersion, &out.KubernetesVersion
+		*out = new(KubernetesVersion)
+		**out = **in
+	}
+	if in.Batches != nil {
+		in, out := &in.Batches, &out.Batches
+		*out = make([]ClusterUpgradeBatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindow)
+		**out = **in
+	}
+	if in.ClusterTimeout != nil {
+		in, out := &in.ClusterTimeout, &out.ClusterTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUpgradePlanSpec.
+func (in *ClusterUpgradePlanSpec) DeepCopy() *ClusterUpgradePlanSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterUpgradePlanSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterUpgradePlanStatus) DeepCopyInto(out *ClusterUpgradePlanStatus) {
+	*out = *in
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]ClusterUpgradeStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FailureMessage != nil {
+		in, out := &in.FailureMessage, &out.FailureMessage
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUpgradePlanStatus.
+func (in *ClusterUpgradePlanStatus) DeepCopy() *ClusterUpgradePlanStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterUpgradePlanStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterUpgradeStatus) DeepCopyInto(out *ClusterUpgradeStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUpgradeStatus.
+func (in *ClusterUpgradeStatus) DeepCopy() *ClusterUpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterUpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ControlPlaneConfiguration) DeepCopyInto(out *ControlPlaneConfiguration) {
 	*out = *in
@@ -940,93 +1330,173 @@ func (in *ControlPlaneUpgrade) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
-	return nil
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneUpgradeList) DeepCopyInto(out *ControlPlaneUpgradeList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ControlPlaneUpgrade, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneUpgradeList.
+func (in *ControlPlaneUpgradeList) DeepCopy() *ControlPlaneUpgradeList {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneUpgradeList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ControlPlaneUpgradeList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneUpgradeRolloutStrategy) DeepCopyInto(out *ControlPlaneUpgradeRolloutStrategy) {
+	*out = *in
+	if in.RollingUpdate != nil {
+		in, out := &in.RollingUpdate, &out.RollingUpdate
+		*out = new(ControlPlaneRollingUpdateParams)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneUpgradeRolloutStrategy.
+func (in *ControlPlaneUpgradeRolloutStrategy) DeepCopy() *ControlPlaneUpgradeRolloutStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneUpgradeRolloutStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneUpgradeSpec) DeepCopyInto(out *ControlPlaneUpgradeSpec) {
+	*out = *in
+	out.ControlPlane = in.ControlPlane
+	if in.MachinesRequireUpgrade != nil {
+		in, out := &in.MachinesRequireUpgrade, &out.MachinesRequireUpgrade
+		*out = make([]v1.ObjectReference, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneUpgradeSpec.
+func (in *ControlPlaneUpgradeSpec) DeepCopy() *ControlPlaneUpgradeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneUpgradeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneUpgradeStatus) DeepCopyInto(out *ControlPlaneUpgradeStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneUpgradeStatus.
+func (in *ControlPlaneUpgradeStatus) DeepCopy() *ControlPlaneUpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneUpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ControlPlaneUpgradeList) DeepCopyInto(out *ControlPlaneUpgradeList) {
+func (in *CustomCNIConfig) DeepCopyInto(out *CustomCNIConfig) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]ControlPlaneUpgrade, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.HelmChart != nil {
+		in, out := &in.HelmChart, &out.HelmChart
+		*out = new(CustomCNIHelmChart)
+		**out = **in
+	}
+	if in.ReadinessCheck != nil {
+		in, out := &in.ReadinessCheck, &out.ReadinessCheck
+		*out = new(CustomCNIReadinessCheck)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneUpgradeList.
-func (in *ControlPlaneUpgradeList) DeepCopy() *ControlPlaneUpgradeList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomCNIConfig.
+func (in *CustomCNIConfig) DeepCopy() *CustomCNIConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(ControlPlaneUpgradeList)
+	out := new(CustomCNIConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ControlPlaneUpgradeList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ControlPlaneUpgradeRolloutStrategy) DeepCopyInto(out *ControlPlaneUpgradeRolloutStrategy) {
+func (in *CustomCNIHelmChart) DeepCopyInto(out *CustomCNIHelmChart) {
 	*out = *in
-	if in.RollingUpdate != nil {
-		in, out := &in.RollingUpdate, &out.RollingUpdate
-		*out = new(ControlPlaneRollingUpdateParams)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneUpgradeRolloutStrategy.
-func (in *ControlPlaneUpgradeRolloutStrategy) DeepCopy() *ControlPlaneUpgradeRolloutStrategy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomCNIHelmChart.
+func (in *CustomCNIHelmChart) DeepCopy() *CustomCNIHelmChart {
 	if in == nil {
 		return nil
 	}
-	out := new(ControlPlaneUpgradeRolloutStrategy)
+	out := new(CustomCNIHelmChart)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ControlPlaneUpgradeSpec) DeepCopyInto(out *ControlPlaneUpgradeSpec) {
+func (in *CustomCNIReadinessCheck) DeepCopyInto(out *CustomCNIReadinessCheck) {
 	*out = *in
-	out.ControlPlane = in.ControlPlane
-	if in.MachinesRequireUpgrade != nil {
-		in, out := &in.MachinesRequireUpgrade, &out.MachinesRequireUpgrade
-		*out = make([]v1.ObjectReference, len(*in))
+	if in.DaemonSets != nil {
+		in, out := &in.DaemonSets, &out.DaemonSets
+		*out = make([]CustomCNIWorkload, len(*in))
+		copy(*out, *in)
+	}
+	if in.Deployments != nil {
+		in, out := &in.Deployments, &out.Deployments
+		*out = make([]CustomCNIWorkload, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneUpgradeSpec.
-func (in *ControlPlaneUpgradeSpec) DeepCopy() *ControlPlaneUpgradeSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomCNIReadinessCheck.
+func (in *CustomCNIReadinessCheck) DeepCopy() *CustomCNIReadinessCheck {
 	if in == nil {
 		return nil
 	}
-	out := new(ControlPlaneUpgradeSpec)
+	out := new(CustomCNIReadinessCheck)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ControlPlaneUpgradeStatus) DeepCopyInto(out *ControlPlaneUpgradeStatus) {
+func (in *CustomCNIWorkload) DeepCopyInto(out *CustomCNIWorkload) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneUpgradeStatus.
-func (in *ControlPlaneUpgradeStatus) DeepCopy() *ControlPlaneUpgradeStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomCNIWorkload.
+func (in *CustomCNIWorkload) DeepCopy() *CustomCNIWorkload {
 	if in == nil {
 		return nil
 	}
-	out := new(ControlPlaneUpgradeStatus)
+	out := new(CustomCNIWorkload)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1170,6 +1640,61 @@ func (in *Endpoint) DeepCopy() *Endpoint {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdBackup) DeepCopyInto(out *EtcdBackup) {
+	*out = *in
+	if in.PersistentVolumeClaim != nil {
+		in, out := &in.PersistentVolumeClaim, &out.PersistentVolumeClaim
+		*out = new(EtcdBackupPersistentVolumeClaim)
+		**out = **in
+	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(EtcdBackupS3)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdBackup.
+func (in *EtcdBackup) DeepCopy() *EtcdBackup {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdBackup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdBackupPersistentVolumeClaim) DeepCopyInto(out *EtcdBackupPersistentVolumeClaim) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdBackupPersistentVolumeClaim.
+func (in *EtcdBackupPersistentVolumeClaim) DeepCopy() *EtcdBackupPersistentVolumeClaim {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdBackupPersistentVolumeClaim)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdBackupS3) DeepCopyInto(out *EtcdBackupS3) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdBackupS3.
+func (in *EtcdBackupS3) DeepCopy() *EtcdBackupS3 {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdBackupS3)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EtcdEncryption) DeepCopyInto(out *EtcdEncryption) {
 	*out = *in
@@ -1340,6 +1865,16 @@ func (in *FluxConfigSpec) DeepCopyInto(out *FluxConfigSpec) {
 		*out = new(GitProviderConfig)
 		**out = **in
 	}
+	if in.Gitlab != nil {
+		in, out := &in.Gitlab, &out.Gitlab
+		*out = new(GitlabProviderConfig)
+		**out = **in
+	}
+	if in.BitbucketServer != nil {
+		in, out := &in.BitbucketServer, &out.BitbucketServer
+		*out = new(BitbucketServerProviderConfig)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluxConfigSpec.
@@ -1502,6 +2037,21 @@ func (in *GithubProviderConfig) DeepCopy() *GithubProviderConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitlabProviderConfig) DeepCopyInto(out *GitlabProviderConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitlabProviderConfig.
+func (in *GitlabProviderConfig) DeepCopy() *GitlabProviderConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GitlabProviderConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in HardwareSelector) DeepCopyInto(out *HardwareSelector) {
 	{
@@ -1748,6 +2298,22 @@ func (in *MachineHealthCheck) DeepCopy() *MachineHealthCheck {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ManagementCluster) DeepCopyInto(out *ManagementCluster) {
 	*out = *in
@@ -1823,6 +2389,62 @@ func (in *NTPConfiguration) DeepCopy() *NTPConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicyAllowRule) DeepCopyInto(out *NetworkPolicyAllowRule) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = make([]networkingv1.NetworkPolicyIngressRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Egress != nil {
+		in, out := &in.Egress, &out.Egress
+		*out = make([]networkingv1.NetworkPolicyEgressRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyAllowRule.
+func (in *NetworkPolicyAllowRule) DeepCopy() *NetworkPolicyAllowRule {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicyAllowRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicyConfig) DeepCopyInto(out *NetworkPolicyConfig) {
+	*out = *in
+	if in.AllowRules != nil {
+		in, out := &in.AllowRules, &out.AllowRules
+		*out = make([]NetworkPolicyAllowRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyConfig.
+func (in *NetworkPolicyConfig) DeepCopy() *NetworkPolicyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeUpgrade) DeepCopyInto(out *NodeUpgrade) {
 	*out = *in
@@ -1933,6 +2555,11 @@ func (in *Nodes) DeepCopyInto(out *Nodes) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.CIDRMaskSizeIPv6 != nil {
+		in, out := &in.CIDRMaskSizeIPv6, &out.CIDRMaskSizeIPv6
+		*out = new(int)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Nodes.
@@ -3289,6 +3916,158 @@ func (in *TinkerbellTemplateConfigStatus) DeepCopy() *TinkerbellTemplateConfigSt
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeCanary) DeepCopyInto(out *UpgradeCanary) {
+	*out = *in
+	in.HealthCheck.DeepCopyInto(&out.HealthCheck)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeCanary.
+func (in *UpgradeCanary) DeepCopy() *UpgradeCanary {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeCanary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeCanaryHTTPCheck) DeepCopyInto(out *UpgradeCanaryHTTPCheck) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeCanaryHTTPCheck.
+func (in *UpgradeCanaryHTTPCheck) DeepCopy() *UpgradeCanaryHTTPCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeCanaryHTTPCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeCanaryHealthCheck) DeepCopyInto(out *UpgradeCanaryHealthCheck) {
+	*out = *in
+	if in.Job != nil {
+		in, out := &in.Job, &out.Job
+		*out = new(UpgradeCanaryJobCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(UpgradeCanaryHTTPCheck)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeCanaryHealthCheck.
+func (in *UpgradeCanaryHealthCheck) DeepCopy() *UpgradeCanaryHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeCanaryHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeCanaryJobCheck) DeepCopyInto(out *UpgradeCanaryJobCheck) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeCanaryJobCheck.
+func (in *UpgradeCanaryJobCheck) DeepCopy() *UpgradeCanaryJobCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeCanaryJobCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeCanaryStatus) DeepCopyInto(out *UpgradeCanaryStatus) {
+	*out = *in
+	if in.HealthCheckStartTime != nil {
+		in, out := &in.HealthCheckStartTime, &out.HealthCheckStartTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeCanaryStatus.
+func (in *UpgradeCanaryStatus) DeepCopy() *UpgradeCanaryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeCanaryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradePolicy) DeepCopyInto(out *UpgradePolicy) {
+	*out = *in
+	if in.Waves != nil {
+		in, out := &in.Waves, &out.Waves
+		*out = make([]UpgradeWave, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(UpgradeCanary)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradePolicy.
+func (in *UpgradePolicy) DeepCopy() *UpgradePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeWave) DeepCopyInto(out *UpgradeWave) {
+	*out = *in
+	if in.WorkerNodeGroups != nil {
+		in, out := &in.WorkerNodeGroups, &out.WorkerNodeGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeWave.
+func (in *UpgradeWave) DeepCopy() *UpgradeWave {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeWave)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UserConfiguration) DeepCopyInto(out *UserConfiguration) {
 	*out = *in
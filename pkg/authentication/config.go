@@ -0,0 +1,152 @@
+package authentication
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"sigs.k8s.io/yaml"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+const (
+	// ConfigFileName is the name of the structured authentication config file and the key
+	// holding its content in the authentication config secrets.
+	ConfigFileName = "authentication-config.yaml"
+
+	// HostDir is the directory of the control plane nodes where the authentication config is written.
+	HostDir = "/var/lib/kubeadm/authentication"
+
+	// MountDir is the directory where HostDir is mounted in the kube-apiserver pod.
+	// The whole directory is mounted instead of the file so replacing the file on the
+	// host is visible to the API server, which reloads it without a restart.
+	MountDir = "/etc/kubernetes/authentication"
+
+	configAPIVersion = "apiserver.config.k8s.io/v1beta1"
+	configKind       = "AuthenticationConfiguration"
+
+	defaultUsernameClaim = "sub"
+	emailClaim           = "email"
+	noPrefix             = "-"
+)
+
+// ConfigSecretName returns the name of the secret in the management cluster holding the
+// authentication config for a cluster.
+func ConfigSecretName(clusterName string) string {
+	return fmt.Sprintf("%s-authentication-config", clusterName)
+}
+
+// HostConfigPath returns the path of the authentication config file in the control plane nodes.
+func HostConfigPath() string {
+	return filepath.Join(HostDir, ConfigFileName)
+}
+
+// APIServerConfigPath returns the path of the authentication config file in the kube-apiserver pod.
+func APIServerConfigPath() string {
+	return filepath.Join(MountDir, ConfigFileName)
+}
+
+// authenticationConfiguration mirrors the apiserver.config.k8s.io/v1beta1 AuthenticationConfiguration,
+// only including the fields that can be configured through an OIDCConfig.
+type authenticationConfiguration struct {
+	APIVersion string             `json:"apiVersion"`
+	Kind       string             `json:"kind"`
+	JWT        []jwtAuthenticator `json:"jwt"`
+}
+
+type jwtAuthenticator struct {
+	Issuer               issuer                `json:"issuer"`
+	ClaimValidationRules []claimValidationRule `json:"claimValidationRules,omitempty"`
+	ClaimMappings        claimMappings         `json:"claimMappings"`
+}
+
+type issuer struct {
+	URL       string   `json:"url"`
+	Audiences []string `json:"audiences"`
+}
+
+type claimValidationRule struct {
+	Claim         string `json:"claim"`
+	RequiredValue string `json:"requiredValue"`
+}
+
+type claimMappings struct {
+	Username prefixedClaim  `json:"username"`
+	Groups   *prefixedClaim `json:"groups,omitempty"`
+}
+
+type prefixedClaim struct {
+	Claim  string `json:"claim"`
+	Prefix string `json:"prefix"`
+}
+
+// Config generates the structured authentication config with one JWT authenticator per OIDCConfig,
+// keeping the same semantics as the kube-apiserver oidc flags.
+func Config(oidcConfigs []*anywherev1.OIDCConfig) ([]byte, error) {
+	config := authenticationConfiguration{
+		APIVersion: configAPIVersion,
+		Kind:       configKind,
+		JWT:        make([]jwtAuthenticator, 0, len(oidcConfigs)),
+	}
+
+	for _, oidc := range oidcConfigs {
+		config.JWT = append(config.JWT, jwtAuthenticatorFromOIDC(oidc))
+	}
+
+	content, err := yaml.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling authentication config: %v", err)
+	}
+
+	return content, nil
+}
+
+func jwtAuthenticatorFromOIDC(oidc *anywherev1.OIDCConfig) jwtAuthenticator {
+	authenticator := jwtAuthenticator{
+		Issuer: issuer{
+			URL:       oidc.Spec.IssuerUrl,
+			Audiences: []string{oidc.Spec.ClientId},
+		},
+		ClaimMappings: claimMappings{
+			Username: usernameMapping(oidc.Spec),
+		},
+	}
+
+	for _, r := range oidc.Spec.RequiredClaims {
+		if r.Claim == "" {
+			continue
+		}
+		authenticator.ClaimValidationRules = append(authenticator.ClaimValidationRules, claimValidationRule{
+			Claim:         r.Claim,
+			RequiredValue: r.Value,
+		})
+	}
+
+	if oidc.Spec.GroupsClaim != "" {
+		authenticator.ClaimMappings.Groups = &prefixedClaim{
+			Claim:  oidc.Spec.GroupsClaim,
+			Prefix: oidc.Spec.GroupsPrefix,
+		}
+	}
+
+	return authenticator
+}
+
+// usernameMapping follows the --oidc-username-prefix defaults: claims other than email are
+// prefixed with the issuer URL unless a prefix is provided, and "-" disables the prefix.
+func usernameMapping(spec anywherev1.OIDCConfigSpec) prefixedClaim {
+	claim := spec.UsernameClaim
+	if claim == "" {
+		claim = defaultUsernameClaim
+	}
+
+	prefix := spec.UsernamePrefix
+	switch {
+	case prefix == noPrefix:
+		prefix = ""
+	case prefix == "" && claim != emailClaim:
+		prefix = spec.IssuerUrl + "#"
+	}
+
+	return prefixedClaim{Claim: claim, Prefix: prefix}
+}
@@ -0,0 +1,140 @@
+package authentication_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/authentication"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		oidcConfigs []*anywherev1.OIDCConfig
+		want        string
+	}{
+		{
+			name: "defaults",
+			oidcConfigs: []*anywherev1.OIDCConfig{
+				oidcConfig("https://issuer.com", "client", nil),
+			},
+			want: `apiVersion: apiserver.config.k8s.io/v1beta1
+jwt:
+- claimMappings:
+    username:
+      claim: sub
+      prefix: https://issuer.com#
+  issuer:
+    audiences:
+    - client
+    url: https://issuer.com
+kind: AuthenticationConfiguration
+`,
+		},
+		{
+			name: "multiple issuers",
+			oidcConfigs: []*anywherev1.OIDCConfig{
+				oidcConfig("https://issuer.com", "client", func(s *anywherev1.OIDCConfigSpec) {
+					s.GroupsClaim = "groups"
+					s.GroupsPrefix = "oidc:"
+					s.UsernameClaim = "email"
+					s.RequiredClaims = []anywherev1.OIDCConfigRequiredClaim{
+						{Claim: "hd", Value: "example.com"},
+						{Claim: "aud", Value: "client"},
+					}
+				}),
+				oidcConfig("https://other-issuer.com", "other-client", func(s *anywherev1.OIDCConfigSpec) {
+					s.UsernameClaim = "preferred_username"
+					s.UsernamePrefix = "-"
+				}),
+			},
+			want: `apiVersion: apiserver.config.k8s.io/v1beta1
+jwt:
+- claimMappings:
+    groups:
+      claim: groups
+      prefix: 'oidc:'
+    username:
+      claim: email
+      prefix: ""
+  claimValidationRules:
+  - claim: hd
+    requiredValue: example.com
+  - claim: aud
+    requiredValue: client
+  issuer:
+    audiences:
+    - client
+    url: https://issuer.com
+- claimMappings:
+    username:
+      claim: preferred_username
+      prefix: ""
+  issuer:
+    audiences:
+    - other-client
+    url: https://other-issuer.com
+kind: AuthenticationConfiguration
+`,
+		},
+		{
+			name: "custom username prefix",
+			oidcConfigs: []*anywherev1.OIDCConfig{
+				oidcConfig("https://issuer.com", "client", func(s *anywherev1.OIDCConfigSpec) {
+					s.UsernamePrefix = "oidc:"
+				}),
+			},
+			want: `apiVersion: apiserver.config.k8s.io/v1beta1
+jwt:
+- claimMappings:
+    username:
+      claim: sub
+      prefix: 'oidc:'
+  issuer:
+    audiences:
+    - client
+    url: https://issuer.com
+kind: AuthenticationConfiguration
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			got, err := authentication.Config(tt.oidcConfigs)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(string(got)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestConfigSecretName(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(authentication.ConfigSecretName("my-cluster")).To(Equal("my-cluster-authentication-config"))
+}
+
+func TestConfigPaths(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(authentication.HostConfigPath()).To(Equal("/var/lib/kubeadm/authentication/authentication-config.yaml"))
+	g.Expect(authentication.APIServerConfigPath()).To(Equal("/etc/kubernetes/authentication/authentication-config.yaml"))
+}
+
+func oidcConfig(issuer, clientID string, opt func(*anywherev1.OIDCConfigSpec)) *anywherev1.OIDCConfig {
+	o := &anywherev1.OIDCConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: clientID,
+		},
+		Spec: anywherev1.OIDCConfigSpec{
+			IssuerUrl: issuer,
+			ClientId:  clientID,
+		},
+	}
+	if opt != nil {
+		opt(&o.Spec)
+	}
+
+	return o
+}
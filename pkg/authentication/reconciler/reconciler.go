@@ -0,0 +1,217 @@
+package reconciler
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/authentication"
+	anywhereCluster "github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
+	"github.com/aws/eks-anywhere/pkg/controller/clusters"
+	"github.com/aws/eks-anywhere/pkg/controller/reconcileutil"
+	"github.com/aws/eks-anywhere/pkg/nodeupgrader"
+)
+
+const (
+	// ConfigHashAnnotation holds the hash of the authentication config applied to a cluster or a control plane node.
+	ConfigHashAnnotation = "anywhere.eks.amazonaws.com/authentication-config-hash"
+
+	// ConfigSecretName is the name of the secret created in the workload cluster
+	// with the authentication config consumed by the node updater pods.
+	ConfigSecretName = "authentication-config"
+
+	configHashLabel     = "anywhere.eks.amazonaws.com/authentication-config-hash"
+	controlPlaneLabel   = "node-role.kubernetes.io/control-plane"
+	requeueAfter        = 10 * time.Second
+	failureRequeueAfter = time.Minute
+)
+
+// RemoteClientRegistry defines methods for remote cluster controller clients.
+type RemoteClientRegistry interface {
+	GetClient(ctx context.Context, cluster client.ObjectKey) (client.Client, error)
+}
+
+// Reconciler keeps the structured authentication config of the control plane nodes of a cluster
+// up to date with its OIDCConfigs, without rolling out new machines.
+type Reconciler struct {
+	client               client.Client
+	remoteClientRegistry RemoteClientRegistry
+}
+
+// New returns a new Reconciler.
+func New(client client.Client, remoteClientRegistry RemoteClientRegistry) *Reconciler {
+	return &Reconciler{
+		client:               client,
+		remoteClientRegistry: remoteClientRegistry,
+	}
+}
+
+// Reconcile generates the authentication config for the OIDCConfigs referenced by the cluster, stores it
+// in the secret used to bootstrap new control plane machines and writes it to every existing control plane
+// node, where the kube-apiserver reloads it. The hash of the applied config is tracked in the cluster and
+// node annotations and progress is reported through the AuthenticationConfigUpdated condition.
+// It's a noop for clusters that don't use structured authentication.
+// It uses a controller.Result to indicate when requeues are needed.
+func (r *Reconciler) Reconcile(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) (controller.Result, error) {
+	if !cluster.UsesStructuredAuthentication() {
+		return controller.Result{}, nil
+	}
+
+	oidcConfigs, err := r.oidcConfigs(ctx, cluster)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	config, err := authentication.Config(oidcConfigs)
+	if err != nil {
+		return controller.Result{}, err
+	}
+	hash := reconcileutil.ShortHash(config)
+
+	// New control plane machines are bootstrapped with the config in this secret, so it needs
+	// to be up to date before they are created and before touching the existing nodes.
+	if err := reconcileutil.CreateOrUpdateSecret(ctx, r.client, bootstrapSecret(cluster.Name, config)); err != nil {
+		return controller.Result{}, errors.Wrap(err, "updating authentication config secret")
+	}
+
+	appliedHash, ok := cluster.Annotations[ConfigHashAnnotation]
+	if !ok {
+		// Nodes created before the config was tracked have been bootstrapped with the current one.
+		log.Info("Tracking authentication config for the first time")
+		clientutil.AddAnnotation(cluster, ConfigHashAnnotation, hash)
+		conditions.MarkTrue(cluster, anywherev1.AuthenticationConfigUpdatedCondition)
+		return controller.Result{}, nil
+	}
+
+	if appliedHash == hash {
+		conditions.MarkTrue(cluster, anywherev1.AuthenticationConfigUpdatedCondition)
+		return controller.Result{}, nil
+	}
+
+	log.Info("OIDC configuration has changed, updating the authentication config in place")
+	conditions.MarkFalse(cluster, anywherev1.AuthenticationConfigUpdatedCondition, anywherev1.AuthenticationConfigUpdateInProgressReason, clusterv1.ConditionSeverityInfo, "Waiting for control plane to be ready")
+
+	result, err := clusters.CheckControlPlaneReady(ctx, r.client, log, cluster)
+	if err != nil {
+		return controller.Result{}, errors.Wrap(err, "checking controlplane ready")
+	}
+	if result.Return() {
+		return result, nil
+	}
+
+	spec, err := anywhereCluster.BuildSpec(ctx, clientutil.NewKubeClient(r.client), cluster)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	rClient, err := r.remoteClientRegistry.GetClient(ctx, controller.CapiClusterObjectKey(cluster))
+	if err != nil {
+		return controller.Result{}, errors.Wrap(err, "getting workload cluster's client to update authentication config")
+	}
+
+	if err := reconcileutil.EnsureNamespace(ctx, rClient, constants.EksaSystemNamespace); err != nil {
+		return controller.Result{}, err
+	}
+
+	if err := reconcileutil.CreateOrUpdateSecret(ctx, rClient, workloadSecret(hash, config)); err != nil {
+		return controller.Result{}, errors.Wrap(err, "updating authentication config secret in workload cluster")
+	}
+
+	nodes := &corev1.NodeList{}
+	if err := rClient.List(ctx, nodes, client.HasLabels{controlPlaneLabel}); err != nil {
+		return controller.Result{}, errors.Wrap(err, "listing control plane nodes in workload cluster")
+	}
+
+	progress, err := nodeUpdater(spec.RootVersionsBundle().Upgrader.Upgrader.VersionedImage(), hash).UpdateNodes(ctx, log, rClient, nodes.Items)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	if len(progress.Failed) > 0 {
+		conditions.MarkFalse(cluster, anywherev1.AuthenticationConfigUpdatedCondition, anywherev1.AuthenticationConfigUpdateFailedReason, clusterv1.ConditionSeverityError,
+			"Updating authentication config failed on nodes: %s", strings.Join(progress.Failed, ", "))
+		return controller.ResultWithRequeue(failureRequeueAfter), nil
+	}
+
+	if progress.Updated < progress.Total {
+		conditions.MarkFalse(cluster, anywherev1.AuthenticationConfigUpdatedCondition, anywherev1.AuthenticationConfigUpdateInProgressReason, clusterv1.ConditionSeverityInfo,
+			"%d of %d control plane nodes have the new authentication config", progress.Updated, progress.Total)
+		return controller.ResultWithRequeue(requeueAfter), nil
+	}
+
+	log.Info("Authentication config updated on all control plane nodes")
+	clientutil.AddAnnotation(cluster, ConfigHashAnnotation, hash)
+	conditions.MarkTrue(cluster, anywherev1.AuthenticationConfigUpdatedCondition)
+
+	return controller.Result{}, nil
+}
+
+// oidcConfigs returns the OIDCConfigs referenced by the cluster, in the order of its identityProviderRefs.
+func (r *Reconciler) oidcConfigs(ctx context.Context, cluster *anywherev1.Cluster) ([]*anywherev1.OIDCConfig, error) {
+	names := cluster.OIDCConfigRefs()
+	oidcConfigs := make([]*anywherev1.OIDCConfig, 0, len(names))
+	for _, name := range names {
+		oidc := &anywherev1.OIDCConfig{}
+		if err := r.client.Get(ctx, client.ObjectKey{Name: name, Namespace: cluster.Namespace}, oidc); err != nil {
+			return nil, errors.Wrapf(err, "getting OIDCConfig %s", name)
+		}
+		oidcConfigs = append(oidcConfigs, oidc)
+	}
+
+	return oidcConfigs, nil
+}
+
+func nodeUpdater(image, hash string) reconcileutil.NodeUpdater {
+	return reconcileutil.NodeUpdater{
+		Description:    "authentication config",
+		Hash:           hash,
+		HashAnnotation: ConfigHashAnnotation,
+		HashLabel:      configHashLabel,
+		PodName:        nodeupgrader.AuthenticationConfigPodName,
+		Pod: func(node *corev1.Node) *corev1.Pod {
+			return nodeupgrader.UpdateAuthenticationConfigPod(node.Name, image, ConfigSecretName)
+		},
+	}
+}
+
+func bootstrapSecret(clusterName string, config []byte) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      authentication.ConfigSecretName(clusterName),
+			Namespace: constants.EksaSystemNamespace,
+			Labels: map[string]string{
+				constants.ClusterctlMoveLabelName: "true",
+			},
+		},
+		Data: map[string][]byte{
+			authentication.ConfigFileName: config,
+		},
+	}
+}
+
+func workloadSecret(hash string, config []byte) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ConfigSecretName,
+			Namespace: constants.EksaSystemNamespace,
+			Labels: map[string]string{
+				configHashLabel: hash,
+			},
+		},
+		Data: map[string][]byte{
+			authentication.ConfigFileName: config,
+		},
+	}
+}
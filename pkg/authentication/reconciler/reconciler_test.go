@@ -0,0 +1,319 @@
+package reconciler_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	eksdv1 "github.com/aws/eks-distro-build-tooling/release/api/v1alpha1"
+	"github.com/go-logr/logr"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/authentication"
+	"github.com/aws/eks-anywhere/pkg/authentication/reconciler"
+	"github.com/aws/eks-anywhere/pkg/authentication/reconciler/mocks"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/nodeupgrader"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+type reconcilerTest struct {
+	*WithT
+	ctx                  context.Context
+	cluster              *anywherev1.Cluster
+	client               client.Client
+	remoteClient         client.Client
+	remoteClientRegistry *mocks.MockRemoteClientRegistry
+	reconciler           *reconciler.Reconciler
+}
+
+func newReconcilerTest(t *testing.T, nodes ...*corev1.Node) *reconcilerTest {
+	ctrl := gomock.NewController(t)
+	remoteClientRegistry := mocks.NewMockRemoteClientRegistry(ctrl)
+
+	bundle := test.Bundle()
+	bundle.Spec.VersionsBundles[0].KubeVersion = string(anywherev1.Kube130)
+	version := test.DevEksaVersion()
+	cluster := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster",
+			Namespace: constants.EksaSystemNamespace,
+		},
+		Spec: anywherev1.ClusterSpec{
+			KubernetesVersion: anywherev1.Kube130,
+			BundlesRef: &anywherev1.BundlesRef{
+				Name:       bundle.Name,
+				Namespace:  bundle.Namespace,
+				APIVersion: bundle.APIVersion,
+			},
+			EksaVersion: &version,
+			IdentityProviderRefs: []anywherev1.Ref{
+				{Kind: anywherev1.OIDCConfigKind, Name: "my-oidc"},
+			},
+		},
+	}
+	oidc := &anywherev1.OIDCConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-oidc",
+			Namespace: constants.EksaSystemNamespace,
+		},
+		Spec: anywherev1.OIDCConfigSpec{
+			ClientId:  "my-client",
+			IssuerUrl: "https://issuer.com",
+		},
+	}
+	kcp := test.KubeadmControlPlane(func(kcp *controlplanev1.KubeadmControlPlane) {
+		kcp.Name = cluster.Name
+		kcp.Spec.Version = "test"
+		kcp.Status = controlplanev1.KubeadmControlPlaneStatus{
+			Conditions: clusterv1.Conditions{
+				{
+					Type:               clusterapi.ReadyCondition,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(time.Now()),
+				},
+			},
+			Version: pointer.String("test"),
+		}
+	})
+
+	scheme := runtime.NewScheme()
+	_ = releasev1.AddToScheme(scheme)
+	_ = eksdv1.AddToScheme(scheme)
+	_ = clusterv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+	_ = controlplanev1.AddToScheme(scheme)
+	_ = anywherev1.AddToScheme(scheme)
+
+	objs := []runtime.Object{bundle, test.EksdRelease("1-30"), test.EKSARelease(), kcp, oidc}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objs...).Build()
+
+	remoteObjs := make([]runtime.Object, 0, len(nodes))
+	for _, n := range nodes {
+		remoteObjs = append(remoteObjs, n)
+	}
+	remoteClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(remoteObjs...).Build()
+
+	return &reconcilerTest{
+		WithT:                NewWithT(t),
+		ctx:                  context.Background(),
+		cluster:              cluster,
+		client:               cl,
+		remoteClient:         remoteClient,
+		remoteClientRegistry: remoteClientRegistry,
+		reconciler:           reconciler.New(cl, remoteClientRegistry),
+	}
+}
+
+func (tt *reconcilerTest) updateOIDCGroupsClaim(groupsClaim string) {
+	oidc := &anywherev1.OIDCConfig{}
+	tt.Expect(tt.client.Get(tt.ctx, client.ObjectKey{Name: "my-oidc", Namespace: constants.EksaSystemNamespace}, oidc)).To(Succeed())
+	oidc.Spec.GroupsClaim = groupsClaim
+	tt.Expect(tt.client.Update(tt.ctx, oidc)).To(Succeed())
+}
+
+func (tt *reconcilerTest) expectRemoteClient() {
+	tt.remoteClientRegistry.EXPECT().GetClient(tt.ctx, client.ObjectKey{Name: tt.cluster.Name, Namespace: constants.EksaSystemNamespace}).Return(tt.remoteClient, nil)
+}
+
+func (tt *reconcilerTest) setPodPhase(nodeName string, phase corev1.PodPhase) {
+	pod := &corev1.Pod{}
+	tt.Expect(tt.remoteClient.Get(tt.ctx, client.ObjectKey{Name: nodeupgrader.AuthenticationConfigPodName(nodeName), Namespace: constants.EksaSystemNamespace}, pod)).To(Succeed())
+	pod.Status.Phase = phase
+	tt.Expect(tt.remoteClient.Status().Update(tt.ctx, pod)).To(Succeed())
+}
+
+func (tt *reconcilerTest) expectCondition(status corev1.ConditionStatus, reason string) {
+	condition := conditions.Get(tt.cluster, anywherev1.AuthenticationConfigUpdatedCondition)
+	tt.Expect(condition).ToNot(BeNil())
+	tt.Expect(condition.Status).To(Equal(status))
+	tt.Expect(condition.Reason).To(Equal(reason))
+}
+
+func (tt *reconcilerTest) bootstrapConfig() string {
+	secret := &corev1.Secret{}
+	tt.Expect(tt.client.Get(tt.ctx, client.ObjectKey{Name: "my-cluster-authentication-config", Namespace: constants.EksaSystemNamespace}, secret)).To(Succeed())
+	return string(secret.Data[authentication.ConfigFileName])
+}
+
+func controlPlaneNode(name string) *corev1.Node {
+	n := workerNode(name)
+	n.Labels = map[string]string{"node-role.kubernetes.io/control-plane": ""}
+	return n
+}
+
+func workerNode(name string) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+	}
+}
+
+func nullLog() logr.Logger {
+	return logr.New(logf.NullLogSink{})
+}
+
+func TestReconcileNoStructuredAuthentication(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.cluster.Spec.KubernetesVersion = anywherev1.Kube129
+
+	result, err := tt.reconciler.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+	tt.Expect(tt.cluster.Annotations).NotTo(HaveKey(reconciler.ConfigHashAnnotation))
+	tt.Expect(conditions.Get(tt.cluster, anywherev1.AuthenticationConfigUpdatedCondition)).To(BeNil())
+}
+
+func TestReconcileMissingOIDCConfig(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.cluster.Spec.IdentityProviderRefs = append(tt.cluster.Spec.IdentityProviderRefs, anywherev1.Ref{
+		Kind: anywherev1.OIDCConfigKind,
+		Name: "missing-oidc",
+	})
+
+	_, err := tt.reconciler.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).To(MatchError(ContainSubstring("getting OIDCConfig missing-oidc")))
+}
+
+func TestReconcileFirstTimeTracksConfig(t *testing.T) {
+	tt := newReconcilerTest(t)
+
+	result, err := tt.reconciler.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+	tt.Expect(tt.cluster.Annotations).To(HaveKey(reconciler.ConfigHashAnnotation))
+	tt.Expect(conditions.IsTrue(tt.cluster, anywherev1.AuthenticationConfigUpdatedCondition)).To(BeTrue())
+	tt.Expect(tt.bootstrapConfig()).To(ContainSubstring("url: https://issuer.com"))
+
+	// Reconciling again with the same config is a no-op
+	hash := tt.cluster.Annotations[reconciler.ConfigHashAnnotation]
+	result, err = tt.reconciler.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+	tt.Expect(tt.cluster.Annotations[reconciler.ConfigHashAnnotation]).To(Equal(hash))
+	tt.Expect(conditions.IsTrue(tt.cluster, anywherev1.AuthenticationConfigUpdatedCondition)).To(BeTrue())
+}
+
+func TestReconcileUpdateConfig(t *testing.T) {
+	tt := newReconcilerTest(t, controlPlaneNode("cp-node-1"), controlPlaneNode("cp-node-2"), workerNode("worker-node"))
+	_, err := tt.reconciler.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	oldHash := tt.cluster.Annotations[reconciler.ConfigHashAnnotation]
+
+	tt.updateOIDCGroupsClaim("groups")
+
+	tt.expectRemoteClient()
+	result, err := tt.reconciler.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result.Return()).To(BeTrue())
+	tt.Expect(tt.cluster.Annotations[reconciler.ConfigHashAnnotation]).To(Equal(oldHash))
+	tt.expectCondition(corev1.ConditionFalse, anywherev1.AuthenticationConfigUpdateInProgressReason)
+	tt.Expect(conditions.GetMessage(tt.cluster, anywherev1.AuthenticationConfigUpdatedCondition)).To(Equal("0 of 2 control plane nodes have the new authentication config"))
+	tt.Expect(tt.bootstrapConfig()).To(ContainSubstring("claim: groups"))
+
+	remoteSecret := &corev1.Secret{}
+	tt.Expect(tt.remoteClient.Get(tt.ctx, client.ObjectKey{Name: reconciler.ConfigSecretName, Namespace: constants.EksaSystemNamespace}, remoteSecret)).To(Succeed())
+	tt.Expect(string(remoteSecret.Data[authentication.ConfigFileName])).To(Equal(tt.bootstrapConfig()))
+
+	pods := &corev1.PodList{}
+	tt.Expect(tt.remoteClient.List(tt.ctx, pods)).To(Succeed())
+	tt.Expect(pods.Items).To(HaveLen(2))
+	for _, p := range pods.Items {
+		tt.Expect(p.Spec.NodeName).To(HavePrefix("cp-node"))
+	}
+
+	tt.setPodPhase("cp-node-1", corev1.PodSucceeded)
+	tt.setPodPhase("cp-node-2", corev1.PodSucceeded)
+
+	tt.expectRemoteClient()
+	result, err = tt.reconciler.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+	tt.Expect(tt.cluster.Annotations[reconciler.ConfigHashAnnotation]).NotTo(Equal(oldHash))
+	tt.Expect(conditions.IsTrue(tt.cluster, anywherev1.AuthenticationConfigUpdatedCondition)).To(BeTrue())
+
+	tt.Expect(tt.remoteClient.List(tt.ctx, pods)).To(Succeed())
+	tt.Expect(pods.Items).To(BeEmpty())
+
+	nodes := &corev1.NodeList{}
+	tt.Expect(tt.remoteClient.List(tt.ctx, nodes, client.HasLabels{"node-role.kubernetes.io/control-plane"})).To(Succeed())
+	for _, n := range nodes.Items {
+		tt.Expect(n.Annotations[reconciler.ConfigHashAnnotation]).To(Equal(tt.cluster.Annotations[reconciler.ConfigHashAnnotation]))
+	}
+}
+
+func TestReconcileUpdateConfigReplacesOutdatedPod(t *testing.T) {
+	tt := newReconcilerTest(t, controlPlaneNode("cp-node"))
+	_, err := tt.reconciler.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+
+	tt.updateOIDCGroupsClaim("groups")
+	tt.expectRemoteClient()
+	_, err = tt.reconciler.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+
+	tt.updateOIDCGroupsClaim("other-groups")
+	tt.expectRemoteClient()
+	_, err = tt.reconciler.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+
+	// The pod created for the previous config is removed and recreated in the next reconciliation
+	pods := &corev1.PodList{}
+	tt.Expect(tt.remoteClient.List(tt.ctx, pods)).To(Succeed())
+	tt.Expect(pods.Items).To(BeEmpty())
+}
+
+func TestReconcileUpdateConfigPodFailed(t *testing.T) {
+	tt := newReconcilerTest(t, controlPlaneNode("cp-node"))
+	_, err := tt.reconciler.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+
+	tt.updateOIDCGroupsClaim("groups")
+
+	tt.expectRemoteClient()
+	_, err = tt.reconciler.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+
+	tt.setPodPhase("cp-node", corev1.PodFailed)
+
+	tt.expectRemoteClient()
+	result, err := tt.reconciler.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result.Return()).To(BeTrue())
+	tt.expectCondition(corev1.ConditionFalse, anywherev1.AuthenticationConfigUpdateFailedReason)
+	tt.Expect(conditions.GetMessage(tt.cluster, anywherev1.AuthenticationConfigUpdatedCondition)).To(ContainSubstring("cp-node"))
+
+	// The failed pod is removed so it gets retried in the next reconciliation
+	pods := &corev1.PodList{}
+	tt.Expect(tt.remoteClient.List(tt.ctx, pods)).To(Succeed())
+	tt.Expect(pods.Items).To(BeEmpty())
+}
+
+func TestReconcileUpdateConfigRemoteClientError(t *testing.T) {
+	tt := newReconcilerTest(t)
+	_, err := tt.reconciler.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+
+	tt.updateOIDCGroupsClaim("groups")
+
+	tt.remoteClientRegistry.EXPECT().GetClient(tt.ctx, gomock.AssignableToTypeOf(client.ObjectKey{})).Return(nil, errors.New("client error"))
+	_, err = tt.reconciler.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).To(MatchError(ContainSubstring("client error")))
+}
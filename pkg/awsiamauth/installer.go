@@ -81,6 +81,10 @@ func (i *Installer) InstallAWSIAMAuth(
 		return fmt.Errorf("applying aws-iam-authenticator manifest: %v", err)
 	}
 
+	if err = i.applyIAMIdentityMappings(ctx, workload, spec); err != nil {
+		return err
+	}
+
 	if err = i.GenerateWorkloadKubeconfig(ctx, management, workload, spec); err != nil {
 		return err
 	}
@@ -99,6 +103,29 @@ func (i *Installer) UpgradeAWSIAMAuth(ctx context.Context, cluster *types.Cluste
 		return fmt.Errorf("applying manifest: %v", err)
 	}
 
+	return i.applyIAMIdentityMappings(ctx, cluster, spec)
+}
+
+// applyIAMIdentityMappings applies the IAMIdentityMappings for the AWSIamConfig mappings when
+// aws-iam-authenticator uses the CRD backend. It needs to run after the manifest with the CRD is applied.
+func (i *Installer) applyIAMIdentityMappings(ctx context.Context, cluster *types.Cluster, spec *cluster.Spec) error {
+	if !spec.AWSIamConfig.UsesCRDBackend() {
+		return nil
+	}
+
+	mappings, err := i.templateBuilder.GenerateMappingsManifest(spec.AWSIamConfig)
+	if err != nil {
+		return fmt.Errorf("generating aws-iam-authenticator identity mappings: %v", err)
+	}
+
+	if len(mappings) == 0 {
+		return nil
+	}
+
+	if err = i.k8s.Apply(ctx, cluster, mappings); err != nil {
+		return fmt.Errorf("applying aws-iam-authenticator identity mappings: %v", err)
+	}
+
 	return nil
 }
 
@@ -332,6 +332,68 @@ func TestUpgradeAWSIAMAuth(t *testing.T) {
 	test.AssertContentToFile(t, string(manifest), "testdata/UpgradeAWSIAMAuth-manifest.yaml")
 }
 
+func TestUpgradeAWSIAMAuthCRDBackend(t *testing.T) {
+	clusterID := uuid.Nil
+
+	ctrl := gomock.NewController(t)
+	certs := cryptomocks.NewMockCertificateGenerator(ctrl)
+	writer := filewritermock.NewMockFileWriter(ctrl)
+
+	k8s := NewMockKubernetesClient(ctrl)
+
+	var manifests [][]byte
+	k8s.EXPECT().Apply(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, cluster *types.Cluster, data []byte) error {
+			manifests = append(manifests, data)
+			return nil
+		},
+	).Times(2)
+
+	kwriter := kubeconfigmocks.NewMockWriter(ctrl)
+	installer := awsiamauth.NewInstaller(certs, clusterID, k8s, writer, kwriter)
+
+	spec := &cluster.Spec{
+		Config: &cluster.Config{
+			Cluster: &v1alpha1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-cluster",
+				},
+				Spec: v1alpha1.ClusterSpec{
+					KubernetesVersion: v1alpha1.Kube123,
+				},
+			},
+		},
+		VersionsBundles: test.VersionsBundlesMap(),
+		AWSIamConfig: &v1alpha1.AWSIamConfig{
+			Spec: v1alpha1.AWSIamConfigSpec{
+				AWSRegion:   "test-region",
+				BackendMode: []string{"CRD"},
+				MapRoles: []v1alpha1.MapRoles{
+					{
+						RoleARN:  "test-role-arn",
+						Username: "test",
+						Groups:   []string{"group1", "group2"},
+					},
+				},
+				MapUsers: []v1alpha1.MapUsers{
+					{
+						UserARN:  "test-user-arn",
+						Username: "test",
+					},
+				},
+				Partition: "test",
+			},
+		},
+	}
+
+	err := installer.UpgradeAWSIAMAuth(context.Background(), &types.Cluster{}, spec)
+	if err != nil {
+		t.Fatalf("Received unexpected error: %v", err)
+	}
+	test.AssertContentToFile(t, string(manifests[0]), "testdata/UpgradeAWSIAMAuthCRDBackend-manifest.yaml")
+	test.AssertContentToFile(t, string(manifests[1]), "testdata/UpgradeAWSIAMAuthCRDBackend-mappings.yaml")
+}
+
 func TestGenerateManagementAWSIAMKubeconfig(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	certs := cryptomocks.NewMockCertificateGenerator(ctrl)
@@ -0,0 +1,199 @@
+package awsiamauth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/controller/reconcileutil"
+	"github.com/aws/eks-anywhere/pkg/templater"
+)
+
+const (
+	// IAMIdentityMappingManagedLabel is set on the IAMIdentityMappings generated from an AWSIamConfig,
+	// so the ones removed from it can be pruned without touching mappings created by other means.
+	IAMIdentityMappingManagedLabel = "anywhere.eks.amazonaws.com/iam-identity-mapping"
+
+	iamIdentityMappingNamePrefix = "eksa-"
+)
+
+// IAMIdentityMappingGVK is the GroupVersionKind of the aws-iam-authenticator IAMIdentityMapping CRD.
+var IAMIdentityMappingGVK = schema.GroupVersionKind{
+	Group:   "iamauthenticator.k8s.aws",
+	Version: "v1alpha1",
+	Kind:    "IAMIdentityMapping",
+}
+
+// Mappings holds the IAM roles and users mapped to kubernetes users and groups for a cluster.
+type Mappings struct {
+	MapRoles []v1alpha1.MapRoles `json:"mapRoles,omitempty"`
+	MapUsers []v1alpha1.MapUsers `json:"mapUsers,omitempty"`
+}
+
+// MappingsFromConfig returns the mappings configured in an AWSIamConfig.
+func MappingsFromConfig(config *v1alpha1.AWSIamConfig) Mappings {
+	return Mappings{
+		MapRoles: config.Spec.MapRoles,
+		MapUsers: config.Spec.MapUsers,
+	}
+}
+
+// GetClusterAWSIamConfig returns the AWSIamConfig referenced by a cluster from its management cluster.
+func GetClusterAWSIamConfig(ctx context.Context, client kubernetes.Client, clusterName, namespace string) (*v1alpha1.AWSIamConfig, error) {
+	cluster := &v1alpha1.Cluster{}
+	if err := client.Get(ctx, clusterName, namespace, cluster); err != nil {
+		return nil, fmt.Errorf("getting cluster %s: %v", clusterName, err)
+	}
+
+	ref := cluster.AWSIamConfigRef()
+	if ref == nil {
+		return nil, fmt.Errorf("cluster %s doesn't have AWS IAM Authenticator configured", clusterName)
+	}
+
+	iamConfig := &v1alpha1.AWSIamConfig{}
+	if err := client.Get(ctx, ref.Name, namespace, iamConfig); err != nil {
+		return nil, fmt.Errorf("getting AWSIamConfig %s: %v", ref.Name, err)
+	}
+
+	return iamConfig, nil
+}
+
+// ApplyMappings replaces the role and user mappings in the AWSIamConfig of a cluster.
+// The EKS Anywhere controller updates them in the cluster without upgrading it.
+func ApplyMappings(ctx context.Context, client kubernetes.Client, clusterName, namespace string, m Mappings) error {
+	cluster := &v1alpha1.Cluster{}
+	if err := client.Get(ctx, clusterName, namespace, cluster); err != nil {
+		return fmt.Errorf("getting cluster %s: %v", clusterName, err)
+	}
+
+	if cluster.Spec.GitOpsRef != nil {
+		return fmt.Errorf("cluster %s is managed with GitOps, update the AWSIamConfig in the GitOps repository instead", clusterName)
+	}
+
+	iamConfig, err := GetClusterAWSIamConfig(ctx, client, clusterName, namespace)
+	if err != nil {
+		return err
+	}
+
+	iamConfig.Spec.MapRoles = m.MapRoles
+	iamConfig.Spec.MapUsers = m.MapUsers
+	if err := iamConfig.Validate(); err != nil {
+		return err
+	}
+
+	if err := client.Update(ctx, iamConfig); err != nil {
+		return fmt.Errorf("updating AWSIamConfig %s: %v", iamConfig.Name, err)
+	}
+
+	return nil
+}
+
+// ReadMappingsFile reads the mappings from a yaml file with mapRoles and mapUsers lists.
+func ReadMappingsFile(filename string) (*Mappings, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("reading iam mappings file: %v", err)
+	}
+
+	m := &Mappings{}
+	if err := yaml.UnmarshalStrict(content, m); err != nil {
+		return nil, fmt.Errorf("parsing iam mappings file %s: %v", filename, err)
+	}
+
+	return m, nil
+}
+
+// Hash returns a short hash of the mappings, used to detect when they change.
+func (m Mappings) Hash() (string, error) {
+	content, err := json.Marshal(m)
+	if err != nil {
+		return "", fmt.Errorf("marshalling iam mappings: %v", err)
+	}
+
+	return reconcileutil.ShortHash(content), nil
+}
+
+// IAMIdentityMappingName returns the name of the IAMIdentityMapping generated for an IAM ARN.
+// ARNs can't be used as object names, so the name is derived from a hash of it.
+func IAMIdentityMappingName(arn string) string {
+	return iamIdentityMappingNamePrefix + reconcileutil.ShortHash([]byte(arn))
+}
+
+// IAMIdentityMappings returns one IAMIdentityMapping per mapped IAM role and user.
+func IAMIdentityMappings(m Mappings) []*unstructured.Unstructured {
+	mappings := make([]*unstructured.Unstructured, 0, len(m.MapRoles)+len(m.MapUsers))
+	for _, r := range m.MapRoles {
+		mappings = append(mappings, iamIdentityMapping(r.RoleARN, r.Username, r.Groups))
+	}
+	for _, u := range m.MapUsers {
+		mappings = append(mappings, iamIdentityMapping(u.UserARN, u.Username, u.Groups))
+	}
+
+	return mappings
+}
+
+func iamIdentityMapping(arn, username string, groups []string) *unstructured.Unstructured {
+	spec := map[string]interface{}{
+		"arn":      arn,
+		"username": username,
+	}
+	if len(groups) > 0 {
+		g := make([]interface{}, 0, len(groups))
+		for _, group := range groups {
+			g = append(g, group)
+		}
+		spec["groups"] = g
+	}
+
+	u := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	u.SetGroupVersionKind(IAMIdentityMappingGVK)
+	u.SetName(IAMIdentityMappingName(arn))
+	u.SetLabels(map[string]string{IAMIdentityMappingManagedLabel: "true"})
+
+	return u
+}
+
+// GenerateMappingsManifest generates a YAML Kubernetes manifest with the IAMIdentityMappings
+// for the mappings in the AWSIamConfig. It returns an empty manifest if there are no mappings.
+func (t *TemplateBuilder) GenerateMappingsManifest(config *v1alpha1.AWSIamConfig) ([]byte, error) {
+	mappings := IAMIdentityMappings(MappingsFromConfig(config))
+	resources := make([][]byte, 0, len(mappings))
+	for _, m := range mappings {
+		b, err := yaml.Marshal(m)
+		if err != nil {
+			return nil, fmt.Errorf("marshalling iam identity mapping %s: %v", m.GetName(), err)
+		}
+		resources = append(resources, b)
+	}
+
+	return templater.AppendYamlResources(resources...), nil
+}
+
+// AWSAuthConfigMapData returns the data of the aws-auth ConfigMap for the mappings.
+func (t *TemplateBuilder) AWSAuthConfigMapData(m Mappings) (map[string]string, error) {
+	data := map[string]string{}
+	mapRoles, err := t.mapRolesToYaml(m.MapRoles)
+	if err != nil {
+		return nil, err
+	}
+	if mapRoles != "" {
+		data["mapRoles"] = mapRoles + "\n"
+	}
+
+	mapUsers, err := t.mapUsersToYaml(m.MapUsers)
+	if err != nil {
+		return nil, err
+	}
+	if mapUsers != "" {
+		data["mapUsers"] = mapUsers + "\n"
+	}
+
+	return data, nil
+}
@@ -0,0 +1,184 @@
+package awsiamauth_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/awsiamauth"
+)
+
+func TestReadMappingsFile(t *testing.T) {
+	g := NewWithT(t)
+	m, err := awsiamauth.ReadMappingsFile("testdata/iam-mappings.yaml")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(m).To(Equal(&awsiamauth.Mappings{
+		MapRoles: []v1alpha1.MapRoles{
+			{RoleARN: "arn:aws:iam::123456789012:role/admin", Username: "admin", Groups: []string{"system:masters"}},
+		},
+		MapUsers: []v1alpha1.MapUsers{
+			{UserARN: "arn:aws:iam::123456789012:user/dev", Username: "dev"},
+		},
+	}))
+}
+
+func TestReadMappingsFileError(t *testing.T) {
+	g := NewWithT(t)
+	_, err := awsiamauth.ReadMappingsFile("testdata/missing.yaml")
+	g.Expect(err).To(MatchError(ContainSubstring("reading iam mappings file")))
+}
+
+func TestMappingsHash(t *testing.T) {
+	g := NewWithT(t)
+	m := awsiamauth.Mappings{
+		MapRoles: []v1alpha1.MapRoles{{RoleARN: "role", Username: "admin"}},
+	}
+	hash, err := m.Hash()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(hash).To(HaveLen(16))
+
+	m.MapRoles[0].Groups = []string{"system:masters"}
+	newHash, err := m.Hash()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(newHash).NotTo(Equal(hash))
+}
+
+func TestIAMIdentityMappings(t *testing.T) {
+	g := NewWithT(t)
+	mappings := awsiamauth.IAMIdentityMappings(awsiamauth.Mappings{
+		MapRoles: []v1alpha1.MapRoles{{RoleARN: "role", Username: "admin", Groups: []string{"system:masters"}}},
+		MapUsers: []v1alpha1.MapUsers{{UserARN: "user", Username: "dev"}},
+	})
+	g.Expect(mappings).To(HaveLen(2))
+	g.Expect(mappings[0].GetName()).To(Equal(awsiamauth.IAMIdentityMappingName("role")))
+	g.Expect(mappings[0].GetLabels()).To(HaveKeyWithValue(awsiamauth.IAMIdentityMappingManagedLabel, "true"))
+	g.Expect(mappings[0].Object["spec"]).To(Equal(map[string]interface{}{
+		"arn":      "role",
+		"username": "admin",
+		"groups":   []interface{}{"system:masters"},
+	}))
+	g.Expect(mappings[1].Object["spec"]).To(Equal(map[string]interface{}{
+		"arn":      "user",
+		"username": "dev",
+	}))
+}
+
+func TestIAMIdentityMappingName(t *testing.T) {
+	g := NewWithT(t)
+	name := awsiamauth.IAMIdentityMappingName("arn:aws:iam::123456789012:role/admin")
+	g.Expect(name).To(HavePrefix("eksa-"))
+	g.Expect(name).To(HaveLen(21))
+	g.Expect(awsiamauth.IAMIdentityMappingName("arn:aws:iam::123456789012:role/dev")).NotTo(Equal(name))
+}
+
+func TestAWSAuthConfigMapData(t *testing.T) {
+	g := NewWithT(t)
+	tb := &awsiamauth.TemplateBuilder{}
+	data, err := tb.AWSAuthConfigMapData(awsiamauth.Mappings{
+		MapRoles: []v1alpha1.MapRoles{{RoleARN: "role", Username: "admin", Groups: []string{"system:masters"}}},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(data).To(Equal(map[string]string{
+		"mapRoles": "- rolearn: role\n  username: admin\n  groups:\n    - system:masters\n",
+	}))
+}
+
+func TestGetClusterAWSIamConfig(t *testing.T) {
+	g := NewWithT(t)
+	cluster, iamConfig := mappingsCluster()
+	client := test.NewFakeKubeClient(cluster, iamConfig)
+
+	got, err := awsiamauth.GetClusterAWSIamConfig(context.Background(), client, cluster.Name, cluster.Namespace)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(got.Spec).To(Equal(iamConfig.Spec))
+}
+
+func TestGetClusterAWSIamConfigNotConfigured(t *testing.T) {
+	g := NewWithT(t)
+	cluster, _ := mappingsCluster()
+	cluster.Spec.IdentityProviderRefs = nil
+	client := test.NewFakeKubeClient(cluster)
+
+	_, err := awsiamauth.GetClusterAWSIamConfig(context.Background(), client, cluster.Name, cluster.Namespace)
+	g.Expect(err).To(MatchError("cluster my-cluster doesn't have AWS IAM Authenticator configured"))
+}
+
+func TestGetClusterAWSIamConfigClusterNotFound(t *testing.T) {
+	g := NewWithT(t)
+	client := test.NewFakeKubeClient()
+
+	_, err := awsiamauth.GetClusterAWSIamConfig(context.Background(), client, "my-cluster", "default")
+	g.Expect(err).To(MatchError(ContainSubstring("getting cluster my-cluster")))
+}
+
+func TestApplyMappings(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cluster, iamConfig := mappingsCluster()
+	client := test.NewFakeKubeClient(cluster, iamConfig)
+	mappings := awsiamauth.Mappings{
+		MapUsers: []v1alpha1.MapUsers{{UserARN: "user", Username: "dev", Groups: []string{"developers"}}},
+	}
+
+	g.Expect(awsiamauth.ApplyMappings(ctx, client, cluster.Name, cluster.Namespace, mappings)).To(Succeed())
+
+	got, err := awsiamauth.GetClusterAWSIamConfig(ctx, client, cluster.Name, cluster.Namespace)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(awsiamauth.MappingsFromConfig(got)).To(Equal(mappings))
+	g.Expect(got.Spec.AWSRegion).To(Equal(iamConfig.Spec.AWSRegion))
+}
+
+func TestApplyMappingsInvalid(t *testing.T) {
+	g := NewWithT(t)
+	cluster, iamConfig := mappingsCluster()
+	client := test.NewFakeKubeClient(cluster, iamConfig)
+	mappings := awsiamauth.Mappings{
+		MapRoles: []v1alpha1.MapRoles{{RoleARN: "role"}},
+	}
+
+	err := awsiamauth.ApplyMappings(context.Background(), client, cluster.Name, cluster.Namespace, mappings)
+	g.Expect(err).To(MatchError("AWSIamConfig MapRoles Username is required"))
+}
+
+func TestApplyMappingsGitOps(t *testing.T) {
+	g := NewWithT(t)
+	cluster, iamConfig := mappingsCluster()
+	cluster.Spec.GitOpsRef = &v1alpha1.Ref{Kind: v1alpha1.FluxConfigKind, Name: "flux"}
+	client := test.NewFakeKubeClient(cluster, iamConfig)
+
+	err := awsiamauth.ApplyMappings(context.Background(), client, cluster.Name, cluster.Namespace, awsiamauth.Mappings{})
+	g.Expect(err).To(MatchError(ContainSubstring("is managed with GitOps")))
+}
+
+func mappingsCluster() (*v1alpha1.Cluster, *v1alpha1.AWSIamConfig) {
+	cluster := &v1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster",
+			Namespace: "default",
+		},
+		Spec: v1alpha1.ClusterSpec{
+			IdentityProviderRefs: []v1alpha1.Ref{
+				{Kind: v1alpha1.AWSIamConfigKind, Name: "aws-iam"},
+			},
+		},
+	}
+	iamConfig := &v1alpha1.AWSIamConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "aws-iam",
+			Namespace: "default",
+		},
+		Spec: v1alpha1.AWSIamConfigSpec{
+			AWSRegion:   "us-west-2",
+			BackendMode: []string{"EKSConfigMap"},
+			MapRoles: []v1alpha1.MapRoles{
+				{RoleARN: "role", Username: "admin"},
+			},
+		},
+	}
+
+	return cluster, iamConfig
+}
@@ -4,13 +4,16 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -28,6 +31,9 @@ import (
 	"github.com/aws/eks-anywhere/pkg/crypto"
 )
 
+// MappingsHashAnnotation holds the hash of the aws-iam-authenticator mappings last applied to a cluster.
+const MappingsHashAnnotation = "anywhere.eks.amazonaws.com/iam-mappings-hash"
+
 // RemoteClientRegistry defines methods for remote cluster controller clients.
 type RemoteClientRegistry interface {
 	GetClient(ctx context.Context, cluster client.ObjectKey) (client.Client, error)
@@ -142,9 +148,184 @@ func (r *Reconciler) Reconcile(ctx context.Context, log logr.Logger, cluster *an
 		return controller.Result{}, errors.Wrap(err, "applying aws-iam-authenticator manifest")
 	}
 
+	if clusterSpec.AWSIamConfig.UsesCRDBackend() {
+		log.Info("Applying aws-iam-authenticator identity mappings")
+		err := r.reconcileIAMIdentityMappings(ctx, rClient, awsiamauth.MappingsFromConfig(clusterSpec.AWSIamConfig))
+		if meta.IsNoMatchError(err) {
+			// The IAMIdentityMapping CRD was just created and it's not served yet.
+			log.Info("IAMIdentityMapping CRD is not available yet, requeuing")
+			return controller.ResultWithRequeue(5 * time.Second), nil
+		}
+		if err != nil {
+			return controller.Result{}, err
+		}
+	}
+
+	return controller.Result{}, nil
+}
+
+// ReconcileMappings updates the aws-iam-authenticator role and user mappings in the cluster when
+// the ones in its AWSIamConfig change. It runs independently of the full cluster reconciliation,
+// so mappings can be managed without upgrading the cluster.
+// It uses a controller.Result to indicate when requeues are needed.
+func (r *Reconciler) ReconcileMappings(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) (controller.Result, error) {
+	ref := cluster.AWSIamConfigRef()
+	if ref == nil {
+		return controller.Result{}, nil
+	}
+
+	iamConfig := &anywherev1.AWSIamConfig{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: cluster.Namespace}, iamConfig); err != nil {
+		return controller.Result{}, errors.Wrapf(err, "getting AWSIamConfig %s", ref.Name)
+	}
+
+	mappings := awsiamauth.MappingsFromConfig(iamConfig)
+	hash, err := mappings.Hash()
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	currentHash, ok := cluster.Annotations[MappingsHashAnnotation]
+	if !ok {
+		// The mappings are applied together with the rest of aws-iam-authenticator
+		// the first time the cluster is reconciled.
+		setMappingsHash(cluster, hash)
+		return controller.Result{}, nil
+	}
+
+	if currentHash == hash {
+		return controller.Result{}, nil
+	}
+
+	result, err := clusters.CheckControlPlaneReady(ctx, r.client, log, cluster)
+	if err != nil {
+		return controller.Result{}, errors.Wrap(err, "checking controlplane ready")
+	}
+	if result.Return() {
+		return result, nil
+	}
+
+	rClient, err := r.remoteClientRegistry.GetClient(ctx, controller.CapiClusterObjectKey(cluster))
+	if err != nil {
+		return controller.Result{}, errors.Wrap(err, "getting workload cluster's client to reconcile aws-iam-authenticator mappings")
+	}
+
+	if iamConfig.UsesEKSConfigMapBackend() {
+		log.Info("Updating aws-iam-authenticator aws-auth ConfigMap")
+		if err := r.updateAWSAuthConfigMap(ctx, rClient, mappings); err != nil {
+			return controller.Result{}, err
+		}
+	}
+
+	if iamConfig.UsesCRDBackend() {
+		log.Info("Updating aws-iam-authenticator identity mappings")
+		if err := r.reconcileIAMIdentityMappings(ctx, rClient, mappings); err != nil {
+			return controller.Result{}, err
+		}
+	}
+
+	setMappingsHash(cluster, hash)
+
 	return controller.Result{}, nil
 }
 
+func setMappingsHash(cluster *anywherev1.Cluster, hash string) {
+	if cluster.Annotations == nil {
+		cluster.Annotations = map[string]string{}
+	}
+	cluster.Annotations[MappingsHashAnnotation] = hash
+}
+
+// updateAWSAuthConfigMap replaces the role and user mappings in the aws-auth ConfigMap,
+// keeping any other key set in it.
+func (r *Reconciler) updateAWSAuthConfigMap(ctx context.Context, client client.Client, mappings awsiamauth.Mappings) error {
+	data, err := r.templateBuilder.AWSAuthConfigMapData(mappings)
+	if err != nil {
+		return errors.Wrap(err, "generating aws-auth ConfigMap data")
+	}
+
+	cm := &corev1.ConfigMap{}
+	err = client.Get(ctx, types.NamespacedName{Name: awsiamauth.AwsAuthConfigMapName, Namespace: constants.KubeSystemNamespace}, cm)
+	if apierrors.IsNotFound(err) {
+		cm = &corev1.ConfigMap{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      awsiamauth.AwsAuthConfigMapName,
+				Namespace: constants.KubeSystemNamespace,
+			},
+			Data: data,
+		}
+		return errors.Wrap(client.Create(ctx, cm), "creating aws-auth ConfigMap")
+	}
+	if err != nil {
+		return errors.Wrap(err, "getting aws-auth ConfigMap")
+	}
+
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	delete(cm.Data, "mapRoles")
+	delete(cm.Data, "mapUsers")
+	for k, v := range data {
+		cm.Data[k] = v
+	}
+
+	return errors.Wrap(client.Update(ctx, cm), "updating aws-auth ConfigMap")
+}
+
+// reconcileIAMIdentityMappings creates or updates one IAMIdentityMapping per mapping and deletes
+// the ones previously generated by EKS Anywhere that are not in the mappings anymore.
+func (r *Reconciler) reconcileIAMIdentityMappings(ctx context.Context, c client.Client, mappings awsiamauth.Mappings) error {
+	desired := awsiamauth.IAMIdentityMappings(mappings)
+	desiredNames := make(map[string]struct{}, len(desired))
+	for _, m := range desired {
+		desiredNames[m.GetName()] = struct{}{}
+		if err := upsertIAMIdentityMapping(ctx, c, m); err != nil {
+			return err
+		}
+	}
+
+	existing := &unstructured.UnstructuredList{}
+	existing.SetGroupVersionKind(awsiamauth.IAMIdentityMappingGVK.GroupVersion().WithKind(awsiamauth.IAMIdentityMappingGVK.Kind + "List"))
+	if err := c.List(ctx, existing, client.HasLabels{awsiamauth.IAMIdentityMappingManagedLabel}); err != nil {
+		return errors.Wrap(err, "listing IAMIdentityMappings")
+	}
+
+	for i := range existing.Items {
+		m := &existing.Items[i]
+		if _, ok := desiredNames[m.GetName()]; ok {
+			continue
+		}
+		if err := c.Delete(ctx, m); err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "deleting IAMIdentityMapping %s", m.GetName())
+		}
+	}
+
+	return nil
+}
+
+func upsertIAMIdentityMapping(ctx context.Context, c client.Client, desired *unstructured.Unstructured) error {
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(desired.GroupVersionKind())
+	err := c.Get(ctx, client.ObjectKeyFromObject(desired), current)
+	if apierrors.IsNotFound(err) {
+		if err := c.Create(ctx, desired); err != nil {
+			return errors.Wrapf(err, "creating IAMIdentityMapping %s", desired.GetName())
+		}
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "getting IAMIdentityMapping %s", desired.GetName())
+	}
+
+	current.Object["spec"] = desired.Object["spec"]
+	current.SetLabels(desired.GetLabels())
+	if err := c.Update(ctx, current); err != nil {
+		return errors.Wrapf(err, "updating IAMIdentityMapping %s", desired.GetName())
+	}
+
+	return nil
+}
+
 func (r *Reconciler) applyIAMAuthManifest(ctx context.Context, client client.Client, clusterSpec *anywhereCluster.Spec, clusterID uuid.UUID) error {
 	yaml, err := r.templateBuilder.GenerateManifest(clusterSpec, clusterID)
 	if err != nil {
@@ -12,7 +12,9 @@ import (
 	"github.com/google/uuid"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/pointer"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -369,3 +371,239 @@ func TestReconcileConfigMapNotFoundApplyError(t *testing.T) {
 	g.Expect(err).To(HaveOccurred())
 	g.Expect(result).To(Equal(controller.Result{}))
 }
+
+func TestReconcileMappingsNoAWSIamConfig(t *testing.T) {
+	g := NewWithT(t)
+	cluster := mappingsTestCluster()
+	cluster.Spec.IdentityProviderRefs = nil
+	r := newReconciler(t, fake.NewClientBuilder().Build())
+
+	result, err := r.ReconcileMappings(context.Background(), nullLog(), cluster)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result).To(Equal(controller.Result{}))
+	g.Expect(cluster.Annotations).ToNot(HaveKey(reconciler.MappingsHashAnnotation))
+}
+
+func TestReconcileMappingsAWSIamConfigNotFound(t *testing.T) {
+	g := NewWithT(t)
+	cluster := mappingsTestCluster()
+	r := newReconciler(t, fake.NewClientBuilder().Build())
+
+	_, err := r.ReconcileMappings(context.Background(), nullLog(), cluster)
+	g.Expect(err).To(MatchError(ContainSubstring("getting AWSIamConfig aws-config")))
+}
+
+func TestReconcileMappingsFirstReconcile(t *testing.T) {
+	g := NewWithT(t)
+	cluster := mappingsTestCluster()
+	iamConfig := mappingsTestAWSIamConfig("EKSConfigMap")
+	cl := fake.NewClientBuilder().WithRuntimeObjects(iamConfig).Build()
+	r := newReconciler(t, cl)
+
+	result, err := r.ReconcileMappings(context.Background(), nullLog(), cluster)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result).To(Equal(controller.Result{}))
+	g.Expect(cluster.Annotations).To(HaveKeyWithValue(reconciler.MappingsHashAnnotation, mappingsHash(g, iamConfig)))
+}
+
+func TestReconcileMappingsUnchanged(t *testing.T) {
+	g := NewWithT(t)
+	iamConfig := mappingsTestAWSIamConfig("EKSConfigMap")
+	cluster := mappingsTestCluster()
+	cluster.Annotations = map[string]string{reconciler.MappingsHashAnnotation: mappingsHash(g, iamConfig)}
+	cl := fake.NewClientBuilder().WithRuntimeObjects(iamConfig).Build()
+	r := newReconciler(t, cl)
+
+	result, err := r.ReconcileMappings(context.Background(), nullLog(), cluster)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestReconcileMappingsControlPlaneNotReady(t *testing.T) {
+	g := NewWithT(t)
+	iamConfig := mappingsTestAWSIamConfig("EKSConfigMap")
+	cluster := mappingsTestCluster()
+	cluster.Annotations = map[string]string{reconciler.MappingsHashAnnotation: "old"}
+	cl := fake.NewClientBuilder().WithRuntimeObjects(iamConfig).Build()
+	r := newReconciler(t, cl)
+
+	result, err := r.ReconcileMappings(context.Background(), nullLog(), cluster)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result).To(Equal(controller.ResultWithRequeue(5 * time.Second)))
+	g.Expect(cluster.Annotations).To(HaveKeyWithValue(reconciler.MappingsHashAnnotation, "old"))
+}
+
+func TestReconcileMappingsUpdatesAWSAuthConfigMap(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	remoteClientRegistry := reconcilermocks.NewMockRemoteClientRegistry(ctrl)
+	iamConfig := mappingsTestAWSIamConfig("EKSConfigMap")
+	cluster := mappingsTestCluster()
+	cluster.Annotations = map[string]string{reconciler.MappingsHashAnnotation: "old"}
+	cl := fake.NewClientBuilder().WithRuntimeObjects(iamConfig, readyKCP(cluster)).Build()
+	awsAuth := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      awsiamauth.AwsAuthConfigMapName,
+			Namespace: constants.KubeSystemNamespace,
+		},
+		Data: map[string]string{
+			"mapRoles":    "- rolearn: old-role\n  username: old\n",
+			"mapUsers":    "- userarn: old-user\n  username: old\n",
+			"mapAccounts": "- \"123456789012\"\n",
+		},
+	}
+	rCl := fake.NewClientBuilder().WithRuntimeObjects(awsAuth).Build()
+	remoteClientRegistry.EXPECT().GetClient(ctx, client.ObjectKey{Name: cluster.Name, Namespace: constants.EksaSystemNamespace}).Return(rCl, nil)
+
+	r := reconciler.New(nil, uuid.New, cl, remoteClientRegistry)
+	result, err := r.ReconcileMappings(ctx, nullLog(), cluster)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result).To(Equal(controller.Result{}))
+	g.Expect(cluster.Annotations).To(HaveKeyWithValue(reconciler.MappingsHashAnnotation, mappingsHash(g, iamConfig)))
+
+	g.Expect(rCl.Get(ctx, client.ObjectKeyFromObject(awsAuth), awsAuth)).To(Succeed())
+	g.Expect(awsAuth.Data).To(Equal(map[string]string{
+		"mapRoles":    "- rolearn: arn:aws:iam::123456789012:role/admin\n  username: admin\n  groups:\n    - system:masters\n",
+		"mapAccounts": "- \"123456789012\"\n",
+	}))
+}
+
+func TestReconcileMappingsCreatesAWSAuthConfigMap(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	remoteClientRegistry := reconcilermocks.NewMockRemoteClientRegistry(ctrl)
+	iamConfig := mappingsTestAWSIamConfig("EKSConfigMap")
+	cluster := mappingsTestCluster()
+	cluster.Annotations = map[string]string{reconciler.MappingsHashAnnotation: "old"}
+	cl := fake.NewClientBuilder().WithRuntimeObjects(iamConfig, readyKCP(cluster)).Build()
+	rCl := fake.NewClientBuilder().Build()
+	remoteClientRegistry.EXPECT().GetClient(ctx, gomock.AssignableToTypeOf(client.ObjectKey{})).Return(rCl, nil)
+
+	r := reconciler.New(nil, uuid.New, cl, remoteClientRegistry)
+	_, err := r.ReconcileMappings(ctx, nullLog(), cluster)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	awsAuth := &corev1.ConfigMap{}
+	g.Expect(rCl.Get(ctx, client.ObjectKey{Name: awsiamauth.AwsAuthConfigMapName, Namespace: constants.KubeSystemNamespace}, awsAuth)).To(Succeed())
+	g.Expect(awsAuth.Data).To(HaveKey("mapRoles"))
+}
+
+func TestReconcileMappingsIAMIdentityMappings(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	remoteClientRegistry := reconcilermocks.NewMockRemoteClientRegistry(ctrl)
+	iamConfig := mappingsTestAWSIamConfig("CRD")
+	cluster := mappingsTestCluster()
+	cluster.Annotations = map[string]string{reconciler.MappingsHashAnnotation: "old"}
+	cl := fake.NewClientBuilder().WithRuntimeObjects(iamConfig, readyKCP(cluster)).Build()
+
+	stale := iamIdentityMapping("eksa-stale", "old-role", true)
+	unmanaged := iamIdentityMapping("user-managed", "other-role", false)
+	outdated := iamIdentityMapping(awsiamauth.IAMIdentityMappingName("arn:aws:iam::123456789012:role/admin"), "arn:aws:iam::123456789012:role/admin", true)
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(awsiamauth.IAMIdentityMappingGVK, meta.RESTScopeRoot)
+	rCl := fake.NewClientBuilder().WithRESTMapper(mapper).WithObjects(stale, unmanaged, outdated).Build()
+	remoteClientRegistry.EXPECT().GetClient(ctx, gomock.AssignableToTypeOf(client.ObjectKey{})).Return(rCl, nil)
+
+	r := reconciler.New(nil, uuid.New, cl, remoteClientRegistry)
+	result, err := r.ReconcileMappings(ctx, nullLog(), cluster)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result).To(Equal(controller.Result{}))
+
+	mappings := &unstructured.UnstructuredList{}
+	mappings.SetGroupVersionKind(awsiamauth.IAMIdentityMappingGVK.GroupVersion().WithKind("IAMIdentityMappingList"))
+	g.Expect(rCl.List(ctx, mappings)).To(Succeed())
+	names := []string{}
+	for _, m := range mappings.Items {
+		names = append(names, m.GetName())
+	}
+	g.Expect(names).To(ConsistOf("user-managed", outdated.GetName()))
+
+	updated := &unstructured.Unstructured{}
+	updated.SetGroupVersionKind(awsiamauth.IAMIdentityMappingGVK)
+	g.Expect(rCl.Get(ctx, client.ObjectKeyFromObject(outdated), updated)).To(Succeed())
+	g.Expect(updated.Object["spec"]).To(Equal(map[string]interface{}{
+		"arn":      "arn:aws:iam::123456789012:role/admin",
+		"username": "admin",
+		"groups":   []interface{}{"system:masters"},
+	}))
+}
+
+func mappingsTestCluster() *anywherev1.Cluster {
+	return &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster",
+			Namespace: "eksa-system",
+		},
+		Spec: anywherev1.ClusterSpec{
+			IdentityProviderRefs: []anywherev1.Ref{
+				{
+					Name: "aws-config",
+					Kind: anywherev1.AWSIamConfigKind,
+				},
+			},
+		},
+	}
+}
+
+func mappingsTestAWSIamConfig(backendMode string) *anywherev1.AWSIamConfig {
+	return &anywherev1.AWSIamConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "aws-config",
+			Namespace: "eksa-system",
+		},
+		Spec: anywherev1.AWSIamConfigSpec{
+			AWSRegion:   "us-west-2",
+			BackendMode: []string{backendMode},
+			MapRoles: []anywherev1.MapRoles{
+				{
+					RoleARN:  "arn:aws:iam::123456789012:role/admin",
+					Username: "admin",
+					Groups:   []string{"system:masters"},
+				},
+			},
+		},
+	}
+}
+
+func mappingsHash(g *WithT, iamConfig *anywherev1.AWSIamConfig) string {
+	hash, err := awsiamauth.MappingsFromConfig(iamConfig).Hash()
+	g.Expect(err).ToNot(HaveOccurred())
+	return hash
+}
+
+func readyKCP(cluster *anywherev1.Cluster) *controlplanev1.KubeadmControlPlane {
+	return test.KubeadmControlPlane(func(kcp *controlplanev1.KubeadmControlPlane) {
+		kcp.Name = cluster.Name
+		kcp.Spec.Version = "v1.30.0"
+		kcp.Status = controlplanev1.KubeadmControlPlaneStatus{
+			Conditions: clusterv1.Conditions{
+				{
+					Type:               clusterapi.ReadyCondition,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(time.Now()),
+				},
+			},
+			Version: pointer.String("v1.30.0"),
+		}
+	})
+}
+
+func iamIdentityMapping(name, arn string, managed bool) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"arn":      arn,
+			"username": "old",
+		},
+	}}
+	u.SetGroupVersionKind(awsiamauth.IAMIdentityMappingGVK)
+	u.SetName(name)
+	if managed {
+		u.SetLabels(map[string]string{awsiamauth.IAMIdentityMappingManagedLabel: "true"})
+	}
+
+	return u
+}
@@ -0,0 +1,102 @@
+package cluster_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/cluster/mocks"
+)
+
+func TestDefaultConfigClientBuilderArgoCDConfig(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	b := cluster.NewDefaultConfigClientBuilder()
+	ctrl := gomock.NewController(t)
+	client := mocks.NewMockClient(ctrl)
+	cluster := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster",
+			Namespace: "default",
+		},
+		Spec: anywherev1.ClusterSpec{
+			GitOpsRef: &anywherev1.Ref{
+				Kind: anywherev1.ArgoCDConfigKind,
+				Name: "my-argo",
+			},
+		},
+	}
+	argoCDConfig := &anywherev1.ArgoCDConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-argo",
+			Namespace: "default",
+		},
+	}
+
+	client.EXPECT().Get(ctx, "my-argo", "default", &anywherev1.ArgoCDConfig{}).DoAndReturn(
+		func(ctx context.Context, name, namespace string, obj runtime.Object) error {
+			c := obj.(*anywherev1.ArgoCDConfig)
+			c.ObjectMeta = argoCDConfig.ObjectMeta
+			return nil
+		},
+	)
+
+	config, err := b.Build(ctx, client, cluster)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config).NotTo(BeNil())
+	g.Expect(config.Cluster).To(Equal(cluster))
+	g.Expect(config.ArgoCDConfig).To(Equal(argoCDConfig))
+	g.Expect(config.FluxConfig).To(BeNil())
+}
+
+func TestParseConfigArgoCDConfig(t *testing.T) {
+	g := NewWithT(t)
+	config, err := cluster.ParseConfig([]byte(`apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: Cluster
+metadata:
+  name: mgmt
+spec:
+  gitOpsRef:
+    kind: ArgoCDConfig
+    name: mgmt-argo
+---
+apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: ArgoCDConfig
+metadata:
+  name: mgmt-argo
+spec:
+  git:
+    repositoryUrl: ssh://git@github.com/username/repo.git
+`))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config.ArgoCDConfig).NotTo(BeNil())
+	g.Expect(config.ArgoCDConfig.Spec.Git.RepositoryUrl).To(Equal("ssh://git@github.com/username/repo.git"))
+	g.Expect(config.ChildObjects()).To(ContainElement(config.ArgoCDConfig))
+}
+
+func TestSetArgoCDConfigDefaults(t *testing.T) {
+	g := NewWithT(t)
+	config := &cluster.Config{
+		Cluster: &anywherev1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "workload",
+			},
+			Spec: anywherev1.ClusterSpec{
+				ManagementCluster: anywherev1.ManagementCluster{
+					Name: "mgmt",
+				},
+			},
+		},
+		ArgoCDConfig: &anywherev1.ArgoCDConfig{},
+	}
+
+	g.Expect(cluster.SetConfigDefaults(config)).To(Succeed())
+	g.Expect(config.ArgoCDConfig.Spec.ClusterConfigPath).To(Equal("clusters/mgmt"))
+	g.Expect(config.ArgoCDConfig.Spec.SystemNamespace).To(Equal(anywherev1.ArgoCDDefaultNamespace))
+}
@@ -21,6 +21,7 @@ type FileSpecBuilder struct {
 	cliVersion          version.Info
 	releasesManifestURL string
 	bundlesManifestURL  string
+	kubernetesVersion   v1alpha1.KubernetesVersion
 }
 
 // FileSpecBuilderOpt allows to configure [FileSpecBuilder].
@@ -42,6 +43,14 @@ func WithOverrideBundlesManifest(url string) FileSpecBuilderOpt {
 	}
 }
 
+// WithKubernetesVersion overrides the control plane Kubernetes version
+// read from the cluster config file.
+func WithKubernetesVersion(version v1alpha1.KubernetesVersion) FileSpecBuilderOpt {
+	return func(b *FileSpecBuilder) {
+		b.kubernetesVersion = version
+	}
+}
+
 // NewFileSpecBuilder builds a new [FileSpecBuilder].
 // cliVersion is used to chose the right Bundles from the the Release manifest.
 func NewFileSpecBuilder(reader manifests.FileReader, cliVersion version.Info, opts ...FileSpecBuilderOpt) FileSpecBuilder {
@@ -65,6 +74,10 @@ func (b FileSpecBuilder) Build(clusterConfigURL string) (*Spec, error) {
 		return nil, err
 	}
 
+	if b.kubernetesVersion != "" {
+		config.Cluster.Spec.KubernetesVersion = b.kubernetesVersion
+	}
+
 	mReader := b.createManifestReader()
 	bundlesManifest, err := b.getBundles(mReader)
 	if err != nil {
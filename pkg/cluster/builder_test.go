@@ -5,6 +5,7 @@ import (
 
 	. "github.com/onsi/gomega"
 
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/files"
 	"github.com/aws/eks-anywhere/pkg/version"
@@ -74,6 +75,22 @@ func TestFileSpecBuilderBuildSuccess(t *testing.T) {
 	validateSpecFromSimpleBundle(t, gotSpec)
 }
 
+func TestFileSpecBuilderBuildWithKubernetesVersion(t *testing.T) {
+	g := NewWithT(t)
+
+	v := version.Info{GitVersion: "v0.0.1"}
+	reader := files.NewReader()
+	b := cluster.NewFileSpecBuilder(reader, v,
+		cluster.WithReleasesManifest("testdata/simple_release.yaml"),
+		cluster.WithKubernetesVersion(anywherev1.Kube120),
+	)
+
+	gotSpec, err := b.Build("testdata/cluster_1_19.yaml")
+
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(gotSpec.Cluster.Spec.KubernetesVersion).To(Equal(anywherev1.Kube120))
+}
+
 func TestNewSpecWithBundlesOverrideValid(t *testing.T) {
 	g := NewWithT(t)
 
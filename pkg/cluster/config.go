@@ -27,6 +27,7 @@ type Config struct {
 	AWSIAMConfigs             map[string]*anywherev1.AWSIamConfig
 	GitOpsConfig              *anywherev1.GitOpsConfig
 	FluxConfig                *anywherev1.FluxConfig
+	ArgoCDConfig              *anywherev1.ArgoCDConfig
 	SnowCredentialsSecret     *v1.Secret
 	SnowIPPools               map[string]*anywherev1.SnowIPPool
 }
@@ -71,6 +72,7 @@ func (c *Config) DeepCopy() *Config {
 		TinkerbellDatacenter: c.TinkerbellDatacenter.DeepCopy(),
 		GitOpsConfig:         c.GitOpsConfig.DeepCopy(),
 		FluxConfig:           c.FluxConfig.DeepCopy(),
+		ArgoCDConfig:         c.ArgoCDConfig.DeepCopy(),
 	}
 
 	if c.VSphereMachineConfigs != nil {
@@ -158,6 +160,7 @@ func (c *Config) ChildObjects() []kubernetes.Object {
 		c.TinkerbellDatacenter,
 		c.GitOpsConfig,
 		c.FluxConfig,
+		c.ArgoCDConfig,
 	)
 
 	for _, e := range c.VSphereMachineConfigs {
@@ -22,6 +22,7 @@ func NewDefaultConfigManager() (*ConfigManager, error) {
 		awsIamEntry(),
 		gitOpsEntry(),
 		fluxEntry(),
+		argoCDEntry(),
 		vsphereEntry(),
 		cloudstackEntry(),
 		dockerEntry(),
@@ -2,6 +2,7 @@ package cluster
 
 import (
 	"context"
+	"fmt"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 
@@ -33,10 +34,48 @@ func oidcEntry() *ConfigManagerEntry {
 				}
 				return nil
 			},
+			validateUniqueOIDCIssuers,
 		},
 	}
 }
 
+// validateUniqueOIDCIssuers makes sure each issuer is only configured once, since the
+// API server can't have two authenticators for the same issuer.
+func validateUniqueOIDCIssuers(c *Config) error {
+	issuers := make(map[string]string, len(c.OIDCConfigs))
+	for _, name := range c.Cluster.OIDCConfigRefs() {
+		o, ok := c.OIDCConfigs[name]
+		if !ok {
+			continue
+		}
+		if other, ok := issuers[o.Spec.IssuerUrl]; ok && other != o.Name {
+			return fmt.Errorf("OIDCConfigs %s and %s have the same issuerUrl %s", other, o.Name, o.Spec.IssuerUrl)
+		}
+		issuers[o.Spec.IssuerUrl] = o.Name
+	}
+
+	return nil
+}
+
+// ReferencedOIDCConfigs returns the OIDCConfigs referenced by the cluster, in the order of
+// its identityProviderRefs. It falls back to OIDCConfig when the configs are not indexed by name.
+func (s *Spec) ReferencedOIDCConfigs() []*anywherev1.OIDCConfig {
+	var oidcConfigs []*anywherev1.OIDCConfig
+	if s.Config != nil && s.Cluster != nil {
+		for _, name := range s.Cluster.OIDCConfigRefs() {
+			if o, ok := s.Config.OIDCConfigs[name]; ok {
+				oidcConfigs = append(oidcConfigs, o)
+			}
+		}
+	}
+
+	if len(oidcConfigs) == 0 && s.OIDCConfig != nil {
+		oidcConfigs = append(oidcConfigs, s.OIDCConfig)
+	}
+
+	return oidcConfigs
+}
+
 func processOIDC(c *Config, objects ObjectLookup) {
 	if c.OIDCConfigs == nil {
 		c.OIDCConfigs = map[string]*anywherev1.OIDCConfig{}
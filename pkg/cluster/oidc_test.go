@@ -81,3 +81,56 @@ func TestConfigManagerValidateOIDCConfigMultipleErrors(t *testing.T) {
 	err = m.Validate(c)
 	g.Expect(err).To(MatchError(ContainSubstring("clientId is required")))
 }
+
+func TestConfigManagerValidateOIDCConfigDuplicateIssuer(t *testing.T) {
+	g := NewWithT(t)
+	c := clusterConfigFromFile(t, "testdata/docker_cluster_oidc_awsiam_flux.yaml")
+	second := c.OIDCConfigs["eksa-unit-test"].DeepCopy()
+	second.Name = "second-oidc"
+	c.OIDCConfigs[second.Name] = second
+	c.Cluster.Spec.IdentityProviderRefs = append(c.Cluster.Spec.IdentityProviderRefs, anywherev1.Ref{
+		Kind: anywherev1.OIDCConfigKind,
+		Name: second.Name,
+	})
+	m, err := cluster.NewDefaultConfigManager()
+	g.Expect(err).To(BeNil())
+
+	err = m.Validate(c)
+	g.Expect(err).To(MatchError(ContainSubstring("OIDCConfigs eksa-unit-test and second-oidc have the same issuerUrl https://mydomain.com/issuer")))
+}
+
+func TestSpecReferencedOIDCConfigs(t *testing.T) {
+	g := NewWithT(t)
+	first := &anywherev1.OIDCConfig{ObjectMeta: metav1.ObjectMeta{Name: "first"}}
+	second := &anywherev1.OIDCConfig{ObjectMeta: metav1.ObjectMeta{Name: "second"}}
+	spec := &cluster.Spec{
+		Config: &cluster.Config{
+			Cluster: &anywherev1.Cluster{
+				Spec: anywherev1.ClusterSpec{
+					IdentityProviderRefs: []anywherev1.Ref{
+						{Kind: anywherev1.OIDCConfigKind, Name: "second"},
+						{Kind: anywherev1.AWSIamConfigKind, Name: "first"},
+						{Kind: anywherev1.OIDCConfigKind, Name: "first"},
+					},
+				},
+			},
+			OIDCConfigs: map[string]*anywherev1.OIDCConfig{
+				"first":  first,
+				"second": second,
+			},
+		},
+	}
+
+	g.Expect(spec.ReferencedOIDCConfigs()).To(Equal([]*anywherev1.OIDCConfig{second, first}))
+}
+
+func TestSpecReferencedOIDCConfigsFallback(t *testing.T) {
+	g := NewWithT(t)
+	oidc := &anywherev1.OIDCConfig{ObjectMeta: metav1.ObjectMeta{Name: "oidc"}}
+	spec := &cluster.Spec{
+		Config:     &cluster.Config{Cluster: &anywherev1.Cluster{}},
+		OIDCConfig: oidc,
+	}
+
+	g.Expect(spec.ReferencedOIDCConfigs()).To(Equal([]*anywherev1.OIDCConfig{oidc}))
+}
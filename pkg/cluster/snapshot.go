@@ -0,0 +1,320 @@
+package cluster
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	etcdv1 "github.com/aws/etcdadm-controller/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	apiyaml "k8s.io/apimachinery/pkg/util/yaml"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/yaml"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	yamlutils "github.com/aws/eks-anywhere/pkg/utils/yaml"
+)
+
+const (
+	// SnapshotConfigFile is the file in a snapshot folder holding the EKS-A objects of the cluster.
+	SnapshotConfigFile = "eksa-cluster-snapshot.yaml"
+	// SnapshotCAPIFile is the file in a snapshot folder holding the CAPI objects that define the machines of the cluster.
+	SnapshotCAPIFile = "capi-cluster-snapshot.yaml"
+	// SnapshotVersionsFile is the file in a snapshot folder holding the versions the cluster was running.
+	SnapshotVersionsFile = "eksa-versions-snapshot.yaml"
+)
+
+// Snapshot is the state of a cluster before an upgrade, used to roll the cluster back if the upgrade fails.
+// Besides the EKS-A objects, it holds the CAPI objects that reference the machine templates, so a rollback
+// can point them back to the templates the machines the upgrade didn't replace were created from.
+type Snapshot struct {
+	// Config holds the EKS-A cluster and its child objects.
+	Config *Config
+	// CAPI holds the CAPI objects that define the machines of the cluster.
+	CAPI *SnapshotCAPI
+	// Versions holds the versions the control plane was running.
+	Versions SnapshotVersions
+}
+
+// SnapshotVersions are the versions of the control plane components that can't always be reverted.
+type SnapshotVersions struct {
+	// KubernetesVersion is the EKS-D Kubernetes version of the control plane, e.g. v1.28.3-eks-1-28-9.
+	KubernetesVersion string `json:"kubernetesVersion"`
+	// EtcdVersion is the etcd version of the cluster.
+	EtcdVersion string `json:"etcdVersion"`
+}
+
+// SnapshotCAPI are the CAPI objects that define the machines of a cluster: the control plane, etcd and
+// machine deployments, and the templates they create their machines from.
+type SnapshotCAPI struct {
+	KubeadmControlPlane *controlplanev1.KubeadmControlPlane
+	// EtcdadmCluster is only set for clusters with external etcd.
+	EtcdadmCluster         *etcdv1.EtcdadmCluster
+	MachineDeployments     []*clusterv1.MachineDeployment
+	KubeadmConfigTemplates []*bootstrapv1.KubeadmConfigTemplate
+	// MachineTemplates are the provider machine templates, which depend on the provider so they are unstructured.
+	MachineTemplates []*unstructured.Unstructured
+}
+
+// NewSnapshotCAPI reads from the management cluster the CAPI objects that define the machines of the cluster.
+func NewSnapshotCAPI(ctx context.Context, client kubernetes.Reader, c *anywherev1.Cluster) (*SnapshotCAPI, error) {
+	capiCluster := &clusterv1.Cluster{}
+	if err := client.Get(ctx, c.Name, constants.EksaSystemNamespace, capiCluster); err != nil {
+		return nil, fmt.Errorf("reading CAPI cluster for snapshot: %v", err)
+	}
+
+	if capiCluster.Spec.ControlPlaneRef == nil {
+		return nil, fmt.Errorf("CAPI cluster %s doesn't have a control plane", capiCluster.Name)
+	}
+
+	s := &SnapshotCAPI{KubeadmControlPlane: &controlplanev1.KubeadmControlPlane{}}
+	if err := client.Get(ctx, capiCluster.Spec.ControlPlaneRef.Name, constants.EksaSystemNamespace, s.KubeadmControlPlane); err != nil {
+		return nil, fmt.Errorf("reading kubeadm control plane for snapshot: %v", err)
+	}
+
+	templateRefs := []corev1.ObjectReference{s.KubeadmControlPlane.Spec.MachineTemplate.InfrastructureRef}
+
+	if capiCluster.Spec.ManagedExternalEtcdRef != nil {
+		s.EtcdadmCluster = &etcdv1.EtcdadmCluster{}
+		if err := client.Get(ctx, capiCluster.Spec.ManagedExternalEtcdRef.Name, constants.EksaSystemNamespace, s.EtcdadmCluster); err != nil {
+			return nil, fmt.Errorf("reading etcdadm cluster for snapshot: %v", err)
+		}
+		templateRefs = append(templateRefs, s.EtcdadmCluster.Spec.InfrastructureTemplate)
+	}
+
+	machineDeployments := &clusterv1.MachineDeploymentList{}
+	if err := client.List(ctx, machineDeployments, kubernetes.ListOptions{Namespace: constants.EksaSystemNamespace}); err != nil {
+		return nil, fmt.Errorf("listing machine deployments for snapshot: %v", err)
+	}
+
+	for i := range machineDeployments.Items {
+		md := &machineDeployments.Items[i]
+		if md.Spec.ClusterName != capiCluster.Name {
+			continue
+		}
+		s.MachineDeployments = append(s.MachineDeployments, md)
+		templateRefs = append(templateRefs, md.Spec.Template.Spec.InfrastructureRef)
+
+		if md.Spec.Template.Spec.Bootstrap.ConfigRef == nil {
+			continue
+		}
+		kct := &bootstrapv1.KubeadmConfigTemplate{}
+		if err := client.Get(ctx, md.Spec.Template.Spec.Bootstrap.ConfigRef.Name, constants.EksaSystemNamespace, kct); err != nil {
+			return nil, fmt.Errorf("reading kubeadm config template for snapshot: %v", err)
+		}
+		s.KubeadmConfigTemplates = append(s.KubeadmConfigTemplates, kct)
+	}
+
+	for _, ref := range templateRefs {
+		t := &unstructured.Unstructured{}
+		t.SetAPIVersion(ref.APIVersion)
+		t.SetKind(ref.Kind)
+		if err := client.Get(ctx, ref.Name, constants.EksaSystemNamespace, t); err != nil {
+			return nil, fmt.Errorf("reading %s %s for snapshot: %v", ref.Kind, ref.Name, err)
+		}
+		s.MachineTemplates = append(s.MachineTemplates, t)
+	}
+
+	return s, nil
+}
+
+// Objects returns all the CAPI objects in the snapshot.
+func (s *SnapshotCAPI) Objects() []kubernetes.Object {
+	objs := []kubernetes.Object{s.KubeadmControlPlane}
+	if s.EtcdadmCluster != nil {
+		objs = append(objs, s.EtcdadmCluster)
+	}
+	for _, md := range s.MachineDeployments {
+		objs = append(objs, md)
+	}
+	for _, kct := range s.KubeadmConfigTemplates {
+		objs = append(objs, kct)
+	}
+	for _, t := range s.MachineTemplates {
+		objs = append(objs, t)
+	}
+
+	return objs
+}
+
+// NewSnapshot builds a Snapshot from the spec of a running cluster.
+func NewSnapshot(spec *Spec) *Snapshot {
+	s := &Snapshot{Config: spec.Config.DeepCopy()}
+	if versionsBundle := spec.RootVersionsBundle(); versionsBundle != nil {
+		s.Versions = SnapshotVersions{
+			KubernetesVersion: versionsBundle.KubeDistro.Kubernetes.Tag,
+			EtcdVersion:       versionsBundle.KubeDistro.EtcdVersion,
+		}
+	}
+
+	return s
+}
+
+// WriteSnapshot writes a Snapshot to the dir folder. The objects are written
+// without server populated metadata, so they can be applied again to the cluster.
+func WriteSnapshot(dir string, s *Snapshot) error {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return fmt.Errorf("creating cluster snapshot folder: %v", err)
+	}
+
+	config, err := marshalSnapshotObjects(s.Config.ClusterAndChildren())
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, SnapshotConfigFile), config, 0o644); err != nil {
+		return fmt.Errorf("writing cluster snapshot: %v", err)
+	}
+
+	if s.CAPI != nil {
+		capi, err := marshalSnapshotObjects(s.CAPI.Objects())
+		if err != nil {
+			return err
+		}
+
+		if err := os.WriteFile(filepath.Join(dir, SnapshotCAPIFile), capi, 0o644); err != nil {
+			return fmt.Errorf("writing cluster CAPI snapshot: %v", err)
+		}
+	}
+
+	versions, err := yaml.Marshal(s.Versions)
+	if err != nil {
+		return fmt.Errorf("marshalling cluster snapshot versions: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, SnapshotVersionsFile), versions, 0o644); err != nil {
+		return fmt.Errorf("writing cluster snapshot versions: %v", err)
+	}
+
+	return nil
+}
+
+// ReadSnapshot reads a Snapshot written with WriteSnapshot from the dir folder.
+func ReadSnapshot(dir string) (*Snapshot, error) {
+	content, err := os.ReadFile(filepath.Join(dir, SnapshotConfigFile))
+	if err != nil {
+		return nil, fmt.Errorf("reading cluster snapshot: %v", err)
+	}
+
+	config, err := ParseConfig(content)
+	if err != nil {
+		return nil, fmt.Errorf("parsing cluster snapshot: %v", err)
+	}
+
+	content, err = os.ReadFile(filepath.Join(dir, SnapshotCAPIFile))
+	if err != nil {
+		return nil, fmt.Errorf("reading cluster CAPI snapshot: %v", err)
+	}
+
+	capi, err := parseSnapshotCAPI(content)
+	if err != nil {
+		return nil, fmt.Errorf("parsing cluster CAPI snapshot: %v", err)
+	}
+
+	content, err = os.ReadFile(filepath.Join(dir, SnapshotVersionsFile))
+	if err != nil {
+		return nil, fmt.Errorf("reading cluster snapshot versions: %v", err)
+	}
+
+	s := &Snapshot{Config: config, CAPI: capi}
+	if err := yaml.Unmarshal(content, &s.Versions); err != nil {
+		return nil, fmt.Errorf("parsing cluster snapshot versions: %v", err)
+	}
+
+	return s, nil
+}
+
+func parseSnapshotCAPI(content []byte) (*SnapshotCAPI, error) {
+	s := &SnapshotCAPI{}
+	decoder := apiyaml.NewYAMLOrJSONDecoder(bytes.NewReader(content), 4096)
+	for {
+		u := &unstructured.Unstructured{}
+		if err := decoder.Decode(&u.Object); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		if len(u.Object) == 0 {
+			continue
+		}
+
+		var err error
+		switch u.GetKind() {
+		case "KubeadmControlPlane":
+			s.KubeadmControlPlane = &controlplanev1.KubeadmControlPlane{}
+			err = runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, s.KubeadmControlPlane)
+		case "EtcdadmCluster":
+			s.EtcdadmCluster = &etcdv1.EtcdadmCluster{}
+			err = runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, s.EtcdadmCluster)
+		case "MachineDeployment":
+			md := &clusterv1.MachineDeployment{}
+			err = runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, md)
+			s.MachineDeployments = append(s.MachineDeployments, md)
+		case "KubeadmConfigTemplate":
+			kct := &bootstrapv1.KubeadmConfigTemplate{}
+			err = runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, kct)
+			s.KubeadmConfigTemplates = append(s.KubeadmConfigTemplates, kct)
+		default:
+			s.MachineTemplates = append(s.MachineTemplates, u)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("converting %s %s: %v", u.GetKind(), u.GetName(), err)
+		}
+	}
+
+	if s.KubeadmControlPlane == nil {
+		return nil, errors.New("missing kubeadm control plane")
+	}
+
+	return s, nil
+}
+
+func marshalSnapshotObjects(objs []kubernetes.Object) ([]byte, error) {
+	scheme := runtime.NewScheme()
+	for _, add := range []func(*runtime.Scheme) error{
+		anywherev1.AddToScheme,
+		corev1.AddToScheme,
+		clusterv1.AddToScheme,
+		controlplanev1.AddToScheme,
+		bootstrapv1.AddToScheme,
+		etcdv1.AddToScheme,
+	} {
+		if err := add(scheme); err != nil {
+			return nil, err
+		}
+	}
+
+	resources := make([][]byte, 0, len(objs))
+	for _, o := range objs {
+		obj := o.DeepCopyObject().(kubernetes.Object)
+		gvk, err := apiutil.GVKForObject(obj, scheme)
+		if err != nil {
+			return nil, err
+		}
+		obj.GetObjectKind().SetGroupVersionKind(gvk)
+		obj.SetResourceVersion("")
+		obj.SetUID("")
+		obj.SetGeneration(0)
+		obj.SetManagedFields(nil)
+		obj.SetOwnerReferences(nil)
+
+		b, err := yaml.Marshal(obj)
+		if err != nil {
+			return nil, fmt.Errorf("marshalling %s %s: %v", gvk.Kind, obj.GetName(), err)
+		}
+		resources = append(resources, b)
+	}
+
+	return yamlutils.Join(resources), nil
+}
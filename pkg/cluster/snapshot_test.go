@@ -0,0 +1,227 @@
+package cluster_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	etcdv1 "github.com/aws/etcdadm-controller/api/v1beta1"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	dockerv1 "sigs.k8s.io/cluster-api/test/infrastructure/docker/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+func snapshotClusterSpec() *cluster.Spec {
+	return test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = "my-cluster"
+		s.Cluster.Namespace = "default"
+		s.Cluster.ResourceVersion = "1234"
+		s.Cluster.UID = "uid"
+		s.Cluster.ManagedFields = []metav1.ManagedFieldsEntry{{Manager: "eks-a-cli"}}
+		s.Cluster.Spec.DatacenterRef = anywherev1.Ref{Kind: anywherev1.DockerDatacenterKind, Name: "my-cluster"}
+		s.DockerDatacenter = &anywherev1.DockerDatacenterConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-cluster",
+				Namespace: "default",
+				OwnerReferences: []metav1.OwnerReference{
+					{Kind: anywherev1.ClusterKind, Name: "my-cluster", UID: "uid"},
+				},
+			},
+		}
+		s.VersionsBundles[anywherev1.Kube119].KubeDistro.Kubernetes.Tag = "v1.19.8-eks-1-19-4"
+		s.VersionsBundles[anywherev1.Kube119].KubeDistro.EtcdVersion = "3.4.14"
+	})
+}
+
+func dockerMachineTemplate(name string) *dockerv1.DockerMachineTemplate {
+	return &dockerv1.DockerMachineTemplate{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "DockerMachineTemplate",
+			APIVersion: dockerv1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+		},
+	}
+}
+
+func templateRef(kind, apiVersion, name string) corev1.ObjectReference {
+	return corev1.ObjectReference{Kind: kind, APIVersion: apiVersion, Name: name, Namespace: constants.EksaSystemNamespace}
+}
+
+func snapshotCAPIObjects() []client.Object {
+	capiCluster := test.CAPICluster(func(c *clusterv1.Cluster) {
+		c.Name = "my-cluster"
+		c.Spec.ControlPlaneRef = &corev1.ObjectReference{Name: "my-cluster"}
+		c.Spec.ManagedExternalEtcdRef = &corev1.ObjectReference{Name: "my-cluster-etcd"}
+	})
+	kcp := test.KubeadmControlPlane(func(kcp *controlplanev1.KubeadmControlPlane) {
+		kcp.Name = "my-cluster"
+		kcp.Spec.Version = "v1.19.8-eks-1-19-4"
+		kcp.Spec.MachineTemplate.InfrastructureRef = templateRef("DockerMachineTemplate", dockerv1.GroupVersion.String(), "my-cluster-control-plane-1")
+	})
+	etcdadmCluster := &etcdv1.EtcdadmCluster{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "EtcdadmCluster",
+			APIVersion: etcdv1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster-etcd",
+			Namespace: constants.EksaSystemNamespace,
+		},
+		Spec: etcdv1.EtcdadmClusterSpec{
+			InfrastructureTemplate: templateRef("DockerMachineTemplate", dockerv1.GroupVersion.String(), "my-cluster-etcd-1"),
+		},
+	}
+	md := test.MachineDeployment(func(md *clusterv1.MachineDeployment) {
+		md.Name = "my-cluster-md-0"
+		md.Spec.ClusterName = "my-cluster"
+		md.Spec.Template.Spec.InfrastructureRef = templateRef("DockerMachineTemplate", dockerv1.GroupVersion.String(), "my-cluster-md-0-1")
+		md.Spec.Template.Spec.Bootstrap.ConfigRef = &corev1.ObjectReference{
+			Kind:       "KubeadmConfigTemplate",
+			APIVersion: bootstrapv1.GroupVersion.String(),
+			Name:       "my-cluster-md-0-1",
+		}
+	})
+	otherMD := test.MachineDeployment(func(md *clusterv1.MachineDeployment) {
+		md.Name = "other-cluster-md-0"
+		md.Spec.ClusterName = "other-cluster"
+	})
+	kct := &bootstrapv1.KubeadmConfigTemplate{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "KubeadmConfigTemplate",
+			APIVersion: bootstrapv1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster-md-0-1",
+			Namespace: constants.EksaSystemNamespace,
+		},
+	}
+
+	return []client.Object{
+		capiCluster, kcp, etcdadmCluster, md, otherMD, kct,
+		dockerMachineTemplate("my-cluster-control-plane-1"),
+		dockerMachineTemplate("my-cluster-etcd-1"),
+		dockerMachineTemplate("my-cluster-md-0-1"),
+	}
+}
+
+func TestNewSnapshotCAPI(t *testing.T) {
+	g := NewWithT(t)
+	client := test.NewFakeKubeClient(snapshotCAPIObjects()...)
+
+	s, err := cluster.NewSnapshotCAPI(context.Background(), client, snapshotClusterSpec().Cluster)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(s.KubeadmControlPlane.Name).To(Equal("my-cluster"))
+	g.Expect(s.EtcdadmCluster.Name).To(Equal("my-cluster-etcd"))
+	g.Expect(s.MachineDeployments).To(HaveLen(1))
+	g.Expect(s.MachineDeployments[0].Name).To(Equal("my-cluster-md-0"))
+	g.Expect(s.KubeadmConfigTemplates).To(HaveLen(1))
+	g.Expect(s.KubeadmConfigTemplates[0].Name).To(Equal("my-cluster-md-0-1"))
+	g.Expect(s.MachineTemplates).To(HaveLen(3))
+	for i, name := range []string{"my-cluster-control-plane-1", "my-cluster-etcd-1", "my-cluster-md-0-1"} {
+		g.Expect(s.MachineTemplates[i].GetKind()).To(Equal("DockerMachineTemplate"))
+		g.Expect(s.MachineTemplates[i].GetName()).To(Equal(name))
+	}
+}
+
+func TestNewSnapshotCAPIMissingTemplate(t *testing.T) {
+	g := NewWithT(t)
+	objs := snapshotCAPIObjects()
+	client := test.NewFakeKubeClient(objs[:len(objs)-1]...)
+
+	_, err := cluster.NewSnapshotCAPI(context.Background(), client, snapshotClusterSpec().Cluster)
+	g.Expect(err).To(MatchError(ContainSubstring("reading DockerMachineTemplate my-cluster-md-0-1 for snapshot")))
+}
+
+func TestNewSnapshotCAPIMissingCAPICluster(t *testing.T) {
+	g := NewWithT(t)
+	client := test.NewFakeKubeClient()
+
+	_, err := cluster.NewSnapshotCAPI(context.Background(), client, snapshotClusterSpec().Cluster)
+	g.Expect(err).To(MatchError(ContainSubstring("reading CAPI cluster for snapshot")))
+}
+
+func TestNewSnapshot(t *testing.T) {
+	g := NewWithT(t)
+	spec := snapshotClusterSpec()
+
+	s := cluster.NewSnapshot(spec)
+	g.Expect(s.Config).To(Equal(spec.Config))
+	g.Expect(s.Versions).To(Equal(cluster.SnapshotVersions{
+		KubernetesVersion: "v1.19.8-eks-1-19-4",
+		EtcdVersion:       "3.4.14",
+	}))
+}
+
+func TestWriteAndReadSnapshot(t *testing.T) {
+	g := NewWithT(t)
+	dir := filepath.Join(t.TempDir(), "my-cluster-backup")
+	want := cluster.NewSnapshot(snapshotClusterSpec())
+	capi, err := cluster.NewSnapshotCAPI(context.Background(), test.NewFakeKubeClient(snapshotCAPIObjects()...), want.Config.Cluster)
+	g.Expect(err).NotTo(HaveOccurred())
+	want.CAPI = capi
+
+	g.Expect(cluster.WriteSnapshot(dir, want)).To(Succeed())
+	got, err := cluster.ReadSnapshot(dir)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(got.Versions).To(Equal(want.Versions))
+	g.Expect(got.Config.Cluster.Name).To(Equal("my-cluster"))
+	g.Expect(got.Config.Cluster.Spec.KubernetesVersion).To(Equal(anywherev1.Kube119))
+	g.Expect(got.Config.Cluster.ResourceVersion).To(BeEmpty())
+	g.Expect(got.Config.Cluster.UID).To(BeEmpty())
+	g.Expect(got.Config.Cluster.ManagedFields).To(BeEmpty())
+	g.Expect(got.Config.DockerDatacenter).NotTo(BeNil())
+	g.Expect(got.Config.DockerDatacenter.OwnerReferences).To(BeEmpty())
+	g.Expect(want.Config.Cluster.ResourceVersion).To(Equal("1234"), "snapshot should not be modified")
+
+	g.Expect(got.CAPI.KubeadmControlPlane.Spec.Version).To(Equal("v1.19.8-eks-1-19-4"))
+	g.Expect(got.CAPI.KubeadmControlPlane.Spec.MachineTemplate.InfrastructureRef.Name).To(Equal("my-cluster-control-plane-1"))
+	g.Expect(got.CAPI.KubeadmControlPlane.ResourceVersion).To(BeEmpty())
+	g.Expect(got.CAPI.EtcdadmCluster.Spec.InfrastructureTemplate.Name).To(Equal("my-cluster-etcd-1"))
+	g.Expect(got.CAPI.MachineDeployments).To(HaveLen(1))
+	g.Expect(got.CAPI.MachineDeployments[0].Spec.Template.Spec.Bootstrap.ConfigRef.Name).To(Equal("my-cluster-md-0-1"))
+	g.Expect(got.CAPI.KubeadmConfigTemplates).To(HaveLen(1))
+	g.Expect(got.CAPI.MachineTemplates).To(HaveLen(3))
+	g.Expect(got.CAPI.MachineTemplates[2].GetName()).To(Equal("my-cluster-md-0-1"))
+	g.Expect(got.CAPI.MachineTemplates[2].GetResourceVersion()).To(BeEmpty())
+}
+
+func TestReadSnapshotMissingCAPI(t *testing.T) {
+	g := NewWithT(t)
+	dir := t.TempDir()
+	g.Expect(cluster.WriteSnapshot(dir, cluster.NewSnapshot(snapshotClusterSpec()))).To(Succeed())
+
+	_, err := cluster.ReadSnapshot(dir)
+	g.Expect(err).To(MatchError(ContainSubstring("reading cluster CAPI snapshot")))
+}
+
+func TestReadSnapshotCAPIWithoutControlPlane(t *testing.T) {
+	g := NewWithT(t)
+	dir := t.TempDir()
+	g.Expect(cluster.WriteSnapshot(dir, cluster.NewSnapshot(snapshotClusterSpec()))).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(dir, cluster.SnapshotCAPIFile), []byte("kind: MachineDeployment\n"), 0o644)).To(Succeed())
+
+	_, err := cluster.ReadSnapshot(dir)
+	g.Expect(err).To(MatchError(ContainSubstring("missing kubeadm control plane")))
+}
+
+func TestReadSnapshotMissing(t *testing.T) {
+	g := NewWithT(t)
+
+	_, err := cluster.ReadSnapshot(t.TempDir())
+	g.Expect(err).To(MatchError(ContainSubstring("reading cluster snapshot")))
+}
@@ -111,10 +111,14 @@ func NewSpec(config *Config, bundles *v1alpha1.Bundles, eksdReleases []eksdv1alp
 		break
 	}
 
-	// Get first oidc config if it exists
-	for _, oc := range s.Config.OIDCConfigs {
-		s.OIDCConfig = oc
-		break
+	// Get first oidc config if it exists, preferring the first one referenced by the cluster
+	if oidcConfigs := s.ReferencedOIDCConfigs(); len(oidcConfigs) > 0 {
+		s.OIDCConfig = oidcConfigs[0]
+	} else {
+		for _, oc := range s.Config.OIDCConfigs {
+			s.OIDCConfig = oc
+			break
+		}
 	}
 
 	return s, nil
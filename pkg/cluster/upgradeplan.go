@@ -0,0 +1,299 @@
+package cluster
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/version"
+	yamlutil "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	yamlutils "github.com/aws/eks-anywhere/pkg/utils/yaml"
+	v1alpha1release "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+// KubernetesUpgradeStep is one of the cluster upgrades needed to reach a target Kubernetes version.
+type KubernetesUpgradeStep struct {
+	// Cluster is the desired cluster with the Kubernetes versions for this step.
+	Cluster *v1alpha1.Cluster
+	// EksD is the EKS-D release the control plane is upgraded to in this step.
+	EksD v1alpha1release.EksDRelease
+}
+
+// PlanKubernetesUpgrade computes the upgrades needed to take the current cluster to the desired one,
+// one Kubernetes minor version at a time. All the changes in desired other than the Kubernetes versions
+// are applied in the first step. Worker node groups are upgraded as early as the version skew rules allow,
+// never past the version they have in desired, and the last step is always desired.
+// It fails if any of the transitions breaks the version skew rules or if any of the
+// Kubernetes versions is not included in bundles.
+func PlanKubernetesUpgrade(current, desired *v1alpha1.Cluster, bundles *v1alpha1release.Bundles) ([]KubernetesUpgradeStep, error) {
+	path, err := v1alpha1.KubernetesUpgradePath(current.Spec.KubernetesVersion, desired.Spec.KubernetesVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(path) == 0 {
+		path = []v1alpha1.KubernetesVersion{desired.Spec.KubernetesVersion}
+	}
+
+	steps := make([]KubernetesUpgradeStep, 0, len(path))
+	previous := current
+	for i, kubeVersion := range path {
+		step := desired.DeepCopy()
+		if i < len(path)-1 {
+			if err := setIntermediateKubernetesVersions(step, previous, desired, kubeVersion); err != nil {
+				return nil, err
+			}
+		}
+
+		errs := v1alpha1.ValidateKubernetesVersionSkew(step, previous)
+		errs = append(errs, v1alpha1.ValidateWorkerKubernetesVersionSkew(step, previous)...)
+		if len(errs) != 0 {
+			return nil, fmt.Errorf("upgrading from kubernetes version %s to %s: %v", previous.Spec.KubernetesVersion, kubeVersion, errs.ToAggregate())
+		}
+
+		for _, v := range step.KubernetesVersions() {
+			if _, err := GetVersionsBundle(v, bundles); err != nil {
+				return nil, fmt.Errorf("upgrading to kubernetes version %s requires an EKS Anywhere version that supports it: %v", v, err)
+			}
+		}
+
+		versionsBundle, err := GetVersionsBundle(kubeVersion, bundles)
+		if err != nil {
+			return nil, err
+		}
+
+		steps = append(steps, KubernetesUpgradeStep{
+			Cluster: step,
+			EksD:    versionsBundle.EksD,
+		})
+		previous = step
+	}
+
+	return steps, nil
+}
+
+// setIntermediateKubernetesVersions sets the control plane Kubernetes version of an intermediate step
+// and moves each worker node group one minor version closer to its desired version.
+func setIntermediateKubernetesVersions(step, previous, desired *v1alpha1.Cluster, kubeVersion v1alpha1.KubernetesVersion) error {
+	step.Spec.KubernetesVersion = kubeVersion
+	previousGroups := BuildMapForWorkerNodeGroupsByName(previous.Spec.WorkerNodeGroupConfigurations)
+
+	for i := range step.Spec.WorkerNodeGroupConfigurations {
+		group := &step.Spec.WorkerNodeGroupConfigurations[i]
+		desiredVersion := desired.Spec.KubernetesVersion
+		if group.KubernetesVersion != nil {
+			desiredVersion = *group.KubernetesVersion
+		}
+
+		previousGroup, ok := previousGroups[group.Name]
+		if !ok {
+			// New worker node groups are created with the control plane version of the step.
+			if group.KubernetesVersion != nil {
+				v, err := minKubernetesVersion(desiredVersion, kubeVersion)
+				if err != nil {
+					return err
+				}
+				group.KubernetesVersion = &v
+			}
+			continue
+		}
+
+		previousVersion := previous.Spec.KubernetesVersion
+		if previousGroup.KubernetesVersion != nil {
+			previousVersion = *previousGroup.KubernetesVersion
+		}
+
+		nextVersion, err := nextMinorKubernetesVersion(previousVersion)
+		if err != nil {
+			return err
+		}
+
+		v, err := minKubernetesVersion(desiredVersion, nextVersion, kubeVersion)
+		if err != nil {
+			return err
+		}
+
+		if previousGroup.KubernetesVersion == nil && group.KubernetesVersion == nil && v == kubeVersion {
+			// The worker node group keeps following the control plane version.
+			continue
+		}
+		group.KubernetesVersion = &v
+	}
+
+	return nil
+}
+
+func nextMinorKubernetesVersion(kubeVersion v1alpha1.KubernetesVersion) (v1alpha1.KubernetesVersion, error) {
+	v, err := version.ParseGeneric(string(kubeVersion))
+	if err != nil {
+		return "", fmt.Errorf("parsing kubernetes version %s: %v", kubeVersion, err)
+	}
+
+	return v1alpha1.KubernetesVersion(fmt.Sprintf("%d.%d", v.Major(), v.Minor()+1)), nil
+}
+
+func minKubernetesVersion(kubeVersions ...v1alpha1.KubernetesVersion) (v1alpha1.KubernetesVersion, error) {
+	var minVersion v1alpha1.KubernetesVersion
+	var minParsed *version.Version
+	for _, kubeVersion := range kubeVersions {
+		v, err := version.ParseGeneric(string(kubeVersion))
+		if err != nil {
+			return "", fmt.Errorf("parsing kubernetes version %s: %v", kubeVersion, err)
+		}
+
+		if minParsed == nil || v.LessThan(minParsed) {
+			minVersion, minParsed = kubeVersion, v
+		}
+	}
+
+	return minVersion, nil
+}
+
+// MachineImage is the OS image a machine config needs to run the machines of a Kubernetes version.
+type MachineImage struct {
+	// MachineConfig is the kind and name of the machine config, like VSphereMachineConfig/prod-cp.
+	MachineConfig string
+	// KubernetesVersion is the Kubernetes version of the machines using the machine config.
+	KubernetesVersion v1alpha1.KubernetesVersion
+	// Image is the template, OS image URL or image currently set for the machine config.
+	Image string
+}
+
+// MachineImages returns the OS images the machine configs in config need for the Kubernetes versions
+// of c, for the providers that validate the Kubernetes version of the OS image: vSphere templates,
+// Tinkerbell OS image URLs and Nutanix images. Machine configs without an image, like the Bottlerocket
+// ones using the images from the bundle, are not included.
+func MachineImages(config *Config, c *v1alpha1.Cluster) []MachineImage {
+	var images []MachineImage
+	seen := map[MachineImage]bool{}
+	add := func(ref *v1alpha1.Ref, kubeVersion v1alpha1.KubernetesVersion) {
+		if ref == nil {
+			return
+		}
+		image := machineConfigImage(config, ref)
+		if image == "" {
+			return
+		}
+		m := MachineImage{
+			MachineConfig:     ref.Kind + "/" + ref.Name,
+			KubernetesVersion: kubeVersion,
+			Image:             image,
+		}
+		if !seen[m] {
+			seen[m] = true
+			images = append(images, m)
+		}
+	}
+
+	add(c.Spec.ControlPlaneConfiguration.MachineGroupRef, c.Spec.KubernetesVersion)
+	if c.Spec.ExternalEtcdConfiguration != nil {
+		add(c.Spec.ExternalEtcdConfiguration.MachineGroupRef, c.Spec.KubernetesVersion)
+	}
+	for _, w := range c.Spec.WorkerNodeGroupConfigurations {
+		kubeVersion := c.Spec.KubernetesVersion
+		if w.KubernetesVersion != nil {
+			kubeVersion = *w.KubernetesVersion
+		}
+		add(w.MachineGroupRef, kubeVersion)
+	}
+
+	return images
+}
+
+// MissingMachineImages returns the machine images needed by the step cluster that the machine configs
+// in config don't provide, because they are set for the Kubernetes versions of the desired cluster.
+// Intermediate upgrade steps need machine configs with OS images for their own Kubernetes versions.
+func MissingMachineImages(config *Config, step, desired *v1alpha1.Cluster) []MachineImage {
+	provided := map[string]bool{}
+	for _, m := range MachineImages(config, desired) {
+		provided[m.MachineConfig+"@"+string(m.KubernetesVersion)] = true
+	}
+
+	var missing []MachineImage
+	for _, m := range MachineImages(config, step) {
+		if !provided[m.MachineConfig+"@"+string(m.KubernetesVersion)] {
+			missing = append(missing, m)
+		}
+	}
+
+	return missing
+}
+
+func machineConfigImage(config *Config, ref *v1alpha1.Ref) string {
+	switch ref.Kind {
+	case v1alpha1.VSphereMachineConfigKind:
+		if m, ok := config.VSphereMachineConfigs[ref.Name]; ok {
+			return m.Spec.Template
+		}
+	case v1alpha1.TinkerbellMachineConfigKind:
+		if m, ok := config.TinkerbellMachineConfigs[ref.Name]; ok {
+			if m.Spec.OSImageURL != "" {
+				return m.Spec.OSImageURL
+			}
+			if config.TinkerbellDatacenter != nil {
+				return config.TinkerbellDatacenter.Spec.OSImageURL
+			}
+		}
+	case v1alpha1.NutanixMachineConfigKind:
+		if m, ok := config.NutanixMachineConfigs[ref.Name]; ok {
+			if m.Spec.Image.Name != nil {
+				return *m.Spec.Image.Name
+			}
+			if m.Spec.Image.UUID != nil {
+				return *m.Spec.Image.UUID
+			}
+		}
+	}
+
+	return ""
+}
+
+// ReplaceClusterInConfig replaces the Cluster object in a multi-object yaml cluster config,
+// keeping the rest of the objects as they are.
+func ReplaceClusterInConfig(yamlManifest []byte, c *v1alpha1.Cluster) ([]byte, error) {
+	c = c.DeepCopy()
+	c.TypeMeta.APIVersion = v1alpha1.GroupVersion.String()
+	c.TypeMeta.Kind = v1alpha1.ClusterKind
+
+	var resources [][]byte
+	replaced := false
+	r := yamlutil.NewYAMLReader(bufio.NewReader(bytes.NewReader(yamlManifest)))
+	for {
+		d, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if len(bytes.TrimSpace(d)) == 0 {
+			continue
+		}
+
+		typeMeta := &metav1.TypeMeta{}
+		if err := yaml.Unmarshal(d, typeMeta); err != nil {
+			return nil, err
+		}
+
+		if !replaced && typeMeta.Kind == v1alpha1.ClusterKind {
+			if d, err = yaml.Marshal(c); err != nil {
+				return nil, fmt.Errorf("marshalling cluster: %v", err)
+			}
+			replaced = true
+		}
+
+		resources = append(resources, bytes.TrimSpace(d))
+	}
+
+	if !replaced {
+		return nil, fmt.Errorf("cluster config does not contain kind %s", v1alpha1.ClusterKind)
+	}
+
+	return yamlutils.Join(resources), nil
+}
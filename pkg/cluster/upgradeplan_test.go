@@ -0,0 +1,271 @@
+package cluster_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+func upgradePlanBundles(kubeVersions ...anywherev1.KubernetesVersion) *releasev1.Bundles {
+	bundles := &releasev1.Bundles{
+		Spec: releasev1.BundlesSpec{
+			Number: 2,
+		},
+	}
+	for _, v := range kubeVersions {
+		bundles.Spec.VersionsBundles = append(bundles.Spec.VersionsBundles, releasev1.VersionsBundle{
+			KubeVersion: string(v),
+			EksD: releasev1.EksDRelease{
+				Name: "kubernetes-" + string(v) + "-eks-1",
+			},
+		})
+	}
+	return bundles
+}
+
+func upgradePlanCluster(kubeVersion anywherev1.KubernetesVersion, workers ...anywherev1.WorkerNodeGroupConfiguration) *anywherev1.Cluster {
+	return &anywherev1.Cluster{
+		Spec: anywherev1.ClusterSpec{
+			KubernetesVersion:             kubeVersion,
+			WorkerNodeGroupConfigurations: workers,
+		},
+	}
+}
+
+func upgradePlanKubeVersion(v anywherev1.KubernetesVersion) *anywherev1.KubernetesVersion {
+	return &v
+}
+
+func workerVersions(c *anywherev1.Cluster) map[string]*anywherev1.KubernetesVersion {
+	m := map[string]*anywherev1.KubernetesVersion{}
+	for _, w := range c.Spec.WorkerNodeGroupConfigurations {
+		m[w.Name] = w.KubernetesVersion
+	}
+	return m
+}
+
+func TestPlanKubernetesUpgradeSameMinor(t *testing.T) {
+	g := NewWithT(t)
+	current := upgradePlanCluster(anywherev1.Kube128, anywherev1.WorkerNodeGroupConfiguration{Name: "md-0"})
+	desired := upgradePlanCluster(anywherev1.Kube128, anywherev1.WorkerNodeGroupConfiguration{Name: "md-0"})
+
+	steps, err := cluster.PlanKubernetesUpgrade(current, desired, upgradePlanBundles(anywherev1.Kube128))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(steps).To(HaveLen(1))
+	g.Expect(steps[0].Cluster).To(Equal(desired))
+	g.Expect(steps[0].EksD.Name).To(Equal("kubernetes-1.28-eks-1"))
+}
+
+func TestPlanKubernetesUpgradeMultipleMinors(t *testing.T) {
+	g := NewWithT(t)
+	current := upgradePlanCluster(anywherev1.Kube127,
+		anywherev1.WorkerNodeGroupConfiguration{Name: "md-0"},
+		anywherev1.WorkerNodeGroupConfiguration{Name: "md-1", KubernetesVersion: upgradePlanKubeVersion(anywherev1.Kube126)},
+		anywherev1.WorkerNodeGroupConfiguration{Name: "md-2"},
+	)
+	desired := upgradePlanCluster(anywherev1.Kube130,
+		anywherev1.WorkerNodeGroupConfiguration{Name: "md-0"},
+		anywherev1.WorkerNodeGroupConfiguration{Name: "md-1", KubernetesVersion: upgradePlanKubeVersion(anywherev1.Kube129)},
+		anywherev1.WorkerNodeGroupConfiguration{Name: "md-2", KubernetesVersion: upgradePlanKubeVersion(anywherev1.Kube128)},
+	)
+	bundles := upgradePlanBundles(anywherev1.Kube126, anywherev1.Kube127, anywherev1.Kube128, anywherev1.Kube129, anywherev1.Kube130)
+
+	steps, err := cluster.PlanKubernetesUpgrade(current, desired, bundles)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(steps).To(HaveLen(3))
+
+	g.Expect(steps[0].Cluster.Spec.KubernetesVersion).To(Equal(anywherev1.Kube128))
+	g.Expect(steps[0].EksD.Name).To(Equal("kubernetes-1.28-eks-1"))
+	g.Expect(workerVersions(steps[0].Cluster)).To(Equal(map[string]*anywherev1.KubernetesVersion{
+		"md-0": nil,
+		"md-1": upgradePlanKubeVersion(anywherev1.Kube127),
+		"md-2": upgradePlanKubeVersion(anywherev1.Kube128),
+	}))
+
+	g.Expect(steps[1].Cluster.Spec.KubernetesVersion).To(Equal(anywherev1.Kube129))
+	g.Expect(workerVersions(steps[1].Cluster)).To(Equal(map[string]*anywherev1.KubernetesVersion{
+		"md-0": nil,
+		"md-1": upgradePlanKubeVersion(anywherev1.Kube128),
+		"md-2": upgradePlanKubeVersion(anywherev1.Kube128),
+	}))
+
+	g.Expect(steps[2].Cluster).To(Equal(desired))
+	g.Expect(steps[2].EksD.Name).To(Equal("kubernetes-1.30-eks-1"))
+}
+
+func TestPlanKubernetesUpgradeNewWorkerNodeGroup(t *testing.T) {
+	g := NewWithT(t)
+	current := upgradePlanCluster(anywherev1.Kube128)
+	desired := upgradePlanCluster(anywherev1.Kube130,
+		anywherev1.WorkerNodeGroupConfiguration{Name: "md-0"},
+		anywherev1.WorkerNodeGroupConfiguration{Name: "md-1", KubernetesVersion: upgradePlanKubeVersion(anywherev1.Kube130)},
+	)
+
+	steps, err := cluster.PlanKubernetesUpgrade(current, desired, upgradePlanBundles(anywherev1.Kube129, anywherev1.Kube130))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(steps).To(HaveLen(2))
+	g.Expect(workerVersions(steps[0].Cluster)).To(Equal(map[string]*anywherev1.KubernetesVersion{
+		"md-0": nil,
+		"md-1": upgradePlanKubeVersion(anywherev1.Kube129),
+	}))
+}
+
+func TestPlanKubernetesUpgradeVersionNotInBundles(t *testing.T) {
+	g := NewWithT(t)
+	current := upgradePlanCluster(anywherev1.Kube127)
+	desired := upgradePlanCluster(anywherev1.Kube129)
+
+	_, err := cluster.PlanKubernetesUpgrade(current, desired, upgradePlanBundles(anywherev1.Kube127, anywherev1.Kube129))
+	g.Expect(err).To(MatchError(ContainSubstring("upgrading to kubernetes version 1.28 requires an EKS Anywhere version that supports it")))
+}
+
+func TestPlanKubernetesUpgradeDowngrade(t *testing.T) {
+	g := NewWithT(t)
+	current := upgradePlanCluster(anywherev1.Kube129)
+	desired := upgradePlanCluster(anywherev1.Kube127)
+
+	_, err := cluster.PlanKubernetesUpgrade(current, desired, upgradePlanBundles(anywherev1.Kube127))
+	g.Expect(err).To(MatchError(ContainSubstring("kubernetes version downgrade is not supported")))
+}
+
+func TestPlanKubernetesUpgradeInvalidWorkerSkew(t *testing.T) {
+	g := NewWithT(t)
+	current := upgradePlanCluster(anywherev1.Kube127,
+		anywherev1.WorkerNodeGroupConfiguration{Name: "md-0", KubernetesVersion: upgradePlanKubeVersion(anywherev1.Kube126)},
+	)
+	desired := upgradePlanCluster(anywherev1.Kube129,
+		anywherev1.WorkerNodeGroupConfiguration{Name: "md-0"},
+	)
+
+	_, err := cluster.PlanKubernetesUpgrade(current, desired, upgradePlanBundles(anywherev1.Kube127, anywherev1.Kube128, anywherev1.Kube129))
+	g.Expect(err).To(MatchError(ContainSubstring("upgrading from kubernetes version 1.28 to 1.29")))
+}
+
+func TestReplaceClusterInConfig(t *testing.T) {
+	g := NewWithT(t)
+	config := []byte(`apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: Cluster
+metadata:
+  name: my-cluster
+spec:
+  kubernetesVersion: "1.27"
+---
+apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: DockerDatacenterConfig
+metadata:
+  name: my-cluster
+spec: {}
+`)
+	c := upgradePlanCluster(anywherev1.Kube128)
+	c.Name = "my-cluster"
+
+	got, err := cluster.ReplaceClusterInConfig(config, c)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	parsed, err := cluster.ParseConfig(got)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(parsed.Cluster.Spec.KubernetesVersion).To(Equal(anywherev1.Kube128))
+	g.Expect(string(got)).To(ContainSubstring("kind: DockerDatacenterConfig"))
+}
+
+func TestReplaceClusterInConfigNoCluster(t *testing.T) {
+	g := NewWithT(t)
+	config := []byte(`apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: DockerDatacenterConfig
+metadata:
+  name: my-cluster
+`)
+
+	_, err := cluster.ReplaceClusterInConfig(config, upgradePlanCluster(anywherev1.Kube128))
+	g.Expect(err).To(MatchError(ContainSubstring("cluster config does not contain kind Cluster")))
+}
+
+func machineImagesCluster(kubeVersion anywherev1.KubernetesVersion, cpKind, cpName string, workers ...anywherev1.WorkerNodeGroupConfiguration) *anywherev1.Cluster {
+	c := upgradePlanCluster(kubeVersion, workers...)
+	c.Spec.ControlPlaneConfiguration.MachineGroupRef = &anywherev1.Ref{Kind: cpKind, Name: cpName}
+	return c
+}
+
+func TestMachineImagesVSphere(t *testing.T) {
+	g := NewWithT(t)
+	config := &cluster.Config{
+		VSphereMachineConfigs: map[string]*anywherev1.VSphereMachineConfig{
+			"cp":      {Spec: anywherev1.VSphereMachineConfigSpec{Template: "/dc/vm/ubuntu-1-29"}},
+			"md":      {Spec: anywherev1.VSphereMachineConfigSpec{Template: "/dc/vm/ubuntu-1-28"}},
+			"default": {},
+		},
+	}
+	c := machineImagesCluster(anywherev1.Kube129, anywherev1.VSphereMachineConfigKind, "cp",
+		anywherev1.WorkerNodeGroupConfiguration{
+			Name:              "md-0",
+			KubernetesVersion: upgradePlanKubeVersion(anywherev1.Kube128),
+			MachineGroupRef:   &anywherev1.Ref{Kind: anywherev1.VSphereMachineConfigKind, Name: "md"},
+		},
+		anywherev1.WorkerNodeGroupConfiguration{
+			Name:              "md-1",
+			KubernetesVersion: upgradePlanKubeVersion(anywherev1.Kube128),
+			MachineGroupRef:   &anywherev1.Ref{Kind: anywherev1.VSphereMachineConfigKind, Name: "md"},
+		},
+		anywherev1.WorkerNodeGroupConfiguration{
+			Name:            "md-2",
+			MachineGroupRef: &anywherev1.Ref{Kind: anywherev1.VSphereMachineConfigKind, Name: "default"},
+		},
+	)
+
+	g.Expect(cluster.MachineImages(config, c)).To(Equal([]cluster.MachineImage{
+		{MachineConfig: "VSphereMachineConfig/cp", KubernetesVersion: anywherev1.Kube129, Image: "/dc/vm/ubuntu-1-29"},
+		{MachineConfig: "VSphereMachineConfig/md", KubernetesVersion: anywherev1.Kube128, Image: "/dc/vm/ubuntu-1-28"},
+	}))
+}
+
+func TestMachineImagesTinkerbellDatacenterImage(t *testing.T) {
+	g := NewWithT(t)
+	config := &cluster.Config{
+		TinkerbellDatacenter: &anywherev1.TinkerbellDatacenterConfig{
+			Spec: anywherev1.TinkerbellDatacenterConfigSpec{OSImageURL: "https://images/ubuntu-1-29.gz"},
+		},
+		TinkerbellMachineConfigs: map[string]*anywherev1.TinkerbellMachineConfig{
+			"cp": {},
+			"md": {Spec: anywherev1.TinkerbellMachineConfigSpec{OSImageURL: "https://images/ubuntu-md-1-29.gz"}},
+		},
+	}
+	c := machineImagesCluster(anywherev1.Kube129, anywherev1.TinkerbellMachineConfigKind, "cp",
+		anywherev1.WorkerNodeGroupConfiguration{
+			Name:            "md-0",
+			MachineGroupRef: &anywherev1.Ref{Kind: anywherev1.TinkerbellMachineConfigKind, Name: "md"},
+		},
+	)
+
+	g.Expect(cluster.MachineImages(config, c)).To(Equal([]cluster.MachineImage{
+		{MachineConfig: "TinkerbellMachineConfig/cp", KubernetesVersion: anywherev1.Kube129, Image: "https://images/ubuntu-1-29.gz"},
+		{MachineConfig: "TinkerbellMachineConfig/md", KubernetesVersion: anywherev1.Kube129, Image: "https://images/ubuntu-md-1-29.gz"},
+	}))
+}
+
+func TestMissingMachineImages(t *testing.T) {
+	g := NewWithT(t)
+	config := &cluster.Config{
+		VSphereMachineConfigs: map[string]*anywherev1.VSphereMachineConfig{
+			"cp": {Spec: anywherev1.VSphereMachineConfigSpec{Template: "/dc/vm/ubuntu-1-29"}},
+			"md": {Spec: anywherev1.VSphereMachineConfigSpec{Template: "/dc/vm/ubuntu-1-28"}},
+		},
+	}
+	worker := func(kubeVersion anywherev1.KubernetesVersion) anywherev1.WorkerNodeGroupConfiguration {
+		return anywherev1.WorkerNodeGroupConfiguration{
+			Name:              "md-0",
+			KubernetesVersion: upgradePlanKubeVersion(kubeVersion),
+			MachineGroupRef:   &anywherev1.Ref{Kind: anywherev1.VSphereMachineConfigKind, Name: "md"},
+		}
+	}
+	desired := machineImagesCluster(anywherev1.Kube129, anywherev1.VSphereMachineConfigKind, "cp", worker(anywherev1.Kube128))
+	step := machineImagesCluster(anywherev1.Kube128, anywherev1.VSphereMachineConfigKind, "cp", worker(anywherev1.Kube128))
+
+	g.Expect(cluster.MissingMachineImages(config, step, desired)).To(Equal([]cluster.MachineImage{
+		{MachineConfig: "VSphereMachineConfig/cp", KubernetesVersion: anywherev1.Kube128, Image: "/dc/vm/ubuntu-1-29"},
+	}))
+	g.Expect(cluster.MissingMachineImages(config, desired, desired)).To(BeEmpty())
+}
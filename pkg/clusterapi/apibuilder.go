@@ -35,6 +35,9 @@ var (
 	etcdAPIVersion                = etcdv1.GroupVersion.String()
 )
 
+// kubeProxyAddonPhase is the kubeadm init phase that deploys kube-proxy, it's skipped when Cilium replaces it.
+const kubeProxyAddonPhase = "addon/kube-proxy"
+
 type APIObject interface {
 	runtime.Object
 	GetName() string
@@ -201,6 +204,10 @@ func KubeadmControlPlane(clusterSpec *cluster.Spec, infrastructureObject APIObje
 
 	SetUpgradeRolloutStrategyInKubeadmControlPlane(kcp, clusterSpec.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy)
 
+	if clusterSpec.Cluster.Spec.ClusterNetwork.KubeProxyReplaced() {
+		kcp.Spec.KubeadmConfigSpec.InitConfiguration.SkipPhases = []string{kubeProxyAddonPhase}
+	}
+
 	return kcp, nil
 }
 
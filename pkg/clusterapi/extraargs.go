@@ -7,6 +7,8 @@ import (
 	"strings"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/authentication"
+	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/crypto"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/templater"
@@ -24,15 +26,26 @@ func OIDCToExtraArgs(oidc *v1alpha1.OIDCConfig) ExtraArgs {
 	args.AddIfNotEmpty("oidc-groups-claim", oidc.Spec.GroupsClaim)
 	args.AddIfNotEmpty("oidc-groups-prefix", oidc.Spec.GroupsPrefix)
 	args.AddIfNotEmpty("oidc-issuer-url", oidc.Spec.IssuerUrl)
-	if len(oidc.Spec.RequiredClaims) > 0 {
-		args.AddIfNotEmpty("oidc-required-claim", requiredClaimToArg(&oidc.Spec.RequiredClaims[0]))
-	}
+	args.AddIfNotEmpty("oidc-required-claim", requiredClaimsToArg(oidc.Spec.RequiredClaims))
 	args.AddIfNotEmpty("oidc-username-claim", oidc.Spec.UsernameClaim)
 	args.AddIfNotEmpty("oidc-username-prefix", oidc.Spec.UsernamePrefix)
 
 	return args
 }
 
+// AuthenticationExtraArgs returns the kube-apiserver args to configure the OIDC identity providers
+// of a cluster. Clusters using structured authentication read them from the authentication config
+// file, which is reloaded in place, while the rest use the oidc flags for the first OIDCConfig.
+func AuthenticationExtraArgs(clusterSpec *cluster.Spec) ExtraArgs {
+	if !clusterSpec.Cluster.UsesStructuredAuthentication() {
+		return OIDCToExtraArgs(clusterSpec.OIDCConfig)
+	}
+
+	return ExtraArgs{
+		"authentication-config": authentication.APIServerConfigPath(),
+	}
+}
+
 func AwsIamAuthExtraArgs(awsiam *v1alpha1.AWSIamConfig) ExtraArgs {
 	args := ExtraArgs{}
 	if awsiam == nil {
@@ -71,12 +84,48 @@ func PodIAMAuthExtraArgs(podIAMConfig *v1alpha1.PodIAMConfig) ExtraArgs {
 	return args
 }
 
+// NodeCIDRMaskExtraArgs returns the controller manager args that set the node CIDR mask sizes.
+// Dual-stack clusters need a mask size per IP family, while single stack clusters use the mask
+// size that corresponds to their only family.
 func NodeCIDRMaskExtraArgs(clusterNetwork *v1alpha1.ClusterNetwork) ExtraArgs {
-	if clusterNetwork == nil || clusterNetwork.Nodes == nil || clusterNetwork.Nodes.CIDRMaskSize == nil {
+	if clusterNetwork == nil || clusterNetwork.Nodes == nil {
 		return nil
 	}
 	args := ExtraArgs{}
-	args.AddIfNotEmpty("node-cidr-mask-size", strconv.Itoa(*clusterNetwork.Nodes.CIDRMaskSize))
+	switch clusterNetwork.IPFamily() {
+	case v1alpha1.DualStack:
+		args.AddIfNotEmpty("node-cidr-mask-size-ipv4", intPtrToString(clusterNetwork.Nodes.CIDRMaskSize))
+		args.AddIfNotEmpty("node-cidr-mask-size-ipv6", intPtrToString(clusterNetwork.Nodes.CIDRMaskSizeIPv6))
+	case v1alpha1.IPv6:
+		args.AddIfNotEmpty("node-cidr-mask-size", intPtrToString(clusterNetwork.Nodes.CIDRMaskSizeIPv6))
+	default:
+		args.AddIfNotEmpty("node-cidr-mask-size", intPtrToString(clusterNetwork.Nodes.CIDRMaskSize))
+	}
+	if len(args) == 0 {
+		return nil
+	}
+	return args
+}
+
+// IPv6BindAddressExtraArgs returns the controller manager and scheduler args needed to
+// serve on IPv6 addresses when IPv6 is the primary IP family of the cluster.
+func IPv6BindAddressExtraArgs(clusterNetwork *v1alpha1.ClusterNetwork) ExtraArgs {
+	if clusterNetwork == nil || !clusterNetwork.IsIPv6Primary() {
+		return nil
+	}
+	args := ExtraArgs{}
+	args.AddIfNotEmpty("bind-address", "::")
+	return args
+}
+
+// IPv6NodeIPExtraArgs returns the kubelet args needed to register the node with its IPv6
+// address when IPv6 is the primary IP family of the cluster.
+func IPv6NodeIPExtraArgs(clusterNetwork *v1alpha1.ClusterNetwork) ExtraArgs {
+	if clusterNetwork == nil || !clusterNetwork.IsIPv6Primary() {
+		return nil
+	}
+	args := ExtraArgs{}
+	args.AddIfNotEmpty("node-ip", "::")
 	return args
 }
 
@@ -164,12 +213,16 @@ func (e ExtraArgs) ToPartialYaml() templater.PartialYaml {
 	return p
 }
 
-func requiredClaimToArg(r *v1alpha1.OIDCConfigRequiredClaim) string {
-	if r == nil || r.Claim == "" {
-		return ""
+func requiredClaimsToArg(claims []v1alpha1.OIDCConfigRequiredClaim) string {
+	args := make([]string, 0, len(claims))
+	for _, r := range claims {
+		if r.Claim == "" {
+			continue
+		}
+		args = append(args, fmt.Sprintf("%s=%s", r.Claim, r.Value))
 	}
 
-	return fmt.Sprintf("%s=%s", r.Claim, r.Value)
+	return strings.Join(args, ",")
 }
 
 func labelsMapToArg(m map[string]string) string {
@@ -182,3 +235,10 @@ func labelsMapToArg(m map[string]string) string {
 	labelStr := strings.Join(labels, ",")
 	return labelStr
 }
+
+func intPtrToString(i *int) string {
+	if i == nil {
+		return ""
+	}
+	return strconv.Itoa(*i)
+}
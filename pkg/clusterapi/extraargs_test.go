@@ -77,6 +77,24 @@ func TestOIDCToExtraArgs(t *testing.T) {
 				"oidc-username-prefix": "username-prefix",
 			},
 		},
+		{
+			testName: "multiple required claims",
+			oidc: &v1alpha1.OIDCConfig{
+				Spec: v1alpha1.OIDCConfigSpec{
+					ClientId:  "my-client-id",
+					IssuerUrl: "https://mydomain.com/issuer",
+					RequiredClaims: []v1alpha1.OIDCConfigRequiredClaim{
+						{Claim: "sub", Value: "test"},
+						{Claim: "hd", Value: "example.com"},
+					},
+				},
+			},
+			want: clusterapi.ExtraArgs{
+				"oidc-client-id":      "my-client-id",
+				"oidc-issuer-url":     "https://mydomain.com/issuer",
+				"oidc-required-claim": "sub=test,hd=example.com",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -553,6 +571,8 @@ func TestAppend(t *testing.T) {
 func TestNodeCIDRMaskExtraArgs(t *testing.T) {
 	nodeCidrMaskSize := new(int)
 	*nodeCidrMaskSize = 28
+	nodeCidrMaskSizeIPv6 := new(int)
+	*nodeCidrMaskSizeIPv6 = 120
 	tests := []struct {
 		testName       string
 		clusterNetwork *v1alpha1.ClusterNetwork
@@ -586,6 +606,37 @@ func TestNodeCIDRMaskExtraArgs(t *testing.T) {
 			},
 			want: nil,
 		},
+		{
+			testName: "ipv6 with nodes config",
+			clusterNetwork: &v1alpha1.ClusterNetwork{
+				Pods:  v1alpha1.Pods{CidrBlocks: []string{"fd00:10:244::/56"}},
+				Nodes: &v1alpha1.Nodes{CIDRMaskSize: nodeCidrMaskSize, CIDRMaskSizeIPv6: nodeCidrMaskSizeIPv6},
+			},
+			want: clusterapi.ExtraArgs{
+				"node-cidr-mask-size": "120",
+			},
+		},
+		{
+			testName: "dual-stack with nodes config",
+			clusterNetwork: &v1alpha1.ClusterNetwork{
+				Pods:  v1alpha1.Pods{CidrBlocks: []string{"192.168.0.0/16", "fd00:10:244::/56"}},
+				Nodes: &v1alpha1.Nodes{CIDRMaskSize: nodeCidrMaskSize, CIDRMaskSizeIPv6: nodeCidrMaskSizeIPv6},
+			},
+			want: clusterapi.ExtraArgs{
+				"node-cidr-mask-size-ipv4": "28",
+				"node-cidr-mask-size-ipv6": "120",
+			},
+		},
+		{
+			testName: "dual-stack with only ipv6 nodes config",
+			clusterNetwork: &v1alpha1.ClusterNetwork{
+				Pods:  v1alpha1.Pods{CidrBlocks: []string{"fd00:10:244::/56", "192.168.0.0/16"}},
+				Nodes: &v1alpha1.Nodes{CIDRMaskSizeIPv6: nodeCidrMaskSizeIPv6},
+			},
+			want: clusterapi.ExtraArgs{
+				"node-cidr-mask-size-ipv6": "120",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -597,6 +648,59 @@ func TestNodeCIDRMaskExtraArgs(t *testing.T) {
 	}
 }
 
+func TestIPv6ExtraArgs(t *testing.T) {
+	tests := []struct {
+		testName       string
+		clusterNetwork *v1alpha1.ClusterNetwork
+		wantBindArgs   clusterapi.ExtraArgs
+		wantNodeIPArgs clusterapi.ExtraArgs
+	}{
+		{
+			testName:       "no cluster network config",
+			clusterNetwork: nil,
+		},
+		{
+			testName: "ipv4",
+			clusterNetwork: &v1alpha1.ClusterNetwork{
+				Pods: v1alpha1.Pods{CidrBlocks: []string{"192.168.0.0/16"}},
+			},
+		},
+		{
+			testName: "ipv4 primary dual-stack",
+			clusterNetwork: &v1alpha1.ClusterNetwork{
+				Pods: v1alpha1.Pods{CidrBlocks: []string{"192.168.0.0/16", "fd00:10:244::/56"}},
+			},
+		},
+		{
+			testName: "ipv6 primary dual-stack",
+			clusterNetwork: &v1alpha1.ClusterNetwork{
+				Pods: v1alpha1.Pods{CidrBlocks: []string{"fd00:10:244::/56", "192.168.0.0/16"}},
+			},
+			wantBindArgs:   clusterapi.ExtraArgs{"bind-address": "::"},
+			wantNodeIPArgs: clusterapi.ExtraArgs{"node-ip": "::"},
+		},
+		{
+			testName: "ipv6",
+			clusterNetwork: &v1alpha1.ClusterNetwork{
+				Pods: v1alpha1.Pods{CidrBlocks: []string{"fd00:10:244::/56"}},
+			},
+			wantBindArgs:   clusterapi.ExtraArgs{"bind-address": "::"},
+			wantNodeIPArgs: clusterapi.ExtraArgs{"node-ip": "::"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			if got := clusterapi.IPv6BindAddressExtraArgs(tt.clusterNetwork); !reflect.DeepEqual(got, tt.wantBindArgs) {
+				t.Errorf("IPv6BindAddressExtraArgs() = %v, want %v", got, tt.wantBindArgs)
+			}
+			if got := clusterapi.IPv6NodeIPExtraArgs(tt.clusterNetwork); !reflect.DeepEqual(got, tt.wantNodeIPArgs) {
+				t.Errorf("IPv6NodeIPExtraArgs() = %v, want %v", got, tt.wantNodeIPArgs)
+			}
+		})
+	}
+}
+
 func TestEtcdEncryptionExtraArgs(t *testing.T) {
 	tests := []struct {
 		name           string
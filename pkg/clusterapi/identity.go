@@ -5,6 +5,7 @@ import (
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/authentication"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 )
 
@@ -92,15 +93,37 @@ func configureAWSIAMAuthInKubeadmControlPlane(kcp *controlplanev1.KubeadmControl
 	kcp.Spec.KubeadmConfigSpec.Files = append(kcp.Spec.KubeadmConfigSpec.Files, awsIamFiles...)
 }
 
-func configureOIDCInKubeadmControlPlane(kcp *controlplanev1.KubeadmControlPlane, oidcConfig *v1alpha1.OIDCConfig) {
-	if oidcConfig == nil {
-		return
-	}
-
+func configureOIDCInKubeadmControlPlane(kcp *controlplanev1.KubeadmControlPlane, clusterSpec *cluster.Spec) {
 	apiServerExtraArgs := kcp.Spec.KubeadmConfigSpec.ClusterConfiguration.APIServer.ExtraArgs
-	for k, v := range OIDCToExtraArgs(oidcConfig) {
+	for k, v := range AuthenticationExtraArgs(clusterSpec) {
 		apiServerExtraArgs[k] = v
 	}
+
+	if !clusterSpec.Cluster.UsesStructuredAuthentication() {
+		return
+	}
+
+	kcp.Spec.KubeadmConfigSpec.ClusterConfiguration.APIServer.ExtraVolumes = append(
+		kcp.Spec.KubeadmConfigSpec.ClusterConfiguration.APIServer.ExtraVolumes,
+		bootstrapv1.HostPathMount{
+			Name:      "authentication-config",
+			HostPath:  authentication.HostDir,
+			MountPath: authentication.MountDir,
+			ReadOnly:  true,
+		},
+	)
+
+	kcp.Spec.KubeadmConfigSpec.Files = append(kcp.Spec.KubeadmConfigSpec.Files, bootstrapv1.File{
+		Path:        authentication.HostConfigPath(),
+		Owner:       "root:root",
+		Permissions: "0640",
+		ContentFrom: &bootstrapv1.FileSource{
+			Secret: bootstrapv1.SecretFileSource{
+				Name: authentication.ConfigSecretName(clusterSpec.Cluster.Name),
+				Key:  authentication.ConfigFileName,
+			},
+		},
+	})
 }
 
 func configureAPIServerExtraArgsInKubeadmControlPlane(kcp *controlplanev1.KubeadmControlPlane, apiServerExtraArgs map[string]string) {
@@ -123,7 +146,7 @@ func configurePodIamAuthInKubeadmControlPlane(kcp *controlplanev1.KubeadmControl
 }
 
 func SetIdentityAuthInKubeadmControlPlane(kcp *controlplanev1.KubeadmControlPlane, clusterSpec *cluster.Spec) {
-	configureOIDCInKubeadmControlPlane(kcp, clusterSpec.OIDCConfig)
+	configureOIDCInKubeadmControlPlane(kcp, clusterSpec)
 	configureAWSIAMAuthInKubeadmControlPlane(kcp, clusterSpec.AWSIamConfig)
 	configureAPIServerExtraArgsInKubeadmControlPlane(kcp, clusterSpec.Cluster.Spec.ControlPlaneConfiguration.APIServerExtraArgs)
 	configurePodIamAuthInKubeadmControlPlane(kcp, clusterSpec.Cluster.Spec.PodIAMConfig)
@@ -494,6 +494,48 @@ func TestConfigureOIDCInKubeadmControlPlane(t *testing.T) {
 	}
 }
 
+func TestConfigureStructuredAuthenticationInKubeadmControlPlane(t *testing.T) {
+	g := newApiBuilerTest(t)
+	g.clusterSpec.Cluster.Spec.KubernetesVersion = v1alpha1.Kube130
+	g.clusterSpec.Cluster.Spec.IdentityProviderRefs = []v1alpha1.Ref{
+		{Kind: v1alpha1.OIDCConfigKind, Name: "eksa-unit-test"},
+	}
+	g.clusterSpec.OIDCConfig = &v1alpha1.OIDCConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "eksa-unit-test",
+		},
+		Spec: v1alpha1.OIDCConfigSpec{
+			ClientId:  "id1",
+			IssuerUrl: "https://mydomain.com/issuer",
+		},
+	}
+	got := wantKubeadmControlPlane()
+
+	clusterapi.SetIdentityAuthInKubeadmControlPlane(got, g.clusterSpec)
+
+	apiServer := got.Spec.KubeadmConfigSpec.ClusterConfiguration.APIServer
+	g.Expect(apiServer.ExtraArgs).To(Equal(map[string]string{
+		"authentication-config": "/etc/kubernetes/authentication/authentication-config.yaml",
+	}))
+	g.Expect(apiServer.ExtraVolumes).To(ConsistOf(bootstrapv1.HostPathMount{
+		Name:      "authentication-config",
+		HostPath:  "/var/lib/kubeadm/authentication",
+		MountPath: "/etc/kubernetes/authentication",
+		ReadOnly:  true,
+	}))
+	g.Expect(got.Spec.KubeadmConfigSpec.Files).To(ConsistOf(bootstrapv1.File{
+		Path:        "/var/lib/kubeadm/authentication/authentication-config.yaml",
+		Owner:       "root:root",
+		Permissions: "0640",
+		ContentFrom: &bootstrapv1.FileSource{
+			Secret: bootstrapv1.SecretFileSource{
+				Name: "test-cluster-authentication-config",
+				Key:  "authentication-config.yaml",
+			},
+		},
+	}))
+}
+
 func TestConfigurePodIamAuthInKubeadmControlPlane(t *testing.T) {
 	replicas := int32(3)
 	tests := []struct {
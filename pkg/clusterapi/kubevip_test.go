@@ -25,3 +25,20 @@ func TestSetKubeVipInKubeadmControlPlane(t *testing.T) {
 	g.Expect(clusterapi.SetKubeVipInKubeadmControlPlane(got, g.clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host, "public.ecr.aws/l0g8r8j6/kube-vip/kube-vip:v0.3.7-eks-a-v0.0.0-dev-build.1433")).To(Succeed())
 	g.Expect(got).To(Equal(want))
 }
+
+func TestKubeVipCIDR(t *testing.T) {
+	tests := []struct {
+		address string
+		want    string
+	}{
+		{address: "1.2.3.4", want: "32"},
+		{address: "fd00::10", want: "128"},
+		{address: "not-an-ip", want: "32"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(clusterapi.KubeVipCIDR(tt.address)).To(Equal(tt.want))
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/nodeconfig"
 )
 
 // Workers represents the provider specific CAPI spec for an eks-a cluster's workers.
@@ -67,7 +68,9 @@ func (g *WorkerGroup[M]) Objects() []kubernetes.Object {
 // with the current state of the cluster. If they had, it generates a new name for them by increasing a monotonic number
 // at the end of the name.
 // This process is performed to the provider machine template and the kubeadmconfigtemplate.
-// The kubeadmconfigtemplate is not immutable at the API level but we treat it as such for consistency.
+// The kubeadmconfigtemplate is not immutable at the API level but we treat it as such for consistency,
+// except for the changes that are applied in place to the running nodes (see nodeconfig.Config):
+// those update the existing kubeadmconfigtemplate so the machines are not rolled out.
 func (g *WorkerGroup[M]) UpdateImmutableObjectNames(
 	ctx context.Context,
 	client kubernetes.Client,
@@ -91,7 +94,7 @@ func (g *WorkerGroup[M]) UpdateImmutableObjectNames(
 	g.MachineDeployment.Spec.Template.Spec.InfrastructureRef.Name = g.ProviderMachineTemplate.GetName()
 
 	g.KubeadmConfigTemplate.SetName(currentMachineDeployment.Spec.Template.Spec.Bootstrap.ConfigRef.Name)
-	if err = EnsureNewNameIfChanged(ctx, client, GetKubeadmConfigTemplate, KubeadmConfigTemplateEqual, g.KubeadmConfigTemplate); err != nil {
+	if err = EnsureNewNameIfChanged(ctx, client, GetKubeadmConfigTemplate, KubeadmConfigTemplateInPlaceEqual, g.KubeadmConfigTemplate); err != nil {
 		return err
 	}
 	g.MachineDeployment.Spec.Template.Spec.Bootstrap.ConfigRef.Name = g.KubeadmConfigTemplate.Name
@@ -132,6 +135,17 @@ func KubeadmConfigTemplateEqual(new, old *kubeadmv1.KubeadmConfigTemplate) bool
 		equality.Semantic.DeepDerivative(new.Spec, old.Spec)
 }
 
+// KubeadmConfigTemplateInPlaceEqual returns true if the new version of a KubeadmConfigTemplate only
+// differs from the old one in fields that can be updated in place on the running nodes.
+// Implements ObjectComparator.
+func KubeadmConfigTemplateInPlaceEqual(new, old *kubeadmv1.KubeadmConfigTemplate) bool {
+	n, o := new.DeepCopy(), old.DeepCopy()
+	n.Spec.Template.Spec = *nodeconfig.WithoutInPlaceFields(&new.Spec.Template.Spec)
+	o.Spec.Template.Spec = *nodeconfig.WithoutInPlaceFields(&old.Spec.Template.Spec)
+
+	return KubeadmConfigTemplateEqual(n, o)
+}
+
 func kubeadmConfigTemplateTaintsEqual(new, old *kubeadmv1.KubeadmConfigTemplate) bool {
 	return new.Spec.Template.Spec.JoinConfiguration == nil ||
 		old.Spec.Template.Spec.JoinConfiguration == nil ||
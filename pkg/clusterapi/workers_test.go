@@ -171,6 +171,54 @@ func TestWorkerGroupUpdateImmutableObjectNamesSuccess(t *testing.T) {
 	g.Expect(group.MachineDeployment.Spec.Template.Spec.InfrastructureRef.Name).To(Equal(group.ProviderMachineTemplate.Name))
 }
 
+func TestWorkerGroupUpdateImmutableObjectNamesInPlaceChanges(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	group := &dockerGroup{
+		MachineDeployment:       machineDeployment(),
+		ProviderMachineTemplate: dockerMachineTemplate(),
+		KubeadmConfigTemplate:   kubeadmConfigTemplate(),
+	}
+	group.MachineDeployment.Spec.Template.Spec.InfrastructureRef = *objectReference(group.ProviderMachineTemplate)
+	group.KubeadmConfigTemplate.Spec.Template.Spec.JoinConfiguration = &kubeadmv1.JoinConfiguration{
+		NodeRegistration: kubeadmv1.NodeRegistrationOptions{
+			KubeletExtraArgs: map[string]string{"node-labels": "group=md-0"},
+		},
+	}
+	group.MachineDeployment.Spec.Template.Spec.Bootstrap.ConfigRef = objectReference(group.KubeadmConfigTemplate)
+	client := test.NewFakeKubeClient(group.MachineDeployment, group.KubeadmConfigTemplate.DeepCopy(), group.ProviderMachineTemplate)
+	joinConfig := group.KubeadmConfigTemplate.Spec.Template.Spec.JoinConfiguration
+	joinConfig.NodeRegistration.KubeletExtraArgs["node-labels"] = "group=md-1"
+	joinConfig.NodeRegistration.Taints = []corev1.Taint{{Key: "key", Effect: corev1.TaintEffectNoSchedule}}
+	group.KubeadmConfigTemplate.Spec.Template.Spec.NTP = &kubeadmv1.NTP{Servers: []string{"time.example.com"}}
+
+	g.Expect(
+		group.UpdateImmutableObjectNames(ctx, client, dummyRetriever, noChangesCompare),
+	).To(Succeed())
+	g.Expect(group.KubeadmConfigTemplate.Name).To(Equal("template-1"))
+	g.Expect(group.MachineDeployment.Spec.Template.Spec.Bootstrap.ConfigRef.Name).To(Equal(group.KubeadmConfigTemplate.Name))
+}
+
+func TestKubeadmConfigTemplateInPlaceEqual(t *testing.T) {
+	g := NewWithT(t)
+	old := kubeadmConfigTemplate()
+	old.Spec.Template.Spec.JoinConfiguration = &kubeadmv1.JoinConfiguration{
+		NodeRegistration: kubeadmv1.NodeRegistrationOptions{
+			KubeletExtraArgs: map[string]string{"node-labels": "group=md-0", "provider-id": "id"},
+		},
+	}
+
+	inPlace := old.DeepCopy()
+	inPlace.Spec.Template.Spec.JoinConfiguration.NodeRegistration.KubeletExtraArgs["node-labels"] = "group=md-1"
+	inPlace.Spec.Template.Spec.JoinConfiguration.NodeRegistration.Taints = []corev1.Taint{{Key: "key"}}
+	inPlace.Spec.Template.Spec.JoinConfiguration.CertBundles = []kubeadmv1.CertBundle{{Name: "ca", Data: "cert"}}
+	g.Expect(clusterapi.KubeadmConfigTemplateInPlaceEqual(inPlace, old)).To(BeTrue())
+
+	replacement := old.DeepCopy()
+	replacement.Spec.Template.Spec.JoinConfiguration.NodeRegistration.KubeletExtraArgs["provider-id"] = "other-id"
+	g.Expect(clusterapi.KubeadmConfigTemplateInPlaceEqual(replacement, old)).To(BeFalse())
+}
+
 func TestGetKubeadmConfigTemplateSuccess(t *testing.T) {
 	g := NewWithT(t)
 	ctx := context.Background()
@@ -159,8 +159,8 @@ func (a Applier) Run(ctx context.Context, spec *cluster.Spec, managementCluster
 		return errors.Wrapf(err, "waiting for cluster's control plane to be ready")
 	}
 
-	if spec.Cluster.Spec.ClusterNetwork.CNIConfig.IsManaged() {
-		a.log.V(3).Info("Waiting for default CNI to be updated")
+	if cniConfig := spec.Cluster.Spec.ClusterNetwork.CNIConfig; cniConfig.IsManaged() || cniConfig.IsCustom() {
+		a.log.V(3).Info("Waiting for CNI to be updated")
 		retry = a.retrierForWait(waitStartTime)
 		if err := cluster.WaitForCondition(ctx, a.log, client, spec.Cluster, a.conditionCheckoutTotalCount, retry, anywherev1.DefaultCNIConfiguredCondition); err != nil {
 			return errors.Wrapf(err, "waiting for cluster's CNI to be configured")
@@ -242,11 +242,6 @@ func setManagerEnvVars(d *appsv1.Deployment, spec *cluster.Spec) {
 		}
 	}
 
-	// TODO: remove this feature flag if we decide to support in-place upgrades for vSphere provider.
-	if features.IsActive(features.VSphereInPlaceUpgradeEnabled()) {
-		envVars = append(envVars, v1.EnvVar{Name: features.VSphereInPlaceEnvVar, Value: "true"})
-	}
-
 	// TODO: remove this feature flag when we support API server flags.
 	if features.IsActive(features.APIServerExtraArgsEnabled()) {
 		envVars = append(envVars, v1.EnvVar{Name: features.APIServerExtraArgsEnabledEnvVar, Value: "true"})
@@ -344,10 +344,10 @@ func TestSetManagerFlags(t *testing.T) {
 
 func TestSetManagerEnvVars(t *testing.T) {
 	tests := []struct {
-		name       string
-		deployment *appsv1.Deployment
-		spec       *cluster.Spec
-		want       *appsv1.Deployment
+		name                        string
+		deployment                  *appsv1.Deployment
+		spec                        *cluster.Spec
+		want                        *appsv1.Deployment
 		vsphereFailurDomainsEnabled bool
 	}{
 		{
@@ -418,26 +418,6 @@ func TestSetManagerEnvVars(t *testing.T) {
 	}
 }
 
-func TestSetManagerEnvVarsVSphereInPlaceUpgrade(t *testing.T) {
-	g := NewWithT(t)
-	features.ClearCache()
-	t.Setenv(features.VSphereInPlaceEnvVar, "true")
-
-	deploy := deployment()
-	spec := test.NewClusterSpec()
-	want := deployment(func(d *appsv1.Deployment) {
-		d.Spec.Template.Spec.Containers[0].Env = []corev1.EnvVar{
-			{
-				Name:  "VSPHERE_IN_PLACE_UPGRADE",
-				Value: "true",
-			},
-		}
-	})
-
-	clustermanager.SetManagerEnvVars(deploy, spec)
-	g.Expect(deploy).To(Equal(want))
-}
-
 func TestSetManagerEnvVarsAPIServerExtraArgs(t *testing.T) {
 	g := NewWithT(t)
 	features.ClearCache()
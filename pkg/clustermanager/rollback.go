@@ -0,0 +1,233 @@
+package clustermanager
+
+import (
+	"context"
+	"fmt"
+
+	etcdv1 "github.com/aws/etcdadm-controller/api/v1beta1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/version"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+// ValidateRollback checks that a cluster can be rolled back from its current spec to a snapshot
+// taken before an upgrade. EKS Anywhere version changes can't be reverted. Kubernetes minor version
+// and etcd version changes can't be reverted once the whole control plane runs them: kubeadm doesn't
+// support downgrades and etcd bumps the version of its data once all members are upgraded.
+// External etcd is always upgraded before the control plane, so etcd version changes can't be
+// reverted for clusters using it.
+func ValidateRollback(ctx context.Context, client kubernetes.Reader, snapshot *cluster.Snapshot, current *cluster.Spec) error {
+	snapshotCluster := snapshot.Config.Cluster
+	if snapshotCluster.Name != current.Cluster.Name || snapshotCluster.Namespace != current.Cluster.Namespace {
+		return fmt.Errorf("snapshot is for cluster %s/%s, not %s/%s",
+			snapshotCluster.Namespace, snapshotCluster.Name, current.Cluster.Namespace, current.Cluster.Name)
+	}
+
+	if !equality.Semantic.DeepEqual(snapshotCluster.Spec.EksaVersion, current.Cluster.Spec.EksaVersion) ||
+		!equality.Semantic.DeepEqual(snapshotCluster.Spec.BundlesRef, current.Cluster.Spec.BundlesRef) {
+		return fmt.Errorf("EKS Anywhere version was upgraded from %s to %s, EKS Anywhere versions can't be downgraded",
+			eksaVersion(snapshotCluster), eksaVersion(current.Cluster))
+	}
+
+	currentVersions := cluster.NewSnapshot(current).Versions
+	etcdChanged := snapshot.Versions.EtcdVersion != currentVersions.EtcdVersion
+	minorChanged, err := kubernetesMinorChanged(snapshot.Versions.KubernetesVersion, currentVersions.KubernetesVersion)
+	if err != nil {
+		return err
+	}
+
+	if !etcdChanged && !minorChanged {
+		return nil
+	}
+
+	if etcdChanged && current.Cluster.Spec.ExternalEtcdConfiguration != nil {
+		return fmt.Errorf("etcd version was upgraded from %s to %s and external etcd is upgraded before the control plane, etcd can't be downgraded",
+			snapshot.Versions.EtcdVersion, currentVersions.EtcdVersion)
+	}
+
+	machines := &clusterv1.MachineList{}
+	if err := client.List(ctx, machines, kubernetes.ListOptions{Namespace: constants.EksaSystemNamespace}); err != nil {
+		return fmt.Errorf("listing control plane machines: %v", err)
+	}
+
+	upgraded := 0
+	for _, m := range machines.Items {
+		if m.Labels[clusterv1.ClusterNameLabel] != current.Cluster.Name {
+			continue
+		}
+		if _, ok := m.Labels[clusterv1.MachineControlPlaneLabel]; !ok {
+			continue
+		}
+		if m.Spec.Version == nil || *m.Spec.Version == snapshot.Versions.KubernetesVersion {
+			return nil
+		}
+		upgraded++
+	}
+
+	if upgraded == 0 {
+		return nil
+	}
+
+	if etcdChanged {
+		return fmt.Errorf("all control plane machines were upgraded to %s, which upgraded etcd from %s to %s, etcd can't be downgraded",
+			currentVersions.KubernetesVersion, snapshot.Versions.EtcdVersion, currentVersions.EtcdVersion)
+	}
+
+	return fmt.Errorf("all control plane machines were upgraded from %s to %s, kubernetes minor versions can't be downgraded",
+		snapshot.Versions.KubernetesVersion, currentVersions.KubernetesVersion)
+}
+
+// RestoreSnapshot applies the EKS-A objects in a snapshot taken before an upgrade and points the CAPI objects
+// back to the machine templates and KubeadmConfigTemplates they used, re-creating the ones that don't exist anymore.
+// When the controller reconciles the previous spec it finds those templates in use and keeps them, so only the
+// machines the upgrade replaced are rolled out again. The cluster reconciliation is paused until both the EKS-A and
+// CAPI objects are restored, so the controller doesn't point the CAPI objects to the upgraded templates in between.
+// If restoring fails the cluster is left paused and the rollback can be run again.
+func RestoreSnapshot(ctx context.Context, client kubernetes.Client, snapshot *cluster.Snapshot) error {
+	snapshotCluster := snapshot.Config.Cluster
+	current := &anywherev1.Cluster{}
+	if err := client.Get(ctx, snapshotCluster.Name, snapshotCluster.Namespace, current); err != nil {
+		return fmt.Errorf("reading cluster %s: %v", snapshotCluster.Name, err)
+	}
+
+	current.PauseReconcile()
+	if err := client.Update(ctx, current); err != nil {
+		return fmt.Errorf("pausing cluster reconciliation: %v", err)
+	}
+
+	if err := restoreSnapshotCAPI(ctx, client, snapshot.CAPI); err != nil {
+		return err
+	}
+
+	for _, obj := range snapshot.Config.ClusterAndChildren() {
+		obj = obj.DeepCopyObject().(kubernetes.Object)
+		if c, ok := obj.(*anywherev1.Cluster); ok {
+			c.PauseReconcile()
+		}
+		if err := client.ApplyServerSide(ctx, defaultFieldManager, obj, kubernetes.ApplyServerSideOptions{ForceOwnership: true}); err != nil {
+			return fmt.Errorf("applying snapshot %s %s: %v", obj.GetObjectKind().GroupVersionKind().Kind, obj.GetName(), err)
+		}
+	}
+
+	if err := client.Get(ctx, snapshotCluster.Name, snapshotCluster.Namespace, current); err != nil {
+		return fmt.Errorf("reading cluster %s: %v", snapshotCluster.Name, err)
+	}
+
+	current.ClearPauseAnnotation()
+	if err := client.Update(ctx, current); err != nil {
+		return fmt.Errorf("resuming cluster reconciliation: %v", err)
+	}
+
+	return nil
+}
+
+func restoreSnapshotCAPI(ctx context.Context, client kubernetes.Client, snapshot *cluster.SnapshotCAPI) error {
+	for _, t := range snapshot.MachineTemplates {
+		if err := createIfMissing(ctx, client, t.DeepCopy(), &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": t.GetAPIVersion(),
+			"kind":       t.GetKind(),
+		}}); err != nil {
+			return err
+		}
+	}
+
+	for _, t := range snapshot.KubeadmConfigTemplates {
+		if err := createIfMissing(ctx, client, t.DeepCopy(), &bootstrapv1.KubeadmConfigTemplate{}); err != nil {
+			return err
+		}
+	}
+
+	kcp := &controlplanev1.KubeadmControlPlane{}
+	if err := client.Get(ctx, snapshot.KubeadmControlPlane.Name, snapshot.KubeadmControlPlane.Namespace, kcp); err != nil {
+		return fmt.Errorf("reading kubeadm control plane: %v", err)
+	}
+	kcp.Spec.Version = snapshot.KubeadmControlPlane.Spec.Version
+	kcp.Spec.MachineTemplate = snapshot.KubeadmControlPlane.Spec.MachineTemplate
+	kcp.Spec.KubeadmConfigSpec = snapshot.KubeadmControlPlane.Spec.KubeadmConfigSpec
+	if err := client.Update(ctx, kcp); err != nil {
+		return fmt.Errorf("restoring kubeadm control plane templates: %v", err)
+	}
+
+	if snapshot.EtcdadmCluster != nil {
+		etcdadmCluster := &etcdv1.EtcdadmCluster{}
+		if err := client.Get(ctx, snapshot.EtcdadmCluster.Name, snapshot.EtcdadmCluster.Namespace, etcdadmCluster); err != nil {
+			return fmt.Errorf("reading etcdadm cluster: %v", err)
+		}
+		etcdadmCluster.Spec.InfrastructureTemplate = snapshot.EtcdadmCluster.Spec.InfrastructureTemplate
+		etcdadmCluster.Spec.EtcdadmConfigSpec = snapshot.EtcdadmCluster.Spec.EtcdadmConfigSpec
+		if err := client.Update(ctx, etcdadmCluster); err != nil {
+			return fmt.Errorf("restoring etcdadm cluster template: %v", err)
+		}
+	}
+
+	for _, snapshotMD := range snapshot.MachineDeployments {
+		md := &clusterv1.MachineDeployment{}
+		err := client.Get(ctx, snapshotMD.Name, snapshotMD.Namespace, md)
+		if apierrors.IsNotFound(err) {
+			// The upgrade removed the worker node group, the controller will create it again from the snapshot spec.
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("reading machine deployment %s: %v", snapshotMD.Name, err)
+		}
+
+		md.Spec.Template.Spec = snapshotMD.Spec.Template.Spec
+		if err := client.Update(ctx, md); err != nil {
+			return fmt.Errorf("restoring machine deployment %s templates: %v", md.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func createIfMissing(ctx context.Context, client kubernetes.Client, obj, current kubernetes.Object) error {
+	err := client.Get(ctx, obj.GetName(), obj.GetNamespace(), current)
+	if err == nil {
+		return nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return fmt.Errorf("reading %s %s: %v", obj.GetObjectKind().GroupVersionKind().Kind, obj.GetName(), err)
+	}
+
+	obj.SetResourceVersion("")
+	if err := client.Create(ctx, obj); err != nil {
+		return fmt.Errorf("restoring %s %s: %v", obj.GetObjectKind().GroupVersionKind().Kind, obj.GetName(), err)
+	}
+
+	return nil
+}
+
+func kubernetesMinorChanged(previous, current string) (bool, error) {
+	p, err := version.ParseGeneric(previous)
+	if err != nil {
+		return false, fmt.Errorf("parsing kubernetes version %s: %v", previous, err)
+	}
+
+	c, err := version.ParseGeneric(current)
+	if err != nil {
+		return false, fmt.Errorf("parsing kubernetes version %s: %v", current, err)
+	}
+
+	return p.Major() != c.Major() || p.Minor() != c.Minor(), nil
+}
+
+func eksaVersion(c *anywherev1.Cluster) string {
+	if c.Spec.EksaVersion != nil {
+		return string(*c.Spec.EksaVersion)
+	}
+
+	if c.Spec.BundlesRef != nil {
+		return fmt.Sprintf("bundles %s/%s", c.Spec.BundlesRef.Namespace, c.Spec.BundlesRef.Name)
+	}
+
+	return "unknown"
+}
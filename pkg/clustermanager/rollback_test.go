@@ -0,0 +1,317 @@
+package clustermanager_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	dockerv1 "sigs.k8s.io/cluster-api/test/infrastructure/docker/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clustermanager"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+)
+
+type rollbackTest struct {
+	*WithT
+	ctx      context.Context
+	snapshot *cluster.Snapshot
+	current  *cluster.Spec
+}
+
+func newRollbackTest(t *testing.T) *rollbackTest {
+	previous := rollbackClusterSpec("v1.28.3-eks-1-28-9", "3.5.9")
+	return &rollbackTest{
+		WithT:    NewWithT(t),
+		ctx:      context.Background(),
+		snapshot: cluster.NewSnapshot(previous),
+		current:  rollbackClusterSpec("v1.29.0-eks-1-29-1", "3.5.10"),
+	}
+}
+
+func rollbackClusterSpec(kubeVersion, etcdVersion string) *cluster.Spec {
+	return test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = "my-cluster"
+		s.Cluster.Namespace = "default"
+		s.VersionsBundles[anywherev1.Kube119].KubeDistro.Kubernetes.Tag = kubeVersion
+		s.VersionsBundles[anywherev1.Kube119].KubeDistro.EtcdVersion = etcdVersion
+	})
+}
+
+func controlPlaneMachine(name, version string) *clusterv1.Machine {
+	return &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+			Labels: map[string]string{
+				clusterv1.ClusterNameLabel:         "my-cluster",
+				clusterv1.MachineControlPlaneLabel: "",
+			},
+		},
+		Spec: clusterv1.MachineSpec{
+			ClusterName: "my-cluster",
+			Version:     ptr.String(version),
+		},
+	}
+}
+
+func (tt *rollbackTest) validate(objs ...client.Object) error {
+	return clustermanager.ValidateRollback(tt.ctx, test.NewFakeKubeClient(objs...), tt.snapshot, tt.current)
+}
+
+func TestValidateRollbackNoVersionChanges(t *testing.T) {
+	tt := newRollbackTest(t)
+	tt.current = rollbackClusterSpec("v1.28.3-eks-1-28-9", "3.5.9")
+
+	tt.Expect(tt.validate(controlPlaneMachine("cp-1", "v1.28.3-eks-1-28-9"))).To(Succeed())
+}
+
+func TestValidateRollbackPatchVersionChange(t *testing.T) {
+	tt := newRollbackTest(t)
+	tt.current = rollbackClusterSpec("v1.28.5-eks-1-28-12", "3.5.9")
+
+	tt.Expect(tt.validate(controlPlaneMachine("cp-1", "v1.28.5-eks-1-28-12"))).To(Succeed())
+}
+
+func TestValidateRollbackControlPlanePartiallyUpgraded(t *testing.T) {
+	tt := newRollbackTest(t)
+
+	tt.Expect(tt.validate(
+		controlPlaneMachine("cp-1", "v1.29.0-eks-1-29-1"),
+		controlPlaneMachine("cp-2", "v1.28.3-eks-1-28-9"),
+	)).To(Succeed())
+}
+
+func TestValidateRollbackControlPlaneNotUpgraded(t *testing.T) {
+	tt := newRollbackTest(t)
+
+	tt.Expect(tt.validate()).To(Succeed())
+}
+
+func TestValidateRollbackControlPlaneUpgradedEtcdChanged(t *testing.T) {
+	tt := newRollbackTest(t)
+
+	tt.Expect(tt.validate(
+		controlPlaneMachine("cp-1", "v1.29.0-eks-1-29-1"),
+		controlPlaneMachine("cp-2", "v1.29.0-eks-1-29-1"),
+	)).To(MatchError(ContainSubstring("which upgraded etcd from 3.5.9 to 3.5.10, etcd can't be downgraded")))
+}
+
+func TestValidateRollbackControlPlaneUpgradedMinorChanged(t *testing.T) {
+	tt := newRollbackTest(t)
+	tt.current = rollbackClusterSpec("v1.29.0-eks-1-29-1", "3.5.9")
+
+	tt.Expect(tt.validate(controlPlaneMachine("cp-1", "v1.29.0-eks-1-29-1"))).To(
+		MatchError(ContainSubstring("kubernetes minor versions can't be downgraded")),
+	)
+}
+
+func TestValidateRollbackIgnoresOtherClusters(t *testing.T) {
+	tt := newRollbackTest(t)
+	other := controlPlaneMachine("other-cp-1", "v1.29.0-eks-1-29-1")
+	other.Labels[clusterv1.ClusterNameLabel] = "other-cluster"
+
+	tt.Expect(tt.validate(other)).To(Succeed())
+}
+
+func TestValidateRollbackExternalEtcdChanged(t *testing.T) {
+	tt := newRollbackTest(t)
+	tt.current.Cluster.Spec.ExternalEtcdConfiguration = &anywherev1.ExternalEtcdConfiguration{Count: 3}
+
+	tt.Expect(tt.validate()).To(MatchError(ContainSubstring("external etcd is upgraded before the control plane")))
+}
+
+func TestValidateRollbackEksaVersionChanged(t *testing.T) {
+	tt := newRollbackTest(t)
+	v := anywherev1.EksaVersion("v0.20.0")
+	tt.current.Cluster.Spec.EksaVersion = &v
+
+	tt.Expect(tt.validate()).To(MatchError(ContainSubstring("EKS Anywhere version was upgraded from v0.19.0-dev+latest to v0.20.0")))
+}
+
+func TestValidateRollbackDifferentCluster(t *testing.T) {
+	tt := newRollbackTest(t)
+	tt.current.Cluster.Name = "other-cluster"
+
+	tt.Expect(tt.validate()).To(MatchError(ContainSubstring("snapshot is for cluster default/my-cluster, not default/other-cluster")))
+}
+
+type restoreSnapshotTest struct {
+	*WithT
+	ctx      context.Context
+	client   kubernetes.Client
+	snapshot *cluster.Snapshot
+}
+
+func dockerMachineTemplate(name string) *dockerv1.DockerMachineTemplate {
+	return &dockerv1.DockerMachineTemplate{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "DockerMachineTemplate",
+			APIVersion: dockerv1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+		},
+	}
+}
+
+func kubeadmConfigTemplate(name string) *bootstrapv1.KubeadmConfigTemplate {
+	return &bootstrapv1.KubeadmConfigTemplate{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "KubeadmConfigTemplate",
+			APIVersion: bootstrapv1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+		},
+	}
+}
+
+func snapshotKCP(template, version string) *controlplanev1.KubeadmControlPlane {
+	return test.KubeadmControlPlane(func(kcp *controlplanev1.KubeadmControlPlane) {
+		kcp.Name = "my-cluster"
+		kcp.Spec.Version = version
+		kcp.Spec.MachineTemplate.InfrastructureRef = corev1.ObjectReference{
+			Kind:       "DockerMachineTemplate",
+			APIVersion: dockerv1.GroupVersion.String(),
+			Name:       template,
+		}
+	})
+}
+
+func snapshotMachineDeployment(template, version string) *clusterv1.MachineDeployment {
+	return test.MachineDeployment(func(md *clusterv1.MachineDeployment) {
+		md.Name = "my-cluster-md-0"
+		md.Spec.ClusterName = "my-cluster"
+		md.Spec.Template.Spec.Version = ptr.String(version)
+		md.Spec.Template.Spec.InfrastructureRef = corev1.ObjectReference{
+			Kind:       "DockerMachineTemplate",
+			APIVersion: dockerv1.GroupVersion.String(),
+			Name:       template,
+		}
+		md.Spec.Template.Spec.Bootstrap.ConfigRef = &corev1.ObjectReference{
+			Kind:       "KubeadmConfigTemplate",
+			APIVersion: bootstrapv1.GroupVersion.String(),
+			Name:       template,
+		}
+	})
+}
+
+func toUnstructured(t *testing.T, obj runtime.Object) *unstructured.Unstructured {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &unstructured.Unstructured{Object: u}
+}
+
+// newRestoreSnapshotTest builds a cluster half way through an upgrade from v1.28 to v1.29,
+// with the upgraded templates in use and the worker templates from before the upgrade deleted.
+func newRestoreSnapshotTest(t *testing.T, objs ...client.Object) *restoreSnapshotTest {
+	previous := rollbackClusterSpec("v1.28.3-eks-1-28-9", "3.5.9")
+	previous.Cluster.Spec.ControlPlaneConfiguration.Count = 1
+	snapshot := cluster.NewSnapshot(previous)
+	snapshot.CAPI = &cluster.SnapshotCAPI{
+		KubeadmControlPlane:    snapshotKCP("my-cluster-control-plane-1", "v1.28.3-eks-1-28-9"),
+		MachineDeployments:     []*clusterv1.MachineDeployment{snapshotMachineDeployment("my-cluster-md-0-1", "v1.28.3-eks-1-28-9")},
+		KubeadmConfigTemplates: []*bootstrapv1.KubeadmConfigTemplate{kubeadmConfigTemplate("my-cluster-md-0-1")},
+		MachineTemplates: []*unstructured.Unstructured{
+			toUnstructured(t, dockerMachineTemplate("my-cluster-control-plane-1")),
+			toUnstructured(t, dockerMachineTemplate("my-cluster-md-0-1")),
+		},
+	}
+
+	current := previous.Cluster.DeepCopy()
+	current.Spec.ControlPlaneConfiguration.Count = 3
+
+	if objs == nil {
+		objs = []client.Object{
+			current,
+			snapshotKCP("my-cluster-control-plane-2", "v1.29.0-eks-1-29-1"),
+			snapshotMachineDeployment("my-cluster-md-0-2", "v1.29.0-eks-1-29-1"),
+			dockerMachineTemplate("my-cluster-control-plane-1"),
+			dockerMachineTemplate("my-cluster-control-plane-2"),
+			dockerMachineTemplate("my-cluster-md-0-2"),
+			kubeadmConfigTemplate("my-cluster-md-0-2"),
+		}
+	}
+
+	return &restoreSnapshotTest{
+		WithT:    NewWithT(t),
+		ctx:      context.Background(),
+		client:   test.NewFakeKubeClient(objs...),
+		snapshot: snapshot,
+	}
+}
+
+func TestRestoreSnapshot(t *testing.T) {
+	tt := newRestoreSnapshotTest(t)
+
+	tt.Expect(clustermanager.RestoreSnapshot(tt.ctx, tt.client, tt.snapshot)).To(Succeed())
+
+	kcp := &controlplanev1.KubeadmControlPlane{}
+	tt.Expect(tt.client.Get(tt.ctx, "my-cluster", constants.EksaSystemNamespace, kcp)).To(Succeed())
+	tt.Expect(kcp.Spec.Version).To(Equal("v1.28.3-eks-1-28-9"))
+	tt.Expect(kcp.Spec.MachineTemplate.InfrastructureRef.Name).To(Equal("my-cluster-control-plane-1"))
+
+	md := &clusterv1.MachineDeployment{}
+	tt.Expect(tt.client.Get(tt.ctx, "my-cluster-md-0", constants.EksaSystemNamespace, md)).To(Succeed())
+	tt.Expect(md.Spec.Template.Spec.Version).To(HaveValue(Equal("v1.28.3-eks-1-28-9")))
+	tt.Expect(md.Spec.Template.Spec.InfrastructureRef.Name).To(Equal("my-cluster-md-0-1"))
+	tt.Expect(md.Spec.Template.Spec.Bootstrap.ConfigRef.Name).To(Equal("my-cluster-md-0-1"))
+
+	tt.Expect(tt.client.Get(tt.ctx, "my-cluster-md-0-1", constants.EksaSystemNamespace, &dockerv1.DockerMachineTemplate{})).To(Succeed())
+	tt.Expect(tt.client.Get(tt.ctx, "my-cluster-md-0-1", constants.EksaSystemNamespace, &bootstrapv1.KubeadmConfigTemplate{})).To(Succeed())
+
+	c := &anywherev1.Cluster{}
+	tt.Expect(tt.client.Get(tt.ctx, "my-cluster", "default", c)).To(Succeed())
+	tt.Expect(c.Spec.ControlPlaneConfiguration.Count).To(Equal(1))
+	tt.Expect(c.IsReconcilePaused()).To(BeFalse())
+}
+
+func TestRestoreSnapshotMachineDeploymentRemoved(t *testing.T) {
+	previous := rollbackClusterSpec("v1.28.3-eks-1-28-9", "3.5.9")
+	tt := newRestoreSnapshotTest(t,
+		previous.Cluster,
+		snapshotKCP("my-cluster-control-plane-1", "v1.28.3-eks-1-28-9"),
+	)
+
+	tt.Expect(clustermanager.RestoreSnapshot(tt.ctx, tt.client, tt.snapshot)).To(Succeed())
+
+	err := tt.client.Get(tt.ctx, "my-cluster-md-0", constants.EksaSystemNamespace, &clusterv1.MachineDeployment{})
+	tt.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	tt.Expect(tt.client.Get(tt.ctx, "my-cluster-md-0-1", constants.EksaSystemNamespace, &dockerv1.DockerMachineTemplate{})).To(Succeed())
+}
+
+func TestRestoreSnapshotMissingControlPlaneLeavesClusterPaused(t *testing.T) {
+	previous := rollbackClusterSpec("v1.28.3-eks-1-28-9", "3.5.9")
+	tt := newRestoreSnapshotTest(t, previous.Cluster)
+
+	tt.Expect(clustermanager.RestoreSnapshot(tt.ctx, tt.client, tt.snapshot)).To(MatchError(ContainSubstring("reading kubeadm control plane")))
+
+	c := &anywherev1.Cluster{}
+	tt.Expect(tt.client.Get(tt.ctx, "my-cluster", "default", c)).To(Succeed())
+	tt.Expect(c.IsReconcilePaused()).To(BeTrue())
+}
+
+func TestRestoreSnapshotMissingCluster(t *testing.T) {
+	tt := newRestoreSnapshotTest(t, snapshotKCP("my-cluster-control-plane-1", "v1.28.3-eks-1-28-9"))
+
+	tt.Expect(clustermanager.RestoreSnapshot(tt.ctx, tt.client, tt.snapshot)).To(MatchError(ContainSubstring("reading cluster my-cluster")))
+}
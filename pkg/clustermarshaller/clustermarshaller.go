@@ -34,8 +34,12 @@ func MarshalClusterSpec(clusterSpec *cluster.Spec, datacenterConfig providers.Da
 		marshallables = append(marshallables, clusterSpec.FluxConfig.ConvertConfigToConfigGenerateStruct())
 	}
 
-	if clusterSpec.OIDCConfig != nil {
-		marshallables = append(marshallables, clusterSpec.OIDCConfig.ConvertConfigToConfigGenerateStruct())
+	if clusterSpec.ArgoCDConfig != nil {
+		marshallables = append(marshallables, clusterSpec.ArgoCDConfig.ConvertConfigToConfigGenerateStruct())
+	}
+
+	for _, oidcConfig := range clusterSpec.ReferencedOIDCConfigs() {
+		marshallables = append(marshallables, oidcConfig.ConvertConfigToConfigGenerateStruct())
 	}
 	if clusterSpec.AWSIamConfig != nil {
 		marshallables = append(marshallables, clusterSpec.AWSIamConfig.ConvertConfigToConfigGenerateStruct())
@@ -17,12 +17,28 @@ const (
 	AwsSecretAccessKeyEnv     = "AWS_SECRET_ACCESS_KEY"
 	EksaAwsConfigFileEnv      = "EKSA_AWS_CONFIG_FILE"
 	EksaRegionEnv             = "EKSA_AWS_REGION"
+	// EksaGitSigningKeyEnv is the path to a GPG or SSH private key used to sign the commits to the GitOps repository.
+	EksaGitSigningKeyEnv           = "EKSA_GIT_SIGNING_KEY"
+	EksaGitSigningKeyPassphraseEnv = "EKSA_GIT_SIGNING_KEY_PASSPHRASE"
+	// EksaGitAuthorEmailEnv is the author email of the commits to the GitOps repository.
+	EksaGitAuthorEmailEnv = "EKSA_GIT_AUTHOR_EMAIL"
 )
 
 type CliConfig struct {
 	GitSshKeyPassphrase string
 	GitPrivateKeyFile   string
 	GitKnownHostsFile   string
+	// GitOpsPullRequest, when set, makes the CLI propose the changes to the GitOps repository
+	// through a pull request instead of pushing them to the configured branch.
+	GitOpsPullRequest *GitOpsPullRequestConfig
+}
+
+// GitOpsPullRequestConfig is the config for the pull request workflow of GitOps managed clusters.
+type GitOpsPullRequestConfig struct {
+	// Timeout is how long to wait for the pull request to be merged.
+	Timeout time.Duration
+	// PollInterval is how often the pull request is checked while waiting for it to be merged.
+	PollInterval time.Duration
 }
 
 // CreateClusterCLIConfig is the config we use for create cluster specific configurations.
@@ -13,7 +13,8 @@ import (
 	"github.com/aws/eks-anywhere/pkg/constants"
 )
 
-const registryAuthSecretName = "registry-credentials"
+// RegistryAuthSecretName is the name of the secret holding the registry mirror credentials.
+const RegistryAuthSecretName = "registry-credentials"
 
 func ReadCredentials() (username, password string, err error) {
 	username, ok := os.LookupEnv(constants.RegistryUsername)
@@ -33,7 +34,7 @@ func ReadCredentials() (username, password string, err error) {
 // Returns the username and password, or error.
 func ReadCredentialsFromSecret(ctx context.Context, client client.Client) (username, password string, err error) {
 	registryAuthSecret := &corev1.Secret{}
-	key := types.NamespacedName{Name: registryAuthSecretName, Namespace: constants.EksaSystemNamespace}
+	key := types.NamespacedName{Name: RegistryAuthSecretName, Namespace: constants.EksaSystemNamespace}
 	if err := client.Get(ctx, key, registryAuthSecret); err != nil {
 		return "", "", errors.Wrap(err, "fetching registry auth secret")
 	}
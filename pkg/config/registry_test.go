@@ -44,7 +44,7 @@ func TestReadCredentialsFromSecret(t *testing.T) {
 	expectedPassword := "testpass"
 	sec := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      registryAuthSecretName,
+			Name:      RegistryAuthSecretName,
 			Namespace: constants.EksaSystemNamespace,
 		},
 		Data: map[string][]byte{
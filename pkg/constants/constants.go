@@ -35,6 +35,7 @@ const (
 	DefaultHttpsPort                        = "443"
 	DefaultWorkerNodeGroupName              = "md-0"
 	DefaultNodeCidrMaskSize                 = 24
+	DefaultNodeCidrMaskSizeIPv6             = 64
 
 	VSphereProviderName    = "vsphere"
 	DockerProviderName     = "docker"
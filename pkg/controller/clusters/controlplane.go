@@ -17,6 +17,7 @@ import (
 	"sigs.k8s.io/cluster-api/util/annotations"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/controller"
 	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
 	"github.com/aws/eks-anywhere/pkg/controller/serverside"
@@ -77,6 +78,16 @@ func (cp *ControlPlane) nonEtcdObjects() []client.Object {
 // to orchestrate this operation if double kcp rollouts are undesirable.
 const skipCAPIAutoPauseKCPForExternalEtcdAnnotation = "cluster.x-k8s.io/skip-pause-cp-managed-etcd"
 
+// ReconcileControlPlaneForEKSA orchestrates the ControlPlane reconciliation logic for a particular EKS-A cluster.
+// Changes that roll the control plane or etcd machines are deferred while the cluster maintenance window is closed.
+func ReconcileControlPlaneForEKSA(ctx context.Context, log logr.Logger, c client.Client, cluster *anywherev1.Cluster, cp *ControlPlane) (controller.Result, error) {
+	if err := ApplyMaintenanceWindowToControlPlane(ctx, log, c, cluster, cp); err != nil {
+		return controller.Result{}, errors.Wrap(err, "applying maintenance window to control plane")
+	}
+
+	return ReconcileControlPlane(ctx, log, c, cp)
+}
+
 // ReconcileControlPlane orchestrates the ControlPlane reconciliation logic.
 func ReconcileControlPlane(ctx context.Context, log logr.Logger, c client.Client, cp *ControlPlane) (controller.Result, error) {
 	cluster, kcp, etcdadmCluster, err := readCurrentControlPlane(ctx, c, cp)
@@ -0,0 +1,173 @@
+package clusters
+
+import (
+	"context"
+
+	etcdv1 "github.com/aws/etcdadm-controller/api/v1beta1"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/equality"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	kubeadmv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+// UpgradeNeeded returns true if applying the desired control plane and workers would replace or upgrade any
+// of the current control plane, etcd or worker machines. New clusters and new worker node groups don't need
+// an upgrade. The provider cluster reconcilers use it to implement their UpgradeNeeded.
+func UpgradeNeeded(ctx context.Context, c client.Client, cp *ControlPlane, w *Workers) (bool, error) {
+	capiCluster, kcp, etcdadmCluster, err := readCurrentControlPlane(ctx, c, cp)
+	if err != nil {
+		return false, err
+	}
+
+	if capiCluster == nil {
+		return false, nil
+	}
+
+	if controlPlaneRolloutNeeded(cp, kcp, etcdadmCluster) {
+		return true, nil
+	}
+
+	current, err := currentMachineDeployments(ctx, c, capiCluster)
+	if err != nil {
+		return false, err
+	}
+
+	for _, g := range w.Groups {
+		if currentMD, ok := current[g.MachineDeployment.Name]; ok && machineDeploymentRolloutNeeded(g.MachineDeployment, currentMD) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// ApplyMaintenanceWindowToControlPlane keeps the current machines spec of the control plane and etcd in the
+// desired ControlPlane while the cluster changes that roll machines are deferred until the maintenance window
+// opens. The rest of the changes, like the number of replicas, are still applied.
+func ApplyMaintenanceWindowToControlPlane(ctx context.Context, log logr.Logger, c client.Client, cluster *anywherev1.Cluster, cp *ControlPlane) error {
+	if !disruptiveChangesDeferred(cluster) {
+		return nil
+	}
+
+	capiCluster, kcp, etcdadmCluster, err := readCurrentControlPlane(ctx, c, cp)
+	if err != nil {
+		return err
+	}
+
+	if capiCluster == nil || !controlPlaneRolloutNeeded(cp, kcp, etcdadmCluster) {
+		return nil
+	}
+
+	log.Info("Maintenance window is closed, keeping the current control plane machines")
+	keepCurrentControlPlaneMachines(cp, kcp, etcdadmCluster)
+
+	return nil
+}
+
+// ApplyMaintenanceWindowToWorkers keeps the current machines spec of the worker node groups in the desired
+// MachineDeployments while the cluster changes that roll machines are deferred until the maintenance window
+// opens. New worker node groups and the rest of the changes, like the number of replicas, are still applied.
+func ApplyMaintenanceWindowToWorkers(ctx context.Context, log logr.Logger, c client.Client, cluster *anywherev1.Cluster, capiCluster *clusterv1.Cluster, w *Workers) error {
+	if !disruptiveChangesDeferred(cluster) {
+		return nil
+	}
+
+	current, err := currentMachineDeployments(ctx, c, capiCluster)
+	if err != nil {
+		return err
+	}
+
+	var kept []string
+	for _, g := range w.Groups {
+		md := g.MachineDeployment
+		currentMD, ok := current[md.Name]
+		if !ok || !machineDeploymentRolloutNeeded(md, currentMD) {
+			continue
+		}
+
+		md.Spec.Template.Spec.Version = currentMD.Spec.Template.Spec.Version
+		md.Spec.Template.Spec.Bootstrap.ConfigRef = currentMD.Spec.Template.Spec.Bootstrap.ConfigRef
+		md.Spec.Template.Spec.InfrastructureRef = currentMD.Spec.Template.Spec.InfrastructureRef
+		kept = append(kept, md.Name)
+	}
+
+	if len(kept) != 0 {
+		log.Info("Maintenance window is closed, keeping the current worker node groups machines", "machineDeployments", kept)
+	}
+
+	return nil
+}
+
+// disruptiveChangesDeferred returns true if the cluster reconciler has deferred the changes that roll machines
+// until the maintenance window opens.
+func disruptiveChangesDeferred(cluster *anywherev1.Cluster) bool {
+	return cluster.Spec.MaintenanceWindow != nil &&
+		conditions.GetReason(cluster, anywherev1.DisruptiveChangesAppliedCondition) == anywherev1.WaitingForMaintenanceWindowReason
+}
+
+func currentMachineDeployments(ctx context.Context, c client.Client, capiCluster *clusterv1.Cluster) (map[string]*clusterv1.MachineDeployment, error) {
+	machineDeployments := &clusterv1.MachineDeploymentList{}
+	if err := c.List(ctx, machineDeployments,
+		client.MatchingLabels{clusterv1.ClusterNameLabel: capiCluster.Name},
+		client.InNamespace(capiCluster.Namespace)); err != nil {
+		return nil, errors.Wrap(err, "listing current machine deployments")
+	}
+
+	current := make(map[string]*clusterv1.MachineDeployment, len(machineDeployments.Items))
+	for i := range machineDeployments.Items {
+		current[machineDeployments.Items[i].Name] = &machineDeployments.Items[i]
+	}
+
+	return current, nil
+}
+
+// machineDeploymentRolloutNeeded returns true if applying the desired MachineDeployment would replace or upgrade
+// its machines. It uses machineDeploymentHash, the same hash the upgrade policy uses to track their upgrades.
+func machineDeploymentRolloutNeeded(desired, current *clusterv1.MachineDeployment) bool {
+	return machineDeploymentHash(desired) != machineDeploymentHash(current)
+}
+
+// controlPlaneRolloutNeeded returns true if applying the desired control plane would replace or upgrade
+// the control plane or etcd machines.
+func controlPlaneRolloutNeeded(cp *ControlPlane, kcp *controlplanev1.KubeadmControlPlane, etcdadmCluster *etcdv1.EtcdadmCluster) bool {
+	desired := cp.KubeadmControlPlane.Spec
+	if desired.Version != kcp.Spec.Version ||
+		desired.MachineTemplate.InfrastructureRef.Name != kcp.Spec.MachineTemplate.InfrastructureRef.Name {
+		return true
+	}
+
+	// The external etcd endpoints are set by the kcp controller, they are not part of our desired spec.
+	configSpec := desired.KubeadmConfigSpec.DeepCopy()
+	if endpoints := externalEtcdEndpoints(&kcp.Spec.KubeadmConfigSpec); len(endpoints) != 0 && externalEtcdEndpoints(configSpec) != nil {
+		configSpec.ClusterConfiguration.Etcd.External.Endpoints = endpoints
+	}
+	if !equality.Semantic.DeepDerivative(*configSpec, kcp.Spec.KubeadmConfigSpec) {
+		return true
+	}
+
+	return cp.EtcdCluster != nil && etcdadmCluster != nil && !equality.Semantic.DeepDerivative(cp.EtcdCluster.Spec, etcdadmCluster.Spec)
+}
+
+func keepCurrentControlPlaneMachines(cp *ControlPlane, kcp *controlplanev1.KubeadmControlPlane, etcdadmCluster *etcdv1.EtcdadmCluster) {
+	cp.KubeadmControlPlane.Spec.Version = kcp.Spec.Version
+	cp.KubeadmControlPlane.Spec.MachineTemplate.InfrastructureRef = kcp.Spec.MachineTemplate.InfrastructureRef
+	cp.KubeadmControlPlane.Spec.KubeadmConfigSpec = *kcp.Spec.KubeadmConfigSpec.DeepCopy()
+
+	if cp.EtcdCluster != nil && etcdadmCluster != nil {
+		cp.EtcdCluster.Spec = *etcdadmCluster.Spec.DeepCopy()
+	}
+}
+
+func externalEtcdEndpoints(spec *kubeadmv1.KubeadmConfigSpec) []string {
+	if spec.ClusterConfiguration == nil || spec.ClusterConfiguration.Etcd.External == nil {
+		return nil
+	}
+
+	return spec.ClusterConfiguration.Etcd.External.Endpoints
+}
@@ -0,0 +1,218 @@
+package clusters_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller/clusters"
+)
+
+type maintenanceWindowTest struct {
+	*WithT
+	ctx     context.Context
+	cluster *anywherev1.Cluster
+	cp      *clusters.ControlPlane
+}
+
+func newMaintenanceWindowTest(t *testing.T) *maintenanceWindowTest {
+	cp := controlPlaneStackedEtcd(constants.EksaSystemNamespace)
+	cp.KubeadmControlPlane.Spec.Version = "v1.28.1-eks-1-28-5"
+	cluster := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster",
+			Namespace: "default",
+		},
+		Spec: anywherev1.ClusterSpec{
+			MaintenanceWindow: &anywherev1.MaintenanceWindow{
+				Schedule: "0 22 * * 5",
+				Duration: metav1.Duration{Duration: 8 * time.Hour},
+			},
+		},
+	}
+	conditions.MarkFalse(cluster, anywherev1.DisruptiveChangesAppliedCondition, anywherev1.WaitingForMaintenanceWindowReason, clusterv1.ConditionSeverityInfo, "")
+
+	return &maintenanceWindowTest{
+		WithT:   NewWithT(t),
+		ctx:     context.Background(),
+		cluster: cluster,
+		cp:      cp,
+	}
+}
+
+func (tt *maintenanceWindowTest) currentControlPlane() []client.Object {
+	current := controlPlaneStackedEtcd(constants.EksaSystemNamespace)
+	current.KubeadmControlPlane.Spec.Version = "v1.27.5-eks-1-27-12"
+	return current.AllObjects()
+}
+
+func (tt *maintenanceWindowTest) upToDateControlPlane() []client.Object {
+	current := controlPlaneStackedEtcd(constants.EksaSystemNamespace)
+	current.KubeadmControlPlane.Spec.Version = "v1.28.1-eks-1-28-5"
+	return current.AllObjects()
+}
+
+func (tt *maintenanceWindowTest) applyToControlPlane(objs ...client.Object) {
+	c := fake.NewClientBuilder().WithObjects(objs...).Build()
+	tt.Expect(clusters.ApplyMaintenanceWindowToControlPlane(tt.ctx, test.NewNullLogger(), c, tt.cluster, tt.cp)).To(Succeed())
+}
+
+func (tt *maintenanceWindowTest) applyToWorkers(w *clusters.Workers, objs ...client.Object) {
+	c := fake.NewClientBuilder().WithObjects(objs...).Build()
+	capiCluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster",
+			Namespace: constants.EksaSystemNamespace,
+		},
+	}
+	tt.Expect(clusters.ApplyMaintenanceWindowToWorkers(tt.ctx, test.NewNullLogger(), c, tt.cluster, capiCluster, w)).To(Succeed())
+}
+
+func (tt *maintenanceWindowTest) upgradeNeeded(w *clusters.Workers, objs ...client.Object) bool {
+	c := fake.NewClientBuilder().WithObjects(objs...).Build()
+	needed, err := clusters.UpgradeNeeded(tt.ctx, c, tt.cp, w)
+	tt.Expect(err).NotTo(HaveOccurred())
+	return needed
+}
+
+func singleGroupWorkers() *clusters.Workers {
+	return &clusters.Workers{
+		Groups: []clusters.WorkerGroup{
+			{MachineDeployment: upgradePolicyMachineDeployment("my-cluster-md-0", "md-0-2")},
+		},
+	}
+}
+
+func TestUpgradeNeededNewCluster(t *testing.T) {
+	tt := newMaintenanceWindowTest(t)
+
+	tt.Expect(tt.upgradeNeeded(singleGroupWorkers())).To(BeFalse())
+}
+
+func TestUpgradeNeededControlPlaneRollout(t *testing.T) {
+	tt := newMaintenanceWindowTest(t)
+
+	tt.Expect(tt.upgradeNeeded(singleGroupWorkers(), tt.currentControlPlane()...)).To(BeTrue())
+}
+
+func TestUpgradeNeededWorkersRollout(t *testing.T) {
+	tt := newMaintenanceWindowTest(t)
+	objs := append(tt.upToDateControlPlane(), outdatedMachineDeployment("my-cluster-md-0", "md-0-1"))
+
+	tt.Expect(tt.upgradeNeeded(singleGroupWorkers(), objs...)).To(BeTrue())
+}
+
+func TestUpgradeNeededNoRollout(t *testing.T) {
+	tt := newMaintenanceWindowTest(t)
+	tt.cp.KubeadmControlPlane.Spec.Replicas = pointer.Int32(3)
+	w := singleGroupWorkers()
+	w.Groups[0].MachineDeployment.Spec.Replicas = pointer.Int32(3)
+	objs := append(tt.upToDateControlPlane(), upgradePolicyMachineDeployment("my-cluster-md-0", "md-0-2"))
+
+	tt.Expect(tt.upgradeNeeded(w, objs...)).To(BeFalse())
+}
+
+func TestUpgradeNeededNewWorkerGroup(t *testing.T) {
+	tt := newMaintenanceWindowTest(t)
+
+	tt.Expect(tt.upgradeNeeded(singleGroupWorkers(), tt.upToDateControlPlane()...)).To(BeFalse())
+}
+
+func TestApplyMaintenanceWindowToControlPlaneNoWindow(t *testing.T) {
+	tt := newMaintenanceWindowTest(t)
+	tt.cluster.Spec.MaintenanceWindow = nil
+
+	tt.applyToControlPlane(tt.currentControlPlane()...)
+
+	tt.Expect(tt.cp.KubeadmControlPlane.Spec.Version).To(Equal("v1.28.1-eks-1-28-5"))
+}
+
+func TestApplyMaintenanceWindowToControlPlaneNotDeferred(t *testing.T) {
+	tt := newMaintenanceWindowTest(t)
+	conditions.MarkTrue(tt.cluster, anywherev1.DisruptiveChangesAppliedCondition)
+
+	tt.applyToControlPlane(tt.currentControlPlane()...)
+
+	tt.Expect(tt.cp.KubeadmControlPlane.Spec.Version).To(Equal("v1.28.1-eks-1-28-5"))
+}
+
+func TestApplyMaintenanceWindowToControlPlaneNewCluster(t *testing.T) {
+	tt := newMaintenanceWindowTest(t)
+
+	tt.applyToControlPlane()
+
+	tt.Expect(tt.cp.KubeadmControlPlane.Spec.Version).To(Equal("v1.28.1-eks-1-28-5"))
+}
+
+func TestApplyMaintenanceWindowToControlPlaneNoRollout(t *testing.T) {
+	tt := newMaintenanceWindowTest(t)
+	tt.cp.KubeadmControlPlane.Spec.Replicas = pointer.Int32(3)
+
+	tt.applyToControlPlane(tt.upToDateControlPlane()...)
+
+	tt.Expect(tt.cp.KubeadmControlPlane.Spec.Version).To(Equal("v1.28.1-eks-1-28-5"))
+	tt.Expect(tt.cp.KubeadmControlPlane.Spec.Replicas).To(Equal(pointer.Int32(3)))
+}
+
+func TestApplyMaintenanceWindowToControlPlaneDeferred(t *testing.T) {
+	tt := newMaintenanceWindowTest(t)
+	tt.cp.KubeadmControlPlane.Spec.Replicas = pointer.Int32(3)
+
+	tt.applyToControlPlane(tt.currentControlPlane()...)
+
+	tt.Expect(tt.cp.KubeadmControlPlane.Spec.Version).To(Equal("v1.27.5-eks-1-27-12"))
+	tt.Expect(tt.cp.KubeadmControlPlane.Spec.Replicas).To(Equal(pointer.Int32(3)))
+}
+
+func TestApplyMaintenanceWindowToWorkersDeferred(t *testing.T) {
+	tt := newMaintenanceWindowTest(t)
+	w := &clusters.Workers{
+		Groups: []clusters.WorkerGroup{
+			{MachineDeployment: upgradePolicyMachineDeployment("my-cluster-md-0", "md-0-2")},
+			{MachineDeployment: upgradePolicyMachineDeployment("my-cluster-md-1", "md-1-1")},
+		},
+	}
+	w.Groups[0].MachineDeployment.Spec.Replicas = pointer.Int32(3)
+
+	tt.applyToWorkers(w,
+		outdatedMachineDeployment("my-cluster-md-0", "md-0-1"),
+		upgradePolicyMachineDeployment("my-cluster-md-1", "md-1-1"),
+	)
+
+	md := w.Groups[0].MachineDeployment
+	tt.Expect(md.Spec.Replicas).To(Equal(pointer.Int32(3)))
+	tt.Expect(md.Spec.Template.Spec.Version).To(Equal(pointer.String("v1.27.5-eks-1-27-12")))
+	tt.Expect(md.Spec.Template.Spec.InfrastructureRef.Name).To(Equal("md-0-1"))
+	tt.Expect(md.Spec.Template.Spec.Bootstrap.ConfigRef.Name).To(Equal("md-0-1"))
+	tt.Expect(w.Groups[1].MachineDeployment.Spec.Template.Spec.InfrastructureRef.Name).To(Equal("md-1-1"))
+}
+
+func TestApplyMaintenanceWindowToWorkersNewGroup(t *testing.T) {
+	tt := newMaintenanceWindowTest(t)
+	w := singleGroupWorkers()
+
+	tt.applyToWorkers(w)
+
+	tt.Expect(w.Groups[0].MachineDeployment.Spec.Template.Spec.InfrastructureRef.Name).To(Equal("md-0-2"))
+}
+
+func TestApplyMaintenanceWindowToWorkersNotDeferred(t *testing.T) {
+	tt := newMaintenanceWindowTest(t)
+	conditions.MarkTrue(tt.cluster, anywherev1.DisruptiveChangesAppliedCondition)
+	w := singleGroupWorkers()
+
+	tt.applyToWorkers(w, outdatedMachineDeployment("my-cluster-md-0", "md-0-1"))
+
+	tt.Expect(w.Groups[0].MachineDeployment.Spec.Template.Spec.InfrastructureRef.Name).To(Equal("md-0-2"))
+}
@@ -13,6 +13,8 @@ import (
 type ProviderClusterReconciler interface {
 	// Reconcile handles the full cluster reconciliation.
 	Reconcile(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) (controller.Result, error)
+	// UpgradeNeeded returns true if reconciling the cluster would replace or upgrade any of its machines.
+	UpgradeNeeded(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) (bool, error)
 }
 
 // ProviderClusterReconcilerRegistry holds a collection of cluster provider reconcilers
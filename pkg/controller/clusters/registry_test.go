@@ -57,6 +57,10 @@ func (dummyProviderReconciler) Reconcile(ctx context.Context, log logr.Logger, c
 	return controller.Result{}, nil
 }
 
+func (dummyProviderReconciler) UpgradeNeeded(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) (bool, error) {
+	return false, nil
+}
+
 func (dummyProviderReconciler) ReconcileCNI(ctx context.Context, log logr.Logger, clusterSpec *cluster.Spec) (controller.Result, error) {
 	return controller.Result{}, nil
 }
@@ -14,6 +14,7 @@ import (
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/clusterapi"
 	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/gitops/drift"
 )
 
 // UpdateClusterStatusForControlPlane checks the current state of the Cluster's control plane and updates the
@@ -71,8 +72,27 @@ func UpdateClusterStatusForCNI(ctx context.Context, cluster *anywherev1.Cluster)
 	}
 }
 
+// UpdateClusterStatusForGitOps updates the GitOpsInSync condition, flagging clusters with GitOps enabled whose
+// spec was modified outside of the GitOps repository. The GitOps engine eventually reverts those changes.
+func UpdateClusterStatusForGitOps(cluster *anywherev1.Cluster) {
+	if cluster.Spec.GitOpsRef == nil {
+		conditions.Delete(cluster, anywherev1.GitOpsInSyncCondition)
+		return
+	}
+
+	if manager, drifted := drift.ModifiedOutsideGitOps(cluster); drifted {
+		conditions.MarkFalse(cluster, anywherev1.GitOpsInSyncCondition, anywherev1.GitOpsDriftDetectedReason, clusterv1.ConditionSeverityWarning,
+			"Cluster spec was modified by %s outside of the GitOps repository, run eksctl anywhere gitops status to see the drift", manager)
+		return
+	}
+
+	conditions.MarkTrue(cluster, anywherev1.GitOpsInSyncCondition)
+}
+
 // updateConditionsForEtcdAndControlPlane updates the ControlPlaneReady condition if etcdadm cluster is not ready.
 func updateConditionsForEtcdAndControlPlane(cluster *anywherev1.Cluster, kcp *controlplanev1.KubeadmControlPlane, etcdadmCluster *etcdv1.EtcdadmCluster) {
+	updateEtcdHealthyCondition(cluster, kcp, etcdadmCluster)
+
 	// Make sure etcd cluster is ready before marking ControlPlaneReady status to true
 	// This condition happens while creating a workload cluster from the management cluster using controller
 	// where it tries to get the etcdadm cluster for the first time before it generates the resources.
@@ -88,6 +108,40 @@ func updateConditionsForEtcdAndControlPlane(cluster *anywherev1.Cluster, kcp *co
 	updateControlPlaneReadyCondition(cluster, kcp)
 }
 
+// updateEtcdHealthyCondition updates the EtcdHealthy condition from the health checks of the etcd members run by
+// the kubeadm control plane for stacked etcd or the etcdadm cluster for external etcd. The condition is not set
+// until those health checks have run.
+func updateEtcdHealthyCondition(cluster *anywherev1.Cluster, kcp *controlplanev1.KubeadmControlPlane, etcdadmCluster *etcdv1.EtcdadmCluster) {
+	var health *clusterv1.Condition
+	if cluster.Spec.ExternalEtcdConfiguration != nil {
+		if etcdadmCluster != nil {
+			health = conditions.Get(etcdadmCluster, etcdv1.EtcdEndpointsAvailable)
+		}
+	} else if kcp != nil {
+		health = conditions.Get(kcp, controlplanev1.EtcdClusterHealthyCondition)
+	}
+
+	if health == nil {
+		return
+	}
+
+	if health.Status == "True" {
+		conditions.MarkTrue(cluster, anywherev1.EtcdHealthyCondition)
+		return
+	}
+
+	message := health.Message
+	if message == "" {
+		message = "Etcd members are not passing health checks"
+	}
+	severity := health.Severity
+	if severity == "" {
+		severity = clusterv1.ConditionSeverityWarning
+	}
+	conditions.MarkFalse(cluster, anywherev1.EtcdHealthyCondition, anywherev1.EtcdUnhealthyReason, severity,
+		"%s, run eksctl anywhere etcd status to inspect the members", message)
+}
+
 // updateControlPlaneReadyCondition updates the ControlPlaneReady condition, after checking the state of the control plane
 // in the cluster.
 func updateControlPlaneReadyCondition(cluster *anywherev1.Cluster, kcp *controlplanev1.KubeadmControlPlane) {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	etcdv1 "github.com/aws/etcdadm-controller/api/v1beta1"
 	. "github.com/onsi/gomega"
@@ -1341,3 +1342,204 @@ func TestUpdateClusterStatusForCNI(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateClusterStatusForControlPlaneEtcdHealthy(t *testing.T) {
+	tests := []struct {
+		name              string
+		externalEtcd      bool
+		kcpConditions     clusterv1.Conditions
+		etcdadmConditions clusterv1.Conditions
+		wantCondition     *anywherev1.Condition
+	}{
+		{
+			name:          "stacked etcd, not inspected yet",
+			wantCondition: nil,
+		},
+		{
+			name: "stacked etcd, healthy",
+			kcpConditions: clusterv1.Conditions{
+				{Type: controlplanev1.EtcdClusterHealthyCondition, Status: "True"},
+			},
+			wantCondition: &anywherev1.Condition{
+				Type:   anywherev1.EtcdHealthyCondition,
+				Status: "True",
+			},
+		},
+		{
+			name: "stacked etcd, unhealthy",
+			kcpConditions: clusterv1.Conditions{
+				{
+					Type:     controlplanev1.EtcdClusterHealthyCondition,
+					Status:   "False",
+					Severity: clusterv1.ConditionSeverityError,
+					Reason:   controlplanev1.EtcdClusterUnhealthyReason,
+					Message:  "Following machines are reporting etcd member errors: test-cluster-cp-a",
+				},
+			},
+			wantCondition: &anywherev1.Condition{
+				Type:     anywherev1.EtcdHealthyCondition,
+				Status:   "False",
+				Severity: clusterv1.ConditionSeverityError,
+				Reason:   anywherev1.EtcdUnhealthyReason,
+				Message:  "Following machines are reporting etcd member errors: test-cluster-cp-a, run eksctl anywhere etcd status to inspect the members",
+			},
+		},
+		{
+			name:         "external etcd, endpoints available",
+			externalEtcd: true,
+			etcdadmConditions: clusterv1.Conditions{
+				{Type: etcdv1.EtcdEndpointsAvailable, Status: "True"},
+			},
+			wantCondition: &anywherev1.Condition{
+				Type:   anywherev1.EtcdHealthyCondition,
+				Status: "True",
+			},
+		},
+		{
+			name:         "external etcd, endpoints not passing health checks",
+			externalEtcd: true,
+			etcdadmConditions: clusterv1.Conditions{
+				{
+					Type:   etcdv1.EtcdEndpointsAvailable,
+					Status: "False",
+					Reason: etcdv1.WaitingForEtcdadmEndpointsToPassHealthcheckReason,
+				},
+			},
+			wantCondition: &anywherev1.Condition{
+				Type:     anywherev1.EtcdHealthyCondition,
+				Status:   "False",
+				Severity: clusterv1.ConditionSeverityWarning,
+				Reason:   anywherev1.EtcdUnhealthyReason,
+				Message:  "Etcd members are not passing health checks, run eksctl anywhere etcd status to inspect the members",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			cluster := test.NewClusterSpec().Cluster
+			cluster.Name = "test-cluster"
+			cluster.Namespace = constants.EksaSystemNamespace
+
+			kcp := test.KubeadmControlPlane(func(kcp *controlplanev1.KubeadmControlPlane) {
+				kcp.Name = cluster.Name
+				kcp.Namespace = cluster.Namespace
+				kcp.Status.Conditions = tt.kcpConditions
+			})
+			objs := []runtime.Object{kcp}
+			if tt.externalEtcd {
+				cluster.Spec.ExternalEtcdConfiguration = &anywherev1.ExternalEtcdConfiguration{Count: 3}
+				objs = append(objs,
+					&clusterv1.Cluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      cluster.Name,
+							Namespace: constants.EksaSystemNamespace,
+						},
+						Spec: clusterv1.ClusterSpec{
+							ManagedExternalEtcdRef: &corev1.ObjectReference{
+								Kind: "EtcdadmCluster",
+								Name: "test-cluster-etcd",
+							},
+						},
+					},
+					&etcdv1.EtcdadmCluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "test-cluster-etcd",
+							Namespace: constants.EksaSystemNamespace,
+						},
+						Status: etcdv1.EtcdadmClusterStatus{
+							Conditions: tt.etcdadmConditions,
+						},
+					},
+				)
+			}
+			client := fake.NewClientBuilder().WithRuntimeObjects(objs...).Build()
+
+			g.Expect(clusters.UpdateClusterStatusForControlPlane(ctx, client, cluster)).To(Succeed())
+
+			condition := conditions.Get(cluster, anywherev1.EtcdHealthyCondition)
+			if tt.wantCondition == nil {
+				g.Expect(condition).To(BeNil())
+				return
+			}
+			g.Expect(condition).ToNot(BeNil())
+			g.Expect(condition.Status).To(Equal(tt.wantCondition.Status))
+			g.Expect(condition.Severity).To(Equal(tt.wantCondition.Severity))
+			g.Expect(condition.Reason).To(Equal(tt.wantCondition.Reason))
+			g.Expect(condition.Message).To(Equal(tt.wantCondition.Message))
+		})
+	}
+}
+
+func TestUpdateClusterStatusForGitOps(t *testing.T) {
+	fluxApply := metav1.NewTime(time.Date(2024, 1, 1, 0, 1, 0, 0, time.UTC))
+	edit := metav1.NewTime(time.Date(2024, 1, 1, 0, 2, 0, 0, time.UTC))
+	specFields := &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:kubernetesVersion":{}}}`)}
+
+	tests := []struct {
+		name          string
+		gitOpsRef     *anywherev1.Ref
+		managedFields []metav1.ManagedFieldsEntry
+		conditions    []anywherev1.Condition
+		wantCondition *anywherev1.Condition
+	}{
+		{
+			name: "gitops not enabled",
+			conditions: []anywherev1.Condition{
+				{Type: anywherev1.GitOpsInSyncCondition, Status: "True"},
+			},
+		},
+		{
+			name:      "in sync",
+			gitOpsRef: &anywherev1.Ref{Kind: anywherev1.FluxConfigKind, Name: "flux"},
+			managedFields: []metav1.ManagedFieldsEntry{
+				{Manager: "kustomize-controller", Time: &fluxApply, FieldsV1: specFields},
+			},
+			wantCondition: &anywherev1.Condition{
+				Type:   anywherev1.GitOpsInSyncCondition,
+				Status: "True",
+			},
+		},
+		{
+			name:      "drift detected",
+			gitOpsRef: &anywherev1.Ref{Kind: anywherev1.FluxConfigKind, Name: "flux"},
+			managedFields: []metav1.ManagedFieldsEntry{
+				{Manager: "kustomize-controller", Time: &fluxApply, FieldsV1: specFields},
+				{Manager: "kubectl-edit", Time: &edit, FieldsV1: specFields},
+			},
+			wantCondition: &anywherev1.Condition{
+				Type:     anywherev1.GitOpsInSyncCondition,
+				Status:   "False",
+				Reason:   anywherev1.GitOpsDriftDetectedReason,
+				Severity: clusterv1.ConditionSeverityWarning,
+				Message:  "Cluster spec was modified by kubectl-edit outside of the GitOps repository",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			c := &anywherev1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{ManagedFields: tt.managedFields},
+				Spec:       anywherev1.ClusterSpec{GitOpsRef: tt.gitOpsRef},
+				Status:     anywherev1.ClusterStatus{Conditions: tt.conditions},
+			}
+
+			clusters.UpdateClusterStatusForGitOps(c)
+
+			condition := conditions.Get(c, anywherev1.GitOpsInSyncCondition)
+			if tt.wantCondition == nil {
+				g.Expect(condition).To(BeNil())
+				return
+			}
+			g.Expect(condition).ToNot(BeNil())
+			g.Expect(condition.Status).To(Equal(tt.wantCondition.Status))
+			g.Expect(condition.Reason).To(Equal(tt.wantCondition.Reason))
+			g.Expect(condition.Severity).To(Equal(tt.wantCondition.Severity))
+			g.Expect(condition.Message).To(ContainSubstring(tt.wantCondition.Message))
+		})
+	}
+}
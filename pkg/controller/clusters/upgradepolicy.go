@@ -0,0 +1,257 @@
+package clusters
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/controller/reconcileutil"
+)
+
+// machineDeploymentInPlaceUpgradeNeededAnnotation is set on MachineDeployments with an in place
+// upgrade in progress.
+const machineDeploymentInPlaceUpgradeNeededAnnotation = "machinedeployment.clusters.x-k8s.io/in-place-upgrade-needed"
+
+// lastWaveName is the name of the wave of the worker node groups not included in any wave.
+const lastWaveName = "remaining"
+
+type upgradeGroup struct {
+	name    string
+	wave    int
+	hash    string
+	desired *clusterv1.MachineDeployment
+	current *clusterv1.MachineDeployment
+}
+
+// started returns true if the MachineDeployment in the cluster already has the desired spec.
+func (g *upgradeGroup) started() bool {
+	return g.current != nil && machineDeploymentHash(g.current) == g.hash
+}
+
+// needsUpgrade returns true if the MachineDeployment exists and doesn't have the desired spec yet.
+func (g *upgradeGroup) needsUpgrade() bool {
+	return g.current != nil && !g.started()
+}
+
+// done returns true if the worker node group doesn't need to wait for other groups to be upgraded.
+// New worker node groups are created right away.
+func (g *upgradeGroup) done() bool {
+	return g.current == nil || (g.started() && machineDeploymentUpgraded(g.current))
+}
+
+func (g *upgradeGroup) inPlace() bool {
+	return g.desired.Spec.Strategy != nil && g.desired.Spec.Strategy.Type == clusterv1.InPlaceMachineDeploymentStrategyType
+}
+
+// ApplyUpgradePolicy pauses the worker node groups that can't be upgraded yet according to the
+// upgrade policy of the cluster, by adding the CAPI paused annotation to their desired MachineDeployments.
+// Worker node groups are upgraded in waves and, if the policy has a canary, the first one only upgrades
+// a single node until it passes the health check. The canary state is kept in the cluster status.
+// It returns true while any worker node group is still pending to be upgraded.
+func ApplyUpgradePolicy(ctx context.Context, c client.Client, cluster *anywherev1.Cluster, capiCluster *clusterv1.Cluster, w *Workers) (bool, error) {
+	policy := cluster.Spec.UpgradePolicy
+	if policy == nil {
+		return false, nil
+	}
+
+	currentMachineDeployments := &clusterv1.MachineDeploymentList{}
+	if err := c.List(ctx, currentMachineDeployments,
+		client.MatchingLabels{clusterv1.ClusterNameLabel: capiCluster.Name},
+		client.InNamespace(capiCluster.Namespace)); err != nil {
+		return false, errors.Wrap(err, "listing current machine deployments")
+	}
+
+	groups := upgradeGroups(cluster, w, currentMachineDeployments.Items)
+
+	var pending []*upgradeGroup
+	for _, g := range groups {
+		if !g.done() {
+			pending = append(pending, g)
+		}
+	}
+
+	if len(pending) == 0 {
+		cluster.Status.UpgradeCanary = nil
+		conditions.MarkTrue(cluster, anywherev1.WorkerUpgradeWavesCompletedCondition)
+		return false, nil
+	}
+
+	var canary *anywherev1.UpgradeCanaryStatus
+	if policy.Canary != nil {
+		canary = upgradeCanary(cluster, groups, pending)
+	}
+
+	if canary != nil && canary.Phase != anywherev1.UpgradeCanaryPassed {
+		for _, g := range groups {
+			if g.name != canary.MachineDeployment {
+				if !g.done() {
+					pause(g.desired)
+				}
+				continue
+			}
+
+			g.desired.Annotations[anywherev1.UpgradeCanaryAnnotation] = "true"
+			// In place upgrades stop after the canary node by themselves. Rolling upgrades are paused as soon as
+			// the canary machine has been created, so no other machine is replaced until the health check passes.
+			if canary.Phase != anywherev1.UpgradeCanaryUpgrading ||
+				(!g.inPlace() && g.started() && g.current.Status.ObservedGeneration >= g.current.Generation && g.current.Status.UpdatedReplicas > 0) {
+				pause(g.desired)
+			}
+		}
+
+		if canary.Phase == anywherev1.UpgradeCanaryFailed {
+			conditions.MarkFalse(cluster, anywherev1.WorkerUpgradeWavesCompletedCondition, anywherev1.UpgradeCanaryFailedReason, clusterv1.ConditionSeverityError,
+				"Canary node %s failed the health check, worker nodes upgrade is paused: %s", canary.NodeName, canary.Message)
+		} else {
+			conditions.MarkFalse(cluster, anywherev1.WorkerUpgradeWavesCompletedCondition, anywherev1.UpgradeCanaryInProgressReason, clusterv1.ConditionSeverityInfo,
+				"Upgrading canary node of %s, %s", canary.MachineDeployment, canary.Phase)
+		}
+
+		return true, nil
+	}
+
+	currentWave := pending[0].wave
+	for _, g := range pending {
+		if g.wave > currentWave {
+			pause(g.desired)
+		}
+	}
+
+	conditions.MarkFalse(cluster, anywherev1.WorkerUpgradeWavesCompletedCondition, anywherev1.WorkerUpgradeWaveInProgressReason, clusterv1.ConditionSeverityInfo,
+		"Upgrading worker nodes wave %s, %d worker node groups pending", waveName(policy, currentWave), len(pending))
+
+	return true, nil
+}
+
+// upgradeGroups returns the worker groups sorted by their wave.
+func upgradeGroups(cluster *anywherev1.Cluster, w *Workers, current []clusterv1.MachineDeployment) []*upgradeGroup {
+	policy := cluster.Spec.UpgradePolicy
+	lastWave := len(policy.Waves)
+
+	waves := make(map[string]int, len(cluster.Spec.WorkerNodeGroupConfigurations))
+	for _, wng := range cluster.Spec.WorkerNodeGroupConfigurations {
+		waves[clusterapi.MachineDeploymentName(cluster, wng)] = lastWave
+	}
+	for i, wave := range policy.Waves {
+		for _, name := range wave.WorkerNodeGroups {
+			for _, wng := range cluster.Spec.WorkerNodeGroupConfigurations {
+				if wng.Name == name {
+					waves[clusterapi.MachineDeploymentName(cluster, wng)] = i
+				}
+			}
+		}
+	}
+
+	currentByName := make(map[string]*clusterv1.MachineDeployment, len(current))
+	for i := range current {
+		currentByName[current[i].Name] = &current[i]
+	}
+
+	groups := make([]*upgradeGroup, 0, len(w.Groups))
+	for _, g := range w.Groups {
+		md := g.MachineDeployment
+		if md.Annotations == nil {
+			md.Annotations = map[string]string{}
+		}
+
+		wave, ok := waves[md.Name]
+		if !ok {
+			wave = lastWave
+		}
+
+		groups = append(groups, &upgradeGroup{
+			name:    md.Name,
+			wave:    wave,
+			hash:    machineDeploymentHash(md),
+			desired: md,
+			current: currentByName[md.Name],
+		})
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].wave < groups[j].wave
+	})
+
+	return groups
+}
+
+// upgradeCanary returns the canary of the current upgrade, picking a new one from the first
+// pending wave if the upgrade has just started.
+func upgradeCanary(cluster *anywherev1.Cluster, groups, pending []*upgradeGroup) *anywherev1.UpgradeCanaryStatus {
+	if s := cluster.Status.UpgradeCanary; s != nil {
+		for _, g := range groups {
+			if g.name == s.MachineDeployment && g.hash == s.Hash {
+				return s
+			}
+		}
+	}
+
+	cluster.Status.UpgradeCanary = nil
+	for _, g := range pending {
+		if g.wave != pending[0].wave {
+			break
+		}
+		if g.needsUpgrade() {
+			cluster.Status.UpgradeCanary = &anywherev1.UpgradeCanaryStatus{
+				MachineDeployment: g.name,
+				Hash:              g.hash,
+				Phase:             anywherev1.UpgradeCanaryUpgrading,
+			}
+			break
+		}
+	}
+
+	return cluster.Status.UpgradeCanary
+}
+
+func waveName(policy *anywherev1.UpgradePolicy, wave int) string {
+	if wave < len(policy.Waves) {
+		return policy.Waves[wave].Name
+	}
+
+	return lastWaveName
+}
+
+func pause(md *clusterv1.MachineDeployment) {
+	md.Annotations[clusterv1.PausedAnnotation] = "true"
+}
+
+// machineDeploymentHash identifies the machines spec of a MachineDeployment. Changes to the templates
+// always generate new template names, so it changes every time the machines need to be upgraded.
+func machineDeploymentHash(md *clusterv1.MachineDeployment) string {
+	spec := md.Spec.Template.Spec
+	var version, bootstrap string
+	if spec.Version != nil {
+		version = *spec.Version
+	}
+	if spec.Bootstrap.ConfigRef != nil {
+		bootstrap = spec.Bootstrap.ConfigRef.Name
+	}
+
+	return reconcileutil.ShortHash([]byte(fmt.Sprintf("%s/%s/%s/%s", version, bootstrap, spec.InfrastructureRef.Kind, spec.InfrastructureRef.Name)))
+}
+
+// machineDeploymentUpgraded returns true if all the machines of a MachineDeployment have its current spec and are ready.
+func machineDeploymentUpgraded(md *clusterv1.MachineDeployment) bool {
+	if annotations.HasPaused(md) || md.Annotations[machineDeploymentInPlaceUpgradeNeededAnnotation] == "true" {
+		return false
+	}
+
+	status := md.Status
+	if status.ObservedGeneration < md.Generation {
+		return false
+	}
+	if md.Spec.Replicas != nil && *md.Spec.Replicas != status.Replicas {
+		return false
+	}
+
+	return status.UpdatedReplicas == status.Replicas && status.ReadyReplicas == status.Replicas && status.UnavailableReplicas == 0
+}
@@ -0,0 +1,267 @@
+package clusters_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller/clusters"
+)
+
+type upgradePolicyTest struct {
+	*WithT
+	ctx         context.Context
+	cluster     *anywherev1.Cluster
+	capiCluster *clusterv1.Cluster
+	workers     *clusters.Workers
+}
+
+func newUpgradePolicyTest(t *testing.T) *upgradePolicyTest {
+	w := &clusters.Workers{
+		Groups: []clusters.WorkerGroup{
+			{MachineDeployment: upgradePolicyMachineDeployment("my-cluster-md-0", "md-0-2")},
+			{MachineDeployment: upgradePolicyMachineDeployment("my-cluster-md-1", "md-1-2")},
+		},
+	}
+
+	return &upgradePolicyTest{
+		WithT: NewWithT(t),
+		ctx:   context.Background(),
+		cluster: &anywherev1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-cluster",
+				Namespace: "default",
+			},
+			Spec: anywherev1.ClusterSpec{
+				WorkerNodeGroupConfigurations: []anywherev1.WorkerNodeGroupConfiguration{
+					{Name: "md-0"},
+					{Name: "md-1"},
+				},
+				UpgradePolicy: &anywherev1.UpgradePolicy{
+					Waves: []anywherev1.UpgradeWave{
+						{Name: "first", WorkerNodeGroups: []string{"md-0"}},
+						{Name: "second", WorkerNodeGroups: []string{"md-1"}},
+					},
+				},
+			},
+		},
+		capiCluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-cluster",
+				Namespace: constants.EksaSystemNamespace,
+			},
+		},
+		workers: w,
+	}
+}
+
+func (tt *upgradePolicyTest) apply(objs ...client.Object) bool {
+	c := fake.NewClientBuilder().WithObjects(objs...).Build()
+	pending, err := clusters.ApplyUpgradePolicy(tt.ctx, c, tt.cluster, tt.capiCluster, tt.workers)
+	tt.Expect(err).NotTo(HaveOccurred())
+	return pending
+}
+
+func (tt *upgradePolicyTest) desired(i int) *clusterv1.MachineDeployment {
+	return tt.workers.Groups[i].MachineDeployment
+}
+
+func upgradePolicyMachineDeployment(name, template string) *clusterv1.MachineDeployment {
+	md := machineDeployment(name, constants.EksaSystemNamespace)
+	md.Spec.Replicas = pointer.Int32(2)
+	md.Spec.Template.Spec.Version = pointer.String("v1.28.1-eks-1-28-5")
+	md.Spec.Template.Spec.Bootstrap.ConfigRef = &corev1.ObjectReference{Kind: "KubeadmConfigTemplate", Name: template}
+	md.Spec.Template.Spec.InfrastructureRef = corev1.ObjectReference{Kind: "DockerMachineTemplate", Name: template}
+	return md
+}
+
+// outdatedMachineDeployment returns a MachineDeployment with all its machines running the previous templates.
+func outdatedMachineDeployment(name, template string) *clusterv1.MachineDeployment {
+	md := upgradedMachineDeployment(name, template)
+	md.Spec.Template.Spec.Version = pointer.String("v1.27.5-eks-1-27-12")
+	return md
+}
+
+// upgradedMachineDeployment returns a MachineDeployment with all its machines updated and ready.
+func upgradedMachineDeployment(name, template string) *clusterv1.MachineDeployment {
+	md := upgradePolicyMachineDeployment(name, template)
+	md.Generation = 2
+	md.Status = clusterv1.MachineDeploymentStatus{
+		ObservedGeneration: 2,
+		Replicas:           2,
+		UpdatedReplicas:    2,
+		ReadyReplicas:      2,
+	}
+	return md
+}
+
+func TestApplyUpgradePolicyNoPolicy(t *testing.T) {
+	tt := newUpgradePolicyTest(t)
+	tt.cluster.Spec.UpgradePolicy = nil
+
+	tt.Expect(tt.apply(outdatedMachineDeployment("my-cluster-md-0", "md-0-1"))).To(BeFalse())
+	tt.Expect(tt.desired(0).Annotations).To(BeEmpty())
+	tt.Expect(conditions.Get(tt.cluster, anywherev1.WorkerUpgradeWavesCompletedCondition)).To(BeNil())
+}
+
+func TestApplyUpgradePolicyFirstWave(t *testing.T) {
+	tt := newUpgradePolicyTest(t)
+
+	tt.Expect(tt.apply(
+		outdatedMachineDeployment("my-cluster-md-0", "md-0-1"),
+		outdatedMachineDeployment("my-cluster-md-1", "md-1-1"),
+	)).To(BeTrue())
+
+	tt.Expect(tt.desired(0).Annotations).NotTo(HaveKey(clusterv1.PausedAnnotation))
+	tt.Expect(tt.desired(1).Annotations).To(HaveKeyWithValue(clusterv1.PausedAnnotation, "true"))
+	tt.Expect(conditions.IsFalse(tt.cluster, anywherev1.WorkerUpgradeWavesCompletedCondition)).To(BeTrue())
+	tt.Expect(conditions.GetReason(tt.cluster, anywherev1.WorkerUpgradeWavesCompletedCondition)).To(Equal(anywherev1.WorkerUpgradeWaveInProgressReason))
+	tt.Expect(conditions.GetMessage(tt.cluster, anywherev1.WorkerUpgradeWavesCompletedCondition)).To(ContainSubstring("wave first"))
+}
+
+func TestApplyUpgradePolicyFirstWaveInProgress(t *testing.T) {
+	tt := newUpgradePolicyTest(t)
+	inProgress := upgradedMachineDeployment("my-cluster-md-0", "md-0-2")
+	inProgress.Status.UpdatedReplicas = 1
+
+	tt.Expect(tt.apply(
+		inProgress,
+		outdatedMachineDeployment("my-cluster-md-1", "md-1-1"),
+	)).To(BeTrue())
+
+	tt.Expect(tt.desired(0).Annotations).NotTo(HaveKey(clusterv1.PausedAnnotation))
+	tt.Expect(tt.desired(1).Annotations).To(HaveKeyWithValue(clusterv1.PausedAnnotation, "true"))
+}
+
+func TestApplyUpgradePolicySecondWave(t *testing.T) {
+	tt := newUpgradePolicyTest(t)
+
+	tt.Expect(tt.apply(
+		upgradedMachineDeployment("my-cluster-md-0", "md-0-2"),
+		outdatedMachineDeployment("my-cluster-md-1", "md-1-1"),
+	)).To(BeTrue())
+
+	tt.Expect(tt.desired(0).Annotations).NotTo(HaveKey(clusterv1.PausedAnnotation))
+	tt.Expect(tt.desired(1).Annotations).NotTo(HaveKey(clusterv1.PausedAnnotation))
+	tt.Expect(conditions.GetMessage(tt.cluster, anywherev1.WorkerUpgradeWavesCompletedCondition)).To(ContainSubstring("wave second"))
+}
+
+func TestApplyUpgradePolicyNewGroupsNotPaused(t *testing.T) {
+	tt := newUpgradePolicyTest(t)
+
+	tt.Expect(tt.apply(
+		outdatedMachineDeployment("my-cluster-md-0", "md-0-1"),
+	)).To(BeTrue())
+
+	tt.Expect(tt.desired(1).Annotations).NotTo(HaveKey(clusterv1.PausedAnnotation))
+}
+
+func TestApplyUpgradePolicyCompleted(t *testing.T) {
+	tt := newUpgradePolicyTest(t)
+	tt.cluster.Spec.UpgradePolicy.Canary = &anywherev1.UpgradeCanary{}
+	tt.cluster.Status.UpgradeCanary = &anywherev1.UpgradeCanaryStatus{
+		MachineDeployment: "my-cluster-md-0",
+		Phase:             anywherev1.UpgradeCanaryPassed,
+	}
+
+	tt.Expect(tt.apply(
+		upgradedMachineDeployment("my-cluster-md-0", "md-0-2"),
+		upgradedMachineDeployment("my-cluster-md-1", "md-1-2"),
+	)).To(BeFalse())
+
+	tt.Expect(tt.desired(0).Annotations).To(BeEmpty())
+	tt.Expect(tt.desired(1).Annotations).To(BeEmpty())
+	tt.Expect(tt.cluster.Status.UpgradeCanary).To(BeNil())
+	tt.Expect(conditions.IsTrue(tt.cluster, anywherev1.WorkerUpgradeWavesCompletedCondition)).To(BeTrue())
+}
+
+func TestApplyUpgradePolicyCanaryStarted(t *testing.T) {
+	tt := newUpgradePolicyTest(t)
+	tt.cluster.Spec.UpgradePolicy.Canary = &anywherev1.UpgradeCanary{}
+
+	tt.Expect(tt.apply(
+		outdatedMachineDeployment("my-cluster-md-0", "md-0-1"),
+		outdatedMachineDeployment("my-cluster-md-1", "md-1-1"),
+	)).To(BeTrue())
+
+	tt.Expect(tt.cluster.Status.UpgradeCanary).NotTo(BeNil())
+	tt.Expect(tt.cluster.Status.UpgradeCanary.MachineDeployment).To(Equal("my-cluster-md-0"))
+	tt.Expect(tt.cluster.Status.UpgradeCanary.Hash).NotTo(BeEmpty())
+	tt.Expect(tt.cluster.Status.UpgradeCanary.Phase).To(Equal(anywherev1.UpgradeCanaryUpgrading))
+	tt.Expect(tt.desired(0).Annotations).To(Equal(map[string]string{anywherev1.UpgradeCanaryAnnotation: "true"}))
+	tt.Expect(tt.desired(1).Annotations).To(HaveKeyWithValue(clusterv1.PausedAnnotation, "true"))
+	tt.Expect(conditions.GetReason(tt.cluster, anywherev1.WorkerUpgradeWavesCompletedCondition)).To(Equal(anywherev1.UpgradeCanaryInProgressReason))
+}
+
+func TestApplyUpgradePolicyCanaryRollingMachineCreated(t *testing.T) {
+	tt := newUpgradePolicyTest(t)
+	tt.cluster.Spec.UpgradePolicy.Canary = &anywherev1.UpgradeCanary{}
+	tt.apply(outdatedMachineDeployment("my-cluster-md-0", "md-0-1"))
+
+	rolling := upgradedMachineDeployment("my-cluster-md-0", "md-0-2")
+	rolling.Status.UpdatedReplicas = 1
+	tt.Expect(tt.apply(rolling)).To(BeTrue())
+
+	tt.Expect(tt.desired(0).Annotations).To(HaveKeyWithValue(clusterv1.PausedAnnotation, "true"))
+	tt.Expect(tt.cluster.Status.UpgradeCanary.Phase).To(Equal(anywherev1.UpgradeCanaryUpgrading))
+}
+
+func TestApplyUpgradePolicyCanaryInPlaceNotPaused(t *testing.T) {
+	tt := newUpgradePolicyTest(t)
+	tt.cluster.Spec.UpgradePolicy.Canary = &anywherev1.UpgradeCanary{}
+	tt.desired(0).Spec.Strategy = &clusterv1.MachineDeploymentStrategy{Type: clusterv1.InPlaceMachineDeploymentStrategyType}
+	tt.apply(outdatedMachineDeployment("my-cluster-md-0", "md-0-1"))
+
+	inPlace := upgradedMachineDeployment("my-cluster-md-0", "md-0-2")
+	inPlace.Status.UpdatedReplicas = 1
+	tt.Expect(tt.apply(inPlace)).To(BeTrue())
+
+	tt.Expect(tt.desired(0).Annotations).NotTo(HaveKey(clusterv1.PausedAnnotation))
+}
+
+func TestApplyUpgradePolicyCanaryFailed(t *testing.T) {
+	tt := newUpgradePolicyTest(t)
+	tt.cluster.Spec.UpgradePolicy.Canary = &anywherev1.UpgradeCanary{}
+	tt.apply(outdatedMachineDeployment("my-cluster-md-0", "md-0-1"))
+	tt.cluster.Status.UpgradeCanary.Phase = anywherev1.UpgradeCanaryFailed
+	tt.cluster.Status.UpgradeCanary.NodeName = "node-1"
+	tt.cluster.Status.UpgradeCanary.Message = "job failed"
+
+	tt.Expect(tt.apply(
+		upgradedMachineDeployment("my-cluster-md-0", "md-0-2"),
+		outdatedMachineDeployment("my-cluster-md-1", "md-1-1"),
+	)).To(BeTrue())
+
+	tt.Expect(tt.desired(0).Annotations).To(HaveKeyWithValue(clusterv1.PausedAnnotation, "true"))
+	tt.Expect(tt.desired(1).Annotations).To(HaveKeyWithValue(clusterv1.PausedAnnotation, "true"))
+	tt.Expect(conditions.GetReason(tt.cluster, anywherev1.WorkerUpgradeWavesCompletedCondition)).To(Equal(anywherev1.UpgradeCanaryFailedReason))
+	tt.Expect(conditions.GetSeverity(tt.cluster, anywherev1.WorkerUpgradeWavesCompletedCondition)).To(HaveValue(Equal(clusterv1.ConditionSeverityError)))
+	tt.Expect(conditions.GetMessage(tt.cluster, anywherev1.WorkerUpgradeWavesCompletedCondition)).To(ContainSubstring("node-1"))
+}
+
+func TestApplyUpgradePolicyCanaryPassed(t *testing.T) {
+	tt := newUpgradePolicyTest(t)
+	tt.cluster.Spec.UpgradePolicy.Canary = &anywherev1.UpgradeCanary{}
+	tt.apply(outdatedMachineDeployment("my-cluster-md-0", "md-0-1"))
+	tt.cluster.Status.UpgradeCanary.Phase = anywherev1.UpgradeCanaryPassed
+
+	tt.Expect(tt.apply(
+		upgradedMachineDeployment("my-cluster-md-0", "md-0-2"),
+		outdatedMachineDeployment("my-cluster-md-1", "md-1-1"),
+	)).To(BeTrue())
+
+	// The canary group is done, the second wave can start without a new canary
+	tt.Expect(tt.desired(1).Annotations).NotTo(HaveKey(clusterv1.PausedAnnotation))
+	tt.Expect(tt.cluster.Status.UpgradeCanary.MachineDeployment).To(Equal("my-cluster-md-0"))
+	tt.Expect(conditions.GetReason(tt.cluster, anywherev1.WorkerUpgradeWavesCompletedCondition)).To(Equal(anywherev1.WorkerUpgradeWaveInProgressReason))
+}
@@ -89,7 +89,28 @@ func ReconcileWorkersForEKSA(ctx context.Context, log logr.Logger, c client.Clie
 		return controller.ResultWithRequeue(5 * time.Second), nil
 	}
 
-	return ReconcileWorkers(ctx, c, capiCluster, w)
+	if err := ApplyMaintenanceWindowToWorkers(ctx, log, c, cluster, capiCluster, w); err != nil {
+		return controller.Result{}, errors.Wrap(err, "applying maintenance window")
+	}
+
+	pending, err := ApplyUpgradePolicy(ctx, c, cluster, capiCluster, w)
+	if err != nil {
+		return controller.Result{}, errors.Wrap(err, "applying upgrade policy")
+	}
+
+	result, err := ReconcileWorkers(ctx, c, capiCluster, w)
+	if err != nil || result.Return() {
+		return result, err
+	}
+
+	if pending {
+		// Requeue until all the worker node groups have been upgraded, so the paused ones are
+		// released as soon as the previous waves are done.
+		log.Info("Worker nodes upgrade in progress, requeueing")
+		return controller.ResultWithRequeue(10 * time.Second), nil
+	}
+
+	return result, nil
 }
 
 // ReconcileWorkers orchestrates the worker node reconciliation logic.
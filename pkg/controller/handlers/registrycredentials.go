@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+// RegistryCredentialsToClusters returns a request handler that enqueues a reconcile request
+// for every EKS-A Cluster using an authenticated registry mirror when the registry credentials secret changes.
+func RegistryCredentialsToClusters(log logr.Logger, c client.Client) handler.MapFunc {
+	return func(ctx context.Context, o client.Object) []reconcile.Request {
+		if o.GetName() != config.RegistryAuthSecretName || o.GetNamespace() != constants.EksaSystemNamespace {
+			return nil
+		}
+
+		clusters := &anywherev1.ClusterList{}
+		if err := c.List(ctx, clusters); err != nil {
+			log.Error(err, "Listing clusters to enqueue after registry credentials change")
+			return nil
+		}
+
+		requests := []reconcile.Request{}
+		for _, cluster := range clusters.Items {
+			if !cluster.RegistryAuth() {
+				continue
+			}
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Namespace: cluster.Namespace,
+					Name:      cluster.Name,
+				},
+			})
+		}
+
+		return requests
+	}
+}
@@ -0,0 +1,57 @@
+package handlers_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller/handlers"
+)
+
+func TestRegistryCredentialsToClusters(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	withAuth := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "with-auth", Namespace: "default"},
+		Spec: anywherev1.ClusterSpec{
+			RegistryMirrorConfiguration: &anywherev1.RegistryMirrorConfiguration{Authenticate: true},
+		},
+	}
+	withoutAuth := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "without-auth", Namespace: "default"},
+		Spec: anywherev1.ClusterSpec{
+			RegistryMirrorConfiguration: &anywherev1.RegistryMirrorConfiguration{},
+		},
+	}
+	noMirror := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "no-mirror", Namespace: "default"},
+	}
+	scheme := runtime.NewScheme()
+	g.Expect(anywherev1.AddToScheme(scheme)).To(Succeed())
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(withAuth, withoutAuth, noMirror).Build()
+	handle := handlers.RegistryCredentialsToClusters(logr.New(logf.NullLogSink{}), c)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: config.RegistryAuthSecretName, Namespace: constants.EksaSystemNamespace},
+	}
+	g.Expect(handle(ctx, secret)).To(ConsistOf(reconcile.Request{
+		NamespacedName: types.NamespacedName{Name: "with-auth", Namespace: "default"},
+	}))
+
+	other := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: constants.EksaSystemNamespace},
+	}
+	g.Expect(handle(ctx, other)).To(BeEmpty())
+}
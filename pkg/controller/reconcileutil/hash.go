@@ -0,0 +1,13 @@
+package reconcileutil
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// ShortHash returns the first 16 hex characters of the sha256 of data. It's short enough to be used
+// in annotations, labels and object names, and it's used to detect when a config changes.
+func ShortHash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])[:16]
+}
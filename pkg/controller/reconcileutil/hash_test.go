@@ -0,0 +1,16 @@
+package reconcileutil_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/controller/reconcileutil"
+)
+
+func TestShortHash(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(reconcileutil.ShortHash([]byte("config"))).To(Equal("b79606fb3afea5bd"))
+	g.Expect(reconcileutil.ShortHash([]byte("config"))).NotTo(Equal(reconcileutil.ShortHash([]byte("other-config"))))
+}
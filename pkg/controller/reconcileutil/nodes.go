@@ -0,0 +1,157 @@
+package reconcileutil
+
+import (
+	"context"
+	"sort"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+// NodeUpdateProgress tracks a config applied in place to a set of nodes.
+type NodeUpdateProgress struct {
+	Total, Updated int
+	// Failed holds the names of the nodes whose updater pod failed.
+	Failed []string
+}
+
+// Add adds the progress of another set of nodes.
+func (p *NodeUpdateProgress) Add(o NodeUpdateProgress) {
+	p.Total += o.Total
+	p.Updated += o.Updated
+	p.Failed = append(p.Failed, o.Failed...)
+}
+
+// Done returns true if the config has been applied to all the nodes.
+func (p NodeUpdateProgress) Done() bool {
+	return p.Updated == p.Total && len(p.Failed) == 0
+}
+
+// NodeUpdater applies a config to nodes in place, running one updater pod per node in the
+// eksa-system namespace. Nodes record the hash of the config applied to them in an annotation,
+// so they are only updated again when the config changes.
+type NodeUpdater struct {
+	// Description names the config in logs and errors, like "registry credentials".
+	Description string
+	// Hash identifies the config to apply.
+	Hash string
+	// HashAnnotation is the node annotation with the hash of the config applied to the node.
+	HashAnnotation string
+	// HashLabel is the updater pod label with the hash of the config the pod applies.
+	HashLabel string
+	// PodName returns the name of the updater pod for a node.
+	PodName func(nodeName string) string
+	// Pod builds the updater pod for a node.
+	Pod func(node *corev1.Node) *corev1.Pod
+	// OneNodeAtATime updates the nodes one by one, control plane nodes first, for configs that
+	// disrupt the node while they are applied. The next node is only updated once the previous
+	// one is Ready again.
+	OneNodeAtATime bool
+}
+
+const controlPlaneNodeLabel = "node-role.kubernetes.io/control-plane"
+
+// UpdateNodes creates the updater pods for the nodes that don't have the config yet and annotates
+// the nodes once their pod succeeds. Failed pods are deleted, so they are retried in the next call.
+func (u NodeUpdater) UpdateNodes(ctx context.Context, log logr.Logger, c client.Client, nodes []corev1.Node) (NodeUpdateProgress, error) {
+	if u.OneNodeAtATime {
+		nodes = sortControlPlaneFirst(nodes)
+	}
+
+	progress := NodeUpdateProgress{Total: len(nodes)}
+	// busy is set when a node is being updated or hasn't recovered from its update yet.
+	busy := false
+	for i := range nodes {
+		node := &nodes[i]
+		if node.Annotations[u.HashAnnotation] == u.Hash {
+			progress.Updated++
+			if !isNodeReady(node) {
+				busy = true
+			}
+			continue
+		}
+
+		pod := &corev1.Pod{}
+		podKey := client.ObjectKey{Name: u.PodName(node.Name), Namespace: constants.EksaSystemNamespace}
+		err := c.Get(ctx, podKey, pod)
+		if apierrors.IsNotFound(err) {
+			if u.OneNodeAtATime && busy {
+				continue
+			}
+			log.Info("Creating updater pod", "config", u.Description, "node", node.Name)
+			pod = u.Pod(node)
+			if pod.Labels == nil {
+				pod.Labels = map[string]string{}
+			}
+			pod.Labels[u.HashLabel] = u.Hash
+			if err := c.Create(ctx, pod); err != nil {
+				return NodeUpdateProgress{}, errors.Wrapf(err, "creating %s updater pod for node %s", u.Description, node.Name)
+			}
+			busy = true
+			continue
+		}
+		if err != nil {
+			return NodeUpdateProgress{}, errors.Wrapf(err, "getting %s updater pod for node %s", u.Description, node.Name)
+		}
+
+		if pod.Labels[u.HashLabel] != u.Hash {
+			// The pod was created for a previous config, replace it.
+			if err := DeleteIgnoreNotFound(ctx, c, pod); err != nil {
+				return NodeUpdateProgress{}, err
+			}
+			continue
+		}
+
+		// Whatever the phase of the pod, the node was disrupted in this call. The next
+		// node waits until the following call to check this one is Ready again.
+		busy = true
+		switch pod.Status.Phase {
+		case corev1.PodSucceeded:
+			if err := SetAnnotation(ctx, c, node, u.HashAnnotation, u.Hash); err != nil {
+				return NodeUpdateProgress{}, err
+			}
+			if err := DeleteIgnoreNotFound(ctx, c, pod); err != nil {
+				return NodeUpdateProgress{}, err
+			}
+			progress.Updated++
+		case corev1.PodFailed:
+			log.Info("Updater pod failed, it will be retried", "config", u.Description, "node", node.Name)
+			progress.Failed = append(progress.Failed, node.Name)
+			if err := DeleteIgnoreNotFound(ctx, c, pod); err != nil {
+				return NodeUpdateProgress{}, err
+			}
+		}
+	}
+
+	sort.Strings(progress.Failed)
+	return progress, nil
+}
+
+// sortControlPlaneFirst returns a copy of the nodes with the control plane nodes first, sorted by name.
+func sortControlPlaneFirst(nodes []corev1.Node) []corev1.Node {
+	sorted := make([]corev1.Node, len(nodes))
+	copy(sorted, nodes)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		_, iCP := sorted[i].Labels[controlPlaneNodeLabel]
+		_, jCP := sorted[j].Labels[controlPlaneNodeLabel]
+		if iCP != jCP {
+			return iCP
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
+
+func isNodeReady(node *corev1.Node) bool {
+	for _, c := range node.Status.Conditions {
+		if c.Type == corev1.NodeReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
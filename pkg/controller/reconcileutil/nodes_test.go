@@ -0,0 +1,207 @@
+package reconcileutil_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller/reconcileutil"
+)
+
+const (
+	hashAnnotation = "anywhere.eks.amazonaws.com/test-config-hash"
+	hashLabel      = "anywhere.eks.amazonaws.com/test-config-hash"
+)
+
+func nodeUpdater(hash string) reconcileutil.NodeUpdater {
+	return reconcileutil.NodeUpdater{
+		Description:    "test config",
+		Hash:           hash,
+		HashAnnotation: hashAnnotation,
+		HashLabel:      hashLabel,
+		PodName: func(nodeName string) string {
+			return nodeName + "-updater"
+		},
+		Pod: func(node *corev1.Node) *corev1.Pod {
+			return &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      node.Name + "-updater",
+					Namespace: constants.EksaSystemNamespace,
+				},
+			}
+		},
+	}
+}
+
+func node(name, hash string) *corev1.Node {
+	n := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if hash != "" {
+		n.Annotations = map[string]string{hashAnnotation: hash}
+	}
+	return n
+}
+
+func updaterPod(nodeName, hash string, phase corev1.PodPhase) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      nodeName + "-updater",
+			Namespace: constants.EksaSystemNamespace,
+			Labels:    map[string]string{hashLabel: hash},
+		},
+		Status: corev1.PodStatus{Phase: phase},
+	}
+}
+
+func listNodes(g *WithT, c client.Client) []corev1.Node {
+	nodes := &corev1.NodeList{}
+	g.Expect(c.List(context.Background(), nodes)).To(Succeed())
+	return nodes.Items
+}
+
+func TestNodeUpdaterUpdateNodes(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	c := fake.NewClientBuilder().WithObjects(
+		node("node-1", "new"),
+		node("node-2", "old"),
+		node("node-3", ""),
+		node("node-4", "old"),
+		node("node-5", "old"),
+		updaterPod("node-3", "new", corev1.PodSucceeded),
+		updaterPod("node-4", "new", corev1.PodFailed),
+		updaterPod("node-5", "old", corev1.PodRunning),
+	).Build()
+
+	progress, err := nodeUpdater("new").UpdateNodes(ctx, test.NewNullLogger(), c, listNodes(g, c))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(progress).To(Equal(reconcileutil.NodeUpdateProgress{Total: 5, Updated: 2, Failed: []string{"node-4"}}))
+	g.Expect(progress.Done()).To(BeFalse())
+
+	// node-2 gets a new updater pod with the hash of the config.
+	pod := &corev1.Pod{}
+	g.Expect(c.Get(ctx, client.ObjectKey{Name: "node-2-updater", Namespace: constants.EksaSystemNamespace}, pod)).To(Succeed())
+	g.Expect(pod.Labels).To(HaveKeyWithValue(hashLabel, "new"))
+
+	// node-3 is annotated once its pod succeeds and the pod is removed.
+	n := &corev1.Node{}
+	g.Expect(c.Get(ctx, client.ObjectKey{Name: "node-3"}, n)).To(Succeed())
+	g.Expect(n.Annotations).To(HaveKeyWithValue(hashAnnotation, "new"))
+	g.Expect(apierrors.IsNotFound(c.Get(ctx, client.ObjectKey{Name: "node-3-updater", Namespace: constants.EksaSystemNamespace}, &corev1.Pod{}))).To(BeTrue())
+
+	// Failed pods and pods for previous configs are deleted to be recreated.
+	g.Expect(apierrors.IsNotFound(c.Get(ctx, client.ObjectKey{Name: "node-4-updater", Namespace: constants.EksaSystemNamespace}, &corev1.Pod{}))).To(BeTrue())
+	g.Expect(apierrors.IsNotFound(c.Get(ctx, client.ObjectKey{Name: "node-5-updater", Namespace: constants.EksaSystemNamespace}, &corev1.Pod{}))).To(BeTrue())
+}
+
+func TestNodeUpdaterUpdateNodesDone(t *testing.T) {
+	g := NewWithT(t)
+	c := fake.NewClientBuilder().WithObjects(node("node-1", "new"), node("node-2", "new")).Build()
+
+	progress, err := nodeUpdater("new").UpdateNodes(context.Background(), test.NewNullLogger(), c, listNodes(g, c))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(progress.Done()).To(BeTrue())
+}
+
+func readyNode(name, hash string, ready corev1.ConditionStatus, labels map[string]string) *corev1.Node {
+	n := node(name, hash)
+	n.Labels = labels
+	n.Status.Conditions = []corev1.NodeCondition{{Type: corev1.NodeReady, Status: ready}}
+	return n
+}
+
+func TestNodeUpdaterUpdateNodesOneNodeAtATime(t *testing.T) {
+	controlPlane := map[string]string{"node-role.kubernetes.io/control-plane": ""}
+	testCases := []struct {
+		name       string
+		objs       []client.Object
+		wantPods   []string
+		wantUpdate int
+	}{
+		{
+			name: "control plane first",
+			objs: []client.Object{
+				readyNode("a-worker", "old", corev1.ConditionTrue, nil),
+				readyNode("z-cp", "old", corev1.ConditionTrue, controlPlane),
+				readyNode("b-cp", "old", corev1.ConditionTrue, controlPlane),
+			},
+			wantPods: []string{"b-cp-updater"},
+		},
+		{
+			name: "waits for the running pod",
+			objs: []client.Object{
+				readyNode("cp", "old", corev1.ConditionTrue, controlPlane),
+				readyNode("worker", "old", corev1.ConditionTrue, nil),
+				updaterPod("cp", "new", corev1.PodRunning),
+			},
+			wantPods: []string{"cp-updater"},
+		},
+		{
+			name: "waits for the updated node to be ready",
+			objs: []client.Object{
+				readyNode("cp", "new", corev1.ConditionFalse, controlPlane),
+				readyNode("worker", "old", corev1.ConditionTrue, nil),
+			},
+			wantUpdate: 1,
+		},
+		{
+			name: "waits for the next call after a pod succeeds",
+			objs: []client.Object{
+				readyNode("cp", "old", corev1.ConditionTrue, controlPlane),
+				readyNode("worker", "old", corev1.ConditionTrue, nil),
+				updaterPod("cp", "new", corev1.PodSucceeded),
+			},
+			wantUpdate: 1,
+		},
+		{
+			name: "moves on once the updated node is ready",
+			objs: []client.Object{
+				readyNode("cp", "new", corev1.ConditionTrue, controlPlane),
+				readyNode("worker", "old", corev1.ConditionTrue, nil),
+			},
+			wantPods:   []string{"worker-updater"},
+			wantUpdate: 1,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			c := fake.NewClientBuilder().WithObjects(tt.objs...).Build()
+			u := nodeUpdater("new")
+			u.OneNodeAtATime = true
+
+			progress, err := u.UpdateNodes(ctx, test.NewNullLogger(), c, listNodes(g, c))
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(progress.Updated).To(Equal(tt.wantUpdate))
+
+			pods := &corev1.PodList{}
+			g.Expect(c.List(ctx, pods)).To(Succeed())
+			podNames := []string{}
+			for _, p := range pods.Items {
+				podNames = append(podNames, p.Name)
+			}
+			if tt.wantPods == nil {
+				g.Expect(podNames).To(BeEmpty())
+			} else {
+				g.Expect(podNames).To(ConsistOf(tt.wantPods))
+			}
+		})
+	}
+}
+
+func TestNodeUpdateProgressAdd(t *testing.T) {
+	g := NewWithT(t)
+	p := reconcileutil.NodeUpdateProgress{Total: 2, Updated: 1, Failed: []string{"node-1"}}
+	p.Add(reconcileutil.NodeUpdateProgress{Total: 3, Updated: 3})
+
+	g.Expect(p).To(Equal(reconcileutil.NodeUpdateProgress{Total: 5, Updated: 4, Failed: []string{"node-1"}}))
+}
@@ -0,0 +1,85 @@
+package reconcileutil
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// EnsureNamespace creates the namespace if it doesn't exist.
+func EnsureNamespace(ctx context.Context, c client.Client, name string) error {
+	ns := &corev1.Namespace{}
+	err := c.Get(ctx, client.ObjectKey{Name: name}, ns)
+	if apierrors.IsNotFound(err) {
+		ns.Name = name
+		if err := c.Create(ctx, ns); err != nil {
+			return errors.Wrapf(err, "creating namespace %s", name)
+		}
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "getting namespace %s", name)
+	}
+
+	return nil
+}
+
+// CreateOrUpdateSecret creates the secret or, if it already exists, replaces its labels and data.
+func CreateOrUpdateSecret(ctx context.Context, c client.Client, secret *corev1.Secret) error {
+	existing := &corev1.Secret{}
+	err := c.Get(ctx, client.ObjectKeyFromObject(secret), existing)
+	if apierrors.IsNotFound(err) {
+		return c.Create(ctx, secret)
+	}
+	if err != nil {
+		return err
+	}
+
+	existing.Labels = secret.Labels
+	existing.Data = secret.Data
+	return c.Update(ctx, existing)
+}
+
+// CreateOrUpdateConfigMap creates the config map or, if it already exists, replaces its labels and data.
+func CreateOrUpdateConfigMap(ctx context.Context, c client.Client, cm *corev1.ConfigMap) error {
+	existing := &corev1.ConfigMap{}
+	err := c.Get(ctx, client.ObjectKeyFromObject(cm), existing)
+	if apierrors.IsNotFound(err) {
+		return c.Create(ctx, cm)
+	}
+	if err != nil {
+		return err
+	}
+
+	existing.Labels = cm.Labels
+	existing.Data = cm.Data
+	return c.Update(ctx, existing)
+}
+
+// DeleteIgnoreNotFound deletes the object, ignoring the error if it doesn't exist.
+func DeleteIgnoreNotFound(ctx context.Context, c client.Client, obj client.Object, opts ...client.DeleteOption) error {
+	if err := c.Delete(ctx, obj, opts...); err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "deleting %s", obj.GetName())
+	}
+
+	return nil
+}
+
+// SetAnnotation sets an annotation on the object with a merge patch.
+func SetAnnotation(ctx context.Context, c client.Client, obj client.Object, key, value string) error {
+	patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[key] = value
+	obj.SetAnnotations(annotations)
+	if err := c.Patch(ctx, obj, patch); err != nil {
+		return errors.Wrapf(err, "annotating %s with %s", obj.GetName(), key)
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package reconcileutil_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws/eks-anywhere/pkg/controller/reconcileutil"
+)
+
+func TestEnsureNamespace(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	c := fake.NewClientBuilder().Build()
+
+	g.Expect(reconcileutil.EnsureNamespace(ctx, c, "eksa-system")).To(Succeed())
+	g.Expect(reconcileutil.EnsureNamespace(ctx, c, "eksa-system")).To(Succeed())
+	g.Expect(c.Get(ctx, client.ObjectKey{Name: "eksa-system"}, &corev1.Namespace{})).To(Succeed())
+}
+
+func TestCreateOrUpdateSecret(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	existing := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "creds",
+			Namespace:   "eksa-system",
+			Labels:      map[string]string{"hash": "a"},
+			Annotations: map[string]string{"keep": "true"},
+		},
+		Data: map[string][]byte{"old": []byte("value")},
+	}
+	c := fake.NewClientBuilder().WithObjects(existing).Build()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "creds",
+			Namespace: "eksa-system",
+			Labels:    map[string]string{"hash": "b"},
+		},
+		Data: map[string][]byte{"new": []byte("value")},
+	}
+	g.Expect(reconcileutil.CreateOrUpdateSecret(ctx, c, secret)).To(Succeed())
+
+	got := &corev1.Secret{}
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(secret), got)).To(Succeed())
+	g.Expect(got.Labels).To(Equal(secret.Labels))
+	g.Expect(got.Annotations).To(Equal(existing.Annotations))
+	g.Expect(got.Data).To(Equal(secret.Data))
+}
+
+func TestCreateOrUpdateSecretCreate(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	c := fake.NewClientBuilder().Build()
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "creds", Namespace: "eksa-system"},
+		Data:       map[string][]byte{"key": []byte("value")},
+	}
+
+	g.Expect(reconcileutil.CreateOrUpdateSecret(ctx, c, secret)).To(Succeed())
+	got := &corev1.Secret{}
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(secret), got)).To(Succeed())
+	g.Expect(got.Data).To(Equal(secret.Data))
+}
+
+func TestCreateOrUpdateConfigMap(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	c := fake.NewClientBuilder().Build()
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "config", Namespace: "eksa-system"},
+		Data:       map[string]string{"key": "a"},
+	}
+
+	g.Expect(reconcileutil.CreateOrUpdateConfigMap(ctx, c, cm.DeepCopy())).To(Succeed())
+	cm.Data["key"] = "b"
+	g.Expect(reconcileutil.CreateOrUpdateConfigMap(ctx, c, cm.DeepCopy())).To(Succeed())
+
+	got := &corev1.ConfigMap{}
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(cm), got)).To(Succeed())
+	g.Expect(got.Data).To(Equal(cm.Data))
+}
+
+func TestDeleteIgnoreNotFound(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "eksa-system"}}
+	c := fake.NewClientBuilder().WithObjects(pod).Build()
+
+	g.Expect(reconcileutil.DeleteIgnoreNotFound(ctx, c, pod)).To(Succeed())
+	g.Expect(apierrors.IsNotFound(c.Get(ctx, client.ObjectKeyFromObject(pod), &corev1.Pod{}))).To(BeTrue())
+	g.Expect(reconcileutil.DeleteIgnoreNotFound(ctx, c, pod)).To(Succeed())
+}
+
+func TestSetAnnotation(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}}
+	c := fake.NewClientBuilder().WithObjects(node).Build()
+
+	g.Expect(reconcileutil.SetAnnotation(ctx, c, node, "hash", "a")).To(Succeed())
+	got := &corev1.Node{}
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(node), got)).To(Succeed())
+	g.Expect(got.Annotations).To(HaveKeyWithValue("hash", "a"))
+}
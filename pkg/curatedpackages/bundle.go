@@ -123,6 +123,30 @@ func (b *BundleReader) UpgradeBundle(ctx context.Context, controller *packagesv1
 	return nil
 }
 
+// ActivateBundle applies a package bundle to the cluster and makes it the active bundle of the
+// package bundle controller. It's used to install bundles imported from a local archive, since
+// an offline controller can't discover them from the registry.
+func (b *BundleReader) ActivateBundle(ctx context.Context, bundle *packagesv1.PackageBundle) error {
+	bundle.Namespace = constants.EksaPackagesName
+	bundle.ResourceVersion = ""
+	bundle.UID = ""
+	bundleYaml, err := yaml.Marshal(bundle)
+	if err != nil {
+		return fmt.Errorf("marshalling package bundle: %v", err)
+	}
+	params := []string{"apply", "-f", "-", "--kubeconfig", b.kubeConfig}
+	if _, err := b.kubectl.ExecuteFromYaml(ctx, bundleYaml, params...); err != nil {
+		return fmt.Errorf("applying package bundle %s: %v", bundle.Name, err)
+	}
+
+	controller, err := b.GetActiveController(ctx)
+	if err != nil {
+		return err
+	}
+
+	return b.UpgradeBundle(ctx, controller, bundle.Name)
+}
+
 func GetPackageBundleRef(vb releasev1.VersionsBundle) (string, error) {
 	packageController := vb.PackageController
 	// Use package controller registry to fetch packageBundles.
@@ -178,6 +178,41 @@ func TestUpgradeBundleFails(t *testing.T) {
 	tt.Expect(err).NotTo(BeNil())
 }
 
+func TestActivateBundleSucceeds(t *testing.T) {
+	tt := newBundleTest(t)
+	params := []string{"apply", "-f", "-", "--kubeconfig", tt.kubeConfig}
+	tt.packageBundle.Name = "v1-21-1001"
+	expectedBundle := tt.packageBundle.DeepCopy()
+	expectedBundle.Namespace = "eksa-packages"
+	bundleYaml, err := yaml.Marshal(expectedBundle)
+	tt.Expect(err).To(BeNil())
+	expectedCtrl := tt.bundleCtrl.DeepCopy()
+	expectedCtrl.Spec.ActiveBundle = "v1-21-1001"
+	ctrlYaml, err := yaml.Marshal(expectedCtrl)
+	tt.Expect(err).To(BeNil())
+	ctrlParams := []string{"get", "packageBundleController", "-o", "json", "--kubeconfig", tt.kubeConfig, "--namespace", "eksa-packages", tt.cluster}
+	gomock.InOrder(
+		tt.kubectl.EXPECT().ExecuteFromYaml(tt.ctx, bundleYaml, params).Return(bytes.Buffer{}, nil),
+		tt.kubectl.EXPECT().ExecuteCommand(tt.ctx, ctrlParams).Return(convertJsonToBytes(tt.bundleCtrl), nil),
+		tt.kubectl.EXPECT().ExecuteFromYaml(tt.ctx, ctrlYaml, params).Return(bytes.Buffer{}, nil),
+	)
+
+	tt.Command = curatedpackages.NewBundleReader(tt.kubeConfig, tt.cluster, tt.kubectl, tt.bundleManager, tt.registry)
+
+	tt.Expect(tt.Command.ActivateBundle(tt.ctx, tt.packageBundle)).To(Succeed())
+}
+
+func TestActivateBundleApplyFails(t *testing.T) {
+	tt := newBundleTest(t)
+	tt.packageBundle.Name = "v1-21-1001"
+	tt.kubectl.EXPECT().ExecuteFromYaml(tt.ctx, gomock.Any(), gomock.Any()).Return(bytes.Buffer{}, errors.New("unable to apply yaml"))
+
+	tt.Command = curatedpackages.NewBundleReader(tt.kubeConfig, tt.cluster, tt.kubectl, tt.bundleManager, tt.registry)
+
+	err := tt.Command.ActivateBundle(tt.ctx, tt.packageBundle)
+	tt.Expect(err).To(MatchError("applying package bundle v1-21-1001: unable to apply yaml"))
+}
+
 func convertJsonToBytes(obj interface{}) bytes.Buffer {
 	b, _ := json.Marshal(obj)
 	return *bytes.NewBuffer(b)
@@ -0,0 +1,132 @@
+package curatedpackages
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+)
+
+// PackageDependencies returns the names of the bundle packages a package depends on.
+// The dependencies are read from the bundle version matching the given version, or from
+// the first version of the package when no version is given.
+func PackageDependencies(bundle *packagesv1.PackageBundle, packageName, version string) []string {
+	if bundle == nil {
+		return nil
+	}
+	for _, bp := range bundle.Spec.Packages {
+		if !strings.EqualFold(bp.Name, packageName) {
+			continue
+		}
+		for _, v := range bp.Source.Versions {
+			if version == "" || v.Name == version {
+				return v.Dependencies
+			}
+		}
+	}
+
+	return nil
+}
+
+// SortByDependencies groups packages in install waves, so every package is installed
+// after the packages it depends on. Dependencies not present in the list are assumed to
+// be installed already and don't affect the order.
+func SortByDependencies(bundle *packagesv1.PackageBundle, packages []packagesv1.Package) ([][]packagesv1.Package, error) {
+	byBundleName := map[string][]int{}
+	for i, p := range packages {
+		name := strings.ToLower(p.Spec.PackageName)
+		byBundleName[name] = append(byBundleName[name], i)
+	}
+
+	pending := map[int]map[int]struct{}{}
+	for i, p := range packages {
+		pending[i] = map[int]struct{}{}
+		for _, dep := range PackageDependencies(bundle, p.Spec.PackageName, p.Spec.PackageVersion) {
+			for _, j := range byBundleName[strings.ToLower(dep)] {
+				if j != i {
+					pending[i][j] = struct{}{}
+				}
+			}
+		}
+	}
+
+	var waves [][]packagesv1.Package
+	for len(pending) > 0 {
+		var ready []int
+		for i, deps := range pending {
+			if len(deps) == 0 {
+				ready = append(ready, i)
+			}
+		}
+		if len(ready) == 0 {
+			return nil, fmt.Errorf("circular dependency between packages %s", strings.Join(pendingNames(packages, pending), ", "))
+		}
+
+		sort.Ints(ready)
+		wave := make([]packagesv1.Package, 0, len(ready))
+		for _, i := range ready {
+			wave = append(wave, packages[i])
+			delete(pending, i)
+		}
+		for _, deps := range pending {
+			for _, i := range ready {
+				delete(deps, i)
+			}
+		}
+		waves = append(waves, wave)
+	}
+
+	return waves, nil
+}
+
+// Dependents returns the installed packages that depend on the packages being deleted,
+// indexed by the name of the package being deleted. A dependency is only considered broken
+// when no other installed package of the same bundle package remains.
+func Dependents(bundle *packagesv1.PackageBundle, installed []packagesv1.Package, deleting []string) map[string][]string {
+	deleted := map[string]struct{}{}
+	for _, name := range deleting {
+		deleted[name] = struct{}{}
+	}
+
+	remaining := map[string]struct{}{}
+	for _, p := range installed {
+		if _, ok := deleted[p.Name]; !ok {
+			remaining[strings.ToLower(p.Spec.PackageName)] = struct{}{}
+		}
+	}
+
+	dependents := map[string][]string{}
+	for _, d := range installed {
+		if _, ok := deleted[d.Name]; !ok {
+			continue
+		}
+		bundleName := strings.ToLower(d.Spec.PackageName)
+		if _, ok := remaining[bundleName]; ok {
+			continue
+		}
+		for _, p := range installed {
+			if _, ok := deleted[p.Name]; ok {
+				continue
+			}
+			for _, dep := range PackageDependencies(bundle, p.Spec.PackageName, p.Spec.PackageVersion) {
+				if strings.EqualFold(dep, bundleName) {
+					dependents[d.Name] = append(dependents[d.Name], p.Name)
+					break
+				}
+			}
+		}
+	}
+
+	return dependents
+}
+
+func pendingNames(packages []packagesv1.Package, pending map[int]map[int]struct{}) []string {
+	names := make([]string, 0, len(pending))
+	for i := range pending {
+		names = append(names, packages[i].Name)
+	}
+	sort.Strings(names)
+
+	return names
+}
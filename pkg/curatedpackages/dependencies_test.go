@@ -0,0 +1,139 @@
+package curatedpackages_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/curatedpackages"
+)
+
+func dependenciesBundle() *packagesv1.PackageBundle {
+	return &packagesv1.PackageBundle{
+		Spec: packagesv1.PackageBundleSpec{
+			Packages: []packagesv1.BundlePackage{
+				bundlePackage("cert-manager"),
+				bundlePackage("adot", "cert-manager"),
+				bundlePackage("harbor", "cert-manager", "metallb"),
+				bundlePackage("metallb"),
+				bundlePackage("emissary"),
+			},
+		},
+	}
+}
+
+func bundlePackage(name string, dependencies ...string) packagesv1.BundlePackage {
+	return packagesv1.BundlePackage{
+		Name: name,
+		Source: packagesv1.BundlePackageSource{
+			Versions: []packagesv1.SourceVersion{
+				{Name: "0.0.2", Dependencies: dependencies},
+				{Name: "0.0.1"},
+			},
+		},
+	}
+}
+
+func pkg(name, packageName string) packagesv1.Package {
+	return packagesv1.Package{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "eksa-packages-test"},
+		Spec:       packagesv1.PackageSpec{PackageName: packageName},
+	}
+}
+
+func names(waves [][]packagesv1.Package) [][]string {
+	n := make([][]string, 0, len(waves))
+	for _, wave := range waves {
+		w := make([]string, 0, len(wave))
+		for _, p := range wave {
+			w = append(w, p.Name)
+		}
+		n = append(n, w)
+	}
+	return n
+}
+
+func TestPackageDependencies(t *testing.T) {
+	g := NewWithT(t)
+	bundle := dependenciesBundle()
+
+	g.Expect(curatedpackages.PackageDependencies(bundle, "Harbor", "")).To(ConsistOf("cert-manager", "metallb"))
+	g.Expect(curatedpackages.PackageDependencies(bundle, "harbor", "0.0.1")).To(BeEmpty())
+	g.Expect(curatedpackages.PackageDependencies(bundle, "unknown", "")).To(BeEmpty())
+	g.Expect(curatedpackages.PackageDependencies(nil, "harbor", "")).To(BeEmpty())
+}
+
+func TestSortByDependencies(t *testing.T) {
+	g := NewWithT(t)
+	packages := []packagesv1.Package{
+		pkg("my-harbor", "harbor"),
+		pkg("my-adot", "adot"),
+		pkg("my-emissary", "emissary"),
+		pkg("my-cert-manager", "cert-manager"),
+	}
+
+	waves, err := curatedpackages.SortByDependencies(dependenciesBundle(), packages)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(names(waves)).To(Equal([][]string{
+		{"my-emissary", "my-cert-manager"},
+		{"my-harbor", "my-adot"},
+	}))
+}
+
+func TestSortByDependenciesWithoutBundle(t *testing.T) {
+	g := NewWithT(t)
+	packages := []packagesv1.Package{
+		pkg("my-harbor", "harbor"),
+		pkg("my-cert-manager", "cert-manager"),
+	}
+
+	waves, err := curatedpackages.SortByDependencies(nil, packages)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(names(waves)).To(Equal([][]string{{"my-harbor", "my-cert-manager"}}))
+}
+
+func TestSortByDependenciesCircular(t *testing.T) {
+	g := NewWithT(t)
+	bundle := &packagesv1.PackageBundle{
+		Spec: packagesv1.PackageBundleSpec{
+			Packages: []packagesv1.BundlePackage{
+				bundlePackage("a", "b"),
+				bundlePackage("b", "a"),
+				bundlePackage("c"),
+			},
+		},
+	}
+	packages := []packagesv1.Package{pkg("my-a", "a"), pkg("my-b", "b"), pkg("my-c", "c")}
+
+	_, err := curatedpackages.SortByDependencies(bundle, packages)
+	g.Expect(err).To(MatchError("circular dependency between packages my-a, my-b"))
+}
+
+func TestDependents(t *testing.T) {
+	g := NewWithT(t)
+	installed := []packagesv1.Package{
+		pkg("my-cert-manager", "cert-manager"),
+		pkg("my-metallb", "metallb"),
+		pkg("my-harbor", "harbor"),
+		pkg("my-adot", "adot"),
+	}
+
+	g.Expect(curatedpackages.Dependents(dependenciesBundle(), installed, []string{"my-cert-manager", "my-metallb"})).To(Equal(map[string][]string{
+		"my-cert-manager": {"my-harbor", "my-adot"},
+		"my-metallb":      {"my-harbor"},
+	}))
+	g.Expect(curatedpackages.Dependents(dependenciesBundle(), installed, []string{"my-harbor", "my-metallb"})).To(BeEmpty())
+}
+
+func TestDependentsWithAnotherInstance(t *testing.T) {
+	g := NewWithT(t)
+	installed := []packagesv1.Package{
+		pkg("my-cert-manager", "cert-manager"),
+		pkg("other-cert-manager", "cert-manager"),
+		pkg("my-adot", "adot"),
+	}
+
+	g.Expect(curatedpackages.Dependents(dependenciesBundle(), installed, []string{"my-cert-manager"})).To(BeEmpty())
+}
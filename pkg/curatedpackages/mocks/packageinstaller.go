@@ -8,6 +8,7 @@ import (
 	context "context"
 	reflect "reflect"
 
+	v1alpha1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -85,16 +86,16 @@ func (m *MockPackageHandler) EXPECT() *MockPackageHandlerMockRecorder {
 	return m.recorder
 }
 
-// CreatePackages mocks base method.
-func (m *MockPackageHandler) CreatePackages(ctx context.Context, fileName, kubeConfig string) error {
+// CreatePackagesInOrder mocks base method.
+func (m *MockPackageHandler) CreatePackagesInOrder(ctx context.Context, fileName, kubeConfig string, bundle *v1alpha1.PackageBundle) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreatePackages", ctx, fileName, kubeConfig)
+	ret := m.ctrl.Call(m, "CreatePackagesInOrder", ctx, fileName, kubeConfig, bundle)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// CreatePackages indicates an expected call of CreatePackages.
-func (mr *MockPackageHandlerMockRecorder) CreatePackages(ctx, fileName, kubeConfig interface{}) *gomock.Call {
+// CreatePackagesInOrder indicates an expected call of CreatePackagesInOrder.
+func (mr *MockPackageHandlerMockRecorder) CreatePackagesInOrder(ctx, fileName, kubeConfig, bundle interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePackages", reflect.TypeOf((*MockPackageHandler)(nil).CreatePackages), ctx, fileName, kubeConfig)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePackagesInOrder", reflect.TypeOf((*MockPackageHandler)(nil).CreatePackagesInOrder), ctx, fileName, kubeConfig, bundle)
 }
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/curatedpackages/schema.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	v1alpha1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSchemaFetcher is a mock of SchemaFetcher interface.
+type MockSchemaFetcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockSchemaFetcherMockRecorder
+}
+
+// MockSchemaFetcherMockRecorder is the mock recorder for MockSchemaFetcher.
+type MockSchemaFetcherMockRecorder struct {
+	mock *MockSchemaFetcher
+}
+
+// NewMockSchemaFetcher creates a new mock instance.
+func NewMockSchemaFetcher(ctrl *gomock.Controller) *MockSchemaFetcher {
+	mock := &MockSchemaFetcher{ctrl: ctrl}
+	mock.recorder = &MockSchemaFetcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSchemaFetcher) EXPECT() *MockSchemaFetcherMockRecorder {
+	return m.recorder
+}
+
+// FetchSchema mocks base method.
+func (m *MockSchemaFetcher) FetchSchema(ctx context.Context, bp *v1alpha1.BundlePackage, version string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchSchema", ctx, bp, version)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchSchema indicates an expected call of FetchSchema.
+func (mr *MockSchemaFetcherMockRecorder) FetchSchema(ctx, bp, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchSchema", reflect.TypeOf((*MockSchemaFetcher)(nil).FetchSchema), ctx, bp, version)
+}
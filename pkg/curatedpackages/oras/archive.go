@@ -0,0 +1,150 @@
+package oras
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content/oci"
+	"sigs.k8s.io/yaml"
+
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const archiveBundleFile = "bundle.yaml"
+
+// RepositoryBuilder returns the target to push the artifacts of a repository to.
+type RepositoryBuilder func(repository string) (oras.Target, error)
+
+// Archive is a folder with the charts and images of a package bundle in an OCI image layout,
+// together with the bundle itself. It moves curated packages to environments without access
+// to the package registries.
+type Archive struct {
+	dir   string
+	store *oci.Store
+}
+
+// NewArchive returns an Archive backed by a folder, creating it if it doesn't exist.
+func NewArchive(dir string) (*Archive, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("creating packages archive folder: %v", err)
+	}
+
+	store, err := oci.New(dir)
+	if err != nil {
+		return nil, fmt.Errorf("opening packages archive: %v", err)
+	}
+
+	return &Archive{dir: dir, store: store}, nil
+}
+
+// Target returns the target to copy artifacts into the archive. Artifacts must be tagged
+// with the reference returned by ArchiveRef.
+func (a *Archive) Target() oras.Target {
+	return a.store
+}
+
+// ArchiveRef returns the reference of an artifact in an archive. It keeps the repository, so
+// artifacts are pushed to the same repository when imported into a registry.
+func ArchiveRef(repository, reference string) string {
+	if _, err := digest.Parse(reference); err == nil {
+		return repository + "@" + reference
+	}
+
+	return repository + ":" + reference
+}
+
+func parseArchiveRef(ref string) (repository, reference string, ok bool) {
+	if i := strings.LastIndex(ref, "@"); i > 0 {
+		return ref[:i], ref[i+1:], true
+	}
+	if i := strings.LastIndex(ref, ":"); i > 0 {
+		return ref[:i], ref[i+1:], true
+	}
+
+	return "", "", false
+}
+
+// WriteBundle stores the package bundle in the archive.
+func (a *Archive) WriteBundle(bundle *packagesv1.PackageBundle) error {
+	content, err := yaml.Marshal(bundle)
+	if err != nil {
+		return fmt.Errorf("marshalling package bundle: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(a.dir, archiveBundleFile), content, 0o644); err != nil {
+		return fmt.Errorf("writing package bundle to archive: %v", err)
+	}
+
+	return nil
+}
+
+// ReadBundle returns the package bundle stored in the archive.
+func (a *Archive) ReadBundle() (*packagesv1.PackageBundle, error) {
+	content, err := os.ReadFile(filepath.Join(a.dir, archiveBundleFile))
+	if err != nil {
+		return nil, fmt.Errorf("reading package bundle from archive: %v", err)
+	}
+
+	bundle := &packagesv1.PackageBundle{}
+	if err := yaml.Unmarshal(content, bundle); err != nil {
+		return nil, fmt.Errorf("parsing package bundle from archive: %v", err)
+	}
+
+	return bundle, nil
+}
+
+// Artifacts returns the references of the artifacts in the archive.
+func (a *Archive) Artifacts(ctx context.Context) ([]string, error) {
+	var refs []string
+	err := a.store.Tags(ctx, "", func(tags []string) error {
+		for _, t := range tags {
+			// The store also tags every manifest with its own digest.
+			if _, err := digest.Parse(t); err == nil {
+				continue
+			}
+			refs = append(refs, t)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing packages archive artifacts: %v", err)
+	}
+	sort.Strings(refs)
+
+	return refs, nil
+}
+
+// Push copies every artifact in the archive to the repository with the same name in a
+// registry, keeping its tag or digest.
+func (a *Archive) Push(ctx context.Context, repository RepositoryBuilder) error {
+	refs, err := a.Artifacts(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, ref := range refs {
+		repo, reference, ok := parseArchiveRef(ref)
+		if !ok {
+			return fmt.Errorf("invalid packages archive reference %s", ref)
+		}
+
+		dst, err := repository(repo)
+		if err != nil {
+			return err
+		}
+
+		logger.V(0).Info("Importing artifact", "repository", repo, "reference", reference)
+		if _, err := oras.Copy(ctx, a.store, ref, dst, reference, oras.DefaultCopyOptions); err != nil {
+			return fmt.Errorf("importing %s: %v", ref, err)
+		}
+	}
+
+	return nil
+}
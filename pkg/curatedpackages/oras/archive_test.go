@@ -0,0 +1,84 @@
+package oras_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	orasv2 "oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content/memory"
+
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/curatedpackages/oras"
+)
+
+const chartDigest = "sha256:0a6a0cd4c4c9e3a1d4b8a6f2a4bd1e1d4bd4d0b4c1d7a1f5bd6e0f0f9f1c7a1a"
+
+func TestArchiveRef(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(oras.ArchiveRef("eks-anywhere-packages/harbor", "v2.5.1")).To(Equal("eks-anywhere-packages/harbor:v2.5.1"))
+	g.Expect(oras.ArchiveRef("eks-anywhere-packages/harbor", chartDigest)).To(Equal("eks-anywhere-packages/harbor@" + chartDigest))
+}
+
+func TestArchiveBundle(t *testing.T) {
+	g := NewWithT(t)
+	archive, err := oras.NewArchive(t.TempDir())
+	g.Expect(err).NotTo(HaveOccurred())
+
+	bundle := &packagesv1.PackageBundle{
+		ObjectMeta: metav1.ObjectMeta{Name: "v1-28-1001"},
+		Spec: packagesv1.PackageBundleSpec{
+			Packages: []packagesv1.BundlePackage{{Name: "harbor"}},
+		},
+	}
+	g.Expect(archive.WriteBundle(bundle)).To(Succeed())
+
+	got, err := archive.ReadBundle()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(got.Name).To(Equal("v1-28-1001"))
+	g.Expect(got.Spec.Packages).To(HaveLen(1))
+}
+
+func TestArchiveReadBundleMissing(t *testing.T) {
+	g := NewWithT(t)
+	archive, err := oras.NewArchive(t.TempDir())
+	g.Expect(err).NotTo(HaveOccurred())
+
+	_, err = archive.ReadBundle()
+	g.Expect(err).To(MatchError(ContainSubstring("reading package bundle from archive")))
+}
+
+func TestArchivePush(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	archive, err := oras.NewArchive(t.TempDir())
+	g.Expect(err).NotTo(HaveOccurred())
+
+	desc, err := orasv2.PackManifest(ctx, archive.Target(), orasv2.PackManifestVersion1_1_RC4, "application/vnd.test", orasv2.PackManifestOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(archive.Target().Tag(ctx, desc, oras.ArchiveRef("eks-anywhere-packages/harbor", "v2.5.1"))).To(Succeed())
+	g.Expect(archive.Target().Tag(ctx, desc, oras.ArchiveRef("eks-anywhere-packages/harbor", desc.Digest.String()))).To(Succeed())
+
+	refs, err := archive.Artifacts(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(refs).To(ConsistOf(
+		"eks-anywhere-packages/harbor:v2.5.1",
+		"eks-anywhere-packages/harbor@"+desc.Digest.String(),
+	))
+
+	registry := map[string]*memory.Store{}
+	err = archive.Push(ctx, func(repository string) (orasv2.Target, error) {
+		if _, ok := registry[repository]; !ok {
+			registry[repository] = memory.New()
+		}
+		return registry[repository], nil
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(registry).To(HaveKey("eks-anywhere-packages/harbor"))
+
+	pushed, err := registry["eks-anywhere-packages/harbor"].Resolve(ctx, "v2.5.1")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(pushed.Digest).To(Equal(desc.Digest))
+}
@@ -0,0 +1,84 @@
+package oras
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+	"github.com/aws/eks-anywhere-packages/pkg/artifacts"
+	"github.com/aws/eks-anywhere/pkg/curatedpackages"
+)
+
+const chartSchemaFile = "values.schema.json"
+
+// SchemaFetcher fetches the JSON schema of package configurations. The schema is read from the
+// package bundle and, when the bundle doesn't include it, from the values schema of the package chart.
+type SchemaFetcher struct {
+	puller artifacts.Puller
+}
+
+var _ curatedpackages.SchemaFetcher = (*SchemaFetcher)(nil)
+
+// NewSchemaFetcher returns a new SchemaFetcher.
+func NewSchemaFetcher(puller artifacts.Puller) *SchemaFetcher {
+	return &SchemaFetcher{
+		puller: puller,
+	}
+}
+
+// FetchSchema returns the JSON schema of the configuration of a package version.
+// It returns an empty schema if neither the bundle nor the chart provide one.
+func (f *SchemaFetcher) FetchSchema(ctx context.Context, bp *packagesv1.BundlePackage, version string) ([]byte, error) {
+	v, err := curatedpackages.BundlePackageVersion(bp, version)
+	if err != nil {
+		return nil, err
+	}
+
+	if v.Schema != "" {
+		return bp.GetJsonSchema(v)
+	}
+
+	if bp.Source.Registry == "" || v.Digest == "" {
+		return nil, nil
+	}
+
+	chart := fmt.Sprintf("%s/%s@%s", bp.Source.Registry, bp.Source.Repository, v.Digest)
+	data, err := f.puller.Pull(ctx, chart, "")
+	if err != nil {
+		return nil, fmt.Errorf("pulling chart %s: %v", chart, err)
+	}
+
+	return chartSchema(data)
+}
+
+// chartSchema reads the values schema from the top level directory of a packaged chart.
+func chartSchema(chart []byte) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(chart))
+	if err != nil {
+		return nil, fmt.Errorf("reading chart: %v", err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading chart: %v", err)
+		}
+
+		name := path.Clean(header.Name)
+		if path.Base(name) == chartSchemaFile && strings.Count(name, "/") == 1 {
+			return io.ReadAll(tr)
+		}
+	}
+}
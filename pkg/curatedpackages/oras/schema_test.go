@@ -0,0 +1,120 @@
+package oras_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+	"github.com/aws/eks-anywhere-packages/pkg/artifacts/mocks"
+	"github.com/aws/eks-anywhere/pkg/curatedpackages/oras"
+)
+
+const (
+	schema = `{"type":"object"}`
+	// bundleSchema is the gzipped and base64 encoded schema as stored in package bundles.
+	bundleSchema = "H4sIAAAAAAAC/6tWKqksSFWyUspPykpNLlGqBQBEh0ZZEQAAAA=="
+)
+
+func chart(t *testing.T, files map[string]string) []byte {
+	buf := &bytes.Buffer{}
+	gz := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o600, Size: int64(len(content))}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func bundlePackage(version packagesv1.SourceVersion) *packagesv1.BundlePackage {
+	return &packagesv1.BundlePackage{
+		Name: "hello-eks-anywhere",
+		Source: packagesv1.BundlePackageSource{
+			Registry:   "public.ecr.aws/eks-anywhere",
+			Repository: "hello-eks-anywhere",
+			Versions:   []packagesv1.SourceVersion{version},
+		},
+	}
+}
+
+func TestSchemaFetcherFromBundle(t *testing.T) {
+	g := NewWithT(t)
+	puller := mocks.NewMockPuller(gomock.NewController(t))
+	f := oras.NewSchemaFetcher(puller)
+
+	got, err := f.FetchSchema(context.Background(), bundlePackage(packagesv1.SourceVersion{Name: "0.1.0", Schema: bundleSchema}), "")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(got)).To(Equal(schema))
+}
+
+func TestSchemaFetcherFromChart(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	puller := mocks.NewMockPuller(gomock.NewController(t))
+	f := oras.NewSchemaFetcher(puller)
+
+	puller.EXPECT().Pull(ctx, "public.ecr.aws/eks-anywhere/hello-eks-anywhere@sha256:abc", "").Return(chart(t, map[string]string{
+		"hello-eks-anywhere/Chart.yaml":                      "name: hello-eks-anywhere",
+		"hello-eks-anywhere/templates/deployment.yaml":       "kind: Deployment",
+		"hello-eks-anywhere/charts/redis/values.schema.json": `{"type":"array"}`,
+		"hello-eks-anywhere/values.schema.json":              schema,
+	}), nil)
+
+	got, err := f.FetchSchema(ctx, bundlePackage(packagesv1.SourceVersion{Name: "0.1.0", Digest: "sha256:abc"}), "0.1.0")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(got)).To(Equal(schema))
+}
+
+func TestSchemaFetcherChartWithoutSchema(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	puller := mocks.NewMockPuller(gomock.NewController(t))
+	f := oras.NewSchemaFetcher(puller)
+
+	puller.EXPECT().Pull(ctx, gomock.Any(), "").Return(chart(t, map[string]string{
+		"hello-eks-anywhere/Chart.yaml": "name: hello-eks-anywhere",
+	}), nil)
+
+	got, err := f.FetchSchema(ctx, bundlePackage(packagesv1.SourceVersion{Name: "0.1.0", Digest: "sha256:abc"}), "")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(got).To(BeEmpty())
+}
+
+func TestSchemaFetcherPullError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	puller := mocks.NewMockPuller(gomock.NewController(t))
+	f := oras.NewSchemaFetcher(puller)
+
+	puller.EXPECT().Pull(ctx, gomock.Any(), "").Return(nil, errors.New("unauthorized"))
+
+	_, err := f.FetchSchema(ctx, bundlePackage(packagesv1.SourceVersion{Name: "0.1.0", Digest: "sha256:abc"}), "")
+	g.Expect(err).To(MatchError("pulling chart public.ecr.aws/eks-anywhere/hello-eks-anywhere@sha256:abc: unauthorized"))
+}
+
+func TestSchemaFetcherUnknownVersion(t *testing.T) {
+	g := NewWithT(t)
+	puller := mocks.NewMockPuller(gomock.NewController(t))
+	f := oras.NewSchemaFetcher(puller)
+
+	_, err := f.FetchSchema(context.Background(), bundlePackage(packagesv1.SourceVersion{Name: "0.1.0"}), "0.2.0")
+	g.Expect(err).To(MatchError(ContainSubstring("version 0.2.0 of package hello-eks-anywhere not found")))
+}
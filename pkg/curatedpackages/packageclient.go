@@ -1,23 +1,35 @@
 package curatedpackages
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"os"
+	"sort"
 	"strings"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	yamlutil "k8s.io/apimachinery/pkg/util/yaml"
 	"sigs.k8s.io/yaml"
 
 	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/retrier"
 	"github.com/aws/eks-anywhere/pkg/templater"
 )
 
 const (
 	CustomName = "generated-"
 	kind       = "Package"
+
+	packageResource       = "package"
+	packageInstallTimeout = 10 * time.Minute
+	packageInstallBackoff = 5 * time.Second
 )
 
 type PackageClientOpt func(*PackageClient)
@@ -27,11 +39,14 @@ type PackageClient struct {
 	customPackages []string
 	kubectl        KubectlRunner
 	customConfigs  []string
+	retrier        *retrier.Retrier
+	schemaFetcher  SchemaFetcher
 }
 
 func NewPackageClient(kubectl KubectlRunner, options ...PackageClientOpt) *PackageClient {
 	pc := &PackageClient{
 		kubectl: kubectl,
+		retrier: retrier.New(packageInstallTimeout, retrier.WithRetryPolicy(retrier.BackOffPolicy(packageInstallBackoff))),
 	}
 	for _, o := range options {
 		o(pc)
@@ -124,6 +139,10 @@ func (pc *PackageClient) InstallPackage(ctx context.Context, bp *packagesv1.Bund
 	}
 
 	p := convertBundlePackageToPackage(*bp, customName, clusterName, pc.bundle.APIVersion, configString)
+	if err := pc.ValidatePackageConfigs(ctx, []packagesv1.Package{p}); err != nil {
+		return err
+	}
+
 	displayPackage := NewDisplayablePackage(&p)
 	params := []string{"create", "-f", "-", "--kubeconfig", kubeConfig}
 	packageYaml, err := yaml.Marshal(displayPackage)
@@ -168,6 +187,154 @@ func (pc *PackageClient) CreatePackages(ctx context.Context, fileName string, ku
 	return nil
 }
 
+// CreatePackagesInOrder creates the packages in a file in the order given by their dependencies
+// in the bundle, waiting for each group of packages to be installed before creating the packages
+// that depend on them. Files with other resources or without dependencies between their packages
+// are created in one go.
+func (pc *PackageClient) CreatePackagesInOrder(ctx context.Context, fileName string, kubeConfig string, bundle *packagesv1.PackageBundle) error {
+	packages, onlyPackages, err := readPackagesFile(fileName)
+	if err != nil || !onlyPackages {
+		logger.V(4).Info("Creating packages without dependency order", "error", err)
+		return pc.CreatePackages(ctx, fileName, kubeConfig)
+	}
+
+	waves, err := SortByDependencies(bundle, packages)
+	if err != nil {
+		return err
+	}
+	if len(waves) <= 1 {
+		return pc.CreatePackages(ctx, fileName, kubeConfig)
+	}
+
+	for i, wave := range waves {
+		if err := pc.createPackages(ctx, wave, kubeConfig); err != nil {
+			return err
+		}
+		if i == len(waves)-1 {
+			break
+		}
+		if err := pc.WaitForPackagesInstalled(ctx, wave, kubeConfig); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (pc *PackageClient) createPackages(ctx context.Context, packages []packagesv1.Package, kubeConfig string) error {
+	resources := make([][]byte, 0, len(packages))
+	for _, p := range packages {
+		content, err := yaml.Marshal(NewDisplayablePackage(&p))
+		if err != nil {
+			return fmt.Errorf("unable to parse package %s %v", p.Name, err)
+		}
+		resources = append(resources, content)
+	}
+
+	params := []string{"create", "-f", "-", "--kubeconfig", kubeConfig}
+	stdOut, err := pc.kubectl.ExecuteFromYaml(ctx, templater.AppendYamlResources(resources...), params...)
+	fmt.Print(&stdOut)
+	return err
+}
+
+// WaitForPackagesInstalled waits until all the packages report the installed state.
+func (pc *PackageClient) WaitForPackagesInstalled(ctx context.Context, packages []packagesv1.Package, kubeConfig string) error {
+	for _, p := range packages {
+		logger.V(3).Info("Waiting for package to be installed", "package", p.Name)
+		err := pc.retrier.Retry(func() error {
+			installed := &packagesv1.Package{}
+			if err := pc.kubectl.GetObject(ctx, packageResource, p.Name, p.Namespace, kubeConfig, installed); err != nil {
+				return err
+			}
+			if installed.Status.State != packagesv1.StateInstalled {
+				return fmt.Errorf("package %s is in state %q", p.Name, installed.Status.State)
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("waiting for package %s to be installed: %v", p.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// GetInstalledPackages returns the packages of a cluster.
+func (pc *PackageClient) GetInstalledPackages(ctx context.Context, kubeConfig string, clusterName string) ([]packagesv1.Package, error) {
+	params := []string{"get", "packages", "-o", "json", "--kubeconfig", kubeConfig, "--namespace", constants.EksaPackagesName + "-" + clusterName}
+	stdOut, err := pc.kubectl.ExecuteCommand(ctx, params...)
+	if err != nil {
+		return nil, fmt.Errorf("getting packages: %v", err)
+	}
+
+	list := &packagesv1.PackageList{}
+	if err := json.Unmarshal(stdOut.Bytes(), list); err != nil {
+		return nil, fmt.Errorf("unmarshaling packages: %v", err)
+	}
+
+	return list.Items, nil
+}
+
+// WaitForDependencies waits for the packages a bundle package depends on to be installed
+// in the cluster. It fails if one of the dependencies is not installed.
+func (pc *PackageClient) WaitForDependencies(ctx context.Context, bp *packagesv1.BundlePackage, kubeConfig string, clusterName string) error {
+	dependencies := PackageDependencies(pc.bundle, bp.Name, "")
+	if len(dependencies) == 0 {
+		return nil
+	}
+
+	installed, err := pc.GetInstalledPackages(ctx, kubeConfig, clusterName)
+	if err != nil {
+		return err
+	}
+
+	var missing []string
+	var waitFor []packagesv1.Package
+	for _, dep := range dependencies {
+		found := false
+		for _, p := range installed {
+			if strings.EqualFold(p.Spec.PackageName, dep) {
+				waitFor = append(waitFor, p)
+				found = true
+			}
+		}
+		if !found {
+			missing = append(missing, dep)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("package %s depends on packages not installed in the cluster: %s", bp.Name, strings.Join(missing, ", "))
+	}
+
+	return pc.WaitForPackagesInstalled(ctx, waitFor, kubeConfig)
+}
+
+// ValidateDeletion checks that no other package in the cluster depends on the packages to be deleted.
+func (pc *PackageClient) ValidateDeletion(ctx context.Context, packages []string, kubeConfig string, clusterName string) error {
+	installed, err := pc.GetInstalledPackages(ctx, kubeConfig, clusterName)
+	if err != nil {
+		return err
+	}
+
+	dependents := Dependents(pc.bundle, installed, packages)
+	if len(dependents) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(dependents))
+	for name := range dependents {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	msgs := make([]string, 0, len(names))
+	for _, name := range names {
+		msgs = append(msgs, fmt.Sprintf("%s is required by %s", name, strings.Join(dependents[name], ", ")))
+	}
+
+	return fmt.Errorf("packages required by other packages can't be deleted: %s", strings.Join(msgs, "; "))
+}
+
 func (pc *PackageClient) DeletePackages(ctx context.Context, packages []string, kubeConfig string, clusterName string) error {
 	params := []string{"delete", "packages", "--kubeconfig", kubeConfig, "--namespace", constants.EksaPackagesName + "-" + clusterName}
 	params = append(params, packages...)
@@ -195,6 +362,37 @@ func (pc *PackageClient) DescribePackages(ctx context.Context, packages []string
 	return nil
 }
 
+// readPackagesFile reads the packages in a manifest. It also reports if the manifest
+// only contains packages.
+func readPackagesFile(fileName string) (packages []packagesv1.Package, onlyPackages bool, err error) {
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, false, fmt.Errorf("reading packages file: %v", err)
+	}
+
+	onlyPackages = true
+	decoder := yamlutil.NewYAMLOrJSONDecoder(bytes.NewReader(content), 4096)
+	for {
+		p := packagesv1.Package{}
+		if err := decoder.Decode(&p); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, false, fmt.Errorf("parsing packages file: %v", err)
+		}
+		if p.Kind == "" && p.Name == "" {
+			continue
+		}
+		if p.Kind != kind {
+			onlyPackages = false
+			continue
+		}
+		packages = append(packages, p)
+	}
+
+	return packages, onlyPackages, nil
+}
+
 func convertBundlePackageToPackage(bp packagesv1.BundlePackage, name string, clusterName string, apiVersion string, config string) packagesv1.Package {
 	p := packagesv1.Package{
 		ObjectMeta: metav1.ObjectMeta{
@@ -230,3 +428,18 @@ func WithCustomConfigs(customConfigs []string) func(*PackageClient) {
 		config.customConfigs = customConfigs
 	}
 }
+
+// WithSchemaFetcher sets the schema fetcher used to validate package configurations before
+// they are sent to the cluster.
+func WithSchemaFetcher(f SchemaFetcher) func(*PackageClient) {
+	return func(config *PackageClient) {
+		config.schemaFetcher = f
+	}
+}
+
+// WithRetrier sets the retrier used to wait for packages to be installed.
+func WithRetrier(r *retrier.Retrier) func(*PackageClient) {
+	return func(config *PackageClient) {
+		config.retrier = r
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/curatedpackages"
 	"github.com/aws/eks-anywhere/pkg/curatedpackages/mocks"
+	"github.com/aws/eks-anywhere/pkg/retrier"
 )
 
 type packageTest struct {
@@ -270,3 +271,123 @@ func TestDisplayPackages(t *testing.T) {
 	expected := "Package\t\tVersion(s)\t\n-------\t\t----------\t\nharbor-test\t0.0.1, 0.0.2\t\nredis-test\t0.0.3, 0.0.4\t\n"
 	tt.Expect(buf.String()).To(Equal(expected))
 }
+
+func TestCreatePackagesInOrder(t *testing.T) {
+	tt := newPackageTest(t)
+	fileName := "testdata/packages-dependencies.yaml"
+	tt.command = curatedpackages.NewPackageClient(tt.kubectl, curatedpackages.WithRetrier(retrier.NewWithMaxRetries(1, 0)))
+	params := []string{"create", "-f", "-", "--kubeconfig", tt.kubeConfig}
+
+	gomock.InOrder(
+		tt.kubectl.EXPECT().ExecuteFromYaml(tt.ctx, gomock.Any(), params).DoAndReturn(
+			func(_ context.Context, data []byte, _ ...string) (bytes.Buffer, error) {
+				tt.Expect(string(data)).To(ContainSubstring("my-cert-manager"))
+				tt.Expect(string(data)).NotTo(ContainSubstring("my-harbor"))
+				return bytes.Buffer{}, nil
+			}),
+		tt.kubectl.EXPECT().GetObject(tt.ctx, "package", "my-cert-manager", "eksa-packages-test", tt.kubeConfig, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _, _, _, _ string, obj *packagesv1.Package) error {
+				obj.Status.State = packagesv1.StateInstalled
+				return nil
+			}),
+		tt.kubectl.EXPECT().ExecuteFromYaml(tt.ctx, gomock.Any(), params).DoAndReturn(
+			func(_ context.Context, data []byte, _ ...string) (bytes.Buffer, error) {
+				tt.Expect(string(data)).To(ContainSubstring("my-harbor"))
+				return bytes.Buffer{}, nil
+			}),
+	)
+
+	tt.Expect(tt.command.CreatePackagesInOrder(tt.ctx, fileName, tt.kubeConfig, dependenciesBundle())).To(Succeed())
+}
+
+func TestCreatePackagesInOrderNotInstalled(t *testing.T) {
+	tt := newPackageTest(t)
+	fileName := "testdata/packages-dependencies.yaml"
+	tt.command = curatedpackages.NewPackageClient(tt.kubectl, curatedpackages.WithRetrier(retrier.NewWithMaxRetries(1, 0)))
+
+	tt.kubectl.EXPECT().ExecuteFromYaml(tt.ctx, gomock.Any(), gomock.Any()).Return(bytes.Buffer{}, nil)
+	tt.kubectl.EXPECT().GetObject(tt.ctx, "package", "my-cert-manager", "eksa-packages-test", tt.kubeConfig, gomock.Any()).Return(nil)
+
+	err := tt.command.CreatePackagesInOrder(tt.ctx, fileName, tt.kubeConfig, dependenciesBundle())
+	tt.Expect(err).To(MatchError(ContainSubstring("waiting for package my-cert-manager to be installed")))
+}
+
+func TestCreatePackagesInOrderWithoutDependencies(t *testing.T) {
+	tt := newPackageTest(t)
+	fileName := "testdata/packages-dependencies.yaml"
+	params := []string{"create", "-f", fileName, "--kubeconfig", tt.kubeConfig}
+	tt.kubectl.EXPECT().ExecuteCommand(tt.ctx, params).Return(bytes.Buffer{}, nil)
+	tt.command = curatedpackages.NewPackageClient(tt.kubectl)
+
+	tt.Expect(tt.command.CreatePackagesInOrder(tt.ctx, fileName, tt.kubeConfig, nil)).To(Succeed())
+}
+
+func TestCreatePackagesInOrderWithOtherResources(t *testing.T) {
+	tt := newPackageTest(t)
+	fileName := "testdata/packages-with-secret.yaml"
+	params := []string{"create", "-f", fileName, "--kubeconfig", tt.kubeConfig}
+	tt.kubectl.EXPECT().ExecuteCommand(tt.ctx, params).Return(bytes.Buffer{}, nil)
+	tt.command = curatedpackages.NewPackageClient(tt.kubectl)
+
+	tt.Expect(tt.command.CreatePackagesInOrder(tt.ctx, fileName, tt.kubeConfig, dependenciesBundle())).To(Succeed())
+}
+
+func (tt *packageTest) expectInstalledPackages(packages ...packagesv1.Package) {
+	params := []string{"get", "packages", "-o", "json", "--kubeconfig", tt.kubeConfig, "--namespace", constants.EksaPackagesName + "-test"}
+	tt.kubectl.EXPECT().ExecuteCommand(tt.ctx, params).Return(convertJsonToBytes(packagesv1.PackageList{Items: packages}), nil)
+}
+
+func TestWaitForDependencies(t *testing.T) {
+	tt := newPackageTest(t)
+	bundle := dependenciesBundle()
+	tt.command = curatedpackages.NewPackageClient(tt.kubectl, curatedpackages.WithBundle(bundle), curatedpackages.WithRetrier(retrier.NewWithMaxRetries(1, 0)))
+
+	tt.expectInstalledPackages(pkg("my-cert-manager", "cert-manager"))
+	tt.kubectl.EXPECT().GetObject(tt.ctx, "package", "my-cert-manager", "eksa-packages-test", tt.kubeConfig, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _, _, _, _ string, obj *packagesv1.Package) error {
+			obj.Status.State = packagesv1.StateInstalled
+			return nil
+		})
+
+	tt.Expect(tt.command.WaitForDependencies(tt.ctx, &bundle.Spec.Packages[1], tt.kubeConfig, "test")).To(Succeed())
+}
+
+func TestWaitForDependenciesMissing(t *testing.T) {
+	tt := newPackageTest(t)
+	bundle := dependenciesBundle()
+	tt.command = curatedpackages.NewPackageClient(tt.kubectl, curatedpackages.WithBundle(bundle))
+
+	tt.expectInstalledPackages(pkg("my-cert-manager", "cert-manager"))
+
+	err := tt.command.WaitForDependencies(tt.ctx, &bundle.Spec.Packages[2], tt.kubeConfig, "test")
+	tt.Expect(err).To(MatchError("package harbor depends on packages not installed in the cluster: metallb"))
+}
+
+func TestValidateDeletion(t *testing.T) {
+	tt := newPackageTest(t)
+	tt.command = curatedpackages.NewPackageClient(tt.kubectl, curatedpackages.WithBundle(dependenciesBundle()))
+
+	tt.expectInstalledPackages(pkg("my-cert-manager", "cert-manager"), pkg("my-adot", "adot"))
+
+	err := tt.command.ValidateDeletion(tt.ctx, []string{"my-cert-manager"}, tt.kubeConfig, "test")
+	tt.Expect(err).To(MatchError("packages required by other packages can't be deleted: my-cert-manager is required by my-adot"))
+}
+
+func TestValidateDeletionSucceeds(t *testing.T) {
+	tt := newPackageTest(t)
+	tt.command = curatedpackages.NewPackageClient(tt.kubectl, curatedpackages.WithBundle(dependenciesBundle()))
+
+	tt.expectInstalledPackages(pkg("my-cert-manager", "cert-manager"), pkg("my-adot", "adot"))
+
+	tt.Expect(tt.command.ValidateDeletion(tt.ctx, []string{"my-cert-manager", "my-adot"}, tt.kubeConfig, "test")).To(Succeed())
+}
+
+func TestInstallPackagesFailsWhenConfigDoesNotMatchSchema(t *testing.T) {
+	tt := newPackageTest(t)
+	fetcher := mocks.NewMockSchemaFetcher(gomock.NewController(t))
+	fetcher.EXPECT().FetchSchema(tt.ctx, &tt.bundle.Spec.Packages[0], "").Return([]byte(`{"type":"object","properties":{"replicas":{"type":"integer"}},"additionalProperties":false}`), nil)
+	tt.command = curatedpackages.NewPackageClient(tt.kubectl, curatedpackages.WithBundle(tt.bundle), curatedpackages.WithCustomConfigs([]string{"replica=2"}), curatedpackages.WithSchemaFetcher(fetcher))
+
+	err := tt.command.InstallPackage(tt.ctx, &tt.bundle.Spec.Packages[0], "my-harbor", "billy", "")
+	tt.Expect(err).To(MatchError(ContainSubstring("replica is a forbidden property")))
+}
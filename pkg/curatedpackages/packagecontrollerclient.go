@@ -209,7 +209,7 @@ func (pc *PackageControllerClient) Enable(ctx context.Context) error {
 		noProxy := fmt.Sprintf("proxy.NO_PROXY=%s", strings.Join(pc.noProxy, "\\,"))
 		values = append(values, httpProxy, httpsProxy, noProxy)
 	}
-	if (pc.eksaSecretAccessKey == "" || pc.eksaAccessKeyID == "") && pc.registryMirror == nil {
+	if (pc.eksaSecretAccessKey == "" || pc.eksaAccessKeyID == "") && pc.registryMirror == nil || pc.isOffline() {
 		values = append(values, "cronjob.suspend=true")
 	}
 
@@ -270,7 +270,9 @@ func (pc *PackageControllerClient) GetCuratedPackagesRegistries(ctx context.Cont
 		}
 
 		regionalRegistry := GetRegionalRegistry(defaultRegistry, pc.eksaRegion)
-		if err := pc.registryAccessTester.Test(ctx, pc.eksaAccessKeyID, pc.eksaSecretAccessKey, pc.eksaRegion, pc.eksaAwsConfig, regionalRegistry); err == nil {
+		if pc.isOffline() {
+			logger.V(6).Info("Offline packages, skipping regional registry access check")
+		} else if err := pc.registryAccessTester.Test(ctx, pc.eksaAccessKeyID, pc.eksaSecretAccessKey, pc.eksaRegion, pc.eksaAwsConfig, regionalRegistry); err == nil {
 			// use regional registry when the above credential is good
 			logger.V(6).Info("Using regional registry")
 			defaultRegistry = regionalRegistry
@@ -315,9 +317,14 @@ func (pc *PackageControllerClient) generateHelmOverrideValues() ([]byte, error)
 			insecureSkipVerify = "true"
 		}
 	}
+	accessKeyID, secretAccessKey := pc.eksaAccessKeyID, pc.eksaSecretAccessKey
+	if pc.isOffline() {
+		// Offline packages don't refresh ECR tokens, so AWS credentials are never stored in the cluster.
+		accessKeyID, secretAccessKey = "", ""
+	}
 	templateValues := map[string]interface{}{
-		"eksaAccessKeyId":     base64.StdEncoding.EncodeToString([]byte(pc.eksaAccessKeyID)),
-		"eksaSecretAccessKey": base64.StdEncoding.EncodeToString([]byte(pc.eksaSecretAccessKey)),
+		"eksaAccessKeyId":     base64.StdEncoding.EncodeToString([]byte(accessKeyID)),
+		"eksaSecretAccessKey": base64.StdEncoding.EncodeToString([]byte(secretAccessKey)),
 		"eksaRegion":          base64.StdEncoding.EncodeToString([]byte(pc.eksaRegion)),
 		"eksaAwsConfig":       base64.StdEncoding.EncodeToString([]byte(pc.eksaAwsConfig)),
 		"mirrorEndpoint":      base64.StdEncoding.EncodeToString([]byte(endpoint)),
@@ -335,6 +342,11 @@ func (pc *PackageControllerClient) generateHelmOverrideValues() ([]byte, error)
 	return []byte(values + string(result)), err
 }
 
+// isOffline returns true if curated packages are configured to never reach the package registries.
+func (pc *PackageControllerClient) isOffline() bool {
+	return pc.clusterSpec != nil && pc.clusterSpec.Packages != nil && pc.clusterSpec.Packages.Offline
+}
+
 // packageBundleControllerResource is the name of the package bundle controller
 // resource in the API.
 const packageBundleControllerResource string = "packageBundleController"
@@ -509,7 +521,7 @@ func (pc *PackageControllerClient) Reconcile(ctx context.Context, logger logr.Lo
 		return fmt.Errorf("packages client error: %w", err)
 	}
 
-	return nil
+	return ReconcilePackagesReady(ctx, client, cluster)
 }
 
 // getBundleFromCluster based on the cluster's k8s version.
@@ -535,7 +547,7 @@ type KubeDeleter interface {
 // ReconcileDelete removes resources after a full cluster lifecycle cluster is
 // deleted.
 func (pc *PackageControllerClient) ReconcileDelete(ctx context.Context, logger logr.Logger, client KubeDeleter, cluster *anywherev1.Cluster) error {
-	namespace := "eksa-packages-" + cluster.Name
+	namespace := PackagesNamespace(cluster.Name)
 	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}
 	if err := client.Delete(ctx, ns); err != nil {
 		if !apierrors.IsNotFound(err) {
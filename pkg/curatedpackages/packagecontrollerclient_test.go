@@ -3,6 +3,7 @@ package curatedpackages_test
 import (
 	"context"
 	_ "embed"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"os"
@@ -19,6 +20,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
@@ -1377,6 +1379,55 @@ func TestReconcile(s *testing.T) {
 		}
 	})
 
+	s.Run("reports failing packages in the cluster conditions", func(t *testing.T) {
+		ctx := context.Background()
+		log := testr.New(t)
+		cluster := newReconcileTestCluster()
+		ctrl := gomock.NewController(t)
+		k := mocks.NewMockKubectlRunner(ctrl)
+		cm := mocks.NewMockChartManager(ctrl)
+		bundles := createBundle(cluster)
+		bundles.Spec.VersionsBundles[0].KubeVersion = string(cluster.Spec.KubernetesVersion)
+		bundles.ObjectMeta.Name = cluster.Spec.BundlesRef.Name
+		bundles.ObjectMeta.Namespace = cluster.Spec.BundlesRef.Namespace
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: constants.EksaSystemNamespace,
+				Name:      cluster.Name + "-kubeconfig",
+			},
+		}
+		eksaRelease := createEKSARelease(cluster, bundles)
+		cluster.Spec.BundlesRef = nil
+		installed := &packagesv1.Package{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-adot", Namespace: "eksa-packages-" + cluster.Name},
+			Status:     packagesv1.PackageStatus{State: packagesv1.StateInstalled},
+		}
+		failed := &packagesv1.Package{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-harbor", Namespace: "eksa-packages-" + cluster.Name},
+			Status:     packagesv1.PackageStatus{State: packagesv1.StateInstalling, Detail: "helm install failed"},
+		}
+		otherCluster := &packagesv1.Package{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-emissary", Namespace: "eksa-packages-other"},
+			Status:     packagesv1.PackageStatus{State: packagesv1.StateInstalling, Detail: "helm install failed"},
+		}
+		objs := []runtime.Object{cluster, bundles, secret, eksaRelease, installed, failed, otherCluster}
+		fakeClient := fake.NewClientBuilder().WithRuntimeObjects(objs...).Build()
+		cm.EXPECT().InstallChart(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+		pcc := curatedpackages.NewPackageControllerClientFullLifecycle(log, cm, k, nil)
+		if err := pcc.Reconcile(ctx, log, fakeClient, cluster); err != nil {
+			t.Fatalf("expected nil error, got %s", err)
+		}
+
+		condition := conditions.Get(cluster, anywherev1.PackagesReadyCondition)
+		if condition == nil || condition.Status != corev1.ConditionFalse || condition.Reason != anywherev1.PackagesFailedReason {
+			t.Fatalf("expected PackagesReady condition to be false with reason %s, got %v", anywherev1.PackagesFailedReason, condition)
+		}
+		if condition.Message != "Packages failed: my-harbor" {
+			t.Errorf("expected failing packages in condition message, got %q", condition.Message)
+		}
+	})
+
 	s.Run("errors when bundles aren't found", func(t *testing.T) {
 		ctx := context.Background()
 		log := testr.New(t)
@@ -1676,3 +1727,82 @@ func createEKSARelease(cluster *anywherev1.Cluster, bundle *artifactsv1.Bundles)
 		},
 	}
 }
+
+type noCallRegistryAccessTester struct {
+	t *testing.T
+}
+
+func (s *noCallRegistryAccessTester) Test(ctx context.Context, accessKey, secret, registry, region, awsConfig string) error {
+	s.t.Errorf("registry access should not be tested for offline packages")
+	return nil
+}
+
+func offlineClusterSpec() *cluster.Spec {
+	return &cluster.Spec{Config: &cluster.Config{Cluster: &v1alpha1.Cluster{Spec: v1alpha1.ClusterSpec{
+		Packages: &v1alpha1.PackageConfiguration{Offline: true},
+	}}}}
+}
+
+func TestGetCuratedPackagesRegistriesOffline(t *testing.T) {
+	g := NewWithT(t)
+	chart := &artifactsv1.Image{
+		Name: "test_controller",
+		URI:  "test_registry/eks-anywhere/eks-anywhere-packages:v1",
+	}
+	sut := curatedpackages.NewPackageControllerClient(nil, nil, "billy", "", chart, nil,
+		curatedpackages.WithClusterSpec(offlineClusterSpec()),
+		curatedpackages.WithEksaAccessKeyId("key-id"),
+		curatedpackages.WithEksaSecretAccessKey("secret"),
+		curatedpackages.WithRegistryAccessTester(&noCallRegistryAccessTester{t: t}),
+	)
+
+	_, defaultRegistry, img := sut.GetCuratedPackagesRegistries(context.Background())
+	g.Expect(defaultRegistry).To(Equal("public.ecr.aws/eks-anywhere"))
+	g.Expect(img).To(Equal("783794618700.dkr.ecr.us-west-2.amazonaws.com"))
+}
+
+func TestEnableOffline(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	k := mocks.NewMockKubectlRunner(ctrl)
+	cm := mocks.NewMockChartManager(ctrl)
+	chart := &artifactsv1.Image{
+		Name: "test_controller",
+		URI:  "test_registry/eks-anywhere/eks-anywhere-packages:v1",
+	}
+	registryMirror := &registrymirror.RegistryMirror{
+		BaseRegistry: "1.2.3.4:443",
+		NamespacedRegistryMap: map[string]string{
+			constants.DefaultCoreEKSARegistry:        "1.2.3.4:443/public",
+			constants.DefaultCuratedPackagesRegistry: "1.2.3.4:443/private",
+		},
+	}
+	writer, _ := filewriter.NewWriter("billy")
+	sut := curatedpackages.NewPackageControllerClient(cm, k, "billy", "kubeconfig.kubeconfig", chart, registryMirror,
+		curatedpackages.WithClusterSpec(offlineClusterSpec()),
+		curatedpackages.WithManagementClusterName("billy"),
+		curatedpackages.WithEksaAccessKeyId("key-id"),
+		curatedpackages.WithEksaSecretAccessKey("secret"),
+		curatedpackages.WithValuesFileWriter(writer),
+		curatedpackages.WithSkipWait(),
+		curatedpackages.WithRegistryAccessTester(&noCallRegistryAccessTester{t: t}),
+	)
+
+	cm.EXPECT().InstallChart(ctx, chart.Name, gomock.Any(), chart.Tag(), "kubeconfig.kubeconfig", constants.EksaPackagesName, gomock.Any(), false, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _, _, _, _, _ string, _ bool, values []string) error {
+			g.Expect(values).To(ContainElements(
+				"sourceRegistry=1.2.3.4:443/public/eks-anywhere",
+				"defaultImageRegistry=1.2.3.4:443/private",
+				"cronjob.suspend=true",
+			))
+			return nil
+		})
+
+	g.Expect(sut.Enable(ctx)).To(Succeed())
+
+	_, content, err := sut.CreateHelmOverrideValuesYaml()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(content)).NotTo(ContainSubstring(base64.StdEncoding.EncodeToString([]byte("key-id"))))
+	g.Expect(string(content)).NotTo(ContainSubstring(base64.StdEncoding.EncodeToString([]byte("secret"))))
+}
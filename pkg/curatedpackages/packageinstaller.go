@@ -3,6 +3,7 @@ package curatedpackages
 import (
 	"context"
 
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/logger"
@@ -15,7 +16,7 @@ type PackageController interface {
 }
 
 type PackageHandler interface {
-	CreatePackages(ctx context.Context, fileName string, kubeConfig string) error
+	CreatePackagesInOrder(ctx context.Context, fileName string, kubeConfig string, bundle *packagesv1.PackageBundle) error
 }
 
 type Installer struct {
@@ -97,7 +98,15 @@ func (pi *Installer) installPackages(ctx context.Context) error {
 	if pi.packagesLocation == "" {
 		return nil
 	}
-	err := pi.packageClient.CreatePackages(ctx, pi.packagesLocation, pi.mgmtKubeconfig)
+	bundle, err := NewBundleReader(pi.mgmtKubeconfig, pi.spec.Cluster.Name, pi.kubectl, nil, nil).GetLatestBundle(ctx, "")
+	if err != nil {
+		// Without the active bundle the dependencies between packages are unknown, so they
+		// are created in one go and the package controller retries the ones that fail.
+		logger.V(4).Info("Unable to read the active package bundle, installing packages without dependency order", "error", err)
+		bundle = nil
+	}
+
+	err = pi.packageClient.CreatePackagesInOrder(ctx, pi.packagesLocation, pi.mgmtKubeconfig, bundle)
 	if err != nil {
 		return err
 	}
@@ -1,7 +1,9 @@
 package curatedpackages_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"testing"
 
@@ -9,6 +11,7 @@ import (
 	. "github.com/onsi/gomega"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/curatedpackages"
@@ -57,10 +60,36 @@ func newPackageInstallerTest(t *testing.T) *packageInstallerTest {
 	}
 }
 
+func (tt *packageInstallerTest) expectActiveBundle(bundle *packagesv1.PackageBundle) {
+	pbc := &packagesv1.PackageBundleController{Spec: packagesv1.PackageBundleControllerSpec{ActiveBundle: bundle.Name}}
+	pbcJSON, err := json.Marshal(pbc)
+	tt.Expect(err).NotTo(HaveOccurred())
+	bundleJSON, err := json.Marshal(bundle)
+	tt.Expect(err).NotTo(HaveOccurred())
+
+	tt.kubectlRunner.EXPECT().ExecuteCommand(tt.ctx, "get", "packageBundleController", "-o", "json", "--kubeconfig", tt.kubeConfigPath, "--namespace", "eksa-packages", tt.spec.Cluster.Name).
+		Return(*bytes.NewBuffer(pbcJSON), nil)
+	tt.kubectlRunner.EXPECT().ExecuteCommand(tt.ctx, "get", "packageBundle", "-o", "json", "--kubeconfig", tt.kubeConfigPath, "--namespace", "eksa-packages", bundle.Name).
+		Return(*bytes.NewBuffer(bundleJSON), nil)
+}
+
 func TestPackageInstallerSuccess(t *testing.T) {
 	tt := newPackageInstallerTest(t)
+	bundle := &packagesv1.PackageBundle{ObjectMeta: v1.ObjectMeta{Name: "v1-29-1"}}
+
+	tt.expectActiveBundle(bundle)
+	tt.packageClient.EXPECT().CreatePackagesInOrder(tt.ctx, tt.packagePath, tt.kubeConfigPath, bundle).Return(nil)
+	tt.packageControllerClient.EXPECT().Enable(tt.ctx).Return(nil)
+
+	tt.command.InstallCuratedPackages(tt.ctx)
+}
+
+func TestPackageInstallerSuccessWithoutActiveBundle(t *testing.T) {
+	tt := newPackageInstallerTest(t)
 
-	tt.packageClient.EXPECT().CreatePackages(tt.ctx, tt.packagePath, tt.kubeConfigPath).Return(nil)
+	tt.kubectlRunner.EXPECT().ExecuteCommand(tt.ctx, "get", "packageBundleController", "-o", "json", "--kubeconfig", tt.kubeConfigPath, "--namespace", "eksa-packages", tt.spec.Cluster.Name).
+		Return(bytes.Buffer{}, errors.New("not found"))
+	tt.packageClient.EXPECT().CreatePackagesInOrder(tt.ctx, tt.packagePath, tt.kubeConfigPath, nil).Return(nil)
 	tt.packageControllerClient.EXPECT().Enable(tt.ctx).Return(nil)
 
 	tt.command.InstallCuratedPackages(tt.ctx)
@@ -76,8 +105,10 @@ func TestPackageInstallerFailWhenControllerFails(t *testing.T) {
 
 func TestPackageInstallerFailWhenPackageFails(t *testing.T) {
 	tt := newPackageInstallerTest(t)
+	bundle := &packagesv1.PackageBundle{ObjectMeta: v1.ObjectMeta{Name: "v1-29-1"}}
 
-	tt.packageClient.EXPECT().CreatePackages(tt.ctx, tt.packagePath, tt.kubeConfigPath).Return(errors.New("path doesn't exist"))
+	tt.expectActiveBundle(bundle)
+	tt.packageClient.EXPECT().CreatePackagesInOrder(tt.ctx, tt.packagePath, tt.kubeConfigPath, bundle).Return(errors.New("path doesn't exist"))
 	tt.packageControllerClient.EXPECT().Enable(tt.ctx).Return(nil)
 
 	tt.command.InstallCuratedPackages(tt.ctx)
@@ -0,0 +1,143 @@
+package curatedpackages
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
+	"k8s.io/kube-openapi/pkg/validation/validate"
+	"sigs.k8s.io/yaml"
+
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+// SchemaFetcher fetches the JSON schema of the configuration of a package version.
+// It returns an empty schema when the package version doesn't provide one.
+type SchemaFetcher interface {
+	FetchSchema(ctx context.Context, bp *packagesv1.BundlePackage, version string) ([]byte, error)
+}
+
+// BundlePackageVersion returns the version of a bundle package matching a version name or digest,
+// or the first version of the package when no version is given.
+func BundlePackageVersion(bp *packagesv1.BundlePackage, version string) (*packagesv1.SourceVersion, error) {
+	for i, v := range bp.Source.Versions {
+		if version == "" || v.Name == version || v.Digest == version {
+			return &bp.Source.Versions[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("version %s of package %s not found in bundle", version, bp.Name)
+}
+
+// ValidateConfig validates a package configuration in yaml against a JSON schema.
+// It returns an error listing every field that doesn't match the schema.
+func ValidateConfig(schema []byte, config string) error {
+	s := &spec.Schema{}
+	if err := json.Unmarshal(schema, s); err != nil {
+		return fmt.Errorf("parsing package schema: %v", err)
+	}
+
+	var values interface{}
+	if err := yaml.Unmarshal([]byte(config), &values); err != nil {
+		return fmt.Errorf("parsing package config: %v", err)
+	}
+	if values == nil {
+		values = map[string]interface{}{}
+	}
+
+	result := validate.NewSchemaValidator(s, nil, "", strfmt.Default).Validate(values)
+	if result.IsValid() {
+		return nil
+	}
+
+	fieldErrors := make([]string, 0, len(result.Errors))
+	for _, err := range result.Errors {
+		fieldErrors = append(fieldErrors, strings.TrimPrefix(strings.Replace(err.Error(), " in body", "", 1), "."))
+	}
+	// The validator walks object properties in map order, sort the errors to keep them stable.
+	sort.Strings(fieldErrors)
+
+	return fmt.Errorf("invalid config:\n  - %s", strings.Join(fieldErrors, "\n  - "))
+}
+
+// ValidatePackageConfigs validates the configuration of packages against the schema of their
+// version in the bundle. Packages without configuration are not validated, and nothing is
+// validated if the client doesn't have a schema fetcher or a bundle.
+func (pc *PackageClient) ValidatePackageConfigs(ctx context.Context, packages []packagesv1.Package) error {
+	if pc.schemaFetcher == nil || pc.bundle == nil {
+		return nil
+	}
+
+	packageMap := pc.packageMap()
+	for _, p := range packages {
+		if strings.TrimSpace(p.Spec.Config) == "" {
+			continue
+		}
+
+		bp, ok := packageMap[strings.ToLower(p.Spec.PackageName)]
+		if !ok {
+			return fmt.Errorf("package %s: unknown package %q", p.Name, p.Spec.PackageName)
+		}
+
+		schema, err := pc.schemaFetcher.FetchSchema(ctx, &bp, p.Spec.PackageVersion)
+		if err != nil {
+			return fmt.Errorf("package %s: fetching config schema: %v", p.Name, err)
+		}
+		if len(schema) == 0 {
+			logger.V(4).Info("Package doesn't provide a config schema, skipping validation", "package", p.Name)
+			continue
+		}
+
+		if err := ValidateConfig(schema, p.Spec.Config); err != nil {
+			return fmt.Errorf("package %s: %v", p.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// ValidatePackagesFile validates the configuration of the packages in a file against the schema
+// of their version in the active bundle of the cluster they belong to.
+func (pc *PackageClient) ValidatePackagesFile(ctx context.Context, fileName string, kubeConfig string) error {
+	if pc.schemaFetcher == nil {
+		return nil
+	}
+
+	packages, _, err := readPackagesFile(fileName)
+	if err != nil {
+		return err
+	}
+
+	byCluster := map[string][]packagesv1.Package{}
+	var clusters []string
+	for _, p := range packages {
+		clusterName := strings.TrimPrefix(p.Namespace, constants.EksaPackagesName+"-")
+		if _, ok := byCluster[clusterName]; !ok {
+			clusters = append(clusters, clusterName)
+		}
+		byCluster[clusterName] = append(byCluster[clusterName], p)
+	}
+
+	for _, clusterName := range clusters {
+		bundle := pc.bundle
+		if bundle == nil {
+			bundle, err = NewBundleReader(kubeConfig, clusterName, pc.kubectl, nil, nil).GetLatestBundle(ctx, "")
+			if err != nil {
+				return fmt.Errorf("getting the active package bundle for cluster %s: %v", clusterName, err)
+			}
+		}
+
+		client := NewPackageClient(pc.kubectl, WithBundle(bundle), WithSchemaFetcher(pc.schemaFetcher))
+		if err := client.ValidatePackageConfigs(ctx, byCluster[clusterName]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
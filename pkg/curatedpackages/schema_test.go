@@ -0,0 +1,218 @@
+package curatedpackages_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/curatedpackages"
+	"github.com/aws/eks-anywhere/pkg/curatedpackages/mocks"
+)
+
+const helloSchema = `{
+  "$id": "https://hello-eks-anywhere.packages.eks.amazonaws.com/schema.json",
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "title": "hello-eks-anywhere",
+  "type": "object",
+  "properties": {
+    "sourceRegistry": {
+      "type": "string",
+      "default": "public.ecr.aws/eks-anywhere"
+    },
+    "title": {
+      "type": "string",
+      "default": "Amazon EKS Anywhere"
+    },
+    "replicas": {
+      "type": "integer",
+      "minimum": 1
+    },
+    "logging": {
+      "type": "object",
+      "properties": {
+        "level": {
+          "enum": ["debug", "info"]
+        }
+      },
+      "additionalProperties": false
+    }
+  },
+  "additionalProperties": false
+}`
+
+func schemaBundle() *packagesv1.PackageBundle {
+	return &packagesv1.PackageBundle{
+		Spec: packagesv1.PackageBundleSpec{
+			Packages: []packagesv1.BundlePackage{
+				{
+					Name: "hello-eks-anywhere",
+					Source: packagesv1.BundlePackageSource{
+						Versions: []packagesv1.SourceVersion{
+							{Name: "0.1.2", Digest: "sha256:abc"},
+							{Name: "0.1.1", Digest: "sha256:def"},
+						},
+					},
+				},
+				bundlePackage("cert-manager"),
+			},
+		},
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		wantErr string
+	}{
+		{
+			name:   "valid",
+			config: "title: Hello\nreplicas: 2\nlogging:\n  level: debug\n",
+		},
+		{
+			name:   "empty",
+			config: "",
+		},
+		{
+			name:    "unknown and invalid fields",
+			config:  "titel: Hello\nreplicas: 0\nlogging:\n  level: trace\n  format: json\n",
+			wantErr: "invalid config:\n  - logging.format is a forbidden property\n  - logging.level should be one of [debug info]\n  - replicas should be greater than or equal to 1\n  - titel is a forbidden property",
+		},
+		{
+			name:    "wrong type",
+			config:  "title: 1\n",
+			wantErr: "invalid config:\n  - title must be of type string: \"number\"",
+		},
+		{
+			name:    "invalid yaml",
+			config:  "title: [",
+			wantErr: "parsing package config",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := curatedpackages.ValidateConfig([]byte(helloSchema), tt.config)
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+				return
+			}
+			g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+		})
+	}
+}
+
+func TestValidateConfigInvalidSchema(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(curatedpackages.ValidateConfig([]byte("{"), "title: Hello")).To(MatchError(ContainSubstring("parsing package schema")))
+}
+
+func TestBundlePackageVersion(t *testing.T) {
+	g := NewWithT(t)
+	bp := &schemaBundle().Spec.Packages[0]
+
+	v, err := curatedpackages.BundlePackageVersion(bp, "")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(v.Name).To(Equal("0.1.2"))
+
+	v, err = curatedpackages.BundlePackageVersion(bp, "sha256:def")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(v.Name).To(Equal("0.1.1"))
+
+	_, err = curatedpackages.BundlePackageVersion(bp, "0.0.9")
+	g.Expect(err).To(MatchError("version 0.0.9 of package hello-eks-anywhere not found in bundle"))
+}
+
+func TestValidatePackageConfigs(t *testing.T) {
+	tt := newPackageTest(t)
+	fetcher := mocks.NewMockSchemaFetcher(gomock.NewController(t))
+	bundle := schemaBundle()
+	tt.command = curatedpackages.NewPackageClient(tt.kubectl, curatedpackages.WithBundle(bundle), curatedpackages.WithSchemaFetcher(fetcher))
+
+	p := pkg("my-hello", "hello-eks-anywhere")
+	p.Spec.PackageVersion = "0.1.1"
+	p.Spec.Config = "title: 1"
+	fetcher.EXPECT().FetchSchema(tt.ctx, &bundle.Spec.Packages[0], "0.1.1").Return([]byte(helloSchema), nil)
+
+	err := tt.command.ValidatePackageConfigs(tt.ctx, []packagesv1.Package{p, pkg("my-cert-manager", "cert-manager")})
+	tt.Expect(err).To(MatchError("package my-hello: invalid config:\n  - title must be of type string: \"number\""))
+}
+
+func TestValidatePackageConfigsWithoutSchema(t *testing.T) {
+	tt := newPackageTest(t)
+	fetcher := mocks.NewMockSchemaFetcher(gomock.NewController(t))
+	tt.command = curatedpackages.NewPackageClient(tt.kubectl, curatedpackages.WithBundle(schemaBundle()), curatedpackages.WithSchemaFetcher(fetcher))
+
+	p := pkg("my-hello", "hello-eks-anywhere")
+	p.Spec.Config = "title: 1"
+	fetcher.EXPECT().FetchSchema(tt.ctx, gomock.Any(), "").Return(nil, nil)
+
+	tt.Expect(tt.command.ValidatePackageConfigs(tt.ctx, []packagesv1.Package{p})).To(Succeed())
+}
+
+func TestValidatePackageConfigsFetchError(t *testing.T) {
+	tt := newPackageTest(t)
+	fetcher := mocks.NewMockSchemaFetcher(gomock.NewController(t))
+	tt.command = curatedpackages.NewPackageClient(tt.kubectl, curatedpackages.WithBundle(schemaBundle()), curatedpackages.WithSchemaFetcher(fetcher))
+
+	p := pkg("my-hello", "hello-eks-anywhere")
+	p.Spec.Config = "title: Hello"
+	fetcher.EXPECT().FetchSchema(tt.ctx, gomock.Any(), "").Return(nil, errors.New("registry unavailable"))
+
+	err := tt.command.ValidatePackageConfigs(tt.ctx, []packagesv1.Package{p})
+	tt.Expect(err).To(MatchError("package my-hello: fetching config schema: registry unavailable"))
+}
+
+func TestValidatePackageConfigsUnknownPackage(t *testing.T) {
+	tt := newPackageTest(t)
+	fetcher := mocks.NewMockSchemaFetcher(gomock.NewController(t))
+	tt.command = curatedpackages.NewPackageClient(tt.kubectl, curatedpackages.WithBundle(schemaBundle()), curatedpackages.WithSchemaFetcher(fetcher))
+
+	p := pkg("my-harbor", "harbor")
+	p.Spec.Config = "title: Hello"
+
+	err := tt.command.ValidatePackageConfigs(tt.ctx, []packagesv1.Package{p})
+	tt.Expect(err).To(MatchError("package my-harbor: unknown package \"harbor\""))
+}
+
+func TestValidatePackageConfigsWithoutFetcher(t *testing.T) {
+	tt := newPackageTest(t)
+	tt.command = curatedpackages.NewPackageClient(tt.kubectl, curatedpackages.WithBundle(schemaBundle()))
+
+	p := pkg("my-hello", "hello-eks-anywhere")
+	p.Spec.Config = "title: 1"
+
+	tt.Expect(tt.command.ValidatePackageConfigs(tt.ctx, []packagesv1.Package{p})).To(Succeed())
+}
+
+func TestValidatePackagesFile(t *testing.T) {
+	tt := newPackageTest(t)
+	fetcher := mocks.NewMockSchemaFetcher(gomock.NewController(t))
+	bundle := schemaBundle()
+	bundle.Name = "v1-29-1"
+	tt.command = curatedpackages.NewPackageClient(tt.kubectl, curatedpackages.WithSchemaFetcher(fetcher))
+
+	tt.kubectl.EXPECT().ExecuteCommand(tt.ctx, "get", "packageBundleController", "-o", "json", "--kubeconfig", tt.kubeConfig, "--namespace", "eksa-packages", "test").
+		Return(convertJsonToBytes(packagesv1.PackageBundleController{Spec: packagesv1.PackageBundleControllerSpec{ActiveBundle: bundle.Name}}), nil)
+	tt.kubectl.EXPECT().ExecuteCommand(tt.ctx, "get", "packageBundle", "-o", "json", "--kubeconfig", tt.kubeConfig, "--namespace", "eksa-packages", bundle.Name).
+		Return(convertJsonToBytes(bundle), nil)
+	fetcher.EXPECT().FetchSchema(tt.ctx, gomock.Any(), "").Return([]byte(helloSchema), nil)
+
+	err := tt.command.ValidatePackagesFile(tt.ctx, "testdata/packages-config.yaml", tt.kubeConfig)
+	tt.Expect(err).To(MatchError("package my-hello: invalid config:\n  - sourceRegistrty is a forbidden property"))
+}
+
+func TestValidatePackagesFileNoBundle(t *testing.T) {
+	tt := newPackageTest(t)
+	fetcher := mocks.NewMockSchemaFetcher(gomock.NewController(t))
+	tt.command = curatedpackages.NewPackageClient(tt.kubectl, curatedpackages.WithSchemaFetcher(fetcher))
+
+	tt.kubectl.EXPECT().ExecuteCommand(tt.ctx, "get", "packageBundleController", "-o", "json", "--kubeconfig", tt.kubeConfig, "--namespace", "eksa-packages", "test").
+		Return(convertJsonToBytes(nil), errors.New("not found"))
+
+	err := tt.command.ValidatePackagesFile(tt.ctx, "testdata/packages-config.yaml", tt.kubeConfig)
+	tt.Expect(err).To(MatchError("getting the active package bundle for cluster test: not found"))
+}
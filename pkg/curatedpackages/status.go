@@ -0,0 +1,70 @@
+package curatedpackages
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+// PackagesNamespace returns the namespace of the curated packages targeting a cluster.
+func PackagesNamespace(clusterName string) string {
+	return constants.EksaPackagesName + "-" + clusterName
+}
+
+// UpdatePackagesReadyCondition sets the PackagesReady condition of a cluster from the state of the
+// curated packages targeting it. The condition is false with an error severity when a package failed,
+// listing the failing packages, and false with an info severity while packages are being installed.
+func UpdatePackagesReadyCondition(cluster *anywherev1.Cluster, packages []packagesv1.Package) {
+	var failed, pending []string
+	for _, p := range packages {
+		switch {
+		case p.Status.State == packagesv1.StateInstalled:
+		case p.Status.State == packagesv1.StateUnknown || p.Status.Detail != "":
+			failed = append(failed, p.Name)
+		default:
+			pending = append(pending, p.Name)
+		}
+	}
+	sort.Strings(failed)
+	sort.Strings(pending)
+
+	if len(failed) > 0 {
+		conditions.MarkFalse(cluster, anywherev1.PackagesReadyCondition, anywherev1.PackagesFailedReason, clusterv1.ConditionSeverityError,
+			"Packages failed: %s", strings.Join(failed, ", "))
+		return
+	}
+
+	if len(pending) > 0 {
+		conditions.MarkFalse(cluster, anywherev1.PackagesReadyCondition, anywherev1.PackagesInstallingReason, clusterv1.ConditionSeverityInfo,
+			"Waiting for packages to be installed: %s", strings.Join(pending, ", "))
+		return
+	}
+
+	conditions.MarkTrue(cluster, anywherev1.PackagesReadyCondition)
+}
+
+// ReconcilePackagesReady updates the PackagesReady condition of a cluster with the packages in its
+// packages namespace. Nothing is updated if the packages CRDs are not installed yet.
+func ReconcilePackagesReady(ctx context.Context, c client.Client, cluster *anywherev1.Cluster) error {
+	packages := &packagesv1.PackageList{}
+	if err := c.List(ctx, packages, client.InNamespace(PackagesNamespace(cluster.Name))); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil
+		}
+		return fmt.Errorf("listing curated packages: %v", err)
+	}
+
+	UpdatePackagesReadyCondition(cluster, packages.Items)
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package curatedpackages_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/curatedpackages"
+)
+
+func packageWithState(name string, state packagesv1.StateEnum, detail string) packagesv1.Package {
+	return packagesv1.Package{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "eksa-packages-test"},
+		Status:     packagesv1.PackageStatus{State: state, Detail: detail},
+	}
+}
+
+func TestUpdatePackagesReadyCondition(t *testing.T) {
+	tests := []struct {
+		name     string
+		packages []packagesv1.Package
+		want     *anywherev1.Condition
+	}{
+		{
+			name: "no packages",
+			want: &anywherev1.Condition{Type: anywherev1.PackagesReadyCondition, Status: corev1.ConditionTrue},
+		},
+		{
+			name: "all installed",
+			packages: []packagesv1.Package{
+				packageWithState("my-harbor", packagesv1.StateInstalled, ""),
+				packageWithState("my-adot", packagesv1.StateInstalled, ""),
+			},
+			want: &anywherev1.Condition{Type: anywherev1.PackagesReadyCondition, Status: corev1.ConditionTrue},
+		},
+		{
+			name: "installing",
+			packages: []packagesv1.Package{
+				packageWithState("my-harbor", packagesv1.StateInstalling, ""),
+				packageWithState("my-adot", packagesv1.StateInstalled, ""),
+				packageWithState("my-emissary", packagesv1.StateInitializing, ""),
+			},
+			want: &anywherev1.Condition{
+				Type:     anywherev1.PackagesReadyCondition,
+				Status:   corev1.ConditionFalse,
+				Reason:   anywherev1.PackagesInstallingReason,
+				Severity: clusterv1.ConditionSeverityInfo,
+				Message:  "Waiting for packages to be installed: my-emissary, my-harbor",
+			},
+		},
+		{
+			name: "failed",
+			packages: []packagesv1.Package{
+				packageWithState("my-harbor", packagesv1.StateInstalling, "helm install failed"),
+				packageWithState("my-adot", packagesv1.StateUnknown, ""),
+				packageWithState("my-emissary", packagesv1.StateInstalling, ""),
+			},
+			want: &anywherev1.Condition{
+				Type:     anywherev1.PackagesReadyCondition,
+				Status:   corev1.ConditionFalse,
+				Reason:   anywherev1.PackagesFailedReason,
+				Severity: clusterv1.ConditionSeverityError,
+				Message:  "Packages failed: my-adot, my-harbor",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &anywherev1.Cluster{}
+
+			curatedpackages.UpdatePackagesReadyCondition(cluster, tt.packages)
+
+			got := conditions.Get(cluster, anywherev1.PackagesReadyCondition)
+			g.Expect(got).NotTo(BeNil())
+			got.LastTransitionTime = metav1.Time{}
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
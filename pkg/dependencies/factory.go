@@ -30,6 +30,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/files"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
 	gitfactory "github.com/aws/eks-anywhere/pkg/git/factory"
+	"github.com/aws/eks-anywhere/pkg/gitops/argocd"
 	"github.com/aws/eks-anywhere/pkg/gitops/flux"
 	"github.com/aws/eks-anywhere/pkg/govmomi"
 	"github.com/aws/eks-anywhere/pkg/helm"
@@ -81,6 +82,8 @@ type Dependencies struct {
 	KubernetesRetrierClient     *clustermanager.KubernetesRetrierClient
 	Bootstrapper                *bootstrapper.Bootstrapper
 	GitOpsFlux                  *flux.Flux
+	GitOpsArgoCD                *argocd.ArgoCD
+	GitOpsManager               interfaces.GitOpsManager
 	Git                         *gitfactory.GitTools
 	EksdInstaller               *eksd.Installer
 	EksdUpgrader                *eksd.Upgrader
@@ -1321,6 +1324,62 @@ func (f *Factory) WithGitOpsFlux(clusterConfig *v1alpha1.Cluster, fluxConfig *v1
 	return f
 }
 
+// WithGitOpsArgoCD builds the Argo CD GitOps engine.
+func (f *Factory) WithGitOpsArgoCD(clusterConfig *v1alpha1.Cluster, argoCDConfig *v1alpha1.ArgoCDConfig, cliConfig *cliconfig.CliConfig) *Factory {
+	f.WithWriter().WithKubectl()
+
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.dependencies.GitOpsArgoCD != nil {
+			return nil
+		}
+
+		if argoCDConfig != nil && f.dependencies.Git == nil {
+			tools, err := gitfactory.BuildForGitProviderConfig(ctx, clusterConfig, argoCDConfig.Spec.Git, f.dependencies.Writer)
+			if err != nil {
+				return fmt.Errorf("creating Git provider: %v", err)
+			}
+
+			if err = tools.Client.ValidateRemoteExists(ctx); err != nil {
+				return err
+			}
+
+			f.dependencies.Git = tools
+		}
+
+		f.dependencies.GitOpsArgoCD = argocd.New(f.dependencies.Kubectl, f.dependencies.Git, cliConfig)
+
+		return nil
+	})
+
+	return f
+}
+
+// WithGitOps builds the GitOps engine configured for the cluster: Argo CD when the cluster references an
+// ArgoCDConfig, Flux otherwise.
+func (f *Factory) WithGitOps(clusterSpec *cluster.Spec, cliConfig *cliconfig.CliConfig) *Factory {
+	if clusterSpec.ArgoCDConfig != nil {
+		f.WithGitOpsArgoCD(clusterSpec.Cluster, clusterSpec.ArgoCDConfig, cliConfig)
+	} else {
+		f.WithGitOpsFlux(clusterSpec.Cluster, clusterSpec.FluxConfig, cliConfig)
+	}
+
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.dependencies.GitOpsManager != nil {
+			return nil
+		}
+
+		if f.dependencies.GitOpsArgoCD != nil {
+			f.dependencies.GitOpsManager = f.dependencies.GitOpsArgoCD
+		} else {
+			f.dependencies.GitOpsManager = f.dependencies.GitOpsFlux
+		}
+
+		return nil
+	})
+
+	return f
+}
+
 // WithPackageManager builds a package manager.
 func (f *Factory) WithPackageManager(spec *cluster.Spec, packagesLocation, kubeConfig string) *Factory {
 	f.WithKubectl().WithPackageControllerClient(spec, kubeConfig).WithPackageClient()
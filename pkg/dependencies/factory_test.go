@@ -260,6 +260,31 @@ func TestFactoryBuildWithClusterManager(t *testing.T) {
 	tt.Expect(deps.ClusterManager).NotTo(BeNil())
 }
 
+func TestFactoryBuildWithGitOpsFlux(t *testing.T) {
+	tt := newTest(t, vsphere)
+	deps, err := dependencies.NewFactory().
+		WithLocalExecutables().
+		WithGitOps(tt.clusterSpec, nil).
+		Build(context.Background())
+
+	tt.Expect(err).To(BeNil())
+	tt.Expect(deps.GitOpsFlux).NotTo(BeNil())
+	tt.Expect(deps.GitOpsArgoCD).To(BeNil())
+	tt.Expect(deps.GitOpsManager).To(Equal(deps.GitOpsFlux))
+}
+
+func TestFactoryBuildWithGitOpsArgoCDWithoutGit(t *testing.T) {
+	tt := newTest(t, vsphere)
+	deps, err := dependencies.NewFactory().
+		WithLocalExecutables().
+		WithGitOpsArgoCD(tt.clusterSpec.Cluster, nil, nil).
+		Build(context.Background())
+
+	tt.Expect(err).To(BeNil())
+	tt.Expect(deps.GitOpsArgoCD).NotTo(BeNil())
+	tt.Expect(deps.Git).To(BeNil())
+}
+
 func TestFactoryBuildWithHelmEnvClientFactory(t *testing.T) {
 	tt := newTest(t, vsphere)
 	deps, err := dependencies.NewFactory().
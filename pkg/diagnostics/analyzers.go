@@ -51,7 +51,37 @@ func (a *analyzerFactory) managementClusterCrdAnalyzers() []*Analyze {
 func (a *analyzerFactory) PackageAnalyzers() []*Analyze {
 	var analyzers []*Analyze
 	analyzers = append(analyzers, a.packageDeploymentAnalyzers()...)
-	return append(analyzers, a.packageCrdAnalyzers()...)
+	analyzers = append(analyzers, a.packageCrdAnalyzers()...)
+	return append(analyzers, a.packagesReadyAnalyzer())
+}
+
+// packagesReadyAnalyzer reports the curated packages that failed to install for any cluster,
+// reading the PackagesReady condition from the collected clusters.
+func (a *analyzerFactory) packagesReadyAnalyzer() *Analyze {
+	clustersFile := crdPath(fmt.Sprintf("clusters.%s", v1alpha1.GroupVersion.Group)) + ".log"
+	return &Analyze{
+		TextAnalyze: &textAnalyze{
+			analyzeMeta: analyzeMeta{
+				CheckName: fmt.Sprintf("%s condition", v1alpha1.PackagesReadyCondition),
+			},
+			FileName:     clustersFile,
+			RegexPattern: fmt.Sprintf(`"message": "Packages failed: (.*?)",\s*"reason": "%s"`, v1alpha1.PackagesFailedReason),
+			Outcomes: []*outcome{
+				{
+					Fail: &singleOutcome{
+						When:    "true",
+						Message: fmt.Sprintf("One or more curated packages failed to install. See the %s condition of the clusters in %s", v1alpha1.PackagesReadyCondition, clustersFile),
+					},
+				},
+				{
+					Pass: &singleOutcome{
+						When:    "false",
+						Message: "No curated package failures reported",
+					},
+				},
+			},
+		},
+	}
 }
 
 func (a *analyzerFactory) packageCrdAnalyzers() []*Analyze {
@@ -1,6 +1,7 @@
 package diagnostics_test
 
 import (
+	"regexp"
 	"testing"
 
 	. "github.com/onsi/gomega"
@@ -23,6 +24,35 @@ func TestManagementClusterAnalyzers(t *testing.T) {
 	g.Expect(analyzers[12].CustomResourceDefinition.CheckName).To(Equal("bundles.anywhere.eks.amazonaws.com"))
 }
 
+func TestPackageAnalyzers(t *testing.T) {
+	g := NewGomegaWithT(t)
+	factory := diagnostics.NewAnalyzerFactory()
+	analyzers := factory.PackageAnalyzers()
+	g.Expect(analyzers).To(HaveLen(6))
+	g.Expect(getDeploymentStatusAnalyzer(analyzers, "eks-anywhere-packages")).ToNot(BeNil(), "packages controller analyzer should be present")
+
+	packagesReady := analyzers[5].TextAnalyze
+	g.Expect(packagesReady).ToNot(BeNil())
+	g.Expect(packagesReady.CheckName).To(Equal("PackagesReady condition"))
+	g.Expect(packagesReady.FileName).To(Equal("crds/clusters.anywhere.eks.amazonaws.com.log"))
+
+	re := regexp.MustCompile(packagesReady.RegexPattern)
+	g.Expect(re.MatchString(`{
+    "message": "Packages failed: my-harbor",
+    "reason": "PackagesFailed",
+    "severity": "Error",
+    "status": "False",
+    "type": "PackagesReady"
+}`)).To(BeTrue())
+	g.Expect(re.MatchString(`{
+    "message": "Waiting for packages to be installed: my-harbor",
+    "reason": "PackagesInstalling",
+    "severity": "Info",
+    "status": "False",
+    "type": "PackagesReady"
+}`)).To(BeFalse())
+}
+
 func getDeploymentStatusAnalyzer(analyzers []*diagnostics.Analyze, name string) *diagnostics.Analyze {
 	for _, analyzer := range analyzers {
 		if analyzer.DeploymentStatus != nil && analyzer.DeploymentStatus.Name == name {
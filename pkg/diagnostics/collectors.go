@@ -379,6 +379,7 @@ func (c *EKSACollectorFactory) managementClusterCrdCollectors() []*Collect {
 		"controlplaneupgrades.anywhere.eks.amazonaws.com",
 		"machinedeploymentupgrades.anywhere.eks.amazonaws.com",
 		"nodeupgrades.anywhere.eks.amazonaws.com",
+		"clusterupgradeplans.anywhere.eks.amazonaws.com",
 		"bundles.anywhere.eks.amazonaws.com",
 		"clusters.cluster.x-k8s.io",
 		"machinedeployments.cluster.x-k8s.io",
@@ -0,0 +1,70 @@
+package etcdbackup
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+)
+
+// StoreCommand is the manager subcommand run by the etcd backup CronJob to save a snapshot
+// taken by etcdctl to the backup location and remove the old ones.
+const StoreCommand = "etcd-backup-store"
+
+type storeOptions struct {
+	snapshotFile string
+	clusterName  string
+	retention    int
+	dir          string
+	s3           S3Config
+}
+
+// RunStoreCommand parses the StoreCommand args and saves the snapshot to the store they configure.
+func RunStoreCommand(ctx context.Context, out io.Writer, args []string) error {
+	o := &storeOptions{}
+	fs := flag.NewFlagSet(StoreCommand, flag.ContinueOnError)
+	fs.SetOutput(out)
+	fs.StringVar(&o.snapshotFile, "snapshot-file", "", "etcd snapshot file to save")
+	fs.StringVar(&o.clusterName, "cluster", "", "Name of the cluster the snapshot was taken from")
+	fs.IntVar(&o.retention, "retention", DefaultRetention, "Number of snapshots of the cluster to keep")
+	fs.StringVar(&o.dir, "dir", "", "Folder to save the snapshot to")
+	fs.StringVar(&o.s3.Endpoint, "s3-endpoint", "", "URL of the S3 compatible object store")
+	fs.StringVar(&o.s3.Region, "s3-region", "", "Region of the S3 bucket")
+	fs.StringVar(&o.s3.Bucket, "s3-bucket", "", "S3 bucket to save the snapshot to")
+	fs.StringVar(&o.s3.Prefix, "s3-prefix", "", "Prefix of the snapshot key in the S3 bucket")
+	fs.BoolVar(&o.s3.ForcePathStyle, "s3-force-path-style", false, "Use path style URLs for the S3 bucket")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if o.snapshotFile == "" || o.clusterName == "" {
+		return fmt.Errorf("--snapshot-file and --cluster are required")
+	}
+
+	store, err := o.store()
+	if err != nil {
+		return err
+	}
+
+	name, err := Save(ctx, store, o.clusterName, o.snapshotFile, o.retention, time.Now())
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "Saved etcd snapshot %s\n", name)
+	return nil
+}
+
+func (o *storeOptions) store() (Store, error) {
+	switch {
+	case o.dir != "" && o.s3.Bucket != "":
+		return nil, fmt.Errorf("only one of --dir or --s3-bucket can be set")
+	case o.dir != "":
+		return NewDirStore(o.dir), nil
+	case o.s3.Bucket != "":
+		return NewS3Store(o.s3)
+	default:
+		return nil, fmt.Errorf("one of --dir or --s3-bucket is required")
+	}
+}
@@ -0,0 +1,83 @@
+package etcdbackup_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/etcdbackup"
+)
+
+func TestRunStoreCommandDir(t *testing.T) {
+	g := NewWithT(t)
+	dir := t.TempDir()
+	out := &bytes.Buffer{}
+
+	err := etcdbackup.RunStoreCommand(context.Background(), out, []string{
+		"--snapshot-file", writeSnapshotFile(t, "snapshot"),
+		"--cluster", "prod",
+		"--retention", "2",
+		"--dir", dir,
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(out.String()).To(HavePrefix("Saved etcd snapshot prod-"))
+
+	entries, err := os.ReadDir(dir)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(entries).To(HaveLen(1))
+}
+
+func TestRunStoreCommandS3(t *testing.T) {
+	g := NewWithT(t)
+	fake, server := newFakeS3(t, "etcd")
+
+	err := etcdbackup.RunStoreCommand(context.Background(), &bytes.Buffer{}, []string{
+		"--snapshot-file", writeSnapshotFile(t, "snapshot"),
+		"--cluster", "prod",
+		"--s3-endpoint", server.URL,
+		"--s3-bucket", "etcd",
+		"--s3-prefix", "prod",
+		"--s3-force-path-style",
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(fake.keys()).To(ConsistOf(HavePrefix("prod/prod-")))
+}
+
+func TestRunStoreCommandErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "missing snapshot file",
+			args:    []string{"--cluster", "prod", "--dir", "/backups"},
+			wantErr: "--snapshot-file and --cluster are required",
+		},
+		{
+			name:    "no store",
+			args:    []string{"--snapshot-file", "snapshot.db", "--cluster", "prod"},
+			wantErr: "one of --dir or --s3-bucket is required",
+		},
+		{
+			name:    "two stores",
+			args:    []string{"--snapshot-file", "snapshot.db", "--cluster", "prod", "--dir", "/backups", "--s3-bucket", "etcd"},
+			wantErr: "only one of --dir or --s3-bucket can be set",
+		},
+		{
+			name:    "unknown flag",
+			args:    []string{"--bucket", "etcd"},
+			wantErr: "flag provided but not defined: -bucket",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := etcdbackup.RunStoreCommand(context.Background(), &bytes.Buffer{}, tt.args)
+			g.Expect(err).To(MatchError(tt.wantErr))
+		})
+	}
+}
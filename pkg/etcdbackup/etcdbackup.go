@@ -0,0 +1,306 @@
+// Package etcdbackup takes scheduled snapshots of the etcd of a cluster, saves them to a
+// persistent volume or an S3 compatible object store and restores them onto the etcd members.
+package etcdbackup
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+)
+
+const (
+	// ClusterNameLabel is the label with the name of the cluster on the etcd backup objects.
+	ClusterNameLabel = "anywhere.eks.amazonaws.com/etcd-backup"
+
+	// SnapshotContainerName is the name of the container that takes the etcd snapshot.
+	SnapshotContainerName = "etcd-snapshot"
+
+	// StoreContainerName is the name of the container that saves the etcd snapshot to the backup location.
+	StoreContainerName = "etcd-snapshot-store"
+
+	// AccessKeyIDKey is the key of the S3 credentials secret holding the access key id.
+	AccessKeyIDKey = "AWS_ACCESS_KEY_ID"
+
+	// SecretAccessKeyKey is the key of the S3 credentials secret holding the secret access key.
+	SecretAccessKeyKey = "AWS_SECRET_ACCESS_KEY"
+
+	// EndpointAnnotation is the annotation on the etcd backup CronJob with the etcd endpoint
+	// the snapshots are taken from.
+	EndpointAnnotation = "anywhere.eks.amazonaws.com/etcd-backup-endpoint"
+
+	// EndpointSinceAnnotation is the annotation on the etcd backup CronJob with the schedule time
+	// of the first run taking the snapshots from the endpoint in EndpointAnnotation.
+	EndpointSinceAnnotation = "anywhere.eks.amazonaws.com/etcd-backup-endpoint-since"
+
+	defaultCertificatesDir = "/etc/kubernetes/pki"
+	stackedEtcdEndpoint    = "https://127.0.0.1:2379"
+	certificatesVolume     = "etcd-certs"
+	snapshotsVolume        = "snapshots"
+	snapshotsDir           = "/snapshots"
+	backupsVolume          = "backups"
+	backupsDir             = "/backups"
+	snapshotFile           = snapshotsDir + "/snapshot.db"
+	jobHistoryLimit        = 3
+	jobBackoffLimit        = 2
+)
+
+// CronJobName returns the name of the etcd backup CronJob of a cluster.
+func CronJobName(clusterName string) string {
+	return fmt.Sprintf("%s-etcd-backup", clusterName)
+}
+
+// CredentialsSecretName returns the name of the copy of the S3 credentials secret
+// used by the etcd backup CronJob of a cluster.
+func CredentialsSecretName(clusterName string) string {
+	return fmt.Sprintf("%s-etcd-backup-s3", clusterName)
+}
+
+// CronJob returns the CronJob that takes the scheduled etcd snapshots of a cluster. It runs on
+// the control plane nodes, which have the etcd client certs for both stacked and external etcd.
+// etcdctl takes the snapshot with the etcd image and the controller image saves it to the
+// backup location and removes the old snapshots.
+// etcdctl only takes snapshots from a single endpoint. With external etcd, the endpoint of the
+// current CronJob is kept until one of its runs fails, then the next etcd endpoint is used.
+// current is nil when the cluster doesn't have an etcd backup CronJob yet.
+func CronJob(cluster *anywherev1.Cluster, kcp *controlplanev1.KubeadmControlPlane, current *batchv1.CronJob, etcdImage, controllerImage string) *batchv1.CronJob {
+	backup := cluster.Spec.EtcdBackup
+	certsDir, endpoints, etcdEnv := etcdClientConfig(kcp)
+	endpoint, since := snapshotEndpoint(endpoints, current)
+	etcdEnv = append(etcdEnv, corev1.EnvVar{Name: "ETCDCTL_ENDPOINTS", Value: endpoint})
+	annotations := map[string]string{EndpointAnnotation: endpoint}
+	if since != "" {
+		annotations[EndpointSinceAnnotation] = since
+	}
+
+	retention := backup.Retention
+	if retention == 0 {
+		retention = DefaultRetention
+	}
+
+	storeArgs := []string{
+		StoreCommand,
+		"--snapshot-file", snapshotFile,
+		"--cluster", cluster.Name,
+		"--retention", strconv.Itoa(retention),
+	}
+	var storeEnv []corev1.EnvVar
+	hostPathDirectory := corev1.HostPathDirectory
+	volumes := []corev1.Volume{
+		{
+			Name: certificatesVolume,
+			VolumeSource: corev1.VolumeSource{
+				HostPath: &corev1.HostPathVolumeSource{
+					Path: certsDir,
+					Type: &hostPathDirectory,
+				},
+			},
+		},
+		{
+			Name: snapshotsVolume,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		},
+	}
+	storeMounts := []corev1.VolumeMount{{Name: snapshotsVolume, MountPath: snapshotsDir, ReadOnly: true}}
+
+	if pvc := backup.PersistentVolumeClaim; pvc != nil {
+		storeArgs = append(storeArgs, "--dir", backupsDir)
+		volumes = append(volumes, corev1.Volume{
+			Name: backupsVolume,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: pvc.ClaimName},
+			},
+		})
+		storeMounts = append(storeMounts, corev1.VolumeMount{Name: backupsVolume, MountPath: backupsDir})
+	}
+
+	if s3 := backup.S3; s3 != nil {
+		storeArgs = append(storeArgs, "--s3-bucket", s3.Bucket)
+		if s3.Endpoint != "" {
+			storeArgs = append(storeArgs, "--s3-endpoint", s3.Endpoint)
+		}
+		if s3.Region != "" {
+			storeArgs = append(storeArgs, "--s3-region", s3.Region)
+		}
+		if s3.Prefix != "" {
+			storeArgs = append(storeArgs, "--s3-prefix", s3.Prefix)
+		}
+		if s3.ForcePathStyle {
+			storeArgs = append(storeArgs, "--s3-force-path-style")
+		}
+		storeEnv = []corev1.EnvVar{
+			secretEnvVar(AccessKeyIDKey, CredentialsSecretName(cluster.Name)),
+			secretEnvVar(SecretAccessKeyKey, CredentialsSecretName(cluster.Name)),
+		}
+	}
+
+	labels := map[string]string{ClusterNameLabel: cluster.Name}
+
+	return &batchv1.CronJob{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: batchv1.SchemeGroupVersion.String(),
+			Kind:       "CronJob",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        CronJobName(cluster.Name),
+			Namespace:   constants.EksaSystemNamespace,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: batchv1.CronJobSpec{
+			Schedule:                   backup.Schedule,
+			ConcurrencyPolicy:          batchv1.ForbidConcurrent,
+			SuccessfulJobsHistoryLimit: ptr.Int32(jobHistoryLimit),
+			FailedJobsHistoryLimit:     ptr.Int32(jobHistoryLimit),
+			JobTemplate: batchv1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: batchv1.JobSpec{
+					BackoffLimit: ptr.Int32(jobBackoffLimit),
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{Labels: labels},
+						Spec: corev1.PodSpec{
+							RestartPolicy: corev1.RestartPolicyNever,
+							HostNetwork:   true,
+							NodeSelector:  map[string]string{"node-role.kubernetes.io/control-plane": ""},
+							Tolerations: []corev1.Toleration{
+								{
+									Key:    "node-role.kubernetes.io/control-plane",
+									Effect: corev1.TaintEffectNoSchedule,
+								},
+								{
+									Key:    "node-role.kubernetes.io/master",
+									Effect: corev1.TaintEffectNoSchedule,
+								},
+							},
+							// The etcd client certs and the snapshot taken by etcdctl are only readable by root.
+							SecurityContext: &corev1.PodSecurityContext{
+								RunAsUser:  ptr.Int64(0),
+								RunAsGroup: ptr.Int64(0),
+							},
+							InitContainers: []corev1.Container{
+								{
+									Name:    SnapshotContainerName,
+									Image:   etcdImage,
+									Command: []string{"etcdctl", "snapshot", "save", snapshotFile},
+									Env:     etcdEnv,
+									VolumeMounts: []corev1.VolumeMount{
+										{Name: certificatesVolume, MountPath: certsDir, ReadOnly: true},
+										{Name: snapshotsVolume, MountPath: snapshotsDir},
+									},
+								},
+							},
+							Containers: []corev1.Container{
+								{
+									Name:         StoreContainerName,
+									Image:        controllerImage,
+									Command:      []string{"manager"},
+									Args:         storeArgs,
+									Env:          storeEnv,
+									VolumeMounts: storeMounts,
+								},
+							},
+							Volumes: volumes,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// etcdClientConfig returns the folder with the etcd client certs on the control plane nodes,
+// the etcd endpoints and the etcdctl env vars to connect to the etcd members with them.
+func etcdClientConfig(kcp *controlplanev1.KubeadmControlPlane) (string, []string, []corev1.EnvVar) {
+	certsDir := defaultCertificatesDir
+	clusterConfig := kcp.Spec.KubeadmConfigSpec.ClusterConfiguration
+	if clusterConfig != nil && clusterConfig.CertificatesDir != "" {
+		certsDir = clusterConfig.CertificatesDir
+	}
+
+	endpoints := []string{stackedEtcdEndpoint}
+	caFile := filepath.Join(certsDir, "etcd", "ca.crt")
+	certFile := filepath.Join(certsDir, "apiserver-etcd-client.crt")
+	keyFile := filepath.Join(certsDir, "apiserver-etcd-client.key")
+	if clusterConfig != nil && clusterConfig.Etcd.External != nil && len(clusterConfig.Etcd.External.Endpoints) > 0 {
+		external := clusterConfig.Etcd.External
+		endpoints = external.Endpoints
+		caFile = external.CAFile
+		certFile = external.CertFile
+		keyFile = external.KeyFile
+	}
+
+	return certsDir, endpoints, []corev1.EnvVar{
+		{Name: "ETCDCTL_API", Value: "3"},
+		{Name: "ETCDCTL_CACERT", Value: caFile},
+		{Name: "ETCDCTL_CERT", Value: certFile},
+		{Name: "ETCDCTL_KEY", Value: keyFile},
+	}
+}
+
+// snapshotEndpoint returns the etcd endpoint to take the snapshots from and the schedule time of the
+// first run using it. It keeps the endpoint of the current CronJob and moves on to the next one when
+// a run scheduled after the endpoint was selected has failed.
+func snapshotEndpoint(endpoints []string, current *batchv1.CronJob) (endpoint, since string) {
+	if len(endpoints) == 1 || current == nil {
+		return endpoints[0], ""
+	}
+
+	i := 0
+	for j, e := range endpoints {
+		if e == current.Annotations[EndpointAnnotation] {
+			i = j
+			break
+		}
+	}
+	since = current.Annotations[EndpointSinceAnnotation]
+
+	lastRun, failed := lastRunFailed(current)
+	if !failed {
+		return endpoints[i], since
+	}
+
+	selected, err := time.Parse(time.RFC3339, since)
+	if err == nil && !lastRun.After(selected) {
+		return endpoints[i], since
+	}
+
+	return endpoints[(i+1)%len(endpoints)], lastRun.Format(time.RFC3339)
+}
+
+// lastRunFailed returns the schedule time of the last run of a CronJob and whether it failed.
+func lastRunFailed(cronJob *batchv1.CronJob) (time.Time, bool) {
+	status := cronJob.Status
+	if len(status.Active) > 0 || status.LastScheduleTime == nil {
+		return time.Time{}, false
+	}
+
+	lastRun := status.LastScheduleTime.Time
+	if status.LastSuccessfulTime != nil && !status.LastSuccessfulTime.Time.Before(lastRun) {
+		return lastRun, false
+	}
+
+	return lastRun, true
+}
+
+func secretEnvVar(key, secretName string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: key,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+				Key:                  key,
+			},
+		},
+	}
+}
@@ -0,0 +1,248 @@
+package etcdbackup_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/etcdbackup"
+)
+
+const (
+	etcdImage       = "public.ecr.aws/eks-distro/etcd-io/etcd:v3.5.10-eks-1-28-9"
+	controllerImage = "public.ecr.aws/eks-anywhere/eks-anywhere-cluster-controller:v0.19.0"
+)
+
+func backupCluster(backup *anywherev1.EtcdBackup) *anywherev1.Cluster {
+	return &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "default"},
+		Spec: anywherev1.ClusterSpec{
+			EtcdBackup: backup,
+		},
+	}
+}
+
+func kubeadmControlPlane(clusterConfig *bootstrapv1.ClusterConfiguration) *controlplanev1.KubeadmControlPlane {
+	return &controlplanev1.KubeadmControlPlane{
+		Spec: controlplanev1.KubeadmControlPlaneSpec{
+			KubeadmConfigSpec: bootstrapv1.KubeadmConfigSpec{
+				ClusterConfiguration: clusterConfig,
+			},
+		},
+	}
+}
+
+func envValues(env []corev1.EnvVar) map[string]string {
+	values := map[string]string{}
+	for _, e := range env {
+		values[e.Name] = e.Value
+	}
+
+	return values
+}
+
+func TestCronJobStackedEtcdPersistentVolumeClaim(t *testing.T) {
+	g := NewWithT(t)
+	cluster := backupCluster(&anywherev1.EtcdBackup{
+		Schedule:              "0 */6 * * *",
+		PersistentVolumeClaim: &anywherev1.EtcdBackupPersistentVolumeClaim{ClaimName: "etcd-backups"},
+	})
+
+	cronJob := etcdbackup.CronJob(cluster, kubeadmControlPlane(nil), nil, etcdImage, controllerImage)
+
+	g.Expect(cronJob.Name).To(Equal("prod-etcd-backup"))
+	g.Expect(cronJob.Namespace).To(Equal("eksa-system"))
+	g.Expect(cronJob.Spec.Schedule).To(Equal("0 */6 * * *"))
+	g.Expect(cronJob.Spec.ConcurrencyPolicy).To(Equal(batchv1.ForbidConcurrent))
+
+	pod := cronJob.Spec.JobTemplate.Spec.Template.Spec
+	g.Expect(pod.HostNetwork).To(BeTrue())
+	g.Expect(pod.NodeSelector).To(HaveKey("node-role.kubernetes.io/control-plane"))
+
+	g.Expect(pod.InitContainers).To(HaveLen(1))
+	snapshot := pod.InitContainers[0]
+	g.Expect(snapshot.Image).To(Equal(etcdImage))
+	g.Expect(snapshot.Command).To(Equal([]string{"etcdctl", "snapshot", "save", "/snapshots/snapshot.db"}))
+	g.Expect(envValues(snapshot.Env)).To(Equal(map[string]string{
+		"ETCDCTL_API":       "3",
+		"ETCDCTL_ENDPOINTS": "https://127.0.0.1:2379",
+		"ETCDCTL_CACERT":    "/etc/kubernetes/pki/etcd/ca.crt",
+		"ETCDCTL_CERT":      "/etc/kubernetes/pki/apiserver-etcd-client.crt",
+		"ETCDCTL_KEY":       "/etc/kubernetes/pki/apiserver-etcd-client.key",
+	}))
+
+	g.Expect(pod.Containers).To(HaveLen(1))
+	store := pod.Containers[0]
+	g.Expect(store.Image).To(Equal(controllerImage))
+	g.Expect(store.Command).To(Equal([]string{"manager"}))
+	g.Expect(store.Args).To(Equal([]string{
+		"etcd-backup-store",
+		"--snapshot-file", "/snapshots/snapshot.db",
+		"--cluster", "prod",
+		"--retention", "7",
+		"--dir", "/backups",
+	}))
+	g.Expect(store.Env).To(BeEmpty())
+
+	g.Expect(pod.Volumes).To(ContainElement(corev1.Volume{
+		Name: "backups",
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "etcd-backups"},
+		},
+	}))
+	g.Expect(pod.Volumes[0].HostPath.Path).To(Equal("/etc/kubernetes/pki"))
+}
+
+func TestCronJobExternalEtcdS3(t *testing.T) {
+	g := NewWithT(t)
+	cluster := backupCluster(&anywherev1.EtcdBackup{
+		Schedule:  "0 2 * * *",
+		Retention: 14,
+		S3: &anywherev1.EtcdBackupS3{
+			Endpoint:              "https://minio.example.com:9000",
+			Region:                "us-west-2",
+			Bucket:                "etcd",
+			Prefix:                "prod",
+			ForcePathStyle:        true,
+			CredentialsSecretName: "etcd-backup-credentials",
+		},
+	})
+	kcp := kubeadmControlPlane(&bootstrapv1.ClusterConfiguration{
+		CertificatesDir: "/var/lib/kubeadm/pki",
+		Etcd: bootstrapv1.Etcd{
+			External: &bootstrapv1.ExternalEtcd{
+				Endpoints: []string{"https://10.0.0.1:2379", "https://10.0.0.2:2379"},
+				CAFile:    "/var/lib/kubeadm/pki/etcd/ca.crt",
+				CertFile:  "/var/lib/kubeadm/pki/server-etcd-client.crt",
+				KeyFile:   "/var/lib/kubeadm/pki/apiserver-etcd-client.key",
+			},
+		},
+	})
+
+	cronJob := etcdbackup.CronJob(cluster, kcp, nil, etcdImage, controllerImage)
+
+	pod := cronJob.Spec.JobTemplate.Spec.Template.Spec
+	g.Expect(envValues(pod.InitContainers[0].Env)).To(Equal(map[string]string{
+		"ETCDCTL_API":       "3",
+		"ETCDCTL_ENDPOINTS": "https://10.0.0.1:2379",
+		"ETCDCTL_CACERT":    "/var/lib/kubeadm/pki/etcd/ca.crt",
+		"ETCDCTL_CERT":      "/var/lib/kubeadm/pki/server-etcd-client.crt",
+		"ETCDCTL_KEY":       "/var/lib/kubeadm/pki/apiserver-etcd-client.key",
+	}))
+	g.Expect(cronJob.Annotations).To(Equal(map[string]string{
+		"anywhere.eks.amazonaws.com/etcd-backup-endpoint": "https://10.0.0.1:2379",
+	}))
+	g.Expect(pod.Volumes[0].HostPath.Path).To(Equal("/var/lib/kubeadm/pki"))
+
+	store := pod.Containers[0]
+	g.Expect(store.Args).To(Equal([]string{
+		"etcd-backup-store",
+		"--snapshot-file", "/snapshots/snapshot.db",
+		"--cluster", "prod",
+		"--retention", "14",
+		"--s3-bucket", "etcd",
+		"--s3-endpoint", "https://minio.example.com:9000",
+		"--s3-region", "us-west-2",
+		"--s3-prefix", "prod",
+		"--s3-force-path-style",
+	}))
+	g.Expect(store.Env).To(HaveLen(2))
+	g.Expect(store.Env[0].ValueFrom.SecretKeyRef.Name).To(Equal("prod-etcd-backup-s3"))
+	g.Expect(store.Env[0].ValueFrom.SecretKeyRef.Key).To(Equal("AWS_ACCESS_KEY_ID"))
+	g.Expect(store.Env[1].ValueFrom.SecretKeyRef.Key).To(Equal("AWS_SECRET_ACCESS_KEY"))
+	for _, v := range pod.Volumes {
+		g.Expect(v.PersistentVolumeClaim).To(BeNil())
+	}
+}
+
+func TestCronJobExternalEtcdEndpointFailover(t *testing.T) {
+	scheduled := metav1.NewTime(time.Date(2024, 3, 1, 2, 0, 0, 0, time.UTC))
+	succeeded := metav1.NewTime(scheduled.Add(time.Minute))
+	earlier := metav1.NewTime(scheduled.Add(-time.Hour))
+	tests := []struct {
+		name          string
+		endpoint      string
+		since         string
+		status        batchv1.CronJobStatus
+		wantEndpoint  string
+		wantSinceTime string
+	}{
+		{
+			name:         "no runs",
+			endpoint:     "https://10.0.0.2:2379",
+			wantEndpoint: "https://10.0.0.2:2379",
+		},
+		{
+			name:         "last run succeeded",
+			endpoint:     "https://10.0.0.2:2379",
+			status:       batchv1.CronJobStatus{LastScheduleTime: &scheduled, LastSuccessfulTime: &succeeded},
+			wantEndpoint: "https://10.0.0.2:2379",
+		},
+		{
+			name:         "last run in progress",
+			endpoint:     "https://10.0.0.2:2379",
+			status:       batchv1.CronJobStatus{LastScheduleTime: &scheduled, Active: []corev1.ObjectReference{{Name: "job"}}},
+			wantEndpoint: "https://10.0.0.2:2379",
+		},
+		{
+			name:          "last run failed",
+			endpoint:      "https://10.0.0.2:2379",
+			status:        batchv1.CronJobStatus{LastScheduleTime: &scheduled, LastSuccessfulTime: &earlier},
+			wantEndpoint:  "https://10.0.0.3:2379",
+			wantSinceTime: "2024-03-01T02:00:00Z",
+		},
+		{
+			name:          "last endpoint failed",
+			endpoint:      "https://10.0.0.3:2379",
+			since:         "2024-03-01T00:00:00Z",
+			status:        batchv1.CronJobStatus{LastScheduleTime: &scheduled},
+			wantEndpoint:  "https://10.0.0.1:2379",
+			wantSinceTime: "2024-03-01T02:00:00Z",
+		},
+		{
+			name:          "failed run already handled",
+			endpoint:      "https://10.0.0.3:2379",
+			since:         "2024-03-01T02:00:00Z",
+			status:        batchv1.CronJobStatus{LastScheduleTime: &scheduled},
+			wantEndpoint:  "https://10.0.0.3:2379",
+			wantSinceTime: "2024-03-01T02:00:00Z",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := backupCluster(&anywherev1.EtcdBackup{
+				Schedule:              "0 2 * * *",
+				PersistentVolumeClaim: &anywherev1.EtcdBackupPersistentVolumeClaim{ClaimName: "etcd-backups"},
+			})
+			kcp := kubeadmControlPlane(&bootstrapv1.ClusterConfiguration{
+				Etcd: bootstrapv1.Etcd{
+					External: &bootstrapv1.ExternalEtcd{
+						Endpoints: []string{"https://10.0.0.1:2379", "https://10.0.0.2:2379", "https://10.0.0.3:2379"},
+					},
+				},
+			})
+			current := etcdbackup.CronJob(cluster, kcp, nil, etcdImage, controllerImage)
+			current.Annotations = map[string]string{etcdbackup.EndpointAnnotation: tt.endpoint}
+			if tt.since != "" {
+				current.Annotations[etcdbackup.EndpointSinceAnnotation] = tt.since
+			}
+			current.Status = tt.status
+
+			cronJob := etcdbackup.CronJob(cluster, kcp, current, etcdImage, controllerImage)
+
+			env := envValues(cronJob.Spec.JobTemplate.Spec.Template.Spec.InitContainers[0].Env)
+			g.Expect(env["ETCDCTL_ENDPOINTS"]).To(Equal(tt.wantEndpoint))
+			g.Expect(cronJob.Annotations[etcdbackup.EndpointAnnotation]).To(Equal(tt.wantEndpoint))
+			g.Expect(cronJob.Annotations[etcdbackup.EndpointSinceAnnotation]).To(Equal(tt.wantSinceTime))
+		})
+	}
+}
@@ -0,0 +1,347 @@
+package etcdbackup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const (
+	defaultEtcdClientURL   = "https://127.0.0.1:2379"
+	stackedEtcdPKIDir      = "/etc/kubernetes/pki/etcd"
+	externalEtcdPKIDir     = "/etc/etcd/pki"
+	defragCommandTimeout   = "5m"
+	noSpaceAlarm           = "NOSPACE"
+	memberNotRunningReason = "member is not running on any of the etcd machines"
+)
+
+var alarmPattern = regexp.MustCompile(`memberID:(\d+) alarm:(\w+)`)
+
+// MemberStatus is the health of an etcd member of a cluster.
+type MemberStatus struct {
+	Member
+	// ID is the etcd member ID, in hex as printed by etcdctl.
+	ID string `json:"id"`
+	// Healthy is true when the member answers to status requests.
+	Healthy bool `json:"healthy"`
+	// Leader is true for the raft leader of the etcd cluster.
+	Leader bool `json:"leader"`
+	// Version is the etcd version of the member.
+	Version string `json:"version,omitempty"`
+	// DBSize is the size in bytes of the backend database of the member.
+	DBSize int64 `json:"dbSize"`
+	// DBSizeInUse is the size in bytes of the backend database logically in use. The difference with DBSize
+	// is reclaimed by a defragmentation.
+	DBSizeInUse int64 `json:"dbSizeInUse"`
+	// Alarms are the active alarms of the member, like NOSPACE.
+	Alarms []string `json:"alarms,omitempty"`
+	// Error is the reason the member is not healthy.
+	Error string `json:"error,omitempty"`
+}
+
+// Maintainer inspects, defragments and removes the etcd members of a cluster running etcdctl on
+// their machines over SSH.
+type Maintainer struct {
+	remote
+}
+
+// NewMaintainer returns a new Maintainer that connects to the etcd members with the SSH key privateKeyPath.
+func NewMaintainer(ssh SSHRunner, privateKeyPath, username string) *Maintainer {
+	return &Maintainer{
+		remote: remote{
+			ssh:            ssh,
+			privateKeyPath: privateKeyPath,
+			username:       username,
+		},
+	}
+}
+
+// ValidateMaintenance checks that etcdctl can be run on the etcd members of a cluster over SSH.
+// Bottlerocket nodes don't allow running etcdctl on the host, so they are not supported.
+func ValidateMaintenance(ctx context.Context, client kubernetes.Reader, cluster *anywherev1.Cluster) error {
+	return validateSSHAccess(ctx, client, cluster, "managing etcd members")
+}
+
+// Status returns the health, database size and alarms of the etcd members running in the machines ips.
+// Members of the etcd cluster that don't run in any of those machines are returned as unhealthy.
+// Members that can't be reached are reported as unhealthy instead of failing.
+func (m *Maintainer) Status(ctx context.Context, externalEtcd bool, ips []string) ([]MemberStatus, error) {
+	statuses := make([]MemberStatus, 0, len(ips))
+	for _, ip := range ips {
+		statuses = append(statuses, m.memberStatus(ctx, ip, externalEtcd))
+	}
+
+	healthy, ok := firstHealthy(statuses)
+	if !ok {
+		return statuses, nil
+	}
+
+	out, err := m.etcdctl(ctx, healthy.Member, externalEtcd, "member", "list", "-w", "json")
+	if err != nil {
+		return nil, fmt.Errorf("listing etcd members: %v", err)
+	}
+	statuses, err = addListedMembers(statuses, out)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err = m.etcdctl(ctx, healthy.Member, externalEtcd, "alarm", "list")
+	if err != nil {
+		return nil, fmt.Errorf("listing etcd alarms: %v", err)
+	}
+	addAlarms(statuses, out)
+
+	return statuses, nil
+}
+
+// Defrag defragments the etcd members running in the machines ips one at a time, the leader last,
+// checking each member answers to status requests before moving on to the next one. Once all the
+// members are defragmented, the NOSPACE alarms are disarmed.
+// All the members must be healthy, since a member doesn't serve requests while it's defragmented.
+// The members are checked with endpoint status instead of endpoint health, since the latter reports
+// every member as unhealthy while an alarm is active and the NOSPACE alarm is only disarmed at the end.
+func (m *Maintainer) Defrag(ctx context.Context, externalEtcd bool, ips []string) error {
+	statuses, err := m.Status(ctx, externalEtcd, ips)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range statuses {
+		if !s.Healthy {
+			return fmt.Errorf("etcd member %s is not healthy, all members must be healthy to defragment them: %s", s.displayName(), s.Error)
+		}
+	}
+
+	sort.SliceStable(statuses, func(i, j int) bool {
+		return !statuses[i].Leader && statuses[j].Leader
+	})
+
+	for _, s := range statuses {
+		logger.Info("Defragmenting etcd member", "member", s.Name, "ip", s.IP, "dbSize", s.DBSize, "dbSizeInUse", s.DBSizeInUse)
+		if _, err := m.etcdctl(ctx, s.Member, externalEtcd, "defrag", "--command-timeout", defragCommandTimeout); err != nil {
+			return fmt.Errorf("defragmenting etcd member %s: %v", s.Name, err)
+		}
+		out, err := m.etcdctl(ctx, s.Member, externalEtcd, "endpoint", "status", "-w", "json")
+		if err == nil {
+			err = parseEndpointStatus(&MemberStatus{}, out)
+		}
+		if err != nil {
+			return fmt.Errorf("etcd member %s is not healthy after defragmenting it: %v", s.Name, err)
+		}
+	}
+
+	if !hasAlarm(statuses, noSpaceAlarm) {
+		return nil
+	}
+
+	logger.Info("Disarming etcd NOSPACE alarms")
+	if _, err := m.etcdctl(ctx, statuses[0].Member, externalEtcd, "alarm", "disarm"); err != nil {
+		return fmt.Errorf("disarming etcd alarms: %v", err)
+	}
+
+	return nil
+}
+
+// RemoveMember removes the etcd member with name or ID member from the etcd cluster. Only unhealthy
+// members can be removed, and only if the remaining healthy members keep the quorum of the etcd cluster.
+func (m *Maintainer) RemoveMember(ctx context.Context, externalEtcd bool, ips []string, member string) error {
+	statuses, err := m.Status(ctx, externalEtcd, ips)
+	if err != nil {
+		return err
+	}
+
+	var target *MemberStatus
+	healthy := 0
+	for i := range statuses {
+		if statuses[i].Healthy {
+			healthy++
+		}
+		if statuses[i].Name == member || statuses[i].ID == member {
+			target = &statuses[i]
+		}
+	}
+
+	if target == nil {
+		return fmt.Errorf("etcd member %s not found", member)
+	}
+	if target.Healthy {
+		return fmt.Errorf("etcd member %s is healthy, only unhealthy members can be removed", member)
+	}
+	if target.ID == "" {
+		return fmt.Errorf("etcd member %s is not part of the etcd cluster", member)
+	}
+
+	quorum := (len(statuses)-1)/2 + 1
+	if healthy < quorum {
+		return fmt.Errorf("removing etcd member %s would leave %d healthy members, less than the quorum of %d", member, healthy, quorum)
+	}
+
+	h, _ := firstHealthy(statuses)
+	logger.Info("Removing etcd member", "member", target.displayName(), "id", target.ID)
+	if _, err := m.etcdctl(ctx, h.Member, externalEtcd, "member", "remove", target.ID); err != nil {
+		return fmt.Errorf("removing etcd member %s: %v", member, err)
+	}
+
+	return nil
+}
+
+func (m *Maintainer) memberStatus(ctx context.Context, ip string, externalEtcd bool) MemberStatus {
+	member, err := m.member(ctx, ip, externalEtcd)
+	if err != nil {
+		return MemberStatus{Member: Member{IP: ip}, Error: err.Error()}
+	}
+
+	status := MemberStatus{Member: member}
+	out, err := m.etcdctl(ctx, member, externalEtcd, "endpoint", "status", "-w", "json")
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+
+	if err := parseEndpointStatus(&status, out); err != nil {
+		status.Error = err.Error()
+		return status
+	}
+
+	status.Healthy = true
+	return status
+}
+
+// etcdctl runs etcdctl against the local etcd member of a machine. Stacked etcd members run etcdctl
+// in the etcd static pod container, external etcd members use the etcdctl installed by etcdadm.
+func (m *Maintainer) etcdctl(ctx context.Context, member Member, externalEtcd bool, args ...string) (string, error) {
+	endpoint := member.ClientURL
+	if endpoint == "" {
+		endpoint = defaultEtcdClientURL
+	}
+
+	etcdctl := fmt.Sprintf("crictl exec $(crictl ps -q --name ^etcd$) etcdctl --cacert %[1]s/ca.crt --cert %[1]s/healthcheck-client.crt --key %[1]s/healthcheck-client.key",
+		stackedEtcdPKIDir)
+	if externalEtcd {
+		etcdctl = fmt.Sprintf("ETCDCTL_API=3 %[1]s --cacert %[2]s/ca.crt --cert %[2]s/etcdctl-etcd-client.crt --key %[2]s/etcdctl-etcd-client.key",
+			externalEtcdctl, externalEtcdPKIDir)
+	}
+
+	script := fmt.Sprintf("%s --endpoints %s %s", etcdctl, endpoint, strings.Join(args, " "))
+	return m.ssh.RunCommand(ctx, m.privateKeyPath, m.username, member.IP, "sudo", "sh", "-c", quote(script))
+}
+
+func (s MemberStatus) displayName() string {
+	if s.Name != "" {
+		return s.Name
+	}
+
+	return s.IP
+}
+
+func firstHealthy(statuses []MemberStatus) (MemberStatus, bool) {
+	for _, s := range statuses {
+		if s.Healthy {
+			return s, true
+		}
+	}
+
+	return MemberStatus{}, false
+}
+
+func hasAlarm(statuses []MemberStatus, alarm string) bool {
+	for _, s := range statuses {
+		for _, a := range s.Alarms {
+			if a == alarm {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func memberID(id uint64) string {
+	return strconv.FormatUint(id, 16)
+}
+
+func parseEndpointStatus(status *MemberStatus, out string) error {
+	endpoints := []struct {
+		Status struct {
+			Header struct {
+				MemberID uint64 `json:"member_id"`
+			} `json:"header"`
+			Version     string `json:"version"`
+			DBSize      int64  `json:"dbSize"`
+			DBSizeInUse int64  `json:"dbSizeInUse"`
+			Leader      uint64 `json:"leader"`
+		} `json:"Status"`
+	}{}
+	if err := json.Unmarshal([]byte(out), &endpoints); err != nil {
+		return fmt.Errorf("parsing etcd endpoint status: %v", err)
+	}
+	if len(endpoints) == 0 {
+		return fmt.Errorf("etcd endpoint status is empty")
+	}
+
+	s := endpoints[0].Status
+	status.ID = memberID(s.Header.MemberID)
+	status.Leader = s.Leader == s.Header.MemberID
+	status.Version = s.Version
+	status.DBSize = s.DBSize
+	status.DBSizeInUse = s.DBSizeInUse
+
+	return nil
+}
+
+// addListedMembers completes the statuses with the names and IDs from the etcd member list, and
+// adds the members that are not running in any of the machines.
+func addListedMembers(statuses []MemberStatus, out string) ([]MemberStatus, error) {
+	list := struct {
+		Members []struct {
+			ID   uint64 `json:"ID"`
+			Name string `json:"name"`
+		} `json:"members"`
+	}{}
+	if err := json.Unmarshal([]byte(out), &list); err != nil {
+		return nil, fmt.Errorf("parsing etcd member list: %v", err)
+	}
+
+	for _, listed := range list.Members {
+		id := memberID(listed.ID)
+		found := false
+		for i := range statuses {
+			if statuses[i].ID == id || (statuses[i].ID == "" && statuses[i].Name != "" && statuses[i].Name == listed.Name) {
+				statuses[i].ID = id
+				found = true
+				break
+			}
+		}
+		if !found {
+			statuses = append(statuses, MemberStatus{
+				Member: Member{Name: listed.Name},
+				ID:     id,
+				Error:  memberNotRunningReason,
+			})
+		}
+	}
+
+	return statuses, nil
+}
+
+func addAlarms(statuses []MemberStatus, out string) {
+	for _, match := range alarmPattern.FindAllStringSubmatch(out, -1) {
+		id, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		for i := range statuses {
+			if statuses[i].ID == memberID(id) {
+				statuses[i].Alarms = append(statuses[i].Alarms, match[2])
+			}
+		}
+	}
+}
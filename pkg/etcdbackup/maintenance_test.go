@@ -0,0 +1,303 @@
+package etcdbackup_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/etcdbackup"
+	"github.com/aws/eks-anywhere/pkg/etcdbackup/mocks"
+)
+
+const (
+	memberListJSON = `{"header":{"cluster_id":1,"member_id":10,"raft_term":2},"members":[` +
+		`{"ID":10,"name":"prod-cp-a","peerURLs":["https://10.0.0.1:2380"]},` +
+		`{"ID":11,"name":"prod-cp-b","peerURLs":["https://10.0.0.2:2380"]},` +
+		`{"ID":12,"name":"prod-cp-c","peerURLs":["https://10.0.0.3:2380"]}]}`
+	stackedEtcdctl  = "crictl exec $(crictl ps -q --name ^etcd$) etcdctl --cacert /etc/kubernetes/pki/etcd/ca.crt --cert /etc/kubernetes/pki/etcd/healthcheck-client.crt --key /etc/kubernetes/pki/etcd/healthcheck-client.key"
+	externalEtcdctl = "ETCDCTL_API=3 /opt/bin/etcdctl --cacert /etc/etcd/pki/ca.crt --cert /etc/etcd/pki/etcdctl-etcd-client.crt --key /etc/etcd/pki/etcdctl-etcd-client.key"
+)
+
+type etcdctlExpecter struct {
+	ctx     context.Context
+	ssh     *mocks.MockSSHRunner
+	etcdctl string
+}
+
+func (e etcdctlExpecter) expect(ip, args string) *gomock.Call {
+	script := fmt.Sprintf("'%s --endpoints https://%s:2379 %s'", e.etcdctl, ip, args)
+	return e.ssh.EXPECT().RunCommand(e.ctx, sshKey, sshUser, ip, "sudo", "sh", "-c", script)
+}
+
+func (e etcdctlExpecter) expectManifest(ip, name string) *gomock.Call {
+	return e.ssh.EXPECT().RunCommand(e.ctx, sshKey, sshUser, ip, "sudo", "cat", "/etc/kubernetes/manifests/etcd.yaml").Return(etcdManifest(name, ip), nil)
+}
+
+func endpointStatusJSON(ip string, id, leader, dbSize, dbSizeInUse int) string {
+	return fmt.Sprintf(`[{"Endpoint":"https://%s:2379","Status":{"header":{"cluster_id":1,"member_id":%d},"version":"3.5.10","dbSize":%d,"leader":%d,"dbSizeInUse":%d}}]`,
+		ip, id, dbSize, leader, dbSizeInUse)
+}
+
+func newStackedExpecter(t *testing.T) etcdctlExpecter {
+	return etcdctlExpecter{
+		ctx:     context.Background(),
+		ssh:     mocks.NewMockSSHRunner(gomock.NewController(t)),
+		etcdctl: stackedEtcdctl,
+	}
+}
+
+func expectHealthyStackedStatus(e etcdctlExpecter, alarms string) {
+	e.expectManifest("10.0.0.1", "prod-cp-a")
+	e.expect("10.0.0.1", "endpoint status -w json").Return(endpointStatusJSON("10.0.0.1", 10, 10, 4096, 1024), nil)
+	e.expectManifest("10.0.0.2", "prod-cp-b")
+	e.expect("10.0.0.2", "endpoint status -w json").Return(endpointStatusJSON("10.0.0.2", 11, 10, 8192, 1024), nil)
+	e.expectManifest("10.0.0.3", "prod-cp-c")
+	e.expect("10.0.0.3", "endpoint status -w json").Return(endpointStatusJSON("10.0.0.3", 12, 10, 2048, 1024), nil)
+	e.expect("10.0.0.1", "member list -w json").Return(memberListJSON, nil)
+	e.expect("10.0.0.1", "alarm list").Return(alarms, nil)
+}
+
+func expectStackedStatusWithDeadMember(e etcdctlExpecter) {
+	e.expectManifest("10.0.0.1", "prod-cp-a")
+	e.expect("10.0.0.1", "endpoint status -w json").Return(endpointStatusJSON("10.0.0.1", 10, 10, 4096, 1024), nil)
+	e.expectManifest("10.0.0.2", "prod-cp-b")
+	e.expect("10.0.0.2", "endpoint status -w json").Return(endpointStatusJSON("10.0.0.2", 11, 10, 4096, 1024), nil)
+	e.expect("10.0.0.1", "member list -w json").Return(memberListJSON, nil)
+	e.expect("10.0.0.1", "alarm list").Return("", nil)
+}
+
+var stackedIPs = []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"}
+
+func TestMaintainerStatus(t *testing.T) {
+	g := NewWithT(t)
+	e := newStackedExpecter(t)
+	expectHealthyStackedStatus(e, "memberID:11 alarm:NOSPACE \n")
+
+	m := etcdbackup.NewMaintainer(e.ssh, sshKey, sshUser)
+	statuses, err := m.Status(e.ctx, false, stackedIPs)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(statuses).To(HaveLen(3))
+
+	g.Expect(statuses[0].Name).To(Equal("prod-cp-a"))
+	g.Expect(statuses[0].ID).To(Equal("a"))
+	g.Expect(statuses[0].Healthy).To(BeTrue())
+	g.Expect(statuses[0].Leader).To(BeTrue())
+	g.Expect(statuses[0].Version).To(Equal("3.5.10"))
+	g.Expect(statuses[0].DBSize).To(Equal(int64(4096)))
+	g.Expect(statuses[0].DBSizeInUse).To(Equal(int64(1024)))
+	g.Expect(statuses[0].Alarms).To(BeEmpty())
+
+	g.Expect(statuses[1].Leader).To(BeFalse())
+	g.Expect(statuses[1].Alarms).To(Equal([]string{"NOSPACE"}))
+}
+
+func TestMaintainerStatusUnreachableMembers(t *testing.T) {
+	g := NewWithT(t)
+	e := newStackedExpecter(t)
+	e.expectManifest("10.0.0.1", "prod-cp-a")
+	e.expect("10.0.0.1", "endpoint status -w json").Return(endpointStatusJSON("10.0.0.1", 10, 10, 4096, 1024), nil)
+	e.ssh.EXPECT().RunCommand(e.ctx, sshKey, sshUser, "10.0.0.2", "sudo", "cat", "/etc/kubernetes/manifests/etcd.yaml").Return("", errors.New("connection refused"))
+	e.expect("10.0.0.1", "member list -w json").Return(memberListJSON, nil)
+	e.expect("10.0.0.1", "alarm list").Return("", nil)
+
+	m := etcdbackup.NewMaintainer(e.ssh, sshKey, sshUser)
+	statuses, err := m.Status(e.ctx, false, []string{"10.0.0.1", "10.0.0.2"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(statuses).To(HaveLen(4))
+
+	g.Expect(statuses[1].IP).To(Equal("10.0.0.2"))
+	g.Expect(statuses[1].Healthy).To(BeFalse())
+	g.Expect(statuses[1].Error).To(ContainSubstring("connection refused"))
+
+	g.Expect(statuses[2].Name).To(Equal("prod-cp-b"))
+	g.Expect(statuses[2].ID).To(Equal("b"))
+	g.Expect(statuses[2].Healthy).To(BeFalse())
+	g.Expect(statuses[2].Error).To(Equal("member is not running on any of the etcd machines"))
+}
+
+func TestMaintainerStatusNoHealthyMembers(t *testing.T) {
+	g := NewWithT(t)
+	e := newStackedExpecter(t)
+	e.expectManifest("10.0.0.1", "prod-cp-a")
+	e.expect("10.0.0.1", "endpoint status -w json").Return("", errors.New("context deadline exceeded"))
+
+	m := etcdbackup.NewMaintainer(e.ssh, sshKey, sshUser)
+	statuses, err := m.Status(e.ctx, false, []string{"10.0.0.1"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(statuses).To(HaveLen(1))
+	g.Expect(statuses[0].Name).To(Equal("prod-cp-a"))
+	g.Expect(statuses[0].Healthy).To(BeFalse())
+	g.Expect(statuses[0].Error).To(Equal("context deadline exceeded"))
+}
+
+func TestMaintainerStatusExternalEtcd(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ssh := mocks.NewMockSSHRunner(gomock.NewController(t))
+	e := etcdctlExpecter{ctx: ctx, ssh: ssh, etcdctl: externalEtcdctl}
+	ip := "10.0.0.5"
+
+	ssh.EXPECT().RunCommand(ctx, sshKey, sshUser, ip, "sudo", "cat", "/etc/etcd/etcd.env").Return(etcdEnv("prod-etcd-a", ip), nil)
+	e.expect(ip, "endpoint status -w json").Return(endpointStatusJSON(ip, 10, 10, 4096, 1024), nil)
+	e.expect(ip, "member list -w json").Return(`{"members":[{"ID":10,"name":"prod-etcd-a"}]}`, nil)
+	e.expect(ip, "alarm list").Return("", nil)
+
+	m := etcdbackup.NewMaintainer(ssh, sshKey, sshUser)
+	statuses, err := m.Status(ctx, true, []string{ip})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(statuses).To(HaveLen(1))
+	g.Expect(statuses[0].Healthy).To(BeTrue())
+}
+
+func TestMaintainerDefrag(t *testing.T) {
+	g := NewWithT(t)
+	e := newStackedExpecter(t)
+	expectHealthyStackedStatus(e, "")
+	gomock.InOrder(
+		e.expect("10.0.0.2", "defrag --command-timeout 5m"),
+		e.expect("10.0.0.2", "endpoint status -w json").Return(endpointStatusJSON("10.0.0.2", 11, 10, 1024, 1024), nil),
+		e.expect("10.0.0.3", "defrag --command-timeout 5m"),
+		e.expect("10.0.0.3", "endpoint status -w json").Return(endpointStatusJSON("10.0.0.3", 12, 10, 1024, 1024), nil),
+		e.expect("10.0.0.1", "defrag --command-timeout 5m"),
+		e.expect("10.0.0.1", "endpoint status -w json").Return(endpointStatusJSON("10.0.0.1", 10, 10, 1024, 1024), nil),
+	)
+
+	m := etcdbackup.NewMaintainer(e.ssh, sshKey, sshUser)
+	g.Expect(m.Defrag(e.ctx, false, stackedIPs)).To(Succeed())
+}
+
+func TestMaintainerDefragNoSpaceAlarm(t *testing.T) {
+	g := NewWithT(t)
+	e := newStackedExpecter(t)
+	expectHealthyStackedStatus(e, "memberID:10 alarm:NOSPACE \nmemberID:11 alarm:NOSPACE \nmemberID:12 alarm:NOSPACE \n")
+	// endpoint health fails on every member while the NOSPACE alarm is active.
+	e.expect("10.0.0.1", "endpoint health").Return("", errors.New("unhealthy: alarm NOSPACE")).AnyTimes()
+	e.expect("10.0.0.2", "endpoint health").Return("", errors.New("unhealthy: alarm NOSPACE")).AnyTimes()
+	e.expect("10.0.0.3", "endpoint health").Return("", errors.New("unhealthy: alarm NOSPACE")).AnyTimes()
+	gomock.InOrder(
+		e.expect("10.0.0.2", "defrag --command-timeout 5m"),
+		e.expect("10.0.0.2", "endpoint status -w json").Return(endpointStatusJSON("10.0.0.2", 11, 10, 1024, 1024), nil),
+		e.expect("10.0.0.3", "defrag --command-timeout 5m"),
+		e.expect("10.0.0.3", "endpoint status -w json").Return(endpointStatusJSON("10.0.0.3", 12, 10, 1024, 1024), nil),
+		e.expect("10.0.0.1", "defrag --command-timeout 5m"),
+		e.expect("10.0.0.1", "endpoint status -w json").Return(endpointStatusJSON("10.0.0.1", 10, 10, 1024, 1024), nil),
+		e.expect("10.0.0.2", "alarm disarm"),
+	)
+
+	m := etcdbackup.NewMaintainer(e.ssh, sshKey, sshUser)
+	g.Expect(m.Defrag(e.ctx, false, stackedIPs)).To(Succeed())
+}
+
+func TestMaintainerDefragMemberUnhealthyAfterDefrag(t *testing.T) {
+	g := NewWithT(t)
+	e := newStackedExpecter(t)
+	expectHealthyStackedStatus(e, "")
+	e.expect("10.0.0.2", "defrag --command-timeout 5m")
+	e.expect("10.0.0.2", "endpoint status -w json").Return("", errors.New("context deadline exceeded"))
+
+	m := etcdbackup.NewMaintainer(e.ssh, sshKey, sshUser)
+	err := m.Defrag(e.ctx, false, stackedIPs)
+	g.Expect(err).To(MatchError("etcd member prod-cp-b is not healthy after defragmenting it: context deadline exceeded"))
+}
+
+func TestMaintainerDefragUnhealthyMember(t *testing.T) {
+	g := NewWithT(t)
+	e := newStackedExpecter(t)
+	expectStackedStatusWithDeadMember(e)
+
+	m := etcdbackup.NewMaintainer(e.ssh, sshKey, sshUser)
+	err := m.Defrag(e.ctx, false, []string{"10.0.0.1", "10.0.0.2"})
+	g.Expect(err).To(MatchError(ContainSubstring("etcd member prod-cp-c is not healthy")))
+}
+
+func TestMaintainerDefragMemberFails(t *testing.T) {
+	g := NewWithT(t)
+	e := newStackedExpecter(t)
+	expectHealthyStackedStatus(e, "")
+	e.expect("10.0.0.2", "defrag --command-timeout 5m").Return("", errors.New("timeout"))
+
+	m := etcdbackup.NewMaintainer(e.ssh, sshKey, sshUser)
+	err := m.Defrag(e.ctx, false, stackedIPs)
+	g.Expect(err).To(MatchError("defragmenting etcd member prod-cp-b: timeout"))
+}
+
+func TestMaintainerRemoveMember(t *testing.T) {
+	g := NewWithT(t)
+	e := newStackedExpecter(t)
+	expectStackedStatusWithDeadMember(e)
+	e.expect("10.0.0.1", "member remove c")
+
+	m := etcdbackup.NewMaintainer(e.ssh, sshKey, sshUser)
+	g.Expect(m.RemoveMember(e.ctx, false, []string{"10.0.0.1", "10.0.0.2"}, "prod-cp-c")).To(Succeed())
+}
+
+func TestMaintainerRemoveMemberErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		member  string
+		ips     []string
+		status  func(etcdctlExpecter)
+		wantErr string
+	}{
+		{
+			name:    "not found",
+			member:  "prod-cp-d",
+			ips:     []string{"10.0.0.1", "10.0.0.2"},
+			status:  expectStackedStatusWithDeadMember,
+			wantErr: "etcd member prod-cp-d not found",
+		},
+		{
+			name:    "healthy member",
+			member:  "b",
+			ips:     []string{"10.0.0.1", "10.0.0.2"},
+			status:  expectStackedStatusWithDeadMember,
+			wantErr: "etcd member b is healthy, only unhealthy members can be removed",
+		},
+		{
+			name:   "quorum lost",
+			member: "prod-cp-c",
+			ips:    []string{"10.0.0.1"},
+			status: func(e etcdctlExpecter) {
+				e.expectManifest("10.0.0.1", "prod-cp-a")
+				e.expect("10.0.0.1", "endpoint status -w json").Return(endpointStatusJSON("10.0.0.1", 10, 10, 4096, 1024), nil)
+				e.expect("10.0.0.1", "member list -w json").Return(memberListJSON, nil)
+				e.expect("10.0.0.1", "alarm list").Return("", nil)
+			},
+			wantErr: "removing etcd member prod-cp-c would leave 1 healthy members, less than the quorum of 2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			e := newStackedExpecter(t)
+			tt.status(e)
+
+			m := etcdbackup.NewMaintainer(e.ssh, sshKey, sshUser)
+			err := m.RemoveMember(e.ctx, false, tt.ips, tt.member)
+			g.Expect(err).To(MatchError(tt.wantErr))
+		})
+	}
+}
+
+func TestValidateMaintenanceBottlerocket(t *testing.T) {
+	g := NewWithT(t)
+	cluster := &anywherev1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "prod"}}
+	client := test.NewFakeKubeClient(&controlplanev1.KubeadmControlPlane{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "eksa-system"},
+		Spec: controlplanev1.KubeadmControlPlaneSpec{
+			KubeadmConfigSpec: bootstrapv1.KubeadmConfigSpec{Format: bootstrapv1.Bottlerocket},
+		},
+	})
+
+	err := etcdbackup.ValidateMaintenance(context.Background(), client, cluster)
+	g.Expect(err).To(MatchError("managing etcd members is not supported for Bottlerocket control plane machines"))
+}
@@ -0,0 +1,181 @@
+package reconciler
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	anywhereCluster "github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
+	"github.com/aws/eks-anywhere/pkg/controller/reconcileutil"
+	"github.com/aws/eks-anywhere/pkg/etcdbackup"
+)
+
+const requeueAfter = 30 * time.Second
+
+// RemoteClientRegistry defines methods for remote cluster controller clients.
+type RemoteClientRegistry interface {
+	GetClient(ctx context.Context, cluster client.ObjectKey) (client.Client, error)
+}
+
+// Reconciler manages the CronJob that takes the scheduled etcd snapshots of a cluster.
+type Reconciler struct {
+	client               client.Client
+	remoteClientRegistry RemoteClientRegistry
+}
+
+// New returns a new Reconciler.
+func New(client client.Client, remoteClientRegistry RemoteClientRegistry) *Reconciler {
+	return &Reconciler{
+		client:               client,
+		remoteClientRegistry: remoteClientRegistry,
+	}
+}
+
+// Reconcile makes sure the workload cluster has an etcd backup CronJob matching the etcd backup of
+// the cluster spec, and no CronJob when it's not configured. The S3 credentials secret is copied
+// from the eksa-system namespace of the management cluster to the workload cluster.
+// It waits for the control plane to be available, using a controller.Result to indicate when requeues are needed.
+func (r *Reconciler) Reconcile(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) (controller.Result, error) {
+	kcp, err := controller.GetKubeadmControlPlane(ctx, r.client, cluster)
+	if err != nil {
+		return controller.Result{}, errors.Wrap(err, "getting kubeadm control plane")
+	}
+
+	if kcp == nil || !conditions.IsTrue(kcp, controlplanev1.AvailableCondition) {
+		if cluster.Spec.EtcdBackup == nil {
+			return controller.Result{}, nil
+		}
+		log.Info("Waiting for control plane to be available to configure etcd backups")
+		return controller.ResultWithRequeue(requeueAfter), nil
+	}
+
+	rClient, err := r.remoteClientRegistry.GetClient(ctx, controller.CapiClusterObjectKey(cluster))
+	if err != nil {
+		return controller.Result{}, errors.Wrap(err, "getting workload cluster's client to configure etcd backups")
+	}
+
+	if cluster.Spec.EtcdBackup == nil {
+		return controller.Result{}, deleteBackup(ctx, rClient, cluster)
+	}
+
+	spec, err := anywhereCluster.BuildSpec(ctx, clientutil.NewKubeClient(r.client), cluster)
+	if err != nil {
+		return controller.Result{}, err
+	}
+	versionsBundle := spec.RootVersionsBundle()
+
+	if err := reconcileutil.EnsureNamespace(ctx, rClient, constants.EksaSystemNamespace); err != nil {
+		return controller.Result{}, err
+	}
+
+	if s3 := cluster.Spec.EtcdBackup.S3; s3 != nil {
+		if err := r.copyCredentials(ctx, rClient, cluster, s3.CredentialsSecretName); err != nil {
+			return controller.Result{}, err
+		}
+	} else if err := reconcileutil.DeleteIgnoreNotFound(ctx, rClient, credentialsSecret(cluster)); err != nil {
+		return controller.Result{}, err
+	}
+
+	current, err := getCronJob(ctx, rClient, cluster)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	cronJob := etcdbackup.CronJob(cluster, kcp, current,
+		versionsBundle.KubeDistro.EtcdImage.VersionedImage(),
+		versionsBundle.Eksa.ClusterController.VersionedImage(),
+	)
+	if err := createOrUpdateCronJob(ctx, rClient, cronJob); err != nil {
+		return controller.Result{}, errors.Wrap(err, "applying etcd backup CronJob")
+	}
+
+	return controller.Result{}, nil
+}
+
+func (r *Reconciler) copyCredentials(ctx context.Context, rClient client.Client, cluster *anywherev1.Cluster, secretName string) error {
+	secret := &corev1.Secret{}
+	if err := r.client.Get(ctx, client.ObjectKey{Name: secretName, Namespace: constants.EksaSystemNamespace}, secret); err != nil {
+		return errors.Wrapf(err, "getting etcd backup S3 credentials secret %s", secretName)
+	}
+
+	credentials := credentialsSecret(cluster)
+	credentials.Data = map[string][]byte{
+		etcdbackup.AccessKeyIDKey:     secret.Data[etcdbackup.AccessKeyIDKey],
+		etcdbackup.SecretAccessKeyKey: secret.Data[etcdbackup.SecretAccessKeyKey],
+	}
+	if err := reconcileutil.CreateOrUpdateSecret(ctx, rClient, credentials); err != nil {
+		return errors.Wrap(err, "applying etcd backup S3 credentials secret")
+	}
+
+	return nil
+}
+
+func credentialsSecret(cluster *anywherev1.Cluster) *corev1.Secret {
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      etcdbackup.CredentialsSecretName(cluster.Name),
+			Namespace: constants.EksaSystemNamespace,
+			Labels:    map[string]string{etcdbackup.ClusterNameLabel: cluster.Name},
+		},
+	}
+}
+
+func deleteBackup(ctx context.Context, c client.Client, cluster *anywherev1.Cluster) error {
+	cronJob := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      etcdbackup.CronJobName(cluster.Name),
+			Namespace: constants.EksaSystemNamespace,
+		},
+	}
+	if err := reconcileutil.DeleteIgnoreNotFound(ctx, c, cronJob, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil {
+		return err
+	}
+
+	return reconcileutil.DeleteIgnoreNotFound(ctx, c, credentialsSecret(cluster))
+}
+
+func getCronJob(ctx context.Context, c client.Client, cluster *anywherev1.Cluster) (*batchv1.CronJob, error) {
+	cronJob := &batchv1.CronJob{}
+	err := c.Get(ctx, client.ObjectKey{Name: etcdbackup.CronJobName(cluster.Name), Namespace: constants.EksaSystemNamespace}, cronJob)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "getting etcd backup CronJob")
+	}
+
+	return cronJob, nil
+}
+
+func createOrUpdateCronJob(ctx context.Context, c client.Client, cronJob *batchv1.CronJob) error {
+	existing := &batchv1.CronJob{}
+	err := c.Get(ctx, client.ObjectKeyFromObject(cronJob), existing)
+	if apierrors.IsNotFound(err) {
+		return c.Create(ctx, cronJob)
+	}
+	if err != nil {
+		return err
+	}
+
+	existing.Labels = cronJob.Labels
+	existing.Annotations = cronJob.Annotations
+	existing.Spec = cronJob.Spec
+	return c.Update(ctx, existing)
+}
@@ -0,0 +1,261 @@
+package reconciler_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	eksdv1 "github.com/aws/eks-distro-build-tooling/release/api/v1alpha1"
+	"github.com/go-logr/logr"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/etcdbackup"
+	"github.com/aws/eks-anywhere/pkg/etcdbackup/reconciler"
+	"github.com/aws/eks-anywhere/pkg/etcdbackup/reconciler/mocks"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+type reconcilerTest struct {
+	*WithT
+	ctx                  context.Context
+	cluster              *anywherev1.Cluster
+	client               client.Client
+	remoteClient         client.Client
+	remoteClientRegistry *mocks.MockRemoteClientRegistry
+	reconciler           *reconciler.Reconciler
+}
+
+func newReconcilerTest(t *testing.T, backup *anywherev1.EtcdBackup, kcpAvailable bool, remoteObjs ...runtime.Object) *reconcilerTest {
+	ctrl := gomock.NewController(t)
+	remoteClientRegistry := mocks.NewMockRemoteClientRegistry(ctrl)
+
+	bundle := test.Bundle()
+	bundle.Spec.VersionsBundles[0].KubeVersion = string(anywherev1.Kube130)
+	bundle.Spec.VersionsBundles[0].Eksa.ClusterController = releasev1.Image{URI: "public.ecr.aws/eks-anywhere/cluster-controller:v0.19.0"}
+	version := test.DevEksaVersion()
+	cluster := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster",
+			Namespace: constants.EksaSystemNamespace,
+		},
+		Spec: anywherev1.ClusterSpec{
+			KubernetesVersion: anywherev1.Kube130,
+			BundlesRef: &anywherev1.BundlesRef{
+				Name:       bundle.Name,
+				Namespace:  bundle.Namespace,
+				APIVersion: bundle.APIVersion,
+			},
+			EksaVersion: &version,
+			EtcdBackup:  backup,
+		},
+	}
+	kcp := test.KubeadmControlPlane(func(kcp *controlplanev1.KubeadmControlPlane) {
+		kcp.Name = cluster.Name
+		if kcpAvailable {
+			kcp.Status.Conditions = clusterv1.Conditions{
+				{
+					Type:               controlplanev1.AvailableCondition,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(time.Now()),
+				},
+			}
+		}
+	})
+	credentials := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "etcd-backup-credentials",
+			Namespace: constants.EksaSystemNamespace,
+		},
+		Data: map[string][]byte{
+			etcdbackup.AccessKeyIDKey:     []byte("minio"),
+			etcdbackup.SecretAccessKeyKey: []byte("minio123"),
+			"other":                       []byte("value"),
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	_ = releasev1.AddToScheme(scheme)
+	_ = eksdv1.AddToScheme(scheme)
+	_ = clusterv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+	_ = batchv1.AddToScheme(scheme)
+	_ = controlplanev1.AddToScheme(scheme)
+	_ = anywherev1.AddToScheme(scheme)
+
+	cl := fake.NewClientBuilder().WithScheme(scheme).
+		WithRuntimeObjects(bundle, test.EksdRelease("1-30"), test.EKSARelease(), kcp, credentials).Build()
+	remoteClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(remoteObjs...).Build()
+
+	return &reconcilerTest{
+		WithT:                NewWithT(t),
+		ctx:                  context.Background(),
+		cluster:              cluster,
+		client:               cl,
+		remoteClient:         remoteClient,
+		remoteClientRegistry: remoteClientRegistry,
+		reconciler:           reconciler.New(cl, remoteClientRegistry),
+	}
+}
+
+func (tt *reconcilerTest) expectRemoteClient() {
+	tt.remoteClientRegistry.EXPECT().GetClient(tt.ctx, client.ObjectKey{Name: tt.cluster.Name, Namespace: constants.EksaSystemNamespace}).Return(tt.remoteClient, nil)
+}
+
+func (tt *reconcilerTest) cronJob() (*batchv1.CronJob, error) {
+	cronJob := &batchv1.CronJob{}
+	err := tt.remoteClient.Get(tt.ctx, client.ObjectKey{Name: "my-cluster-etcd-backup", Namespace: constants.EksaSystemNamespace}, cronJob)
+	return cronJob, err
+}
+
+func (tt *reconcilerTest) credentials() (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	err := tt.remoteClient.Get(tt.ctx, client.ObjectKey{Name: "my-cluster-etcd-backup-s3", Namespace: constants.EksaSystemNamespace}, secret)
+	return secret, err
+}
+
+func nullLog() logr.Logger {
+	return logr.New(logf.NullLogSink{})
+}
+
+func pvcBackup() *anywherev1.EtcdBackup {
+	return &anywherev1.EtcdBackup{
+		Schedule:              "0 */6 * * *",
+		PersistentVolumeClaim: &anywherev1.EtcdBackupPersistentVolumeClaim{ClaimName: "etcd-backups"},
+	}
+}
+
+func s3Backup() *anywherev1.EtcdBackup {
+	return &anywherev1.EtcdBackup{
+		Schedule: "0 2 * * *",
+		S3: &anywherev1.EtcdBackupS3{
+			Endpoint:              "http://minio.local:9000",
+			Bucket:                "etcd",
+			ForcePathStyle:        true,
+			CredentialsSecretName: "etcd-backup-credentials",
+		},
+	}
+}
+
+func TestReconcileNoBackupControlPlaneNotAvailable(t *testing.T) {
+	tt := newReconcilerTest(t, nil, false)
+
+	result, err := tt.reconciler.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestReconcileControlPlaneNotAvailable(t *testing.T) {
+	tt := newReconcilerTest(t, pvcBackup(), false)
+
+	result, err := tt.reconciler.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.ResultWithRequeue(30 * time.Second)))
+}
+
+func TestReconcileCreatesCronJob(t *testing.T) {
+	tt := newReconcilerTest(t, pvcBackup(), true)
+	tt.expectRemoteClient()
+
+	result, err := tt.reconciler.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+
+	cronJob, err := tt.cronJob()
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(cronJob.Spec.Schedule).To(Equal("0 */6 * * *"))
+	pod := cronJob.Spec.JobTemplate.Spec.Template.Spec
+	tt.Expect(pod.Containers[0].Image).To(Equal("public.ecr.aws/eks-anywhere/cluster-controller:v0.19.0"))
+
+	_, err = tt.credentials()
+	tt.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+	ns := &corev1.Namespace{}
+	tt.Expect(tt.remoteClient.Get(tt.ctx, client.ObjectKey{Name: constants.EksaSystemNamespace}, ns)).To(Succeed())
+}
+
+func TestReconcileUpdatesCronJobAndCopiesCredentials(t *testing.T) {
+	existing := etcdbackup.CronJob(
+		&anywherev1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "my-cluster"}, Spec: anywherev1.ClusterSpec{EtcdBackup: pvcBackup()}},
+		&controlplanev1.KubeadmControlPlane{}, nil, "etcd", "controller",
+	)
+	tt := newReconcilerTest(t, s3Backup(), true, existing)
+	tt.expectRemoteClient()
+
+	result, err := tt.reconciler.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+
+	cronJob, err := tt.cronJob()
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(cronJob.Spec.Schedule).To(Equal("0 2 * * *"))
+	tt.Expect(cronJob.Spec.JobTemplate.Spec.Template.Spec.Containers[0].Args).To(ContainElements("--s3-bucket", "etcd"))
+
+	secret, err := tt.credentials()
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(secret.Data).To(Equal(map[string][]byte{
+		etcdbackup.AccessKeyIDKey:     []byte("minio"),
+		etcdbackup.SecretAccessKeyKey: []byte("minio123"),
+	}))
+}
+
+func TestReconcileMissingCredentials(t *testing.T) {
+	backup := s3Backup()
+	backup.S3.CredentialsSecretName = "missing"
+	tt := newReconcilerTest(t, backup, true)
+	tt.expectRemoteClient()
+
+	_, err := tt.reconciler.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).To(MatchError(ContainSubstring("getting etcd backup S3 credentials secret missing")))
+}
+
+func TestReconcileDeletesCronJob(t *testing.T) {
+	cluster := &anywherev1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "my-cluster"}, Spec: anywherev1.ClusterSpec{EtcdBackup: s3Backup()}}
+	existing := etcdbackup.CronJob(cluster, &controlplanev1.KubeadmControlPlane{}, nil, "etcd", "controller")
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-cluster-etcd-backup-s3", Namespace: constants.EksaSystemNamespace},
+	}
+	tt := newReconcilerTest(t, nil, true, existing, secret)
+	tt.expectRemoteClient()
+
+	result, err := tt.reconciler.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+
+	_, err = tt.cronJob()
+	tt.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	_, err = tt.credentials()
+	tt.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+}
+
+func TestReconcileNoBackupNothingToDelete(t *testing.T) {
+	tt := newReconcilerTest(t, nil, true)
+	tt.expectRemoteClient()
+
+	result, err := tt.reconciler.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestReconcileRemoteClientError(t *testing.T) {
+	tt := newReconcilerTest(t, pvcBackup(), true)
+	tt.remoteClientRegistry.EXPECT().GetClient(tt.ctx, gomock.Any()).Return(nil, errors.New("unreachable"))
+
+	_, err := tt.reconciler.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).To(MatchError(ContainSubstring("unreachable")))
+}
@@ -0,0 +1,356 @@
+package etcdbackup
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	etcdbootstrapv1 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta1"
+	etcdv1 "github.com/aws/etcdadm-controller/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/yaml"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const (
+	stackedEtcdManifest     = "/etc/kubernetes/manifests/etcd.yaml"
+	stoppedStackedEtcdPath  = "/etc/kubernetes/etcd.yaml.eksa-restore"
+	externalEtcdEnvFile     = "/etc/etcd/etcd.env"
+	externalEtcdctl         = "/opt/bin/etcdctl"
+	restoreSnapshotFile     = "/var/lib/eksa-etcd-restore.db"
+	restoreClusterToken     = "eksa-etcd-restore"
+	defaultEtcdDataDir      = "/var/lib/etcd"
+	stackedEtcdStopTimeout  = 2 * time.Minute
+	stackedEtcdStopPollSecs = 2
+)
+
+// SSHRunner runs commands on the etcd members over SSH.
+type SSHRunner interface {
+	RunCommand(ctx context.Context, privateKeyPath, username, IP string, command ...string) (string, error)
+	RunCommandWithStdin(ctx context.Context, in []byte, privateKeyPath, username, IP string, command ...string) (string, error)
+}
+
+// Member is an etcd member of a cluster.
+type Member struct {
+	// IP is the address the node of the member can be reached at over SSH.
+	IP string `json:"ip,omitempty"`
+	// Name is the etcd name of the member.
+	Name string `json:"name,omitempty"`
+	// PeerURL is the URL the member advertises to its peers.
+	PeerURL string `json:"peerURL,omitempty"`
+	// ClientURL is the URL the member advertises to its clients.
+	ClientURL string `json:"clientURL,omitempty"`
+	// DataDir is the etcd data folder on the node.
+	DataDir string `json:"dataDir,omitempty"`
+	// Image is the etcd image of a stacked etcd member, used to run etcdctl.
+	Image string `json:"image,omitempty"`
+}
+
+// remote runs commands on the machines of the etcd members over SSH.
+type remote struct {
+	ssh            SSHRunner
+	privateKeyPath string
+	username       string
+}
+
+// Restorer restores an etcd snapshot onto the etcd members of a cluster.
+type Restorer struct {
+	remote
+}
+
+// NewRestorer returns a new Restorer that connects to the etcd members with the SSH key privateKeyPath.
+func NewRestorer(ssh SSHRunner, privateKeyPath, username string) *Restorer {
+	return &Restorer{
+		remote: remote{
+			ssh:            ssh,
+			privateKeyPath: privateKeyPath,
+			username:       username,
+		},
+	}
+}
+
+// MemberIPs returns the addresses of the machines running the etcd members of a cluster:
+// the control plane machines for stacked etcd and the etcd machines for external etcd.
+func MemberIPs(ctx context.Context, client kubernetes.Reader, cluster *anywherev1.Cluster) ([]string, error) {
+	machines := &clusterv1.MachineList{}
+	if err := client.List(ctx, machines, kubernetes.ListOptions{Namespace: constants.EksaSystemNamespace}); err != nil {
+		return nil, fmt.Errorf("listing machines: %v", err)
+	}
+
+	memberLabel := clusterv1.MachineControlPlaneLabel
+	if cluster.Spec.ExternalEtcdConfiguration != nil {
+		memberLabel = clusterv1.MachineEtcdClusterLabelName
+	}
+
+	var ips []string
+	for _, m := range machines.Items {
+		if m.Labels[clusterv1.ClusterNameLabel] != cluster.Name {
+			continue
+		}
+		if _, ok := m.Labels[memberLabel]; !ok {
+			continue
+		}
+		ip := machineIP(&m)
+		if ip == "" {
+			return nil, fmt.Errorf("machine %s doesn't have an address", m.Name)
+		}
+		ips = append(ips, ip)
+	}
+
+	if len(ips) == 0 {
+		return nil, fmt.Errorf("no etcd machines found for cluster %s", cluster.Name)
+	}
+	sort.Strings(ips)
+
+	return ips, nil
+}
+
+// SetClusterPaused pauses or resumes the reconciliation of the CAPI cluster of a cluster, so no
+// machines are remediated or rolled out while its etcd members are stopped.
+func SetClusterPaused(ctx context.Context, client kubernetes.Client, cluster *anywherev1.Cluster, paused bool) error {
+	capiCluster := &clusterv1.Cluster{}
+	if err := client.Get(ctx, cluster.Name, constants.EksaSystemNamespace, capiCluster); err != nil {
+		return fmt.Errorf("getting CAPI cluster: %v", err)
+	}
+
+	if capiCluster.Spec.Paused == paused {
+		return nil
+	}
+
+	capiCluster.Spec.Paused = paused
+	if err := client.Update(ctx, capiCluster); err != nil {
+		return fmt.Errorf("updating CAPI cluster: %v", err)
+	}
+
+	return nil
+}
+
+// ValidateRestore checks that the etcd members of a cluster can be restored over SSH. Bottlerocket
+// nodes don't allow running etcdctl on the host, so they are not supported.
+func ValidateRestore(ctx context.Context, client kubernetes.Reader, cluster *anywherev1.Cluster) error {
+	return validateSSHAccess(ctx, client, cluster, "restoring etcd")
+}
+
+func validateSSHAccess(ctx context.Context, client kubernetes.Reader, cluster *anywherev1.Cluster, action string) error {
+	kcp := &controlplanev1.KubeadmControlPlane{}
+	if err := client.Get(ctx, clusterapi.KubeadmControlPlaneName(cluster), constants.EksaSystemNamespace, kcp); err != nil {
+		return fmt.Errorf("getting kubeadm control plane: %v", err)
+	}
+
+	if cluster.Spec.ExternalEtcdConfiguration != nil {
+		etcdCluster := &etcdv1.EtcdadmCluster{}
+		if err := client.Get(ctx, clusterapi.EtcdClusterName(cluster.Name), constants.EksaSystemNamespace, etcdCluster); err != nil {
+			return fmt.Errorf("getting etcdadm cluster: %v", err)
+		}
+		if etcdCluster.Spec.EtcdadmConfigSpec.Format == etcdbootstrapv1.Bottlerocket {
+			return fmt.Errorf("%s is not supported for Bottlerocket etcd machines", action)
+		}
+	} else if kcp.Spec.KubeadmConfigSpec.Format == bootstrapv1.Bottlerocket {
+		return fmt.Errorf("%s is not supported for Bottlerocket control plane machines", action)
+	}
+
+	return nil
+}
+
+func machineIP(m *clusterv1.Machine) string {
+	for _, addressType := range []clusterv1.MachineAddressType{clusterv1.MachineExternalIP, clusterv1.MachineInternalIP} {
+		for _, a := range m.Status.Addresses {
+			if a.Type == addressType && a.Address != "" {
+				return a.Address
+			}
+		}
+	}
+
+	return ""
+}
+
+// Restore replaces the data of all the etcd members of a cluster with snapshot. The members are
+// stopped, a new etcd cluster with the same members is restored from the snapshot on each of them and
+// they are started again. The previous data of each member is kept in a .eksa-restore-old folder.
+// Stacked etcd members run as kubeadm static pods, external etcd members as etcdadm systemd services.
+func (r *Restorer) Restore(ctx context.Context, snapshot []byte, externalEtcd bool, ips []string) error {
+	members := make([]Member, 0, len(ips))
+	for _, ip := range ips {
+		m, err := r.member(ctx, ip, externalEtcd)
+		if err != nil {
+			return err
+		}
+		members = append(members, m)
+	}
+
+	for _, m := range members {
+		logger.V(3).Info("Copying etcd snapshot", "member", m.Name, "ip", m.IP)
+		if _, err := r.ssh.RunCommandWithStdin(ctx, snapshot, r.privateKeyPath, r.username, m.IP,
+			"sudo", "tee", restoreSnapshotFile, ">", "/dev/null"); err != nil {
+			return fmt.Errorf("copying etcd snapshot to %s: %v", m.IP, err)
+		}
+	}
+
+	for _, m := range members {
+		logger.V(3).Info("Stopping etcd member", "member", m.Name, "ip", m.IP)
+		if err := r.stop(ctx, m, externalEtcd); err != nil {
+			return fmt.Errorf("stopping etcd member %s: %v", m.Name, err)
+		}
+	}
+
+	initialCluster := initialCluster(members)
+	for _, m := range members {
+		logger.V(3).Info("Restoring etcd snapshot", "member", m.Name, "ip", m.IP)
+		if err := r.restore(ctx, m, initialCluster, externalEtcd); err != nil {
+			return fmt.Errorf("restoring etcd snapshot on member %s: %v", m.Name, err)
+		}
+	}
+
+	for _, m := range members {
+		logger.V(3).Info("Starting etcd member", "member", m.Name, "ip", m.IP)
+		if err := r.start(ctx, m, externalEtcd); err != nil {
+			return fmt.Errorf("starting etcd member %s: %v", m.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (r remote) member(ctx context.Context, ip string, externalEtcd bool) (Member, error) {
+	if externalEtcd {
+		out, err := r.ssh.RunCommand(ctx, r.privateKeyPath, r.username, ip, "sudo", "cat", externalEtcdEnvFile)
+		if err != nil {
+			return Member{}, fmt.Errorf("reading etcd config on %s: %v", ip, err)
+		}
+		return parseEtcdEnv(ip, out)
+	}
+
+	out, err := r.ssh.RunCommand(ctx, r.privateKeyPath, r.username, ip, "sudo", "cat", stackedEtcdManifest)
+	if err != nil {
+		return Member{}, fmt.Errorf("reading etcd manifest on %s: %v", ip, err)
+	}
+	return parseEtcdManifest(ip, out)
+}
+
+func (r *Restorer) stop(ctx context.Context, m Member, externalEtcd bool) error {
+	if externalEtcd {
+		_, err := r.ssh.RunCommand(ctx, r.privateKeyPath, r.username, m.IP, "sudo", "systemctl", "stop", "etcd")
+		return err
+	}
+
+	// The kubelet stops the static pod once its manifest is removed.
+	script := fmt.Sprintf("mv %s %s && timeout %d sh -c \"while crictl ps -q --name ^etcd$ | grep -q .; do sleep %d; done\"",
+		stackedEtcdManifest, stoppedStackedEtcdPath, int(stackedEtcdStopTimeout.Seconds()), stackedEtcdStopPollSecs)
+	_, err := r.ssh.RunCommand(ctx, r.privateKeyPath, r.username, m.IP, "sudo", "sh", "-c", quote(script))
+	return err
+}
+
+func (r *Restorer) restore(ctx context.Context, m Member, initialCluster string, externalEtcd bool) error {
+	newDataDir := m.DataDir + ".eksa-restore"
+	oldDataDir := m.DataDir + ".eksa-restore-old"
+	restoreArgs := fmt.Sprintf("snapshot restore %s --name %s --initial-cluster %s --initial-cluster-token %s --initial-advertise-peer-urls %s --data-dir %s",
+		restoreSnapshotFile, m.Name, initialCluster, restoreClusterToken, m.PeerURL, newDataDir)
+
+	etcdctl := "ETCDCTL_API=3 " + externalEtcdctl
+	if !externalEtcd {
+		// etcdctl is not installed on the kubeadm nodes, so it runs from the etcd image the member was running.
+		etcdctl = fmt.Sprintf("ctr -n k8s.io run --rm --env ETCDCTL_API=3 --mount type=bind,src=/var/lib,dst=/var/lib,options=rbind:rw %s eksa-etcd-restore etcdctl", m.Image)
+	}
+
+	script := strings.Join([]string{
+		fmt.Sprintf("rm -rf %s %s", newDataDir, oldDataDir),
+		fmt.Sprintf("%s %s", etcdctl, restoreArgs),
+		fmt.Sprintf("chown -R --reference=%s %s", m.DataDir, newDataDir),
+		fmt.Sprintf("mv %s %s", m.DataDir, oldDataDir),
+		fmt.Sprintf("mv %s %s", newDataDir, m.DataDir),
+		fmt.Sprintf("rm -f %s", restoreSnapshotFile),
+	}, " && ")
+
+	_, err := r.ssh.RunCommand(ctx, r.privateKeyPath, r.username, m.IP, "sudo", "sh", "-c", quote(script))
+	return err
+}
+
+func (r *Restorer) start(ctx context.Context, m Member, externalEtcd bool) error {
+	if externalEtcd {
+		_, err := r.ssh.RunCommand(ctx, r.privateKeyPath, r.username, m.IP, "sudo", "systemctl", "start", "etcd")
+		return err
+	}
+
+	_, err := r.ssh.RunCommand(ctx, r.privateKeyPath, r.username, m.IP, "sudo", "mv", stoppedStackedEtcdPath, stackedEtcdManifest)
+	return err
+}
+
+func initialCluster(members []Member) string {
+	peers := make([]string, 0, len(members))
+	for _, m := range members {
+		peers = append(peers, fmt.Sprintf("%s=%s", m.Name, m.PeerURL))
+	}
+
+	return strings.Join(peers, ",")
+}
+
+// parseEtcdManifest reads the member config from the flags of the kubeadm etcd static pod manifest.
+func parseEtcdManifest(ip, manifest string) (Member, error) {
+	pod := &corev1.Pod{}
+	if err := yaml.Unmarshal([]byte(manifest), pod); err != nil {
+		return Member{}, fmt.Errorf("parsing etcd manifest on %s: %v", ip, err)
+	}
+
+	if len(pod.Spec.Containers) == 0 {
+		return Member{}, fmt.Errorf("etcd manifest on %s doesn't have containers", ip)
+	}
+	container := pod.Spec.Containers[0]
+
+	flags := map[string]string{}
+	for _, arg := range append(container.Command, container.Args...) {
+		if k, v, ok := strings.Cut(strings.TrimPrefix(arg, "--"), "="); ok {
+			flags[k] = v
+		}
+	}
+
+	return newMember(ip, flags["name"], flags["initial-advertise-peer-urls"], flags["advertise-client-urls"], flags["data-dir"], container.Image)
+}
+
+// parseEtcdEnv reads the member config from the env file of the etcdadm etcd service.
+func parseEtcdEnv(ip, env string) (Member, error) {
+	vars := map[string]string{}
+	for _, line := range strings.Split(env, "\n") {
+		if k, v, ok := strings.Cut(strings.TrimSpace(line), "="); ok {
+			vars[k] = strings.Trim(v, `"`)
+		}
+	}
+
+	return newMember(ip, vars["ETCD_NAME"], vars["ETCD_INITIAL_ADVERTISE_PEER_URLS"], vars["ETCD_ADVERTISE_CLIENT_URLS"], vars["ETCD_DATA_DIR"], "")
+}
+
+func newMember(ip, name, peerURL, clientURLs, dataDir, image string) (Member, error) {
+	if name == "" || peerURL == "" {
+		return Member{}, fmt.Errorf("etcd member name or peer URL not found on %s", ip)
+	}
+
+	if dataDir == "" {
+		dataDir = defaultEtcdDataDir
+	}
+
+	// Members can advertise several client URLs, any of them works to reach the member.
+	clientURL, _, _ := strings.Cut(clientURLs, ",")
+
+	return Member{
+		IP:        ip,
+		Name:      name,
+		PeerURL:   peerURL,
+		ClientURL: clientURL,
+		DataDir:   dataDir,
+		Image:     image,
+	}, nil
+}
+
+// quote wraps a script in single quotes so the remote shell passes it as a single argument.
+func quote(script string) string {
+	return "'" + strings.ReplaceAll(script, "'", `'\''`) + "'"
+}
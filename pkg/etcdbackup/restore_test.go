@@ -0,0 +1,297 @@
+package etcdbackup_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	etcdbootstrapv1 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta1"
+	etcdv1 "github.com/aws/etcdadm-controller/api/v1beta1"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/etcdbackup"
+	"github.com/aws/eks-anywhere/pkg/etcdbackup/mocks"
+)
+
+const (
+	sshKey  = "id_rsa"
+	sshUser = "ec2-user"
+)
+
+func etcdManifest(name, ip string) string {
+	return `apiVersion: v1
+kind: Pod
+metadata:
+  name: etcd
+  namespace: kube-system
+spec:
+  containers:
+  - command:
+    - etcd
+    - --advertise-client-urls=https://` + ip + `:2379
+    - --data-dir=/var/lib/etcd
+    - --initial-advertise-peer-urls=https://` + ip + `:2380
+    - --name=` + name + `
+    image: ` + etcdImage + `
+    name: etcd
+`
+}
+
+func etcdEnv(name, ip string) string {
+	return `ETCD_NAME=` + name + `
+ETCD_DATA_DIR=/var/lib/etcd
+ETCD_INITIAL_ADVERTISE_PEER_URLS="https://` + ip + `:2380"
+ETCD_ADVERTISE_CLIENT_URLS=https://` + ip + `:2379
+`
+}
+
+func TestRestoreStackedEtcd(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	ssh := mocks.NewMockSSHRunner(ctrl)
+	snapshot := []byte("snapshot")
+	ips := []string{"10.0.0.1", "10.0.0.2"}
+	names := map[string]string{"10.0.0.1": "prod-cp-a", "10.0.0.2": "prod-cp-b"}
+	initialCluster := "prod-cp-a=https://10.0.0.1:2380,prod-cp-b=https://10.0.0.2:2380"
+
+	var calls []*gomock.Call
+	for _, ip := range ips {
+		calls = append(calls, ssh.EXPECT().RunCommand(ctx, sshKey, sshUser, ip, "sudo", "cat", "/etc/kubernetes/manifests/etcd.yaml").Return(etcdManifest(names[ip], ip), nil))
+	}
+	for _, ip := range ips {
+		calls = append(calls, ssh.EXPECT().RunCommandWithStdin(ctx, snapshot, sshKey, sshUser, ip, "sudo", "tee", "/var/lib/eksa-etcd-restore.db", ">", "/dev/null"))
+	}
+	for _, ip := range ips {
+		calls = append(calls, ssh.EXPECT().RunCommand(ctx, sshKey, sshUser, ip, "sudo", "sh", "-c",
+			`'mv /etc/kubernetes/manifests/etcd.yaml /etc/kubernetes/etcd.yaml.eksa-restore && timeout 120 sh -c "while crictl ps -q --name ^etcd$ | grep -q .; do sleep 2; done"'`))
+	}
+	for _, ip := range ips {
+		calls = append(calls, ssh.EXPECT().RunCommand(ctx, sshKey, sshUser, ip, "sudo", "sh", "-c",
+			"'rm -rf /var/lib/etcd.eksa-restore /var/lib/etcd.eksa-restore-old && "+
+				"ctr -n k8s.io run --rm --env ETCDCTL_API=3 --mount type=bind,src=/var/lib,dst=/var/lib,options=rbind:rw "+etcdImage+" eksa-etcd-restore etcdctl "+
+				"snapshot restore /var/lib/eksa-etcd-restore.db --name "+names[ip]+" --initial-cluster "+initialCluster+" --initial-cluster-token eksa-etcd-restore "+
+				"--initial-advertise-peer-urls https://"+ip+":2380 --data-dir /var/lib/etcd.eksa-restore && "+
+				"chown -R --reference=/var/lib/etcd /var/lib/etcd.eksa-restore && "+
+				"mv /var/lib/etcd /var/lib/etcd.eksa-restore-old && mv /var/lib/etcd.eksa-restore /var/lib/etcd && "+
+				"rm -f /var/lib/eksa-etcd-restore.db'"))
+	}
+	for _, ip := range ips {
+		calls = append(calls, ssh.EXPECT().RunCommand(ctx, sshKey, sshUser, ip, "sudo", "mv", "/etc/kubernetes/etcd.yaml.eksa-restore", "/etc/kubernetes/manifests/etcd.yaml"))
+	}
+	gomock.InOrder(calls...)
+
+	r := etcdbackup.NewRestorer(ssh, sshKey, sshUser)
+	g.Expect(r.Restore(ctx, snapshot, false, ips)).To(Succeed())
+}
+
+func TestRestoreExternalEtcd(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	ssh := mocks.NewMockSSHRunner(ctrl)
+	snapshot := []byte("snapshot")
+	ip := "10.0.0.5"
+
+	gomock.InOrder(
+		ssh.EXPECT().RunCommand(ctx, sshKey, sshUser, ip, "sudo", "cat", "/etc/etcd/etcd.env").Return(etcdEnv("prod-etcd-a", ip), nil),
+		ssh.EXPECT().RunCommandWithStdin(ctx, snapshot, sshKey, sshUser, ip, "sudo", "tee", "/var/lib/eksa-etcd-restore.db", ">", "/dev/null"),
+		ssh.EXPECT().RunCommand(ctx, sshKey, sshUser, ip, "sudo", "systemctl", "stop", "etcd"),
+		ssh.EXPECT().RunCommand(ctx, sshKey, sshUser, ip, "sudo", "sh", "-c",
+			"'rm -rf /var/lib/etcd.eksa-restore /var/lib/etcd.eksa-restore-old && "+
+				"ETCDCTL_API=3 /opt/bin/etcdctl snapshot restore /var/lib/eksa-etcd-restore.db --name prod-etcd-a --initial-cluster prod-etcd-a=https://10.0.0.5:2380 "+
+				"--initial-cluster-token eksa-etcd-restore --initial-advertise-peer-urls https://10.0.0.5:2380 --data-dir /var/lib/etcd.eksa-restore && "+
+				"chown -R --reference=/var/lib/etcd /var/lib/etcd.eksa-restore && "+
+				"mv /var/lib/etcd /var/lib/etcd.eksa-restore-old && mv /var/lib/etcd.eksa-restore /var/lib/etcd && "+
+				"rm -f /var/lib/eksa-etcd-restore.db'"),
+		ssh.EXPECT().RunCommand(ctx, sshKey, sshUser, ip, "sudo", "systemctl", "start", "etcd"),
+	)
+
+	r := etcdbackup.NewRestorer(ssh, sshKey, sshUser)
+	g.Expect(r.Restore(ctx, snapshot, true, []string{ip})).To(Succeed())
+}
+
+func TestRestoreMemberConfigNotFound(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	ssh := mocks.NewMockSSHRunner(ctrl)
+	ip := "10.0.0.5"
+
+	ssh.EXPECT().RunCommand(ctx, sshKey, sshUser, ip, "sudo", "cat", "/etc/etcd/etcd.env").Return("ETCD_DATA_DIR=/var/lib/etcd\n", nil)
+
+	r := etcdbackup.NewRestorer(ssh, sshKey, sshUser)
+	g.Expect(r.Restore(ctx, []byte("snapshot"), true, []string{ip})).To(MatchError("etcd member name or peer URL not found on 10.0.0.5"))
+}
+
+func TestRestoreStopError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	ssh := mocks.NewMockSSHRunner(ctrl)
+	ip := "10.0.0.5"
+
+	ssh.EXPECT().RunCommand(ctx, sshKey, sshUser, ip, "sudo", "cat", "/etc/etcd/etcd.env").Return(etcdEnv("prod-etcd-a", ip), nil)
+	ssh.EXPECT().RunCommandWithStdin(ctx, gomock.Any(), sshKey, sshUser, ip, gomock.Any())
+	ssh.EXPECT().RunCommand(ctx, sshKey, sshUser, ip, "sudo", "systemctl", "stop", "etcd").Return("", errors.New("unit not found"))
+
+	r := etcdbackup.NewRestorer(ssh, sshKey, sshUser)
+	g.Expect(r.Restore(ctx, []byte("snapshot"), true, []string{ip})).To(MatchError("stopping etcd member prod-etcd-a: unit not found"))
+}
+
+func machine(name, cluster string, labels map[string]string, addresses ...clusterv1.MachineAddress) *clusterv1.Machine {
+	m := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "eksa-system",
+			Labels:    map[string]string{clusterv1.ClusterNameLabel: cluster},
+		},
+		Status: clusterv1.MachineStatus{Addresses: addresses},
+	}
+	for k, v := range labels {
+		m.Labels[k] = v
+	}
+
+	return m
+}
+
+func TestMemberIPs(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cp := map[string]string{clusterv1.MachineControlPlaneLabel: ""}
+	etcd := map[string]string{clusterv1.MachineEtcdClusterLabelName: "prod-etcd"}
+	client := test.NewFakeKubeClient(
+		machine("prod-cp-b", "prod", cp, clusterv1.MachineAddress{Type: clusterv1.MachineInternalIP, Address: "10.0.0.2"}),
+		machine("prod-cp-a", "prod", cp,
+			clusterv1.MachineAddress{Type: clusterv1.MachineInternalIP, Address: "192.168.0.1"},
+			clusterv1.MachineAddress{Type: clusterv1.MachineExternalIP, Address: "10.0.0.1"},
+		),
+		machine("prod-md-a", "prod", nil, clusterv1.MachineAddress{Type: clusterv1.MachineExternalIP, Address: "10.0.0.10"}),
+		machine("prod-etcd-a", "prod", etcd, clusterv1.MachineAddress{Type: clusterv1.MachineExternalIP, Address: "10.0.0.20"}),
+		machine("staging-cp-a", "staging", cp, clusterv1.MachineAddress{Type: clusterv1.MachineExternalIP, Address: "10.0.1.1"}),
+	)
+	cluster := &anywherev1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "prod"}}
+
+	ips, err := etcdbackup.MemberIPs(ctx, client, cluster)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ips).To(Equal([]string{"10.0.0.1", "10.0.0.2"}))
+
+	cluster.Spec.ExternalEtcdConfiguration = &anywherev1.ExternalEtcdConfiguration{Count: 1}
+	ips, err = etcdbackup.MemberIPs(ctx, client, cluster)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ips).To(Equal([]string{"10.0.0.20"}))
+}
+
+func TestMemberIPsNoMachines(t *testing.T) {
+	g := NewWithT(t)
+	cluster := &anywherev1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "prod"}}
+
+	_, err := etcdbackup.MemberIPs(context.Background(), test.NewFakeKubeClient(), cluster)
+	g.Expect(err).To(MatchError("no etcd machines found for cluster prod"))
+}
+
+func TestValidateRestore(t *testing.T) {
+	kcp := func(format bootstrapv1.Format) *controlplanev1.KubeadmControlPlane {
+		return &controlplanev1.KubeadmControlPlane{
+			ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "eksa-system"},
+			Spec: controlplanev1.KubeadmControlPlaneSpec{
+				KubeadmConfigSpec: bootstrapv1.KubeadmConfigSpec{Format: format},
+			},
+		}
+	}
+	etcdCluster := func(format etcdbootstrapv1.Format) *etcdv1.EtcdadmCluster {
+		return &etcdv1.EtcdadmCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "prod-etcd", Namespace: "eksa-system"},
+			Spec: etcdv1.EtcdadmClusterSpec{
+				EtcdadmConfigSpec: etcdbootstrapv1.EtcdadmConfigSpec{Format: format},
+			},
+		}
+	}
+	tests := []struct {
+		name         string
+		externalEtcd bool
+		objs         []client.Object
+		wantErr      string
+	}{
+		{
+			name: "stacked etcd ubuntu",
+			objs: []client.Object{kcp(bootstrapv1.CloudConfig)},
+		},
+		{
+			name:    "stacked etcd bottlerocket",
+			objs:    []client.Object{kcp(bootstrapv1.Bottlerocket)},
+			wantErr: "restoring etcd is not supported for Bottlerocket control plane machines",
+		},
+		{
+			name:         "external etcd ubuntu",
+			externalEtcd: true,
+			objs:         []client.Object{kcp(bootstrapv1.CloudConfig), etcdCluster(etcdbootstrapv1.CloudConfig)},
+		},
+		{
+			name:         "external etcd bottlerocket",
+			externalEtcd: true,
+			objs:         []client.Object{kcp(bootstrapv1.Bottlerocket), etcdCluster(etcdbootstrapv1.Bottlerocket)},
+			wantErr:      "restoring etcd is not supported for Bottlerocket etcd machines",
+		},
+		{
+			name:    "no control plane",
+			wantErr: "getting kubeadm control plane",
+		},
+		{
+			name:         "no etcd cluster",
+			externalEtcd: true,
+			objs:         []client.Object{kcp(bootstrapv1.CloudConfig)},
+			wantErr:      "getting etcdadm cluster",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &anywherev1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "prod"}}
+			if tt.externalEtcd {
+				cluster.Spec.ExternalEtcdConfiguration = &anywherev1.ExternalEtcdConfiguration{Count: 3}
+			}
+
+			err := etcdbackup.ValidateRestore(context.Background(), test.NewFakeKubeClient(tt.objs...), cluster)
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestSetClusterPaused(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cluster := &anywherev1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "prod"}}
+	client := test.NewFakeKubeClient(&clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "eksa-system"},
+	})
+
+	g.Expect(etcdbackup.SetClusterPaused(ctx, client, cluster, true)).To(Succeed())
+	capiCluster := &clusterv1.Cluster{}
+	g.Expect(client.Get(ctx, "prod", "eksa-system", capiCluster)).To(Succeed())
+	g.Expect(capiCluster.Spec.Paused).To(BeTrue())
+
+	g.Expect(etcdbackup.SetClusterPaused(ctx, client, cluster, false)).To(Succeed())
+	g.Expect(client.Get(ctx, "prod", "eksa-system", capiCluster)).To(Succeed())
+	g.Expect(capiCluster.Spec.Paused).To(BeFalse())
+}
+
+func TestSetClusterPausedNotFound(t *testing.T) {
+	g := NewWithT(t)
+	cluster := &anywherev1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "prod"}}
+
+	err := etcdbackup.SetClusterPaused(context.Background(), test.NewFakeKubeClient(), cluster, true)
+	g.Expect(err).To(MatchError(ContainSubstring("getting CAPI cluster")))
+}
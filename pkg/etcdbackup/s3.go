@@ -0,0 +1,136 @@
+package etcdbackup
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+)
+
+const defaultS3Region = "us-east-1"
+
+// S3Config is the configuration of an S3 compatible object store.
+type S3Config struct {
+	// Endpoint is the URL of the object store. If empty, AWS S3 is used.
+	Endpoint string
+	// Region is the region of the bucket. If empty, us-east-1 is used.
+	Region string
+	// Bucket is the bucket the snapshots are saved to.
+	Bucket string
+	// Prefix is prepended to the key of the snapshots.
+	Prefix string
+	// ForcePathStyle uses path style URLs, required by most S3 compatible object stores.
+	ForcePathStyle bool
+}
+
+// S3Store is a Store that saves snapshots to an S3 compatible object store.
+type S3Store struct {
+	client s3iface.S3API
+	bucket string
+	prefix string
+}
+
+// NewS3Store returns a new S3Store. Credentials are taken from the default AWS
+// credentials chain, usually the AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY env vars.
+func NewS3Store(config S3Config) (*S3Store, error) {
+	region := config.Region
+	if region == "" {
+		region = defaultS3Region
+	}
+
+	awsConfig := &aws.Config{
+		Region:           aws.String(region),
+		S3ForcePathStyle: aws.Bool(config.ForcePathStyle),
+	}
+	if config.Endpoint != "" {
+		awsConfig.Endpoint = aws.String(config.Endpoint)
+	}
+
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		return nil, fmt.Errorf("creating S3 session: %v", err)
+	}
+
+	return NewS3StoreWithClient(s3.New(sess), config.Bucket, config.Prefix), nil
+}
+
+// NewS3StoreWithClient returns a new S3Store that uses client.
+func NewS3StoreWithClient(client s3iface.S3API, bucket, prefix string) *S3Store {
+	return &S3Store{
+		client: client,
+		bucket: bucket,
+		prefix: strings.Trim(prefix, "/"),
+	}
+}
+
+// Put saves the content of body as the snapshot name.
+func (s *S3Store) Put(ctx context.Context, name string, body io.ReadSeeker) error {
+	_, err := s.client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.key(name)),
+		Body:   body,
+	})
+	return err
+}
+
+// Get returns the content of the snapshot name.
+func (s *S3Store) Get(ctx context.Context, name string) (io.ReadCloser, error) {
+	out, err := s.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.key(name)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return out.Body, nil
+}
+
+// List returns the names of all the snapshots under the prefix.
+func (s *S3Store) List(ctx context.Context) ([]string, error) {
+	input := &s3.ListObjectsV2Input{
+		Bucket: aws.String(s.bucket),
+	}
+	if s.prefix != "" {
+		input.Prefix = aws.String(s.prefix + "/")
+	}
+
+	var names []string
+	err := s.client.ListObjectsV2PagesWithContext(ctx, input, func(page *s3.ListObjectsV2Output, _ bool) bool {
+		for _, o := range page.Contents {
+			name := strings.TrimPrefix(aws.StringValue(o.Key), aws.StringValue(input.Prefix))
+			if name != "" && !strings.Contains(name, "/") {
+				names = append(names, name)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+
+// Delete removes the snapshot name.
+func (s *S3Store) Delete(ctx context.Context, name string) error {
+	_, err := s.client.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.key(name)),
+	})
+	return err
+}
+
+func (s *S3Store) key(name string) string {
+	if s.prefix == "" {
+		return name
+	}
+
+	return path.Join(s.prefix, name)
+}
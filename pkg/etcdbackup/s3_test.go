@@ -0,0 +1,179 @@
+package etcdbackup_test
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/etcdbackup"
+)
+
+// fakeS3 is a minimal path style S3 compatible object store, like a local MinIO.
+type fakeS3 struct {
+	mu      sync.Mutex
+	bucket  string
+	objects map[string][]byte
+}
+
+type listBucketResult struct {
+	XMLName  xml.Name `xml:"ListBucketResult"`
+	Name     string   `xml:"Name"`
+	KeyCount int      `xml:"KeyCount"`
+	Contents []struct {
+		Key  string `xml:"Key"`
+		Size int    `xml:"Size"`
+	} `xml:"Contents"`
+}
+
+func newFakeS3(t *testing.T, bucket string) (*fakeS3, *httptest.Server) {
+	t.Helper()
+	f := &fakeS3{bucket: bucket, objects: map[string][]byte{}}
+	server := httptest.NewServer(f)
+	t.Cleanup(server.Close)
+	t.Setenv("AWS_ACCESS_KEY_ID", "minio")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "minio123")
+
+	return f, server
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if bucket != f.bucket {
+		http.Error(w, "NoSuchBucket", http.StatusNotFound)
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodGet && key == "":
+		result := listBucketResult{Name: f.bucket}
+		prefix := r.URL.Query().Get("prefix")
+		keys := make([]string, 0, len(f.objects))
+		for k := range f.objects {
+			if strings.HasPrefix(k, prefix) {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			result.Contents = append(result.Contents, struct {
+				Key  string `xml:"Key"`
+				Size int    `xml:"Size"`
+			}{Key: k, Size: len(f.objects[k])})
+		}
+		result.KeyCount = len(keys)
+		w.Header().Set("Content-Type", "application/xml")
+		_ = xml.NewEncoder(w).Encode(result)
+	case r.Method == http.MethodPut:
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		f.objects[key] = body
+	case r.Method == http.MethodGet:
+		body, ok := f.objects[key]
+		if !ok {
+			http.Error(w, "NoSuchKey", http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(body)
+	case r.Method == http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "NotImplemented", http.StatusNotImplemented)
+	}
+}
+
+func (f *fakeS3) keys() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	keys := make([]string, 0, len(f.objects))
+	for k := range f.objects {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func TestSaveS3Store(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	fake, server := newFakeS3(t, "etcd")
+	fake.objects["backups/staging-20240301T020000Z.db"] = []byte("other")
+
+	store, err := etcdbackup.NewS3Store(etcdbackup.S3Config{
+		Endpoint:       server.URL,
+		Bucket:         "etcd",
+		Prefix:         "/backups/",
+		ForcePathStyle: true,
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	start := time.Date(2024, 3, 5, 2, 0, 0, 0, time.UTC)
+	snapshotFile := writeSnapshotFile(t, "snapshot")
+	for i := 0; i < 3; i++ {
+		_, err := etcdbackup.Save(ctx, store, "prod", snapshotFile, 2, start.Add(time.Duration(i)*time.Hour))
+		g.Expect(err).NotTo(HaveOccurred())
+	}
+
+	g.Expect(fake.keys()).To(Equal([]string{
+		"backups/prod-20240305T030000Z.db",
+		"backups/prod-20240305T040000Z.db",
+		"backups/staging-20240301T020000Z.db",
+	}))
+
+	r, err := store.Get(ctx, "prod-20240305T040000Z.db")
+	g.Expect(err).NotTo(HaveOccurred())
+	defer r.Close()
+	content, err := io.ReadAll(r)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(content)).To(Equal("snapshot"))
+}
+
+func TestS3StoreListWithoutPrefix(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	fake, server := newFakeS3(t, "etcd")
+	fake.objects["prod-20240305T030000Z.db"] = []byte("snapshot")
+	fake.objects["backups/prod-20240305T040000Z.db"] = []byte("snapshot")
+
+	store, err := etcdbackup.NewS3Store(etcdbackup.S3Config{
+		Endpoint:       server.URL,
+		Bucket:         "etcd",
+		ForcePathStyle: true,
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	names, err := store.List(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(names).To(Equal([]string{"prod-20240305T030000Z.db"}))
+}
+
+func TestS3StoreMissingBucket(t *testing.T) {
+	g := NewWithT(t)
+	_, server := newFakeS3(t, "etcd")
+
+	store, err := etcdbackup.NewS3Store(etcdbackup.S3Config{
+		Endpoint:       server.URL,
+		Bucket:         "missing",
+		ForcePathStyle: true,
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	_, err = etcdbackup.Save(context.Background(), store, "prod", writeSnapshotFile(t, "snapshot"), 2, time.Now())
+	g.Expect(err).To(MatchError(ContainSubstring("saving etcd snapshot")))
+}
@@ -0,0 +1,201 @@
+package etcdbackup
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultRetention is the number of snapshots kept when the etcd backup doesn't configure one.
+	DefaultRetention = 7
+
+	snapshotExtension  = ".db"
+	snapshotTimeFormat = "20060102T150405Z"
+)
+
+// Store saves etcd snapshots to a backup location.
+type Store interface {
+	// Put saves the content of body as the snapshot name.
+	Put(ctx context.Context, name string, body io.ReadSeeker) error
+	// Get returns the content of the snapshot name. The caller needs to close it.
+	Get(ctx context.Context, name string) (io.ReadCloser, error)
+	// List returns the names of all the snapshots in the store.
+	List(ctx context.Context) ([]string, error)
+	// Delete removes the snapshot name.
+	Delete(ctx context.Context, name string) error
+}
+
+// SnapshotName returns the name of the snapshot of a cluster taken at t. Snapshot names
+// of the same cluster sort in the order they were taken.
+func SnapshotName(clusterName string, t time.Time) string {
+	return fmt.Sprintf("%s-%s%s", clusterName, t.UTC().Format(snapshotTimeFormat), snapshotExtension)
+}
+
+// Save saves the snapshot file of a cluster to the store and removes the oldest snapshots
+// of the cluster so only retention snapshots are kept. It returns the name of the saved snapshot.
+func Save(ctx context.Context, store Store, clusterName, snapshotFile string, retention int, now time.Time) (string, error) {
+	f, err := os.Open(snapshotFile)
+	if err != nil {
+		return "", fmt.Errorf("opening etcd snapshot: %v", err)
+	}
+	defer f.Close()
+
+	name := SnapshotName(clusterName, now)
+	if err := store.Put(ctx, name, f); err != nil {
+		return "", fmt.Errorf("saving etcd snapshot %s: %v", name, err)
+	}
+
+	if err := Prune(ctx, store, clusterName, retention); err != nil {
+		return "", err
+	}
+
+	return name, nil
+}
+
+// Prune removes the oldest snapshots of a cluster from the store so only retention snapshots are kept.
+// Snapshots of other clusters sharing the store are not touched.
+func Prune(ctx context.Context, store Store, clusterName string, retention int) error {
+	if retention <= 0 {
+		retention = DefaultRetention
+	}
+
+	snapshots, err := ListSnapshots(ctx, store, clusterName)
+	if err != nil {
+		return err
+	}
+
+	if len(snapshots) <= retention {
+		return nil
+	}
+
+	for _, name := range snapshots[:len(snapshots)-retention] {
+		if err := store.Delete(ctx, name); err != nil {
+			return fmt.Errorf("deleting etcd snapshot %s: %v", name, err)
+		}
+	}
+
+	return nil
+}
+
+// ListSnapshots returns the names of the snapshots of a cluster in the store, from oldest to newest.
+func ListSnapshots(ctx context.Context, store Store, clusterName string) ([]string, error) {
+	names, err := store.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing etcd snapshots: %v", err)
+	}
+
+	snapshots := make([]string, 0, len(names))
+	for _, name := range names {
+		if isSnapshotOf(clusterName, name) {
+			snapshots = append(snapshots, name)
+		}
+	}
+	sort.Strings(snapshots)
+
+	return snapshots, nil
+}
+
+// LatestSnapshot can be passed to Load to read the newest snapshot of a cluster.
+const LatestSnapshot = "latest"
+
+// Load reads the snapshot name of a cluster from the store. If name is LatestSnapshot, the newest
+// snapshot of the cluster is read.
+func Load(ctx context.Context, store Store, clusterName, name string) ([]byte, error) {
+	if name == LatestSnapshot {
+		snapshots, err := ListSnapshots(ctx, store, clusterName)
+		if err != nil {
+			return nil, err
+		}
+		if len(snapshots) == 0 {
+			return nil, fmt.Errorf("no etcd snapshots found for cluster %s", clusterName)
+		}
+		name = snapshots[len(snapshots)-1]
+	}
+
+	r, err := store.Get(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("getting etcd snapshot %s: %v", name, err)
+	}
+	defer r.Close()
+
+	snapshot, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading etcd snapshot %s: %v", name, err)
+	}
+
+	return snapshot, nil
+}
+
+func isSnapshotOf(clusterName, name string) bool {
+	timestamp := strings.TrimPrefix(name, clusterName+"-")
+	if timestamp == name || !strings.HasSuffix(timestamp, snapshotExtension) {
+		return false
+	}
+
+	_, err := time.Parse(snapshotTimeFormat, strings.TrimSuffix(timestamp, snapshotExtension))
+	return err == nil
+}
+
+// DirStore is a Store that saves snapshots to a folder, usually a mounted persistent volume.
+type DirStore struct {
+	dir string
+}
+
+// NewDirStore returns a new DirStore.
+func NewDirStore(dir string) *DirStore {
+	return &DirStore{dir: dir}
+}
+
+// Put saves the content of body as the snapshot name. The snapshot is written to a temporary
+// file first, so an interrupted backup never leaves a partial snapshot behind.
+func (s *DirStore) Put(_ context.Context, name string, body io.ReadSeeker) error {
+	tmp, err := os.CreateTemp(s.dir, "."+name+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), filepath.Join(s.dir, name))
+}
+
+// Get returns the content of the snapshot name.
+func (s *DirStore) Get(_ context.Context, name string) (io.ReadCloser, error) {
+	return os.Open(filepath.Join(s.dir, name))
+}
+
+// List returns the names of all the snapshots in the folder.
+func (s *DirStore) List(_ context.Context) ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if e.Type().IsRegular() && !strings.HasPrefix(e.Name(), ".") {
+			names = append(names, e.Name())
+		}
+	}
+
+	return names, nil
+}
+
+// Delete removes the snapshot name.
+func (s *DirStore) Delete(_ context.Context, name string) error {
+	return os.Remove(filepath.Join(s.dir, name))
+}
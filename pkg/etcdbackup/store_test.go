@@ -0,0 +1,149 @@
+package etcdbackup_test
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/etcdbackup"
+)
+
+func writeSnapshotFile(t *testing.T, content string) string {
+	t.Helper()
+	f := filepath.Join(t.TempDir(), "snapshot.db")
+	if err := os.WriteFile(f, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return f
+}
+
+func TestSnapshotName(t *testing.T) {
+	g := NewWithT(t)
+	now := time.Date(2024, 3, 5, 2, 0, 0, 0, time.FixedZone("PST", -8*3600))
+	g.Expect(etcdbackup.SnapshotName("prod", now)).To(Equal("prod-20240305T100000Z.db"))
+}
+
+func TestSaveDirStore(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	dir := t.TempDir()
+	store := etcdbackup.NewDirStore(dir)
+	now := time.Date(2024, 3, 5, 2, 0, 0, 0, time.UTC)
+
+	name, err := etcdbackup.Save(ctx, store, "prod", writeSnapshotFile(t, "snapshot"), 3, now)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(name).To(Equal("prod-20240305T020000Z.db"))
+
+	content, err := os.ReadFile(filepath.Join(dir, name))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(content)).To(Equal("snapshot"))
+
+	r, err := store.Get(ctx, name)
+	g.Expect(err).NotTo(HaveOccurred())
+	defer r.Close()
+	content, err = io.ReadAll(r)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(content)).To(Equal("snapshot"))
+}
+
+func TestSavePrunesOldSnapshots(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	dir := t.TempDir()
+	store := etcdbackup.NewDirStore(dir)
+	start := time.Date(2024, 3, 5, 2, 0, 0, 0, time.UTC)
+	snapshotFile := writeSnapshotFile(t, "snapshot")
+
+	g.Expect(os.WriteFile(filepath.Join(dir, "staging-20240301T020000Z.db"), []byte("other"), 0o600)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(dir, "prod-notes.txt"), []byte("notes"), 0o600)).To(Succeed())
+
+	for i := 0; i < 5; i++ {
+		_, err := etcdbackup.Save(ctx, store, "prod", snapshotFile, 3, start.Add(time.Duration(i)*time.Hour))
+		g.Expect(err).NotTo(HaveOccurred())
+	}
+
+	snapshots, err := etcdbackup.ListSnapshots(ctx, store, "prod")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(snapshots).To(Equal([]string{
+		"prod-20240305T040000Z.db",
+		"prod-20240305T050000Z.db",
+		"prod-20240305T060000Z.db",
+	}))
+
+	names, err := store.List(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(names).To(ContainElements("staging-20240301T020000Z.db", "prod-notes.txt"))
+}
+
+func TestPruneDefaultRetention(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	dir := t.TempDir()
+	store := etcdbackup.NewDirStore(dir)
+	start := time.Date(2024, 3, 5, 2, 0, 0, 0, time.UTC)
+
+	for i := 0; i < etcdbackup.DefaultRetention+2; i++ {
+		name := etcdbackup.SnapshotName("prod", start.Add(time.Duration(i)*time.Hour))
+		g.Expect(os.WriteFile(filepath.Join(dir, name), []byte("snapshot"), 0o600)).To(Succeed())
+	}
+
+	g.Expect(etcdbackup.Prune(ctx, store, "prod", 0)).To(Succeed())
+
+	snapshots, err := etcdbackup.ListSnapshots(ctx, store, "prod")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(snapshots).To(HaveLen(etcdbackup.DefaultRetention))
+	g.Expect(snapshots[0]).To(Equal("prod-20240305T040000Z.db"))
+}
+
+func TestSaveMissingSnapshotFile(t *testing.T) {
+	g := NewWithT(t)
+	store := etcdbackup.NewDirStore(t.TempDir())
+
+	_, err := etcdbackup.Save(context.Background(), store, "prod", filepath.Join(t.TempDir(), "missing.db"), 3, time.Now())
+	g.Expect(err).To(MatchError(ContainSubstring("opening etcd snapshot")))
+}
+
+func TestSaveDirStoreMissingDir(t *testing.T) {
+	g := NewWithT(t)
+	store := etcdbackup.NewDirStore(filepath.Join(t.TempDir(), "missing"))
+
+	_, err := etcdbackup.Save(context.Background(), store, "prod", writeSnapshotFile(t, "snapshot"), 3, time.Now())
+	g.Expect(err).To(MatchError(ContainSubstring("saving etcd snapshot")))
+}
+
+func TestLoad(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	store := etcdbackup.NewDirStore(t.TempDir())
+	start := time.Date(2024, 3, 5, 2, 0, 0, 0, time.UTC)
+	for i, content := range []string{"first", "second"} {
+		_, err := etcdbackup.Save(ctx, store, "prod", writeSnapshotFile(t, content), 3, start.Add(time.Duration(i)*time.Hour))
+		g.Expect(err).NotTo(HaveOccurred())
+	}
+
+	snapshot, err := etcdbackup.Load(ctx, store, "prod", etcdbackup.LatestSnapshot)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(snapshot)).To(Equal("second"))
+
+	snapshot, err = etcdbackup.Load(ctx, store, "prod", "prod-20240305T020000Z.db")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(snapshot)).To(Equal("first"))
+}
+
+func TestLoadErrors(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	store := etcdbackup.NewDirStore(t.TempDir())
+
+	_, err := etcdbackup.Load(ctx, store, "prod", etcdbackup.LatestSnapshot)
+	g.Expect(err).To(MatchError("no etcd snapshots found for cluster prod"))
+
+	_, err = etcdbackup.Load(ctx, store, "prod", "prod-20240305T020000Z.db")
+	g.Expect(err).To(MatchError(ContainSubstring("getting etcd snapshot prod-20240305T020000Z.db")))
+}
@@ -6,7 +6,9 @@ import (
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/git/providers/bitbucket"
 	"github.com/aws/eks-anywhere/pkg/git/providers/github"
+	"github.com/aws/eks-anywhere/pkg/git/providers/gitlab"
 	"github.com/aws/eks-anywhere/pkg/types"
 )
 
@@ -15,6 +17,8 @@ const (
 	eksaGithubTokenEnv         = "EKSA_GITHUB_TOKEN"
 	githubTokenEnv             = "GITHUB_TOKEN"
 	githubProvider             = "github"
+	gitlabProvider             = "gitlab"
+	bitbucketServerProvider    = "bitbucket-server"
 	gitProvider                = "git"
 	defaultPrivateKeyAlgorithm = "ecdsa"
 )
@@ -64,6 +68,83 @@ func (f *Flux) BootstrapGithub(ctx context.Context, cluster *types.Cluster, flux
 	return err
 }
 
+// BootstrapGitlab creates the GitLab repository if it doesn’t exist, and commits the toolkit
+// components manifests to the main branch. Then it configures the target cluster to synchronize with the repository.
+// If the toolkit components are present on the cluster, the bootstrap command will perform an upgrade if needed.
+func (f *Flux) BootstrapGitlab(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error {
+	c := fluxConfig.Spec
+	params := []string{
+		"bootstrap",
+		gitlabProvider,
+		"--repository", c.Gitlab.Repository,
+		"--owner", c.Gitlab.Owner,
+		"--path", c.ClusterConfigPath,
+		"--ssh-key-algorithm", defaultPrivateKeyAlgorithm,
+	}
+	if c.Gitlab.Hostname != "" {
+		params = append(params, "--hostname", c.Gitlab.Hostname)
+	}
+	params = setUpCommonParamsBootstrap(cluster, fluxConfig, params)
+
+	if c.Gitlab.Personal {
+		params = append(params, "--personal")
+	}
+
+	token, err := gitlab.GetGitlabAccessTokenFromEnv()
+	if err != nil {
+		return fmt.Errorf("setting token env: %v", err)
+	}
+
+	env := map[string]string{
+		gitlab.GitlabTokenEnv: token,
+	}
+
+	_, err = f.ExecuteWithEnv(ctx, env, params...)
+	if err != nil {
+		return fmt.Errorf("executing flux bootstrap gitlab: %v", err)
+	}
+
+	return err
+}
+
+// BootstrapBitbucketServer creates the Bitbucket Server repository if it doesn’t exist, and commits the toolkit
+// components manifests to the main branch. Then it configures the target cluster to synchronize with the repository.
+// If the toolkit components are present on the cluster, the bootstrap command will perform an upgrade if needed.
+func (f *Flux) BootstrapBitbucketServer(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error {
+	c := fluxConfig.Spec
+	auth, err := bitbucket.GetBitbucketAccessTokenFromEnv()
+	if err != nil {
+		return fmt.Errorf("setting token env: %v", err)
+	}
+
+	params := []string{
+		"bootstrap",
+		bitbucketServerProvider,
+		"--repository", bitbucket.Slug(c.BitbucketServer.Repository),
+		"--owner", c.BitbucketServer.Owner,
+		"--hostname", c.BitbucketServer.Hostname,
+		"--username", auth.Username,
+		"--path", c.ClusterConfigPath,
+		"--ssh-key-algorithm", defaultPrivateKeyAlgorithm,
+	}
+	params = setUpCommonParamsBootstrap(cluster, fluxConfig, params)
+
+	if c.BitbucketServer.Personal {
+		params = append(params, "--personal")
+	}
+
+	env := map[string]string{
+		bitbucket.BitbucketTokenEnv: auth.Token,
+	}
+
+	_, err = f.ExecuteWithEnv(ctx, env, params...)
+	if err != nil {
+		return fmt.Errorf("executing flux bootstrap bitbucket-server: %v", err)
+	}
+
+	return err
+}
+
 // BootstrapGit commits the toolkit components manifests to the branch of a Git repository.
 // It then configures the target cluster to synchronize with the repository. If the toolkit components are present on the cluster, the
 // bootstrap command will perform an upgrade if needed.
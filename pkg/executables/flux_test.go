@@ -149,6 +149,118 @@ func TestFluxInstallGithubToolkitsSuccess(t *testing.T) {
 	}
 }
 
+func TestFluxInstallGitlabToolkitsSuccess(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	t.Setenv("EKSA_GITLAB_TOKEN", "glpat-token")
+
+	tests := []struct {
+		testName     string
+		cluster      *types.Cluster
+		fluxConfig   *v1alpha1.FluxConfig
+		wantExecArgs []interface{}
+	}{
+		{
+			testName: "with hostname and personal",
+			cluster: &types.Cluster{
+				KubeconfigFile: "f.kubeconfig",
+			},
+			fluxConfig: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{
+					ClusterConfigPath: "clusters/cluster-name",
+					Gitlab: &v1alpha1.GitlabProviderConfig{
+						Owner:      "janedoe",
+						Repository: "gitops-fleet",
+						Hostname:   "gitlab.example.com",
+						Personal:   true,
+					},
+				},
+			},
+			wantExecArgs: []interface{}{
+				"bootstrap", "gitlab", "--repository", "gitops-fleet", "--owner", "janedoe", "--path", "clusters/cluster-name", "--ssh-key-algorithm", "ecdsa",
+				"--hostname", "gitlab.example.com", "--kubeconfig", "f.kubeconfig", "--personal",
+			},
+		},
+		{
+			testName: "minimum args",
+			cluster:  &types.Cluster{},
+			fluxConfig: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{
+					Gitlab: &v1alpha1.GitlabProviderConfig{},
+				},
+			},
+			wantExecArgs: []interface{}{
+				"bootstrap", "gitlab", "--repository", "", "--owner", "", "--path", "", "--ssh-key-algorithm", "ecdsa",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			ctx := context.Background()
+			executable := mockexecutables.NewMockExecutable(mockCtrl)
+			env := map[string]string{"GITLAB_TOKEN": "glpat-token"}
+			executable.EXPECT().ExecuteWithEnv(
+				ctx,
+				env,
+				tt.wantExecArgs...,
+			).Return(bytes.Buffer{}, nil)
+
+			f := executables.NewFlux(executable)
+			if err := f.BootstrapGitlab(ctx, tt.cluster, tt.fluxConfig); err != nil {
+				t.Errorf("flux.BootstrapGitlab() error = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func TestFluxInstallGitlabToolkitsNoToken(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	t.Setenv("EKSA_GITLAB_TOKEN", "")
+	executable := mockexecutables.NewMockExecutable(mockCtrl)
+
+	f := executables.NewFlux(executable)
+	fluxConfig := &v1alpha1.FluxConfig{
+		Spec: v1alpha1.FluxConfigSpec{
+			Gitlab: &v1alpha1.GitlabProviderConfig{},
+		},
+	}
+	if err := f.BootstrapGitlab(context.Background(), &types.Cluster{}, fluxConfig); err == nil {
+		t.Error("flux.BootstrapGitlab() error = nil, want not nil")
+	}
+}
+
+func TestFluxInstallBitbucketServerToolkitsSuccess(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	t.Setenv("EKSA_BITBUCKET_TOKEN", "bbs-token")
+	t.Setenv("EKSA_BITBUCKET_USERNAME", "janedoe")
+
+	ctx := context.Background()
+	executable := mockexecutables.NewMockExecutable(mockCtrl)
+	env := map[string]string{"BITBUCKET_TOKEN": "bbs-token"}
+	executable.EXPECT().ExecuteWithEnv(
+		ctx,
+		env,
+		"bootstrap", "bitbucket-server", "--repository", "gitops-fleet", "--owner", "EKSA", "--hostname", "bitbucket.example.com",
+		"--username", "janedoe", "--path", "clusters/cluster-name", "--ssh-key-algorithm", "ecdsa", "--branch", "main",
+	).Return(bytes.Buffer{}, nil)
+
+	f := executables.NewFlux(executable)
+	fluxConfig := &v1alpha1.FluxConfig{
+		Spec: v1alpha1.FluxConfigSpec{
+			ClusterConfigPath: "clusters/cluster-name",
+			Branch:            "main",
+			BitbucketServer: &v1alpha1.BitbucketServerProviderConfig{
+				Owner:      "EKSA",
+				Repository: "Gitops-Fleet",
+				Hostname:   "bitbucket.example.com",
+			},
+		},
+	}
+	if err := f.BootstrapBitbucketServer(ctx, &types.Cluster{}, fluxConfig); err != nil {
+		t.Errorf("flux.BootstrapBitbucketServer() error = %v, want nil", err)
+	}
+}
+
 func TestFluxUninstallGitOpsToolkitsComponents(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 
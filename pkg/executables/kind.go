@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/aws/eks-anywhere/pkg/bootstrapper"
 	"github.com/aws/eks-anywhere/pkg/cluster"
@@ -57,6 +58,7 @@ type kindExecConfig struct {
 	DisableDefaultCNI    bool
 	PodSubnet            string
 	ServiceSubnet        string
+	IPFamily             string
 }
 
 func NewKind(executable Executable, writer filewriter.FileWriter) *Kind {
@@ -82,11 +84,15 @@ func (k *Kind) CreateBootstrapCluster(ctx context.Context, clusterSpec *cluster.
 	podCidrs := clusterSpec.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks
 
 	if len(serviceCidrs) != 0 {
-		k.execConfig.ServiceSubnet = serviceCidrs[0]
+		k.execConfig.ServiceSubnet = strings.Join(serviceCidrs, ",")
 	}
 
 	if len(podCidrs) != 0 {
-		k.execConfig.PodSubnet = podCidrs[0]
+		k.execConfig.PodSubnet = strings.Join(podCidrs, ",")
+	}
+
+	if clusterSpec.Cluster.Spec.ClusterNetwork.UsesIPv6() {
+		k.execConfig.IPFamily = string(clusterSpec.Cluster.Spec.ClusterNetwork.IPFamily())
 	}
 
 	err = k.buildConfigFile()
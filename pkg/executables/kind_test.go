@@ -148,6 +148,40 @@ func TestKindCreateBootstrapClusterSuccess(t *testing.T) {
 	}
 }
 
+func TestKindCreateBootstrapClusterSuccessDualStack(t *testing.T) {
+	_, writer := test.NewWriter(t)
+	ctx := context.Background()
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = "test_cluster"
+		s.VersionsBundles["1.19"] = versionBundle
+		s.Cluster.Spec.ClusterNetwork = v1alpha1.ClusterNetwork{
+			Pods: v1alpha1.Pods{
+				CidrBlocks: []string{"fd00:10:244::/56", "192.168.0.0/16"},
+			},
+			Services: v1alpha1.Services{
+				CidrBlocks: []string{"fd00:10:96::/108", "10.96.0.0/12"},
+			},
+		}
+	})
+
+	executable := mockexecutables.NewMockExecutable(gomock.NewController(t))
+	executable.EXPECT().ExecuteWithEnv(
+		ctx,
+		map[string]string{},
+		"create", "cluster", "--name", "test_cluster-eks-a-cluster", "--kubeconfig", test.OfType("string"), "--image", "public.ecr.aws/l0g8r8j6/kubernetes-sigs/kind/node:v1.20.2", "--config", test.OfType("string"),
+	).Return(bytes.Buffer{}, nil).Times(1).Do(
+		func(ctx context.Context, envs map[string]string, args ...string) (stdout bytes.Buffer, err error) {
+			test.AssertFilesEquals(t, args[9], "testdata/kind_config_dual_stack.yaml")
+			return bytes.Buffer{}, nil
+		},
+	)
+
+	k := executables.NewKind(executable, writer)
+	if _, err := k.CreateBootstrapCluster(ctx, clusterSpec); err != nil {
+		t.Fatalf("CreateBootstrapCluster() error = %v, wantErr %v", err, nil)
+	}
+}
+
 func TestKindCreateBootstrapClusterSuccessWithRegistryMirror(t *testing.T) {
 	_, writer := test.NewWriter(t)
 
@@ -47,3 +47,32 @@ func TestSSHRunCommandError(t *testing.T) {
 	_, err := ssh.RunCommand(ctx, privateKeyPath, username, ip, command...)
 	g.Expect(err).To(MatchError(fmt.Sprintf("running SSH command: %s", errMsg)))
 }
+
+func TestSSHRunCommandWithStdinNoError(t *testing.T) {
+	ctx := context.Background()
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	executable := mockexecutables.NewMockExecutable(mockCtrl)
+	ssh := executables.NewSSH(executable)
+	in := []byte("snapshot")
+
+	executable.EXPECT().ExecuteWithStdin(ctx, in, "-i", privateKeyPath, "-o", "StrictHostKeyChecking=no", fmt.Sprintf("%s@%s", username, ip), "some", "random", "test", "command").Return(*bytes.NewBufferString("out"), nil)
+
+	out, err := ssh.RunCommandWithStdin(ctx, in, privateKeyPath, username, ip, command...)
+	g.Expect(err).To(Not(HaveOccurred()))
+	g.Expect(out).To(Equal("out"))
+}
+
+func TestSSHRunCommandWithStdinError(t *testing.T) {
+	ctx := context.Background()
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	executable := mockexecutables.NewMockExecutable(mockCtrl)
+	ssh := executables.NewSSH(executable)
+	in := []byte("snapshot")
+
+	executable.EXPECT().ExecuteWithStdin(ctx, in, "-i", privateKeyPath, "-o", "StrictHostKeyChecking=no", fmt.Sprintf("%s@%s", username, ip), "some", "random", "test", "command").Return(bytes.Buffer{}, errors.New("connection refused"))
+
+	_, err := ssh.RunCommandWithStdin(ctx, in, privateKeyPath, username, ip, command...)
+	g.Expect(err).To(MatchError("running SSH command: connection refused"))
+}
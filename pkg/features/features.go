@@ -6,7 +6,6 @@ const (
 	CheckpointEnabledEnvVar           = "CHECKPOINT_ENABLED"
 	UseNewWorkflowsEnvVar             = "USE_NEW_WORKFLOWS"
 	UseControllerForCli               = "USE_CONTROLLER_FOR_CLI"
-	VSphereInPlaceEnvVar              = "VSPHERE_IN_PLACE_UPGRADE"
 	APIServerExtraArgsEnabledEnvVar   = "API_SERVER_EXTRA_ARGS_ENABLED"
 	VSphereFailureDomainEnabledEnvVar = "VSPHERE_FAILURE_DOMAIN_ENABLED"
 )
@@ -50,14 +49,6 @@ func UseNewWorkflows() Feature {
 	}
 }
 
-// VSphereInPlaceUpgradeEnabled is the feature flag for performing in-place upgrades with the vSphere provider.
-func VSphereInPlaceUpgradeEnabled() Feature {
-	return Feature{
-		Name:     "Perform in-place upgrades with the vSphere provider",
-		IsActive: globalFeatures.isActiveForEnvVar(VSphereInPlaceEnvVar),
-	}
-}
-
 // APIServerExtraArgsEnabled is the feature flag for configuring api server extra args.
 func APIServerExtraArgsEnabled() Feature {
 	return Feature{
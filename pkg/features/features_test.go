@@ -70,14 +70,6 @@ func TestIsActiveWithFeatureGatesTrue(t *testing.T) {
 	g.Expect(IsActive(fakeFeatureWithGate())).To(BeTrue())
 }
 
-func TestVSphereInPlaceUpgradeEnabledFeatureFlag(t *testing.T) {
-	g := NewWithT(t)
-	setupContext(t)
-
-	g.Expect(os.Setenv(VSphereInPlaceEnvVar, "true")).To(Succeed())
-	g.Expect(IsActive(VSphereInPlaceUpgradeEnabled())).To(BeTrue())
-}
-
 func TestAPIServerExtraArgsEnabledFeatureFlag(t *testing.T) {
 	g := NewWithT(t)
 	setupContext(t)
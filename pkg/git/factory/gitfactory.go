@@ -19,8 +19,10 @@ import (
 	"github.com/aws/eks-anywhere/pkg/git"
 	"github.com/aws/eks-anywhere/pkg/git/gitclient"
 	"github.com/aws/eks-anywhere/pkg/git/gogithub"
+	"github.com/aws/eks-anywhere/pkg/git/providers/bitbucket"
 	"github.com/aws/eks-anywhere/pkg/git/providers/codecommit"
 	"github.com/aws/eks-anywhere/pkg/git/providers/github"
+	"github.com/aws/eks-anywhere/pkg/git/providers/gitlab"
 )
 
 type GitTools struct {
@@ -54,6 +56,27 @@ func Build(ctx context.Context, cluster *v1alpha1.Cluster, fluxConfig *v1alpha1.
 		gitAuth = &http.BasicAuth{Password: githubToken, Username: fluxConfig.Spec.Github.Owner}
 		repo = fluxConfig.Spec.Github.Repository
 		repoUrl = github.RepoUrl(fluxConfig.Spec.Github.Owner, repo)
+	case fluxConfig.Spec.Gitlab != nil:
+		gitlabToken, err := gitlab.GetGitlabAccessTokenFromEnv()
+		if err != nil {
+			return nil, err
+		}
+
+		auth := git.TokenAuth{Token: gitlabToken, Username: gitlab.GitAuthUser}
+		tools.Provider = gitlab.New(fluxConfig.Spec.Gitlab, auth)
+		gitAuth = &http.BasicAuth{Password: auth.Token, Username: auth.Username}
+		repo = fluxConfig.Spec.Gitlab.Repository
+		repoUrl = gitlab.RepoUrl(fluxConfig.Spec.Gitlab)
+	case fluxConfig.Spec.BitbucketServer != nil:
+		auth, err := bitbucket.GetBitbucketAccessTokenFromEnv()
+		if err != nil {
+			return nil, err
+		}
+
+		tools.Provider = bitbucket.New(fluxConfig.Spec.BitbucketServer, auth)
+		gitAuth = &http.BasicAuth{Password: auth.Token, Username: auth.Username}
+		repo = bitbucket.Slug(fluxConfig.Spec.BitbucketServer.Repository)
+		repoUrl = bitbucket.RepoUrl(fluxConfig.Spec.BitbucketServer)
 	case fluxConfig.Spec.Git != nil:
 		privateKeyFile := os.Getenv(config.EksaGitPrivateKeyTokenEnv)
 		privateKeyPassphrase := os.Getenv(config.EksaGitPassphraseTokenEnv)
@@ -85,7 +108,11 @@ func Build(ctx context.Context, cluster *v1alpha1.Cluster, fluxConfig *v1alpha1.
 			opt(&tools)
 		}
 	}
-	tools.Client = buildGitClient(ctx, gitAuth, repoUrl, tools.RepositoryDirectory)
+	signingOpts, err := commitSigningOptsFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	tools.Client = buildGitClient(ctx, gitAuth, repoUrl, tools.RepositoryDirectory, signingOpts...)
 
 	tools.Writer, err = newRepositoryWriter(writer, repo)
 	if err != nil {
@@ -95,14 +122,45 @@ func Build(ctx context.Context, cluster *v1alpha1.Cluster, fluxConfig *v1alpha1.
 	return &tools, nil
 }
 
-func buildGitClient(ctx context.Context, auth transport.AuthMethod, repoUrl string, repo string) *gitclient.GitClient {
+// BuildForGitProviderConfig builds the git tools for a generic git repository accessed over SSH,
+// for GitOps engines that don't use a FluxConfig.
+func BuildForGitProviderConfig(ctx context.Context, cluster *v1alpha1.Cluster, gitConfig *v1alpha1.GitProviderConfig, writer filewriter.FileWriter, opts ...GitToolsOpt) (*GitTools, error) {
+	fluxConfig := &v1alpha1.FluxConfig{
+		Spec: v1alpha1.FluxConfigSpec{
+			Git: gitConfig,
+		},
+	}
+	return Build(ctx, cluster, fluxConfig, writer, opts...)
+}
+
+func buildGitClient(ctx context.Context, auth transport.AuthMethod, repoUrl string, repo string, extraOpts ...gitclient.Opt) *gitclient.GitClient {
 	opts := []gitclient.Opt{
 		gitclient.WithRepositoryUrl(repoUrl),
 		gitclient.WithRepositoryDirectory(repo),
 		gitclient.WithAuth(auth),
 	}
 
-	return gitclient.New(opts...)
+	return gitclient.New(append(opts, extraOpts...)...)
+}
+
+// commitSigningOptsFromEnv configures the commits author email and signing key from the env, if set.
+func commitSigningOptsFromEnv() ([]gitclient.Opt, error) {
+	var opts []gitclient.Opt
+	if email := os.Getenv(config.EksaGitAuthorEmailEnv); email != "" {
+		opts = append(opts, gitclient.WithAuthorEmail(email))
+	}
+
+	signingKeyFile := os.Getenv(config.EksaGitSigningKeyEnv)
+	if signingKeyFile == "" {
+		return opts, nil
+	}
+
+	signer, err := gitclient.NewSignerFromFile(signingKeyFile, os.Getenv(config.EksaGitSigningKeyPassphraseEnv))
+	if err != nil {
+		return nil, fmt.Errorf("building git commit signer from %s: %v", config.EksaGitSigningKeyEnv, err)
+	}
+
+	return append(opts, gitclient.WithSigner(signer)), nil
 }
 
 func buildGithubProvider(ctx context.Context, githubToken string, config *v1alpha1.GithubProviderConfig) (git.ProviderClient, error) {
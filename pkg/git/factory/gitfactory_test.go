@@ -2,14 +2,25 @@ package gitfactory_test
 
 import (
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"os"
+	"path/filepath"
 	"testing"
 
+	. "github.com/onsi/gomega"
+	"golang.org/x/crypto/ssh"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/aws/eks-anywhere/internal/test"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/config"
 	gitFactory "github.com/aws/eks-anywhere/pkg/git/factory"
+	"github.com/aws/eks-anywhere/pkg/git/gitclient"
+	"github.com/aws/eks-anywhere/pkg/git/providers/bitbucket"
 	"github.com/aws/eks-anywhere/pkg/git/providers/github"
+	"github.com/aws/eks-anywhere/pkg/git/providers/gitlab"
 )
 
 const (
@@ -69,3 +80,157 @@ func setupContext(t *testing.T) {
 	t.Setenv(github.EksaGithubTokenEnv, validPATValue)
 	t.Setenv(github.GithubTokenEnv, validPATValue)
 }
+
+func TestGitFactoryGitlab(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(gitlab.EksaGitlabTokenEnv, "glpat-token")
+	t.Setenv(gitlab.GitlabTokenEnv, "")
+
+	cluster := &v1alpha1.Cluster{
+		ObjectMeta: v1.ObjectMeta{
+			Name: "testCluster",
+		},
+	}
+	fluxConfig := &v1alpha1.FluxConfig{
+		Spec: v1alpha1.FluxConfigSpec{
+			Gitlab: &v1alpha1.GitlabProviderConfig{
+				Owner:      "my-group",
+				Repository: "testRepo",
+				Hostname:   "gitlab.example.com",
+			},
+		},
+	}
+	_, w := test.NewWriter(t)
+
+	tools, err := gitFactory.Build(context.Background(), cluster, fluxConfig, w)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tools.Provider).To(BeAssignableToTypeOf(&gitlab.Provider{}))
+	g.Expect(tools.RepositoryDirectory).To(Equal("testCluster/git/testRepo"))
+}
+
+func TestGitFactoryGitlabMissingToken(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(gitlab.EksaGitlabTokenEnv, "")
+
+	fluxConfig := &v1alpha1.FluxConfig{
+		Spec: v1alpha1.FluxConfigSpec{
+			Gitlab: &v1alpha1.GitlabProviderConfig{
+				Owner:      "my-group",
+				Repository: "testRepo",
+			},
+		},
+	}
+	_, w := test.NewWriter(t)
+
+	_, err := gitFactory.Build(context.Background(), &v1alpha1.Cluster{}, fluxConfig, w)
+	g.Expect(err).To(MatchError(ContainSubstring(gitlab.EksaGitlabTokenEnv)))
+}
+
+func TestGitFactoryBitbucketServer(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(bitbucket.EksaBitbucketTokenEnv, "token")
+	t.Setenv(bitbucket.EksaBitbucketUserEnv, "jane")
+	t.Setenv(bitbucket.BitbucketTokenEnv, "")
+
+	cluster := &v1alpha1.Cluster{
+		ObjectMeta: v1.ObjectMeta{
+			Name: "testCluster",
+		},
+	}
+	fluxConfig := &v1alpha1.FluxConfig{
+		Spec: v1alpha1.FluxConfigSpec{
+			BitbucketServer: &v1alpha1.BitbucketServerProviderConfig{
+				Owner:      "EKSA",
+				Repository: "TestRepo",
+				Hostname:   "bitbucket.example.com",
+			},
+		},
+	}
+	_, w := test.NewWriter(t)
+
+	tools, err := gitFactory.Build(context.Background(), cluster, fluxConfig, w)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tools.Provider).To(BeAssignableToTypeOf(&bitbucket.Provider{}))
+	g.Expect(tools.RepositoryDirectory).To(Equal("testCluster/git/testrepo"))
+}
+
+func TestGitFactoryBuildForGitProviderConfig(t *testing.T) {
+	g := NewWithT(t)
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	g.Expect(err).NotTo(HaveOccurred())
+	block, err := ssh.MarshalPrivateKey(key, "")
+	g.Expect(err).NotTo(HaveOccurred())
+	keyFile := filepath.Join(t.TempDir(), "id_ed25519")
+	g.Expect(os.WriteFile(keyFile, pem.EncodeToMemory(block), 0o600)).To(Succeed())
+	t.Setenv(config.EksaGitPrivateKeyTokenEnv, keyFile)
+	t.Setenv(config.EksaGitKnownHostsFileEnv, "")
+	t.Setenv(config.SshKnownHostsEnv, "")
+
+	cluster := &v1alpha1.Cluster{
+		ObjectMeta: v1.ObjectMeta{
+			Name: "testCluster",
+		},
+	}
+	gitConfig := &v1alpha1.GitProviderConfig{
+		RepositoryUrl: "ssh://git@github.com/username/repo.git",
+	}
+	_, w := test.NewWriter(t)
+
+	tools, err := gitFactory.BuildForGitProviderConfig(context.Background(), cluster, gitConfig, w)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tools.Provider).To(BeNil())
+	g.Expect(tools.Client).NotTo(BeNil())
+	g.Expect(tools.RepositoryDirectory).To(Equal("testCluster/git/repo"))
+}
+
+func TestGitFactoryCommitSigning(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(gitlab.EksaGitlabTokenEnv, "glpat-token")
+	t.Setenv(gitlab.GitlabTokenEnv, "")
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	g.Expect(err).NotTo(HaveOccurred())
+	block, err := ssh.MarshalPrivateKey(key, "")
+	g.Expect(err).NotTo(HaveOccurred())
+	keyFile := filepath.Join(t.TempDir(), "id_ed25519")
+	g.Expect(os.WriteFile(keyFile, pem.EncodeToMemory(block), 0o600)).To(Succeed())
+	t.Setenv(config.EksaGitSigningKeyEnv, keyFile)
+	t.Setenv(config.EksaGitSigningKeyPassphraseEnv, "")
+	t.Setenv(config.EksaGitAuthorEmailEnv, "eksa@example.com")
+
+	fluxConfig := &v1alpha1.FluxConfig{
+		Spec: v1alpha1.FluxConfigSpec{
+			Gitlab: &v1alpha1.GitlabProviderConfig{
+				Owner:      "my-group",
+				Repository: "testRepo",
+			},
+		},
+	}
+	_, w := test.NewWriter(t)
+
+	tools, err := gitFactory.Build(context.Background(), &v1alpha1.Cluster{}, fluxConfig, w)
+	g.Expect(err).NotTo(HaveOccurred())
+	client, ok := tools.Client.(*gitclient.GitClient)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(client.Signer).NotTo(BeNil())
+	g.Expect(client.AuthorEmail).To(Equal("eksa@example.com"))
+}
+
+func TestGitFactoryCommitSigningInvalidKey(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(gitlab.EksaGitlabTokenEnv, "glpat-token")
+	t.Setenv(gitlab.GitlabTokenEnv, "")
+	t.Setenv(config.EksaGitSigningKeyEnv, filepath.Join(t.TempDir(), "missing"))
+
+	fluxConfig := &v1alpha1.FluxConfig{
+		Spec: v1alpha1.FluxConfigSpec{
+			Gitlab: &v1alpha1.GitlabProviderConfig{
+				Owner:      "my-group",
+				Repository: "testRepo",
+			},
+		},
+	}
+	_, w := test.NewWriter(t)
+
+	_, err := gitFactory.Build(context.Background(), &v1alpha1.Cluster{}, fluxConfig, w)
+	g.Expect(err).To(MatchError(ContainSubstring(config.EksaGitSigningKeyEnv)))
+}
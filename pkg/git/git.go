@@ -24,6 +24,8 @@ type ProviderClient interface {
 	AddDeployKeyToRepo(ctx context.Context, opts AddDeployKeyOpts) error
 	Validate(ctx context.Context) error
 	PathExists(ctx context.Context, owner, repo, branch, path string) (bool, error)
+	CreatePullRequest(ctx context.Context, opts CreatePullRequestOpts) (*PullRequest, error)
+	GetPullRequest(ctx context.Context, opts GetPullRequestOpts) (*PullRequest, error)
 }
 
 type CreateRepoOpts struct {
@@ -53,6 +55,39 @@ type AddDeployKeyOpts struct {
 	ReadOnly   bool
 }
 
+type CreatePullRequestOpts struct {
+	Owner      string
+	Repository string
+	Title      string
+	Body       string
+	// Head is the branch with the changes to merge.
+	Head string
+	// Base is the branch the changes are merged into.
+	Base string
+}
+
+type GetPullRequestOpts struct {
+	Owner      string
+	Repository string
+	Number     int
+}
+
+// PullRequestState is the state of a pull request in the git provider.
+type PullRequestState string
+
+const (
+	PullRequestOpen   PullRequestState = "open"
+	PullRequestMerged PullRequestState = "merged"
+	PullRequestClosed PullRequestState = "closed"
+)
+
+// PullRequest is a pull request (merge request in GitLab) opened in the git provider.
+type PullRequest struct {
+	Number int
+	Url    string
+	State  PullRequestState
+}
+
 type Repository struct {
 	Name         string
 	Owner        string
@@ -25,6 +25,7 @@ const (
 	maxRetries     = 5
 	backOffPeriod  = 5 * time.Second
 	emptyRepoError = "remote repository is empty"
+	authorName     = "EKS-A"
 )
 
 type GitClient struct {
@@ -33,6 +34,11 @@ type GitClient struct {
 	RepoUrl       string
 	RepoDirectory string
 	Retrier       *retrier.Retrier
+	// Signer signs the commits when set.
+	Signer gogit.Signer
+	// AuthorEmail is the email of the commits author. Git providers only show signed commits
+	// as verified when it matches an email of the account owning the signing key.
+	AuthorEmail string
 }
 
 type Opt func(*GitClient)
@@ -66,6 +72,20 @@ func WithRepositoryDirectory(repoDir string) Opt {
 	}
 }
 
+// WithSigner configures the client to sign the commits it creates.
+func WithSigner(signer gogit.Signer) Opt {
+	return func(c *GitClient) {
+		c.Signer = signer
+	}
+}
+
+// WithAuthorEmail sets the email of the author of the commits.
+func WithAuthorEmail(email string) Opt {
+	return func(c *GitClient) {
+		c.AuthorEmail = email
+	}
+}
+
 func (g *GitClient) Clone(ctx context.Context) error {
 	_, err := g.Client.Clone(ctx, g.RepoDirectory, g.RepoUrl, g.Auth)
 	if err != nil && strings.Contains(err.Error(), emptyRepoError) {
@@ -128,10 +148,11 @@ func (g *GitClient) Commit(message string) error {
 
 	logger.V(3).Info("Generating Commit object...")
 	commitSignature := &object.Signature{
-		Name: "EKS-A",
-		When: time.Now(),
+		Name:  authorName,
+		Email: g.AuthorEmail,
+		When:  time.Now(),
 	}
-	commit, err := g.Client.Commit(message, commitSignature, w)
+	commit, err := g.Client.Commit(message, commitSignature, g.Signer, w)
 	if err != nil {
 		return err
 	}
@@ -316,7 +337,7 @@ type GoGit interface {
 	AddGlob(f string, w *gogit.Worktree) error
 	Checkout(w *gogit.Worktree, opts *gogit.CheckoutOptions) error
 	Clone(ctx context.Context, dir string, repoUrl string, auth transport.AuthMethod) (*gogit.Repository, error)
-	Commit(m string, sig *object.Signature, w *gogit.Worktree) (plumbing.Hash, error)
+	Commit(m string, sig *object.Signature, signer gogit.Signer, w *gogit.Worktree) (plumbing.Hash, error)
 	CommitObject(r *gogit.Repository, h plumbing.Hash) (*object.Commit, error)
 	Create(r *gogit.Repository, url string) (*gogit.Remote, error)
 	CreateBranch(r *gogit.Repository, config *config.Branch) error
@@ -358,10 +379,11 @@ func (gg *goGit) AddGlob(f string, w *gogit.Worktree) error {
 	return w.AddGlob(f)
 }
 
-func (gg *goGit) Commit(m string, sig *object.Signature, w *gogit.Worktree) (plumbing.Hash, error) {
+func (gg *goGit) Commit(m string, sig *object.Signature, signer gogit.Signer, w *gogit.Worktree) (plumbing.Hash, error) {
 	return w.Commit(m, &gogit.CommitOptions{
 		Author:            sig,
 		AllowEmptyCommits: true,
+		Signer:            signer,
 	})
 }
 
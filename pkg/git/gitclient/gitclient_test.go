@@ -3,6 +3,7 @@ package gitclient_test
 import (
 	"context"
 	"fmt"
+	"io"
 	"reflect"
 	"testing"
 
@@ -118,7 +119,7 @@ func TestGoGitCommit(t *testing.T) {
 
 	client.EXPECT().OpenDir(repoDir).Return(&goGit.Repository{}, nil)
 	client.EXPECT().OpenWorktree(gomock.Any()).Do(func(arg0 *goGit.Repository) {}).Return(&goGit.Worktree{}, nil)
-	client.EXPECT().Commit(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(arg0 string, arg1 *object.Signature, arg2 *goGit.Worktree) {}).Return(plumbing.Hash{}, nil)
+	client.EXPECT().Commit(gomock.Any(), gomock.Any(), nil, gomock.Any()).Do(func(arg0 string, arg1 *object.Signature, arg2 goGit.Signer, arg3 *goGit.Worktree) {}).Return(plumbing.Hash{}, nil)
 	client.EXPECT().CommitObject(gomock.Any(), gomock.Any()).Do(func(arg0 *goGit.Repository, arg1 plumbing.Hash) {}).Return(&object.Commit{}, nil)
 
 	g := &gitclient.GitClient{
@@ -133,6 +134,37 @@ func TestGoGitCommit(t *testing.T) {
 	}
 }
 
+type fakeSigner struct{}
+
+func (fakeSigner) Sign(message io.Reader) ([]byte, error) {
+	return []byte("signature"), nil
+}
+
+func TestGoGitCommitSigned(t *testing.T) {
+	_, client := newGoGitMock(t)
+	signer := fakeSigner{}
+
+	client.EXPECT().OpenDir(repoDir).Return(&goGit.Repository{}, nil)
+	client.EXPECT().OpenWorktree(gomock.Any()).Return(&goGit.Worktree{}, nil)
+	client.EXPECT().Commit("message", gomock.Any(), signer, gomock.Any()).Do(func(arg0 string, arg1 *object.Signature, arg2 goGit.Signer, arg3 *goGit.Worktree) {
+		if arg1.Email != "eksa@example.com" {
+			t.Errorf("Commit() author email = %s, want eksa@example.com", arg1.Email)
+		}
+	}).Return(plumbing.Hash{}, nil)
+	client.EXPECT().CommitObject(gomock.Any(), gomock.Any()).Return(&object.Commit{}, nil)
+
+	g := gitclient.New(
+		gitclient.WithRepositoryDirectory(repoDir),
+		gitclient.WithSigner(signer),
+		gitclient.WithAuthorEmail("eksa@example.com"),
+	)
+	g.Client = client
+
+	if err := g.Commit("message"); err != nil {
+		t.Errorf("Commit() error = %v", err)
+	}
+}
+
 func TestGoGitPush(t *testing.T) {
 	ctx, client := newGoGitMock(t)
 
@@ -83,18 +83,18 @@ func (mr *MockGoGitMockRecorder) Clone(arg0, arg1, arg2, arg3 interface{}) *gomo
 }
 
 // Commit mocks base method.
-func (m *MockGoGit) Commit(arg0 string, arg1 *object.Signature, arg2 *git.Worktree) (plumbing.Hash, error) {
+func (m *MockGoGit) Commit(arg0 string, arg1 *object.Signature, arg2 git.Signer, arg3 *git.Worktree) (plumbing.Hash, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Commit", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "Commit", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(plumbing.Hash)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Commit indicates an expected call of Commit.
-func (mr *MockGoGitMockRecorder) Commit(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockGoGitMockRecorder) Commit(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Commit", reflect.TypeOf((*MockGoGit)(nil).Commit), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Commit", reflect.TypeOf((*MockGoGit)(nil).Commit), arg0, arg1, arg2, arg3)
 }
 
 // CommitObject mocks base method.
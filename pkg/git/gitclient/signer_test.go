@@ -0,0 +1,149 @@
+package gitclient_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/pem"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	goGit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	. "github.com/onsi/gomega"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/aws/eks-anywhere/pkg/git/gitclient"
+)
+
+func commitWithSigner(t *testing.T, signer goGit.Signer) *object.Commit {
+	t.Helper()
+	g := NewWithT(t)
+	r, err := goGit.PlainInit(t.TempDir(), false)
+	g.Expect(err).NotTo(HaveOccurred())
+	w, err := r.Worktree()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	h, err := w.Commit("signed", &goGit.CommitOptions{
+		Author:            &object.Signature{Name: "EKS-A", When: time.Now()},
+		AllowEmptyCommits: true,
+		Signer:            signer,
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	c, err := r.CommitObject(h)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	return c
+}
+
+func TestNewSignerFromFileGPG(t *testing.T) {
+	g := NewWithT(t)
+	entity, err := openpgp.NewEntity("EKS-A", "", "eksa@example.com", nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(entity.PrivateKey.Encrypt([]byte("passphrase"))).To(Succeed())
+
+	var private, public bytes.Buffer
+	w, err := armor.Encode(&private, openpgp.PrivateKeyType, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(entity.SerializePrivateWithoutSigning(w, nil)).To(Succeed())
+	g.Expect(w.Close()).To(Succeed())
+	w, err = armor.Encode(&public, openpgp.PublicKeyType, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(entity.Serialize(w)).To(Succeed())
+	g.Expect(w.Close()).To(Succeed())
+
+	keyFile := filepath.Join(t.TempDir(), "key.asc")
+	g.Expect(os.WriteFile(keyFile, private.Bytes(), 0o600)).To(Succeed())
+
+	_, err = gitclient.NewSignerFromFile(keyFile, "")
+	g.Expect(err).To(MatchError(ContainSubstring("no passphrase")))
+
+	signer, err := gitclient.NewSignerFromFile(keyFile, "passphrase")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	c := commitWithSigner(t, signer)
+	_, err = c.Verify(public.String())
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestNewSignerFromFileSSH(t *testing.T) {
+	g := NewWithT(t)
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	g.Expect(err).NotTo(HaveOccurred())
+	block, err := ssh.MarshalPrivateKey(key, "")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	keyFile := filepath.Join(t.TempDir(), "id_ed25519")
+	g.Expect(os.WriteFile(keyFile, pem.EncodeToMemory(block), 0o600)).To(Succeed())
+
+	signer, err := gitclient.NewSignerFromFile(keyFile, "")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	c := commitWithSigner(t, signer)
+	armored, _ := pem.Decode([]byte(c.PGPSignature))
+	g.Expect(armored).NotTo(BeNil())
+	g.Expect(armored.Type).To(Equal("SSH SIGNATURE"))
+
+	sig := struct {
+		Magic         [6]byte
+		Version       uint32
+		PublicKey     []byte
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Signature     []byte
+	}{}
+	g.Expect(ssh.Unmarshal(armored.Bytes, &sig)).To(Succeed())
+	g.Expect(string(sig.Magic[:])).To(Equal("SSHSIG"))
+	g.Expect(sig.Namespace).To(Equal("git"))
+
+	publicKey, err := ssh.ParsePublicKey(sig.PublicKey)
+	g.Expect(err).NotTo(HaveOccurred())
+	signature := &ssh.Signature{}
+	g.Expect(ssh.Unmarshal(sig.Signature, signature)).To(Succeed())
+
+	obj := &plumbing.MemoryObject{}
+	g.Expect(c.EncodeWithoutSignature(obj)).To(Succeed())
+	r, err := obj.Reader()
+	g.Expect(err).NotTo(HaveOccurred())
+	encoded, err := io.ReadAll(r)
+	g.Expect(err).NotTo(HaveOccurred())
+	hash := sha512.Sum512(encoded)
+
+	signedData := ssh.Marshal(struct {
+		Magic         [6]byte
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Hash          []byte
+	}{
+		Magic:         sig.Magic,
+		Namespace:     "git",
+		HashAlgorithm: "sha512",
+		Hash:          hash[:],
+	})
+	g.Expect(publicKey.Verify(signedData, signature)).To(Succeed())
+}
+
+func TestNewSignerFromFileInvalidKey(t *testing.T) {
+	g := NewWithT(t)
+	keyFile := filepath.Join(t.TempDir(), "key")
+	g.Expect(os.WriteFile(keyFile, []byte("not a key"), 0o600)).To(Succeed())
+
+	_, err := gitclient.NewSignerFromFile(keyFile, "")
+	g.Expect(err).To(MatchError(ContainSubstring("parsing SSH signing key")))
+}
+
+func TestNewSignerFromFileMissing(t *testing.T) {
+	g := NewWithT(t)
+
+	_, err := gitclient.NewSignerFromFile(filepath.Join(t.TempDir(), "key"), "")
+	g.Expect(err).To(MatchError(ContainSubstring("reading signing key file")))
+}
@@ -46,6 +46,8 @@ type Client interface {
 		fileContent *goGithub.RepositoryContent, directoryContent []*goGithub.RepositoryContent, resp *goGithub.Response, err error,
 	)
 	DeleteRepo(ctx context.Context, owner, repo string) (*goGithub.Response, error)
+	CreatePullRequest(ctx context.Context, owner, repo string, pull *goGithub.NewPullRequest) (*goGithub.PullRequest, *goGithub.Response, error)
+	GetPullRequest(ctx context.Context, owner, repo string, number int) (*goGithub.PullRequest, *goGithub.Response, error)
 }
 
 type githubClient struct {
@@ -82,6 +84,14 @@ func (ggc *githubClient) DeleteRepo(ctx context.Context, owner, repo string) (*g
 	return ggc.client.Repositories.Delete(ctx, owner, repo)
 }
 
+func (ggc *githubClient) CreatePullRequest(ctx context.Context, owner, repo string, pull *goGithub.NewPullRequest) (*goGithub.PullRequest, *goGithub.Response, error) {
+	return ggc.client.PullRequests.Create(ctx, owner, repo, pull)
+}
+
+func (ggc *githubClient) GetPullRequest(ctx context.Context, owner, repo string, number int) (*goGithub.PullRequest, *goGithub.Response, error) {
+	return ggc.client.PullRequests.Get(ctx, owner, repo, number)
+}
+
 func (ggc *githubClient) AddDeployKeyToRepo(ctx context.Context, owner, repo string, key *goGithub.Key) error {
 	_, resp, err := ggc.client.Repositories.CreateKey(ctx, owner, repo, key)
 	if err != nil {
@@ -237,6 +247,46 @@ func (g *GoGithub) DeleteRepo(ctx context.Context, opts git.DeleteRepoOpts) erro
 	return nil
 }
 
+// CreatePullRequest opens a pull request to merge the head branch into the base branch of a Github repository.
+func (g *GoGithub) CreatePullRequest(ctx context.Context, opts git.CreatePullRequestOpts) (*git.PullRequest, error) {
+	logger.V(3).Info("Creating Github pull request", "repository", opts.Repository, "owner", opts.Owner, "head", opts.Head, "base", opts.Base)
+	pr, _, err := g.Client.CreatePullRequest(ctx, opts.Owner, opts.Repository, &goGithub.NewPullRequest{
+		Title: &opts.Title,
+		Body:  &opts.Body,
+		Head:  &opts.Head,
+		Base:  &opts.Base,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating pull request in repository %s: %v", opts.Repository, err)
+	}
+	return toPullRequest(pr), nil
+}
+
+// GetPullRequest describes a pull request of a Github repository.
+func (g *GoGithub) GetPullRequest(ctx context.Context, opts git.GetPullRequestOpts) (*git.PullRequest, error) {
+	pr, _, err := g.Client.GetPullRequest(ctx, opts.Owner, opts.Repository, opts.Number)
+	if err != nil {
+		return nil, fmt.Errorf("getting pull request %d in repository %s: %v", opts.Number, opts.Repository, err)
+	}
+	return toPullRequest(pr), nil
+}
+
+func toPullRequest(pr *goGithub.PullRequest) *git.PullRequest {
+	state := git.PullRequestOpen
+	switch {
+	case pr.GetMerged():
+		state = git.PullRequestMerged
+	case pr.GetState() == "closed":
+		state = git.PullRequestClosed
+	}
+
+	return &git.PullRequest{
+		Number: pr.GetNumber(),
+		Url:    pr.GetHTMLURL(),
+		State:  state,
+	}
+}
+
 func newClient(ctx context.Context, opts Options) Client {
 	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: opts.Auth.Token})
 	tc := oauth2.NewClient(ctx, ts)
@@ -500,3 +500,73 @@ func notFoundError() error {
 		},
 	}
 }
+
+func TestCreatePullRequest(t *testing.T) {
+	tt := newTest(t)
+	opts := git.CreatePullRequestOpts{
+		Owner:      "owner",
+		Repository: "repo",
+		Title:      "Upgrade cluster",
+		Body:       "body",
+		Head:       "eksa-upgrade",
+		Base:       "main",
+	}
+	tt.client.EXPECT().CreatePullRequest(tt.ctx, "owner", "repo", &github.NewPullRequest{
+		Title: github.String("Upgrade cluster"),
+		Body:  github.String("body"),
+		Head:  github.String("eksa-upgrade"),
+		Base:  github.String("main"),
+	}).Return(&github.PullRequest{
+		Number:  github.Int(4),
+		HTMLURL: github.String("https://github.com/owner/repo/pull/4"),
+		State:   github.String("open"),
+	}, nil, nil)
+
+	tt.Expect(tt.g.CreatePullRequest(tt.ctx, opts)).To(Equal(&git.PullRequest{
+		Number: 4,
+		Url:    "https://github.com/owner/repo/pull/4",
+		State:  git.PullRequestOpen,
+	}))
+}
+
+func TestCreatePullRequestError(t *testing.T) {
+	tt := newTest(t)
+	tt.client.EXPECT().CreatePullRequest(tt.ctx, "owner", "repo", gomock.Any()).Return(nil, nil, errors.New("validation failed"))
+
+	_, err := tt.g.CreatePullRequest(tt.ctx, git.CreatePullRequestOpts{Owner: "owner", Repository: "repo"})
+	tt.Expect(err).To(MatchError(ContainSubstring("validation failed")))
+}
+
+func TestGetPullRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		pr   *github.PullRequest
+		want git.PullRequestState
+	}{
+		{
+			name: "open",
+			pr:   &github.PullRequest{State: github.String("open")},
+			want: git.PullRequestOpen,
+		},
+		{
+			name: "merged",
+			pr:   &github.PullRequest{State: github.String("closed"), Merged: github.Bool(true)},
+			want: git.PullRequestMerged,
+		},
+		{
+			name: "closed without merging",
+			pr:   &github.PullRequest{State: github.String("closed"), Merged: github.Bool(false)},
+			want: git.PullRequestClosed,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tt := newTest(t)
+			tt.client.EXPECT().GetPullRequest(tt.ctx, "owner", "repo", 4).Return(test.pr, nil, nil)
+
+			pr, err := tt.g.GetPullRequest(tt.ctx, git.GetPullRequestOpts{Owner: "owner", Repository: "repo", Number: 4})
+			tt.Expect(err).NotTo(HaveOccurred())
+			tt.Expect(pr.State).To(Equal(test.want))
+		})
+	}
+}
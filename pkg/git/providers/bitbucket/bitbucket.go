@@ -0,0 +1,371 @@
+package bitbucket
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const (
+	GitProviderName         = "bitbucket-server"
+	EksaBitbucketTokenEnv   = "EKSA_BITBUCKET_TOKEN"
+	EksaBitbucketUserEnv    = "EKSA_BITBUCKET_USERNAME"
+	BitbucketTokenEnv       = "BITBUCKET_TOKEN"
+	bitbucketUrlTemplate    = "https://%s/scm/%s/%s.git"
+	apiPath                 = "/rest/api/1.0"
+	keysPath                = "/rest/keys/1.0"
+	repoReadPermission      = "REPO_READ"
+	repoWritePermission     = "REPO_WRITE"
+	personalProjectPrefix   = "~"
+	defaultBranchRefsPrefix = "refs/heads/"
+)
+
+// Provider implements git.ProviderClient for Bitbucket Server (and Bitbucket Data Center)
+// using the Bitbucket Server REST API 1.0.
+type Provider struct {
+	config     *v1alpha1.BitbucketServerProviderConfig
+	auth       git.TokenAuth
+	baseURL    string
+	httpClient *http.Client
+}
+
+// Opt allows to customize a Bitbucket Server Provider.
+type Opt func(*Provider)
+
+// WithBaseURL overrides the base url of the Bitbucket Server instance, by default built from the configured hostname.
+func WithBaseURL(baseURL string) Opt {
+	return func(p *Provider) {
+		p.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// WithHTTPClient sets the http client used to make requests to the Bitbucket Server API.
+func WithHTTPClient(client *http.Client) Opt {
+	return func(p *Provider) {
+		p.httpClient = client
+	}
+}
+
+// New builds a new Bitbucket Server Provider.
+func New(config *v1alpha1.BitbucketServerProviderConfig, auth git.TokenAuth, opts ...Opt) *Provider {
+	p := &Provider{
+		config:     config,
+		auth:       auth,
+		baseURL:    "https://" + config.Hostname,
+		httpClient: http.DefaultClient,
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+type project struct {
+	Key string `json:"key"`
+}
+
+type link struct {
+	Href string `json:"href"`
+	Name string `json:"name"`
+}
+
+type repository struct {
+	Name    string  `json:"name"`
+	Slug    string  `json:"slug"`
+	Project project `json:"project"`
+	Links   struct {
+		Clone []link `json:"clone"`
+	} `json:"links"`
+}
+
+func (r repository) toRepository() *git.Repository {
+	repo := &git.Repository{
+		Name:  r.Slug,
+		Owner: r.Project.Key,
+	}
+	for _, l := range r.Links.Clone {
+		if l.Name == "http" || l.Name == "https" {
+			repo.CloneUrl = l.Href
+		}
+	}
+
+	return repo
+}
+
+// GetRepo describes the configured remote repository.
+// If the repo does not exist, a nil repo is returned.
+func (b *Provider) GetRepo(ctx context.Context) (*git.Repository, error) {
+	o := b.config.Owner
+	r := b.config.Repository
+	logger.V(3).Info("Describing Bitbucket Server repository", "name", r, "owner", o)
+	repo := &repository{}
+	found, err := b.do(ctx, http.MethodGet, apiPath+repoPath(o, r, b.config.Personal), nil, repo)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected error when describing repository %s: %w", r, err)
+	}
+	if !found {
+		return nil, nil
+	}
+
+	return repo.toRepository(), nil
+}
+
+// CreateRepo creates a repository in the owner project, or in the owner personal project if Personal is set.
+// Bitbucket Server repositories are always created empty.
+func (b *Provider) CreateRepo(ctx context.Context, opts git.CreateRepoOpts) (*git.Repository, error) {
+	body := map[string]interface{}{
+		"name":        opts.Name,
+		"scmId":       "git",
+		"description": opts.Description,
+		"public":      !opts.Privacy,
+	}
+
+	repo := &repository{}
+	found, err := b.do(ctx, http.MethodPost, apiPath+projectPath(opts.Owner, opts.Personal)+"/repos", body, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Bitbucket Server repository %s: %v", opts.Name, err)
+	}
+	if !found {
+		return nil, fmt.Errorf("failed to create Bitbucket Server repository %s: project %s not found", opts.Name, opts.Owner)
+	}
+
+	return repo.toRepository(), nil
+}
+
+// DeleteRepo deletes a Bitbucket Server repository.
+func (b *Provider) DeleteRepo(ctx context.Context, opts git.DeleteRepoOpts) error {
+	found, err := b.do(ctx, http.MethodDelete, apiPath+repoPath(opts.Owner, opts.Repository, b.config.Personal), nil, nil)
+	if err != nil {
+		return fmt.Errorf("deleting Bitbucket Server repository %s: %v", opts.Repository, err)
+	}
+	if !found {
+		return &git.RepositoryDoesNotExistError{Err: fmt.Errorf("repository %s/%s not found", opts.Owner, opts.Repository)}
+	}
+
+	return nil
+}
+
+// AddDeployKeyToRepo registers an access key in a Bitbucket Server repository.
+func (b *Provider) AddDeployKeyToRepo(ctx context.Context, opts git.AddDeployKeyOpts) error {
+	permission := repoWritePermission
+	if opts.ReadOnly {
+		permission = repoReadPermission
+	}
+	body := map[string]interface{}{
+		"key": map[string]interface{}{
+			"text":  opts.Key,
+			"label": opts.Title,
+		},
+		"permission": permission,
+	}
+	if _, err := b.do(ctx, http.MethodPost, keysPath+repoPath(opts.Owner, opts.Repository, b.config.Personal)+"/ssh", body, nil); err != nil {
+		return fmt.Errorf("adding access key to Bitbucket Server repository %s: %v", opts.Repository, err)
+	}
+
+	return nil
+}
+
+// Validate checks the access token is valid and has access to the configured owner.
+func (b *Provider) Validate(ctx context.Context) error {
+	path := apiPath + projectPath(b.config.Owner, b.config.Personal)
+	if b.config.Personal {
+		path = apiPath + "/users/" + url.PathEscape(b.config.Owner)
+	}
+
+	found, err := b.do(ctx, http.MethodGet, path, nil, nil)
+	if err != nil {
+		return fmt.Errorf("validating Bitbucket Server access token: %v", err)
+	}
+	if !found {
+		return fmt.Errorf("the authenticated Bitbucket Server user doesn't have proper access to owner %s", b.config.Owner)
+	}
+
+	if b.config.Personal && b.auth.Username != "" && !strings.EqualFold(b.config.Owner, b.auth.Username) {
+		return fmt.Errorf("the authenticated Bitbucket Server user and owner %s specified in the EKS-A gitops spec don't match; confirm access token owner is %s", b.config.Owner, b.config.Owner)
+	}
+
+	return nil
+}
+
+// PathExists checks if a path exists in the given branch of a Bitbucket Server repository.
+func (b *Provider) PathExists(ctx context.Context, owner, repo, branch, path string) (bool, error) {
+	query := url.Values{}
+	query.Set("at", defaultBranchRefsPrefix+branch)
+	p := apiPath + repoPath(owner, repo, b.config.Personal) + "/browse/" + strings.TrimPrefix(path, "/") + "?" + query.Encode()
+	found, err := b.do(ctx, http.MethodGet, p, nil, nil)
+	if err != nil {
+		return false, fmt.Errorf("checking if path %s exists in Bitbucket Server repository %s: %v", path, repo, err)
+	}
+
+	// Bitbucket Server returns a 404 both for unknown paths and empty repositories
+	return found, nil
+}
+
+type ref struct {
+	ID string `json:"id"`
+}
+
+type pullRequest struct {
+	ID    int    `json:"id"`
+	State string `json:"state"`
+	Links struct {
+		Self []link `json:"self"`
+	} `json:"links"`
+}
+
+func (p pullRequest) toPullRequest() *git.PullRequest {
+	state := git.PullRequestOpen
+	switch p.State {
+	case "MERGED":
+		state = git.PullRequestMerged
+	case "DECLINED":
+		state = git.PullRequestClosed
+	}
+
+	pr := &git.PullRequest{
+		Number: p.ID,
+		State:  state,
+	}
+	if len(p.Links.Self) > 0 {
+		pr.Url = p.Links.Self[0].Href
+	}
+
+	return pr
+}
+
+// CreatePullRequest opens a pull request from the head branch to the base branch of a Bitbucket Server repository.
+func (b *Provider) CreatePullRequest(ctx context.Context, opts git.CreatePullRequestOpts) (*git.PullRequest, error) {
+	body := map[string]interface{}{
+		"title":       opts.Title,
+		"description": opts.Body,
+		"fromRef":     ref{ID: defaultBranchRefsPrefix + opts.Head},
+		"toRef":       ref{ID: defaultBranchRefsPrefix + opts.Base},
+	}
+	pr := &pullRequest{}
+	found, err := b.do(ctx, http.MethodPost, apiPath+repoPath(opts.Owner, opts.Repository, b.config.Personal)+"/pull-requests", body, pr)
+	if err != nil {
+		return nil, fmt.Errorf("creating pull request in Bitbucket Server repository %s: %v", opts.Repository, err)
+	}
+	if !found {
+		return nil, fmt.Errorf("creating pull request in Bitbucket Server repository %s: repository not found", opts.Repository)
+	}
+
+	return pr.toPullRequest(), nil
+}
+
+// GetPullRequest describes a pull request of a Bitbucket Server repository.
+func (b *Provider) GetPullRequest(ctx context.Context, opts git.GetPullRequestOpts) (*git.PullRequest, error) {
+	pr := &pullRequest{}
+	found, err := b.do(ctx, http.MethodGet, fmt.Sprintf("%s%s/pull-requests/%d", apiPath, repoPath(opts.Owner, opts.Repository, b.config.Personal), opts.Number), nil, pr)
+	if err != nil {
+		return nil, fmt.Errorf("getting pull request %d in Bitbucket Server repository %s: %v", opts.Number, opts.Repository, err)
+	}
+	if !found {
+		return nil, fmt.Errorf("pull request %d not found in Bitbucket Server repository %s", opts.Number, opts.Repository)
+	}
+
+	return pr.toPullRequest(), nil
+}
+
+// do sends a request to the Bitbucket Server API and decodes the response into out if not nil.
+// It returns false if the requested resource is not found.
+func (b *Provider) do(ctx context.Context, method, path string, body, out interface{}) (bool, error) {
+	var reqBody io.Reader
+	if body != nil {
+		bs, err := json.Marshal(body)
+		if err != nil {
+			return false, fmt.Errorf("marshalling request body: %v", err)
+		}
+		reqBody = bytes.NewReader(bs)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, b.baseURL+path, reqBody)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Authorization", "Bearer "+b.auth.Token)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return false, fmt.Errorf("Bitbucket Server API %s %s returned %d: %s", method, path, resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return false, fmt.Errorf("decoding Bitbucket Server API response: %v", err)
+		}
+	}
+
+	return true, nil
+}
+
+func projectPath(owner string, personal bool) string {
+	if personal {
+		owner = personalProjectPrefix + owner
+	}
+	return "/projects/" + url.PathEscape(owner)
+}
+
+func repoPath(owner, repo string, personal bool) string {
+	return projectPath(owner, personal) + "/repos/" + url.PathEscape(Slug(repo))
+}
+
+// Slug returns the Bitbucket Server slug for a repository name.
+func Slug(repo string) string {
+	return strings.ToLower(strings.ReplaceAll(repo, " ", "-"))
+}
+
+// GetBitbucketAccessTokenFromEnv returns the Bitbucket Server access token and user set in the
+// EKSA_BITBUCKET_TOKEN and EKSA_BITBUCKET_USERNAME env vars.
+func GetBitbucketAccessTokenFromEnv() (git.TokenAuth, error) {
+	token, ok := os.LookupEnv(EksaBitbucketTokenEnv)
+	if !ok || len(token) == 0 {
+		return git.TokenAuth{}, fmt.Errorf("bitbucket server access token environment variable %s is not set", EksaBitbucketTokenEnv)
+	}
+
+	username, ok := os.LookupEnv(EksaBitbucketUserEnv)
+	if !ok || len(username) == 0 {
+		return git.TokenAuth{}, fmt.Errorf("bitbucket server user environment variable %s is not set", EksaBitbucketUserEnv)
+	}
+
+	if err := os.Setenv(BitbucketTokenEnv, token); err != nil {
+		return git.TokenAuth{}, fmt.Errorf("unable to set %s: %v", BitbucketTokenEnv, err)
+	}
+
+	return git.TokenAuth{Username: username, Token: token}, nil
+}
+
+// RepoUrl returns the https clone url of a Bitbucket Server repository.
+func RepoUrl(config *v1alpha1.BitbucketServerProviderConfig) string {
+	owner := strings.ToLower(config.Owner)
+	if config.Personal {
+		owner = personalProjectPrefix + owner
+	}
+	return fmt.Sprintf(bitbucketUrlTemplate, config.Hostname, owner, Slug(config.Repository))
+}
@@ -0,0 +1,324 @@
+package bitbucket_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/git/providers/bitbucket"
+)
+
+const token = "bbs-token"
+
+type bitbucketTest struct {
+	*WithT
+	ctx       context.Context
+	mux       *http.ServeMux
+	serverURL string
+	config    *v1alpha1.BitbucketServerProviderConfig
+	provider  *bitbucket.Provider
+}
+
+func newBitbucketTest(t *testing.T) *bitbucketTest {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+token {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	config := &v1alpha1.BitbucketServerProviderConfig{
+		Owner:      "EKSA",
+		Repository: "Fleet",
+		Hostname:   "bitbucket.example.com",
+	}
+
+	return &bitbucketTest{
+		WithT:     NewWithT(t),
+		ctx:       context.Background(),
+		mux:       mux,
+		serverURL: server.URL,
+		config:    config,
+		provider:  bitbucket.New(config, git.TokenAuth{Username: "jane", Token: token}, bitbucket.WithBaseURL(server.URL)),
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func repoResponse() map[string]interface{} {
+	return map[string]interface{}{
+		"name":    "Fleet",
+		"slug":    "fleet",
+		"project": map[string]interface{}{"key": "EKSA"},
+		"links": map[string]interface{}{
+			"clone": []map[string]interface{}{
+				{"href": "ssh://git@bitbucket.example.com:7999/eksa/fleet.git", "name": "ssh"},
+				{"href": "https://bitbucket.example.com/scm/eksa/fleet.git", "name": "http"},
+			},
+		},
+	}
+}
+
+func TestGetRepoExists(t *testing.T) {
+	tt := newBitbucketTest(t)
+	tt.mux.HandleFunc("/rest/api/1.0/projects/EKSA/repos/fleet", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, repoResponse())
+	})
+
+	repo, err := tt.provider.GetRepo(tt.ctx)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(repo).To(Equal(&git.Repository{
+		Name:     "fleet",
+		Owner:    "EKSA",
+		CloneUrl: "https://bitbucket.example.com/scm/eksa/fleet.git",
+	}))
+}
+
+func TestGetRepoPersonal(t *testing.T) {
+	tt := newBitbucketTest(t)
+	tt.config.Personal = true
+	tt.config.Owner = "jane"
+	tt.mux.HandleFunc("/rest/api/1.0/projects/~jane/repos/fleet", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, repoResponse())
+	})
+
+	repo, err := tt.provider.GetRepo(tt.ctx)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(repo).NotTo(BeNil())
+}
+
+func TestGetRepoNotFound(t *testing.T) {
+	tt := newBitbucketTest(t)
+
+	repo, err := tt.provider.GetRepo(tt.ctx)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(repo).To(BeNil())
+}
+
+func TestGetRepoUnauthorized(t *testing.T) {
+	tt := newBitbucketTest(t)
+	tt.provider = bitbucket.New(tt.config, git.TokenAuth{Token: "wrong"}, bitbucket.WithBaseURL(tt.serverURL))
+
+	_, err := tt.provider.GetRepo(tt.ctx)
+	tt.Expect(err).To(MatchError(ContainSubstring("returned 401")))
+}
+
+func TestCreateRepo(t *testing.T) {
+	tt := newBitbucketTest(t)
+	tt.mux.HandleFunc("/rest/api/1.0/projects/EKSA/repos", func(w http.ResponseWriter, r *http.Request) {
+		tt.Expect(r.Method).To(Equal(http.MethodPost))
+		body := map[string]interface{}{}
+		tt.Expect(json.NewDecoder(r.Body).Decode(&body)).To(Succeed())
+		tt.Expect(body).To(HaveKeyWithValue("name", "Fleet"))
+		tt.Expect(body).To(HaveKeyWithValue("scmId", "git"))
+		tt.Expect(body).To(HaveKeyWithValue("public", false))
+		writeJSON(w, http.StatusCreated, repoResponse())
+	})
+
+	repo, err := tt.provider.CreateRepo(tt.ctx, git.CreateRepoOpts{Name: "Fleet", Owner: "EKSA", Privacy: true})
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(repo.Name).To(Equal("fleet"))
+}
+
+func TestCreateRepoProjectNotFound(t *testing.T) {
+	tt := newBitbucketTest(t)
+
+	_, err := tt.provider.CreateRepo(tt.ctx, git.CreateRepoOpts{Name: "Fleet", Owner: "MISSING"})
+	tt.Expect(err).To(MatchError(ContainSubstring("project MISSING not found")))
+}
+
+func TestCreateRepoConflict(t *testing.T) {
+	tt := newBitbucketTest(t)
+	tt.mux.HandleFunc("/rest/api/1.0/projects/EKSA/repos", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusConflict, map[string]interface{}{"errors": []map[string]string{{"message": "already exists"}}})
+	})
+
+	_, err := tt.provider.CreateRepo(tt.ctx, git.CreateRepoOpts{Name: "Fleet", Owner: "EKSA"})
+	tt.Expect(err).To(MatchError(ContainSubstring("already exists")))
+}
+
+func TestDeleteRepo(t *testing.T) {
+	tt := newBitbucketTest(t)
+	tt.mux.HandleFunc("/rest/api/1.0/projects/EKSA/repos/fleet", func(w http.ResponseWriter, r *http.Request) {
+		tt.Expect(r.Method).To(Equal(http.MethodDelete))
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	tt.Expect(tt.provider.DeleteRepo(tt.ctx, git.DeleteRepoOpts{Owner: "EKSA", Repository: "Fleet"})).To(Succeed())
+}
+
+func TestDeleteRepoNotFound(t *testing.T) {
+	tt := newBitbucketTest(t)
+
+	err := tt.provider.DeleteRepo(tt.ctx, git.DeleteRepoOpts{Owner: "EKSA", Repository: "Fleet"})
+	var notFound *git.RepositoryDoesNotExistError
+	tt.Expect(err).To(BeAssignableToTypeOf(notFound))
+}
+
+func TestAddDeployKeyToRepo(t *testing.T) {
+	tt := newBitbucketTest(t)
+	tt.mux.HandleFunc("/rest/keys/1.0/projects/EKSA/repos/fleet/ssh", func(w http.ResponseWriter, r *http.Request) {
+		tt.Expect(r.Method).To(Equal(http.MethodPost))
+		body := map[string]interface{}{}
+		tt.Expect(json.NewDecoder(r.Body).Decode(&body)).To(Succeed())
+		tt.Expect(body).To(Equal(map[string]interface{}{
+			"key": map[string]interface{}{
+				"text":  "ssh-ed25519 AAAA",
+				"label": "flux",
+			},
+			"permission": "REPO_WRITE",
+		}))
+		writeJSON(w, http.StatusCreated, map[string]interface{}{})
+	})
+
+	tt.Expect(tt.provider.AddDeployKeyToRepo(tt.ctx, git.AddDeployKeyOpts{
+		Owner:      "EKSA",
+		Repository: "Fleet",
+		Key:        "ssh-ed25519 AAAA",
+		Title:      "flux",
+	})).To(Succeed())
+}
+
+func TestValidateProject(t *testing.T) {
+	tt := newBitbucketTest(t)
+	tt.mux.HandleFunc("/rest/api/1.0/projects/EKSA", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"key": "EKSA"})
+	})
+
+	tt.Expect(tt.provider.Validate(tt.ctx)).To(Succeed())
+}
+
+func TestValidateProjectNoAccess(t *testing.T) {
+	tt := newBitbucketTest(t)
+
+	tt.Expect(tt.provider.Validate(tt.ctx)).To(MatchError(ContainSubstring("doesn't have proper access to owner EKSA")))
+}
+
+func TestValidatePersonal(t *testing.T) {
+	tt := newBitbucketTest(t)
+	tt.config.Personal = true
+	tt.config.Owner = "jane"
+	tt.mux.HandleFunc("/rest/api/1.0/users/jane", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"name": "jane"})
+	})
+
+	tt.Expect(tt.provider.Validate(tt.ctx)).To(Succeed())
+}
+
+func TestValidatePersonalOwnerMismatch(t *testing.T) {
+	tt := newBitbucketTest(t)
+	tt.config.Personal = true
+	tt.config.Owner = "john"
+	tt.mux.HandleFunc("/rest/api/1.0/users/john", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"name": "john"})
+	})
+
+	tt.Expect(tt.provider.Validate(tt.ctx)).To(MatchError(ContainSubstring("don't match")))
+}
+
+func TestValidateInvalidToken(t *testing.T) {
+	tt := newBitbucketTest(t)
+	tt.provider = bitbucket.New(tt.config, git.TokenAuth{Token: "wrong"}, bitbucket.WithBaseURL(tt.serverURL))
+
+	tt.Expect(tt.provider.Validate(tt.ctx)).To(MatchError(ContainSubstring("401")))
+}
+
+func TestPathExists(t *testing.T) {
+	tt := newBitbucketTest(t)
+	tt.mux.HandleFunc("/rest/api/1.0/projects/EKSA/repos/fleet/browse/clusters/mgmt", func(w http.ResponseWriter, r *http.Request) {
+		tt.Expect(r.URL.Query().Get("at")).To(Equal("refs/heads/main"))
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+	})
+
+	exists, err := tt.provider.PathExists(tt.ctx, "EKSA", "Fleet", "main", "clusters/mgmt")
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(exists).To(BeTrue())
+
+	exists, err = tt.provider.PathExists(tt.ctx, "EKSA", "Fleet", "main", "clusters/other")
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(exists).To(BeFalse())
+}
+
+func TestRepoUrl(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(bitbucket.RepoUrl(&v1alpha1.BitbucketServerProviderConfig{Owner: "EKSA", Repository: "Fleet", Hostname: "bitbucket.example.com"})).
+		To(Equal("https://bitbucket.example.com/scm/eksa/fleet.git"))
+	g.Expect(bitbucket.RepoUrl(&v1alpha1.BitbucketServerProviderConfig{Owner: "jane", Repository: "fleet", Hostname: "bitbucket.example.com", Personal: true})).
+		To(Equal("https://bitbucket.example.com/scm/~jane/fleet.git"))
+}
+
+func TestGetBitbucketAccessTokenFromEnv(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(bitbucket.EksaBitbucketTokenEnv, token)
+	t.Setenv(bitbucket.EksaBitbucketUserEnv, "jane")
+	t.Setenv(bitbucket.BitbucketTokenEnv, "")
+
+	auth, err := bitbucket.GetBitbucketAccessTokenFromEnv()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(auth).To(Equal(git.TokenAuth{Username: "jane", Token: token}))
+}
+
+func TestGetBitbucketAccessTokenFromEnvMissingUser(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(bitbucket.EksaBitbucketTokenEnv, token)
+	t.Setenv(bitbucket.EksaBitbucketUserEnv, "")
+
+	_, err := bitbucket.GetBitbucketAccessTokenFromEnv()
+	g.Expect(err).To(MatchError(ContainSubstring(bitbucket.EksaBitbucketUserEnv)))
+}
+
+func TestCreatePullRequest(t *testing.T) {
+	tt := newBitbucketTest(t)
+	tt.mux.HandleFunc("/rest/api/1.0/projects/EKSA/repos/fleet/pull-requests", func(w http.ResponseWriter, r *http.Request) {
+		tt.Expect(r.Method).To(Equal(http.MethodPost))
+		body := map[string]interface{}{}
+		tt.Expect(json.NewDecoder(r.Body).Decode(&body)).To(Succeed())
+		tt.Expect(body).To(HaveKeyWithValue("fromRef", map[string]interface{}{"id": "refs/heads/eksa-upgrade"}))
+		tt.Expect(body).To(HaveKeyWithValue("toRef", map[string]interface{}{"id": "refs/heads/main"}))
+		writeJSON(w, http.StatusCreated, map[string]interface{}{
+			"id":    3,
+			"state": "OPEN",
+			"links": map[string]interface{}{
+				"self": []map[string]interface{}{{"href": "https://bitbucket.example.com/projects/EKSA/repos/fleet/pull-requests/3"}},
+			},
+		})
+	})
+
+	pr, err := tt.provider.CreatePullRequest(tt.ctx, git.CreatePullRequestOpts{
+		Owner:      "EKSA",
+		Repository: "Fleet",
+		Title:      "Upgrade cluster",
+		Head:       "eksa-upgrade",
+		Base:       "main",
+	})
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(pr).To(Equal(&git.PullRequest{
+		Number: 3,
+		Url:    "https://bitbucket.example.com/projects/EKSA/repos/fleet/pull-requests/3",
+		State:  git.PullRequestOpen,
+	}))
+}
+
+func TestGetPullRequestDeclined(t *testing.T) {
+	tt := newBitbucketTest(t)
+	tt.mux.HandleFunc("/rest/api/1.0/projects/EKSA/repos/fleet/pull-requests/3", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"id": 3, "state": "DECLINED"})
+	})
+
+	pr, err := tt.provider.GetPullRequest(tt.ctx, git.GetPullRequestOpts{Owner: "EKSA", Repository: "Fleet", Number: 3})
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(pr.State).To(Equal(git.PullRequestClosed))
+}
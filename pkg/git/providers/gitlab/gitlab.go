@@ -0,0 +1,380 @@
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const (
+	GitProviderName    = "gitlab"
+	EksaGitlabTokenEnv = "EKSA_GITLAB_TOKEN"
+	GitlabTokenEnv     = "GITLAB_TOKEN"
+	// GitAuthUser is the user used to authenticate git operations over https with a GitLab access token.
+	GitAuthUser       = "oauth2"
+	gitlabUrlTemplate = "https://%s/%s/%s.git"
+	apiPath           = "/api/v4"
+	tokenScope        = "api"
+)
+
+// Provider implements git.ProviderClient for GitLab, both gitlab.com and self-hosted instances,
+// using the GitLab REST API v4.
+type Provider struct {
+	config     *v1alpha1.GitlabProviderConfig
+	auth       git.TokenAuth
+	baseURL    string
+	httpClient *http.Client
+}
+
+// Opt allows to customize a GitLab Provider.
+type Opt func(*Provider)
+
+// WithBaseURL overrides the base url of the GitLab API, by default built from the configured hostname.
+func WithBaseURL(baseURL string) Opt {
+	return func(p *Provider) {
+		p.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// WithHTTPClient sets the http client used to make requests to the GitLab API.
+func WithHTTPClient(client *http.Client) Opt {
+	return func(p *Provider) {
+		p.httpClient = client
+	}
+}
+
+// New builds a new GitLab Provider.
+func New(config *v1alpha1.GitlabProviderConfig, auth git.TokenAuth, opts ...Opt) *Provider {
+	p := &Provider{
+		config:     config,
+		auth:       auth,
+		baseURL:    "https://" + hostname(config) + apiPath,
+		httpClient: http.DefaultClient,
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+type namespace struct {
+	ID       int    `json:"id"`
+	FullPath string `json:"full_path"`
+}
+
+type project struct {
+	ID            int       `json:"id"`
+	Name          string    `json:"name"`
+	Path          string    `json:"path"`
+	Namespace     namespace `json:"namespace"`
+	HTTPURLToRepo string    `json:"http_url_to_repo"`
+}
+
+func (p project) toRepository() *git.Repository {
+	return &git.Repository{
+		Name:     p.Path,
+		Owner:    p.Namespace.FullPath,
+		CloneUrl: p.HTTPURLToRepo,
+	}
+}
+
+// GetRepo describes the configured remote repository.
+// If the repo does not exist, a nil repo is returned.
+func (g *Provider) GetRepo(ctx context.Context) (*git.Repository, error) {
+	o := g.config.Owner
+	r := g.config.Repository
+	logger.V(3).Info("Describing GitLab repository", "name", r, "owner", o)
+	p := &project{}
+	found, err := g.do(ctx, http.MethodGet, projectPath(o, r), nil, p)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected error when describing repository %s: %w", r, err)
+	}
+	if !found {
+		return nil, nil
+	}
+
+	return p.toRepository(), nil
+}
+
+// CreateRepo creates a GitLab project. When the owner is not personal, the project is created
+// in the owner group. The project is empty unless AutoInit is set.
+func (g *Provider) CreateRepo(ctx context.Context, opts git.CreateRepoOpts) (*git.Repository, error) {
+	visibility := "public"
+	if opts.Privacy {
+		visibility = "private"
+	}
+	body := map[string]interface{}{
+		"name":                   opts.Name,
+		"path":                   opts.Name,
+		"description":            opts.Description,
+		"visibility":             visibility,
+		"initialize_with_readme": opts.AutoInit,
+	}
+
+	if !opts.Personal {
+		ns := &namespace{}
+		found, err := g.do(ctx, http.MethodGet, "/namespaces/"+url.PathEscape(opts.Owner), nil, ns)
+		if err != nil {
+			return nil, fmt.Errorf("getting GitLab namespace %s: %v", opts.Owner, err)
+		}
+		if !found {
+			return nil, fmt.Errorf("GitLab namespace %s not found", opts.Owner)
+		}
+		body["namespace_id"] = ns.ID
+	}
+
+	p := &project{}
+	if _, err := g.do(ctx, http.MethodPost, "/projects", body, p); err != nil {
+		return nil, fmt.Errorf("failed to create GitLab repository %s: %v", opts.Name, err)
+	}
+
+	return p.toRepository(), nil
+}
+
+// DeleteRepo deletes a GitLab project.
+func (g *Provider) DeleteRepo(ctx context.Context, opts git.DeleteRepoOpts) error {
+	found, err := g.do(ctx, http.MethodDelete, projectPath(opts.Owner, opts.Repository), nil, nil)
+	if err != nil {
+		return fmt.Errorf("deleting GitLab repository %s: %v", opts.Repository, err)
+	}
+	if !found {
+		return &git.RepositoryDoesNotExistError{Err: fmt.Errorf("repository %s/%s not found", opts.Owner, opts.Repository)}
+	}
+
+	return nil
+}
+
+// AddDeployKeyToRepo registers a deploy key in a GitLab project.
+func (g *Provider) AddDeployKeyToRepo(ctx context.Context, opts git.AddDeployKeyOpts) error {
+	body := map[string]interface{}{
+		"title":    opts.Title,
+		"key":      opts.Key,
+		"can_push": !opts.ReadOnly,
+	}
+	if _, err := g.do(ctx, http.MethodPost, projectPath(opts.Owner, opts.Repository)+"/deploy_keys", body, nil); err != nil {
+		return fmt.Errorf("adding deploy key to GitLab repository %s: %v", opts.Repository, err)
+	}
+
+	return nil
+}
+
+type user struct {
+	Username string `json:"username"`
+}
+
+type personalAccessToken struct {
+	Scopes []string `json:"scopes"`
+}
+
+// Validate checks the access token is valid, has the api scope and has access to the configured owner.
+func (g *Provider) Validate(ctx context.Context) error {
+	u := &user{}
+	found, err := g.do(ctx, http.MethodGet, "/user", nil, u)
+	if err != nil {
+		return fmt.Errorf("getting GitLab authenticated user: %v", err)
+	}
+	if !found {
+		return fmt.Errorf("getting GitLab authenticated user: not found")
+	}
+
+	token := &personalAccessToken{}
+	found, err = g.do(ctx, http.MethodGet, "/personal_access_tokens/self", nil, token)
+	if err != nil {
+		return fmt.Errorf("getting GitLab access token scopes: %v", err)
+	}
+	// Older GitLab versions don't expose the token details, in that case the scopes can't be checked.
+	if found {
+		if !containsScope(token.Scopes, tokenScope) {
+			return fmt.Errorf("GitLab access token doesn't have the required %s scope, current scopes: %s", tokenScope, strings.Join(token.Scopes, ","))
+		}
+		logger.MarkPass("GitLab access token has the required api scope")
+	}
+
+	if g.config.Personal {
+		if !strings.EqualFold(g.config.Owner, u.Username) {
+			return fmt.Errorf("the authenticated GitLab user and owner %s specified in the EKS-A gitops spec don't match; confirm access token owner is %s", g.config.Owner, g.config.Owner)
+		}
+		return nil
+	}
+
+	found, err = g.do(ctx, http.MethodGet, "/groups/"+url.PathEscape(g.config.Owner), nil, nil)
+	if err != nil {
+		return fmt.Errorf("the authenticated GitLab user doesn't have proper access to GitLab group %s, %v", g.config.Owner, err)
+	}
+	if !found {
+		return fmt.Errorf("the authenticated GitLab user doesn't have proper access to GitLab group %s", g.config.Owner)
+	}
+
+	return nil
+}
+
+type treeEntry struct {
+	Path string `json:"path"`
+}
+
+// PathExists checks if a directory exists in the given branch of a GitLab project.
+func (g *Provider) PathExists(ctx context.Context, owner, repo, branch, path string) (bool, error) {
+	query := url.Values{}
+	query.Set("path", path)
+	query.Set("ref", branch)
+	entries := []treeEntry{}
+	found, err := g.do(ctx, http.MethodGet, projectPath(owner, repo)+"/repository/tree?"+query.Encode(), nil, &entries)
+	if err != nil {
+		return false, fmt.Errorf("checking if path %s exists in GitLab repository %s: %v", path, repo, err)
+	}
+
+	// GitLab returns a 404 both for unknown paths and empty repositories
+	return found && len(entries) > 0, nil
+}
+
+type mergeRequest struct {
+	IID    int    `json:"iid"`
+	WebURL string `json:"web_url"`
+	State  string `json:"state"`
+}
+
+func (m mergeRequest) toPullRequest() *git.PullRequest {
+	state := git.PullRequestOpen
+	switch m.State {
+	case "merged":
+		state = git.PullRequestMerged
+	case "closed":
+		state = git.PullRequestClosed
+	}
+
+	return &git.PullRequest{
+		Number: m.IID,
+		Url:    m.WebURL,
+		State:  state,
+	}
+}
+
+// CreatePullRequest opens a merge request from the head branch to the base branch of a GitLab project.
+func (g *Provider) CreatePullRequest(ctx context.Context, opts git.CreatePullRequestOpts) (*git.PullRequest, error) {
+	body := map[string]interface{}{
+		"source_branch": opts.Head,
+		"target_branch": opts.Base,
+		"title":         opts.Title,
+		"description":   opts.Body,
+	}
+	m := &mergeRequest{}
+	found, err := g.do(ctx, http.MethodPost, projectPath(opts.Owner, opts.Repository)+"/merge_requests", body, m)
+	if err != nil {
+		return nil, fmt.Errorf("creating merge request in GitLab repository %s: %v", opts.Repository, err)
+	}
+	if !found {
+		return nil, fmt.Errorf("creating merge request in GitLab repository %s: repository not found", opts.Repository)
+	}
+
+	return m.toPullRequest(), nil
+}
+
+// GetPullRequest describes a merge request of a GitLab project.
+func (g *Provider) GetPullRequest(ctx context.Context, opts git.GetPullRequestOpts) (*git.PullRequest, error) {
+	m := &mergeRequest{}
+	found, err := g.do(ctx, http.MethodGet, fmt.Sprintf("%s/merge_requests/%d", projectPath(opts.Owner, opts.Repository), opts.Number), nil, m)
+	if err != nil {
+		return nil, fmt.Errorf("getting merge request %d in GitLab repository %s: %v", opts.Number, opts.Repository, err)
+	}
+	if !found {
+		return nil, fmt.Errorf("merge request %d not found in GitLab repository %s", opts.Number, opts.Repository)
+	}
+
+	return m.toPullRequest(), nil
+}
+
+// do sends a request to the GitLab API and decodes the response into out if not nil.
+// It returns false if the requested resource is not found.
+func (g *Provider) do(ctx context.Context, method, path string, body, out interface{}) (bool, error) {
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return false, fmt.Errorf("marshalling request body: %v", err)
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, g.baseURL+path, reqBody)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("PRIVATE-TOKEN", g.auth.Token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return false, fmt.Errorf("GitLab API %s %s returned %d: %s", method, path, resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return false, fmt.Errorf("decoding GitLab API response: %v", err)
+		}
+	}
+
+	return true, nil
+}
+
+func projectPath(owner, repo string) string {
+	return "/projects/" + url.PathEscape(owner+"/"+repo)
+}
+
+func containsScope(scopes []string, scope string) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func hostname(config *v1alpha1.GitlabProviderConfig) string {
+	if config.Hostname == "" {
+		return v1alpha1.GitlabDefaultHostname
+	}
+	return config.Hostname
+}
+
+// GetGitlabAccessTokenFromEnv returns the GitLab access token set in the EKSA_GITLAB_TOKEN env var.
+func GetGitlabAccessTokenFromEnv() (string, error) {
+	val, ok := os.LookupEnv(EksaGitlabTokenEnv)
+	if !ok || len(val) == 0 {
+		return "", fmt.Errorf("gitlab access token environment variable %s is not set", EksaGitlabTokenEnv)
+	}
+
+	if err := os.Setenv(GitlabTokenEnv, val); err != nil {
+		return "", fmt.Errorf("unable to set %s: %v", GitlabTokenEnv, err)
+	}
+
+	return val, nil
+}
+
+// RepoUrl returns the https clone url of a GitLab repository.
+func RepoUrl(config *v1alpha1.GitlabProviderConfig) string {
+	return fmt.Sprintf(gitlabUrlTemplate, hostname(config), config.Owner, config.Repository)
+}
@@ -0,0 +1,357 @@
+package gitlab_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/git/providers/gitlab"
+)
+
+const token = "glpat-token"
+
+type gitlabTest struct {
+	*WithT
+	ctx      context.Context
+	mux      router
+	apiURL   string
+	config   *v1alpha1.GitlabProviderConfig
+	provider *gitlab.Provider
+}
+
+func newGitlabTest(t *testing.T) *gitlabTest {
+	mux := router{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("PRIVATE-TOKEN") != token {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		mux.serveHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	config := &v1alpha1.GitlabProviderConfig{
+		Owner:      "my-group/sub-group",
+		Repository: "fleet",
+	}
+
+	return &gitlabTest{
+		WithT:    NewWithT(t),
+		ctx:      context.Background(),
+		mux:      mux,
+		apiURL:   server.URL + "/api/v4",
+		config:   config,
+		provider: gitlab.New(config, git.TokenAuth{Token: token}, gitlab.WithBaseURL(server.URL+"/api/v4")),
+	}
+}
+
+// router matches requests on their escaped path, so tests can check project paths are url encoded.
+type router map[string]http.HandlerFunc
+
+func (r router) HandleFunc(path string, handler http.HandlerFunc) {
+	r[path] = handler
+}
+
+func (r router) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	handler, ok := r[req.URL.EscapedPath()]
+	if !ok {
+		http.NotFound(w, req)
+		return
+	}
+	handler(w, req)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func TestGetRepoExists(t *testing.T) {
+	tt := newGitlabTest(t)
+	tt.mux.HandleFunc("/api/v4/projects/my-group%2Fsub-group%2Ffleet", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"id":               1,
+			"path":             "fleet",
+			"namespace":        map[string]interface{}{"id": 2, "full_path": "my-group/sub-group"},
+			"http_url_to_repo": "https://gitlab.com/my-group/sub-group/fleet.git",
+		})
+	})
+
+	repo, err := tt.provider.GetRepo(tt.ctx)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(repo).To(Equal(&git.Repository{
+		Name:     "fleet",
+		Owner:    "my-group/sub-group",
+		CloneUrl: "https://gitlab.com/my-group/sub-group/fleet.git",
+	}))
+}
+
+func TestGetRepoNotFound(t *testing.T) {
+	tt := newGitlabTest(t)
+
+	repo, err := tt.provider.GetRepo(tt.ctx)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(repo).To(BeNil())
+}
+
+func TestGetRepoUnauthorized(t *testing.T) {
+	tt := newGitlabTest(t)
+	tt.provider = gitlab.New(tt.config, git.TokenAuth{Token: "wrong"}, gitlab.WithBaseURL(tt.apiURL))
+
+	_, err := tt.provider.GetRepo(tt.ctx)
+	tt.Expect(err).To(MatchError(ContainSubstring("returned 401")))
+}
+
+func TestCreateRepoInGroup(t *testing.T) {
+	tt := newGitlabTest(t)
+	tt.mux.HandleFunc("/api/v4/namespaces/my-group%2Fsub-group", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"id": 42, "full_path": "my-group/sub-group"})
+	})
+	tt.mux.HandleFunc("/api/v4/projects", func(w http.ResponseWriter, r *http.Request) {
+		tt.Expect(r.Method).To(Equal(http.MethodPost))
+		body := map[string]interface{}{}
+		tt.Expect(json.NewDecoder(r.Body).Decode(&body)).To(Succeed())
+		tt.Expect(body).To(HaveKeyWithValue("namespace_id", BeNumerically("==", 42)))
+		tt.Expect(body).To(HaveKeyWithValue("visibility", "private"))
+		tt.Expect(body).To(HaveKeyWithValue("path", "fleet"))
+		writeJSON(w, http.StatusCreated, map[string]interface{}{
+			"id":               1,
+			"path":             "fleet",
+			"namespace":        map[string]interface{}{"id": 42, "full_path": "my-group/sub-group"},
+			"http_url_to_repo": "https://gitlab.com/my-group/sub-group/fleet.git",
+		})
+	})
+
+	repo, err := tt.provider.CreateRepo(tt.ctx, git.CreateRepoOpts{
+		Name:    "fleet",
+		Owner:   "my-group/sub-group",
+		Privacy: true,
+	})
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(repo.Name).To(Equal("fleet"))
+}
+
+func TestCreateRepoPersonal(t *testing.T) {
+	tt := newGitlabTest(t)
+	tt.mux.HandleFunc("/api/v4/projects", func(w http.ResponseWriter, r *http.Request) {
+		body := map[string]interface{}{}
+		tt.Expect(json.NewDecoder(r.Body).Decode(&body)).To(Succeed())
+		tt.Expect(body).NotTo(HaveKey("namespace_id"))
+		writeJSON(w, http.StatusCreated, map[string]interface{}{"id": 1, "path": "fleet"})
+	})
+
+	_, err := tt.provider.CreateRepo(tt.ctx, git.CreateRepoOpts{Name: "fleet", Owner: "jane", Personal: true})
+	tt.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestCreateRepoNamespaceNotFound(t *testing.T) {
+	tt := newGitlabTest(t)
+
+	_, err := tt.provider.CreateRepo(tt.ctx, git.CreateRepoOpts{Name: "fleet", Owner: "missing"})
+	tt.Expect(err).To(MatchError(ContainSubstring("GitLab namespace missing not found")))
+}
+
+func TestCreateRepoError(t *testing.T) {
+	tt := newGitlabTest(t)
+	tt.mux.HandleFunc("/api/v4/projects", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusBadRequest, map[string]interface{}{"message": "has already been taken"})
+	})
+
+	_, err := tt.provider.CreateRepo(tt.ctx, git.CreateRepoOpts{Name: "fleet", Owner: "jane", Personal: true})
+	tt.Expect(err).To(MatchError(ContainSubstring("has already been taken")))
+}
+
+func TestDeleteRepo(t *testing.T) {
+	tt := newGitlabTest(t)
+	tt.mux.HandleFunc("/api/v4/projects/jane%2Ffleet", func(w http.ResponseWriter, r *http.Request) {
+		tt.Expect(r.Method).To(Equal(http.MethodDelete))
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	tt.Expect(tt.provider.DeleteRepo(tt.ctx, git.DeleteRepoOpts{Owner: "jane", Repository: "fleet"})).To(Succeed())
+}
+
+func TestDeleteRepoNotFound(t *testing.T) {
+	tt := newGitlabTest(t)
+
+	err := tt.provider.DeleteRepo(tt.ctx, git.DeleteRepoOpts{Owner: "jane", Repository: "fleet"})
+	var notFound *git.RepositoryDoesNotExistError
+	tt.Expect(err).To(BeAssignableToTypeOf(notFound))
+}
+
+func TestAddDeployKeyToRepo(t *testing.T) {
+	tt := newGitlabTest(t)
+	tt.mux.HandleFunc("/api/v4/projects/jane%2Ffleet/deploy_keys", func(w http.ResponseWriter, r *http.Request) {
+		tt.Expect(r.Method).To(Equal(http.MethodPost))
+		body := map[string]interface{}{}
+		tt.Expect(json.NewDecoder(r.Body).Decode(&body)).To(Succeed())
+		tt.Expect(body).To(Equal(map[string]interface{}{
+			"title":    "flux",
+			"key":      "ssh-ed25519 AAAA",
+			"can_push": false,
+		}))
+		writeJSON(w, http.StatusCreated, map[string]interface{}{"id": 1})
+	})
+
+	tt.Expect(tt.provider.AddDeployKeyToRepo(tt.ctx, git.AddDeployKeyOpts{
+		Owner:      "jane",
+		Repository: "fleet",
+		Key:        "ssh-ed25519 AAAA",
+		Title:      "flux",
+		ReadOnly:   true,
+	})).To(Succeed())
+}
+
+func TestValidateGroup(t *testing.T) {
+	tt := newGitlabTest(t)
+	tt.mux.HandleFunc("/api/v4/user", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"username": "jane"})
+	})
+	tt.mux.HandleFunc("/api/v4/personal_access_tokens/self", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"scopes": []string{"api", "read_user"}})
+	})
+	tt.mux.HandleFunc("/api/v4/groups/my-group%2Fsub-group", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"id": 42})
+	})
+
+	tt.Expect(tt.provider.Validate(tt.ctx)).To(Succeed())
+}
+
+func TestValidateGroupNoAccess(t *testing.T) {
+	tt := newGitlabTest(t)
+	tt.mux.HandleFunc("/api/v4/user", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"username": "jane"})
+	})
+
+	tt.Expect(tt.provider.Validate(tt.ctx)).To(MatchError(ContainSubstring("doesn't have proper access to GitLab group my-group/sub-group")))
+}
+
+func TestValidateMissingScope(t *testing.T) {
+	tt := newGitlabTest(t)
+	tt.mux.HandleFunc("/api/v4/user", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"username": "jane"})
+	})
+	tt.mux.HandleFunc("/api/v4/personal_access_tokens/self", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"scopes": []string{"read_repository"}})
+	})
+
+	tt.Expect(tt.provider.Validate(tt.ctx)).To(MatchError(ContainSubstring("doesn't have the required api scope")))
+}
+
+func TestValidatePersonalOwnerMismatch(t *testing.T) {
+	tt := newGitlabTest(t)
+	tt.config.Personal = true
+	tt.config.Owner = "john"
+	tt.mux.HandleFunc("/api/v4/user", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"username": "jane"})
+	})
+
+	tt.Expect(tt.provider.Validate(tt.ctx)).To(MatchError(ContainSubstring("don't match")))
+}
+
+func TestValidateInvalidToken(t *testing.T) {
+	tt := newGitlabTest(t)
+	tt.provider = gitlab.New(tt.config, git.TokenAuth{Token: "wrong"}, gitlab.WithBaseURL(tt.apiURL))
+
+	tt.Expect(tt.provider.Validate(tt.ctx)).To(MatchError(ContainSubstring("401")))
+}
+
+func TestPathExists(t *testing.T) {
+	tt := newGitlabTest(t)
+	tt.mux.HandleFunc("/api/v4/projects/jane%2Ffleet/repository/tree", func(w http.ResponseWriter, r *http.Request) {
+		tt.Expect(r.URL.Query().Get("ref")).To(Equal("main"))
+		if r.URL.Query().Get("path") == "clusters/mgmt" {
+			writeJSON(w, http.StatusOK, []map[string]interface{}{{"path": "clusters/mgmt/eksa-system"}})
+			return
+		}
+		writeJSON(w, http.StatusNotFound, map[string]interface{}{"message": "404 Tree Not Found"})
+	})
+
+	exists, err := tt.provider.PathExists(tt.ctx, "jane", "fleet", "main", "clusters/mgmt")
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(exists).To(BeTrue())
+
+	exists, err = tt.provider.PathExists(tt.ctx, "jane", "fleet", "main", "clusters/other")
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(exists).To(BeFalse())
+}
+
+func TestRepoUrl(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(gitlab.RepoUrl(&v1alpha1.GitlabProviderConfig{Owner: "jane", Repository: "fleet"})).To(Equal("https://gitlab.com/jane/fleet.git"))
+	g.Expect(gitlab.RepoUrl(&v1alpha1.GitlabProviderConfig{Owner: "group/sub", Repository: "fleet", Hostname: "gitlab.example.com"})).To(Equal("https://gitlab.example.com/group/sub/fleet.git"))
+}
+
+func TestGetGitlabAccessTokenFromEnv(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(gitlab.EksaGitlabTokenEnv, token)
+	t.Setenv(gitlab.GitlabTokenEnv, "")
+
+	got, err := gitlab.GetGitlabAccessTokenFromEnv()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(got).To(Equal(token))
+}
+
+func TestGetGitlabAccessTokenFromEnvNotSet(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(gitlab.EksaGitlabTokenEnv, "")
+
+	_, err := gitlab.GetGitlabAccessTokenFromEnv()
+	g.Expect(err).To(MatchError(ContainSubstring(gitlab.EksaGitlabTokenEnv)))
+}
+
+func TestCreatePullRequest(t *testing.T) {
+	tt := newGitlabTest(t)
+	tt.mux.HandleFunc("/api/v4/projects/my-group%2Fsub-group%2Ffleet/merge_requests", func(w http.ResponseWriter, r *http.Request) {
+		tt.Expect(r.Method).To(Equal(http.MethodPost))
+		body := map[string]interface{}{}
+		tt.Expect(json.NewDecoder(r.Body).Decode(&body)).To(Succeed())
+		tt.Expect(body).To(HaveKeyWithValue("source_branch", "eksa-upgrade"))
+		tt.Expect(body).To(HaveKeyWithValue("target_branch", "main"))
+		tt.Expect(body).To(HaveKeyWithValue("title", "Upgrade cluster"))
+		writeJSON(w, http.StatusCreated, map[string]interface{}{
+			"iid":     7,
+			"web_url": "https://gitlab.com/my-group/sub-group/fleet/-/merge_requests/7",
+			"state":   "opened",
+		})
+	})
+
+	pr, err := tt.provider.CreatePullRequest(tt.ctx, git.CreatePullRequestOpts{
+		Owner:      "my-group/sub-group",
+		Repository: "fleet",
+		Title:      "Upgrade cluster",
+		Head:       "eksa-upgrade",
+		Base:       "main",
+	})
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(pr).To(Equal(&git.PullRequest{
+		Number: 7,
+		Url:    "https://gitlab.com/my-group/sub-group/fleet/-/merge_requests/7",
+		State:  git.PullRequestOpen,
+	}))
+}
+
+func TestGetPullRequestMerged(t *testing.T) {
+	tt := newGitlabTest(t)
+	tt.mux.HandleFunc("/api/v4/projects/my-group%2Fsub-group%2Ffleet/merge_requests/7", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"iid": 7, "state": "merged"})
+	})
+
+	pr, err := tt.provider.GetPullRequest(tt.ctx, git.GetPullRequestOpts{Owner: "my-group/sub-group", Repository: "fleet", Number: 7})
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(pr.State).To(Equal(git.PullRequestMerged))
+}
+
+func TestGetPullRequestNotFound(t *testing.T) {
+	tt := newGitlabTest(t)
+
+	_, err := tt.provider.GetPullRequest(tt.ctx, git.GetPullRequestOpts{Owner: "my-group/sub-group", Repository: "fleet", Number: 7})
+	tt.Expect(err).To(MatchError(ContainSubstring("merge request 7 not found")))
+}
@@ -0,0 +1,403 @@
+package argocd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/git"
+	gitFactory "github.com/aws/eks-anywhere/pkg/git/factory"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/retrier"
+	"github.com/aws/eks-anywhere/pkg/templater"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+const (
+	maxRetries    = 10
+	backOffPeriod = 5 * time.Second
+
+	applicationResourceType = "applications.argoproj.io"
+	refreshAnnotation       = "argocd.argoproj.io/refresh"
+
+	initialClusterconfigCommitMessage = "Initial commit of cluster configuration; generated by EKS-A CLI"
+	updateClusterconfigCommitMessage  = "Update commit of cluster configuration; generated by EKS-A CLI"
+	deleteClusterconfigCommitMessage  = "Delete commit of cluster configuration; generated by EKS-A CLI"
+
+	pauseSyncPatch  = `{"spec":{"syncPolicy":{"automated":null}}}`
+	resumeSyncPatch = `{"spec":{"syncPolicy":{"automated":{"prune":true,"selfHeal":true}}}}`
+)
+
+// KubeClient is an interface that abstracts the kubectl commands used to manage Argo CD.
+type KubeClient interface {
+	CreateNamespaceIfNotPresent(ctx context.Context, kubeconfig string, namespace string) error
+	ApplyKubeSpecWithNamespace(ctx context.Context, cluster *types.Cluster, spec string, namespace string) error
+	ApplyKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte) error
+	MergePatchResource(ctx context.Context, resource, name, patch, kubeconfig, namespace string) error
+	DeleteManifest(ctx context.Context, kubeconfigPath, manifestPath string, opts ...executables.KubectlOpt) error
+}
+
+// ArgoCD is a GitOps engine that syncs the EKS-A cluster configuration from git with an Argo CD Application.
+// It's the Argo CD alternative to flux.Flux and is selected when the cluster gitOpsRef points to an ArgoCDConfig.
+type ArgoCD struct {
+	kubeClient KubeClient
+	gitClient  git.Client
+	writer     filewriter.FileWriter
+	cliConfig  *config.CliConfig
+	*retrier.Retrier
+}
+
+// New builds an ArgoCD GitOps engine.
+func New(kubeClient KubeClient, gitTools *gitFactory.GitTools, cliConfig *config.CliConfig) *ArgoCD {
+	var w filewriter.FileWriter
+	var c git.Client
+	if gitTools != nil {
+		w = gitTools.Writer
+		c = gitTools.Client
+	}
+
+	return NewWithGitClient(kubeClient, c, w, cliConfig)
+}
+
+// NewWithGitClient builds an ArgoCD GitOps engine from a git client and the writer for the local repository.
+func NewWithGitClient(kubeClient KubeClient, gitClient git.Client, writer filewriter.FileWriter, cliConfig *config.CliConfig) *ArgoCD {
+	return &ArgoCD{
+		kubeClient: kubeClient,
+		gitClient:  gitClient,
+		writer:     writer,
+		cliConfig:  cliConfig,
+		Retrier:    retrier.NewWithMaxRetries(maxRetries, backOffPeriod),
+	}
+}
+
+// InstallGitOps commits the cluster configuration to git and, for management clusters,
+// installs Argo CD and creates the Application that syncs the cluster configurations.
+func (a *ArgoCD) InstallGitOps(ctx context.Context, cluster *types.Cluster, managementComponents *cluster.ManagementComponents, clusterSpec *cluster.Spec, datacenterConfig providers.DatacenterConfig, machineConfigs []providers.MachineConfig) error {
+	if a.shouldSkip() {
+		logger.Info("GitOps field not specified, install Argo CD skipped")
+		return nil
+	}
+
+	ac := newArgoForCluster(a, clusterSpec, datacenterConfig, machineConfigs)
+
+	if err := ac.setupRepository(ctx); err != nil {
+		return err
+	}
+
+	if err := ac.commitClusterConfigToGit(ctx); err != nil {
+		return err
+	}
+
+	if err := a.Bootstrap(ctx, cluster, clusterSpec); err != nil {
+		return err
+	}
+
+	logger.V(4).Info("pulling from remote after Argo CD bootstrap to ensure configuration files in local git repository are in sync",
+		"branch", ac.branch())
+
+	if err := a.gitClient.Pull(ctx, ac.branch()); err != nil {
+		logger.Error(err, "error when pulling from remote repository after Argo CD bootstrap; ensure local repository is up-to-date with remote (git pull)",
+			"branch", ac.branch(), "error", err)
+	}
+	return nil
+}
+
+// Bootstrap installs Argo CD in the management cluster, registers the git repository credentials
+// and creates the Application for the cluster configurations. It's a no-op for workload clusters.
+func (a *ArgoCD) Bootstrap(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	if clusterSpec.Cluster.IsManaged() || clusterSpec.ArgoCDConfig == nil {
+		return nil
+	}
+
+	if err := a.bootstrap(ctx, cluster, clusterSpec); err != nil {
+		_ = a.Uninstall(ctx, cluster, clusterSpec)
+		return fmt.Errorf("installing Argo CD gitops: %v", err)
+	}
+
+	return nil
+}
+
+func (a *ArgoCD) bootstrap(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	ac := newArgoForCluster(a, clusterSpec, nil, nil)
+
+	logger.V(3).Info("Installing Argo CD", "namespace", ac.namespace(), "manifest", ac.installManifestUrl())
+	if err := a.kubeClient.CreateNamespaceIfNotPresent(ctx, cluster.KubeconfigFile, ac.namespace()); err != nil {
+		return fmt.Errorf("creating Argo CD namespace: %v", err)
+	}
+
+	if err := a.installArgoCD(ctx, cluster, ac); err != nil {
+		return err
+	}
+
+	repoSecret, err := ac.repositorySecret()
+	if err != nil {
+		return err
+	}
+
+	if err := a.Retry(func() error {
+		return a.kubeClient.ApplyKubeSpecFromBytes(ctx, cluster, repoSecret)
+	}); err != nil {
+		return fmt.Errorf("creating Argo CD repository credentials: %v", err)
+	}
+
+	application, err := ac.application()
+	if err != nil {
+		return err
+	}
+
+	// The Application CRD might not be established right after the install manifest is applied.
+	if err := a.Retry(func() error {
+		return a.kubeClient.ApplyKubeSpecFromBytes(ctx, cluster, application)
+	}); err != nil {
+		return fmt.Errorf("creating Argo CD application: %v", err)
+	}
+
+	return nil
+}
+
+func (a *ArgoCD) installArgoCD(ctx context.Context, cluster *types.Cluster, ac *argoForCluster) error {
+	if err := a.Retry(func() error {
+		return a.kubeClient.ApplyKubeSpecWithNamespace(ctx, cluster, ac.installManifestUrl(), ac.namespace())
+	}); err != nil {
+		return fmt.Errorf("applying Argo CD install manifest: %v", err)
+	}
+	return nil
+}
+
+// Uninstall removes the Argo CD components from the management cluster.
+func (a *ArgoCD) Uninstall(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	ac := newArgoForCluster(a, clusterSpec, nil, nil)
+	if err := a.kubeClient.DeleteManifest(ctx, cluster.KubeconfigFile, ac.installManifestUrl(), executables.WithNamespace(ac.namespace())); err != nil {
+		logger.Info("Could not uninstall Argo CD components", "error", err)
+		return err
+	}
+	return nil
+}
+
+// PauseClusterResourcesReconcile disables the automated sync of the cluster configurations Application,
+// so the CLI can update the EKS-A objects without Argo CD reverting them.
+func (a *ArgoCD) PauseClusterResourcesReconcile(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec, provider providers.Provider) error {
+	if a.shouldSkip() {
+		logger.V(4).Info("GitOps field not specified, pause cluster resources reconcile skipped")
+		return nil
+	}
+
+	ac := newArgoForCluster(a, clusterSpec, nil, nil)
+	logger.V(3).Info("Pause Argo CD EKS-A resources reconcile", "application", ac.applicationName())
+
+	if err := a.Retry(func() error {
+		return a.kubeClient.MergePatchResource(ctx, applicationResourceType, ac.applicationName(), pauseSyncPatch, cluster.KubeconfigFile, ac.namespace())
+	}); err != nil {
+		return fmt.Errorf("disabling automated sync for Argo CD application %s: %v", ac.applicationName(), err)
+	}
+
+	return nil
+}
+
+// ResumeClusterResourcesReconcile re-enables the automated sync of the cluster configurations Application.
+func (a *ArgoCD) ResumeClusterResourcesReconcile(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec, provider providers.Provider) error {
+	if a.shouldSkip() {
+		logger.V(4).Info("GitOps field not specified, resume cluster resources reconcile skipped")
+		return nil
+	}
+
+	ac := newArgoForCluster(a, clusterSpec, nil, nil)
+	logger.V(3).Info("Resume Argo CD EKS-A resources reconcile", "application", ac.applicationName())
+
+	if err := a.Retry(func() error {
+		return a.kubeClient.MergePatchResource(ctx, applicationResourceType, ac.applicationName(), resumeSyncPatch, cluster.KubeconfigFile, ac.namespace())
+	}); err != nil {
+		return fmt.Errorf("enabling automated sync for Argo CD application %s: %v", ac.applicationName(), err)
+	}
+
+	return nil
+}
+
+// ForceReconcileGitRepo makes Argo CD refresh the Application from git and sync it.
+func (a *ArgoCD) ForceReconcileGitRepo(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	if a.shouldSkip() {
+		logger.Info("GitOps not configured, force reconcile Argo CD application skipped")
+		return nil
+	}
+
+	ac := newArgoForCluster(a, clusterSpec, nil, nil)
+	patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:"hard"}},"operation":{"initiatedBy":{"username":"eksa"},"sync":{"revision":%q}}}`, refreshAnnotation, ac.branch())
+
+	if err := a.Retry(func() error {
+		return a.kubeClient.MergePatchResource(ctx, applicationResourceType, ac.applicationName(), patch, cluster.KubeconfigFile, ac.namespace())
+	}); err != nil {
+		return fmt.Errorf("syncing Argo CD application %s: %v", ac.applicationName(), err)
+	}
+
+	return nil
+}
+
+// ProposeGitEksaSpec does nothing: updating the git repo through pull requests is only supported with Flux,
+// so the cluster configuration is always pushed by UpdateGitEksaSpec.
+func (a *ArgoCD) ProposeGitEksaSpec(ctx context.Context, clusterSpec *cluster.Spec, datacenterConfig providers.DatacenterConfig, machineConfigs []providers.MachineConfig) error {
+	return nil
+}
+
+// UpdateGitEksaSpec commits the updated cluster configuration to git.
+func (a *ArgoCD) UpdateGitEksaSpec(ctx context.Context, clusterSpec *cluster.Spec, datacenterConfig providers.DatacenterConfig, machineConfigs []providers.MachineConfig) error {
+	if a.shouldSkip() {
+		logger.Info("GitOps field not specified, update git repo skipped")
+		return nil
+	}
+
+	ac := newArgoForCluster(a, clusterSpec, datacenterConfig, machineConfigs)
+
+	if err := ac.syncGitRepo(ctx); err != nil {
+		return err
+	}
+
+	if err := ac.writeClusterConfig(); err != nil {
+		return err
+	}
+
+	p := ac.eksaSystemDir()
+	if err := a.gitClient.Add(p); err != nil {
+		return fmt.Errorf("adding %s to git: %v", p, err)
+	}
+
+	if err := a.pushToRemoteRepo(ctx, p, updateClusterconfigCommitMessage); err != nil {
+		return err
+	}
+	logger.V(3).Info("Finished pushing updated cluster config file to git", "repository", ac.repositoryUrl())
+	return nil
+}
+
+// Validations returns the preflight validations for the Argo CD GitOps engine.
+func (a *ArgoCD) Validations(ctx context.Context, clusterSpec *cluster.Spec) []validations.Validation {
+	if a.shouldSkip() {
+		return nil
+	}
+
+	return []validations.Validation{
+		func() *validations.ValidationResult {
+			return &validations.ValidationResult{
+				Name:        "Argo CD repository credentials",
+				Remediation: fmt.Sprintf("Please set %s to an SSH private key without passphrase", config.EksaGitPrivateKeyTokenEnv),
+				Err:         a.validateRepositoryCredentials(),
+			}
+		},
+	}
+}
+
+func (a *ArgoCD) validateRepositoryCredentials() error {
+	if a.cliConfig == nil || a.cliConfig.GitPrivateKeyFile == "" {
+		return fmt.Errorf("%s is not set", config.EksaGitPrivateKeyTokenEnv)
+	}
+
+	if a.cliConfig.GitSshKeyPassphrase != "" {
+		return errors.New("Argo CD doesn't support passphrase protected SSH keys")
+	}
+
+	return nil
+}
+
+// CleanupGitRepo removes the cluster configuration from git.
+func (a *ArgoCD) CleanupGitRepo(ctx context.Context, clusterSpec *cluster.Spec) error {
+	if a.shouldSkip() {
+		logger.Info("GitOps field not specified, clean up git repo skipped")
+		return nil
+	}
+
+	ac := newArgoForCluster(a, clusterSpec, nil, nil)
+
+	if err := ac.syncGitRepo(ctx); err != nil {
+		return err
+	}
+
+	var p string
+	if clusterSpec.Cluster.IsManaged() {
+		p = ac.eksaSystemDir()
+	} else {
+		p = ac.path()
+	}
+
+	if !validations.FileExists(path.Join(a.writer.Dir(), p)) {
+		logger.V(3).Info("cluster dir does not exist in git, skip clean up")
+		return nil
+	}
+
+	if err := a.gitClient.Remove(p); err != nil {
+		return fmt.Errorf("removing %s in git: %v", p, err)
+	}
+
+	if err := a.pushToRemoteRepo(ctx, p, deleteClusterconfigCommitMessage); err != nil {
+		return err
+	}
+
+	logger.V(3).Info("Finished cleaning up cluster files in git", "repository", ac.repositoryUrl())
+	return nil
+}
+
+// Install installs Argo CD when GitOps is enabled in an existing cluster.
+func (a *ArgoCD) Install(ctx context.Context, cluster *types.Cluster, managementComponents *cluster.ManagementComponents, oldSpec, newSpec *cluster.Spec) error {
+	if oldSpec.Cluster.Spec.GitOpsRef == nil && newSpec.Cluster.Spec.GitOpsRef != nil {
+		return a.InstallGitOps(ctx, cluster, managementComponents, newSpec, nil, nil)
+	}
+	return nil
+}
+
+// Upgrade re-applies the Argo CD install manifest when it changes. Argo CD isn't part of the EKS-A bundle,
+// so no version change diff is reported.
+func (a *ArgoCD) Upgrade(ctx context.Context, managementCluster *types.Cluster, currentManagementComponents, newManagementComponents *cluster.ManagementComponents, currentSpec, newSpec *cluster.Spec) (*types.ChangeDiff, error) {
+	if a.shouldSkip() || newSpec.ArgoCDConfig == nil || newSpec.Cluster.IsManaged() {
+		logger.V(1).Info("Skipping Argo CD upgrades, GitOps not enabled")
+		return nil, nil
+	}
+
+	if currentSpec.ArgoCDConfig == nil || currentSpec.ArgoCDConfig.Spec.InstallManifestUrl == newSpec.ArgoCDConfig.Spec.InstallManifestUrl {
+		logger.V(1).Info("Nothing to upgrade for Argo CD")
+		return nil, nil
+	}
+
+	ac := newArgoForCluster(a, newSpec, nil, nil)
+	logger.V(1).Info("Upgrading Argo CD", "manifest", ac.installManifestUrl())
+	if err := a.installArgoCD(ctx, managementCluster, ac); err != nil {
+		return nil, fmt.Errorf("upgrading Argo CD: %v", err)
+	}
+
+	return nil, nil
+}
+
+func (a *ArgoCD) pushToRemoteRepo(ctx context.Context, path, msg string) error {
+	if err := a.gitClient.Commit(msg); err != nil {
+		return fmt.Errorf("committing %s to git: %v", path, err)
+	}
+
+	if err := a.gitClient.Push(ctx); err != nil {
+		return fmt.Errorf("pushing %s to git: %v", path, err)
+	}
+	return nil
+}
+
+func (a *ArgoCD) shouldSkip() bool {
+	return a.writer == nil
+}
+
+func (a *ArgoCD) readFile(name string) (string, error) {
+	if name == "" {
+		return "", nil
+	}
+	content, err := os.ReadFile(name)
+	if err != nil {
+		return "", fmt.Errorf("reading %s: %v", name, err)
+	}
+	return string(content), nil
+}
+
+func renderTemplate(content string, values interface{}) ([]byte, error) {
+	return templater.Execute(content, values)
+}
@@ -0,0 +1,330 @@
+package argocd_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/git"
+	gitMocks "github.com/aws/eks-anywhere/pkg/git/mocks"
+	"github.com/aws/eks-anywhere/pkg/gitops/argocd"
+	"github.com/aws/eks-anywhere/pkg/gitops/argocd/mocks"
+	"github.com/aws/eks-anywhere/pkg/retrier"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+const (
+	repositoryUrl   = "ssh://git@example.com/aws/eksa-gitops.git"
+	installManifest = "https://example.com/argo-cd/install.yaml"
+)
+
+type argoTest struct {
+	*WithT
+	ctx         context.Context
+	kube        *mocks.MockKubeClient
+	git         *gitMocks.MockClient
+	argo        *argocd.ArgoCD
+	writer      filewriter.FileWriter
+	cliConfig   *config.CliConfig
+	cluster     *types.Cluster
+	clusterSpec *cluster.Spec
+}
+
+func newArgoTest(t *testing.T) *argoTest {
+	ctrl := gomock.NewController(t)
+	kube := mocks.NewMockKubeClient(ctrl)
+	g := gitMocks.NewMockClient(ctrl)
+	dir, w := test.NewWriter(t)
+
+	keyFile := filepath.Join(dir, "id_ed25519")
+	if err := os.WriteFile(keyFile, []byte("private-key"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cliConfig := &config.CliConfig{GitPrivateKeyFile: keyFile}
+
+	gitWriter, err := w.WithDir("repo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a := argocd.NewWithGitClient(kube, g, gitWriter, cliConfig)
+	a.Retrier = retrier.NewWithMaxRetries(1, 0)
+
+	return &argoTest{
+		WithT:       NewWithT(t),
+		ctx:         context.Background(),
+		kube:        kube,
+		git:         g,
+		argo:        a,
+		writer:      gitWriter,
+		cliConfig:   cliConfig,
+		cluster:     &types.Cluster{Name: "mgmt", KubeconfigFile: "mgmt.kubeconfig"},
+		clusterSpec: newClusterSpec(t, "mgmt", "mgmt"),
+	}
+}
+
+func newClusterSpec(t *testing.T, clusterName, managementClusterName string) *cluster.Spec {
+	t.Helper()
+	spec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = clusterName
+		s.Cluster.Spec.ManagementCluster.Name = managementClusterName
+		s.Cluster.Spec.GitOpsRef = &v1alpha1.Ref{Kind: v1alpha1.ArgoCDConfigKind, Name: "argocd"}
+		s.ArgoCDConfig = &v1alpha1.ArgoCDConfig{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       v1alpha1.ArgoCDConfigKind,
+				APIVersion: v1alpha1.SchemeBuilder.GroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{Name: "argocd"},
+			Spec: v1alpha1.ArgoCDConfigSpec{
+				ClusterConfigPath:  "clusters/" + managementClusterName,
+				InstallManifestUrl: installManifest,
+				Git:                &v1alpha1.GitProviderConfig{RepositoryUrl: repositoryUrl},
+			},
+		}
+	})
+	spec.ArgoCDConfig.SetDefaults()
+	return spec
+}
+
+func datacenterConfig(name string) *v1alpha1.VSphereDatacenterConfig {
+	return &v1alpha1.VSphereDatacenterConfig{
+		TypeMeta:   metav1.TypeMeta{Kind: v1alpha1.VSphereDatacenterKind},
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       v1alpha1.VSphereDatacenterConfigSpec{Datacenter: "SDDC-Datacenter"},
+	}
+}
+
+func runValidations(vs []validations.Validation) error {
+	for _, v := range vs {
+		if err := v().Err; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestInstallGitOpsManagementCluster(t *testing.T) {
+	tt := newArgoTest(t)
+
+	gomock.InOrder(
+		tt.git.EXPECT().Clone(tt.ctx).Return(nil),
+		tt.git.EXPECT().Branch("main").Return(nil),
+		tt.git.EXPECT().Add("clusters/mgmt").Return(nil),
+		tt.git.EXPECT().Commit(gomock.Any()).Return(nil),
+		tt.git.EXPECT().Push(tt.ctx).Return(nil),
+		tt.kube.EXPECT().CreateNamespaceIfNotPresent(tt.ctx, "mgmt.kubeconfig", "argocd").Return(nil),
+		tt.kube.EXPECT().ApplyKubeSpecWithNamespace(tt.ctx, tt.cluster, installManifest, "argocd").Return(nil),
+		tt.kube.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ *types.Cluster, data []byte) error {
+				tt.Expect(string(data)).To(ContainSubstring("name: eksa-mgmt-repo"))
+				tt.Expect(string(data)).To(ContainSubstring("argocd.argoproj.io/secret-type: repository"))
+				tt.Expect(string(data)).To(ContainSubstring("    private-key"))
+				tt.Expect(string(data)).NotTo(ContainSubstring("argocd-ssh-known-hosts-cm"))
+				return nil
+			}),
+		tt.kube.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ *types.Cluster, data []byte) error {
+				tt.Expect(string(data)).To(ContainSubstring("kind: Application"))
+				tt.Expect(string(data)).To(ContainSubstring("name: eksa-mgmt"))
+				tt.Expect(string(data)).To(ContainSubstring("repoURL: " + repositoryUrl))
+				tt.Expect(string(data)).To(ContainSubstring("path: clusters/mgmt"))
+				tt.Expect(string(data)).To(ContainSubstring("include: '*/eksa-system/*.yaml'"))
+				return nil
+			}),
+		tt.git.EXPECT().Pull(tt.ctx, "main").Return(nil),
+	)
+
+	tt.Expect(tt.argo.InstallGitOps(tt.ctx, tt.cluster, nil, tt.clusterSpec, datacenterConfig("mgmt"), nil)).To(Succeed())
+
+	content, err := os.ReadFile(path.Join(tt.writer.Dir(), "clusters/mgmt/mgmt/eksa-system/eksa-cluster.yaml"))
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(string(content)).To(ContainSubstring("kind: ArgoCDConfig"))
+}
+
+func TestInstallGitOpsWorkloadClusterEmptyRepo(t *testing.T) {
+	tt := newArgoTest(t)
+	tt.clusterSpec = newClusterSpec(t, "workload", "mgmt")
+
+	gomock.InOrder(
+		tt.git.EXPECT().Clone(tt.ctx).Return(&git.RepositoryIsEmptyError{Repository: "eksa-gitops"}),
+		tt.git.EXPECT().Init().Return(nil),
+		tt.git.EXPECT().Commit(gomock.Any()).Return(nil),
+		tt.git.EXPECT().Branch("main").Return(nil),
+		tt.git.EXPECT().Add("clusters/mgmt").Return(nil),
+		tt.git.EXPECT().Commit(gomock.Any()).Return(nil),
+		tt.git.EXPECT().Push(tt.ctx).Return(nil),
+		tt.git.EXPECT().Pull(tt.ctx, "main").Return(nil),
+	)
+
+	tt.Expect(tt.argo.InstallGitOps(tt.ctx, tt.cluster, nil, tt.clusterSpec, datacenterConfig("workload"), nil)).To(Succeed())
+	tt.Expect(path.Join(tt.writer.Dir(), "clusters/mgmt/workload/eksa-system/eksa-cluster.yaml")).To(BeAnExistingFile())
+}
+
+func TestInstallGitOpsBootstrapErrorUninstalls(t *testing.T) {
+	tt := newArgoTest(t)
+
+	tt.git.EXPECT().Clone(tt.ctx).Return(nil)
+	tt.git.EXPECT().Branch("main").Return(nil)
+	tt.git.EXPECT().Add("clusters/mgmt").Return(nil)
+	tt.git.EXPECT().Commit(gomock.Any()).Return(nil)
+	tt.git.EXPECT().Push(tt.ctx).Return(nil)
+	tt.kube.EXPECT().CreateNamespaceIfNotPresent(tt.ctx, "mgmt.kubeconfig", "argocd").Return(nil)
+	tt.kube.EXPECT().ApplyKubeSpecWithNamespace(tt.ctx, tt.cluster, installManifest, "argocd").Return(errors.New("error in apply"))
+	tt.kube.EXPECT().DeleteManifest(tt.ctx, "mgmt.kubeconfig", installManifest, gomock.AssignableToTypeOf(executables.WithNamespace(""))).Return(nil)
+
+	tt.Expect(tt.argo.InstallGitOps(tt.ctx, tt.cluster, nil, tt.clusterSpec, datacenterConfig("mgmt"), nil)).To(
+		MatchError(ContainSubstring("applying Argo CD install manifest: error in apply")),
+	)
+}
+
+func TestInstallGitOpsCloneError(t *testing.T) {
+	tt := newArgoTest(t)
+
+	tt.git.EXPECT().Clone(tt.ctx).Return(errors.New("error in clone"))
+
+	tt.Expect(tt.argo.InstallGitOps(tt.ctx, tt.cluster, nil, tt.clusterSpec, datacenterConfig("mgmt"), nil)).To(MatchError("error in clone"))
+}
+
+func TestInstallGitOpsSkippedWithoutWriter(t *testing.T) {
+	tt := newArgoTest(t)
+	a := argocd.New(tt.kube, nil, tt.cliConfig)
+
+	tt.Expect(a.InstallGitOps(tt.ctx, tt.cluster, nil, tt.clusterSpec, datacenterConfig("mgmt"), nil)).To(Succeed())
+}
+
+func TestPauseClusterResourcesReconcile(t *testing.T) {
+	tt := newArgoTest(t)
+
+	tt.kube.EXPECT().MergePatchResource(tt.ctx, "applications.argoproj.io", "eksa-mgmt", `{"spec":{"syncPolicy":{"automated":null}}}`, "mgmt.kubeconfig", "argocd").Return(nil)
+
+	tt.Expect(tt.argo.PauseClusterResourcesReconcile(tt.ctx, tt.cluster, tt.clusterSpec, nil)).To(Succeed())
+}
+
+func TestResumeClusterResourcesReconcileError(t *testing.T) {
+	tt := newArgoTest(t)
+
+	tt.kube.EXPECT().MergePatchResource(tt.ctx, "applications.argoproj.io", "eksa-mgmt", gomock.Any(), "mgmt.kubeconfig", "argocd").Return(errors.New("error in patch"))
+
+	tt.Expect(tt.argo.ResumeClusterResourcesReconcile(tt.ctx, tt.cluster, tt.clusterSpec, nil)).To(
+		MatchError(ContainSubstring("enabling automated sync for Argo CD application eksa-mgmt: error in patch")),
+	)
+}
+
+func TestForceReconcileGitRepo(t *testing.T) {
+	tt := newArgoTest(t)
+
+	tt.kube.EXPECT().MergePatchResource(tt.ctx, "applications.argoproj.io", "eksa-mgmt", gomock.Any(), "mgmt.kubeconfig", "argocd").DoAndReturn(
+		func(_ context.Context, _, _, patch, _, _ string) error {
+			tt.Expect(patch).To(ContainSubstring(`"argocd.argoproj.io/refresh":"hard"`))
+			tt.Expect(patch).To(ContainSubstring(`"revision":"main"`))
+			return nil
+		})
+
+	tt.Expect(tt.argo.ForceReconcileGitRepo(tt.ctx, tt.cluster, tt.clusterSpec)).To(Succeed())
+}
+
+func TestUpdateGitEksaSpec(t *testing.T) {
+	tt := newArgoTest(t)
+
+	tt.git.EXPECT().Clone(tt.ctx).Return(nil)
+	tt.git.EXPECT().Branch("main").Return(nil)
+	tt.git.EXPECT().Add("clusters/mgmt/mgmt/eksa-system").Return(nil)
+	tt.git.EXPECT().Commit(gomock.Any()).Return(nil)
+	tt.git.EXPECT().Push(tt.ctx).Return(nil)
+
+	tt.Expect(tt.argo.UpdateGitEksaSpec(tt.ctx, tt.clusterSpec, datacenterConfig("mgmt"), nil)).To(Succeed())
+	tt.Expect(path.Join(tt.writer.Dir(), "clusters/mgmt/mgmt/eksa-system/eksa-cluster.yaml")).To(BeAnExistingFile())
+}
+
+func TestCleanupGitRepoWorkloadCluster(t *testing.T) {
+	tt := newArgoTest(t)
+	tt.clusterSpec = newClusterSpec(t, "workload", "mgmt")
+	_, err := tt.writer.WithDir("clusters/mgmt/workload/eksa-system")
+	tt.Expect(err).NotTo(HaveOccurred())
+
+	tt.git.EXPECT().Clone(tt.ctx).Return(nil)
+	tt.git.EXPECT().Branch("main").Return(nil)
+	tt.git.EXPECT().Remove("clusters/mgmt/workload/eksa-system").Return(nil)
+	tt.git.EXPECT().Commit(gomock.Any()).Return(nil)
+	tt.git.EXPECT().Push(tt.ctx).Return(nil)
+
+	tt.Expect(tt.argo.CleanupGitRepo(tt.ctx, tt.clusterSpec)).To(Succeed())
+}
+
+func TestCleanupGitRepoMissingDir(t *testing.T) {
+	tt := newArgoTest(t)
+
+	tt.git.EXPECT().Clone(tt.ctx).Return(nil)
+	tt.git.EXPECT().Branch("main").Return(nil)
+
+	tt.Expect(tt.argo.CleanupGitRepo(tt.ctx, tt.clusterSpec)).To(Succeed())
+}
+
+func TestValidations(t *testing.T) {
+	tests := []struct {
+		name      string
+		cliConfig *config.CliConfig
+		wantErr   string
+	}{
+		{
+			name:      "valid",
+			cliConfig: &config.CliConfig{GitPrivateKeyFile: "key"},
+		},
+		{
+			name:      "missing private key",
+			cliConfig: &config.CliConfig{},
+			wantErr:   "EKSA_GIT_PRIVATE_KEY is not set",
+		},
+		{
+			name:      "passphrase",
+			cliConfig: &config.CliConfig{GitPrivateKeyFile: "key", GitSshKeyPassphrase: "pass"},
+			wantErr:   "Argo CD doesn't support passphrase protected SSH keys",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tt := newArgoTest(t)
+			a := argocd.NewWithGitClient(tt.kube, tt.git, tt.writer, tc.cliConfig)
+			err := runValidations(a.Validations(tt.ctx, tt.clusterSpec))
+			if tc.wantErr == "" {
+				tt.Expect(err).NotTo(HaveOccurred())
+			} else {
+				tt.Expect(err).To(MatchError(tc.wantErr))
+			}
+		})
+	}
+}
+
+func TestUpgradeInstallManifestChanged(t *testing.T) {
+	tt := newArgoTest(t)
+	currentSpec := tt.clusterSpec.DeepCopy()
+	currentSpec.ArgoCDConfig.Spec.InstallManifestUrl = "https://example.com/argo-cd/old.yaml"
+
+	tt.kube.EXPECT().ApplyKubeSpecWithNamespace(tt.ctx, tt.cluster, installManifest, "argocd").Return(nil)
+
+	diff, err := tt.argo.Upgrade(tt.ctx, tt.cluster, nil, nil, currentSpec, tt.clusterSpec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(diff).To(BeNil())
+}
+
+func TestUpgradeNoChanges(t *testing.T) {
+	tt := newArgoTest(t)
+
+	diff, err := tt.argo.Upgrade(tt.ctx, tt.cluster, nil, nil, tt.clusterSpec.DeepCopy(), tt.clusterSpec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(diff).To(BeNil())
+}
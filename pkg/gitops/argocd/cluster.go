@@ -0,0 +1,256 @@
+package argocd
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"path"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clustermarshaller"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+const (
+	eksaSystemDirName      = "eksa-system"
+	clusterConfigFileName  = "eksa-cluster.yaml"
+	applicationNamePrefix  = "eksa-"
+	repositorySecretSuffix = "-repo"
+)
+
+//go:embed manifests/application.yaml
+var applicationTemplate string
+
+//go:embed manifests/repository-secret.yaml
+var repositorySecretTemplate string
+
+// argoForCluster bundles the ArgoCD struct with a specific clusterSpec, so that all the git and file write
+// operations for the clusterSpec can be done in each structure method.
+type argoForCluster struct {
+	*ArgoCD
+	clusterSpec      *cluster.Spec
+	datacenterConfig providers.DatacenterConfig
+	machineConfigs   []providers.MachineConfig
+}
+
+func newArgoForCluster(argo *ArgoCD, clusterSpec *cluster.Spec, datacenterConfig providers.DatacenterConfig, machineConfigs []providers.MachineConfig) *argoForCluster {
+	return &argoForCluster{
+		ArgoCD:           argo,
+		clusterSpec:      clusterSpec,
+		datacenterConfig: datacenterConfig,
+		machineConfigs:   machineConfigs,
+	}
+}
+
+// commitClusterConfigToGit marshals the cluster configuration to the eksa-system directory of the cluster
+// in the git repository and pushes it to the remote. Argo CD syncs every eksa-system directory under
+// the management cluster config path.
+func (ac *argoForCluster) commitClusterConfigToGit(ctx context.Context) error {
+	logger.Info("Adding cluster configuration files to Git")
+
+	if err := ac.validateLocalConfigPathDoesNotExist(); err != nil {
+		return err
+	}
+
+	if err := ac.writeClusterConfig(); err != nil {
+		return err
+	}
+
+	p := ac.path()
+	if err := ac.gitClient.Add(p); err != nil {
+		return fmt.Errorf("adding %s to git: %v", p, err)
+	}
+
+	if err := ac.pushToRemoteRepo(ctx, p, initialClusterconfigCommitMessage); err != nil {
+		return err
+	}
+
+	logger.V(3).Info("Finished pushing cluster config files to git")
+	return nil
+}
+
+func (ac *argoForCluster) writeClusterConfig() error {
+	w, err := ac.writer.WithDir(ac.eksaSystemDir())
+	if err != nil {
+		return fmt.Errorf("initializing eks-a system writer: %v", err)
+	}
+
+	resourcesSpec, err := clustermarshaller.MarshalClusterSpec(ac.clusterSpec, ac.datacenterConfig, ac.machineConfigs)
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.Write(clusterConfigFileName, resourcesSpec, filewriter.PersistentFile); err != nil {
+		return fmt.Errorf("writing eks-a config files: %v", err)
+	}
+	return nil
+}
+
+func (ac *argoForCluster) syncGitRepo(ctx context.Context) error {
+	if !validations.FileExists(path.Join(ac.writer.Dir(), ".git")) {
+		if err := ac.clone(ctx); err != nil {
+			return fmt.Errorf("cloning git repo: %v", err)
+		}
+	} else {
+		// Make sure the local git repo is on the branch specified in config and up-to-date with the remote
+		if err := ac.gitClient.Branch(ac.branch()); err != nil {
+			return fmt.Errorf("switching to git branch %s: %v", ac.branch(), err)
+		}
+	}
+	return nil
+}
+
+// setupRepository clones the repository which will house the GitOps configuration for the cluster.
+// The repository must be created by the user beforehand. If it's empty, it will be initialized locally,
+// as a bare repository cannot be cloned.
+func (ac *argoForCluster) setupRepository(ctx context.Context) error {
+	err := ac.clone(ctx)
+
+	var repoEmptyErr *git.RepositoryIsEmptyError
+	if errors.As(err, &repoEmptyErr) {
+		logger.V(3).Info("remote repository is empty and can't be cloned; will initialize locally")
+		if initErr := ac.initializeLocalRepository(); initErr != nil {
+			return fmt.Errorf("initializing local repository: %v", initErr)
+		}
+		return nil
+	}
+
+	return err
+}
+
+func (ac *argoForCluster) clone(ctx context.Context) error {
+	logger.V(3).Info("Cloning remote repository")
+	if err := ac.gitClient.Clone(ctx); err != nil {
+		return err
+	}
+
+	logger.V(3).Info("Creating a new branch")
+	return ac.gitClient.Branch(ac.branch())
+}
+
+func (ac *argoForCluster) initializeLocalRepository() error {
+	if err := ac.gitClient.Init(); err != nil {
+		return fmt.Errorf("initializing repository: %v", err)
+	}
+
+	// git requires at least one commit in the repo to branch from
+	if err := ac.gitClient.Commit("initializing repository"); err != nil {
+		return fmt.Errorf("committing to repository: %v", err)
+	}
+
+	if err := ac.gitClient.Branch(ac.branch()); err != nil {
+		return fmt.Errorf("creating branch: %v", err)
+	}
+	return nil
+}
+
+// validateLocalConfigPathDoesNotExist returns an error if the cluster configuration directory exists.
+// This is done so that we avoid clobbering existing cluster configurations in the user-provided git repository.
+func (ac *argoForCluster) validateLocalConfigPathDoesNotExist() error {
+	if ac.clusterSpec.Cluster.IsSelfManaged() {
+		p := path.Join(ac.writer.Dir(), ac.path())
+		if validations.FileExists(p) {
+			return fmt.Errorf("a cluster configuration file already exists at path %s", p)
+		}
+	}
+	return nil
+}
+
+func (ac *argoForCluster) application() ([]byte, error) {
+	values := map[string]interface{}{
+		"Name":              ac.applicationName(),
+		"Namespace":         ac.namespace(),
+		"RepositoryUrl":     ac.repositoryUrl(),
+		"Branch":            ac.branch(),
+		"Path":              ac.path(),
+		"EksaSystemDirName": eksaSystemDirName,
+	}
+
+	b, err := renderTemplate(applicationTemplate, values)
+	if err != nil {
+		return nil, fmt.Errorf("generating Argo CD application: %v", err)
+	}
+	return b, nil
+}
+
+func (ac *argoForCluster) repositorySecret() ([]byte, error) {
+	var privateKeyFile, knownHostsFile string
+	if ac.cliConfig != nil {
+		privateKeyFile = ac.cliConfig.GitPrivateKeyFile
+		knownHostsFile = ac.cliConfig.GitKnownHostsFile
+	}
+
+	privateKey, err := ac.readFile(privateKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading git private key: %v", err)
+	}
+
+	knownHosts, err := ac.readFile(knownHostsFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading git known hosts: %v", err)
+	}
+
+	values := map[string]interface{}{
+		"Name":          ac.applicationName() + repositorySecretSuffix,
+		"Namespace":     ac.namespace(),
+		"RepositoryUrl": ac.repositoryUrl(),
+		"PrivateKey":    privateKey,
+		"KnownHosts":    knownHosts,
+	}
+
+	b, err := renderTemplate(repositorySecretTemplate, values)
+	if err != nil {
+		return nil, fmt.Errorf("generating Argo CD repository secret: %v", err)
+	}
+	return b, nil
+}
+
+func (ac *argoForCluster) namespace() string {
+	if ac.clusterSpec.ArgoCDConfig == nil {
+		return ""
+	}
+	return ac.clusterSpec.ArgoCDConfig.Spec.SystemNamespace
+}
+
+func (ac *argoForCluster) repositoryUrl() string {
+	if ac.clusterSpec.ArgoCDConfig == nil || ac.clusterSpec.ArgoCDConfig.Spec.Git == nil {
+		return ""
+	}
+	return ac.clusterSpec.ArgoCDConfig.Spec.Git.RepositoryUrl
+}
+
+func (ac *argoForCluster) branch() string {
+	if ac.clusterSpec.ArgoCDConfig == nil {
+		return ""
+	}
+	return ac.clusterSpec.ArgoCDConfig.Spec.Branch
+}
+
+func (ac *argoForCluster) installManifestUrl() string {
+	if ac.clusterSpec.ArgoCDConfig == nil {
+		return ""
+	}
+	return ac.clusterSpec.ArgoCDConfig.Spec.InstallManifestUrl
+}
+
+func (ac *argoForCluster) path() string {
+	if ac.clusterSpec.ArgoCDConfig == nil {
+		return ""
+	}
+	return ac.clusterSpec.ArgoCDConfig.Spec.ClusterConfigPath
+}
+
+// applicationName is the name of the Argo CD Application that syncs all the cluster configurations
+// of a management cluster.
+func (ac *argoForCluster) applicationName() string {
+	return applicationNamePrefix + ac.clusterSpec.Cluster.ManagedBy()
+}
+
+func (ac *argoForCluster) eksaSystemDir() string {
+	return path.Join(ac.path(), ac.clusterSpec.Cluster.GetName(), eksaSystemDirName)
+}
@@ -0,0 +1,112 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/aws/eks-anywhere/pkg/gitops/argocd (interfaces: KubeClient)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	executables "github.com/aws/eks-anywhere/pkg/executables"
+	types "github.com/aws/eks-anywhere/pkg/types"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockKubeClient is a mock of KubeClient interface.
+type MockKubeClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockKubeClientMockRecorder
+}
+
+// MockKubeClientMockRecorder is the mock recorder for MockKubeClient.
+type MockKubeClientMockRecorder struct {
+	mock *MockKubeClient
+}
+
+// NewMockKubeClient creates a new mock instance.
+func NewMockKubeClient(ctrl *gomock.Controller) *MockKubeClient {
+	mock := &MockKubeClient{ctrl: ctrl}
+	mock.recorder = &MockKubeClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKubeClient) EXPECT() *MockKubeClientMockRecorder {
+	return m.recorder
+}
+
+// ApplyKubeSpecFromBytes mocks base method.
+func (m *MockKubeClient) ApplyKubeSpecFromBytes(arg0 context.Context, arg1 *types.Cluster, arg2 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyKubeSpecFromBytes", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyKubeSpecFromBytes indicates an expected call of ApplyKubeSpecFromBytes.
+func (mr *MockKubeClientMockRecorder) ApplyKubeSpecFromBytes(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyKubeSpecFromBytes", reflect.TypeOf((*MockKubeClient)(nil).ApplyKubeSpecFromBytes), arg0, arg1, arg2)
+}
+
+// ApplyKubeSpecWithNamespace mocks base method.
+func (m *MockKubeClient) ApplyKubeSpecWithNamespace(arg0 context.Context, arg1 *types.Cluster, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyKubeSpecWithNamespace", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyKubeSpecWithNamespace indicates an expected call of ApplyKubeSpecWithNamespace.
+func (mr *MockKubeClientMockRecorder) ApplyKubeSpecWithNamespace(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyKubeSpecWithNamespace", reflect.TypeOf((*MockKubeClient)(nil).ApplyKubeSpecWithNamespace), arg0, arg1, arg2, arg3)
+}
+
+// CreateNamespaceIfNotPresent mocks base method.
+func (m *MockKubeClient) CreateNamespaceIfNotPresent(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNamespaceIfNotPresent", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateNamespaceIfNotPresent indicates an expected call of CreateNamespaceIfNotPresent.
+func (mr *MockKubeClientMockRecorder) CreateNamespaceIfNotPresent(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNamespaceIfNotPresent", reflect.TypeOf((*MockKubeClient)(nil).CreateNamespaceIfNotPresent), arg0, arg1, arg2)
+}
+
+// DeleteManifest mocks base method.
+func (m *MockKubeClient) DeleteManifest(arg0 context.Context, arg1, arg2 string, arg3 ...executables.KubectlOpt) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteManifest", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteManifest indicates an expected call of DeleteManifest.
+func (mr *MockKubeClientMockRecorder) DeleteManifest(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteManifest", reflect.TypeOf((*MockKubeClient)(nil).DeleteManifest), varargs...)
+}
+
+// MergePatchResource mocks base method.
+func (m *MockKubeClient) MergePatchResource(arg0 context.Context, arg1, arg2, arg3, arg4, arg5 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergePatchResource", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MergePatchResource indicates an expected call of MergePatchResource.
+func (mr *MockKubeClientMockRecorder) MergePatchResource(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergePatchResource", reflect.TypeOf((*MockKubeClient)(nil).MergePatchResource), arg0, arg1, arg2, arg3, arg4, arg5)
+}
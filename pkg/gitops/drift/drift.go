@@ -0,0 +1,177 @@
+package drift
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+)
+
+const (
+	// ObjectPath is the Path of a Field reporting that a whole object is present in git but not in the cluster.
+	ObjectPath = "(object)"
+
+	noValue = "<none>"
+)
+
+// Field is a field of an EKS-A object whose value in the GitOps repository differs from its live value in the cluster.
+type Field struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+	// Path is the json path of the field, relative to the object root.
+	Path string `json:"path"`
+	// Git and Live are the json encoded values of the field in git and in the cluster.
+	Git  string `json:"git"`
+	Live string `json:"live"`
+}
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	_ = anywherev1.AddToScheme(scheme)
+}
+
+// ReadGitConfig reads the cluster configuration file stored in a local copy of the GitOps repository
+// and sets its defaults, so it can be compared with the objects in the cluster.
+func ReadGitConfig(p string) (*cluster.Config, error) {
+	content, err := os.ReadFile(p)
+	if err != nil {
+		return nil, fmt.Errorf("reading cluster config from git: %v", err)
+	}
+
+	c, err := cluster.ParseConfig(content)
+	if err != nil {
+		return nil, fmt.Errorf("parsing cluster config from git %s: %v", p, err)
+	}
+
+	if err = cluster.SetConfigDefaults(c); err != nil {
+		return nil, fmt.Errorf("setting defaults for cluster config from git: %v", err)
+	}
+
+	return c, nil
+}
+
+// Compare reports the spec fields of the EKS-A objects in the git cluster config whose values
+// differ from the ones in the live cluster config. Objects only present in the cluster are ignored,
+// since they are not managed through git.
+func Compare(gitConfig, liveConfig *cluster.Config) ([]Field, error) {
+	live := map[string]kubernetes.Object{}
+	for _, o := range append([]kubernetes.Object{liveConfig.Cluster}, liveConfig.ChildObjects()...) {
+		kind, err := kindOf(o)
+		if err != nil {
+			return nil, err
+		}
+		live[key(kind, o.GetName())] = o
+	}
+
+	var fields []Field
+	for _, g := range append([]kubernetes.Object{gitConfig.Cluster}, gitConfig.ChildObjects()...) {
+		kind, err := kindOf(g)
+		if err != nil {
+			return nil, err
+		}
+
+		l, ok := live[key(kind, g.GetName())]
+		if !ok {
+			fields = append(fields, Field{Kind: kind, Name: g.GetName(), Path: ObjectPath, Git: "present", Live: noValue})
+			continue
+		}
+
+		gitSpec, err := specOf(g)
+		if err != nil {
+			return nil, err
+		}
+		liveSpec, err := specOf(l)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, d := range diff("spec", gitSpec, liveSpec) {
+			d.Kind = kind
+			d.Name = g.GetName()
+			fields = append(fields, d)
+		}
+	}
+
+	return fields, nil
+}
+
+func key(kind, name string) string {
+	return kind + "/" + name
+}
+
+func kindOf(obj kubernetes.Object) (string, error) {
+	kinds, _, err := scheme.ObjectKinds(obj)
+	if err != nil {
+		return "", fmt.Errorf("getting kind for %s: %v", obj.GetName(), err)
+	}
+	return kinds[0].Kind, nil
+}
+
+func specOf(obj kubernetes.Object) (interface{}, error) {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, fmt.Errorf("converting %s to unstructured: %v", obj.GetName(), err)
+	}
+	return u["spec"], nil
+}
+
+func diff(path string, git, live interface{}) []Field {
+	if reflect.DeepEqual(git, live) {
+		return nil
+	}
+
+	gitMap, gitIsMap := git.(map[string]interface{})
+	liveMap, liveIsMap := live.(map[string]interface{})
+	if gitIsMap && liveIsMap {
+		keys := map[string]struct{}{}
+		for k := range gitMap {
+			keys[k] = struct{}{}
+		}
+		for k := range liveMap {
+			keys[k] = struct{}{}
+		}
+		sorted := make([]string, 0, len(keys))
+		for k := range keys {
+			sorted = append(sorted, k)
+		}
+		sort.Strings(sorted)
+
+		var fields []Field
+		for _, k := range sorted {
+			fields = append(fields, diff(path+"."+k, gitMap[k], liveMap[k])...)
+		}
+		return fields
+	}
+
+	gitList, gitIsList := git.([]interface{})
+	liveList, liveIsList := live.([]interface{})
+	if gitIsList && liveIsList && len(gitList) == len(liveList) {
+		var fields []Field
+		for i := range gitList {
+			fields = append(fields, diff(fmt.Sprintf("%s[%d]", path, i), gitList[i], liveList[i])...)
+		}
+		return fields
+	}
+
+	return []Field{{Path: path, Git: encode(git), Live: encode(live)}}
+}
+
+func encode(v interface{}) string {
+	if v == nil {
+		return noValue
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return strings.TrimSpace(string(b))
+}
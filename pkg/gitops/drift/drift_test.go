@@ -0,0 +1,106 @@
+package drift_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/gitops/drift"
+)
+
+func config() *cluster.Config {
+	return &cluster.Config{
+		Cluster: &anywherev1.Cluster{
+			TypeMeta:   metav1.TypeMeta{Kind: anywherev1.ClusterKind, APIVersion: anywherev1.GroupVersion.String()},
+			ObjectMeta: metav1.ObjectMeta{Name: "mgmt", Namespace: "default"},
+			Spec: anywherev1.ClusterSpec{
+				KubernetesVersion: anywherev1.Kube129,
+				WorkerNodeGroupConfigurations: []anywherev1.WorkerNodeGroupConfiguration{
+					{Name: "md-0", Count: ptr(2)},
+				},
+			},
+		},
+		FluxConfig: &anywherev1.FluxConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "flux", Namespace: "default"},
+			Spec:       anywherev1.FluxConfigSpec{Branch: "main"},
+		},
+	}
+}
+
+func ptr(i int) *int {
+	return &i
+}
+
+func TestCompareNoDrift(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(drift.Compare(config(), config())).To(BeEmpty())
+}
+
+func TestCompareDrift(t *testing.T) {
+	g := NewWithT(t)
+	gitConfig := config()
+	liveConfig := config()
+	liveConfig.Cluster.Spec.WorkerNodeGroupConfigurations[0].Count = ptr(5)
+	liveConfig.Cluster.Spec.KubernetesVersion = anywherev1.Kube130
+	liveConfig.FluxConfig.Spec.Branch = "dev"
+
+	g.Expect(drift.Compare(gitConfig, liveConfig)).To(ConsistOf(
+		drift.Field{Kind: "Cluster", Name: "mgmt", Path: "spec.kubernetesVersion", Git: `"1.29"`, Live: `"1.30"`},
+		drift.Field{Kind: "Cluster", Name: "mgmt", Path: "spec.workerNodeGroupConfigurations[0].count", Git: "2", Live: "5"},
+		drift.Field{Kind: "FluxConfig", Name: "flux", Path: "spec.branch", Git: `"main"`, Live: `"dev"`},
+	))
+}
+
+func TestCompareAddedAndRemovedFields(t *testing.T) {
+	g := NewWithT(t)
+	gitConfig := config()
+	liveConfig := config()
+	liveConfig.Cluster.Spec.WorkerNodeGroupConfigurations = append(liveConfig.Cluster.Spec.WorkerNodeGroupConfigurations,
+		anywherev1.WorkerNodeGroupConfiguration{Name: "md-1", Count: ptr(1)},
+	)
+	liveConfig.Cluster.Spec.EksaVersion = ptrEksaVersion("v0.19.0")
+
+	fields, err := drift.Compare(gitConfig, liveConfig)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(fields).To(HaveLen(2))
+	g.Expect(fields[0].Path).To(Equal("spec.eksaVersion"))
+	g.Expect(fields[0].Git).To(Equal("<none>"))
+	g.Expect(fields[1].Path).To(Equal("spec.workerNodeGroupConfigurations"))
+}
+
+func ptrEksaVersion(v string) *anywherev1.EksaVersion {
+	e := anywherev1.EksaVersion(v)
+	return &e
+}
+
+func TestCompareMissingObject(t *testing.T) {
+	g := NewWithT(t)
+	liveConfig := config()
+	liveConfig.FluxConfig = nil
+
+	g.Expect(drift.Compare(config(), liveConfig)).To(ConsistOf(
+		drift.Field{Kind: "FluxConfig", Name: "flux", Path: drift.ObjectPath, Git: "present", Live: "<none>"},
+	))
+}
+
+func TestReadGitConfig(t *testing.T) {
+	g := NewWithT(t)
+
+	c, err := drift.ReadGitConfig("testdata/eksa-cluster.yaml")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(c.Cluster.Name).To(Equal("mgmt"))
+	g.Expect(c.DockerDatacenter).NotTo(BeNil())
+	g.Expect(c.FluxConfig.Spec.SystemNamespace).To(Equal("flux-system"))
+}
+
+func TestReadGitConfigMissingFile(t *testing.T) {
+	g := NewWithT(t)
+
+	_, err := drift.ReadGitConfig(filepath.Join(t.TempDir(), "eksa-cluster.yaml"))
+	g.Expect(err).To(MatchError(ContainSubstring("reading cluster config from git")))
+}
@@ -0,0 +1,79 @@
+package drift
+
+import (
+	"encoding/json"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+const (
+	// FluxFieldManager is the field manager of the Flux kustomize-controller when it applies the git contents.
+	FluxFieldManager = "kustomize-controller"
+	// ArgoCDFieldManager is the field manager of the Argo CD application controller when it syncs the git contents.
+	ArgoCDFieldManager = "argocd-controller"
+
+	eksaControllerFieldManager = "manager"
+)
+
+var gitOpsFieldManagers = map[string]struct{}{
+	FluxFieldManager:   {},
+	ArgoCDFieldManager: {},
+}
+
+// allowedFieldManagers can update the spec of the EKS-A objects without it being considered drift,
+// since they either apply the git contents or write them to git before updating the cluster.
+var allowedFieldManagers = map[string]struct{}{
+	FluxFieldManager:              {},
+	ArgoCDFieldManager:            {},
+	constants.EKSACLIFieldManager: {},
+	eksaControllerFieldManager:    {},
+}
+
+// ModifiedOutsideGitOps checks the managed fields of an object to find whether its spec was updated by
+// a client other than the GitOps engine after the engine last applied it, for example with kubectl edit.
+// It returns the field manager of the most recent of those updates. Objects never applied by a GitOps
+// engine are not considered drifted.
+func ModifiedOutsideGitOps(obj metav1.Object) (manager string, drifted bool) {
+	var lastGitOpsApply *metav1.Time
+	for _, e := range obj.GetManagedFields() {
+		if _, ok := gitOpsFieldManagers[e.Manager]; !ok || e.Time == nil || !managesSpec(e) {
+			continue
+		}
+		if lastGitOpsApply == nil || lastGitOpsApply.Before(e.Time) {
+			lastGitOpsApply = e.Time
+		}
+	}
+
+	if lastGitOpsApply == nil {
+		return "", false
+	}
+
+	var lastUpdate *metav1.Time
+	for _, e := range obj.GetManagedFields() {
+		if _, ok := allowedFieldManagers[e.Manager]; ok || e.Time == nil || !managesSpec(e) {
+			continue
+		}
+		if lastGitOpsApply.Before(e.Time) && (lastUpdate == nil || lastUpdate.Before(e.Time)) {
+			lastUpdate = e.Time
+			manager = e.Manager
+		}
+	}
+
+	return manager, lastUpdate != nil
+}
+
+func managesSpec(e metav1.ManagedFieldsEntry) bool {
+	if e.FieldsV1 == nil {
+		return false
+	}
+
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(e.FieldsV1.Raw, &fields); err != nil {
+		return false
+	}
+
+	_, ok := fields["f:spec"]
+	return ok
+}
@@ -0,0 +1,96 @@
+package drift_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/gitops/drift"
+)
+
+var (
+	specFields   = &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:kubernetesVersion":{}}}`)}
+	statusFields = &metav1.FieldsV1{Raw: []byte(`{"f:status":{"f:conditions":{}}}`)}
+)
+
+func managedField(manager string, minute int, fields *metav1.FieldsV1) metav1.ManagedFieldsEntry {
+	t := metav1.NewTime(time.Date(2024, 1, 1, 0, minute, 0, 0, time.UTC))
+	return metav1.ManagedFieldsEntry{Manager: manager, Time: &t, FieldsV1: fields}
+}
+
+func TestModifiedOutsideGitOps(t *testing.T) {
+	tests := []struct {
+		name          string
+		managedFields []metav1.ManagedFieldsEntry
+		wantManager   string
+		wantDrifted   bool
+	}{
+		{
+			name: "never applied by gitops",
+			managedFields: []metav1.ManagedFieldsEntry{
+				managedField("kubectl-edit", 5, specFields),
+			},
+		},
+		{
+			name: "applied by flux after cli",
+			managedFields: []metav1.ManagedFieldsEntry{
+				managedField("kubectl-client-side-apply", 1, specFields),
+				managedField(drift.FluxFieldManager, 2, specFields),
+			},
+		},
+		{
+			name: "edited after flux apply",
+			managedFields: []metav1.ManagedFieldsEntry{
+				managedField(drift.FluxFieldManager, 2, specFields),
+				managedField("kubectl-edit", 3, specFields),
+			},
+			wantManager: "kubectl-edit",
+			wantDrifted: true,
+		},
+		{
+			name: "edited after argo cd sync, most recent manager reported",
+			managedFields: []metav1.ManagedFieldsEntry{
+				managedField(drift.ArgoCDFieldManager, 2, specFields),
+				managedField("kubectl-patch", 4, specFields),
+				managedField("kubectl-edit", 3, specFields),
+			},
+			wantManager: "kubectl-patch",
+			wantDrifted: true,
+		},
+		{
+			name: "status updated after flux apply",
+			managedFields: []metav1.ManagedFieldsEntry{
+				managedField(drift.FluxFieldManager, 2, specFields),
+				managedField("kubectl-edit", 3, statusFields),
+			},
+		},
+		{
+			name: "cli and controller updates after flux apply",
+			managedFields: []metav1.ManagedFieldsEntry{
+				managedField(drift.FluxFieldManager, 2, specFields),
+				managedField("eks-a-cli", 3, specFields),
+				managedField("manager", 4, specFields),
+			},
+		},
+		{
+			name: "flux reverted the edit",
+			managedFields: []metav1.ManagedFieldsEntry{
+				managedField("kubectl-edit", 3, specFields),
+				managedField(drift.FluxFieldManager, 4, specFields),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			c := &anywherev1.Cluster{ObjectMeta: metav1.ObjectMeta{ManagedFields: tt.managedFields}}
+
+			manager, drifted := drift.ModifiedOutsideGitOps(c)
+			g.Expect(drifted).To(Equal(tt.wantDrifted))
+			g.Expect(manager).To(Equal(tt.wantManager))
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/git/providers/bitbucket"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/validations"
@@ -151,7 +152,7 @@ func (fc *fluxForCluster) clone(ctx context.Context) error {
 
 // createRemoteRepository will create a repository in the remote git provider with the user-provided configuration.
 func (fc *fluxForCluster) createRemoteRepository(ctx context.Context) error {
-	logger.V(3).Info("Remote Github repo does not exist; will create and initialize", "repo", fc.repository(), "owner", fc.owner())
+	logger.V(3).Info("Remote repo does not exist; will create and initialize", "repo", fc.repository(), "owner", fc.owner())
 
 	opts := git.CreateRepoOpts{
 		Name:        fc.repository(),
@@ -161,7 +162,7 @@ func (fc *fluxForCluster) createRemoteRepository(ctx context.Context) error {
 		Privacy:     true,
 	}
 
-	logger.V(4).Info("Creating remote Github repo", "options", opts)
+	logger.V(4).Info("Creating remote repo", "options", opts)
 	if err := fc.gitClient.CreateRepo(ctx, opts); err != nil {
 		return fmt.Errorf("creating repo: %v", err)
 	}
@@ -224,6 +225,12 @@ func (fc *fluxForCluster) repository() string {
 	if fc.clusterSpec.FluxConfig.Spec.Github != nil {
 		return fc.clusterSpec.FluxConfig.Spec.Github.Repository
 	}
+	if fc.clusterSpec.FluxConfig.Spec.Gitlab != nil {
+		return fc.clusterSpec.FluxConfig.Spec.Gitlab.Repository
+	}
+	if fc.clusterSpec.FluxConfig.Spec.BitbucketServer != nil {
+		return bitbucket.Slug(fc.clusterSpec.FluxConfig.Spec.BitbucketServer.Repository)
+	}
 	if fc.clusterSpec.FluxConfig.Spec.Git != nil {
 		r := fc.clusterSpec.FluxConfig.Spec.Git.RepositoryUrl
 		return path.Base(strings.TrimSuffix(r, filepath.Ext(r)))
@@ -235,6 +242,12 @@ func (fc *fluxForCluster) owner() string {
 	if fc.clusterSpec.FluxConfig.Spec.Github != nil {
 		return fc.clusterSpec.FluxConfig.Spec.Github.Owner
 	}
+	if fc.clusterSpec.FluxConfig.Spec.Gitlab != nil {
+		return fc.clusterSpec.FluxConfig.Spec.Gitlab.Owner
+	}
+	if fc.clusterSpec.FluxConfig.Spec.BitbucketServer != nil {
+		return fc.clusterSpec.FluxConfig.Spec.BitbucketServer.Owner
+	}
 	return ""
 }
 
@@ -246,6 +259,12 @@ func (fc *fluxForCluster) personal() bool {
 	if fc.clusterSpec.FluxConfig.Spec.Github != nil {
 		return fc.clusterSpec.FluxConfig.Spec.Github.Personal
 	}
+	if fc.clusterSpec.FluxConfig.Spec.Gitlab != nil {
+		return fc.clusterSpec.FluxConfig.Spec.Gitlab.Personal
+	}
+	if fc.clusterSpec.FluxConfig.Spec.BitbucketServer != nil {
+		return fc.clusterSpec.FluxConfig.Spec.BitbucketServer.Personal
+	}
 	return false
 }
 
@@ -254,9 +273,28 @@ func (fc *fluxForCluster) path() string {
 }
 
 func (fc *fluxForCluster) eksaSystemDir() string {
-	return path.Join(fc.path(), fc.clusterSpec.Cluster.GetName(), eksaSystemDirName)
+	return EksaSystemDir(fc.path(), fc.clusterSpec.Cluster.GetName())
 }
 
 func (fc *fluxForCluster) fluxSystemDir() string {
 	return path.Join(fc.path(), fc.namespace())
 }
+
+// addEksaFiles writes the EKS-A cluster config files to the local repository and stages them.
+func (fc *fluxForCluster) addEksaFiles() (string, error) {
+	g := NewFileGenerator()
+	if err := g.Init(fc.writer, fc.eksaSystemDir(), fc.fluxSystemDir()); err != nil {
+		return "", err
+	}
+
+	if err := g.WriteEksaFiles(fc.clusterSpec, fc.datacenterConfig, fc.machineConfigs); err != nil {
+		return "", err
+	}
+
+	path := fc.eksaSystemDir()
+	if err := fc.gitClient.Add(path); err != nil {
+		return "", fmt.Errorf("adding %s to git: %v", path, err)
+	}
+
+	return path, nil
+}
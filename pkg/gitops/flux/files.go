@@ -3,6 +3,7 @@ package flux
 import (
 	_ "embed"
 	"fmt"
+	"path"
 
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/clustermarshaller"
@@ -28,6 +29,18 @@ var fluxKustomizeContent string
 //go:embed manifests/flux-system/gotk-sync.yaml
 var fluxSyncContent string
 
+// EksaSystemDir returns the directory, relative to the root of the git repository, where the EKS-A
+// configuration files of a cluster are stored.
+func EksaSystemDir(clusterConfigPath, clusterName string) string {
+	return path.Join(clusterConfigPath, clusterName, eksaSystemDirName)
+}
+
+// ClusterConfigFilePath returns the path, relative to the root of the git repository, of the EKS-A
+// cluster configuration file of a cluster.
+func ClusterConfigFilePath(clusterConfigPath, clusterName string) string {
+	return path.Join(EksaSystemDir(clusterConfigPath, clusterName), clusterConfigFileName)
+}
+
 type Templater interface {
 	WriteToFile(templateContent string, data interface{}, fileName string, f ...filewriter.FileOptionsFunc) (filePath string, err error)
 }
@@ -27,6 +27,8 @@ const (
 
 type GitOpsFluxClient interface {
 	BootstrapGithub(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error
+	BootstrapGitlab(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error
+	BootstrapBitbucketServer(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error
 	BootstrapGit(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig, cliConfig *config.CliConfig) error
 	Uninstall(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error
 	GetCluster(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) (eksaCluster *v1alpha1.Cluster, err error)
@@ -49,6 +51,8 @@ type GitClient interface {
 	Commit(message string) error
 	Branch(name string) error
 	Init() error
+	CreatePullRequest(ctx context.Context, opts git.CreatePullRequestOpts) (*git.PullRequest, error)
+	GetPullRequest(ctx context.Context, opts git.GetPullRequestOpts) (*git.PullRequest, error)
 }
 
 type Flux struct {
@@ -118,6 +122,16 @@ func (f *Flux) Bootstrap(ctx context.Context, cluster *types.Cluster, clusterSpe
 		return fmt.Errorf("installing GitHub gitops: %v", err)
 	}
 
+	if err := f.BootstrapGitlab(ctx, cluster, clusterSpec); err != nil {
+		_ = f.Uninstall(ctx, cluster, clusterSpec)
+		return fmt.Errorf("installing GitLab gitops: %v", err)
+	}
+
+	if err := f.BootstrapBitbucketServer(ctx, cluster, clusterSpec); err != nil {
+		_ = f.Uninstall(ctx, cluster, clusterSpec)
+		return fmt.Errorf("installing Bitbucket Server gitops: %v", err)
+	}
+
 	if err := f.BootstrapGit(ctx, cluster, clusterSpec); err != nil {
 		_ = f.Uninstall(ctx, cluster, clusterSpec)
 		return fmt.Errorf("installing generic git gitops: %v", err)
@@ -134,6 +148,22 @@ func (f *Flux) BootstrapGithub(ctx context.Context, cluster *types.Cluster, clus
 	return f.fluxClient.BootstrapGithub(ctx, cluster, clusterSpec.FluxConfig)
 }
 
+func (f *Flux) BootstrapGitlab(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	if clusterSpec.Cluster.IsManaged() || clusterSpec.FluxConfig.Spec.Gitlab == nil {
+		return nil
+	}
+
+	return f.fluxClient.BootstrapGitlab(ctx, cluster, clusterSpec.FluxConfig)
+}
+
+func (f *Flux) BootstrapBitbucketServer(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	if clusterSpec.Cluster.IsManaged() || clusterSpec.FluxConfig.Spec.BitbucketServer == nil {
+		return nil
+	}
+
+	return f.fluxClient.BootstrapBitbucketServer(ctx, cluster, clusterSpec.FluxConfig)
+}
+
 func (f *Flux) BootstrapGit(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
 	if clusterSpec.Cluster.IsManaged() || clusterSpec.FluxConfig.Spec.Git == nil {
 		return nil
@@ -219,31 +249,55 @@ func (f *Flux) UpdateGitEksaSpec(ctx context.Context, clusterSpec *cluster.Spec,
 		return nil
 	}
 
+	if f.pullRequestEnabled() {
+		logger.V(3).Info("Cluster config changes were merged through a pull request, update git repo skipped")
+		return nil
+	}
+
 	fc := newFluxForCluster(f, clusterSpec, datacenterConfig, machineConfigs)
 
 	if err := fc.syncGitRepo(ctx); err != nil {
 		return err
 	}
 
-	g := NewFileGenerator()
-	if err := g.Init(f.writer, fc.eksaSystemDir(), fc.fluxSystemDir()); err != nil {
+	path, err := fc.addEksaFiles()
+	if err != nil {
 		return err
 	}
 
-	if err := g.WriteEksaFiles(clusterSpec, datacenterConfig, machineConfigs); err != nil {
+	if err := f.pushToRemoteRepo(ctx, path, updateClusterconfigCommitMessage); err != nil {
 		return err
 	}
+	logger.V(3).Info("Finished pushing updated cluster config file to git", "repository", fc.repository())
+	return nil
+}
 
-	path := fc.eksaSystemDir()
-	if err := f.gitClient.Add(path); err != nil {
-		return fmt.Errorf("adding %s to git: %v", path, err)
+// ProposeGitEksaSpec opens a pull request with the updated cluster config and waits for it to be merged,
+// when the CLI is configured to update the git repo through pull requests. Otherwise it does nothing and
+// the cluster config is pushed by UpdateGitEksaSpec.
+func (f *Flux) ProposeGitEksaSpec(ctx context.Context, clusterSpec *cluster.Spec, datacenterConfig providers.DatacenterConfig, machineConfigs []providers.MachineConfig) error {
+	if f.shouldSkipFlux() || !f.pullRequestEnabled() {
+		logger.V(4).Info("GitOps pull request not enabled, propose git repo changes skipped")
+		return nil
 	}
 
-	if err := f.pushToRemoteRepo(ctx, path, updateClusterconfigCommitMessage); err != nil {
+	fc := newFluxForCluster(f, clusterSpec, datacenterConfig, machineConfigs)
+
+	if err := fc.syncGitRepo(ctx); err != nil {
 		return err
 	}
-	logger.V(3).Info("Finished pushing updated cluster config file to git", "repository", fc.repository())
-	return nil
+
+	branch := newPullRequestBranch(clusterSpec.Cluster.Name)
+	if err := f.gitClient.Branch(branch); err != nil {
+		return fmt.Errorf("creating git branch %s: %v", branch, err)
+	}
+
+	path, err := fc.addEksaFiles()
+	if err != nil {
+		return err
+	}
+
+	return f.mergeThroughPullRequest(ctx, fc, path, branch)
 }
 
 func (f *Flux) Validations(ctx context.Context, clusterSpec *cluster.Spec) []validations.Validation {
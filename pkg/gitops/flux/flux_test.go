@@ -1074,6 +1074,45 @@ func TestBootstrapGithubError(t *testing.T) {
 	g.Expect(g.gitOpsFlux.Bootstrap(g.ctx, c, clusterSpec)).To(MatchError(ContainSubstring("error in bootstrap github")))
 }
 
+func TestBootstrapGitlabError(t *testing.T) {
+	g := newFluxTest(t)
+	c := &types.Cluster{}
+	clusterConfig := NewCluster("management-cluster")
+	clusterSpec := newClusterSpec(t, clusterConfig, "")
+	clusterSpec.FluxConfig.Spec.Github = nil
+	clusterSpec.FluxConfig.Spec.Gitlab = &v1alpha1.GitlabProviderConfig{Owner: "janedoe", Repository: "testRepo"}
+
+	g.flux.EXPECT().BootstrapGitlab(g.ctx, c, clusterSpec.FluxConfig).Return(errors.New("error in bootstrap gitlab"))
+	g.flux.EXPECT().Uninstall(g.ctx, c, clusterSpec.FluxConfig).Return(nil)
+
+	g.Expect(g.gitOpsFlux.Bootstrap(g.ctx, c, clusterSpec)).To(MatchError(ContainSubstring("error in bootstrap gitlab")))
+}
+
+func TestBootstrapBitbucketServerSuccess(t *testing.T) {
+	g := newFluxTest(t)
+	c := &types.Cluster{}
+	clusterConfig := NewCluster("management-cluster")
+	clusterSpec := newClusterSpec(t, clusterConfig, "")
+	clusterSpec.FluxConfig.Spec.Github = nil
+	clusterSpec.FluxConfig.Spec.BitbucketServer = &v1alpha1.BitbucketServerProviderConfig{Owner: "EKSA", Repository: "testRepo", Hostname: "bitbucket.example.com"}
+
+	g.flux.EXPECT().BootstrapBitbucketServer(g.ctx, c, clusterSpec.FluxConfig).Return(nil)
+
+	g.Expect(g.gitOpsFlux.Bootstrap(g.ctx, c, clusterSpec)).To(Succeed())
+}
+
+func TestBootstrapBitbucketServerSkipManaged(t *testing.T) {
+	g := newFluxTest(t)
+	c := &types.Cluster{}
+	clusterConfig := NewCluster("workload-cluster")
+	clusterConfig.SetManagedBy("management-cluster")
+	clusterSpec := newClusterSpec(t, clusterConfig, "")
+	clusterSpec.FluxConfig.Spec.Github = nil
+	clusterSpec.FluxConfig.Spec.BitbucketServer = &v1alpha1.BitbucketServerProviderConfig{Owner: "EKSA", Repository: "testRepo", Hostname: "bitbucket.example.com"}
+
+	g.Expect(g.gitOpsFlux.BootstrapBitbucketServer(g.ctx, c, clusterSpec)).To(Succeed())
+}
+
 func TestBootstrapGitError(t *testing.T) {
 	g := newFluxTest(t)
 	c := &types.Cluster{}
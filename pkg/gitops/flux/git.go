@@ -2,6 +2,7 @@ package flux
 
 import (
 	"context"
+	"errors"
 
 	"github.com/aws/eks-anywhere/pkg/git"
 	gitFactory "github.com/aws/eks-anywhere/pkg/git/factory"
@@ -110,3 +111,28 @@ func (c *gitClient) Branch(name string) error {
 func (c *gitClient) Init() error {
 	return c.git.Init()
 }
+
+// CreatePullRequest opens a pull request in the git provider. It's not retried to avoid opening duplicated pull requests.
+func (c *gitClient) CreatePullRequest(ctx context.Context, opts git.CreatePullRequestOpts) (*git.PullRequest, error) {
+	if c.gitProvider == nil {
+		return nil, errPullRequestsNotSupported
+	}
+
+	return c.gitProvider.CreatePullRequest(ctx, opts)
+}
+
+func (c *gitClient) GetPullRequest(ctx context.Context, opts git.GetPullRequestOpts) (pr *git.PullRequest, err error) {
+	if c.gitProvider == nil {
+		return nil, errPullRequestsNotSupported
+	}
+
+	err = c.Retry(
+		func() error {
+			pr, err = c.gitProvider.GetPullRequest(ctx, opts)
+			return err
+		},
+	)
+	return pr, err
+}
+
+var errPullRequestsNotSupported = errors.New("pull requests require a git provider with an API, like github, gitlab or bitbucketServer")
@@ -239,3 +239,29 @@ func TestGitClientInitError(t *testing.T) {
 
 	tt.Expect(tt.c.Init()).To(MatchError(ContainSubstring("error in init")), "gitClient.Init() should fail after 1 try")
 }
+
+func TestGitClientCreatePullRequestError(t *testing.T) {
+	tt := newGitClientTest(t)
+	opts := git.CreatePullRequestOpts{}
+	tt.p.EXPECT().CreatePullRequest(tt.ctx, opts).Return(nil, errors.New("error in create pull request")).Times(1)
+
+	_, err := tt.c.CreatePullRequest(tt.ctx, opts)
+	tt.Expect(err).To(MatchError(ContainSubstring("error in create pull request")), "gitClient.CreatePullRequest() should fail after 1 try")
+}
+
+func TestGitClientCreatePullRequestNoProvider(t *testing.T) {
+	tt := newGitClientTest(t)
+
+	c := newGitClient(&gitFactory.GitTools{Provider: nil, Client: tt.g})
+	_, err := c.CreatePullRequest(tt.ctx, git.CreatePullRequestOpts{})
+	tt.Expect(err).To(MatchError(ContainSubstring("pull requests require a git provider")))
+}
+
+func TestGitClientGetPullRequestSuccess(t *testing.T) {
+	tt := newGitClientTest(t)
+	opts := git.GetPullRequestOpts{Number: 1}
+	tt.p.EXPECT().GetPullRequest(tt.ctx, opts).Return(nil, errors.New("error in get pull request")).Times(4)
+	tt.p.EXPECT().GetPullRequest(tt.ctx, opts).Return(&git.PullRequest{Number: 1}, nil).Times(1)
+
+	tt.Expect(tt.c.GetPullRequest(tt.ctx, opts)).To(Equal(&git.PullRequest{Number: 1}), "gitClient.GetPullRequest() should succeed with 5 tries")
+}
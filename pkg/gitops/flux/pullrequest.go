@@ -0,0 +1,91 @@
+package flux
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const (
+	pullRequestBranchPrefix = "eksa-update"
+	pullRequestTitle        = "Update EKS-A cluster %s"
+	pullRequestBody         = "Update of the EKS-A cluster %s configuration; generated by EKS-A CLI.\n\nThe CLI upgrades the cluster once this pull request is merged. Closing it cancels the upgrade."
+)
+
+func (f *Flux) pullRequestEnabled() bool {
+	return f.cliConfig != nil && f.cliConfig.GitOpsPullRequest != nil
+}
+
+func newPullRequestBranch(clusterName string) string {
+	return fmt.Sprintf("%s-%s-%d", pullRequestBranchPrefix, clusterName, time.Now().Unix())
+}
+
+// mergeThroughPullRequest pushes the changes staged in the current branch and opens a pull request
+// to merge them into the configured branch. It waits for the pull request to be merged and leaves
+// the local repository in the configured branch, up to date with the merged changes.
+func (f *Flux) mergeThroughPullRequest(ctx context.Context, fc *fluxForCluster, path, branch string) error {
+	if err := f.pushToRemoteRepo(ctx, path, updateClusterconfigCommitMessage); err != nil {
+		return err
+	}
+
+	clusterName := fc.clusterSpec.Cluster.Name
+	pr, err := f.gitClient.CreatePullRequest(ctx, git.CreatePullRequestOpts{
+		Owner:      fc.owner(),
+		Repository: fc.repository(),
+		Title:      fmt.Sprintf(pullRequestTitle, clusterName),
+		Body:       fmt.Sprintf(pullRequestBody, clusterName),
+		Head:       branch,
+		Base:       fc.branch(),
+	})
+	if err != nil {
+		return fmt.Errorf("opening pull request for branch %s: %v", branch, err)
+	}
+
+	logger.Info("Waiting for the GitOps pull request to be merged", "url", pr.Url, "timeout", f.cliConfig.GitOpsPullRequest.Timeout)
+	if err = f.waitForPullRequestMerge(ctx, fc, pr); err != nil {
+		return err
+	}
+	logger.V(3).Info("GitOps pull request merged", "url", pr.Url)
+
+	if err = f.gitClient.Branch(fc.branch()); err != nil {
+		return fmt.Errorf("switching to git branch %s: %v", fc.branch(), err)
+	}
+
+	return nil
+}
+
+func (f *Flux) waitForPullRequestMerge(ctx context.Context, fc *fluxForCluster, pr *git.PullRequest) error {
+	timeout := time.NewTimer(f.cliConfig.GitOpsPullRequest.Timeout)
+	defer timeout.Stop()
+	ticker := time.NewTicker(f.cliConfig.GitOpsPullRequest.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		current, err := f.gitClient.GetPullRequest(ctx, git.GetPullRequestOpts{
+			Owner:      fc.owner(),
+			Repository: fc.repository(),
+			Number:     pr.Number,
+		})
+		if err != nil {
+			return fmt.Errorf("checking pull request %s: %v", pr.Url, err)
+		}
+
+		switch current.State {
+		case git.PullRequestMerged:
+			return nil
+		case git.PullRequestClosed:
+			return fmt.Errorf("pull request %s was closed without being merged", pr.Url)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timeout.C:
+			return fmt.Errorf("timed out after %s waiting for pull request %s to be merged", f.cliConfig.GitOpsPullRequest.Timeout, pr.Url)
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package flux_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/gitops/flux"
+	"github.com/aws/eks-anywhere/pkg/providers"
+)
+
+func newPullRequestFluxTest(t *testing.T, timeout time.Duration) fluxTest {
+	g := newFluxTest(t)
+	g.gitOpsFlux = flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, &config.CliConfig{
+		GitOpsPullRequest: &config.GitOpsPullRequestConfig{
+			Timeout:      timeout,
+			PollInterval: time.Millisecond,
+		},
+	})
+	return g
+}
+
+func (g *fluxTest) expectPullRequestCreated(branch string) *gomock.Call {
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch(branch).Return(nil)
+	g.git.EXPECT().Branch(gomock.Any()).DoAndReturn(func(name string) error {
+		g.Expect(name).To(HavePrefix("eksa-update-management-cluster-"))
+		return nil
+	})
+	g.git.EXPECT().Add("clusters/management-cluster/management-cluster/eksa-system").Return(nil)
+	g.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(nil)
+	return g.git.EXPECT().CreatePullRequest(g.ctx, gomock.Any()).DoAndReturn(
+		func(_ interface{}, opts git.CreatePullRequestOpts) (*git.PullRequest, error) {
+			g.Expect(opts.Owner).To(Equal("mFolwer"))
+			g.Expect(opts.Repository).To(Equal("testRepo"))
+			g.Expect(opts.Base).To(Equal(branch))
+			g.Expect(opts.Head).To(HavePrefix("eksa-update-management-cluster-"))
+			return &git.PullRequest{Number: 4, Url: "https://github.com/mFolwer/testRepo/pull/4", State: git.PullRequestOpen}, nil
+		},
+	)
+}
+
+func TestProposeGitEksaSpecPullRequestMerged(t *testing.T) {
+	clusterName := "management-cluster"
+	g := newPullRequestFluxTest(t, time.Minute)
+	clusterSpec := newClusterSpec(t, NewCluster(clusterName), "")
+	getOpts := git.GetPullRequestOpts{Owner: "mFolwer", Repository: "testRepo", Number: 4}
+
+	created := g.expectPullRequestCreated(clusterSpec.FluxConfig.Spec.Branch)
+	gomock.InOrder(
+		created,
+		g.git.EXPECT().GetPullRequest(g.ctx, getOpts).Return(&git.PullRequest{Number: 4, State: git.PullRequestOpen}, nil),
+		g.git.EXPECT().GetPullRequest(g.ctx, getOpts).Return(&git.PullRequest{Number: 4, State: git.PullRequestMerged}, nil),
+		g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil),
+	)
+
+	g.Expect(g.gitOpsFlux.ProposeGitEksaSpec(g.ctx, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})).To(Succeed())
+}
+
+func TestProposeGitEksaSpecPullRequestClosed(t *testing.T) {
+	clusterName := "management-cluster"
+	g := newPullRequestFluxTest(t, time.Minute)
+	clusterSpec := newClusterSpec(t, NewCluster(clusterName), "")
+
+	g.expectPullRequestCreated(clusterSpec.FluxConfig.Spec.Branch)
+	g.git.EXPECT().GetPullRequest(g.ctx, gomock.Any()).Return(&git.PullRequest{Number: 4, State: git.PullRequestClosed}, nil)
+
+	g.Expect(g.gitOpsFlux.ProposeGitEksaSpec(g.ctx, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})).To(
+		MatchError(ContainSubstring("closed without being merged")),
+	)
+}
+
+func TestProposeGitEksaSpecPullRequestTimeout(t *testing.T) {
+	clusterName := "management-cluster"
+	g := newPullRequestFluxTest(t, 10*time.Millisecond)
+	clusterSpec := newClusterSpec(t, NewCluster(clusterName), "")
+
+	g.expectPullRequestCreated(clusterSpec.FluxConfig.Spec.Branch)
+	g.git.EXPECT().GetPullRequest(g.ctx, gomock.Any()).Return(&git.PullRequest{Number: 4, State: git.PullRequestOpen}, nil).MinTimes(1)
+
+	g.Expect(g.gitOpsFlux.ProposeGitEksaSpec(g.ctx, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})).To(
+		MatchError(ContainSubstring("timed out")),
+	)
+}
+
+func TestProposeGitEksaSpecPullRequestCreateError(t *testing.T) {
+	clusterName := "management-cluster"
+	g := newPullRequestFluxTest(t, time.Minute)
+	clusterSpec := newClusterSpec(t, NewCluster(clusterName), "")
+
+	g.expectPullRequestCreated(clusterSpec.FluxConfig.Spec.Branch).Return(nil, errors.New("branch protection"))
+
+	g.Expect(g.gitOpsFlux.ProposeGitEksaSpec(g.ctx, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})).To(
+		MatchError(ContainSubstring("branch protection")),
+	)
+}
+
+func TestProposeGitEksaSpecPullRequestDisabled(t *testing.T) {
+	clusterName := "management-cluster"
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, NewCluster(clusterName), "")
+
+	g.Expect(g.gitOpsFlux.ProposeGitEksaSpec(g.ctx, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})).To(Succeed())
+}
+
+func TestUpdateGitEksaSpecPullRequestSkipped(t *testing.T) {
+	clusterName := "management-cluster"
+	g := newPullRequestFluxTest(t, time.Minute)
+	clusterSpec := newClusterSpec(t, NewCluster(clusterName), "")
+
+	g.Expect(g.gitOpsFlux.UpdateGitEksaSpec(g.ctx, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})).To(Succeed())
+}
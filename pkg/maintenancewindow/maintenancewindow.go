@@ -0,0 +1,211 @@
+package maintenancewindow
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// searchLimit bounds the search of the next time a schedule matches, so schedules that never
+// match, like the 30th of February, don't loop forever.
+const searchLimit = 5 * 366 * 24 * time.Hour
+
+// Window is a recurrent period of time that opens every time its cron schedule matches
+// and stays open for a fixed duration. Times are evaluated in UTC.
+type Window struct {
+	schedule *schedule
+	duration time.Duration
+}
+
+// New parses a standard 5 fields cron schedule (minute, hour, day of month, month and day of week)
+// and returns a Window that opens on it. Fields accept numbers, *, ranges (1-5), lists (1,3) and steps (*/15).
+func New(cron string, duration time.Duration) (*Window, error) {
+	if duration <= 0 {
+		return nil, fmt.Errorf("maintenance window duration must be greater than 0")
+	}
+
+	s, err := parse(cron)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.next(time.Now().UTC()).IsZero() {
+		return nil, fmt.Errorf("maintenance window schedule %s never matches", cron)
+	}
+
+	return &Window{schedule: s, duration: duration}, nil
+}
+
+// ValidateSchedule checks that cron is a standard 5 fields cron schedule supported by New.
+func ValidateSchedule(cron string) error {
+	_, err := parseSchedule("schedule", cron)
+	return err
+}
+
+// IsOpen returns true if the window is open at the given time.
+func (w *Window) IsOpen(now time.Time) bool {
+	start := w.schedule.next(now.UTC().Add(-w.duration))
+	return !start.IsZero() && !start.After(now.UTC())
+}
+
+// NextOpen returns the next time the window opens after the given time.
+func (w *Window) NextOpen(now time.Time) time.Time {
+	return w.schedule.next(now.UTC())
+}
+
+// CloseTime returns when the window open at the given time closes, or the zero time if it's closed.
+func (w *Window) CloseTime(now time.Time) time.Time {
+	start := w.schedule.next(now.UTC().Add(-w.duration))
+	if start.IsZero() || start.After(now.UTC()) {
+		return time.Time{}
+	}
+
+	// A window can open again before the previous one closes, the latest opening is the one that counts.
+	for next := w.schedule.next(start); !next.IsZero() && !next.After(now.UTC()); next = w.schedule.next(next) {
+		start = next
+	}
+
+	return start.Add(w.duration)
+}
+
+type field struct {
+	name     string
+	min, max int
+}
+
+var fields = []field{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12},
+	{name: "day of week", min: 0, max: 7},
+}
+
+type schedule struct {
+	minute, hour, dom, month, dow uint64
+	// domStar and dowStar follow cron semantics: when both day fields are restricted,
+	// a day matches if any of them does. A field starting with "*", like "*/2", isn't restricted.
+	domStar, dowStar bool
+}
+
+func parse(cron string) (*schedule, error) {
+	return parseSchedule("maintenance window schedule", cron)
+}
+
+// parseSchedule parses cron, using name to describe it in errors.
+func parseSchedule(name, cron string) (*schedule, error) {
+	parts := strings.Fields(cron)
+	if len(parts) != len(fields) {
+		return nil, fmt.Errorf("invalid %s %s: expected %d fields, got %d", name, cron, len(fields), len(parts))
+	}
+
+	bits := make([]uint64, len(fields))
+	for i, f := range fields {
+		b, err := parseField(parts[i], f)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %s: %v", name, cron, err)
+		}
+		bits[i] = b
+	}
+
+	dow := bits[4]
+	if dow&(1<<7) != 0 {
+		// 7 is also Sunday
+		dow |= 1
+	}
+
+	return &schedule{
+		minute:  bits[0],
+		hour:    bits[1],
+		dom:     bits[2],
+		month:   bits[3],
+		dow:     dow,
+		domStar: strings.HasPrefix(parts[2], "*"),
+		dowStar: strings.HasPrefix(parts[4], "*"),
+	}, nil
+}
+
+func parseField(value string, f field) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(value, ",") {
+		rangePart, step := item, 1
+		if i := strings.Index(item, "/"); i >= 0 {
+			s, err := strconv.Atoi(item[i+1:])
+			if err != nil || s <= 0 {
+				return 0, fmt.Errorf("invalid step in %s field: %s", f.name, item)
+			}
+			rangePart, step = item[:i], s
+		}
+
+		start, end := f.min, f.max
+		if rangePart != "*" {
+			var err error
+			bounds := strings.SplitN(rangePart, "-", 2)
+			if start, err = strconv.Atoi(bounds[0]); err != nil {
+				return 0, fmt.Errorf("invalid value in %s field: %s", f.name, item)
+			}
+			end = start
+			if len(bounds) == 2 {
+				if end, err = strconv.Atoi(bounds[1]); err != nil {
+					return 0, fmt.Errorf("invalid value in %s field: %s", f.name, item)
+				}
+			} else if step > 1 {
+				end = f.max
+			}
+		}
+
+		if start < f.min || end > f.max || start > end {
+			return 0, fmt.Errorf("%s field must be between %d and %d: %s", f.name, f.min, f.max, item)
+		}
+
+		for v := start; v <= end; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+
+	return bits, nil
+}
+
+// next returns the first minute after t that matches the schedule or the zero time if
+// there isn't any in the search limit.
+func (s *schedule) next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(searchLimit)
+
+	for t.Before(limit) {
+		if !has(s.month, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !has(s.hour, t.Hour()) {
+			t = t.Truncate(time.Hour).Add(time.Hour)
+			continue
+		}
+		if !has(s.minute, t.Minute()) {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+
+	return time.Time{}
+}
+
+func (s *schedule) dayMatches(t time.Time) bool {
+	dom := has(s.dom, t.Day())
+	dow := has(s.dow, int(t.Weekday()))
+	if s.domStar || s.dowStar {
+		return dom && dow
+	}
+
+	return dom || dow
+}
+
+func has(bits uint64, v int) bool {
+	return bits&(1<<uint(v)) != 0
+}
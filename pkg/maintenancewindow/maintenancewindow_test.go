@@ -0,0 +1,275 @@
+package maintenancewindow_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/maintenancewindow"
+)
+
+func TestNewError(t *testing.T) {
+	testCases := []struct {
+		name     string
+		schedule string
+		duration time.Duration
+		wantErr  string
+	}{
+		{
+			name:     "no duration",
+			schedule: "0 2 * * *",
+			wantErr:  "duration must be greater than 0",
+		},
+		{
+			name:     "missing fields",
+			schedule: "0 2 * *",
+			duration: time.Hour,
+			wantErr:  "expected 5 fields, got 4",
+		},
+		{
+			name:     "invalid value",
+			schedule: "a 2 * * *",
+			duration: time.Hour,
+			wantErr:  "invalid value in minute field",
+		},
+		{
+			name:     "out of range",
+			schedule: "0 24 * * *",
+			duration: time.Hour,
+			wantErr:  "hour field must be between 0 and 23",
+		},
+		{
+			name:     "invalid range",
+			schedule: "0 2 * * 5-1",
+			duration: time.Hour,
+			wantErr:  "day of week field must be between 0 and 7",
+		},
+		{
+			name:     "invalid step",
+			schedule: "*/0 2 * * *",
+			duration: time.Hour,
+			wantErr:  "invalid step in minute field",
+		},
+		{
+			name:     "never matches",
+			schedule: "0 2 30 2 *",
+			duration: time.Hour,
+			wantErr:  "never matches",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			_, err := maintenancewindow.New(tt.schedule, tt.duration)
+			g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+		})
+	}
+}
+
+func TestWindowIsOpen(t *testing.T) {
+	testCases := []struct {
+		name     string
+		schedule string
+		duration time.Duration
+		now      string
+		want     bool
+	}{
+		{
+			name:     "daily at opening",
+			schedule: "0 2 * * *",
+			duration: 2 * time.Hour,
+			now:      "2026-10-19T02:00:00Z",
+			want:     true,
+		},
+		{
+			name:     "daily inside",
+			schedule: "0 2 * * *",
+			duration: 2 * time.Hour,
+			now:      "2026-10-19T03:59:00Z",
+			want:     true,
+		},
+		{
+			name:     "daily at closing",
+			schedule: "0 2 * * *",
+			duration: 2 * time.Hour,
+			now:      "2026-10-19T04:00:00Z",
+			want:     false,
+		},
+		{
+			name:     "daily before opening",
+			schedule: "0 2 * * *",
+			duration: 2 * time.Hour,
+			now:      "2026-10-19T01:59:59Z",
+			want:     false,
+		},
+		{
+			name:     "crosses midnight",
+			schedule: "0 22 * * *",
+			duration: 4 * time.Hour,
+			now:      "2026-10-20T01:30:00Z",
+			want:     true,
+		},
+		{
+			name:     "weekends, saturday",
+			schedule: "0 0 * * 6,0",
+			duration: 24 * time.Hour,
+			now:      "2026-10-24T12:00:00Z",
+			want:     true,
+		},
+		{
+			name:     "weekends, monday",
+			schedule: "0 0 * * 6,7",
+			duration: 24 * time.Hour,
+			now:      "2026-10-19T12:00:00Z",
+			want:     false,
+		},
+		{
+			name:     "weekends, sunday as 7",
+			schedule: "0 0 * * 6-7",
+			duration: 24 * time.Hour,
+			now:      "2026-10-25T12:00:00Z",
+			want:     true,
+		},
+		{
+			name:     "day of month or day of week",
+			schedule: "0 0 1 * 1",
+			duration: time.Hour,
+			now:      "2026-10-19T00:30:00Z",
+			want:     true,
+		},
+		{
+			name:     "day of month step and day of week, both match",
+			schedule: "0 2 */2 * 1",
+			duration: time.Hour,
+			now:      "2026-10-19T02:30:00Z",
+			want:     true,
+		},
+		{
+			name:     "day of month step and day of week, only day of month matches",
+			schedule: "0 2 */2 * 1",
+			duration: time.Hour,
+			now:      "2026-10-21T02:30:00Z",
+			want:     false,
+		},
+		{
+			name:     "day of month step and day of week, only day of week matches",
+			schedule: "0 2 */2 * 1",
+			duration: time.Hour,
+			now:      "2026-10-26T02:30:00Z",
+			want:     false,
+		},
+		{
+			name:     "steps",
+			schedule: "*/15 * * * *",
+			duration: 5 * time.Minute,
+			now:      "2026-10-19T10:47:00Z",
+			want:     true,
+		},
+		{
+			name:     "steps, closed",
+			schedule: "*/15 * * * *",
+			duration: 5 * time.Minute,
+			now:      "2026-10-19T10:40:00Z",
+			want:     false,
+		},
+		{
+			name:     "non UTC time",
+			schedule: "0 2 * * *",
+			duration: time.Hour,
+			now:      "2026-10-19T04:30:00+02:00",
+			want:     true,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			w, err := maintenancewindow.New(tt.schedule, tt.duration)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(w.IsOpen(parseTime(t, tt.now))).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestWindowNextOpen(t *testing.T) {
+	testCases := []struct {
+		name     string
+		schedule string
+		now      string
+		want     string
+	}{
+		{
+			name:     "same day",
+			schedule: "30 2 * * *",
+			now:      "2026-10-19T01:00:00Z",
+			want:     "2026-10-19T02:30:00Z",
+		},
+		{
+			name:     "next day",
+			schedule: "30 2 * * *",
+			now:      "2026-10-19T02:30:00Z",
+			want:     "2026-10-20T02:30:00Z",
+		},
+		{
+			name:     "next month",
+			schedule: "0 0 1 * *",
+			now:      "2026-10-19T01:00:00Z",
+			want:     "2026-11-01T00:00:00Z",
+		},
+		{
+			name:     "next year",
+			schedule: "0 3 * 1-2 1-5",
+			now:      "2026-10-19T01:00:00Z",
+			want:     "2027-01-01T03:00:00Z",
+		},
+		{
+			name:     "leap day",
+			schedule: "0 0 29 2 *",
+			now:      "2026-10-19T01:00:00Z",
+			want:     "2028-02-29T00:00:00Z",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			w, err := maintenancewindow.New(tt.schedule, time.Hour)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(w.NextOpen(parseTime(t, tt.now))).To(Equal(parseTime(t, tt.want).UTC()))
+		})
+	}
+}
+
+func TestWindowCloseTime(t *testing.T) {
+	g := NewWithT(t)
+	w, err := maintenancewindow.New("0 */2 * * *", 3*time.Hour)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(w.CloseTime(parseTime(t, "2026-10-19T03:30:00Z"))).To(Equal(parseTime(t, "2026-10-19T05:00:00Z")))
+}
+
+func TestWindowCloseTimeClosed(t *testing.T) {
+	g := NewWithT(t)
+	w, err := maintenancewindow.New("0 2 * * *", time.Hour)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(w.CloseTime(parseTime(t, "2026-10-19T03:30:00Z"))).To(BeZero())
+}
+
+func parseTime(t *testing.T, s string) time.Time {
+	t.Helper()
+	ts, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ts
+}
+
+func TestValidateSchedule(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(maintenancewindow.ValidateSchedule("0 */6 * * *")).To(Succeed())
+	g.Expect(maintenancewindow.ValidateSchedule("0 */6 * *")).To(MatchError("invalid schedule 0 */6 * *: expected 5 fields, got 4"))
+}
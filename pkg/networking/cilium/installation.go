@@ -28,6 +28,10 @@ const (
 	ConfigMapName = "cilium-config"
 	// ServiceName is the default name for the Cilium Service installed in EKS-A clusters.
 	ServiceName = "cilium-agent"
+	// HubbleRelayDeploymentName is the name for the Hubble Relay deployment installed by the Cilium chart.
+	HubbleRelayDeploymentName = "hubble-relay"
+	// HubbleUIDeploymentName is the name for the Hubble UI deployment installed by the Cilium chart.
+	HubbleUIDeploymentName = "hubble-ui"
 
 	ciliumConfigMapName   = "cilium-config"
 	ciliumConfigNamespace = "kube-system"
@@ -35,9 +39,11 @@ const (
 
 // Installation is an installation of EKSA Cilium components.
 type Installation struct {
-	DaemonSet *appsv1.DaemonSet
-	Operator  *appsv1.Deployment
-	ConfigMap *corev1.ConfigMap
+	DaemonSet   *appsv1.DaemonSet
+	Operator    *appsv1.Deployment
+	ConfigMap   *corev1.ConfigMap
+	HubbleRelay *appsv1.Deployment
+	HubbleUI    *appsv1.Deployment
 }
 
 // Installed determines if all EKS-A Embedded Cilium components are present. It identifies
@@ -61,7 +67,17 @@ func GetInstallation(ctx context.Context, client client.Client) (*Installation,
 		return nil, err
 	}
 
-	operator, err := getDeployment(ctx, client)
+	operator, err := getDeployment(ctx, client, DeploymentName)
+	if err != nil {
+		return nil, err
+	}
+
+	hubbleRelay, err := getDeployment(ctx, client, HubbleRelayDeploymentName)
+	if err != nil {
+		return nil, err
+	}
+
+	hubbleUI, err := getDeployment(ctx, client, HubbleUIDeploymentName)
 	if err != nil {
 		return nil, err
 	}
@@ -72,9 +88,11 @@ func GetInstallation(ctx context.Context, client client.Client) (*Installation,
 	}
 
 	return &Installation{
-		DaemonSet: ds,
-		Operator:  operator,
-		ConfigMap: cm,
+		DaemonSet:   ds,
+		Operator:    operator,
+		ConfigMap:   cm,
+		HubbleRelay: hubbleRelay,
+		HubbleUI:    hubbleUI,
 	}, nil
 }
 
@@ -104,10 +122,10 @@ func getConfigMap(ctx context.Context, client client.Client, name string, namesp
 	return c, nil
 }
 
-func getDeployment(ctx context.Context, client client.Client) (*appsv1.Deployment, error) {
+func getDeployment(ctx context.Context, client client.Client, name string) (*appsv1.Deployment, error) {
 	deployment := &appsv1.Deployment{}
 	key := types.NamespacedName{
-		Name:      DeploymentName,
+		Name:      name,
 		Namespace: constants.KubeSystemNamespace,
 	}
 	err := client.Get(ctx, key, deployment)
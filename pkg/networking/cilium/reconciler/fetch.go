@@ -5,6 +5,7 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -68,3 +69,17 @@ func getDaemonSet(ctx context.Context, client client.Client, name string) (*apps
 
 	return ds, nil
 }
+
+func deleteDeploymentIfExists(ctx context.Context, client client.Client, name string) error {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.KubeSystemNamespace,
+		},
+	}
+	if err := client.Delete(ctx, deployment); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
@@ -36,6 +36,21 @@ func (m *MockTemplater) EXPECT() *MockTemplaterMockRecorder {
 	return m.recorder
 }
 
+// GenerateBGPPeeringPolicyManifest mocks base method.
+func (m *MockTemplater) GenerateBGPPeeringPolicyManifest(spec *cluster.Spec) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateBGPPeeringPolicyManifest", spec)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateBGPPeeringPolicyManifest indicates an expected call of GenerateBGPPeeringPolicyManifest.
+func (mr *MockTemplaterMockRecorder) GenerateBGPPeeringPolicyManifest(spec interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateBGPPeeringPolicyManifest", reflect.TypeOf((*MockTemplater)(nil).GenerateBGPPeeringPolicyManifest), spec)
+}
+
 // GenerateManifest mocks base method.
 func (m *MockTemplater) GenerateManifest(ctx context.Context, spec *cluster.Spec, opts ...cilium.ManifestOpt) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -9,6 +9,10 @@ import (
 	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -30,14 +34,16 @@ const (
 )
 
 var (
-	serviceKind    = corev1.SchemeGroupVersion.WithKind("Service").GroupKind()
-	daemonSetKind  = appsv1.SchemeGroupVersion.WithKind("DaemonSet").GroupKind()
-	deploymentKind = appsv1.SchemeGroupVersion.WithKind("Deployment").GroupKind()
+	serviceKind         = corev1.SchemeGroupVersion.WithKind("Service").GroupKind()
+	daemonSetKind       = appsv1.SchemeGroupVersion.WithKind("DaemonSet").GroupKind()
+	deploymentKind      = appsv1.SchemeGroupVersion.WithKind("Deployment").GroupKind()
+	bgpPeeringPolicyGVK = schema.GroupVersionKind{Group: "cilium.io", Version: "v2alpha1", Kind: "CiliumBGPPeeringPolicy"}
 )
 
 type Templater interface {
 	GenerateUpgradePreflightManifest(ctx context.Context, spec *cluster.Spec) ([]byte, error)
 	GenerateManifest(ctx context.Context, spec *cluster.Spec, opts ...cilium.ManifestOpt) ([]byte, error)
+	GenerateBGPPeeringPolicyManifest(spec *cluster.Spec) ([]byte, error)
 }
 
 // Reconciler allows to reconcile a Cilium CNI.
@@ -107,7 +113,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, logger logr.Logger, client c
 
 		markCiliumInstalled(ctx, spec.Cluster)
 		conditions.MarkTrue(spec.Cluster, anywherev1.DefaultCNIConfiguredCondition)
-		return controller.Result{}, nil
+		return r.reconcileBGPPeeringPolicy(ctx, logger, client, spec)
 	}
 
 	if !ciliumCfg.IsManaged() {
@@ -140,6 +146,10 @@ func (r *Reconciler) Reconcile(ctx context.Context, logger logr.Logger, client c
 	// Upgrade process has run its course, and so we can now mark that the default cni has been configured.
 	conditions.MarkTrue(spec.Cluster, anywherev1.DefaultCNIConfiguredCondition)
 
+	if result, err := r.reconcileBGPPeeringPolicy(ctx, logger, client, spec); err != nil || result.Return() {
+		return result, err
+	}
+
 	return r.deletePreflightIfExists(ctx, client, spec)
 }
 
@@ -238,9 +248,55 @@ func (r *Reconciler) updateConfig(ctx context.Context, client client.Client, spe
 		return errors.Wrap(err, "updating cilium config")
 	}
 
+	// Server side apply doesn't remove the objects the chart stops rendering, so the
+	// Hubble deployments need to be deleted explicitly when disabled.
+	if hubble := spec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium.Hubble; hubble != nil {
+		if !hubble.Relay {
+			if err := deleteDeploymentIfExists(ctx, client, cilium.HubbleRelayDeploymentName); err != nil {
+				return errors.Wrap(err, "deleting hubble relay")
+			}
+		}
+		if !hubble.UI {
+			if err := deleteDeploymentIfExists(ctx, client, cilium.HubbleUIDeploymentName); err != nil {
+				return errors.Wrap(err, "deleting hubble ui")
+			}
+		}
+	}
+
 	return nil
 }
 
+// reconcileBGPPeeringPolicy applies the CiliumBGPPeeringPolicy when the BGP control plane is enabled
+// and deletes it otherwise. The policy CRD is created by the Cilium operator once it's running, so the
+// reconciliation is requeued until it's available.
+func (r *Reconciler) reconcileBGPPeeringPolicy(ctx context.Context, logger logr.Logger, c client.Client, spec *cluster.Spec) (controller.Result, error) {
+	policy, err := r.templater.GenerateBGPPeeringPolicyManifest(spec)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	if policy == nil {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(bgpPeeringPolicyGVK)
+		obj.SetName(cilium.BGPPeeringPolicyName)
+		if err := c.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+			return controller.Result{}, errors.Wrap(err, "deleting cilium BGP peering policy")
+		}
+		return controller.Result{}, nil
+	}
+
+	if err := serverside.ReconcileYaml(ctx, c, policy); meta.IsNoMatchError(err) {
+		logger.Info("Cilium BGP peering policy CRD is not available yet, requeueing")
+		return controller.Result{Result: &ctrl.Result{
+			RequeueAfter: defaultRequeueTime,
+		}}, nil
+	} else if err != nil {
+		return controller.Result{}, errors.Wrap(err, "applying cilium BGP peering policy")
+	}
+
+	return controller.Result{}, nil
+}
+
 func (r *Reconciler) applyFullManifest(ctx context.Context, client client.Client, spec *cluster.Spec) error {
 	upgradeManifest, err := r.templater.GenerateManifest(ctx, spec, cilium.WithPolicyAllowedNamespaces(r.providerNamespaces))
 	if err != nil {
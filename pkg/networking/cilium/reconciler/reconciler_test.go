@@ -17,6 +17,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/rand"
 	"sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util/conditions"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/yaml"
 
@@ -539,6 +540,61 @@ func TestReconcilerReconcileSkipUpgradeWithAnnotationWithCilium(t *testing.T) {
 	tt.expectDefaultCNIConfigured(defaultCNIConfiguredCondition("False", anywherev1.SkipUpgradesForDefaultCNIConfiguredReason, v1beta1.ConditionSeverityWarning, "Configured to skip default Cilium CNI upgrades"))
 }
 
+func TestReconcilerReconcileBGPPeeringPolicyCRDNotAvailable(t *testing.T) {
+	ds := ciliumDaemonSet()
+	operator := ciliumOperator()
+	cm := ciliumConfigMap()
+	tt := newReconcileTest(t).withObjects(ds, operator, cm)
+	tt.bgpPolicy = []byte(`apiVersion: cilium.io/v2alpha1
+kind: CiliumBGPPeeringPolicy
+metadata:
+  name: eksa-bgp-peering-policy
+spec:
+  virtualRouters:
+  - localASN: 64512
+    exportPodCIDR: false
+    neighbors:
+    - peerAddress: "10.0.0.1/32"
+      peerASN: 64513
+`)
+
+	tt.Expect(tt.reconciler.Reconcile(tt.ctx, test.NewNullLogger(), tt.client, tt.spec)).To(
+		Equal(controller.Result{Result: &ctrl.Result{RequeueAfter: 10 * time.Second}}),
+	)
+	tt.expectDefaultCNIConfigured(defaultCNIConfiguredCondition("True", "", "", ""))
+}
+
+func TestReconcilerReconcileBGPPeeringPolicyErrorFromTemplater(t *testing.T) {
+	ds := ciliumDaemonSet()
+	operator := ciliumOperator()
+	cm := ciliumConfigMap()
+	tt := newReconcileTest(t).withObjects(ds, operator, cm)
+	tt.templater = mocks.NewMockTemplater(gomock.NewController(t))
+	tt.reconciler = reconciler.New(tt.templater, providerNamespaces)
+	tt.templater.EXPECT().GenerateBGPPeeringPolicyManifest(tt.spec).Return(nil, errors.New("generating bgp policy"))
+
+	_, err := tt.reconciler.Reconcile(tt.ctx, test.NewNullLogger(), tt.client, tt.spec)
+	tt.Expect(err).To(MatchError(ContainSubstring("generating bgp policy")))
+}
+
+func TestReconcilerReconcileUpdateConfigDeletesDisabledHubbleRelay(t *testing.T) {
+	ds := ciliumDaemonSet()
+	operator := ciliumOperator()
+	cm := ciliumConfigMap()
+	cm.Data[cilium.HubbleConfigMapKey] = "true"
+	relay := hubbleRelay()
+	tt := newReconcileTest(t).withObjects(ds, operator, cm, relay)
+
+	tt.spec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium.Hubble = &anywherev1.CiliumHubbleConfig{}
+	tt.templater.EXPECT().GenerateManifest(tt.ctx, tt.spec, gomock.Not(gomock.Nil())).Return(tt.buildManifest(ds, operator, cm), nil)
+
+	tt.Expect(tt.reconciler.Reconcile(tt.ctx, test.NewNullLogger(), tt.client, tt.spec)).To(
+		Equal(controller.Result{}),
+	)
+	tt.expectDeploymentToNotExist(relay.Name, relay.Namespace)
+	tt.expectDefaultCNIConfigured(defaultCNIConfiguredCondition("True", "", "", ""))
+}
+
 type reconcileTest struct {
 	*WithT
 	t          *testing.T
@@ -548,6 +604,7 @@ type reconcileTest struct {
 	client     client.Client
 	templater  *mocks.MockTemplater
 	reconciler *reconciler.Reconciler
+	bgpPolicy  []byte
 }
 
 func newReconcileTest(t *testing.T) *reconcileTest {
@@ -573,6 +630,10 @@ func newReconcileTest(t *testing.T) *reconcileTest {
 		reconciler: reconciler.New(templater, providerNamespaces),
 	}
 
+	templater.EXPECT().GenerateBGPPeeringPolicyManifest(gomock.Any()).DoAndReturn(func(_ *cluster.Spec) ([]byte, error) {
+		return tt.bgpPolicy, nil
+	}).AnyTimes()
+
 	t.Cleanup(tt.cleanup)
 
 	return tt
@@ -753,6 +814,10 @@ func ciliumConfigMap() *corev1.ConfigMap {
 	return simpleConfigMap(cilium.ConfigMapName, "default")
 }
 
+func hubbleRelay() *appsv1.Deployment {
+	return simpleDeployment(cilium.HubbleRelayDeploymentName, "hubble-relay:1.10.1-eksa-1")
+}
+
 func ciliumPreflightDaemonSet() *appsv1.DaemonSet {
 	return simpleDaemonSet(cilium.PreflightDaemonSetName, "cilium-pre-flight-check:1.10.1-eksa-1")
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	_ "embed"
 	"fmt"
+	"net"
 	"strings"
 	"time"
 
@@ -19,12 +20,21 @@ import (
 //go:embed network_policy.yaml
 var networkPolicyAllowAll string
 
+//go:embed bgp_peering_policy.yaml
+var bgpPeeringPolicy string
+
 const (
 	maxRetries           = 10
 	defaultBackOffPeriod = 5 * time.Second
 	namespace            = constants.KubeSystemNamespace
+	apiServerPort        = 6443
+
+	// DefaultIPsecSecretName is the Secret holding the IPsec keys when none is configured.
+	DefaultIPsecSecretName = "cilium-ipsec-keys"
 )
 
+var ciliumVersionWithBoolKubeProxyReplacement = &semver.Version{Major: 1, Minor: 14}
+
 // HelmClientFactory provides a helm client for a cluster.
 type HelmClientFactory interface {
 	Get(ctx context.Context, clus *anywherev1.Cluster) (helm.Client, error)
@@ -177,6 +187,45 @@ func (t *Templater) GenerateNetworkPolicyManifest(spec *cluster.Spec, namespaces
 	return templater.Execute(networkPolicyAllowAll, values)
 }
 
+// BGPPeeringPolicyName is the name of the CiliumBGPPeeringPolicy generated from the cluster spec.
+const BGPPeeringPolicyName = "eksa-bgp-peering-policy"
+
+// GenerateBGPPeeringPolicyManifest generates the CiliumBGPPeeringPolicy for the BGP control plane
+// configured in the cluster spec. It returns nil if the BGP control plane is not enabled.
+// The policy can only be applied once the Cilium operator has created its CRD, so it's not part
+// of the manifest returned by GenerateManifest.
+func (t *Templater) GenerateBGPPeeringPolicyManifest(spec *cluster.Spec) ([]byte, error) {
+	bgp := spec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium.BGPControlPlane
+	if bgp == nil {
+		return nil, nil
+	}
+
+	peers := make([]map[string]interface{}, 0, len(bgp.Peers))
+	for _, p := range bgp.Peers {
+		peers = append(peers, map[string]interface{}{
+			"address": peerAddressCIDR(p.Address),
+			"asn":     p.ASN,
+		})
+	}
+
+	values := map[string]interface{}{
+		"name":          BGPPeeringPolicyName,
+		"nodeSelector":  bgp.NodeSelector,
+		"localASN":      bgp.LocalASN,
+		"exportPodCIDR": bgp.ExportPodCIDR,
+		"peers":         peers,
+	}
+
+	return templater.Execute(bgpPeeringPolicy, values)
+}
+
+func peerAddressCIDR(address string) string {
+	if ip := net.ParseIP(address); ip != nil && ip.To4() == nil {
+		return address + "/128"
+	}
+	return address + "/32"
+}
+
 type values map[string]interface{}
 
 func (c values) set(value interface{}, path ...string) {
@@ -236,6 +285,31 @@ func templateValues(spec *cluster.Spec, versionsBundle *cluster.VersionsBundle)
 		val["egressMasqueradeInterfaces"] = spec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium.EgressMasqueradeInterfaces
 	}
 
+	switch spec.Cluster.Spec.ClusterNetwork.IPFamily() {
+	case anywherev1.IPv6:
+		val["ipv4"] = values{"enabled": false}
+		val["ipv6"] = values{"enabled": true}
+	case anywherev1.DualStack:
+		val["ipv4"] = values{"enabled": true}
+		val["ipv6"] = values{"enabled": true}
+	}
+
+	setHubbleValues(val, spec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium.Hubble)
+	setEncryptionValues(val, spec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium.Encryption)
+
+	if spec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium.BGPControlPlane != nil {
+		val["bgpControlPlane"] = values{"enabled": true}
+	}
+
+	if spec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium.KubeProxyReplacement {
+		val["kubeProxyReplacement"] = kubeProxyReplacementValue(versionsBundle)
+		// Without kube-proxy, the agents can't rely on the kubernetes service to reach the API server.
+		if endpoint := spec.Cluster.Spec.ControlPlaneConfiguration.Endpoint; endpoint != nil && endpoint.Host != "" {
+			val["k8sServiceHost"] = endpoint.Host
+			val["k8sServicePort"] = apiServerPort
+		}
+	}
+
 	if spec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium.RoutingMode == anywherev1.CiliumRoutingModeDirect {
 		val["routing-mode"] = "native"
 		delete(val, "tunnel-protocol")
@@ -252,6 +326,46 @@ func templateValues(spec *cluster.Spec, versionsBundle *cluster.VersionsBundle)
 	return val
 }
 
+func setHubbleValues(val values, hubble *anywherev1.CiliumHubbleConfig) {
+	if hubble == nil {
+		return
+	}
+
+	val.set(true, "hubble", "enabled")
+	val.set(hubble.Relay, "hubble", "relay", "enabled")
+	val.set(hubble.UI, "hubble", "ui", "enabled")
+}
+
+func setEncryptionValues(val values, encryption *anywherev1.CiliumEncryptionConfig) {
+	if encryption == nil {
+		return
+	}
+
+	val.set(true, "encryption", "enabled")
+	val.set(string(encryption.Type), "encryption", "type")
+	switch encryption.Type {
+	case anywherev1.CiliumEncryptionWireGuard:
+		val.set(encryption.NodeEncryption, "encryption", "nodeEncryption")
+	case anywherev1.CiliumEncryptionIPsec:
+		secretName := encryption.IPsecSecretName
+		if secretName == "" {
+			secretName = DefaultIPsecSecretName
+		}
+		val.set(secretName, "encryption", "ipsec", "secretName")
+	}
+}
+
+// kubeProxyReplacementValue returns the chart value to enable the kube-proxy replacement.
+// Cilium 1.14 replaced the strict/partial modes with a boolean.
+func kubeProxyReplacementValue(versionsBundle *cluster.VersionsBundle) string {
+	ciliumVersion, err := semver.New(versionsBundle.Cilium.Cilium.Tag())
+	if err != nil || ciliumVersion.LessThan(ciliumVersionWithBoolKubeProxyReplacement) {
+		return "strict"
+	}
+
+	return "true"
+}
+
 func getChartURIAndVersion(versionsBundle *cluster.VersionsBundle) (uri, version string) {
 	chart := versionsBundle.Cilium.HelmChart
 	uri = fmt.Sprintf("oci://%s", chart.Image())
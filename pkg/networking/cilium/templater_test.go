@@ -380,6 +380,71 @@ func TestTemplaterGenerateManifestDirectModeManualIPCIDRSuccess(t *testing.T) {
 	tt.Expect(tt.t.GenerateManifest(tt.ctx, tt.spec)).To(Equal(tt.manifest), "templater.GenerateManifest() should return right manifest")
 }
 
+func TestTemplaterGenerateManifestIPFamiliesSuccess(t *testing.T) {
+	tests := []struct {
+		name      string
+		podCIDRs  []string
+		ipv4Value bool
+	}{
+		{
+			name:      "ipv6",
+			podCIDRs:  []string{"fd00:10:244::/56"},
+			ipv4Value: false,
+		},
+		{
+			name:      "dual-stack",
+			podCIDRs:  []string{"192.168.0.0/16", "fd00:10:244::/56"},
+			ipv4Value: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			wantValues := map[string]interface{}{
+				"cni": map[string]interface{}{
+					"chainingMode": "portmap",
+				},
+				"ipam": map[string]interface{}{
+					"mode": "kubernetes",
+				},
+				"identityAllocationMode": "crd",
+				"prometheus": map[string]interface{}{
+					"enabled": true,
+				},
+				"rollOutCiliumPods": true,
+				"routing-mode":      "tunnel",
+				"tunnel-protocol":   "geneve",
+				"ipv4": map[string]interface{}{
+					"enabled": tc.ipv4Value,
+				},
+				"ipv6": map[string]interface{}{
+					"enabled": true,
+				},
+				"image": map[string]interface{}{
+					"repository": "public.ecr.aws/isovalent/cilium",
+					"tag":        "v1.9.11-eksa.1",
+				},
+				"operator": map[string]interface{}{
+					"image": map[string]interface{}{
+						"repository": "public.ecr.aws/isovalent/operator",
+						"tag":        "v1.9.11-eksa.1",
+					},
+					"prometheus": map[string]interface{}{
+						"enabled": true,
+					},
+				},
+			}
+
+			tt := newtemplaterTest(t)
+			tt.spec.Cluster.Spec.ManagementCluster.Name = "managed"
+			tt.spec.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks = tc.podCIDRs
+			tt.expectHelmClientFactoryGet("", "")
+			tt.expectHelmTemplateWith(eqMap(wantValues), "1.22").Return(tt.manifest, nil)
+
+			tt.Expect(tt.t.GenerateManifest(tt.ctx, tt.spec)).To(Equal(tt.manifest), "templater.GenerateManifest() should return right manifest")
+		})
+	}
+}
+
 func TestTemplaterGenerateManifestError(t *testing.T) {
 	expectedAttempts := 2
 	tt := newtemplaterTest(t)
@@ -584,3 +649,123 @@ func TestTemplaterGenerateManifestForRegistryAuth(t *testing.T) {
 
 	tt.Expect(tt.t.GenerateManifest(tt.ctx, tt.spec)).To(Equal(tt.manifest), "templater.GenerateManifest() should return right manifest")
 }
+
+func TestTemplaterGenerateManifestHubbleEncryptionBGPSuccess(t *testing.T) {
+	wantValues := wantDefaultValues()
+	wantValues["hubble"] = map[string]interface{}{
+		"enabled": true,
+		"relay": map[string]interface{}{
+			"enabled": true,
+		},
+		"ui": map[string]interface{}{
+			"enabled": false,
+		},
+	}
+	wantValues["encryption"] = map[string]interface{}{
+		"enabled":        true,
+		"type":           "wireguard",
+		"nodeEncryption": true,
+	}
+	wantValues["bgpControlPlane"] = map[string]interface{}{
+		"enabled": true,
+	}
+
+	tt := newtemplaterTest(t)
+	tt.spec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium = &v1alpha1.CiliumConfig{
+		Hubble:     &v1alpha1.CiliumHubbleConfig{Relay: true},
+		Encryption: &v1alpha1.CiliumEncryptionConfig{Type: v1alpha1.CiliumEncryptionWireGuard, NodeEncryption: true},
+		BGPControlPlane: &v1alpha1.CiliumBGPControlPlaneConfig{
+			LocalASN: 64512,
+			Peers:    []v1alpha1.CiliumBGPPeer{{Address: "10.0.0.1", ASN: 64513}},
+		},
+	}
+	tt.expectHelmClientFactoryGet("", "")
+	tt.expectHelmTemplateWith(eqMap(wantValues), "1.22").Return(tt.manifest, nil)
+
+	tt.Expect(tt.t.GenerateManifest(tt.ctx, tt.spec)).To(Equal(tt.manifest), "templater.GenerateManifest() should return right manifest")
+}
+
+func TestTemplaterGenerateManifestIPsecDefaultSecretSuccess(t *testing.T) {
+	wantValues := wantDefaultValues()
+	wantValues["encryption"] = map[string]interface{}{
+		"enabled": true,
+		"type":    "ipsec",
+		"ipsec": map[string]interface{}{
+			"secretName": "cilium-ipsec-keys",
+		},
+	}
+
+	tt := newtemplaterTest(t)
+	tt.spec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium.Encryption = &v1alpha1.CiliumEncryptionConfig{Type: v1alpha1.CiliumEncryptionIPsec}
+	tt.expectHelmClientFactoryGet("", "")
+	tt.expectHelmTemplateWith(eqMap(wantValues), "1.22").Return(tt.manifest, nil)
+
+	tt.Expect(tt.t.GenerateManifest(tt.ctx, tt.spec)).To(Equal(tt.manifest), "templater.GenerateManifest() should return right manifest")
+}
+
+func TestTemplaterGenerateManifestKubeProxyReplacementSuccess(t *testing.T) {
+	tests := []struct {
+		name      string
+		ciliumTag string
+		wantValue string
+	}{
+		{
+			name:      "strict before 1.14",
+			ciliumTag: "v1.13.9-eksa.1",
+			wantValue: "strict",
+		},
+		{
+			name:      "boolean from 1.14",
+			ciliumTag: "v1.14.6-eksa.1",
+			wantValue: "true",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tt := newtemplaterTest(t)
+			tt.spec.VersionsBundles["1.22"].Cilium.Cilium.URI = "public.ecr.aws/isovalent/cilium:" + tc.ciliumTag
+			tt.spec.Cluster.Spec.ControlPlaneConfiguration.Endpoint = &v1alpha1.Endpoint{Host: "1.2.3.4"}
+			tt.spec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium.KubeProxyReplacement = true
+
+			wantValues := wantDefaultValues()
+			wantValues["image"].(map[string]interface{})["tag"] = tc.ciliumTag
+			wantValues["kubeProxyReplacement"] = tc.wantValue
+			wantValues["k8sServiceHost"] = "1.2.3.4"
+			wantValues["k8sServicePort"] = float64(6443)
+
+			tt.expectHelmClientFactoryGet("", "")
+			tt.expectHelmTemplateWith(eqMap(wantValues), "1.22").Return(tt.manifest, nil)
+
+			tt.Expect(tt.t.GenerateManifest(tt.ctx, tt.spec)).To(Equal(tt.manifest), "templater.GenerateManifest() should return right manifest")
+		})
+	}
+}
+
+func TestTemplaterGenerateBGPPeeringPolicyManifest(t *testing.T) {
+	tt := newtemplaterTest(t)
+	tt.spec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium.BGPControlPlane = &v1alpha1.CiliumBGPControlPlaneConfig{
+		LocalASN:      64512,
+		NodeSelector:  map[string]string{"rack": "rack0", "bgp": "enabled"},
+		ExportPodCIDR: true,
+		Peers: []v1alpha1.CiliumBGPPeer{
+			{Address: "10.0.0.1", ASN: 64513},
+			{Address: "fd00::1", ASN: 64514},
+		},
+	}
+
+	manifest, err := tt.t.GenerateBGPPeeringPolicyManifest(tt.spec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	test.AssertContentToFile(t, string(manifest), "testdata/bgp_peering_policy.yaml")
+}
+
+func TestTemplaterGenerateBGPPeeringPolicyManifestDisabled(t *testing.T) {
+	tt := newtemplaterTest(t)
+
+	tt.Expect(tt.t.GenerateBGPPeeringPolicyManifest(tt.spec)).To(BeNil())
+}
+
+func wantDefaultValues() map[string]interface{} {
+	values := wantUpgradeValues()
+	delete(values, "upgradeCompatibility")
+	return values
+}
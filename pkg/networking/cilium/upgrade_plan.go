@@ -7,6 +7,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/types"
 )
@@ -27,6 +28,51 @@ const (
 	// EgressMasqueradeInterfacesComponentName is the ConfigComponentUpdatePlan name for the
 	// egressMasqueradeInterfaces configuration component.
 	EgressMasqueradeInterfacesComponentName = "EgressMasqueradeInterfaces"
+
+	// HubbleConfigMapKey is the key used in the "cilium-config" ConfigMap to store if Hubble is enabled.
+	HubbleConfigMapKey = "enable-hubble"
+
+	// HubbleComponentName is the ConfigComponentUpdatePlan name for the Hubble configuration component.
+	HubbleComponentName = "Hubble"
+
+	// HubbleRelayComponentName is the ConfigComponentUpdatePlan name for the Hubble Relay deployment.
+	HubbleRelayComponentName = "HubbleRelay"
+
+	// HubbleUIComponentName is the ConfigComponentUpdatePlan name for the Hubble UI deployment.
+	HubbleUIComponentName = "HubbleUI"
+
+	// WireGuardConfigMapKey is the key used in the "cilium-config" ConfigMap to store if
+	// WireGuard encryption is enabled.
+	WireGuardConfigMapKey = "enable-wireguard"
+
+	// IPsecConfigMapKey is the key used in the "cilium-config" ConfigMap to store if
+	// IPsec encryption is enabled.
+	IPsecConfigMapKey = "enable-ipsec"
+
+	// EncryptionComponentName is the ConfigComponentUpdatePlan name for the encryption configuration component.
+	EncryptionComponentName = "Encryption"
+
+	// NodeEncryptionConfigMapKey is the key used in the "cilium-config" ConfigMap to store if
+	// node to node traffic is encrypted.
+	NodeEncryptionConfigMapKey = "encrypt-node"
+
+	// NodeEncryptionComponentName is the ConfigComponentUpdatePlan name for the node encryption configuration component.
+	NodeEncryptionComponentName = "NodeEncryption"
+
+	// BGPControlPlaneConfigMapKey is the key used in the "cilium-config" ConfigMap to store if
+	// the BGP control plane is enabled.
+	BGPControlPlaneConfigMapKey = "enable-bgp-control-plane"
+
+	// BGPControlPlaneComponentName is the ConfigComponentUpdatePlan name for the BGP control plane configuration component.
+	BGPControlPlaneComponentName = "BGPControlPlane"
+
+	// KubeProxyReplacementConfigMapKey is the key used in the "cilium-config" ConfigMap to store
+	// the kube-proxy replacement mode.
+	KubeProxyReplacementConfigMapKey = "kube-proxy-replacement"
+
+	// KubeProxyReplacementComponentName is the ConfigComponentUpdatePlan name for the kube-proxy
+	// replacement configuration component.
+	KubeProxyReplacementComponentName = "KubeProxyReplacement"
 )
 
 // UpgradePlan contains information about a Cilium installation upgrade.
@@ -135,10 +181,14 @@ type ConfigComponentUpdatePlan struct {
 // BuildUpgradePlan generates the upgrade plan information for a cilium installation by comparing it
 // with a desired cluster Spec.
 func BuildUpgradePlan(installation *Installation, clusterSpec *cluster.Spec) UpgradePlan {
+	configPlan := configMapUpgradePlan(installation.ConfigMap, clusterSpec)
+	configPlan.Components = append(configPlan.Components, hubbleDeploymentsUpdatePlan(installation, clusterSpec)...)
+	configPlan.generateUpdateReasonFromComponents()
+
 	return UpgradePlan{
 		DaemonSet: daemonSetUpgradePlan(installation.DaemonSet, clusterSpec),
 		Operator:  operatorUpgradePlan(installation.Operator, clusterSpec),
-		ConfigMap: configMapUpgradePlan(installation.ConfigMap, clusterSpec),
+		ConfigMap: configPlan,
 	}
 }
 
@@ -246,12 +296,108 @@ func configMapUpgradePlan(configMap *corev1.ConfigMap, clusterSpec *cluster.Spec
 	}
 
 	updatePlan.Components = append(updatePlan.Components, egressMasqueradeUpdate)
+	updatePlan.Components = append(updatePlan.Components, featuresUpdatePlan(configMap, clusterSpec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium)...)
 
 	updatePlan.generateUpdateReasonFromComponents()
 
 	return *updatePlan
 }
 
+// featuresUpdatePlan compares the optional Cilium features with the values in the Cilium config.
+// A feature is only included in the plan if it's enabled either in the config or the cluster spec,
+// so installations that don't use them are not affected.
+func featuresUpdatePlan(configMap *corev1.ConfigMap, ciliumCfg *anywherev1.CiliumConfig) []ConfigComponentUpdatePlan {
+	var data map[string]string
+	if configMap != nil {
+		data = configMap.Data
+	}
+
+	var oldEncryption, newEncryption string
+	switch {
+	case data[WireGuardConfigMapKey] == "true":
+		oldEncryption = string(anywherev1.CiliumEncryptionWireGuard)
+	case data[IPsecConfigMapKey] == "true":
+		oldEncryption = string(anywherev1.CiliumEncryptionIPsec)
+	}
+	if ciliumCfg.Encryption != nil {
+		newEncryption = string(ciliumCfg.Encryption.Type)
+	}
+
+	oldKubeProxyReplacement := data[KubeProxyReplacementConfigMapKey] == "strict" || data[KubeProxyReplacementConfigMapKey] == "true"
+
+	components := []ConfigComponentUpdatePlan{
+		featureUpdatePlan(configMap, EncryptionComponentName, oldEncryption, newEncryption),
+		featureUpdatePlan(configMap, NodeEncryptionComponentName, enabledValue(data[NodeEncryptionConfigMapKey] == "true"), enabledValue(ciliumCfg.Encryption != nil && ciliumCfg.Encryption.NodeEncryption)),
+		featureUpdatePlan(configMap, BGPControlPlaneComponentName, enabledValue(data[BGPControlPlaneConfigMapKey] == "true"), enabledValue(ciliumCfg.BGPControlPlane != nil)),
+		featureUpdatePlan(configMap, KubeProxyReplacementComponentName, enabledValue(oldKubeProxyReplacement), enabledValue(ciliumCfg.KubeProxyReplacement)),
+	}
+
+	// The chart enables Hubble by default, so it's only compared when explicitly configured.
+	if ciliumCfg.Hubble != nil {
+		components = append(components, featureUpdatePlan(configMap, HubbleComponentName, enabledValue(data[HubbleConfigMapKey] == "true"), enabledValue(true)))
+	}
+
+	plans := make([]ConfigComponentUpdatePlan, 0, len(components))
+	for _, c := range components {
+		if c.OldValue != "" || c.NewValue != "" {
+			plans = append(plans, c)
+		}
+	}
+
+	return plans
+}
+
+// hubbleDeploymentsUpdatePlan compares the Hubble Relay and UI deployments present in the cluster
+// with the ones configured in the cluster spec.
+func hubbleDeploymentsUpdatePlan(installation *Installation, clusterSpec *cluster.Spec) []ConfigComponentUpdatePlan {
+	hubble := clusterSpec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium.Hubble
+	if hubble == nil {
+		return nil
+	}
+
+	return []ConfigComponentUpdatePlan{
+		deploymentUpdatePlan(HubbleRelayComponentName, installation.HubbleRelay != nil, hubble.Relay),
+		deploymentUpdatePlan(HubbleUIComponentName, installation.HubbleUI != nil, hubble.UI),
+	}
+}
+
+func featureUpdatePlan(configMap *corev1.ConfigMap, name, oldValue, newValue string) ConfigComponentUpdatePlan {
+	plan := ConfigComponentUpdatePlan{
+		Name:     name,
+		OldValue: oldValue,
+		NewValue: newValue,
+	}
+
+	if configMap != nil && oldValue != newValue {
+		plan.UpdateReason = fmt.Sprintf("Cilium %s changed: [%s] -> [%s]", name, oldValue, newValue)
+	}
+
+	return plan
+}
+
+func deploymentUpdatePlan(name string, installed, enabled bool) ConfigComponentUpdatePlan {
+	plan := ConfigComponentUpdatePlan{
+		Name:     name,
+		OldValue: enabledValue(installed),
+		NewValue: enabledValue(enabled),
+	}
+
+	if installed != enabled {
+		plan.UpdateReason = fmt.Sprintf("Cilium %s changed: [%s] -> [%s]", name, plan.OldValue, plan.NewValue)
+	}
+
+	return plan
+}
+
+// enabledValue returns the value used in the update plan for a boolean feature.
+// Disabled features have an empty value.
+func enabledValue(enabled bool) string {
+	if enabled {
+		return "true"
+	}
+	return ""
+}
+
 // ChangeDiff returns the change diff between the current and new cluster specs.
 func ChangeDiff(currentSpec, newSpec *cluster.Spec) *types.ChangeDiff {
 	return ciliumChangeDiff(currentSpec, newSpec)
@@ -261,6 +407,10 @@ func ciliumChangeDiff(currentSpec, newSpec *cluster.Spec) *types.ChangeDiff {
 	currentVersionsBundle := currentSpec.RootVersionsBundle()
 
 	newCiliumCfg := newSpec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium
+	if newCiliumCfg == nil {
+		return nil
+	}
+
 	if !newCiliumCfg.IsManaged() {
 		return &types.ChangeDiff{
 			ComponentReports: []types.ComponentChangeDiff{
@@ -502,6 +502,134 @@ func TestBuildUpgradePlan(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "encryption, bgp and kube-proxy replacement enabled",
+			installation: &cilium.Installation{
+				DaemonSet: daemonSet("cilium:v1.0.0"),
+				Operator:  deployment("cilium-operator:v1.0.0"),
+				ConfigMap: ciliumConfigMap("default", "", func(cm *corev1.ConfigMap) {
+					cm.Data[cilium.IPsecConfigMapKey] = "true"
+					cm.Data[cilium.KubeProxyReplacementConfigMapKey] = "strict"
+				}),
+			},
+			clusterSpec: test.NewClusterSpec(func(s *cluster.Spec) {
+				s.VersionsBundles["1.19"].Cilium.Cilium.URI = "cilium:v1.0.0"
+				s.VersionsBundles["1.19"].Cilium.Operator.URI = "cilium-operator:v1.0.0"
+				s.Cluster.Spec.ClusterNetwork.CNIConfig = &anywherev1.CNIConfig{
+					Cilium: &anywherev1.CiliumConfig{
+						Encryption: &anywherev1.CiliumEncryptionConfig{
+							Type:           anywherev1.CiliumEncryptionWireGuard,
+							NodeEncryption: true,
+						},
+						BGPControlPlane: &anywherev1.CiliumBGPControlPlaneConfig{
+							LocalASN: 64512,
+							Peers:    []anywherev1.CiliumBGPPeer{{Address: "10.0.0.1", ASN: 64513}},
+						},
+						KubeProxyReplacement: true,
+					},
+				}
+			}),
+			want: cilium.UpgradePlan{
+				DaemonSet: cilium.VersionedComponentUpgradePlan{
+					OldImage: "cilium:v1.0.0",
+					NewImage: "cilium:v1.0.0",
+				},
+				Operator: cilium.VersionedComponentUpgradePlan{
+					OldImage: "cilium-operator:v1.0.0",
+					NewImage: "cilium-operator:v1.0.0",
+				},
+				ConfigMap: cilium.ConfigUpdatePlan{
+					UpdateReason: "Cilium Encryption changed: [ipsec] -> [wireguard] - Cilium NodeEncryption changed: [] -> [true] - Cilium BGPControlPlane changed: [] -> [true]",
+					Components: []cilium.ConfigComponentUpdatePlan{
+						{
+							Name:     cilium.PolicyEnforcementComponentName,
+							OldValue: "default",
+							NewValue: "default",
+						},
+						{
+							Name: cilium.EgressMasqueradeInterfacesComponentName,
+						},
+						{
+							Name:         cilium.EncryptionComponentName,
+							OldValue:     "ipsec",
+							NewValue:     "wireguard",
+							UpdateReason: "Cilium Encryption changed: [ipsec] -> [wireguard]",
+						},
+						{
+							Name:         cilium.NodeEncryptionComponentName,
+							NewValue:     "true",
+							UpdateReason: "Cilium NodeEncryption changed: [] -> [true]",
+						},
+						{
+							Name:         cilium.BGPControlPlaneComponentName,
+							NewValue:     "true",
+							UpdateReason: "Cilium BGPControlPlane changed: [] -> [true]",
+						},
+						{
+							Name:     cilium.KubeProxyReplacementComponentName,
+							OldValue: "true",
+							NewValue: "true",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "hubble relay disabled",
+			installation: &cilium.Installation{
+				DaemonSet: daemonSet("cilium:v1.0.0"),
+				Operator:  deployment("cilium-operator:v1.0.0"),
+				ConfigMap: ciliumConfigMap("default", "", func(cm *corev1.ConfigMap) {
+					cm.Data[cilium.HubbleConfigMapKey] = "true"
+				}),
+				HubbleRelay: deployment("hubble-relay:v1.0.0"),
+			},
+			clusterSpec: test.NewClusterSpec(func(s *cluster.Spec) {
+				s.VersionsBundles["1.19"].Cilium.Cilium.URI = "cilium:v1.0.0"
+				s.VersionsBundles["1.19"].Cilium.Operator.URI = "cilium-operator:v1.0.0"
+				s.Cluster.Spec.ClusterNetwork.CNIConfig = &anywherev1.CNIConfig{
+					Cilium: &anywherev1.CiliumConfig{
+						Hubble: &anywherev1.CiliumHubbleConfig{},
+					},
+				}
+			}),
+			want: cilium.UpgradePlan{
+				DaemonSet: cilium.VersionedComponentUpgradePlan{
+					OldImage: "cilium:v1.0.0",
+					NewImage: "cilium:v1.0.0",
+				},
+				Operator: cilium.VersionedComponentUpgradePlan{
+					OldImage: "cilium-operator:v1.0.0",
+					NewImage: "cilium-operator:v1.0.0",
+				},
+				ConfigMap: cilium.ConfigUpdatePlan{
+					UpdateReason: "Cilium HubbleRelay changed: [true] -> []",
+					Components: []cilium.ConfigComponentUpdatePlan{
+						{
+							Name:     cilium.PolicyEnforcementComponentName,
+							OldValue: "default",
+							NewValue: "default",
+						},
+						{
+							Name: cilium.EgressMasqueradeInterfacesComponentName,
+						},
+						{
+							Name:     cilium.HubbleComponentName,
+							OldValue: "true",
+							NewValue: "true",
+						},
+						{
+							Name:         cilium.HubbleRelayComponentName,
+							OldValue:     "true",
+							UpdateReason: "Cilium HubbleRelay changed: [true] -> []",
+						},
+						{
+							Name: cilium.HubbleUIComponentName,
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -895,6 +1023,22 @@ func TestChangeDiff(t *testing.T) {
 			}),
 			want: nil,
 		},
+		{
+			name: "custom cni",
+			currentSpec: test.NewClusterSpec(func(s *cluster.Spec) {
+				s.Cluster.Spec.KubernetesVersion = "1.22"
+				s.VersionsBundles["1.22"] = test.VersionBundle()
+				s.VersionsBundles["1.22"].Cilium.Version = "v1.9.10-eksa.1"
+				s.Cluster.Spec.ClusterNetwork.CNIConfig = &v1alpha1.CNIConfig{Custom: &v1alpha1.CustomCNIConfig{}}
+			}),
+			newSpec: test.NewClusterSpec(func(s *cluster.Spec) {
+				s.Cluster.Spec.KubernetesVersion = "1.22"
+				s.VersionsBundles["1.22"] = test.VersionBundle()
+				s.VersionsBundles["1.22"].Cilium.Version = "v1.13.5-eksa.1"
+				s.Cluster.Spec.ClusterNetwork.CNIConfig = &v1alpha1.CNIConfig{Custom: &v1alpha1.CustomCNIConfig{}}
+			}),
+			want: nil,
+		},
 		{
 			name: "version change",
 			currentSpec: test.NewClusterSpec(func(s *cluster.Spec) {
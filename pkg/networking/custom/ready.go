@@ -0,0 +1,81 @@
+package custom
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+// CheckReady verifies all the workloads in the readiness check are ready.
+// A nil readiness check is always ready.
+func CheckReady(ctx context.Context, c client.Client, check *anywherev1.CustomCNIReadinessCheck) error {
+	if check == nil {
+		return nil
+	}
+
+	for _, w := range check.DaemonSets {
+		ds := &appsv1.DaemonSet{}
+		if err := getWorkload(ctx, c, "daemonSet", w, ds); err != nil {
+			return err
+		}
+		if err := checkDaemonSetReady(ds); err != nil {
+			return err
+		}
+	}
+
+	for _, w := range check.Deployments {
+		deployment := &appsv1.Deployment{}
+		if err := getWorkload(ctx, c, "deployment", w, deployment); err != nil {
+			return err
+		}
+		if err := checkDeploymentReady(deployment); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func getWorkload(ctx context.Context, c client.Client, kind string, w anywherev1.CustomCNIWorkload, obj client.Object) error {
+	err := c.Get(ctx, types.NamespacedName{Name: w.Name, Namespace: w.Namespace}, obj)
+	if apierrors.IsNotFound(err) {
+		return fmt.Errorf("%s %s/%s doesn't exist", kind, w.Namespace, w.Name)
+	}
+	if err != nil {
+		return fmt.Errorf("reading %s %s/%s: %v", kind, w.Namespace, w.Name, err)
+	}
+
+	return nil
+}
+
+func checkDaemonSetReady(ds *appsv1.DaemonSet) error {
+	if ds.Status.ObservedGeneration != ds.Generation {
+		return fmt.Errorf("daemonSet %s/%s status needs to be refreshed: observed generation is %d, want %d", ds.Namespace, ds.Name, ds.Status.ObservedGeneration, ds.Generation)
+	}
+
+	desired := ds.Status.DesiredNumberScheduled
+	if ds.Status.UpdatedNumberScheduled != desired || ds.Status.NumberReady != desired {
+		return fmt.Errorf("daemonSet %s/%s is not ready: %d/%d updated, %d/%d ready", ds.Namespace, ds.Name, ds.Status.UpdatedNumberScheduled, desired, ds.Status.NumberReady, desired)
+	}
+
+	return nil
+}
+
+func checkDeploymentReady(deployment *appsv1.Deployment) error {
+	if deployment.Status.ObservedGeneration != deployment.Generation {
+		return fmt.Errorf("deployment %s/%s status needs to be refreshed: observed generation is %d, want %d", deployment.Namespace, deployment.Name, deployment.Status.ObservedGeneration, deployment.Generation)
+	}
+
+	replicas := deployment.Status.Replicas
+	if deployment.Status.UpdatedReplicas != replicas || deployment.Status.ReadyReplicas != replicas {
+		return fmt.Errorf("deployment %s/%s is not ready: %d/%d updated, %d/%d ready", deployment.Namespace, deployment.Name, deployment.Status.UpdatedReplicas, replicas, deployment.Status.ReadyReplicas, replicas)
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package custom_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/networking/custom"
+)
+
+func TestCheckReady(t *testing.T) {
+	tests := []struct {
+		name    string
+		objs    []client.Object
+		check   *anywherev1.CustomCNIReadinessCheck
+		wantErr string
+	}{
+		{
+			name:  "nil check",
+			check: nil,
+		},
+		{
+			name: "all ready",
+			objs: []client.Object{
+				daemonSet(1, 1, 3, 3, 3),
+				deployment(2, 2, 2, 2, 2),
+			},
+			check: readinessCheck(),
+		},
+		{
+			name: "daemonset missing",
+			objs: []client.Object{
+				deployment(1, 1, 1, 1, 1),
+			},
+			check:   readinessCheck(),
+			wantErr: "daemonSet kube-system/my-cni-node doesn't exist",
+		},
+		{
+			name: "daemonset status outdated",
+			objs: []client.Object{
+				daemonSet(2, 1, 3, 3, 3),
+				deployment(1, 1, 1, 1, 1),
+			},
+			check:   readinessCheck(),
+			wantErr: "daemonSet kube-system/my-cni-node status needs to be refreshed: observed generation is 1, want 2",
+		},
+		{
+			name: "daemonset not ready",
+			objs: []client.Object{
+				daemonSet(1, 1, 3, 3, 2),
+				deployment(1, 1, 1, 1, 1),
+			},
+			check:   readinessCheck(),
+			wantErr: "daemonSet kube-system/my-cni-node is not ready: 3/3 updated, 2/3 ready",
+		},
+		{
+			name: "deployment missing",
+			objs: []client.Object{
+				daemonSet(1, 1, 3, 3, 3),
+			},
+			check:   readinessCheck(),
+			wantErr: "deployment kube-system/my-cni-controller doesn't exist",
+		},
+		{
+			name: "deployment not updated",
+			objs: []client.Object{
+				daemonSet(1, 1, 3, 3, 3),
+				deployment(1, 1, 2, 1, 2),
+			},
+			check:   readinessCheck(),
+			wantErr: "deployment kube-system/my-cni-controller is not ready: 1/2 updated, 2/2 ready",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			c := fake.NewClientBuilder().WithObjects(tt.objs...).Build()
+
+			err := custom.CheckReady(context.Background(), c, tt.check)
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(tt.wantErr))
+			}
+		})
+	}
+}
+
+func readinessCheck() *anywherev1.CustomCNIReadinessCheck {
+	return &anywherev1.CustomCNIReadinessCheck{
+		DaemonSets: []anywherev1.CustomCNIWorkload{
+			{Name: "my-cni-node", Namespace: "kube-system"},
+		},
+		Deployments: []anywherev1.CustomCNIWorkload{
+			{Name: "my-cni-controller", Namespace: "kube-system"},
+		},
+	}
+}
+
+func daemonSet(generation, observedGeneration int64, desired, updated, ready int32) *appsv1.DaemonSet {
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "my-cni-node",
+			Namespace:  "kube-system",
+			Generation: generation,
+		},
+		Status: appsv1.DaemonSetStatus{
+			ObservedGeneration:     observedGeneration,
+			DesiredNumberScheduled: desired,
+			UpdatedNumberScheduled: updated,
+			NumberReady:            ready,
+		},
+	}
+}
+
+func deployment(generation, observedGeneration int64, replicas, updated, ready int32) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "my-cni-controller",
+			Namespace:  "kube-system",
+			Generation: generation,
+		},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: observedGeneration,
+			Replicas:           replicas,
+			UpdatedReplicas:    updated,
+			ReadyReplicas:      ready,
+		},
+	}
+}
@@ -0,0 +1,163 @@
+package custom
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
+	"github.com/aws/eks-anywhere/pkg/controller/serverside"
+	"github.com/aws/eks-anywhere/pkg/helm"
+	"github.com/aws/eks-anywhere/pkg/templater"
+)
+
+// CNIManifestAppliedAnnotation stores the hash of the last custom CNI manifest source applied to the cluster.
+// It avoids templating and applying the manifest again when it hasn't changed.
+const CNIManifestAppliedAnnotation = "anywhere.eks.amazonaws.com/custom-cni-manifest"
+
+const defaultRequeueTime = 10 * time.Second
+
+// HelmClientFactory provides a helm client for a cluster.
+type HelmClientFactory interface {
+	Get(ctx context.Context, clus *anywherev1.Cluster) (helm.Client, error)
+}
+
+// Reconciler allows to reconcile a CNI provided by the user.
+type Reconciler struct {
+	client      client.Client
+	helmFactory HelmClientFactory
+}
+
+// New returns a new Reconciler. client is connected to the management cluster and it's used to
+// read the ConfigMaps containing the user CNI manifests.
+func New(client client.Client, helmFactory HelmClientFactory) *Reconciler {
+	return &Reconciler{
+		client:      client,
+		helmFactory: helmFactory,
+	}
+}
+
+// Reconcile applies the user CNI manifest or Helm chart, if any, and checks the CNI readiness.
+// It uses a controller.Result to indicate when requeues are needed. client is connected to the
+// target Kubernetes cluster, not the management cluster.
+func (r *Reconciler) Reconcile(ctx context.Context, logger logr.Logger, client client.Client, spec *cluster.Spec) (controller.Result, error) {
+	custom := spec.Cluster.Spec.ClusterNetwork.CNIConfig.Custom
+
+	if err := r.applyManifest(ctx, logger, client, spec); err != nil {
+		return controller.Result{}, err
+	}
+
+	if err := CheckReady(ctx, client, custom.ReadinessCheck); err != nil {
+		logger.Info("Custom CNI is not ready yet, requeueing", "reason", err.Error())
+		conditions.MarkFalse(spec.Cluster, anywherev1.DefaultCNIConfiguredCondition, anywherev1.CustomCNINotReadyReason, clusterv1.ConditionSeverityInfo, err.Error())
+		return controller.ResultWithRequeue(defaultRequeueTime), nil
+	}
+
+	conditions.MarkTrue(spec.Cluster, anywherev1.DefaultCNIConfiguredCondition)
+	return controller.Result{}, nil
+}
+
+func (r *Reconciler) applyManifest(ctx context.Context, logger logr.Logger, client client.Client, spec *cluster.Spec) error {
+	custom := spec.Cluster.Spec.ClusterNetwork.CNIConfig.Custom
+
+	var source []byte
+	var err error
+	switch {
+	case custom.ManifestConfigMap != "":
+		source, err = r.readManifestConfigMap(ctx, spec.Cluster.Namespace, custom.ManifestConfigMap)
+	case custom.HelmChart != nil:
+		source, err = yaml.Marshal(struct {
+			Chart       *anywherev1.CustomCNIHelmChart
+			KubeVersion anywherev1.KubernetesVersion
+		}{custom.HelmChart, spec.Cluster.Spec.KubernetesVersion})
+	default:
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	hash := sha256.Sum256(source)
+	sourceHash := hex.EncodeToString(hash[:])
+	if spec.Cluster.Annotations[CNIManifestAppliedAnnotation] == sourceHash {
+		return nil
+	}
+
+	manifest := source
+	if custom.HelmChart != nil {
+		if manifest, err = r.templateHelmChart(ctx, spec); err != nil {
+			return err
+		}
+	}
+
+	logger.Info("Applying custom CNI manifest")
+	if err := serverside.ReconcileYaml(ctx, client, manifest); err != nil {
+		return errors.Wrap(err, "applying custom CNI manifest")
+	}
+
+	clientutil.AddAnnotation(spec.Cluster, CNIManifestAppliedAnnotation, sourceHash)
+	return nil
+}
+
+// readManifestConfigMap returns the manifests in all the ConfigMap keys, sorted by key.
+func (r *Reconciler) readManifestConfigMap(ctx context.Context, namespace, name string) ([]byte, error) {
+	cm := &corev1.ConfigMap{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, cm); err != nil {
+		return nil, errors.Wrapf(err, "reading custom CNI manifest ConfigMap %s", name)
+	}
+
+	keys := make([]string, 0, len(cm.Data))
+	for k := range cm.Data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	manifests := make([][]byte, 0, len(keys))
+	for _, k := range keys {
+		manifests = append(manifests, []byte(cm.Data[k]))
+	}
+
+	return templater.AppendYamlResources(manifests...), nil
+}
+
+func (r *Reconciler) templateHelmChart(ctx context.Context, spec *cluster.Spec) ([]byte, error) {
+	chart := spec.Cluster.Spec.ClusterNetwork.CNIConfig.Custom.HelmChart
+
+	values := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(chart.Values), &values); err != nil {
+		return nil, errors.Wrap(err, "parsing custom CNI helm chart values")
+	}
+
+	namespace := chart.Namespace
+	if namespace == "" {
+		namespace = constants.KubeSystemNamespace
+	}
+
+	helm, err := r.helmFactory.Get(ctx, spec.Cluster)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get helm client for cluster %s: %v", spec.Cluster.Name, err)
+	}
+
+	manifest, err := helm.Template(ctx, chart.URI, chart.Version, namespace, values, string(spec.Cluster.Spec.KubernetesVersion))
+	if err != nil {
+		return nil, errors.Wrap(err, "generating custom CNI manifest from helm chart")
+	}
+
+	return manifest, nil
+}
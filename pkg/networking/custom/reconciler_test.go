@@ -0,0 +1,199 @@
+package custom_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	helmmocks "github.com/aws/eks-anywhere/pkg/helm/mocks"
+	"github.com/aws/eks-anywhere/pkg/networking/custom"
+	"github.com/aws/eks-anywhere/pkg/networking/custom/mocks"
+	"github.com/aws/eks-anywhere/pkg/templater"
+)
+
+const cniManifest = "apiVersion: v1\nkind: ServiceAccount\nmetadata:\n  name: my-cni\n  namespace: kube-system\n"
+
+type reconcileTest struct {
+	*WithT
+	ctx         context.Context
+	spec        *cluster.Spec
+	helmFactory *mocks.MockHelmClientFactory
+	helm        *helmmocks.MockClient
+	mgmtClient  client.Client
+	clusterObjs []client.Object
+	clusterCNI  *anywherev1.CustomCNIConfig
+}
+
+func newReconcileTest(t *testing.T, mgmtObjs ...client.Object) *reconcileTest {
+	ctrl := gomock.NewController(t)
+	custom := &anywherev1.CustomCNIConfig{}
+	return &reconcileTest{
+		WithT:       NewWithT(t),
+		ctx:         context.Background(),
+		helmFactory: mocks.NewMockHelmClientFactory(ctrl),
+		helm:        helmmocks.NewMockClient(ctrl),
+		mgmtClient:  fake.NewClientBuilder().WithObjects(mgmtObjs...).Build(),
+		clusterCNI:  custom,
+		spec: test.NewClusterSpec(func(s *cluster.Spec) {
+			s.Cluster.Name = "my-cluster"
+			s.Cluster.Namespace = "eksa-system"
+			s.Cluster.Spec.KubernetesVersion = anywherev1.Kube128
+			s.Cluster.Spec.ClusterNetwork.CNIConfig = &anywherev1.CNIConfig{Custom: custom}
+		}),
+	}
+}
+
+func (tt *reconcileTest) withClusterObjects(objs ...client.Object) *reconcileTest {
+	tt.clusterObjs = append(tt.clusterObjs, objs...)
+	return tt
+}
+
+func (tt *reconcileTest) reconcile() (controller.Result, error) {
+	clusterClient := fake.NewClientBuilder().WithObjects(tt.clusterObjs...).Build()
+	return custom.New(tt.mgmtClient, tt.helmFactory).Reconcile(tt.ctx, test.NewNullLogger(), clusterClient, tt.spec)
+}
+
+func (tt *reconcileTest) expectDefaultCNIConfigured(status corev1.ConditionStatus, reason string) {
+	condition := conditions.Get(tt.spec.Cluster, anywherev1.DefaultCNIConfiguredCondition)
+	tt.Expect(condition).NotTo(BeNil())
+	tt.Expect(condition.Status).To(Equal(status))
+	tt.Expect(condition.Reason).To(Equal(reason))
+}
+
+func manifestConfigMap(data string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cni-manifest",
+			Namespace: "eksa-system",
+		},
+		Data: map[string]string{
+			"cni.yaml": data,
+		},
+	}
+}
+
+func sourceHash(source []byte) string {
+	hash := sha256.Sum256(source)
+	return hex.EncodeToString(hash[:])
+}
+
+func TestReconcilerReconcileNoManifestReady(t *testing.T) {
+	tt := newReconcileTest(t)
+	tt.clusterCNI.ReadinessCheck = readinessCheck()
+	tt.withClusterObjects(daemonSet(1, 1, 3, 3, 3), deployment(1, 1, 1, 1, 1))
+
+	tt.Expect(tt.reconcile()).To(Equal(controller.Result{}))
+	tt.expectDefaultCNIConfigured(corev1.ConditionTrue, "")
+	tt.Expect(tt.spec.Cluster.Annotations).NotTo(HaveKey(custom.CNIManifestAppliedAnnotation))
+}
+
+func TestReconcilerReconcileNotReady(t *testing.T) {
+	tt := newReconcileTest(t)
+	tt.clusterCNI.ReadinessCheck = readinessCheck()
+	tt.withClusterObjects(daemonSet(1, 1, 3, 3, 1), deployment(1, 1, 1, 1, 1))
+
+	tt.Expect(tt.reconcile()).To(Equal(controller.ResultWithRequeue(10 * time.Second)))
+	tt.expectDefaultCNIConfigured(corev1.ConditionFalse, anywherev1.CustomCNINotReadyReason)
+}
+
+func TestReconcilerReconcileManifestAlreadyApplied(t *testing.T) {
+	tt := newReconcileTest(t, manifestConfigMap(cniManifest))
+	tt.clusterCNI.ManifestConfigMap = "my-cni-manifest"
+	tt.spec.Cluster.Annotations = map[string]string{
+		custom.CNIManifestAppliedAnnotation: sourceHash(templater.AppendYamlResources([]byte(cniManifest))),
+	}
+
+	tt.Expect(tt.reconcile()).To(Equal(controller.Result{}))
+	tt.expectDefaultCNIConfigured(corev1.ConditionTrue, "")
+}
+
+func TestReconcilerReconcileManifestConfigMapMissing(t *testing.T) {
+	tt := newReconcileTest(t)
+	tt.clusterCNI.ManifestConfigMap = "my-cni-manifest"
+
+	_, err := tt.reconcile()
+	tt.Expect(err).To(MatchError(ContainSubstring("reading custom CNI manifest ConfigMap my-cni-manifest")))
+}
+
+func TestReconcilerReconcileManifestInvalid(t *testing.T) {
+	tt := newReconcileTest(t, manifestConfigMap("invalid yaml"))
+	tt.clusterCNI.ManifestConfigMap = "my-cni-manifest"
+
+	_, err := tt.reconcile()
+	tt.Expect(err).To(MatchError(ContainSubstring("applying custom CNI manifest")))
+	tt.Expect(tt.spec.Cluster.Annotations).NotTo(HaveKey(custom.CNIManifestAppliedAnnotation))
+}
+
+func TestReconcilerReconcileHelmChartTemplated(t *testing.T) {
+	tt := newReconcileTest(t)
+	tt.clusterCNI.HelmChart = &anywherev1.CustomCNIHelmChart{
+		URI:     "oci://public.ecr.aws/my-org/my-cni",
+		Version: "1.2.3",
+		Values:  "ipam:\n  mode: kubernetes\n",
+	}
+	wantValues := map[string]interface{}{
+		"ipam": map[string]interface{}{"mode": "kubernetes"},
+	}
+	tt.helmFactory.EXPECT().Get(tt.ctx, tt.spec.Cluster).Return(tt.helm, nil)
+	tt.helm.EXPECT().Template(
+		tt.ctx, "oci://public.ecr.aws/my-org/my-cni", "1.2.3", "kube-system", wantValues, "1.28",
+	).Return([]byte("invalid yaml"), nil)
+
+	_, err := tt.reconcile()
+	tt.Expect(err).To(MatchError(ContainSubstring("applying custom CNI manifest")))
+}
+
+func TestReconcilerReconcileHelmChartErrorGettingClient(t *testing.T) {
+	tt := newReconcileTest(t)
+	tt.clusterCNI.HelmChart = &anywherev1.CustomCNIHelmChart{
+		URI:     "oci://public.ecr.aws/my-org/my-cni",
+		Version: "1.2.3",
+	}
+	tt.helmFactory.EXPECT().Get(tt.ctx, tt.spec.Cluster).Return(nil, errors.New("no helm"))
+
+	_, err := tt.reconcile()
+	tt.Expect(err).To(MatchError(ContainSubstring("failed to get helm client for cluster my-cluster: no helm")))
+}
+
+func TestReconcilerReconcileHelmChartErrorTemplating(t *testing.T) {
+	tt := newReconcileTest(t)
+	tt.clusterCNI.HelmChart = &anywherev1.CustomCNIHelmChart{
+		URI:       "oci://public.ecr.aws/my-org/my-cni",
+		Version:   "1.2.3",
+		Namespace: "my-cni",
+	}
+	tt.helmFactory.EXPECT().Get(tt.ctx, tt.spec.Cluster).Return(tt.helm, nil)
+	tt.helm.EXPECT().Template(
+		tt.ctx, "oci://public.ecr.aws/my-org/my-cni", "1.2.3", "my-cni", map[string]interface{}{}, "1.28",
+	).Return(nil, errors.New("chart not found"))
+
+	_, err := tt.reconcile()
+	tt.Expect(err).To(MatchError(ContainSubstring("generating custom CNI manifest from helm chart: chart not found")))
+}
+
+func TestReconcilerReconcileHelmChartInvalidValues(t *testing.T) {
+	tt := newReconcileTest(t)
+	tt.clusterCNI.HelmChart = &anywherev1.CustomCNIHelmChart{
+		URI:     "oci://public.ecr.aws/my-org/my-cni",
+		Version: "1.2.3",
+		Values:  "- not\n- a map",
+	}
+
+	_, err := tt.reconcile()
+	tt.Expect(err).To(MatchError(ContainSubstring("parsing custom CNI helm chart values")))
+}
@@ -0,0 +1,291 @@
+package networkpolicy
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+// BaselinePolicyName is the name of the NetworkPolicy created in each management namespace.
+const BaselinePolicyName = "eksa-baseline"
+
+const (
+	webhookPort    = 9443
+	etcdPort       = 2379
+	ipmiPort       = 623
+	snowEC2Port    = 8243
+	snowDevicePort = 9092
+	dnsPort        = 53
+)
+
+// Kubernetes API server ports, either accessed directly or through the kubernetes service.
+var apiServerPorts = []int{443, 6443}
+
+// Ports exposed by the Tinkerbell stack running in eksa-system.
+var (
+	tinkerbellStackTCPPorts = []int{80, 7171, 8080, 42113, 50061}
+	tinkerbellStackUDPPorts = []int{67, 69, 514}
+)
+
+var providerNamespaces = map[string]string{
+	anywherev1.VSphereDatacenterKind:    constants.CapvSystemNamespace,
+	anywherev1.DockerDatacenterKind:     constants.CapdSystemNamespace,
+	anywherev1.CloudStackDatacenterKind: constants.CapcSystemNamespace,
+	anywherev1.NutanixDatacenterKind:    constants.CapxSystemNamespace,
+	anywherev1.SnowDatacenterKind:       constants.CapasSystemNamespace,
+	anywherev1.TinkerbellDatacenterKind: constants.CaptSystemNamespace,
+}
+
+// Namespaces returns the management namespaces covered by the NetworkPolicy baseline for a cluster.
+func Namespaces(clus *anywherev1.Cluster) []string {
+	namespaces := []string{
+		constants.EksaSystemNamespace,
+		constants.CapiSystemNamespace,
+		constants.CapiKubeadmBootstrapSystemNamespace,
+		constants.CapiKubeadmControlPlaneSystemNamespace,
+		constants.EtcdAdmBootstrapProviderSystemNamespace,
+		constants.EtcdAdmControllerSystemNamespace,
+	}
+
+	if ns, ok := providerNamespaces[clus.Spec.DatacenterRef.Kind]; ok {
+		namespaces = append(namespaces, ns)
+	}
+
+	return namespaces
+}
+
+// Baseline generates the NetworkPolicies for the management namespaces of a cluster.
+// Each namespace gets a single policy selecting all its pods that only allows:
+//   - Traffic between pods in the same namespace.
+//   - DNS queries to kube-system.
+//   - Egress to the Kubernetes API servers and ingress to the webhooks.
+//   - Provider specific traffic, like egress to the infrastructure APIs.
+//
+// The allow rules in the cluster spec are appended to the policies of the namespaces they target.
+func Baseline(spec *cluster.Spec) ([]*networkingv1.NetworkPolicy, error) {
+	providerEgress, err := providerEgressPorts(spec)
+	if err != nil {
+		return nil, err
+	}
+	clusterEgress, err := clusterEgressPorts(spec.Cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	providerNamespace := providerNamespaces[spec.Cluster.Spec.DatacenterRef.Kind]
+	namespaces := Namespaces(spec.Cluster)
+	policies := make([]*networkingv1.NetworkPolicy, 0, len(namespaces))
+	for _, ns := range namespaces {
+		p := basePolicy(ns)
+
+		egressPorts := append([]networkingv1.NetworkPolicyPort{}, clusterEgress...)
+		if ns == constants.EksaSystemNamespace || ns == providerNamespace {
+			egressPorts = append(egressPorts, providerEgress...)
+		}
+		if ns == constants.EtcdAdmControllerSystemNamespace {
+			egressPorts = append(egressPorts, tcpPorts(etcdPort)...)
+		}
+		if len(egressPorts) > 0 {
+			p.Spec.Egress = append(p.Spec.Egress, networkingv1.NetworkPolicyEgressRule{Ports: egressPorts})
+		}
+
+		if ns == constants.EksaSystemNamespace && spec.Cluster.Spec.DatacenterRef.Kind == anywherev1.TinkerbellDatacenterKind {
+			p.Spec.Ingress = append(p.Spec.Ingress, networkingv1.NetworkPolicyIngressRule{
+				Ports: append(tcpPorts(tinkerbellStackTCPPorts...), udpPorts(tinkerbellStackUDPPorts...)...),
+			})
+		}
+
+		addAllowRules(p, spec.Cluster.Spec.ClusterNetwork.NetworkPolicy)
+		policies = append(policies, p)
+	}
+
+	return policies, nil
+}
+
+func basePolicy(namespace string) *networkingv1.NetworkPolicy {
+	sameNamespace := []networkingv1.NetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{}}}
+
+	return &networkingv1.NetworkPolicy{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: networkingv1.SchemeGroupVersion.String(),
+			Kind:       "NetworkPolicy",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      BaselinePolicyName,
+			Namespace: namespace,
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{},
+			PolicyTypes: []networkingv1.PolicyType{
+				networkingv1.PolicyTypeIngress,
+				networkingv1.PolicyTypeEgress,
+			},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{
+				{From: sameNamespace},
+				{Ports: tcpPorts(webhookPort)},
+			},
+			Egress: []networkingv1.NetworkPolicyEgressRule{
+				{To: sameNamespace},
+				{
+					To: []networkingv1.NetworkPolicyPeer{{
+						NamespaceSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{corev1.LabelMetadataName: constants.KubeSystemNamespace},
+						},
+						PodSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{"k8s-app": "kube-dns"},
+						},
+					}},
+					Ports: append(udpPorts(dnsPort), tcpPorts(dnsPort)...),
+				},
+				{Ports: tcpPorts(apiServerPorts...)},
+			},
+		},
+	}
+}
+
+func addAllowRules(p *networkingv1.NetworkPolicy, config *anywherev1.NetworkPolicyConfig) {
+	if config == nil {
+		return
+	}
+
+	for _, rule := range config.AllowRules {
+		if len(rule.Namespaces) > 0 && !contains(rule.Namespaces, p.Namespace) {
+			continue
+		}
+		for _, ingress := range rule.Ingress {
+			p.Spec.Ingress = append(p.Spec.Ingress, *ingress.DeepCopy())
+		}
+		for _, egress := range rule.Egress {
+			p.Spec.Egress = append(p.Spec.Egress, *egress.DeepCopy())
+		}
+	}
+}
+
+// providerEgressPorts returns the ports needed to reach the infrastructure provider APIs.
+func providerEgressPorts(spec *cluster.Spec) ([]networkingv1.NetworkPolicyPort, error) {
+	switch spec.Cluster.Spec.DatacenterRef.Kind {
+	case anywherev1.NutanixDatacenterKind:
+		if spec.NutanixDatacenter == nil || spec.NutanixDatacenter.Spec.Port == 0 {
+			return nil, nil
+		}
+		return tcpPorts(spec.NutanixDatacenter.Spec.Port), nil
+	case anywherev1.CloudStackDatacenterKind:
+		if spec.CloudStackDatacenter == nil {
+			return nil, nil
+		}
+		var ports []int
+		for _, az := range spec.CloudStackDatacenter.Spec.AvailabilityZones {
+			port, err := endpointPort(az.ManagementApiEndpoint)
+			if err != nil {
+				return nil, fmt.Errorf("reading port from CloudStack management API endpoint for availability zone %s: %v", az.Name, err)
+			}
+			ports = append(ports, port)
+		}
+		return tcpPorts(ports...), nil
+	case anywherev1.SnowDatacenterKind:
+		return tcpPorts(snowEC2Port, snowDevicePort), nil
+	case anywherev1.TinkerbellDatacenterKind:
+		return udpPorts(ipmiPort), nil
+	default:
+		return nil, nil
+	}
+}
+
+// clusterEgressPorts returns the ports needed to reach the registry mirror and proxies
+// configured for the cluster.
+func clusterEgressPorts(clus *anywherev1.Cluster) ([]networkingv1.NetworkPolicyPort, error) {
+	var ports []int
+
+	if mirror := clus.Spec.RegistryMirrorConfiguration; mirror != nil && mirror.Port != "" {
+		port, err := strconv.Atoi(mirror.Port)
+		if err != nil {
+			return nil, fmt.Errorf("invalid registry mirror port %s: %v", mirror.Port, err)
+		}
+		ports = append(ports, port)
+	}
+
+	if proxy := clus.Spec.ProxyConfiguration; proxy != nil {
+		for _, endpoint := range []string{proxy.HttpProxy, proxy.HttpsProxy} {
+			if endpoint == "" {
+				continue
+			}
+			port, err := endpointPort(endpoint)
+			if err != nil {
+				return nil, fmt.Errorf("reading port from proxy %s: %v", endpoint, err)
+			}
+			ports = append(ports, port)
+		}
+	}
+
+	return tcpPorts(ports...), nil
+}
+
+// endpointPort returns the port of an endpoint in the form [scheme://]host[:port][/path].
+// When the port is not explicit, it defaults to 443 for https and 80 otherwise.
+func endpointPort(endpoint string) (int, error) {
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "http://" + endpoint
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return 0, err
+	}
+
+	if p := u.Port(); p != "" {
+		return strconv.Atoi(p)
+	}
+
+	if u.Scheme == "https" {
+		return 443, nil
+	}
+
+	return 80, nil
+}
+
+func tcpPorts(ports ...int) []networkingv1.NetworkPolicyPort {
+	return policyPorts(corev1.ProtocolTCP, ports)
+}
+
+func udpPorts(ports ...int) []networkingv1.NetworkPolicyPort {
+	return policyPorts(corev1.ProtocolUDP, ports)
+}
+
+func policyPorts(protocol corev1.Protocol, ports []int) []networkingv1.NetworkPolicyPort {
+	seen := map[int]struct{}{}
+	policyPorts := make([]networkingv1.NetworkPolicyPort, 0, len(ports))
+	for _, port := range ports {
+		if _, ok := seen[port]; ok {
+			continue
+		}
+		seen[port] = struct{}{}
+
+		protocol := protocol
+		p := intstr.FromInt(port)
+		policyPorts = append(policyPorts, networkingv1.NetworkPolicyPort{
+			Protocol: &protocol,
+			Port:     &p,
+		})
+	}
+
+	return policyPorts
+}
+
+func contains(list []string, s string) bool {
+	for _, e := range list {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,288 @@
+package networkpolicy_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/networking/networkpolicy"
+)
+
+func TestNamespaces(t *testing.T) {
+	tests := []struct {
+		name           string
+		datacenterKind string
+		want           []string
+	}{
+		{
+			name:           "vsphere",
+			datacenterKind: anywherev1.VSphereDatacenterKind,
+			want: []string{
+				"eksa-system",
+				"capi-system",
+				"capi-kubeadm-bootstrap-system",
+				"capi-kubeadm-control-plane-system",
+				"etcdadm-bootstrap-provider-system",
+				"etcdadm-controller-system",
+				"capv-system",
+			},
+		},
+		{
+			name:           "tinkerbell",
+			datacenterKind: anywherev1.TinkerbellDatacenterKind,
+			want: []string{
+				"eksa-system",
+				"capi-system",
+				"capi-kubeadm-bootstrap-system",
+				"capi-kubeadm-control-plane-system",
+				"etcdadm-bootstrap-provider-system",
+				"etcdadm-controller-system",
+				"capt-system",
+			},
+		},
+		{
+			name:           "unknown provider",
+			datacenterKind: "MyDatacenterConfig",
+			want: []string{
+				"eksa-system",
+				"capi-system",
+				"capi-kubeadm-bootstrap-system",
+				"capi-kubeadm-control-plane-system",
+				"etcdadm-bootstrap-provider-system",
+				"etcdadm-controller-system",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			clus := &anywherev1.Cluster{}
+			clus.Spec.DatacenterRef.Kind = tt.datacenterKind
+			g.Expect(networkpolicy.Namespaces(clus)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestBaselineDefault(t *testing.T) {
+	g := NewWithT(t)
+	spec := baselineSpec()
+
+	policies, err := networkpolicy.Baseline(spec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(policies).To(HaveLen(7))
+
+	for _, p := range policies {
+		g.Expect(p.Name).To(Equal("eksa-baseline"))
+		g.Expect(p.Spec.PodSelector).To(Equal(metav1.LabelSelector{}))
+		g.Expect(p.Spec.PolicyTypes).To(ConsistOf(networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress))
+		g.Expect(p.Spec.Ingress).To(HaveLen(2))
+		g.Expect(p.Spec.Ingress[1].Ports).To(Equal(tcpPorts(9443)))
+		g.Expect(p.Spec.Egress[2].Ports).To(Equal(tcpPorts(443, 6443)))
+	}
+
+	g.Expect(policyFor(policies, "eksa-system").Spec.Egress).To(HaveLen(3))
+	g.Expect(policyFor(policies, "etcdadm-controller-system").Spec.Egress[3].Ports).To(Equal(tcpPorts(2379)))
+}
+
+func TestBaselineProviderPorts(t *testing.T) {
+	tests := []struct {
+		name              string
+		spec              *cluster.Spec
+		providerNamespace string
+		want              []networkingv1.NetworkPolicyPort
+	}{
+		{
+			name: "nutanix",
+			spec: baselineSpec(func(s *cluster.Spec) {
+				s.Cluster.Spec.DatacenterRef.Kind = anywherev1.NutanixDatacenterKind
+				s.NutanixDatacenter = &anywherev1.NutanixDatacenterConfig{
+					Spec: anywherev1.NutanixDatacenterConfigSpec{Port: 9440},
+				}
+			}),
+			providerNamespace: "capx-system",
+			want:              tcpPorts(9440),
+		},
+		{
+			name: "cloudstack",
+			spec: baselineSpec(func(s *cluster.Spec) {
+				s.Cluster.Spec.DatacenterRef.Kind = anywherev1.CloudStackDatacenterKind
+				s.CloudStackDatacenter = &anywherev1.CloudStackDatacenterConfig{
+					Spec: anywherev1.CloudStackDatacenterConfigSpec{
+						AvailabilityZones: []anywherev1.CloudStackAvailabilityZone{
+							{Name: "az-1", ManagementApiEndpoint: "http://cloudstack-1:8080/client/api"},
+							{Name: "az-2", ManagementApiEndpoint: "https://cloudstack-2/client/api"},
+							{Name: "az-3", ManagementApiEndpoint: "http://cloudstack-3:8080/client/api"},
+						},
+					},
+				}
+			}),
+			providerNamespace: "capc-system",
+			want:              tcpPorts(8080, 443),
+		},
+		{
+			name: "snow",
+			spec: baselineSpec(func(s *cluster.Spec) {
+				s.Cluster.Spec.DatacenterRef.Kind = anywherev1.SnowDatacenterKind
+			}),
+			providerNamespace: "capas-system",
+			want:              tcpPorts(8243, 9092),
+		},
+		{
+			name: "tinkerbell",
+			spec: baselineSpec(func(s *cluster.Spec) {
+				s.Cluster.Spec.DatacenterRef.Kind = anywherev1.TinkerbellDatacenterKind
+			}),
+			providerNamespace: "capt-system",
+			want:              udpPorts(623),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			policies, err := networkpolicy.Baseline(tt.spec)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			for _, ns := range []string{"eksa-system", tt.providerNamespace} {
+				egress := policyFor(policies, ns).Spec.Egress
+				g.Expect(egress).To(HaveLen(4))
+				g.Expect(egress[3].Ports).To(Equal(tt.want))
+			}
+			g.Expect(policyFor(policies, "capi-system").Spec.Egress).To(HaveLen(3))
+		})
+	}
+}
+
+func TestBaselineTinkerbellStackIngress(t *testing.T) {
+	g := NewWithT(t)
+	spec := baselineSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.DatacenterRef.Kind = anywherev1.TinkerbellDatacenterKind
+	})
+
+	policies, err := networkpolicy.Baseline(spec)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	ingress := policyFor(policies, "eksa-system").Spec.Ingress
+	g.Expect(ingress).To(HaveLen(3))
+	g.Expect(ingress[2].Ports).To(Equal(append(tcpPorts(80, 7171, 8080, 42113, 50061), udpPorts(67, 69, 514)...)))
+	g.Expect(policyFor(policies, "capt-system").Spec.Ingress).To(HaveLen(2))
+}
+
+func TestBaselineRegistryMirrorAndProxy(t *testing.T) {
+	g := NewWithT(t)
+	spec := baselineSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.RegistryMirrorConfiguration = &anywherev1.RegistryMirrorConfiguration{
+			Endpoint: "1.2.3.4",
+			Port:     "5000",
+		}
+		s.Cluster.Spec.ProxyConfiguration = &anywherev1.ProxyConfiguration{
+			HttpProxy:  "1.2.3.5:3128",
+			HttpsProxy: "http://1.2.3.5:3128",
+		}
+	})
+
+	policies, err := networkpolicy.Baseline(spec)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	for _, p := range policies {
+		g.Expect(p.Spec.Egress[3].Ports[:2]).To(Equal(tcpPorts(5000, 3128)))
+	}
+}
+
+func TestBaselineErrorInvalidRegistryMirrorPort(t *testing.T) {
+	g := NewWithT(t)
+	spec := baselineSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.RegistryMirrorConfiguration = &anywherev1.RegistryMirrorConfiguration{
+			Endpoint: "1.2.3.4",
+			Port:     "port",
+		}
+	})
+
+	_, err := networkpolicy.Baseline(spec)
+	g.Expect(err).To(MatchError(ContainSubstring("invalid registry mirror port port")))
+}
+
+func TestBaselineErrorInvalidProxy(t *testing.T) {
+	g := NewWithT(t)
+	spec := baselineSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.ProxyConfiguration = &anywherev1.ProxyConfiguration{
+			HttpProxy: "http://1.2.3.5:port",
+		}
+	})
+
+	_, err := networkpolicy.Baseline(spec)
+	g.Expect(err).To(MatchError(ContainSubstring("reading port from proxy http://1.2.3.5:port")))
+}
+
+func TestBaselineAllowRules(t *testing.T) {
+	g := NewWithT(t)
+	metricsIngress := networkingv1.NetworkPolicyIngressRule{Ports: tcpPorts(8080)}
+	gitEgress := networkingv1.NetworkPolicyEgressRule{Ports: tcpPorts(22)}
+	spec := baselineSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.ClusterNetwork.NetworkPolicy = &anywherev1.NetworkPolicyConfig{
+			AllowRules: []anywherev1.NetworkPolicyAllowRule{
+				{
+					Ingress: []networkingv1.NetworkPolicyIngressRule{metricsIngress},
+				},
+				{
+					Namespaces: []string{"eksa-system"},
+					Egress:     []networkingv1.NetworkPolicyEgressRule{gitEgress},
+				},
+			},
+		}
+	})
+
+	policies, err := networkpolicy.Baseline(spec)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	for _, p := range policies {
+		g.Expect(p.Spec.Ingress).To(HaveLen(3))
+		g.Expect(p.Spec.Ingress[2]).To(Equal(metricsIngress))
+	}
+
+	eksaEgress := policyFor(policies, "eksa-system").Spec.Egress
+	g.Expect(eksaEgress).To(HaveLen(4))
+	g.Expect(eksaEgress[3]).To(Equal(gitEgress))
+	g.Expect(policyFor(policies, "capi-system").Spec.Egress).To(HaveLen(3))
+}
+
+func baselineSpec(opts ...test.ClusterSpecOpt) *cluster.Spec {
+	return test.NewClusterSpec(append([]test.ClusterSpecOpt{func(s *cluster.Spec) {
+		s.Cluster.Name = "mgmt"
+		s.Cluster.Spec.DatacenterRef.Kind = anywherev1.VSphereDatacenterKind
+	}}, opts...)...)
+}
+
+func policyFor(policies []*networkingv1.NetworkPolicy, namespace string) *networkingv1.NetworkPolicy {
+	for _, p := range policies {
+		if p.Namespace == namespace {
+			return p
+		}
+	}
+	return nil
+}
+
+func tcpPorts(ports ...int) []networkingv1.NetworkPolicyPort {
+	return policyPorts(corev1.ProtocolTCP, ports)
+}
+
+func udpPorts(ports ...int) []networkingv1.NetworkPolicyPort {
+	return policyPorts(corev1.ProtocolUDP, ports)
+}
+
+func policyPorts(protocol corev1.Protocol, ports []int) []networkingv1.NetworkPolicyPort {
+	policyPorts := make([]networkingv1.NetworkPolicyPort, 0, len(ports))
+	for _, port := range ports {
+		protocol := protocol
+		p := intstr.FromInt(port)
+		policyPorts = append(policyPorts, networkingv1.NetworkPolicyPort{Protocol: &protocol, Port: &p})
+	}
+	return policyPorts
+}
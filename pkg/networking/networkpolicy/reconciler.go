@@ -0,0 +1,97 @@
+package networkpolicy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/controller/serverside"
+)
+
+// Reconciler allows to reconcile the NetworkPolicy baseline for the management namespaces.
+type Reconciler struct{}
+
+// New returns a new Reconciler.
+func New() *Reconciler {
+	return &Reconciler{}
+}
+
+// Reconcile applies the NetworkPolicy baseline to a management cluster and removes the allow-all
+// policies previously created for the same namespaces. It's a noop for workload clusters.
+// client is connected to the target Kubernetes cluster, not the management cluster.
+func (r *Reconciler) Reconcile(ctx context.Context, logger logr.Logger, client client.Client, spec *cluster.Spec) (controller.Result, error) {
+	if spec.Cluster.IsManaged() {
+		return controller.Result{}, nil
+	}
+
+	policies, err := Baseline(spec)
+	if err != nil {
+		return controller.Result{}, errors.Wrap(err, "generating NetworkPolicy baseline")
+	}
+
+	logger.V(4).Info("Applying NetworkPolicy baseline")
+	if err := serverside.ReconcileObjects(ctx, client, baselineObjects(policies)); err != nil {
+		return controller.Result{}, errors.Wrap(err, "applying NetworkPolicy baseline")
+	}
+
+	for _, p := range policies {
+		if err := deleteAllowAllPolicy(ctx, client, p.Namespace); err != nil {
+			return controller.Result{}, err
+		}
+	}
+
+	return controller.Result{}, nil
+}
+
+// deleteAllowAllPolicy removes the allow-all NetworkPolicy created for a namespace by the Cilium
+// "always" policy enforcement mode, since it would make the baseline ineffective.
+func deleteAllowAllPolicy(ctx context.Context, c client.Client, namespace string) error {
+	p := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      allowAllPolicyName(namespace),
+			Namespace: namespace,
+		},
+	}
+	if err := c.Delete(ctx, p); err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "deleting NetworkPolicy %s/%s", namespace, p.Name)
+	}
+
+	return nil
+}
+
+// allowAllPolicyName returns the name of the allow-all NetworkPolicy for a namespace.
+func allowAllPolicyName(namespace string) string {
+	return fmt.Sprintf("allow-all-%s", namespace)
+}
+
+// baselineObjects returns the policies preceded by their namespaces, so they can be applied
+// before the namespaces are created by the management components.
+func baselineObjects(policies []*networkingv1.NetworkPolicy) []client.Object {
+	objs := make([]client.Object, 0, 2*len(policies))
+	for _, p := range policies {
+		objs = append(objs, namespace(p.Namespace), p)
+	}
+
+	return objs
+}
+
+func namespace(name string) *corev1.Namespace {
+	return &corev1.Namespace{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "Namespace",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+	}
+}
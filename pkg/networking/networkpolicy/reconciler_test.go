@@ -0,0 +1,109 @@
+package networkpolicy_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/internal/test/envtest"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/networking/networkpolicy"
+)
+
+func TestReconcilerReconcileManagementCluster(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	c := env.Client()
+	spec := baselineSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.ClusterNetwork.NetworkPolicy = &anywherev1.NetworkPolicyConfig{
+			AllowRules: []anywherev1.NetworkPolicyAllowRule{
+				{
+					Namespaces: []string{"capv-system"},
+					Ingress:    []networkingv1.NetworkPolicyIngressRule{{Ports: tcpPorts(8080)}},
+				},
+			},
+		}
+	})
+	allowAll := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "allow-all-capi-system",
+			Namespace: "capi-system",
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			Ingress: []networkingv1.NetworkPolicyIngressRule{{}},
+		},
+	}
+	envtest.CreateObjs(ctx, t, c, namespace("capi-system"), allowAll)
+	t.Cleanup(func() {
+		for _, ns := range networkpolicy.Namespaces(spec.Cluster) {
+			g.Expect(client.IgnoreNotFound(c.DeleteAllOf(ctx, &networkingv1.NetworkPolicy{}, client.InNamespace(ns)))).To(Succeed())
+		}
+	})
+
+	r := networkpolicy.New()
+	g.Expect(r.Reconcile(ctx, test.NewNullLogger(), c, spec)).To(Equal(controller.Result{}))
+
+	for _, ns := range networkpolicy.Namespaces(spec.Cluster) {
+		p := &networkingv1.NetworkPolicy{}
+		g.Expect(env.APIReader().Get(ctx, types.NamespacedName{Name: "eksa-baseline", Namespace: ns}, p)).To(Succeed())
+		g.Expect(p.Spec.PolicyTypes).To(ConsistOf(networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress))
+	}
+
+	capv := &networkingv1.NetworkPolicy{}
+	g.Expect(env.APIReader().Get(ctx, types.NamespacedName{Name: "eksa-baseline", Namespace: "capv-system"}, capv)).To(Succeed())
+	g.Expect(capv.Spec.Ingress).To(HaveLen(3))
+	g.Expect(capv.Spec.Ingress[2].Ports).To(Equal(tcpPorts(8080)))
+
+	err := env.APIReader().Get(ctx, client.ObjectKeyFromObject(allowAll), &networkingv1.NetworkPolicy{})
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue(), "allow-all NetworkPolicy should be deleted")
+}
+
+func TestReconcilerReconcileWorkloadCluster(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	c := env.Client()
+	spec := baselineSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = "workload"
+		s.Cluster.Spec.ManagementCluster.Name = "mgmt"
+		s.Cluster.Spec.DatacenterRef.Kind = anywherev1.DockerDatacenterKind
+	})
+
+	r := networkpolicy.New()
+	g.Expect(r.Reconcile(ctx, test.NewNullLogger(), c, spec)).To(Equal(controller.Result{}))
+
+	err := env.APIReader().Get(ctx, types.NamespacedName{Name: "eksa-baseline", Namespace: "capd-system"}, &networkingv1.NetworkPolicy{})
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue(), "baseline shouldn't be applied to workload clusters")
+}
+
+func TestReconcilerReconcileErrorGeneratingBaseline(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	spec := baselineSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.RegistryMirrorConfiguration = &anywherev1.RegistryMirrorConfiguration{
+			Endpoint: "1.2.3.4",
+			Port:     "port",
+		}
+	})
+
+	r := networkpolicy.New()
+	_, err := r.Reconcile(ctx, test.NewNullLogger(), env.Client(), spec)
+	g.Expect(err).To(MatchError(ContainSubstring("generating NetworkPolicy baseline")))
+}
+
+func namespace(name string) *corev1.Namespace {
+	return &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+	}
+}
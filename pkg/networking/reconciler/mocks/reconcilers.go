@@ -52,3 +52,79 @@ func (mr *MockCiliumReconcilerMockRecorder) Reconcile(ctx, logger, client, spec
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockCiliumReconciler)(nil).Reconcile), ctx, logger, client, spec)
 }
+
+// MockCustomCNIReconciler is a mock of CustomCNIReconciler interface.
+type MockCustomCNIReconciler struct {
+	ctrl     *gomock.Controller
+	recorder *MockCustomCNIReconcilerMockRecorder
+}
+
+// MockCustomCNIReconcilerMockRecorder is the mock recorder for MockCustomCNIReconciler.
+type MockCustomCNIReconcilerMockRecorder struct {
+	mock *MockCustomCNIReconciler
+}
+
+// NewMockCustomCNIReconciler creates a new mock instance.
+func NewMockCustomCNIReconciler(ctrl *gomock.Controller) *MockCustomCNIReconciler {
+	mock := &MockCustomCNIReconciler{ctrl: ctrl}
+	mock.recorder = &MockCustomCNIReconcilerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCustomCNIReconciler) EXPECT() *MockCustomCNIReconcilerMockRecorder {
+	return m.recorder
+}
+
+// Reconcile mocks base method.
+func (m *MockCustomCNIReconciler) Reconcile(ctx context.Context, logger logr.Logger, client client.Client, spec *cluster.Spec) (controller.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reconcile", ctx, logger, client, spec)
+	ret0, _ := ret[0].(controller.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reconcile indicates an expected call of Reconcile.
+func (mr *MockCustomCNIReconcilerMockRecorder) Reconcile(ctx, logger, client, spec interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockCustomCNIReconciler)(nil).Reconcile), ctx, logger, client, spec)
+}
+
+// MockNetworkPolicyReconciler is a mock of NetworkPolicyReconciler interface.
+type MockNetworkPolicyReconciler struct {
+	ctrl     *gomock.Controller
+	recorder *MockNetworkPolicyReconcilerMockRecorder
+}
+
+// MockNetworkPolicyReconcilerMockRecorder is the mock recorder for MockNetworkPolicyReconciler.
+type MockNetworkPolicyReconcilerMockRecorder struct {
+	mock *MockNetworkPolicyReconciler
+}
+
+// NewMockNetworkPolicyReconciler creates a new mock instance.
+func NewMockNetworkPolicyReconciler(ctrl *gomock.Controller) *MockNetworkPolicyReconciler {
+	mock := &MockNetworkPolicyReconciler{ctrl: ctrl}
+	mock.recorder = &MockNetworkPolicyReconcilerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNetworkPolicyReconciler) EXPECT() *MockNetworkPolicyReconcilerMockRecorder {
+	return m.recorder
+}
+
+// Reconcile mocks base method.
+func (m *MockNetworkPolicyReconciler) Reconcile(ctx context.Context, logger logr.Logger, client client.Client, spec *cluster.Spec) (controller.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reconcile", ctx, logger, client, spec)
+	ret0, _ := ret[0].(controller.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reconcile indicates an expected call of Reconcile.
+func (mr *MockNetworkPolicyReconcilerMockRecorder) Reconcile(ctx, logger, client, spec interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockNetworkPolicyReconciler)(nil).Reconcile), ctx, logger, client, spec)
+}
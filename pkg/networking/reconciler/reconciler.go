@@ -15,24 +15,51 @@ type CiliumReconciler interface {
 	Reconcile(ctx context.Context, logger logr.Logger, client client.Client, spec *cluster.Spec) (controller.Result, error)
 }
 
+// CustomCNIReconciler reconciles a CNI provided by the user.
+type CustomCNIReconciler interface {
+	Reconcile(ctx context.Context, logger logr.Logger, client client.Client, spec *cluster.Spec) (controller.Result, error)
+}
+
+// NetworkPolicyReconciler reconciles the NetworkPolicy baseline for the management namespaces.
+type NetworkPolicyReconciler interface {
+	Reconcile(ctx context.Context, logger logr.Logger, client client.Client, spec *cluster.Spec) (controller.Result, error)
+}
+
 type Reconciler struct {
-	ciliumReconciler CiliumReconciler
+	ciliumReconciler        CiliumReconciler
+	customCNIReconciler     CustomCNIReconciler
+	networkPolicyReconciler NetworkPolicyReconciler
 }
 
-func New(ciliumReconciler CiliumReconciler) *Reconciler {
+func New(ciliumReconciler CiliumReconciler, customCNIReconciler CustomCNIReconciler, networkPolicyReconciler NetworkPolicyReconciler) *Reconciler {
 	return &Reconciler{
-		ciliumReconciler: ciliumReconciler,
+		ciliumReconciler:        ciliumReconciler,
+		customCNIReconciler:     customCNIReconciler,
+		networkPolicyReconciler: networkPolicyReconciler,
 	}
 }
 
 // Reconcile takes the specified CNI in a cluster to the desired state defined in a cluster Spec
 // It uses a controller.Result to indicate when requeues are needed
 // Intended to be used in a kubernetes controller
-// Only Cilium CNI is supported for now.
+// Only Cilium and custom CNIs are supported for now.
+// Once the CNI is ready, it reconciles the NetworkPolicy baseline.
 func (r *Reconciler) Reconcile(ctx context.Context, logger logr.Logger, client client.Client, spec *cluster.Spec) (controller.Result, error) {
-	if spec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium != nil {
+	result, err := r.reconcileCNI(ctx, logger, client, spec)
+	if err != nil || result.Return() {
+		return result, err
+	}
+
+	return r.networkPolicyReconciler.Reconcile(ctx, logger, client, spec)
+}
+
+func (r *Reconciler) reconcileCNI(ctx context.Context, logger logr.Logger, client client.Client, spec *cluster.Spec) (controller.Result, error) {
+	switch {
+	case spec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium != nil:
 		return r.ciliumReconciler.Reconcile(ctx, logger, client, spec)
-	} else {
-		return controller.Result{}, errors.New("unsupported CNI, only Cilium is supported at this time")
+	case spec.Cluster.Spec.ClusterNetwork.CNIConfig.IsCustom():
+		return r.customCNIReconciler.Reconcile(ctx, logger, client, spec)
+	default:
+		return controller.Result{}, errors.New("unsupported CNI, only Cilium and custom CNIs are supported at this time")
 	}
 }
@@ -2,7 +2,9 @@ package reconciler_test
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
@@ -30,8 +32,10 @@ func TestReconcilerReconcileCilium(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	ciliumReconciler := mocks.NewMockCiliumReconciler(ctrl)
 	ciliumReconciler.EXPECT().Reconcile(ctx, logger, client, spec)
+	networkPolicyReconciler := mocks.NewMockNetworkPolicyReconciler(ctrl)
+	networkPolicyReconciler.EXPECT().Reconcile(ctx, logger, client, spec)
 
-	r := reconciler.New(ciliumReconciler)
+	r := reconciler.New(ciliumReconciler, mocks.NewMockCustomCNIReconciler(ctrl), networkPolicyReconciler)
 	result, err := r.Reconcile(ctx, logger, client, spec)
 	g.Expect(result).To(Equal(controller.Result{}))
 	g.Expect(err).NotTo(HaveOccurred())
@@ -49,7 +53,73 @@ func TestReconcilerReconcileUnsupportedCNI(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	ciliumReconciler := mocks.NewMockCiliumReconciler(ctrl)
 
-	r := reconciler.New(ciliumReconciler)
+	r := reconciler.New(ciliumReconciler, mocks.NewMockCustomCNIReconciler(ctrl), mocks.NewMockNetworkPolicyReconciler(ctrl))
 	_, err := r.Reconcile(ctx, logger, client, spec)
-	g.Expect(err).To(MatchError(ContainSubstring("unsupported CNI, only Cilium is supported at this time")))
+	g.Expect(err).To(MatchError(ContainSubstring("unsupported CNI, only Cilium and custom CNIs are supported at this time")))
+}
+
+func TestReconcilerReconcileCustomCNI(t *testing.T) {
+	ctx := context.Background()
+	logger := test.NewNullLogger()
+	client := fake.NewClientBuilder().Build()
+	spec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.ClusterNetwork.CNIConfig = &v1alpha1.CNIConfig{
+			Custom: &v1alpha1.CustomCNIConfig{},
+		}
+	})
+
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	customCNIReconciler := mocks.NewMockCustomCNIReconciler(ctrl)
+	customCNIReconciler.EXPECT().Reconcile(ctx, logger, client, spec)
+	networkPolicyReconciler := mocks.NewMockNetworkPolicyReconciler(ctrl)
+	networkPolicyReconciler.EXPECT().Reconcile(ctx, logger, client, spec)
+
+	r := reconciler.New(mocks.NewMockCiliumReconciler(ctrl), customCNIReconciler, networkPolicyReconciler)
+	result, err := r.Reconcile(ctx, logger, client, spec)
+	g.Expect(result).To(Equal(controller.Result{}))
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestReconcilerReconcileCNINotReady(t *testing.T) {
+	ctx := context.Background()
+	logger := test.NewNullLogger()
+	client := fake.NewClientBuilder().Build()
+	spec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.ClusterNetwork.CNIConfig = &v1alpha1.CNIConfig{
+			Cilium: &v1alpha1.CiliumConfig{},
+		}
+	})
+
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	ciliumReconciler := mocks.NewMockCiliumReconciler(ctrl)
+	ciliumReconciler.EXPECT().Reconcile(ctx, logger, client, spec).Return(controller.ResultWithRequeue(10*time.Second), nil)
+
+	r := reconciler.New(ciliumReconciler, mocks.NewMockCustomCNIReconciler(ctrl), mocks.NewMockNetworkPolicyReconciler(ctrl))
+	result, err := r.Reconcile(ctx, logger, client, spec)
+	g.Expect(result).To(Equal(controller.ResultWithRequeue(10 * time.Second)))
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestReconcilerReconcileNetworkPolicyError(t *testing.T) {
+	ctx := context.Background()
+	logger := test.NewNullLogger()
+	client := fake.NewClientBuilder().Build()
+	spec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.ClusterNetwork.CNIConfig = &v1alpha1.CNIConfig{
+			Cilium: &v1alpha1.CiliumConfig{},
+		}
+	})
+
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	ciliumReconciler := mocks.NewMockCiliumReconciler(ctrl)
+	ciliumReconciler.EXPECT().Reconcile(ctx, logger, client, spec)
+	networkPolicyReconciler := mocks.NewMockNetworkPolicyReconciler(ctrl)
+	networkPolicyReconciler.EXPECT().Reconcile(ctx, logger, client, spec).Return(controller.Result{}, errors.New("applying policies"))
+
+	r := reconciler.New(ciliumReconciler, mocks.NewMockCustomCNIReconciler(ctrl), networkPolicyReconciler)
+	_, err := r.Reconcile(ctx, logger, client, spec)
+	g.Expect(err).To(MatchError(ContainSubstring("applying policies")))
 }
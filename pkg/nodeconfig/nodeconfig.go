@@ -0,0 +1,136 @@
+package nodeconfig
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	kubeadmv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/controller/reconcileutil"
+)
+
+const (
+	// KubeletConfigurationPath is the path of the kubelet configuration patch on the node
+	// when the node group sets a KubeletConfiguration.
+	KubeletConfigurationPath = "/etc/kubernetes/patches/kubeletconfiguration0+strategic.yaml"
+
+	nodeLabelsArg      = "node-labels"
+	bottlerocketFormat = "bottlerocket"
+)
+
+// Config is the configuration of a worker node that can be applied to a running node
+// without replacing its machine.
+type Config struct {
+	Labels               map[string]string      `json:"labels,omitempty"`
+	Taints               []corev1.Taint         `json:"taints,omitempty"`
+	KubeletConfiguration string                 `json:"kubeletConfiguration,omitempty"`
+	NTPServers           []string               `json:"ntpServers,omitempty"`
+	CertBundles          []kubeadmv1.CertBundle `json:"certBundles,omitempty"`
+	Bottlerocket         bool                   `json:"bottlerocket,omitempty"`
+}
+
+// FromKubeadmConfigSpec extracts the in place updatable configuration from the kubeadm
+// config spec used to bootstrap a worker node.
+func FromKubeadmConfigSpec(spec *kubeadmv1.KubeadmConfigSpec) Config {
+	c := Config{
+		Bottlerocket: string(spec.Format) == bottlerocketFormat,
+	}
+
+	if join := spec.JoinConfiguration; join != nil {
+		c.Labels = parseNodeLabels(join.NodeRegistration.KubeletExtraArgs[nodeLabelsArg])
+		c.Taints = join.NodeRegistration.Taints
+		c.CertBundles = join.CertBundles
+	}
+
+	for _, f := range spec.Files {
+		if f.Path == KubeletConfigurationPath {
+			c.KubeletConfiguration = f.Content
+		}
+	}
+
+	if spec.NTP != nil && (spec.NTP.Enabled == nil || *spec.NTP.Enabled) {
+		c.NTPServers = spec.NTP.Servers
+	}
+
+	return c
+}
+
+// Hash returns a short identifier for the whole configuration.
+func (c Config) Hash() string {
+	return hash(c)
+}
+
+// HostHash returns a short identifier for the part of the configuration that lives
+// in the node's host files, as opposed to the Node object.
+func (c Config) HostHash() string {
+	return hash(c.host())
+}
+
+// HasHostConfig returns true if the configuration includes any host file setting.
+func (c Config) HasHostConfig() bool {
+	h := c.host()
+	return h.KubeletConfiguration != "" || len(h.NTPServers) > 0 || len(h.CertBundles) > 0
+}
+
+func (c Config) host() Config {
+	return Config{
+		KubeletConfiguration: c.KubeletConfiguration,
+		NTPServers:           c.NTPServers,
+		CertBundles:          c.CertBundles,
+		Bottlerocket:         c.Bottlerocket,
+	}
+}
+
+// WithoutInPlaceFields returns a copy of spec without the fields that can be updated in place.
+// Two specs that are equal once these fields are removed only differ in changes that don't
+// require replacing the machines.
+// The kubelet configuration file is kept with empty content so adding or removing it
+// still requires a replacement, since that changes the kubelet flags.
+func WithoutInPlaceFields(spec *kubeadmv1.KubeadmConfigSpec) *kubeadmv1.KubeadmConfigSpec {
+	s := spec.DeepCopy()
+	s.NTP = nil
+	if s.JoinConfiguration != nil {
+		s.JoinConfiguration.NodeRegistration.Taints = nil
+		s.JoinConfiguration.CertBundles = nil
+		delete(s.JoinConfiguration.NodeRegistration.KubeletExtraArgs, nodeLabelsArg)
+	}
+	for i := range s.Files {
+		if s.Files[i].Path == KubeletConfigurationPath {
+			s.Files[i].Content = ""
+		}
+	}
+
+	return s
+}
+
+func parseNodeLabels(arg string) map[string]string {
+	if arg == "" {
+		return nil
+	}
+
+	labels := map[string]string{}
+	for _, l := range strings.Split(arg, ",") {
+		k, v, _ := strings.Cut(l, "=")
+		labels[k] = v
+	}
+
+	return labels
+}
+
+func hash(c Config) string {
+	taints := append([]corev1.Taint(nil), c.Taints...)
+	sort.Slice(taints, func(i, j int) bool {
+		return taints[i].Key+string(taints[i].Effect) < taints[j].Key+string(taints[j].Effect)
+	})
+	c.Taints = taints
+	for i := range c.Taints {
+		// TimeAdded is set by the API server and doesn't change the configuration.
+		c.Taints[i].TimeAdded = nil
+	}
+
+	// Marshalling maps sorts their keys, so the result is stable.
+	b, _ := json.Marshal(c)
+	return reconcileutil.ShortHash(b)
+}
@@ -0,0 +1,123 @@
+package nodeconfig_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	kubeadmv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/nodeconfig"
+)
+
+func kubeadmConfigSpec() *kubeadmv1.KubeadmConfigSpec {
+	return &kubeadmv1.KubeadmConfigSpec{
+		JoinConfiguration: &kubeadmv1.JoinConfiguration{
+			NodeRegistration: kubeadmv1.NodeRegistrationOptions{
+				KubeletExtraArgs: map[string]string{
+					"node-labels": "group=md-0,tier=frontend",
+					"provider-id": "id",
+				},
+				Taints: []corev1.Taint{
+					{Key: "dedicated", Value: "md-0", Effect: corev1.TaintEffectNoSchedule},
+				},
+			},
+			CertBundles: []kubeadmv1.CertBundle{
+				{Name: "my-ca", Data: "cert"},
+			},
+		},
+		Files: []kubeadmv1.File{
+			{Path: nodeconfig.KubeletConfigurationPath, Content: "maxPods: 20"},
+			{Path: "/etc/other", Content: "other"},
+		},
+		NTP: &kubeadmv1.NTP{
+			Servers: []string{"time.example.com"},
+		},
+	}
+}
+
+func TestFromKubeadmConfigSpec(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(nodeconfig.FromKubeadmConfigSpec(kubeadmConfigSpec())).To(Equal(nodeconfig.Config{
+		Labels: map[string]string{"group": "md-0", "tier": "frontend"},
+		Taints: []corev1.Taint{
+			{Key: "dedicated", Value: "md-0", Effect: corev1.TaintEffectNoSchedule},
+		},
+		KubeletConfiguration: "maxPods: 20",
+		NTPServers:           []string{"time.example.com"},
+		CertBundles:          []kubeadmv1.CertBundle{{Name: "my-ca", Data: "cert"}},
+	}))
+}
+
+func TestFromKubeadmConfigSpecNTPDisabled(t *testing.T) {
+	g := NewWithT(t)
+	spec := kubeadmConfigSpec()
+	spec.NTP.Enabled = pointer.Bool(false)
+	spec.Format = "bottlerocket"
+
+	c := nodeconfig.FromKubeadmConfigSpec(spec)
+	g.Expect(c.NTPServers).To(BeEmpty())
+	g.Expect(c.Bottlerocket).To(BeTrue())
+}
+
+func TestConfigHash(t *testing.T) {
+	g := NewWithT(t)
+	c := nodeconfig.Config{
+		Labels: map[string]string{"group": "md-0"},
+		Taints: []corev1.Taint{
+			{Key: "a", Effect: corev1.TaintEffectNoSchedule},
+			{Key: "b", Effect: corev1.TaintEffectNoExecute},
+		},
+		NTPServers: []string{"time.example.com"},
+	}
+
+	// The taints order and the time they were added don't change the config
+	reordered := c
+	now := metav1.Now()
+	reordered.Taints = []corev1.Taint{
+		{Key: "b", Effect: corev1.TaintEffectNoExecute, TimeAdded: &now},
+		{Key: "a", Effect: corev1.TaintEffectNoSchedule},
+	}
+	g.Expect(reordered.Hash()).To(Equal(c.Hash()))
+	g.Expect(reordered.Taints[0].TimeAdded).NotTo(BeNil())
+
+	// Labels and taints are not part of the host config
+	labelsChanged := c
+	labelsChanged.Labels = map[string]string{"group": "md-1"}
+	g.Expect(labelsChanged.Hash()).NotTo(Equal(c.Hash()))
+	g.Expect(labelsChanged.HostHash()).To(Equal(c.HostHash()))
+
+	ntpChanged := c
+	ntpChanged.NTPServers = []string{"time2.example.com"}
+	g.Expect(ntpChanged.HostHash()).NotTo(Equal(c.HostHash()))
+}
+
+func TestConfigHasHostConfig(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(nodeconfig.Config{Labels: map[string]string{"group": "md-0"}}.HasHostConfig()).To(BeFalse())
+	g.Expect(nodeconfig.Config{KubeletConfiguration: "maxPods: 20"}.HasHostConfig()).To(BeTrue())
+	g.Expect(nodeconfig.Config{NTPServers: []string{"time.example.com"}}.HasHostConfig()).To(BeTrue())
+	g.Expect(nodeconfig.Config{CertBundles: []kubeadmv1.CertBundle{{Name: "my-ca"}}}.HasHostConfig()).To(BeTrue())
+}
+
+func TestWithoutInPlaceFields(t *testing.T) {
+	g := NewWithT(t)
+	spec := kubeadmConfigSpec()
+
+	got := nodeconfig.WithoutInPlaceFields(spec)
+	g.Expect(got.NTP).To(BeNil())
+	g.Expect(got.JoinConfiguration.NodeRegistration.Taints).To(BeNil())
+	g.Expect(got.JoinConfiguration.CertBundles).To(BeNil())
+	g.Expect(got.JoinConfiguration.NodeRegistration.KubeletExtraArgs).To(Equal(map[string]string{"provider-id": "id"}))
+	g.Expect(got.Files).To(Equal([]kubeadmv1.File{
+		{Path: nodeconfig.KubeletConfigurationPath},
+		{Path: "/etc/other", Content: "other"},
+	}))
+
+	// The original spec is not modified
+	g.Expect(spec).To(Equal(kubeadmConfigSpec()))
+}
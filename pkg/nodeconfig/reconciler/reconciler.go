@@ -0,0 +1,492 @@
+package reconciler
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	kubeadmv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	anywhereCluster "github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
+	"github.com/aws/eks-anywhere/pkg/controller/clusters"
+	"github.com/aws/eks-anywhere/pkg/controller/reconcileutil"
+	"github.com/aws/eks-anywhere/pkg/nodeconfig"
+	"github.com/aws/eks-anywhere/pkg/nodeupgrader"
+)
+
+const (
+	// ConfigHashAnnotation holds the hash of the node config applied to all the nodes of a machine deployment.
+	ConfigHashAnnotation = "anywhere.eks.amazonaws.com/node-config-hash"
+
+	// AppliedConfigAnnotation holds the node config applied in place to a node: its hash and the
+	// labels and taints managed by EKS Anywhere, so they can be removed when they change.
+	AppliedConfigAnnotation = "anywhere.eks.amazonaws.com/node-config"
+
+	hostHashLabel       = "anywhere.eks.amazonaws.com/node-config-host-hash"
+	requeueAfter        = 10 * time.Second
+	failureRequeueAfter = time.Minute
+)
+
+// RemoteClientRegistry defines methods for remote cluster controller clients.
+type RemoteClientRegistry interface {
+	GetClient(ctx context.Context, cluster client.ObjectKey) (client.Client, error)
+}
+
+// Reconciler applies the worker node configuration changes that don't require a machine replacement
+// to the running nodes of a cluster.
+type Reconciler struct {
+	client               client.Client
+	remoteClientRegistry RemoteClientRegistry
+}
+
+// New returns a new Reconciler.
+func New(client client.Client, remoteClientRegistry RemoteClientRegistry) *Reconciler {
+	return &Reconciler{
+		client:               client,
+		remoteClientRegistry: remoteClientRegistry,
+	}
+}
+
+// appliedConfig is the record of the node config applied to a node.
+type appliedConfig struct {
+	Hash     string            `json:"hash"`
+	HostHash string            `json:"hostHash"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Taints   []corev1.Taint    `json:"taints,omitempty"`
+}
+
+func newAppliedConfig(c nodeconfig.Config) appliedConfig {
+	return appliedConfig{
+		Hash:     c.Hash(),
+		HostHash: c.HostHash(),
+		Labels:   c.Labels,
+		Taints:   c.Taints,
+	}
+}
+
+// Reconcile makes sure every running worker node has the labels, taints, kubelet configuration,
+// NTP servers and cert bundles of the KubeadmConfigTemplate of its machine deployment.
+// The KubeadmConfigTemplates are updated in place by the provider reconcilers when these are the only
+// changes, so the machines are not rolled out. Labels and taints are updated through the Node API
+// and host files through a privileged pod, one node at a time per machine deployment.
+// Progress is reported through the NodeConfigUpdated condition.
+// It uses a controller.Result to indicate when requeues are needed.
+func (r *Reconciler) Reconcile(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) (controller.Result, error) {
+	mds := &clusterv1.MachineDeploymentList{}
+	if err := r.client.List(ctx, mds,
+		client.InNamespace(constants.EksaSystemNamespace),
+		client.MatchingLabels{clusterv1.ClusterNameLabel: cluster.Name},
+	); err != nil {
+		return controller.Result{}, errors.Wrap(err, "listing machine deployments")
+	}
+
+	if len(mds.Items) == 0 {
+		return controller.Result{}, nil
+	}
+
+	var pending []pendingMachineDeployment
+	for i := range mds.Items {
+		md := &mds.Items[i]
+		p, err := r.pendingUpdate(ctx, md)
+		if err != nil {
+			return controller.Result{}, err
+		}
+		if p != nil {
+			pending = append(pending, *p)
+		}
+	}
+
+	if len(pending) == 0 {
+		conditions.MarkTrue(cluster, anywherev1.NodeConfigUpdatedCondition)
+		return controller.Result{}, nil
+	}
+
+	log.Info("Worker node configuration has changed, updating it in place")
+	conditions.MarkFalse(cluster, anywherev1.NodeConfigUpdatedCondition, anywherev1.NodeConfigUpdateInProgressReason, clusterv1.ConditionSeverityInfo, "Waiting for control plane to be ready")
+
+	result, err := clusters.CheckControlPlaneReady(ctx, r.client, log, cluster)
+	if err != nil {
+		return controller.Result{}, errors.Wrap(err, "checking controlplane ready")
+	}
+	if result.Return() {
+		return result, nil
+	}
+
+	spec, err := anywhereCluster.BuildSpec(ctx, clientutil.NewKubeClient(r.client), cluster)
+	if err != nil {
+		return controller.Result{}, err
+	}
+	image := spec.RootVersionsBundle().Upgrader.Upgrader.VersionedImage()
+
+	rClient, err := r.remoteClientRegistry.GetClient(ctx, controller.CapiClusterObjectKey(cluster))
+	if err != nil {
+		return controller.Result{}, errors.Wrap(err, "getting workload cluster's client to update node config")
+	}
+
+	if err := reconcileutil.EnsureNamespace(ctx, rClient, constants.EksaSystemNamespace); err != nil {
+		return controller.Result{}, err
+	}
+
+	total := reconcileutil.NodeUpdateProgress{}
+	for _, p := range pending {
+		progress, err := r.updateMachineDeployment(ctx, log, rClient, image, p)
+		if err != nil {
+			return controller.Result{}, err
+		}
+		total.Add(progress)
+	}
+
+	if len(total.Failed) > 0 {
+		sort.Strings(total.Failed)
+		conditions.MarkFalse(cluster, anywherev1.NodeConfigUpdatedCondition, anywherev1.NodeConfigUpdateFailedReason, clusterv1.ConditionSeverityError,
+			"Updating node config failed on nodes: %s", strings.Join(total.Failed, ", "))
+		return controller.ResultWithRequeue(failureRequeueAfter), nil
+	}
+
+	if total.Updated < total.Total {
+		conditions.MarkFalse(cluster, anywherev1.NodeConfigUpdatedCondition, anywherev1.NodeConfigUpdateInProgressReason, clusterv1.ConditionSeverityInfo,
+			"%d of %d worker nodes have the new node config", total.Updated, total.Total)
+		return controller.ResultWithRequeue(requeueAfter), nil
+	}
+
+	log.Info("Node config updated on all worker nodes")
+	conditions.MarkTrue(cluster, anywherev1.NodeConfigUpdatedCondition)
+
+	return controller.Result{}, nil
+}
+
+type pendingMachineDeployment struct {
+	md       *clusterv1.MachineDeployment
+	desired  nodeconfig.Config
+	machines []*clusterv1.Machine
+}
+
+// pendingUpdate returns the machine deployment with the node config its nodes should have, or nil
+// if all the nodes are known to have it already.
+func (r *Reconciler) pendingUpdate(ctx context.Context, md *clusterv1.MachineDeployment) (*pendingMachineDeployment, error) {
+	configRef := md.Spec.Template.Spec.Bootstrap.ConfigRef
+	if configRef == nil || configRef.Kind != "KubeadmConfigTemplate" {
+		return nil, nil
+	}
+
+	kct := &kubeadmv1.KubeadmConfigTemplate{}
+	if err := r.client.Get(ctx, client.ObjectKey{Name: configRef.Name, Namespace: md.Namespace}, kct); err != nil {
+		return nil, errors.Wrapf(err, "getting KubeadmConfigTemplate %s", configRef.Name)
+	}
+	desired := nodeconfig.FromKubeadmConfigSpec(&kct.Spec.Template.Spec)
+	hash := desired.Hash()
+
+	if md.Annotations[ConfigHashAnnotation] == hash {
+		return nil, nil
+	}
+
+	machines := &clusterv1.MachineList{}
+	if err := r.client.List(ctx, machines,
+		client.InNamespace(md.Namespace),
+		client.MatchingLabels{clusterv1.MachineDeploymentNameLabel: md.Name},
+	); err != nil {
+		return nil, errors.Wrapf(err, "listing machines for machine deployment %s", md.Name)
+	}
+
+	p := &pendingMachineDeployment{md: md, desired: desired}
+	upToDate := true
+	for i := range machines.Items {
+		m := &machines.Items[i]
+		if !m.DeletionTimestamp.IsZero() || m.Status.NodeRef == nil {
+			continue
+		}
+		p.machines = append(p.machines, m)
+
+		bootstrapped, err := r.bootstrappedConfig(ctx, m)
+		if err != nil {
+			return nil, err
+		}
+		if bootstrapped.Hash() != hash {
+			upToDate = false
+		}
+	}
+
+	if upToDate {
+		// Nodes with an in place update have been bootstrapped with an older config, so this only
+		// happens when all of them were created with the current one.
+		return nil, reconcileutil.SetAnnotation(ctx, r.client, md, ConfigHashAnnotation, hash)
+	}
+
+	return p, nil
+}
+
+// bootstrappedConfig returns the node config a machine was bootstrapped with.
+func (r *Reconciler) bootstrappedConfig(ctx context.Context, m *clusterv1.Machine) (nodeconfig.Config, error) {
+	configRef := m.Spec.Bootstrap.ConfigRef
+	if configRef == nil {
+		return nodeconfig.Config{}, nil
+	}
+
+	kc := &kubeadmv1.KubeadmConfig{}
+	err := r.client.Get(ctx, client.ObjectKey{Name: configRef.Name, Namespace: m.Namespace}, kc)
+	if apierrors.IsNotFound(err) {
+		return nodeconfig.Config{}, nil
+	}
+	if err != nil {
+		return nodeconfig.Config{}, errors.Wrapf(err, "getting KubeadmConfig %s", configRef.Name)
+	}
+
+	return nodeconfig.FromKubeadmConfigSpec(&kc.Spec), nil
+}
+
+func (r *Reconciler) updateMachineDeployment(ctx context.Context, log logr.Logger, rClient client.Client, image string, p pendingMachineDeployment) (reconcileutil.NodeUpdateProgress, error) {
+	desired := newAppliedConfig(p.desired)
+
+	if p.desired.HasHostConfig() {
+		cm, err := hostConfigMap(p.md.Name, p.desired)
+		if err != nil {
+			return reconcileutil.NodeUpdateProgress{}, err
+		}
+		if err := reconcileutil.CreateOrUpdateConfigMap(ctx, rClient, cm); err != nil {
+			return reconcileutil.NodeUpdateProgress{}, errors.Wrapf(err, "updating node config map for machine deployment %s", p.md.Name)
+		}
+	}
+
+	progress := reconcileutil.NodeUpdateProgress{Total: len(p.machines)}
+	hostUpdateInFlight := false
+	for _, m := range p.machines {
+		node := &corev1.Node{}
+		if err := rClient.Get(ctx, client.ObjectKey{Name: m.Status.NodeRef.Name}, node); err != nil {
+			return reconcileutil.NodeUpdateProgress{}, errors.Wrapf(err, "getting node %s", m.Status.NodeRef.Name)
+		}
+
+		applied, err := r.appliedConfig(ctx, m, node)
+		if err != nil {
+			return reconcileutil.NodeUpdateProgress{}, err
+		}
+
+		if applied.Hash == desired.Hash {
+			progress.Updated++
+			continue
+		}
+
+		if applied.HostHash == desired.HostHash || !p.desired.HasHostConfig() {
+			if err := updateNode(ctx, rClient, node, applied, desired); err != nil {
+				return reconcileutil.NodeUpdateProgress{}, err
+			}
+			progress.Updated++
+			continue
+		}
+
+		pod := &corev1.Pod{}
+		podKey := client.ObjectKey{Name: nodeupgrader.NodeConfigPodName(node.Name), Namespace: constants.EksaSystemNamespace}
+		err = rClient.Get(ctx, podKey, pod)
+		if apierrors.IsNotFound(err) {
+			// Host updates restart the kubelet, so only one node of the group is updated at a time.
+			if hostUpdateInFlight {
+				continue
+			}
+			log.Info("Creating node config updater pod", "node", node.Name)
+			if err := rClient.Create(ctx, updaterPod(node.Name, image, p.md.Name, desired.HostHash, p.desired.Bottlerocket)); err != nil {
+				return reconcileutil.NodeUpdateProgress{}, errors.Wrapf(err, "creating node config updater pod for node %s", node.Name)
+			}
+			hostUpdateInFlight = true
+			continue
+		}
+		if err != nil {
+			return reconcileutil.NodeUpdateProgress{}, errors.Wrapf(err, "getting node config updater pod for node %s", node.Name)
+		}
+
+		if pod.Labels[hostHashLabel] != desired.HostHash {
+			// The pod was created for a previous config, replace it.
+			if err := reconcileutil.DeleteIgnoreNotFound(ctx, rClient, pod); err != nil {
+				return reconcileutil.NodeUpdateProgress{}, err
+			}
+			continue
+		}
+
+		switch pod.Status.Phase {
+		case corev1.PodSucceeded:
+			if err := updateNode(ctx, rClient, node, applied, desired); err != nil {
+				return reconcileutil.NodeUpdateProgress{}, err
+			}
+			if err := reconcileutil.DeleteIgnoreNotFound(ctx, rClient, pod); err != nil {
+				return reconcileutil.NodeUpdateProgress{}, err
+			}
+			progress.Updated++
+		case corev1.PodFailed:
+			log.Info("Node config updater pod failed, it will be retried", "node", node.Name)
+			progress.Failed = append(progress.Failed, node.Name)
+			if err := reconcileutil.DeleteIgnoreNotFound(ctx, rClient, pod); err != nil {
+				return reconcileutil.NodeUpdateProgress{}, err
+			}
+		default:
+			hostUpdateInFlight = true
+		}
+	}
+
+	if progress.Done() {
+		log.Info("Node config updated on all nodes of machine deployment", "machineDeployment", p.md.Name)
+		if err := reconcileutil.SetAnnotation(ctx, r.client, p.md, ConfigHashAnnotation, desired.Hash); err != nil {
+			return reconcileutil.NodeUpdateProgress{}, err
+		}
+	}
+
+	return progress, nil
+}
+
+// appliedConfig returns the node config applied to a node, either in place or when it was bootstrapped.
+func (r *Reconciler) appliedConfig(ctx context.Context, m *clusterv1.Machine, node *corev1.Node) (appliedConfig, error) {
+	if a, ok := node.Annotations[AppliedConfigAnnotation]; ok {
+		applied := appliedConfig{}
+		if err := json.Unmarshal([]byte(a), &applied); err == nil {
+			return applied, nil
+		}
+	}
+
+	bootstrapped, err := r.bootstrappedConfig(ctx, m)
+	if err != nil {
+		return appliedConfig{}, err
+	}
+
+	return newAppliedConfig(bootstrapped), nil
+}
+
+// updateNode replaces the labels and taints previously managed by EKS Anywhere with the desired ones
+// and records the applied config in the node.
+func updateNode(ctx context.Context, c client.Client, node *corev1.Node, applied, desired appliedConfig) error {
+	patch := client.MergeFrom(node.DeepCopy())
+
+	if node.Labels == nil {
+		node.Labels = map[string]string{}
+	}
+	for k := range applied.Labels {
+		if _, ok := desired.Labels[k]; !ok {
+			delete(node.Labels, k)
+		}
+	}
+	for k, v := range desired.Labels {
+		node.Labels[k] = v
+	}
+
+	taints := make([]corev1.Taint, 0, len(node.Spec.Taints)+len(desired.Taints))
+	for _, t := range node.Spec.Taints {
+		if !containsTaint(applied.Taints, t) && !containsTaint(desired.Taints, t) {
+			taints = append(taints, t)
+		}
+	}
+	node.Spec.Taints = append(taints, desired.Taints...)
+
+	a, err := json.Marshal(desired)
+	if err != nil {
+		return errors.Wrap(err, "marshalling applied node config")
+	}
+	if node.Annotations == nil {
+		node.Annotations = map[string]string{}
+	}
+	node.Annotations[AppliedConfigAnnotation] = string(a)
+
+	if err := c.Patch(ctx, node, patch); err != nil {
+		return errors.Wrapf(err, "updating node %s with node config", node.Name)
+	}
+
+	return nil
+}
+
+// containsTaint checks if a taint with the same key and effect is in taints.
+func containsTaint(taints []corev1.Taint, t corev1.Taint) bool {
+	for _, taint := range taints {
+		if taint.MatchTaint(&t) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func updaterPod(nodeName, image, mdName, hostHash string, bottlerocket bool) *corev1.Pod {
+	var pod *corev1.Pod
+	if bottlerocket {
+		pod = nodeupgrader.UpdateBottlerocketNodeConfigPod(nodeName, image, configMapName(mdName))
+	} else {
+		pod = nodeupgrader.UpdateNodeConfigPod(nodeName, image, configMapName(mdName))
+	}
+	pod.Labels[hostHashLabel] = hostHash
+
+	return pod
+}
+
+func configMapName(mdName string) string {
+	return fmt.Sprintf("%s-node-config", mdName)
+}
+
+func hostConfigMap(mdName string, c nodeconfig.Config) (*corev1.ConfigMap, error) {
+	data := map[string]string{}
+	if c.Bottlerocket {
+		settings, err := bottlerocketSettings(c)
+		if err != nil {
+			return nil, err
+		}
+		data[nodeupgrader.BottlerocketSettingsKey] = string(settings)
+	} else {
+		if c.KubeletConfiguration != "" {
+			data[nodeupgrader.KubeletConfigurationKey] = c.KubeletConfiguration
+		}
+		if len(c.NTPServers) > 0 {
+			data[nodeupgrader.NTPServersKey] = strings.Join(c.NTPServers, "\n") + "\n"
+		}
+	}
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      configMapName(mdName),
+			Namespace: constants.EksaSystemNamespace,
+			Labels: map[string]string{
+				hostHashLabel: c.HostHash(),
+			},
+		},
+		Data: data,
+	}, nil
+}
+
+type bottlerocketCertBundle struct {
+	Data    string `json:"data"`
+	Trusted bool   `json:"trusted"`
+}
+
+func bottlerocketSettings(c nodeconfig.Config) ([]byte, error) {
+	settings := map[string]interface{}{}
+	if len(c.NTPServers) > 0 {
+		settings["ntp"] = map[string]interface{}{
+			"time-servers": c.NTPServers,
+		}
+	}
+	if len(c.CertBundles) > 0 {
+		pki := map[string]bottlerocketCertBundle{}
+		for _, b := range c.CertBundles {
+			pki[b.Name] = bottlerocketCertBundle{
+				Data:    base64.StdEncoding.EncodeToString([]byte(b.Data)),
+				Trusted: true,
+			}
+		}
+		settings["pki"] = pki
+	}
+
+	b, err := json.Marshal(map[string]interface{}{"settings": settings})
+	if err != nil {
+		return nil, fmt.Errorf("marshalling bottlerocket node config settings: %v", err)
+	}
+
+	return b, nil
+}
@@ -0,0 +1,460 @@
+package reconciler_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	eksdv1 "github.com/aws/eks-distro-build-tooling/release/api/v1alpha1"
+	"github.com/go-logr/logr"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	kubeadmv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/nodeconfig/reconciler"
+	"github.com/aws/eks-anywhere/pkg/nodeconfig/reconciler/mocks"
+	"github.com/aws/eks-anywhere/pkg/nodeupgrader"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+const (
+	mdName  = "my-cluster-md-0"
+	kctName = "my-cluster-md-0-1"
+)
+
+type reconcilerTest struct {
+	*WithT
+	ctx                  context.Context
+	cluster              *anywherev1.Cluster
+	client               client.Client
+	remoteClient         client.Client
+	remoteClientRegistry *mocks.MockRemoteClientRegistry
+	reconciler           *reconciler.Reconciler
+}
+
+// newReconcilerTest builds a cluster with one machine deployment whose nodes were bootstrapped
+// with kubeadmConfigSpec, which is also the current KubeadmConfigTemplate spec.
+func newReconcilerTest(t *testing.T, kubeadmConfigSpec kubeadmv1.KubeadmConfigSpec, nodeNames ...string) *reconcilerTest {
+	ctrl := gomock.NewController(t)
+	remoteClientRegistry := mocks.NewMockRemoteClientRegistry(ctrl)
+
+	bundle := test.Bundle()
+	bundle.Spec.VersionsBundles[0].KubeVersion = string(anywherev1.Kube130)
+	version := test.DevEksaVersion()
+	cluster := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster",
+			Namespace: constants.EksaSystemNamespace,
+		},
+		Spec: anywherev1.ClusterSpec{
+			KubernetesVersion: anywherev1.Kube130,
+			BundlesRef: &anywherev1.BundlesRef{
+				Name:       bundle.Name,
+				Namespace:  bundle.Namespace,
+				APIVersion: bundle.APIVersion,
+			},
+			EksaVersion: &version,
+		},
+	}
+	kcp := test.KubeadmControlPlane(func(kcp *controlplanev1.KubeadmControlPlane) {
+		kcp.Name = cluster.Name
+		kcp.Spec.Version = "test"
+		kcp.Status = controlplanev1.KubeadmControlPlaneStatus{
+			Conditions: clusterv1.Conditions{
+				{
+					Type:               clusterapi.ReadyCondition,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(time.Now()),
+				},
+			},
+			Version: pointer.String("test"),
+		}
+	})
+	md := &clusterv1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      mdName,
+			Namespace: constants.EksaSystemNamespace,
+			Labels: map[string]string{
+				clusterv1.ClusterNameLabel: cluster.Name,
+			},
+		},
+		Spec: clusterv1.MachineDeploymentSpec{
+			Template: clusterv1.MachineTemplateSpec{
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						ConfigRef: &corev1.ObjectReference{
+							Kind: "KubeadmConfigTemplate",
+							Name: kctName,
+						},
+					},
+				},
+			},
+		},
+	}
+	kct := &kubeadmv1.KubeadmConfigTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      kctName,
+			Namespace: constants.EksaSystemNamespace,
+		},
+		Spec: kubeadmv1.KubeadmConfigTemplateSpec{
+			Template: kubeadmv1.KubeadmConfigTemplateResource{
+				Spec: *kubeadmConfigSpec.DeepCopy(),
+			},
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	_ = releasev1.AddToScheme(scheme)
+	_ = eksdv1.AddToScheme(scheme)
+	_ = clusterv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+	_ = controlplanev1.AddToScheme(scheme)
+	_ = kubeadmv1.AddToScheme(scheme)
+	_ = anywherev1.AddToScheme(scheme)
+
+	objs := []runtime.Object{bundle, test.EksdRelease("1-30"), test.EKSARelease(), kcp, md, kct}
+	remoteObjs := make([]runtime.Object, 0, len(nodeNames))
+	for _, name := range nodeNames {
+		objs = append(objs, machine(name), kubeadmConfig(name, kubeadmConfigSpec))
+		remoteObjs = append(remoteObjs, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}})
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objs...).Build()
+	remoteClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(remoteObjs...).Build()
+
+	return &reconcilerTest{
+		WithT:                NewWithT(t),
+		ctx:                  context.Background(),
+		cluster:              cluster,
+		client:               cl,
+		remoteClient:         remoteClient,
+		remoteClientRegistry: remoteClientRegistry,
+		reconciler:           reconciler.New(cl, remoteClientRegistry),
+	}
+}
+
+func machine(nodeName string) *clusterv1.Machine {
+	return &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      nodeName,
+			Namespace: constants.EksaSystemNamespace,
+			Labels: map[string]string{
+				clusterv1.MachineDeploymentNameLabel: mdName,
+			},
+		},
+		Spec: clusterv1.MachineSpec{
+			Bootstrap: clusterv1.Bootstrap{
+				ConfigRef: &corev1.ObjectReference{
+					Kind: "KubeadmConfig",
+					Name: nodeName,
+				},
+			},
+		},
+		Status: clusterv1.MachineStatus{
+			NodeRef: &corev1.ObjectReference{
+				Name: nodeName,
+			},
+		},
+	}
+}
+
+func kubeadmConfig(name string, spec kubeadmv1.KubeadmConfigSpec) *kubeadmv1.KubeadmConfig {
+	return &kubeadmv1.KubeadmConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+		},
+		Spec: *spec.DeepCopy(),
+	}
+}
+
+func kubeadmConfigSpec() kubeadmv1.KubeadmConfigSpec {
+	return kubeadmv1.KubeadmConfigSpec{
+		JoinConfiguration: &kubeadmv1.JoinConfiguration{
+			NodeRegistration: kubeadmv1.NodeRegistrationOptions{
+				KubeletExtraArgs: map[string]string{
+					"node-labels": "group=md-0",
+				},
+				Taints: []corev1.Taint{
+					{Key: "dedicated", Value: "md-0", Effect: corev1.TaintEffectNoSchedule},
+				},
+			},
+		},
+	}
+}
+
+func (tt *reconcilerTest) updateKubeadmConfigTemplate(update func(*kubeadmv1.KubeadmConfigSpec)) {
+	kct := &kubeadmv1.KubeadmConfigTemplate{}
+	tt.Expect(tt.client.Get(tt.ctx, client.ObjectKey{Name: kctName, Namespace: constants.EksaSystemNamespace}, kct)).To(Succeed())
+	update(&kct.Spec.Template.Spec)
+	tt.Expect(tt.client.Update(tt.ctx, kct)).To(Succeed())
+}
+
+func (tt *reconcilerTest) expectRemoteClient() {
+	tt.remoteClientRegistry.EXPECT().GetClient(tt.ctx, client.ObjectKey{Name: tt.cluster.Name, Namespace: constants.EksaSystemNamespace}).Return(tt.remoteClient, nil)
+}
+
+func (tt *reconcilerTest) setPodPhase(nodeName string, phase corev1.PodPhase) {
+	pod := &corev1.Pod{}
+	tt.Expect(tt.remoteClient.Get(tt.ctx, client.ObjectKey{Name: nodeupgrader.NodeConfigPodName(nodeName), Namespace: constants.EksaSystemNamespace}, pod)).To(Succeed())
+	pod.Status.Phase = phase
+	tt.Expect(tt.remoteClient.Status().Update(tt.ctx, pod)).To(Succeed())
+}
+
+func (tt *reconcilerTest) expectCondition(status corev1.ConditionStatus, reason string) {
+	condition := conditions.Get(tt.cluster, anywherev1.NodeConfigUpdatedCondition)
+	tt.Expect(condition).ToNot(BeNil())
+	tt.Expect(condition.Status).To(Equal(status))
+	tt.Expect(condition.Reason).To(Equal(reason))
+}
+
+func (tt *reconcilerTest) machineDeploymentHash() string {
+	md := &clusterv1.MachineDeployment{}
+	tt.Expect(tt.client.Get(tt.ctx, client.ObjectKey{Name: mdName, Namespace: constants.EksaSystemNamespace}, md)).To(Succeed())
+	return md.Annotations[reconciler.ConfigHashAnnotation]
+}
+
+func (tt *reconcilerTest) node(name string) *corev1.Node {
+	node := &corev1.Node{}
+	tt.Expect(tt.remoteClient.Get(tt.ctx, client.ObjectKey{Name: name}, node)).To(Succeed())
+	return node
+}
+
+func (tt *reconcilerTest) pods() []corev1.Pod {
+	pods := &corev1.PodList{}
+	tt.Expect(tt.remoteClient.List(tt.ctx, pods)).To(Succeed())
+	return pods.Items
+}
+
+func nullLog() logr.Logger {
+	return logr.New(logf.NullLogSink{})
+}
+
+func TestReconcileNoMachineDeployments(t *testing.T) {
+	tt := newReconcilerTest(t, kubeadmConfigSpec())
+	tt.Expect(tt.client.DeleteAllOf(tt.ctx, &clusterv1.MachineDeployment{}, client.InNamespace(constants.EksaSystemNamespace))).To(Succeed())
+
+	result, err := tt.reconciler.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+	tt.Expect(conditions.Get(tt.cluster, anywherev1.NodeConfigUpdatedCondition)).To(BeNil())
+}
+
+func TestReconcileNodesUpToDate(t *testing.T) {
+	tt := newReconcilerTest(t, kubeadmConfigSpec(), "node-1", "node-2")
+
+	result, err := tt.reconciler.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+	tt.Expect(tt.machineDeploymentHash()).NotTo(BeEmpty())
+	tt.Expect(conditions.IsTrue(tt.cluster, anywherev1.NodeConfigUpdatedCondition)).To(BeTrue())
+	tt.Expect(tt.node("node-1").Annotations).NotTo(HaveKey(reconciler.AppliedConfigAnnotation))
+}
+
+func TestReconcileUpdateLabelsAndTaints(t *testing.T) {
+	tt := newReconcilerTest(t, kubeadmConfigSpec(), "node-1", "node-2")
+	_, err := tt.reconciler.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	oldHash := tt.machineDeploymentHash()
+
+	node := tt.node("node-1")
+	node.Labels = map[string]string{"group": "md-0", "user": "label"}
+	node.Spec.Taints = []corev1.Taint{
+		{Key: "dedicated", Value: "md-0", Effect: corev1.TaintEffectNoSchedule},
+		{Key: "user", Effect: corev1.TaintEffectNoExecute},
+	}
+	tt.Expect(tt.remoteClient.Update(tt.ctx, node)).To(Succeed())
+
+	tt.updateKubeadmConfigTemplate(func(s *kubeadmv1.KubeadmConfigSpec) {
+		s.JoinConfiguration.NodeRegistration.KubeletExtraArgs["node-labels"] = "tier=frontend"
+		s.JoinConfiguration.NodeRegistration.Taints = []corev1.Taint{
+			{Key: "tier", Value: "frontend", Effect: corev1.TaintEffectPreferNoSchedule},
+		}
+	})
+
+	tt.expectRemoteClient()
+	result, err := tt.reconciler.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+	tt.Expect(tt.machineDeploymentHash()).NotTo(Equal(oldHash))
+	tt.Expect(conditions.IsTrue(tt.cluster, anywherev1.NodeConfigUpdatedCondition)).To(BeTrue())
+	tt.Expect(tt.pods()).To(BeEmpty())
+
+	// Labels and taints set by users are kept, the ones from the previous config are removed
+	node = tt.node("node-1")
+	tt.Expect(node.Labels).To(Equal(map[string]string{"tier": "frontend", "user": "label"}))
+	tt.Expect(node.Spec.Taints).To(ConsistOf(
+		corev1.Taint{Key: "user", Effect: corev1.TaintEffectNoExecute},
+		corev1.Taint{Key: "tier", Value: "frontend", Effect: corev1.TaintEffectPreferNoSchedule},
+	))
+	tt.Expect(node.Annotations).To(HaveKey(reconciler.AppliedConfigAnnotation))
+	tt.Expect(tt.node("node-2").Labels).To(Equal(map[string]string{"tier": "frontend"}))
+}
+
+func TestReconcileUpdateHostConfig(t *testing.T) {
+	tt := newReconcilerTest(t, kubeadmConfigSpec(), "node-1", "node-2")
+	_, err := tt.reconciler.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	oldHash := tt.machineDeploymentHash()
+
+	tt.updateKubeadmConfigTemplate(func(s *kubeadmv1.KubeadmConfigSpec) {
+		s.NTP = &kubeadmv1.NTP{
+			Enabled: pointer.Bool(true),
+			Servers: []string{"time.example.com", "time2.example.com"},
+		}
+	})
+
+	tt.expectRemoteClient()
+	result, err := tt.reconciler.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.ResultWithRequeue(10 * time.Second)))
+	tt.Expect(tt.machineDeploymentHash()).To(Equal(oldHash))
+	tt.expectCondition(corev1.ConditionFalse, anywherev1.NodeConfigUpdateInProgressReason)
+	tt.Expect(conditions.GetMessage(tt.cluster, anywherev1.NodeConfigUpdatedCondition)).To(Equal("0 of 2 worker nodes have the new node config"))
+
+	cm := &corev1.ConfigMap{}
+	tt.Expect(tt.remoteClient.Get(tt.ctx, client.ObjectKey{Name: "my-cluster-md-0-node-config", Namespace: constants.EksaSystemNamespace}, cm)).To(Succeed())
+	tt.Expect(cm.Data).To(Equal(map[string]string{nodeupgrader.NTPServersKey: "time.example.com\ntime2.example.com\n"}))
+
+	// Only one node of the machine deployment is updated at a time
+	pods := tt.pods()
+	tt.Expect(pods).To(HaveLen(1))
+	tt.Expect(pods[0].Spec.NodeName).To(Equal("node-1"))
+
+	tt.setPodPhase("node-1", corev1.PodSucceeded)
+	tt.expectRemoteClient()
+	_, err = tt.reconciler.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(conditions.GetMessage(tt.cluster, anywherev1.NodeConfigUpdatedCondition)).To(Equal("1 of 2 worker nodes have the new node config"))
+	tt.Expect(tt.node("node-1").Annotations).To(HaveKey(reconciler.AppliedConfigAnnotation))
+	pods = tt.pods()
+	tt.Expect(pods).To(HaveLen(1))
+	tt.Expect(pods[0].Spec.NodeName).To(Equal("node-2"))
+
+	tt.setPodPhase("node-2", corev1.PodSucceeded)
+	tt.expectRemoteClient()
+	result, err = tt.reconciler.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+	tt.Expect(tt.machineDeploymentHash()).NotTo(Equal(oldHash))
+	tt.Expect(conditions.IsTrue(tt.cluster, anywherev1.NodeConfigUpdatedCondition)).To(BeTrue())
+	tt.Expect(tt.pods()).To(BeEmpty())
+
+	// Reconciling again with the same config is a no-op
+	result, err = tt.reconciler.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestReconcileUpdateBottlerocketHostConfig(t *testing.T) {
+	spec := kubeadmConfigSpec()
+	spec.Format = "bottlerocket"
+	tt := newReconcilerTest(t, spec, "node-1")
+	_, err := tt.reconciler.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+
+	tt.updateKubeadmConfigTemplate(func(s *kubeadmv1.KubeadmConfigSpec) {
+		s.JoinConfiguration.CertBundles = []kubeadmv1.CertBundle{
+			{Name: "my-ca", Data: "cert"},
+		}
+	})
+
+	tt.expectRemoteClient()
+	_, err = tt.reconciler.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+
+	cm := &corev1.ConfigMap{}
+	tt.Expect(tt.remoteClient.Get(tt.ctx, client.ObjectKey{Name: "my-cluster-md-0-node-config", Namespace: constants.EksaSystemNamespace}, cm)).To(Succeed())
+	settings := map[string]interface{}{}
+	tt.Expect(json.Unmarshal([]byte(cm.Data[nodeupgrader.BottlerocketSettingsKey]), &settings)).To(Succeed())
+	tt.Expect(settings).To(Equal(map[string]interface{}{
+		"settings": map[string]interface{}{
+			"pki": map[string]interface{}{
+				"my-ca": map[string]interface{}{"data": "Y2VydA==", "trusted": true},
+			},
+		},
+	}))
+
+	pods := tt.pods()
+	tt.Expect(pods).To(HaveLen(1))
+	tt.Expect(pods[0].Spec.InitContainers[1].Args).To(ContainElement("apiclient"))
+}
+
+func TestReconcileUpdateHostConfigReplacesOutdatedPod(t *testing.T) {
+	tt := newReconcilerTest(t, kubeadmConfigSpec(), "node-1")
+	_, err := tt.reconciler.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+
+	tt.updateKubeadmConfigTemplate(func(s *kubeadmv1.KubeadmConfigSpec) {
+		s.NTP = &kubeadmv1.NTP{Servers: []string{"time.example.com"}}
+	})
+	tt.expectRemoteClient()
+	_, err = tt.reconciler.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(tt.pods()).To(HaveLen(1))
+
+	tt.updateKubeadmConfigTemplate(func(s *kubeadmv1.KubeadmConfigSpec) {
+		s.NTP = &kubeadmv1.NTP{Servers: []string{"time2.example.com"}}
+	})
+	tt.expectRemoteClient()
+	_, err = tt.reconciler.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+
+	// The pod created for the previous config is removed and recreated in the next reconciliation
+	tt.Expect(tt.pods()).To(BeEmpty())
+}
+
+func TestReconcileUpdateHostConfigPodFailed(t *testing.T) {
+	tt := newReconcilerTest(t, kubeadmConfigSpec(), "node-1")
+	_, err := tt.reconciler.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+
+	tt.updateKubeadmConfigTemplate(func(s *kubeadmv1.KubeadmConfigSpec) {
+		s.NTP = &kubeadmv1.NTP{Servers: []string{"time.example.com"}}
+	})
+	tt.expectRemoteClient()
+	_, err = tt.reconciler.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+
+	tt.setPodPhase("node-1", corev1.PodFailed)
+
+	tt.expectRemoteClient()
+	result, err := tt.reconciler.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.ResultWithRequeue(time.Minute)))
+	tt.expectCondition(corev1.ConditionFalse, anywherev1.NodeConfigUpdateFailedReason)
+	tt.Expect(conditions.GetMessage(tt.cluster, anywherev1.NodeConfigUpdatedCondition)).To(ContainSubstring("node-1"))
+
+	// The failed pod is removed so it gets retried in the next reconciliation
+	tt.Expect(tt.pods()).To(BeEmpty())
+}
+
+func TestReconcileRemoteClientError(t *testing.T) {
+	tt := newReconcilerTest(t, kubeadmConfigSpec(), "node-1")
+	_, err := tt.reconciler.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+
+	tt.updateKubeadmConfigTemplate(func(s *kubeadmv1.KubeadmConfigSpec) {
+		s.JoinConfiguration.NodeRegistration.Taints = nil
+	})
+
+	tt.remoteClientRegistry.EXPECT().GetClient(tt.ctx, gomock.AssignableToTypeOf(client.ObjectKey{})).Return(nil, errors.New("client error"))
+	_, err = tt.reconciler.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).To(MatchError(ContainSubstring("client error")))
+}
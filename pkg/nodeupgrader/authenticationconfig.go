@@ -0,0 +1,89 @@
+package nodeupgrader
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/pkg/authentication"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+const (
+	// AuthenticationConfigUpdaterContainerName holds the name of the container that writes
+	// the authentication config to the node.
+	AuthenticationConfigUpdaterContainerName = "authentication-config-updater"
+
+	authenticationConfigVolume     = "authentication-config"
+	hostAuthenticationConfigVolume = "host-authentication-config"
+)
+
+// AuthenticationConfigPodName returns the name of the authentication config updater pod based on the nodeName.
+func AuthenticationConfigPodName(nodeName string) string {
+	return fmt.Sprintf("%s-authentication-config", nodeName)
+}
+
+// UpdateAuthenticationConfigPod returns a pod that replaces the structured authentication config of a
+// control plane node with the one stored in secretName. The file is replaced atomically and the
+// kube-apiserver reloads it on its own, so no component needs to be restarted.
+func UpdateAuthenticationConfigPod(nodeName, image, secretName string) *corev1.Pod {
+	dirOrCreate := corev1.HostPathDirectoryOrCreate
+	script := fmt.Sprintf(`set -eu
+cp /authentication-config/%[1]s /usr/host/.%[1]s.new
+chmod 0640 /usr/host/.%[1]s.new
+mv /usr/host/.%[1]s.new /usr/host/%[1]s
+`, authentication.ConfigFileName)
+
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      AuthenticationConfigPodName(nodeName),
+			Namespace: constants.EksaSystemNamespace,
+			Labels: map[string]string{
+				"eksa-authentication-config-updater": "true",
+			},
+		},
+		Spec: corev1.PodSpec{
+			NodeName: nodeName,
+			Volumes: []corev1.Volume{
+				{
+					Name: authenticationConfigVolume,
+					VolumeSource: corev1.VolumeSource{
+						Secret: &corev1.SecretVolumeSource{
+							SecretName: secretName,
+						},
+					},
+				},
+				{
+					Name: hostAuthenticationConfigVolume,
+					VolumeSource: corev1.VolumeSource{
+						HostPath: &corev1.HostPathVolumeSource{
+							Path: authentication.HostDir,
+							Type: &dirOrCreate,
+						},
+					},
+				},
+			},
+			Containers: []corev1.Container{
+				{
+					Name:    AuthenticationConfigUpdaterContainerName,
+					Image:   image,
+					Command: []string{"sh", "-c"},
+					Args:    []string{script},
+					VolumeMounts: []corev1.VolumeMount{
+						{
+							Name:      authenticationConfigVolume,
+							MountPath: "/authentication-config",
+							ReadOnly:  true,
+						},
+						{
+							Name:      hostAuthenticationConfigVolume,
+							MountPath: "/usr/host",
+						},
+					},
+				},
+			},
+			RestartPolicy: corev1.RestartPolicyNever,
+		},
+	}
+}
@@ -0,0 +1,22 @@
+package nodeupgrader_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/nodeupgrader"
+)
+
+func TestUpdateAuthenticationConfigPod(t *testing.T) {
+	g := NewWithT(t)
+	pod := nodeupgrader.UpdateAuthenticationConfigPod(nodeName, upgraderImage, "authentication-config")
+	g.Expect(pod).ToNot(BeNil())
+	g.Expect(pod.Name).To(Equal(nodeupgrader.AuthenticationConfigPodName(nodeName)))
+
+	data, err := yaml.Marshal(pod)
+	g.Expect(err).ToNot(HaveOccurred())
+	test.AssertContentToFile(t, string(data), "testdata/expected_authentication_config_pod.yaml")
+}
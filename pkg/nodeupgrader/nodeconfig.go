@@ -0,0 +1,141 @@
+package nodeupgrader
+
+import (
+	"fmt"
+	"path/filepath"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/nodeconfig"
+)
+
+const (
+	// NodeConfigCopierContainerName holds the name of the node config copier container.
+	NodeConfigCopierContainerName = "node-config-copier"
+
+	// NodeConfigUpdaterContainerName holds the name of the container that applies
+	// the node config to the host.
+	NodeConfigUpdaterContainerName = "node-config-updater"
+
+	// NodeConfigCleanupContainerName holds the name of the container that removes
+	// the node config files copied to the node.
+	NodeConfigCleanupContainerName = "node-config-cleanup"
+
+	// KubeletConfigurationKey is the key in the node config map holding the kubelet configuration patch.
+	KubeletConfigurationKey = "kubelet-configuration.yaml"
+
+	// NTPServersKey is the key in the node config map holding the NTP servers, one per line.
+	NTPServersKey = "ntp-servers"
+
+	nodeConfigHostPath   = "/var/lib/eksa-node-config"
+	nodeConfigVolume     = "node-config"
+	hostNodeConfigVolume = "host-node-config"
+)
+
+// NodeConfigPodName returns the name of the node config updater pod based on the nodeName.
+func NodeConfigPodName(nodeName string) string {
+	return fmt.Sprintf("%s-node-config", nodeName)
+}
+
+// UpdateNodeConfigPod returns a pod that applies the host configuration stored in configMapName
+// to a node: it replaces the kubelet configuration patch and regenerates the kubelet config
+// from it, and replaces the chrony NTP servers. The affected services are restarted.
+func UpdateNodeConfigPod(nodeName, image, configMapName string) *corev1.Pod {
+	kubeletConfig := filepath.Join(nodeConfigHostPath, KubeletConfigurationKey)
+	ntpServers := filepath.Join(nodeConfigHostPath, NTPServersKey)
+	script := fmt.Sprintf(`set -eu
+if [ -f %[1]s ]; then
+  cp %[1]s %[2]s
+  kubeadm upgrade node phase kubelet-config --patches %[3]s
+  systemctl restart kubelet
+fi
+if [ -f %[4]s ]; then
+  conf=/etc/chrony/chrony.conf
+  [ -f "$conf" ] || conf=/etc/chrony.conf
+  sed -i '/^\(server\|pool\) /d' "$conf"
+  while read -r server; do echo "server $server iburst" >> "$conf"; done < %[4]s
+  systemctl restart chronyd || systemctl restart chrony
+fi
+`, kubeletConfig, nodeconfig.KubeletConfigurationPath, filepath.Dir(nodeconfig.KubeletConfigurationPath), ntpServers)
+
+	return nodeConfigPod(nodeName, image, configMapName, "sh", "-c", script)
+}
+
+// UpdateBottlerocketNodeConfigPod returns a pod that applies the host configuration of a
+// Bottlerocket node through the Bottlerocket API with the settings stored in configMapName.
+func UpdateBottlerocketNodeConfigPod(nodeName, image, configMapName string) *corev1.Pod {
+	settings := filepath.Join(nodeConfigHostPath, BottlerocketSettingsKey)
+	return nodeConfigPod(nodeName, image, configMapName, "apiclient", "apply", "--from-file", "file://"+settings)
+}
+
+func nodeConfigPod(nodeName, image, configMapName string, updateCommand ...string) *corev1.Pod {
+	dirOrCreate := corev1.HostPathDirectoryOrCreate
+	hostMount := corev1.VolumeMount{
+		Name:      hostNodeConfigVolume,
+		MountPath: "/usr/host",
+	}
+
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      NodeConfigPodName(nodeName),
+			Namespace: constants.EksaSystemNamespace,
+			Labels: map[string]string{
+				"eksa-node-config-updater": "true",
+			},
+		},
+		Spec: corev1.PodSpec{
+			NodeName: nodeName,
+			HostPID:  true,
+			Volumes: []corev1.Volume{
+				{
+					Name: nodeConfigVolume,
+					VolumeSource: corev1.VolumeSource{
+						ConfigMap: &corev1.ConfigMapVolumeSource{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: configMapName,
+							},
+						},
+					},
+				},
+				{
+					Name: hostNodeConfigVolume,
+					VolumeSource: corev1.VolumeSource{
+						HostPath: &corev1.HostPathVolumeSource{
+							Path: nodeConfigHostPath,
+							Type: &dirOrCreate,
+						},
+					},
+				},
+			},
+			InitContainers: []corev1.Container{
+				{
+					Name:    NodeConfigCopierContainerName,
+					Image:   image,
+					Command: []string{"sh", "-c"},
+					Args:    []string{"rm -f /usr/host/* && cp /node-config/* /usr/host/"},
+					VolumeMounts: []corev1.VolumeMount{
+						{
+							Name:      nodeConfigVolume,
+							MountPath: "/node-config",
+							ReadOnly:  true,
+						},
+						hostMount,
+					},
+				},
+				nsenterContainer(image, NodeConfigUpdaterContainerName, updateCommand...),
+			},
+			Containers: []corev1.Container{
+				{
+					Name:         NodeConfigCleanupContainerName,
+					Image:        image,
+					Command:      []string{"sh", "-c"},
+					Args:         []string{"rm -f /usr/host/*"},
+					VolumeMounts: []corev1.VolumeMount{hostMount},
+				},
+			},
+			RestartPolicy: corev1.RestartPolicyNever,
+		},
+	}
+}
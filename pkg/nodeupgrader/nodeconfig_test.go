@@ -0,0 +1,35 @@
+package nodeupgrader_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/nodeupgrader"
+)
+
+const nodeConfigMap = "md-0-node-config"
+
+func TestUpdateNodeConfigPod(t *testing.T) {
+	g := NewWithT(t)
+	pod := nodeupgrader.UpdateNodeConfigPod(nodeName, upgraderImage, nodeConfigMap)
+	g.Expect(pod).ToNot(BeNil())
+	g.Expect(pod.Name).To(Equal(nodeupgrader.NodeConfigPodName(nodeName)))
+
+	data, err := yaml.Marshal(pod)
+	g.Expect(err).ToNot(HaveOccurred())
+	test.AssertContentToFile(t, string(data), "testdata/expected_node_config_pod.yaml")
+}
+
+func TestUpdateBottlerocketNodeConfigPod(t *testing.T) {
+	g := NewWithT(t)
+	pod := nodeupgrader.UpdateBottlerocketNodeConfigPod(nodeName, upgraderImage, nodeConfigMap)
+	g.Expect(pod).ToNot(BeNil())
+	g.Expect(pod.Name).To(Equal(nodeupgrader.NodeConfigPodName(nodeName)))
+
+	data, err := yaml.Marshal(pod)
+	g.Expect(err).ToNot(HaveOccurred())
+	test.AssertContentToFile(t, string(data), "testdata/expected_bottlerocket_node_config_pod.yaml")
+}
@@ -0,0 +1,133 @@
+package nodeupgrader
+
+import (
+	"fmt"
+	"path/filepath"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/registrymirror/containerd"
+)
+
+const (
+	// RegistryCredentialsCopierContainerName holds the name of the registry credentials copier container.
+	RegistryCredentialsCopierContainerName = "registry-credentials-copier"
+
+	// RegistryCredentialsUpdaterContainerName holds the name of the container that updates
+	// the registry credentials on the node.
+	RegistryCredentialsUpdaterContainerName = "registry-credentials-updater"
+
+	// RegistryCredentialsCleanupContainerName holds the name of the container that removes
+	// the registry credentials copied to the node.
+	RegistryCredentialsCleanupContainerName = "registry-credentials-cleanup"
+
+	// ContainerdAuthConfigKey is the key in the registry credentials secret holding the
+	// containerd registry auth configuration.
+	ContainerdAuthConfigKey = "registry-auth.toml"
+
+	// BottlerocketSettingsKey is the key in the registry credentials secret holding the
+	// Bottlerocket settings with the registry credentials.
+	BottlerocketSettingsKey = "bottlerocket-settings.json"
+
+	registryCredentialsHostPath = "/var/lib/eksa-registry-credentials"
+	registryCredentialsVolume   = "registry-credentials"
+	hostCredentialsVolume       = "host-registry-credentials"
+)
+
+// RegistryCredentialsPodName returns the name of the registry credentials updater pod based on the nodeName.
+func RegistryCredentialsPodName(nodeName string) string {
+	return fmt.Sprintf("%s-registry-credentials", nodeName)
+}
+
+// UpdateContainerdRegistryCredentialsPod returns a pod that replaces the registry mirror credentials
+// in the containerd configuration of the node with the ones stored in secretName and restarts containerd.
+func UpdateContainerdRegistryCredentialsPod(nodeName, image, secretName string) *corev1.Pod {
+	authConfig := filepath.Join(registryCredentialsHostPath, ContainerdAuthConfigKey)
+	script := fmt.Sprintf(`set -eu
+header=$(head -n 1 %[1]s)
+awk -v header="$header" '{line=$0; sub(/^[ \t]+/, "", line)} line == header {skip=1; next} skip && line ~ /^(username|password) = / {next} {skip=0; print}' /etc/containerd/config.toml > /etc/containerd/config.toml.new
+cat %[1]s >> /etc/containerd/config.toml.new
+cp %[1]s %[2]s
+mv /etc/containerd/config.toml.new /etc/containerd/config.toml
+systemctl restart containerd
+`, authConfig, containerd.AuthConfigPath)
+
+	return registryCredentialsPod(nodeName, image, secretName, "sh", "-c", script)
+}
+
+// UpdateBottlerocketRegistryCredentialsPod returns a pod that updates the registry mirror credentials
+// of a Bottlerocket node through the Bottlerocket API with the settings stored in secretName.
+func UpdateBottlerocketRegistryCredentialsPod(nodeName, image, secretName string) *corev1.Pod {
+	settings := filepath.Join(registryCredentialsHostPath, BottlerocketSettingsKey)
+	return registryCredentialsPod(nodeName, image, secretName, "apiclient", "apply", "--from-file", "file://"+settings)
+}
+
+func registryCredentialsPod(nodeName, image, secretName string, updateCommand ...string) *corev1.Pod {
+	dirOrCreate := corev1.HostPathDirectoryOrCreate
+	hostMount := corev1.VolumeMount{
+		Name:      hostCredentialsVolume,
+		MountPath: "/usr/host",
+	}
+
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      RegistryCredentialsPodName(nodeName),
+			Namespace: constants.EksaSystemNamespace,
+			Labels: map[string]string{
+				"eksa-registry-credentials-updater": "true",
+			},
+		},
+		Spec: corev1.PodSpec{
+			NodeName: nodeName,
+			HostPID:  true,
+			Volumes: []corev1.Volume{
+				{
+					Name: registryCredentialsVolume,
+					VolumeSource: corev1.VolumeSource{
+						Secret: &corev1.SecretVolumeSource{
+							SecretName: secretName,
+						},
+					},
+				},
+				{
+					Name: hostCredentialsVolume,
+					VolumeSource: corev1.VolumeSource{
+						HostPath: &corev1.HostPathVolumeSource{
+							Path: registryCredentialsHostPath,
+							Type: &dirOrCreate,
+						},
+					},
+				},
+			},
+			InitContainers: []corev1.Container{
+				{
+					Name:    RegistryCredentialsCopierContainerName,
+					Image:   image,
+					Command: []string{"sh", "-c"},
+					Args:    []string{"cp /registry-credentials/* /usr/host/"},
+					VolumeMounts: []corev1.VolumeMount{
+						{
+							Name:      registryCredentialsVolume,
+							MountPath: "/registry-credentials",
+							ReadOnly:  true,
+						},
+						hostMount,
+					},
+				},
+				nsenterContainer(image, RegistryCredentialsUpdaterContainerName, updateCommand...),
+			},
+			Containers: []corev1.Container{
+				{
+					Name:         RegistryCredentialsCleanupContainerName,
+					Image:        image,
+					Command:      []string{"sh", "-c"},
+					Args:         []string{"rm -f /usr/host/*"},
+					VolumeMounts: []corev1.VolumeMount{hostMount},
+				},
+			},
+			RestartPolicy: corev1.RestartPolicyNever,
+		},
+	}
+}
@@ -0,0 +1,35 @@
+package nodeupgrader_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/nodeupgrader"
+)
+
+const registryCredentialsSecret = "registry-mirror-credentials"
+
+func TestUpdateContainerdRegistryCredentialsPod(t *testing.T) {
+	g := NewWithT(t)
+	pod := nodeupgrader.UpdateContainerdRegistryCredentialsPod(nodeName, upgraderImage, registryCredentialsSecret)
+	g.Expect(pod).ToNot(BeNil())
+	g.Expect(pod.Name).To(Equal(nodeupgrader.RegistryCredentialsPodName(nodeName)))
+
+	data, err := yaml.Marshal(pod)
+	g.Expect(err).ToNot(HaveOccurred())
+	test.AssertContentToFile(t, string(data), "testdata/expected_containerd_registry_credentials_pod.yaml")
+}
+
+func TestUpdateBottlerocketRegistryCredentialsPod(t *testing.T) {
+	g := NewWithT(t)
+	pod := nodeupgrader.UpdateBottlerocketRegistryCredentialsPod(nodeName, upgraderImage, registryCredentialsSecret)
+	g.Expect(pod).ToNot(BeNil())
+	g.Expect(pod.Name).To(Equal(nodeupgrader.RegistryCredentialsPodName(nodeName)))
+
+	data, err := yaml.Marshal(pod)
+	g.Expect(err).ToNot(HaveOccurred())
+	test.AssertContentToFile(t, string(data), "testdata/expected_bottlerocket_registry_credentials_pod.yaml")
+}
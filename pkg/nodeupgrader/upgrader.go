@@ -2,6 +2,7 @@ package nodeupgrader
 
 import (
 	"fmt"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -16,6 +17,10 @@ const (
 	// CopierContainerName holds the name of the components copier container.
 	CopierContainerName = "components-copier"
 
+	// SnapshotContainerName holds the name of the container that snapshots the current host components
+	// before they are upgraded, so they can be restored if the upgrade fails.
+	SnapshotContainerName = "components-snapshot"
+
 	// ContainerdUpgraderContainerName holds the name of the containerd upgrader container.
 	ContainerdUpgraderContainerName = "containerd-upgrader"
 
@@ -30,13 +35,64 @@ const (
 
 	// PostUpgradeContainerName holds the name of the post upgrade cleanup/status report container.
 	PostUpgradeContainerName = "post-upgrade-status"
+
+	// RollbackContainerName holds the name of the container that restores the host components from the snapshot.
+	RollbackContainerName = "components-rollback"
+
+	snapshotDir = "/var/lib/eksa-upgrades-snapshot"
 )
 
+// snapshotPaths are the host files replaced by the upgrader containers: containerd and runc, the CNI plugins,
+// the kubernetes binaries and the files kubeadm rewrites when upgrading a node.
+var snapshotPaths = []string{
+	"/usr/bin/containerd*",
+	"/usr/local/bin/containerd*",
+	"/usr/bin/ctr",
+	"/usr/local/bin/ctr",
+	"/usr/sbin/runc",
+	"/usr/local/sbin/runc",
+	"/opt/cni/bin",
+	"/usr/bin/kubeadm",
+	"/usr/bin/kubelet",
+	"/usr/bin/kubectl",
+	"/etc/kubernetes/manifests",
+	"/var/lib/kubelet/config.yaml",
+	"/var/lib/kubelet/kubeadm-flags.env",
+}
+
+// The snapshot and rollback scripts run directly on the host instead of through the upgrader binary, since
+// the upgrader CLI doesn't provide those commands. The snapshot is best-effort: it never fails the upgrade,
+// and only a complete snapshot is marked as restorable.
+var snapshotScript = fmt.Sprintf(`rm -rf %[1]s
+mkdir -p %[1]s/files || exit 0
+complete=true
+for path in %[2]s; do
+  [ -e "$path" ] || continue
+  cp -a --parents "$path" %[1]s/files || { echo "failed to snapshot $path"; complete=false; }
+done
+if [ "$complete" = true ]; then touch %[1]s/complete; else echo "node components snapshot is incomplete, the node can't be rolled back"; fi
+exit 0
+`, snapshotDir, strings.Join(snapshotPaths, " "))
+
+var rollbackScript = fmt.Sprintf(`set -eu
+if [ ! -f %[1]s/complete ]; then echo "no complete snapshot of the node components found"; exit 1; fi
+systemctl stop kubelet
+cp -a %[1]s/files/. /
+systemctl daemon-reload
+systemctl restart containerd
+systemctl start kubelet
+`, snapshotDir)
+
 // PodName returns the name of the upgrader pod based on the nodeName.
 func PodName(nodeName string) string {
 	return fmt.Sprintf("%s-node-upgrader", nodeName)
 }
 
+// RollbackPodName returns the name of the rollback pod based on the nodeName.
+func RollbackPodName(nodeName string) string {
+	return fmt.Sprintf("%s-node-rollback", nodeName)
+}
+
 // UpgradeFirstControlPlanePod returns an upgrader pod that should be deployed on the first control plane node.
 func UpgradeFirstControlPlanePod(nodeName, image, kubernetesVersion, etcdVersion string) *corev1.Pod {
 	p := upgraderPod(nodeName, image, true)
@@ -58,6 +114,28 @@ func UpgradeWorkerPod(nodeName, image string) *corev1.Pod {
 	return p
 }
 
+// RollbackPod returns a pod that restores the host components snapshotted by the upgrader pod on a node.
+func RollbackPod(nodeName, image string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      RollbackPodName(nodeName),
+			Namespace: constants.EksaSystemNamespace,
+			Labels: map[string]string{
+				"eks-d-upgrader": "true",
+			},
+		},
+		Spec: corev1.PodSpec{
+			NodeName: nodeName,
+			HostPID:  true,
+			Volumes:  []corev1.Volume{hostComponentsVolume()},
+			Containers: []corev1.Container{
+				nsenterContainer(image, RollbackContainerName, "sh", "-c", rollbackScript),
+			},
+			RestartPolicy: corev1.RestartPolicyOnFailure,
+		},
+	}
+}
+
 func upgraderPod(nodeName, image string, isCP bool) *corev1.Pod {
 	volumes := []corev1.Volume{hostComponentsVolume()}
 	if isCP {
@@ -86,6 +164,7 @@ func upgraderPod(nodeName, image string, isCP bool) *corev1.Pod {
 func containersForUpgrade(isCP bool, image, nodeName string, kubeadmUpgradeCommand ...string) []corev1.Container {
 	return []corev1.Container{
 		copierContainer(image, isCP),
+		nsenterContainer(image, SnapshotContainerName, "sh", "-c", snapshotScript),
 		nsenterContainer(image, ContainerdUpgraderContainerName, upgradeBin, "upgrade", "containerd"),
 		nsenterContainer(image, CNIPluginsUpgraderContainerName, upgradeBin, "upgrade", "cni-plugins"),
 		nsenterContainer(image, KubeadmUpgraderContainerName, append([]string{upgradeBin}, kubeadmUpgradeCommand...)...),
@@ -1,9 +1,12 @@
 package nodeupgrader_test
 
 import (
+	"os/exec"
+	"strings"
 	"testing"
 
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/yaml"
 
 	"github.com/aws/eks-anywhere/internal/test"
@@ -46,3 +49,56 @@ func TestUpgradeWorkerPod(t *testing.T) {
 	g.Expect(err).ToNot(HaveOccurred())
 	test.AssertContentToFile(t, string(data), "testdata/expected_worker_upgrader_pod.yaml")
 }
+
+func TestRollbackPod(t *testing.T) {
+	g := NewWithT(t)
+	pod := nodeupgrader.RollbackPod(nodeName, upgraderImage)
+	g.Expect(pod).ToNot(BeNil())
+
+	data, err := yaml.Marshal(pod)
+	g.Expect(err).ToNot(HaveOccurred())
+	test.AssertContentToFile(t, string(data), "testdata/expected_rollback_pod.yaml")
+}
+
+// upgraderCommands are the commands provided by the upgrader CLI shipped in the node-upgrader image.
+var upgraderCommands = map[string]bool{
+	"upgrade node":            true,
+	"upgrade containerd":      true,
+	"upgrade cni-plugins":     true,
+	"upgrade kubelet-kubectl": true,
+	"upgrade status":          true,
+}
+
+func TestUpgraderPodsUseUpgraderCommands(t *testing.T) {
+	pods := []*corev1.Pod{
+		nodeupgrader.UpgradeFirstControlPlanePod(nodeName, upgraderImage, kubernetesVersion, etcdVersion),
+		nodeupgrader.UpgradeSecondaryControlPlanePod(nodeName, upgraderImage, kubernetesVersion),
+		nodeupgrader.UpgradeWorkerPod(nodeName, upgraderImage),
+		nodeupgrader.RollbackPod(nodeName, upgraderImage),
+	}
+	for _, pod := range pods {
+		for _, c := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+			for i, arg := range c.Args {
+				if arg != "/foo/eksa-upgrades/tools/upgrader" {
+					continue
+				}
+				if len(c.Args) < i+3 || !upgraderCommands[strings.Join(c.Args[i+1:i+3], " ")] {
+					t.Errorf("container %s in pod %s runs an upgrader command that doesn't exist: %v", c.Name, pod.Name, c.Args[i+1:])
+				}
+			}
+		}
+	}
+}
+
+func TestSnapshotAndRollbackScripts(t *testing.T) {
+	g := NewWithT(t)
+	upgrader := nodeupgrader.UpgradeWorkerPod(nodeName, upgraderImage)
+	rollback := nodeupgrader.RollbackPod(nodeName, upgraderImage)
+
+	for _, c := range []corev1.Container{upgrader.Spec.InitContainers[1], rollback.Spec.Containers[0]} {
+		g.Expect(c.Name).To(BeElementOf(nodeupgrader.SnapshotContainerName, nodeupgrader.RollbackContainerName))
+		script := c.Args[len(c.Args)-1]
+		out, err := exec.Command("sh", "-n", "-c", script).CombinedOutput()
+		g.Expect(err).NotTo(HaveOccurred(), "invalid script in container %s: %s", c.Name, out)
+	}
+}
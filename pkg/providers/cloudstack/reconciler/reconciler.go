@@ -72,6 +72,26 @@ func (r *Reconciler) Reconcile(ctx context.Context, log logr.Logger, cluster *an
 	).Run(ctx, log, clusterSpec)
 }
 
+// UpgradeNeeded returns true if reconciling the cluster would replace or upgrade any of its machines.
+func (r *Reconciler) UpgradeNeeded(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) (bool, error) {
+	clusterSpec, err := c.BuildSpec(ctx, clientutil.NewKubeClient(r.client), cluster)
+	if err != nil {
+		return false, err
+	}
+
+	cp, err := cloudstack.ControlPlaneSpec(ctx, log, clientutil.NewKubeClient(r.client), clusterSpec)
+	if err != nil {
+		return false, err
+	}
+
+	w, err := cloudstack.WorkersSpec(ctx, log, clientutil.NewKubeClient(r.client), clusterSpec)
+	if err != nil {
+		return false, err
+	}
+
+	return clusters.UpgradeNeeded(ctx, r.client, toClientControlPlane(cp), clusters.ToWorkers(w))
+}
+
 // ValidateDatacenterConfig updates the cluster status if the CloudStackDatacenter status indicates that the spec is invalid.
 func (r *Reconciler) ValidateDatacenterConfig(ctx context.Context, log logr.Logger, spec *c.Spec) (controller.Result, error) {
 	log = log.WithValues("phase", "validateDatacenterConfig")
@@ -125,14 +145,18 @@ func (r *Reconciler) ReconcileControlPlane(ctx context.Context, log logr.Logger,
 		return controller.Result{}, err
 	}
 
-	return clusters.ReconcileControlPlane(ctx, log, r.client, &clusters.ControlPlane{
+	return clusters.ReconcileControlPlaneForEKSA(ctx, log, r.client, spec.Cluster, toClientControlPlane(cp))
+}
+
+func toClientControlPlane(cp *cloudstack.ControlPlane) *clusters.ControlPlane {
+	return &clusters.ControlPlane{
 		Cluster:                     cp.Cluster,
 		ProviderCluster:             cp.ProviderCluster,
 		KubeadmControlPlane:         cp.KubeadmControlPlane,
 		ControlPlaneMachineTemplate: cp.ControlPlaneMachineTemplate,
 		EtcdCluster:                 cp.EtcdCluster,
 		EtcdMachineTemplate:         cp.EtcdMachineTemplate,
-	})
+	}
 }
 
 // CheckControlPlaneReady checks whether the control plane for an eks-a cluster is ready or not.
@@ -237,8 +237,8 @@ func TestWorkersSpec(t *testing.T) {
 			Expect: func() []clusterapi.WorkerGroup[*cloudstackv1.CloudStackMachineTemplate] {
 				return []clusterapi.WorkerGroup[*cloudstackv1.CloudStackMachineTemplate]{
 					{
+						// Taints are updated in place, so the template keeps its name
 						KubeadmConfigTemplate: kubeadmConfigTemplate(func(kct *bootstrapv1.KubeadmConfigTemplate) {
-							kct.Name = "test-md-0-2"
 							kct.Spec.Template.Spec.JoinConfiguration.NodeRegistration.Taints = []corev1.Taint{
 								{
 									Key:    "change-taint",
@@ -247,9 +247,7 @@ func TestWorkersSpec(t *testing.T) {
 								},
 							}
 						}),
-						MachineDeployment: machineDeployment(func(md *clusterv1.MachineDeployment) {
-							md.Spec.Template.Spec.Bootstrap.ConfigRef.Name = "test-md-0-2"
-						}),
+						MachineDeployment:       machineDeployment(),
 						ProviderMachineTemplate: machineTemplate(),
 					},
 				}
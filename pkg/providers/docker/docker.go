@@ -15,6 +15,7 @@ import (
 	"sigs.k8s.io/yaml"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/authentication"
 	"github.com/aws/eks-anywhere/pkg/bootstrapper"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/clusterapi"
@@ -282,13 +283,16 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec) (map[string]interface{}, erro
 	etcdExtraArgs := clusterapi.SecureEtcdTlsCipherSuitesExtraArgs()
 	sharedExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs()
 
-	apiServerExtraArgs := clusterapi.OIDCToExtraArgs(clusterSpec.OIDCConfig).
+	apiServerExtraArgs := clusterapi.AuthenticationExtraArgs(clusterSpec).
 		Append(clusterapi.AwsIamAuthExtraArgs(clusterSpec.AWSIamConfig)).
 		Append(clusterapi.APIServerExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration.APIServerExtraArgs)).
 		Append(sharedExtraArgs)
 	clusterapi.SetPodIAMAuthExtraArgs(clusterSpec.Cluster.Spec.PodIAMConfig, apiServerExtraArgs)
 	controllerManagerExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
-		Append(clusterapi.NodeCIDRMaskExtraArgs(&clusterSpec.Cluster.Spec.ClusterNetwork))
+		Append(clusterapi.NodeCIDRMaskExtraArgs(&clusterSpec.Cluster.Spec.ClusterNetwork)).
+		Append(clusterapi.IPv6BindAddressExtraArgs(&clusterSpec.Cluster.Spec.ClusterNetwork))
+	schedulerExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
+		Append(clusterapi.IPv6BindAddressExtraArgs(&clusterSpec.Cluster.Spec.ClusterNetwork))
 
 	values := map[string]interface{}{
 		"clusterName":                   clusterSpec.Cluster.Name,
@@ -304,7 +308,7 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec) (map[string]interface{}, erro
 		"etcdCipherSuites":              crypto.SecureCipherSuitesString(),
 		"apiserverExtraArgs":            apiServerExtraArgs.ToPartialYaml(),
 		"controllermanagerExtraArgs":    controllerManagerExtraArgs.ToPartialYaml(),
-		"schedulerExtraArgs":            sharedExtraArgs.ToPartialYaml(),
+		"schedulerExtraArgs":            schedulerExtraArgs.ToPartialYaml(),
 		"externalEtcdVersion":           versionsBundle.KubeDistro.EtcdVersion,
 		"eksaSystemNamespace":           constants.EksaSystemNamespace,
 		"podCidrs":                      clusterSpec.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks,
@@ -327,6 +331,11 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec) (map[string]interface{}, erro
 		values["awsIamAuth"] = true
 	}
 
+	if clusterSpec.Cluster.UsesStructuredAuthentication() {
+		values["structuredAuthentication"] = true
+		values["authenticationConfigSecret"] = authentication.ConfigSecretName(clusterSpec.Cluster.Name)
+	}
+
 	values["controlPlaneTaints"] = clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Taints
 
 	auditPolicy, err := common.GetAuditPolicy(clusterSpec.Cluster.Spec.KubernetesVersion)
@@ -380,6 +389,12 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec) (map[string]interface{}, erro
 		values["kubeletExtraArgs"] = kubeletExtraArgs.ToPartialYaml()
 	}
 
+	// node-ip is passed as a flag both with and without a kubelet configuration, it can't be set in the config file.
+	nodeIPArgs := clusterapi.IPv6NodeIPExtraArgs(&clusterSpec.Cluster.Spec.ClusterNetwork)
+	if len(nodeIPArgs) != 0 {
+		values["nodeIPArgs"] = nodeIPArgs.ToPartialYaml()
+	}
+
 	nodeLabelArgs := clusterapi.ControlPlaneNodeLabelsExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration)
 	if len(nodeLabelArgs) != 0 {
 		values["nodeLabelArgs"] = nodeLabelArgs.ToPartialYaml()
@@ -445,6 +460,11 @@ func buildTemplateMapMD(clusterSpec *cluster.Spec, workerNodeGroupConfiguration
 		values["kubeletExtraArgs"] = kubeletExtraArgs.ToPartialYaml()
 	}
 
+	nodeIPArgs := clusterapi.IPv6NodeIPExtraArgs(&clusterSpec.Cluster.Spec.ClusterNetwork)
+	if len(nodeIPArgs) != 0 {
+		values["nodeIPArgs"] = nodeIPArgs.ToPartialYaml()
+	}
+
 	nodeLabelArgs := clusterapi.WorkerNodeLabelsExtraArgs(workerNodeGroupConfiguration)
 	if len(nodeLabelArgs) != 0 {
 		values["nodeLabelArgs"] = nodeLabelArgs.ToPartialYaml()
@@ -819,6 +839,7 @@ func populateRegistryMirrorValues(clusterSpec *cluster.Spec, values map[string]i
 		}
 		values["registryUsername"] = username
 		values["registryPassword"] = password
+		values["registryAuthConfig"] = containerd.AuthConfig(registryMirror.BaseRegistry, username, password)
 	}
 	return values, nil
 }
@@ -766,6 +766,39 @@ func TestProviderGenerateCAPISpecForCreateWithStackedEtcd(t *testing.T) {
 	test.AssertContentToFile(t, string(cp), "testdata/valid_deployment_cp_stacked_etcd_expected.yaml")
 }
 
+func TestProviderGenerateCAPISpecForCreateWithIPv6(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	ctx := context.Background()
+	client := dockerMocks.NewMockProviderClient(mockCtrl)
+	kubectl := dockerMocks.NewMockProviderKubectlClient(mockCtrl)
+	provider := docker.NewProvider(&v1alpha1.DockerDatacenterConfig{}, client, kubectl, test.FakeNow)
+	clusterObj := &types.Cluster{
+		Name: "test-cluster",
+	}
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = "test-cluster"
+		s.Cluster.Spec.KubernetesVersion = "1.19"
+		s.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks = []string{"fd00:10:244::/56"}
+		s.Cluster.Spec.ClusterNetwork.Services.CidrBlocks = []string{"fd00:10:96::/108"}
+		s.Cluster.Spec.ClusterNetwork.Nodes = &v1alpha1.Nodes{CIDRMaskSizeIPv6: ptr.Int(64)}
+		s.Cluster.Spec.ControlPlaneConfiguration.Count = 1
+		s.VersionsBundles["1.19"] = versionsBundle
+		s.Cluster.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{{Count: ptr.Int(3), MachineGroupRef: &v1alpha1.Ref{Name: "test-cluster"}, Name: "md-0"}}
+	})
+
+	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	cp, md, err := provider.GenerateCAPISpecForCreate(context.Background(), clusterObj, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	}
+	test.AssertContentToFile(t, string(cp), "testdata/valid_deployment_cp_ipv6_expected.yaml")
+	test.AssertContentToFile(t, string(md), "testdata/valid_deployment_md_ipv6_expected.yaml")
+}
+
 func TestProviderGenerateCAPISpecForCreateWithWorkerKubernetesVersion(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	ctx := context.Background()
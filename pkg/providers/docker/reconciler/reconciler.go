@@ -61,6 +61,26 @@ func (r *Reconciler) Reconcile(ctx context.Context, log logr.Logger, c *anywhere
 	).Run(ctx, log, clusterSpec)
 }
 
+// UpgradeNeeded returns true if reconciling the cluster would replace or upgrade any of its machines.
+func (r *Reconciler) UpgradeNeeded(ctx context.Context, log logr.Logger, c *anywherev1.Cluster) (bool, error) {
+	clusterSpec, err := cluster.BuildSpec(ctx, clientutil.NewKubeClient(r.client), c)
+	if err != nil {
+		return false, err
+	}
+
+	cp, err := docker.ControlPlaneSpec(ctx, log, clientutil.NewKubeClient(r.client), clusterSpec)
+	if err != nil {
+		return false, err
+	}
+
+	w, err := docker.WorkersSpec(ctx, log, clientutil.NewKubeClient(r.client), clusterSpec)
+	if err != nil {
+		return false, err
+	}
+
+	return clusters.UpgradeNeeded(ctx, r.client, toClientControlPlane(cp), clusters.ToWorkers(w))
+}
+
 // CheckControlPlaneReady checks whether the control plane for an eks-a cluster is ready or not.
 // Requeues with the appropriate wait times whenever the cluster is not ready yet.
 func (r *Reconciler) CheckControlPlaneReady(ctx context.Context, log logr.Logger, spec *cluster.Spec) (controller.Result, error) {
@@ -99,12 +119,16 @@ func (r *Reconciler) ReconcileControlPlane(ctx context.Context, log logr.Logger,
 	if err != nil {
 		return controller.Result{}, err
 	}
-	return clusters.ReconcileControlPlane(ctx, log, r.client, &clusters.ControlPlane{
+	return clusters.ReconcileControlPlaneForEKSA(ctx, log, r.client, spec.Cluster, toClientControlPlane(cp))
+}
+
+func toClientControlPlane(cp *docker.ControlPlane) *clusters.ControlPlane {
+	return &clusters.ControlPlane{
 		Cluster:                     cp.Cluster,
 		ProviderCluster:             cp.ProviderCluster,
 		KubeadmControlPlane:         cp.KubeadmControlPlane,
 		ControlPlaneMachineTemplate: cp.ControlPlaneMachineTemplate,
 		EtcdCluster:                 cp.EtcdCluster,
 		EtcdMachineTemplate:         cp.EtcdMachineTemplate,
-	})
+	}
 }
@@ -29,6 +29,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/controller"
 	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
+	"github.com/aws/eks-anywhere/pkg/providers/docker"
 	"github.com/aws/eks-anywhere/pkg/providers/docker/reconciler"
 	dockereconcilermocks "github.com/aws/eks-anywhere/pkg/providers/docker/reconciler/mocks"
 	"github.com/aws/eks-anywhere/pkg/utils/ptr"
@@ -127,6 +128,58 @@ func TestReconcilerReconcileSuccess(t *testing.T) {
 	)
 }
 
+func TestReconcilerUpgradeNeededNewCluster(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.withFakeClient()
+
+	needed, err := tt.reconciler().UpgradeNeeded(tt.ctx, test.NewNullLogger(), tt.cluster)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(needed).To(BeFalse())
+}
+
+func TestReconcilerUpgradeNeededScaling(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.withFakeClient()
+	tt.createCurrentCAPIObjects()
+	tt.cluster.Spec.ControlPlaneConfiguration.Count = 3
+	tt.cluster.Spec.WorkerNodeGroupConfigurations[0].Count = ptr.Int(3)
+
+	needed, err := tt.reconciler().UpgradeNeeded(tt.ctx, test.NewNullLogger(), tt.cluster)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(needed).To(BeFalse())
+}
+
+func TestReconcilerUpgradeNeededControlPlaneChanged(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.withFakeClient()
+	tt.createCurrentCAPIObjects()
+	tt.cluster.Spec.ControlPlaneConfiguration.Taints = []corev1.Taint{
+		{Key: "key", Value: "value", Effect: corev1.TaintEffectNoSchedule},
+	}
+
+	needed, err := tt.reconciler().UpgradeNeeded(tt.ctx, test.NewNullLogger(), tt.cluster)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(needed).To(BeTrue())
+}
+
+func TestReconcilerUpgradeNeededWorkersVersionChanged(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.withFakeClient()
+	tt.createCurrentCAPIObjects()
+	md := &clusterv1.MachineDeployment{}
+	tt.Expect(tt.client.Get(tt.ctx, client.ObjectKey{Name: tt.cluster.Name + "-md-0", Namespace: constants.EksaSystemNamespace}, md)).To(Succeed())
+	md.Spec.Template.Spec.Version = ptr.String("v1.21.2-eks-1-21-4")
+	tt.Expect(tt.client.Update(tt.ctx, md)).To(Succeed())
+
+	needed, err := tt.reconciler().UpgradeNeeded(tt.ctx, test.NewNullLogger(), tt.cluster)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(needed).To(BeTrue())
+}
+
 func TestReconcileCNISuccess(t *testing.T) {
 	tt := newReconcilerTest(t)
 	tt.withFakeClient()
@@ -473,6 +526,26 @@ func (tt *reconcilerTest) withFakeClient() {
 	tt.client = fake.NewClientBuilder().WithObjects(clientutil.ObjectsToClientObjects(tt.allObjs())...).Build()
 }
 
+// createCurrentCAPIObjects creates the CAPI objects generated for the current cluster spec, as if
+// the cluster had already been reconciled.
+func (tt *reconcilerTest) createCurrentCAPIObjects() {
+	tt.t.Helper()
+	spec := tt.buildSpec()
+	cp, err := docker.ControlPlaneSpec(tt.ctx, test.NewNullLogger(), clientutil.NewKubeClient(tt.client), spec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	w, err := docker.WorkersSpec(tt.ctx, test.NewNullLogger(), clientutil.NewKubeClient(tt.client), spec)
+	tt.Expect(err).NotTo(HaveOccurred())
+
+	for _, g := range w.Groups {
+		// The CAPI webhook sets the cluster name label on the MachineDeployments.
+		g.MachineDeployment.Labels[clusterv1.ClusterNameLabel] = tt.cluster.Name
+	}
+
+	for _, o := range clientutil.ObjectsToClientObjects(append(cp.Objects(), w.WorkerObjects()...)) {
+		tt.Expect(tt.client.Create(tt.ctx, o)).To(Succeed())
+	}
+}
+
 func (tt *reconcilerTest) createAllObjs() {
 	tt.t.Helper()
 	envtest.CreateObjs(tt.ctx, tt.t, tt.client, tt.allObjs()...)
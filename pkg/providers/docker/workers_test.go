@@ -97,7 +97,7 @@ func TestWorkersSpecUpgradeCluster(t *testing.T) {
 	objs = append(objs, currentGroup2.Objects()...)
 	client := test.NewFakeKubeClient(clientutil.ObjectsToClientObjects(objs)...)
 
-	// This will cause a change in the kubeadmconfigtemplate which we also treat as immutable
+	// This will cause a change in the kubeadmconfigtemplate that is applied in place, so it keeps its name
 	spec.Cluster.Spec.WorkerNodeGroupConfigurations[0].Taints = []corev1.Taint{
 		{
 			Key:    "a",
@@ -112,8 +112,6 @@ func TestWorkersSpecUpgradeCluster(t *testing.T) {
 			Effect: corev1.TaintEffectNoSchedule,
 		},
 	}
-	expectedGroup1.KubeadmConfigTemplate.Name = "test-md-0-2"
-	expectedGroup1.MachineDeployment.Spec.Template.Spec.Bootstrap.ConfigRef.Name = "test-md-0-2"
 
 	// This will cause a change in the docker machine templates, which are immutable
 	spec.VersionsBundles["1.23"].EksD.KindNode = releasev1.Image{
@@ -179,7 +177,7 @@ func TestWorkersSpecUpgradeClusterRemoveLabels(t *testing.T) {
 	objs = append(objs, currentGroup1.Objects()...)
 	client := test.NewFakeKubeClient(clientutil.ObjectsToClientObjects(objs)...)
 
-	// This will cause a change in the kubeadmconfigtemplate which we also treat as immutable
+	// This will cause a change in the kubeadmconfigtemplate that is applied in place, so it keeps its name
 	spec.Cluster.Spec.WorkerNodeGroupConfigurations[0].Labels = map[string]string{}
 
 	expectedGroup1.KubeadmConfigTemplate.Spec.Template.Spec.JoinConfiguration.NodeRegistration.KubeletExtraArgs = map[string]string{
@@ -187,8 +185,6 @@ func TestWorkersSpecUpgradeClusterRemoveLabels(t *testing.T) {
 		"cgroup-driver":     "cgroupfs",
 		"eviction-hard":     "nodefs.available<0%,nodefs.inodesFree<0%,imagefs.available<0%",
 	}
-	expectedGroup1.KubeadmConfigTemplate.Name = "test-md-0-2"
-	expectedGroup1.MachineDeployment.Spec.Template.Spec.Bootstrap.ConfigRef.Name = "test-md-0-2"
 
 	workers, err := docker.WorkersSpec(ctx, logger, client, spec)
 	g.Expect(err).NotTo(HaveOccurred())
@@ -39,6 +39,22 @@ func TestControlPlaneSpecWithUpgradeRolloutStrategy(t *testing.T) {
 	assert.Equal(t, int32(1), cp.KubeadmControlPlane.Spec.RolloutStrategy.RollingUpdate.MaxSurge.IntVal)
 }
 
+func TestControlPlaneSpecWithInPlaceUpgradeRolloutStrategy(t *testing.T) {
+	t.Setenv(constants.EksaNutanixUsernameKey, "admin")
+	t.Setenv(constants.EksaNutanixPasswordKey, "password")
+	logger := test.NewNullLogger()
+	client := test.NewFakeKubeClient()
+	spec := test.NewFullClusterSpec(t, "testdata/eksa-cluster.yaml")
+	spec.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy = &v1alpha1.ControlPlaneUpgradeRolloutStrategy{
+		Type: v1alpha1.InPlaceStrategyType,
+	}
+	cp, err := ControlPlaneSpec(context.TODO(), logger, client, spec)
+	assert.NoError(t, err)
+	assert.NotNil(t, cp)
+	assert.Equal(t, "InPlace", string(cp.KubeadmControlPlane.Spec.RolloutStrategy.Type))
+	assert.Nil(t, cp.KubeadmControlPlane.Spec.RolloutStrategy.RollingUpdate)
+}
+
 func TestCPObjects(t *testing.T) {
 	t.Setenv(constants.EksaNutanixUsernameKey, "admin")
 	t.Setenv(constants.EksaNutanixPasswordKey, "password")
@@ -156,6 +156,26 @@ func (r *Reconciler) Reconcile(ctx context.Context, log logr.Logger, c *anywhere
 	).Run(ctx, log, clusterSpec)
 }
 
+// UpgradeNeeded returns true if reconciling the cluster would replace or upgrade any of its machines.
+func (r *Reconciler) UpgradeNeeded(ctx context.Context, log logr.Logger, c *anywherev1.Cluster) (bool, error) {
+	clusterSpec, err := cluster.BuildSpec(ctx, clientutil.NewKubeClient(r.client), c)
+	if err != nil {
+		return false, err
+	}
+
+	cp, err := nutanix.ControlPlaneSpec(ctx, log, clientutil.NewKubeClient(r.client), clusterSpec)
+	if err != nil {
+		return false, err
+	}
+
+	w, err := nutanix.WorkersSpec(ctx, log, clientutil.NewKubeClient(r.client), clusterSpec)
+	if err != nil {
+		return false, err
+	}
+
+	return clusters.UpgradeNeeded(ctx, r.client, toClientControlPlane(cp), clusters.ToWorkers(w))
+}
+
 // ReconcileCNI reconciles the CNI to the desired state.
 func (r *Reconciler) ReconcileCNI(ctx context.Context, log logr.Logger, clusterSpec *cluster.Spec) (controller.Result, error) {
 	log = log.WithValues("phase", "reconcileCNI")
@@ -199,7 +219,7 @@ func (r *Reconciler) ReconcileControlPlane(ctx context.Context, log logr.Logger,
 		return controller.Result{}, err
 	}
 
-	return clusters.ReconcileControlPlane(ctx, log, r.client, toClientControlPlane(cp))
+	return clusters.ReconcileControlPlaneForEKSA(ctx, log, r.client, clusterSpec.Cluster, toClientControlPlane(cp))
 }
 
 func toClientControlPlane(cp *nutanix.ControlPlane) *clusters.ControlPlane {
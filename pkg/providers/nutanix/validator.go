@@ -782,14 +782,43 @@ func (v *Validator) validateFreeGPU(ctx context.Context, v3Client Client, cluste
 	return nil
 }
 
+// validateUpgradeRolloutStrategy only allows the InPlace upgrade rollout strategy to be set on nutanix clusters.
+// Rolling update customizations are not supported.
 func (v *Validator) validateUpgradeRolloutStrategy(clusterSpec *cluster.Spec) error {
-	if clusterSpec.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy != nil {
-		return fmt.Errorf("upgrade rollout strategy customization is not supported for nutanix provider")
+	cpStrategy := clusterSpec.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy
+	if cpStrategy != nil {
+		if cpStrategy.Type != anywherev1.InPlaceStrategyType {
+			return fmt.Errorf("upgrade rollout strategy customization is not supported for nutanix provider")
+		}
+		if err := validateInPlaceOSFamily(clusterSpec, clusterSpec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef); err != nil {
+			return err
+		}
 	}
 	for _, workerNodeGroupConfiguration := range clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations {
-		if workerNodeGroupConfiguration.UpgradeRolloutStrategy != nil {
+		wnStrategy := workerNodeGroupConfiguration.UpgradeRolloutStrategy
+		if wnStrategy == nil {
+			continue
+		}
+		if wnStrategy.Type != anywherev1.InPlaceStrategyType {
 			return fmt.Errorf("upgrade rollout strategy customization is not supported for nutanix provider")
 		}
+		if err := validateInPlaceOSFamily(clusterSpec, workerNodeGroupConfiguration.MachineGroupRef); err != nil {
+			return err
+		}
+		if workerNodeGroupConfiguration.AutoScalingConfiguration != nil {
+			return fmt.Errorf("autoscaler configuration not supported with InPlace upgrades")
+		}
+	}
+	return nil
+}
+
+func validateInPlaceOSFamily(clusterSpec *cluster.Spec, machineGroupRef *anywherev1.Ref) error {
+	if machineGroupRef == nil {
+		return nil
+	}
+	machineConfig, ok := clusterSpec.NutanixMachineConfigs[machineGroupRef.Name]
+	if ok && machineConfig.OSFamily() != anywherev1.Ubuntu {
+		return fmt.Errorf("InPlace upgrades are only supported on the Ubuntu OS family")
 	}
 	return nil
 }
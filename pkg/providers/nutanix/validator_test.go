@@ -1936,3 +1936,75 @@ func TestValidateControlPlaneIP(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateUpgradeRolloutStrategy(t *testing.T) {
+	tests := []struct {
+		name      string
+		setup     func(*cluster.Spec)
+		expectErr string
+	}{
+		{
+			name:  "no strategy",
+			setup: func(*cluster.Spec) {},
+		},
+		{
+			name: "in place control plane and workers",
+			setup: func(s *cluster.Spec) {
+				s.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy = &v1alpha1.ControlPlaneUpgradeRolloutStrategy{Type: v1alpha1.InPlaceStrategyType}
+				s.Cluster.Spec.WorkerNodeGroupConfigurations[0].UpgradeRolloutStrategy = &v1alpha1.WorkerNodesUpgradeRolloutStrategy{Type: v1alpha1.InPlaceStrategyType}
+			},
+		},
+		{
+			name: "rolling update control plane customization",
+			setup: func(s *cluster.Spec) {
+				s.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy = &v1alpha1.ControlPlaneUpgradeRolloutStrategy{
+					Type:          v1alpha1.RollingUpdateStrategyType,
+					RollingUpdate: &v1alpha1.ControlPlaneRollingUpdateParams{MaxSurge: 1},
+				}
+			},
+			expectErr: "upgrade rollout strategy customization is not supported for nutanix provider",
+		},
+		{
+			name: "rolling update worker customization",
+			setup: func(s *cluster.Spec) {
+				s.Cluster.Spec.WorkerNodeGroupConfigurations[0].UpgradeRolloutStrategy = &v1alpha1.WorkerNodesUpgradeRolloutStrategy{
+					Type:          v1alpha1.RollingUpdateStrategyType,
+					RollingUpdate: &v1alpha1.WorkerNodesRollingUpdateParams{MaxSurge: 1},
+				}
+			},
+			expectErr: "upgrade rollout strategy customization is not supported for nutanix provider",
+		},
+		{
+			name: "in place control plane on non ubuntu",
+			setup: func(s *cluster.Spec) {
+				s.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy = &v1alpha1.ControlPlaneUpgradeRolloutStrategy{Type: v1alpha1.InPlaceStrategyType}
+				for _, mc := range s.NutanixMachineConfigs {
+					mc.Spec.OSFamily = v1alpha1.RedHat
+				}
+			},
+			expectErr: "InPlace upgrades are only supported on the Ubuntu OS family",
+		},
+		{
+			name: "in place workers with autoscaler",
+			setup: func(s *cluster.Spec) {
+				s.Cluster.Spec.WorkerNodeGroupConfigurations[0].UpgradeRolloutStrategy = &v1alpha1.WorkerNodesUpgradeRolloutStrategy{Type: v1alpha1.InPlaceStrategyType}
+				s.Cluster.Spec.WorkerNodeGroupConfigurations[0].AutoScalingConfiguration = &v1alpha1.AutoScalingConfiguration{MinCount: 1, MaxCount: 3}
+			},
+			expectErr: "autoscaler configuration not supported with InPlace upgrades",
+		},
+	}
+
+	validator := &Validator{}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			clusterSpec := test.NewFullClusterSpec(t, "testdata/eksa-cluster.yaml")
+			tc.setup(clusterSpec)
+			err := validator.validateUpgradeRolloutStrategy(clusterSpec)
+			if tc.expectErr != "" {
+				assert.EqualError(t, err, tc.expectErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -53,3 +53,29 @@ func TestWorkersSpecWithUpgradeRolloutStrategy(t *testing.T) {
 	assert.Equal(t, int32(1), workers.Groups[0].MachineDeployment.Spec.Strategy.RollingUpdate.MaxSurge.IntVal)
 	assert.Equal(t, int32(0), workers.Groups[0].MachineDeployment.Spec.Strategy.RollingUpdate.MaxUnavailable.IntVal)
 }
+
+func TestWorkersSpecWithInPlaceUpgradeRolloutStrategy(t *testing.T) {
+	t.Setenv(constants.EksaNutanixUsernameKey, "admin")
+	t.Setenv(constants.EksaNutanixPasswordKey, "password")
+
+	logger := test.NewNullLogger()
+	client := test.NewFakeKubeClient()
+	spec := test.NewFullClusterSpec(t, "testdata/eksa-cluster.yaml")
+	spec.Cluster.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{
+		{
+			Count: ptr.Int(4),
+			MachineGroupRef: &v1alpha1.Ref{
+				Name: "eksa-unit-test",
+			},
+			Name: "eksa-unit-test",
+			UpgradeRolloutStrategy: &v1alpha1.WorkerNodesUpgradeRolloutStrategy{
+				Type: v1alpha1.InPlaceStrategyType,
+			},
+		},
+	}
+	workers, err := WorkersSpec(context.TODO(), logger, client, spec)
+	require.NoError(t, err)
+	assert.Len(t, workers.Groups, 1)
+	assert.Equal(t, "InPlace", string(workers.Groups[0].MachineDeployment.Spec.Strategy.Type))
+	assert.Nil(t, workers.Groups[0].MachineDeployment.Spec.Strategy.RollingUpdate)
+}
@@ -622,10 +622,11 @@ func TestWorkersObjectsTaintsUpdated(t *testing.T) {
 
 	got, err := snow.WorkersObjects(g.ctx, g.logger, g.clusterSpec, g.kubeconfigClient)
 
+	// Taints are updated in place, so the template keeps its name
 	md := wantMachineDeployment()
-	md.Spec.Template.Spec.Bootstrap.ConfigRef.Name = "snow-test-md-0-2"
+	md.Spec.Template.Spec.Bootstrap.ConfigRef.Name = "snow-test-md-0-1"
 	kct := wantKubeadmConfigTemplate()
-	kct.SetName("snow-test-md-0-2")
+	kct.SetName("snow-test-md-0-1")
 
 	g.Expect(err).To(Succeed())
 	g.Expect(got).To(BeComparableTo([]kubernetes.Object{kct, md, mt}))
@@ -680,11 +681,12 @@ func TestWorkersObjectsLabelsUpdated(t *testing.T) {
 
 	got, err := snow.WorkersObjects(g.ctx, g.logger, g.clusterSpec, g.kubeconfigClient)
 
+	// Labels are updated in place, so the template keeps its name
 	md := wantMachineDeployment()
-	md.Spec.Template.Spec.Bootstrap.ConfigRef.Name = "snow-test-md-0-2"
+	md.Spec.Template.Spec.Bootstrap.ConfigRef.Name = "snow-test-md-0-1"
 	md.Spec.Template.Spec.InfrastructureRef.Name = "snow-test-md-0-2"
 	kct := wantKubeadmConfigTemplate()
-	kct.SetName("snow-test-md-0-2")
+	kct.SetName("snow-test-md-0-1")
 	kct.Spec.Template.Spec.JoinConfiguration.NodeRegistration.KubeletExtraArgs = map[string]string{
 		"provider-id": "aws-snow:////'{{ ds.meta_data.instance_id }}'",
 		"node-labels": "label1=val1,label2=val2",
@@ -68,6 +68,26 @@ func (r *Reconciler) Reconcile(ctx context.Context, log logr.Logger, c *anywhere
 	).Run(ctx, log, clusterSpec)
 }
 
+// UpgradeNeeded returns true if reconciling the cluster would replace or upgrade any of its machines.
+func (r *Reconciler) UpgradeNeeded(ctx context.Context, log logr.Logger, c *anywherev1.Cluster) (bool, error) {
+	clusterSpec, err := cluster.BuildSpec(ctx, clientutil.NewKubeClient(r.client), c)
+	if err != nil {
+		return false, err
+	}
+
+	cp, err := snow.ControlPlaneSpec(ctx, log, clientutil.NewKubeClient(r.client), clusterSpec)
+	if err != nil {
+		return false, err
+	}
+
+	w, err := snow.WorkersSpec(ctx, log, clusterSpec, clientutil.NewKubeClient(r.client))
+	if err != nil {
+		return false, err
+	}
+
+	return clusters.UpgradeNeeded(ctx, r.client, toClientControlPlane(cp), toClientWorkers(w))
+}
+
 func (r *Reconciler) ValidateMachineConfigs(ctx context.Context, log logr.Logger, clusterSpec *cluster.Spec) (controller.Result, error) {
 	log = log.WithValues("phase", "validateMachineConfigs")
 	for _, machineConfig := range clusterSpec.SnowMachineConfigs {
@@ -96,7 +116,7 @@ func (s *Reconciler) ReconcileControlPlane(ctx context.Context, log logr.Logger,
 		return controller.Result{}, err
 	}
 
-	return clusters.ReconcileControlPlane(ctx, log, s.client, toClientControlPlane(cp))
+	return clusters.ReconcileControlPlaneForEKSA(ctx, log, s.client, clusterSpec.Cluster, toClientControlPlane(cp))
 }
 
 func (r *Reconciler) CheckControlPlaneReady(ctx context.Context, log logr.Logger, clusterSpec *cluster.Spec) (controller.Result, error) {
@@ -2,6 +2,7 @@ package tinkerbell
 
 import (
 	"context"
+	"sort"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
@@ -22,13 +23,15 @@ type BaseControlPlane = clusterapi.ControlPlane[*tinkerbellv1.TinkerbellCluster,
 // ControlPlane holds the Tinkerbell specific objects for a CAPI Tinkerbell control plane.
 type ControlPlane struct {
 	BaseControlPlane
-	Secrets *corev1.Secret
+	Secrets []*corev1.Secret
 }
 
 // Objects returns the control plane objects associated with the Tinkerbell cluster.
 func (p ControlPlane) Objects() []kubernetes.Object {
 	o := p.BaseControlPlane.Objects()
-	o = append(o, p.Secrets)
+	for _, s := range p.Secrets {
+		o = append(o, s)
+	}
 
 	return o
 }
@@ -84,9 +87,12 @@ func (b *controlPlaneBuilder) BuildFromParsed(lookup yamlutil.ObjectLookup) erro
 	b.ControlPlane.BaseControlPlane = *b.BaseBuilder.ControlPlane
 	for _, obj := range lookup {
 		if obj.GetObjectKind().GroupVersionKind().Kind == constants.SecretKind {
-			b.ControlPlane.Secrets = obj.(*corev1.Secret)
+			b.ControlPlane.Secrets = append(b.ControlPlane.Secrets, obj.(*corev1.Secret))
 		}
 	}
+	sort.Slice(b.ControlPlane.Secrets, func(i, j int) bool {
+		return b.ControlPlane.Secrets[i].Name < b.ControlPlane.Secrets[j].Name
+	})
 
 	return nil
 }
@@ -38,7 +38,7 @@ func TestControlPlaneObjects(t *testing.T) {
 					KubeadmControlPlane:         kubeadmControlPlane(),
 					ControlPlaneMachineTemplate: tinkerbellMachineTemplate("controlplane-machinetemplate"),
 				},
-				Secrets: secret(),
+				Secrets: []*corev1.Secret{secret()},
 			},
 			expected: []kubernetes.Object{
 				capiCluster(),
@@ -159,7 +159,7 @@ func TestControlPlaneSpecRegistryMirrorAuthentication(t *testing.T) {
 	g.Expect(cp.Cluster).To(Equal(capiCluster()))
 	g.Expect(cp.KubeadmControlPlane).To(Equal(kcpWithRegistryCredentials()))
 	g.Expect(cp.ProviderCluster).To(Equal(tinkerbellCluster()))
-	g.Expect(cp.Secrets).To(Equal(secret()))
+	g.Expect(cp.Secrets).To(Equal([]*corev1.Secret{secret(), registryMirrorAuthSecret()}))
 	g.Expect(cp.ControlPlaneMachineTemplate.Name).To(Equal("test-control-plane-1"))
 }
 
@@ -859,11 +859,14 @@ spec:
         [plugins."io.containerd.grpc.v1.cri".registry.mirrors]
           [plugins."io.containerd.grpc.v1.cri".registry.mirrors."public.ecr.aws"]
             endpoint = ["https://:"]
-          [plugins."io.containerd.grpc.v1.cri".registry.configs.":".auth]
-            username = "username"
-            password = "password"
       owner: root:root
       path: /etc/containerd/config_append.toml
+    - contentFrom:
+        secret:
+          key: registry-auth.toml
+          name: test-registry-mirror-auth
+      owner: root:root
+      path: /etc/containerd/registry_auth.toml
     format: cloud-config
     initConfiguration:
       localAPIEndpoint: {}
@@ -891,6 +894,7 @@ spec:
       registryMirror: {}
     preKubeadmCommands:
     - cat /etc/containerd/config_append.toml >> /etc/containerd/config.toml
+    - cat /etc/containerd/registry_auth.toml >> /etc/containerd/config.toml
     - sudo systemctl daemon-reload
     - sudo systemctl restart containerd
     users:
@@ -1108,3 +1112,22 @@ func secret() *corev1.Secret {
 		},
 	}
 }
+
+func registryMirrorAuthSecret() *corev1.Secret {
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Secret",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "eksa-system",
+			Name:      "test-registry-mirror-auth",
+			Labels: map[string]string{
+				"clusterctl.cluster.x-k8s.io/move": "true",
+			},
+		},
+		Data: map[string][]byte{
+			"registry-auth.toml": []byte("[plugins.\"io.containerd.grpc.v1.cri\".registry.configs.\":\".auth]\n  username = \"username\"\n  password = \"password\"\n"),
+		},
+	}
+}
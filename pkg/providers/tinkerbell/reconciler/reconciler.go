@@ -110,6 +110,26 @@ func (r *Reconciler) Reconcile(ctx context.Context, log logr.Logger, cluster *an
 	).Run(ctx, log, NewScope(clusterSpec))
 }
 
+// UpgradeNeeded returns true if reconciling the cluster would replace or upgrade any of its machines.
+func (r *Reconciler) UpgradeNeeded(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) (bool, error) {
+	clusterSpec, err := c.BuildSpec(ctx, clientutil.NewKubeClient(r.client), cluster)
+	if err != nil {
+		return false, err
+	}
+
+	cp, err := tinkerbell.ControlPlaneSpec(ctx, log, clientutil.NewKubeClient(r.client), clusterSpec)
+	if err != nil {
+		return false, err
+	}
+
+	w, err := tinkerbell.WorkersSpec(ctx, log, clientutil.NewKubeClient(r.client), clusterSpec)
+	if err != nil {
+		return false, err
+	}
+
+	return clusters.UpgradeNeeded(ctx, r.client, toClientControlPlane(cp), clusters.ToWorkers(w))
+}
+
 // ValidateControlPlaneIP passes the cluster spec from tinkerbellScope to the IP Validator.
 func (r *Reconciler) ValidateControlPlaneIP(ctx context.Context, log logr.Logger, tinkerbellScope *Scope) (controller.Result, error) {
 	return r.ipValidator.ValidateControlPlaneIP(ctx, log, tinkerbellScope.ClusterSpec)
@@ -196,7 +216,7 @@ func (r *Reconciler) ReconcileControlPlane(ctx context.Context, log logr.Logger,
 	log = log.WithValues("phase", "reconcileControlPlane")
 	log.Info("Applying control plane CAPI objects")
 
-	return clusters.ReconcileControlPlane(ctx, log, r.client, toClientControlPlane(tinkerbellScope.ControlPlane))
+	return clusters.ReconcileControlPlaneForEKSA(ctx, log, r.client, tinkerbellScope.ClusterSpec.Cluster, toClientControlPlane(tinkerbellScope.ControlPlane))
 }
 
 // CheckControlPlaneReady checks whether the control plane for an eks-a cluster is ready or not.
@@ -271,9 +291,9 @@ func (r *Reconciler) validateTinkerbellIPMatch(ctx context.Context, clusterSpec
 }
 
 func toClientControlPlane(cp *tinkerbell.ControlPlane) *clusters.ControlPlane {
-	other := make([]client.Object, 0, 1)
-	if cp.Secrets != nil {
-		other = append(other, cp.Secrets)
+	other := make([]client.Object, 0, len(cp.Secrets))
+	for _, s := range cp.Secrets {
+		other = append(other, s)
 	}
 	return &clusters.ControlPlane{
 		Cluster:                     cp.Cluster,
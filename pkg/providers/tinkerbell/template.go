@@ -18,6 +18,7 @@ import (
 	"sigs.k8s.io/yaml"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/authentication"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/clusterapi"
 	"github.com/aws/eks-anywhere/pkg/config"
@@ -394,7 +395,7 @@ func buildTemplateMapCP(
 	versionsBundle := clusterSpec.RootVersionsBundle()
 	format := "cloud-config"
 
-	apiServerExtraArgs := clusterapi.OIDCToExtraArgs(clusterSpec.OIDCConfig).
+	apiServerExtraArgs := clusterapi.AuthenticationExtraArgs(clusterSpec).
 		Append(clusterapi.AwsIamAuthExtraArgs(clusterSpec.AWSIamConfig)).
 		Append(clusterapi.APIServerExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration.APIServerExtraArgs))
 	clusterapi.SetPodIAMAuthExtraArgs(clusterSpec.Cluster.Spec.PodIAMConfig, apiServerExtraArgs)
@@ -413,6 +414,7 @@ func buildTemplateMapCP(
 		"kubeVipImage":                  versionsBundle.Tinkerbell.KubeVip.VersionedImage(),
 		"podCidrs":                      clusterSpec.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks,
 		"serviceCidrs":                  clusterSpec.Cluster.Spec.ClusterNetwork.Services.CidrBlocks,
+		"skipKubeProxy":                 clusterSpec.Cluster.Spec.ClusterNetwork.KubeProxyReplaced(),
 		"apiserverExtraArgs":            apiServerExtraArgs.ToPartialYaml(),
 		"baseRegistry":                  "", // TODO: need to get this values for creating template IMAGE_URL
 		"osDistro":                      "", // TODO: need to get this values for creating template IMAGE_URL
@@ -487,6 +489,11 @@ func buildTemplateMapCP(
 		values["awsIamAuth"] = true
 	}
 
+	if clusterSpec.Cluster.UsesStructuredAuthentication() {
+		values["structuredAuthentication"] = true
+		values["authenticationConfigSecret"] = authentication.ConfigSecretName(clusterSpec.Cluster.Name)
+	}
+
 	if controlPlaneMachineSpec.HostOSConfiguration != nil {
 		if controlPlaneMachineSpec.HostOSConfiguration.NTPConfiguration != nil {
 			values["cpNtpServers"] = controlPlaneMachineSpec.HostOSConfiguration.NTPConfiguration.Servers
@@ -727,6 +734,7 @@ func populateRegistryMirrorValues(clusterSpec *cluster.Spec, values map[string]i
 		}
 		values["registryUsername"] = username
 		values["registryPassword"] = password
+		values["registryAuthConfig"] = containerd.AuthConfig(registryMirror.BaseRegistry, username, password)
 	}
 	return values, nil
 }
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"strings"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -1108,6 +1109,12 @@ func TestProviderGenerateDeploymentFileForWithBottlerocketRegistryMirrorWithAuth
 
 	test.AssertContentToFile(t, string(cp), "testdata/expected_results_cluster_tinkerbell_bottlerocket_cp_registry_mirror_with_auth.yaml")
 	test.AssertContentToFile(t, string(md), "testdata/expected_results_cluster_tinkerbell_bottlerocket_md_registry_mirror_with_auth.yaml")
+
+	// Bottlerocket machines read the credentials from the registry-credentials secret when they bootstrap,
+	// so they must not be rendered in the machine configs.
+	g := NewWithT(t)
+	g.Expect(strings.Count(string(cp), "cGFzc3dvcmQ=")).To(Equal(1), "only the registry-credentials secret should hold the password")
+	g.Expect(string(md)).NotTo(ContainSubstring("password"))
 }
 
 func TestProviderGenerateDeploymentFileForSingleNodeCluster(t *testing.T) {
@@ -8,6 +8,7 @@ import (
 	"sigs.k8s.io/yaml"
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/authentication"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/clusterapi"
 	"github.com/aws/eks-anywhere/pkg/config"
@@ -168,14 +169,17 @@ func buildTemplateMapCP(
 	etcdExtraArgs := clusterapi.SecureEtcdTlsCipherSuitesExtraArgs()
 	sharedExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs()
 
-	apiServerExtraArgs := clusterapi.OIDCToExtraArgs(clusterSpec.OIDCConfig).
+	apiServerExtraArgs := clusterapi.AuthenticationExtraArgs(clusterSpec).
 		Append(clusterapi.AwsIamAuthExtraArgs(clusterSpec.AWSIamConfig)).
 		Append(clusterapi.APIServerExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration.APIServerExtraArgs)).
 		Append(clusterapi.EtcdEncryptionExtraArgs(clusterSpec.Cluster.Spec.EtcdEncryption)).
 		Append(sharedExtraArgs)
 	clusterapi.SetPodIAMAuthExtraArgs(clusterSpec.Cluster.Spec.PodIAMConfig, apiServerExtraArgs)
 	controllerManagerExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
-		Append(clusterapi.NodeCIDRMaskExtraArgs(&clusterSpec.Cluster.Spec.ClusterNetwork))
+		Append(clusterapi.NodeCIDRMaskExtraArgs(&clusterSpec.Cluster.Spec.ClusterNetwork)).
+		Append(clusterapi.IPv6BindAddressExtraArgs(&clusterSpec.Cluster.Spec.ClusterNetwork))
+	schedulerExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
+		Append(clusterapi.IPv6BindAddressExtraArgs(&clusterSpec.Cluster.Spec.ClusterNetwork))
 
 	vuc := config.NewVsphereUserConfig()
 
@@ -206,8 +210,11 @@ func buildTemplateMapCP(
 		"controlPlaneVsphereFolder":            controlPlaneMachineSpec.Folder,
 		"managerImage":                         versionsBundle.VSphere.Manager.VersionedImage(),
 		"kubeVipImage":                         versionsBundle.VSphere.KubeVip.VersionedImage(),
+		"kubeVipCidr":                          clusterapi.KubeVipCIDR(clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host),
 		"insecure":                             datacenterSpec.Insecure,
 		"vsphereNetwork":                       datacenterSpec.Network,
+		"dhcp4":                                clusterSpec.Cluster.Spec.ClusterNetwork.IPFamily() != anywherev1.IPv6,
+		"dhcp6":                                clusterSpec.Cluster.Spec.ClusterNetwork.UsesIPv6(),
 		"controlPlaneVsphereResourcePool":      controlPlaneMachineSpec.ResourcePool,
 		"vsphereServer":                        datacenterSpec.Server,
 		"controlPlaneVsphereStoragePolicyName": controlPlaneMachineSpec.StoragePolicyName,
@@ -222,11 +229,12 @@ func buildTemplateMapCP(
 		"vsphereControlPlaneSshAuthorizedKey":  controlPlaneSSHKey,
 		"podCidrs":                             clusterSpec.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks,
 		"serviceCidrs":                         clusterSpec.Cluster.Spec.ClusterNetwork.Services.CidrBlocks,
+		"skipKubeProxy":                        clusterSpec.Cluster.Spec.ClusterNetwork.KubeProxyReplaced(),
 		"etcdExtraArgs":                        etcdExtraArgs.ToPartialYaml(),
 		"etcdCipherSuites":                     crypto.SecureCipherSuitesString(),
 		"apiserverExtraArgs":                   apiServerExtraArgs.ToPartialYaml(),
 		"controllerManagerExtraArgs":           controllerManagerExtraArgs.ToPartialYaml(),
-		"schedulerExtraArgs":                   sharedExtraArgs.ToPartialYaml(),
+		"schedulerExtraArgs":                   schedulerExtraArgs.ToPartialYaml(),
 		"format":                               format,
 		"externalEtcdVersion":                  versionsBundle.KubeDistro.EtcdVersion,
 		"etcdImage":                            versionsBundle.KubeDistro.EtcdImage.VersionedImage(),
@@ -270,6 +278,7 @@ func buildTemplateMapCP(
 			}
 			values["registryUsername"] = username
 			values["registryPassword"] = password
+			values["registryAuthConfig"] = containerd.AuthConfig(registryMirror.BaseRegistry, username, password)
 		}
 	}
 
@@ -365,6 +374,11 @@ func buildTemplateMapCP(
 		values["awsIamAuth"] = true
 	}
 
+	if clusterSpec.Cluster.UsesStructuredAuthentication() {
+		values["structuredAuthentication"] = true
+		values["authenticationConfigSecret"] = authentication.ConfigSecretName(clusterSpec.Cluster.Name)
+	}
+
 	if controlPlaneMachineSpec.HostOSConfiguration != nil {
 		if controlPlaneMachineSpec.HostOSConfiguration.NTPConfiguration != nil {
 			values["cpNtpServers"] = controlPlaneMachineSpec.HostOSConfiguration.NTPConfiguration.Servers
@@ -420,6 +434,12 @@ func buildTemplateMapCP(
 		values["kubeletExtraArgs"] = kubeletExtraArgs.ToPartialYaml()
 	}
 
+	// node-ip is passed as a flag both with and without a kubelet configuration, it can't be set in the config file.
+	nodeIPArgs := clusterapi.IPv6NodeIPExtraArgs(&clusterSpec.Cluster.Spec.ClusterNetwork)
+	if len(nodeIPArgs) != 0 {
+		values["nodeIPArgs"] = nodeIPArgs.ToPartialYaml()
+	}
+
 	nodeLabelArgs := clusterapi.ControlPlaneNodeLabelsExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration)
 	if len(nodeLabelArgs) != 0 {
 		values["nodeLabelArgs"] = nodeLabelArgs.ToPartialYaml()
@@ -463,6 +483,8 @@ func buildTemplateMapMD(
 		"workerVsphereDatastore":         workerNodeGroupMachineSpec.Datastore,
 		"workerVsphereFolder":            workerNodeGroupMachineSpec.Folder,
 		"vsphereNetwork":                 datacenterSpec.Network,
+		"dhcp4":                          clusterSpec.Cluster.Spec.ClusterNetwork.IPFamily() != anywherev1.IPv6,
+		"dhcp6":                          clusterSpec.Cluster.Spec.ClusterNetwork.UsesIPv6(),
 		"workerVsphereResourcePool":      workerNodeGroupMachineSpec.ResourcePool,
 		"vsphereServer":                  datacenterSpec.Server,
 		"workerVsphereStoragePolicyName": workerNodeGroupMachineSpec.StoragePolicyName,
@@ -506,6 +528,7 @@ func buildTemplateMapMD(
 			}
 			values["registryUsername"] = username
 			values["registryPassword"] = password
+			values["registryAuthConfig"] = containerd.AuthConfig(registryMirror.BaseRegistry, username, password)
 		}
 	}
 
@@ -594,6 +617,11 @@ func buildTemplateMapMD(
 		values["kubeletExtraArgs"] = kubeletExtraArgs.ToPartialYaml()
 	}
 
+	nodeIPArgs := clusterapi.IPv6NodeIPExtraArgs(&clusterSpec.Cluster.Spec.ClusterNetwork)
+	if len(nodeIPArgs) != 0 {
+		values["nodeIPArgs"] = nodeIPArgs.ToPartialYaml()
+	}
+
 	nodeLabelArgs := clusterapi.WorkerNodeLabelsExtraArgs(workerNodeGroupConfiguration)
 	if len(nodeLabelArgs) != 0 {
 		values["nodeLabelArgs"] = nodeLabelArgs.ToPartialYaml()
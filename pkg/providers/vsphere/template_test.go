@@ -1,6 +1,7 @@
 package vsphere_test
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/clusterapi"
 	"github.com/aws/eks-anywhere/pkg/config"
 	"github.com/aws/eks-anywhere/pkg/providers/vsphere"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
 )
 
 const (
@@ -193,3 +195,87 @@ func TestVsphereTemplateBuilderGenerateFailureDomainYaml(t *testing.T) {
 	g.Expect(err).ToNot(HaveOccurred())
 	test.AssertContentToFile(t, string(data), "testdata/expected_results_failuredomain.yaml")
 }
+
+func TestVsphereTemplateBuilderGenerateCAPISpecIPv6(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewFullClusterSpec(t, "testdata/cluster_main.yaml")
+	spec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host = "fd00::10"
+	spec.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks = []string{"fd00:10:244::/56"}
+	spec.Cluster.Spec.ClusterNetwork.Services.CidrBlocks = []string{"fd00:10:96::/108"}
+	builder := vsphere.NewVsphereTemplateBuilder(time.Now)
+
+	cp, err := builder.GenerateCAPISpecControlPlane(spec)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(cp)).To(ContainSubstring("cidrBlocks: [fd00:10:244::/56]"))
+	g.Expect(string(cp)).To(ContainSubstring("cidrBlocks: [fd00:10:96::/108]"))
+	g.Expect(string(cp)).To(ContainSubstring("- dhcp4: false\n          dhcp6: true\n"))
+	g.Expect(string(cp)).To(ContainSubstring("- name: vip_cidr\n              value: \"128\""))
+	g.Expect(string(cp)).To(ContainSubstring("bind-address: '::'"))
+	g.Expect(string(cp)).To(ContainSubstring("node-ip: '::'"))
+
+	workers, err := builder.GenerateCAPISpecWorkers(spec, nil, nil)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(workers)).To(ContainSubstring("- dhcp4: false\n          dhcp6: true\n"))
+	g.Expect(string(workers)).To(ContainSubstring("node-ip: '::'"))
+}
+
+func TestVsphereTemplateBuilderGenerateCAPISpecDualStack(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewFullClusterSpec(t, "testdata/cluster_main.yaml")
+	spec.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks = []string{"192.168.0.0/16", "fd00:10:244::/56"}
+	spec.Cluster.Spec.ClusterNetwork.Services.CidrBlocks = []string{"10.96.0.0/12", "fd00:10:96::/108"}
+	spec.Cluster.Spec.ClusterNetwork.Nodes = &v1alpha1.Nodes{CIDRMaskSize: ptr.Int(24), CIDRMaskSizeIPv6: ptr.Int(64)}
+	builder := vsphere.NewVsphereTemplateBuilder(time.Now)
+
+	cp, err := builder.GenerateCAPISpecControlPlane(spec)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(cp)).To(ContainSubstring("cidrBlocks: [192.168.0.0/16, fd00:10:244::/56]"))
+	g.Expect(string(cp)).To(ContainSubstring("cidrBlocks: [10.96.0.0/12, fd00:10:96::/108]"))
+	g.Expect(string(cp)).To(ContainSubstring("- dhcp4: true\n          dhcp6: true\n"))
+	g.Expect(string(cp)).To(ContainSubstring("- name: vip_cidr\n              value: \"32\""))
+	g.Expect(string(cp)).To(ContainSubstring("node-cidr-mask-size-ipv4: \"24\""))
+	g.Expect(string(cp)).To(ContainSubstring("node-cidr-mask-size-ipv6: \"64\""))
+	g.Expect(string(cp)).ToNot(ContainSubstring("node-ip"))
+}
+
+func TestVsphereTemplateBuilderGenerateCAPISpecControlPlaneKubeProxyReplacement(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewFullClusterSpec(t, "testdata/cluster_main.yaml")
+	spec.Cluster.Spec.ClusterNetwork.CNIConfig = &v1alpha1.CNIConfig{
+		Cilium: &v1alpha1.CiliumConfig{KubeProxyReplacement: true},
+	}
+	builder := vsphere.NewVsphereTemplateBuilder(time.Now)
+
+	cp, err := builder.GenerateCAPISpecControlPlane(spec)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(cp)).To(ContainSubstring("skipPhases:\n      - addon/kube-proxy"))
+}
+
+func TestVsphereTemplateBuilderGenerateCAPISpecIPv6KubeletConfiguration(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewFullClusterSpec(t, "testdata/cluster_main.yaml")
+	spec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host = "fd00::10"
+	spec.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks = []string{"fd00:10:244::/56"}
+	spec.Cluster.Spec.ClusterNetwork.Services.CidrBlocks = []string{"fd00:10:96::/108"}
+	spec.Cluster.Spec.ControlPlaneConfiguration.KubeletConfiguration = &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"maxPods": 20,
+		},
+	}
+	spec.Cluster.Spec.WorkerNodeGroupConfigurations[0].KubeletConfiguration = &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"maxPods": 20,
+		},
+	}
+	builder := vsphere.NewVsphereTemplateBuilder(time.Now)
+
+	cp, err := builder.GenerateCAPISpecControlPlane(spec)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(cp)).To(ContainSubstring("maxPods: 20"))
+	g.Expect(strings.Count(string(cp), "node-ip: '::'")).To(Equal(2))
+
+	workers, err := builder.GenerateCAPISpecWorkers(spec, nil, nil)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(workers)).To(ContainSubstring("maxPods: 20"))
+	g.Expect(string(workers)).To(ContainSubstring("node-ip: '::'"))
+}
@@ -176,6 +176,14 @@ func (v *Validator) ValidateClusterMachineConfigs(ctx context.Context, vsphereCl
 		}
 	}
 
+	if err := validateInPlaceUpgradeRolloutStrategy(vsphereClusterSpec); err != nil {
+		return err
+	}
+
+	if err := validateIPv6OSFamily(vsphereClusterSpec); err != nil {
+		return err
+	}
+
 	// TODO: move this to api Cluster validations
 	if err := v.validateControlPlaneIp(vsphereClusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host); err != nil {
 		return err
@@ -645,3 +653,48 @@ func sliceIfNotNil(machines ...*anywherev1.VSphereMachineConfig) []*anywherev1.V
 
 	return notNil
 }
+
+// validateInPlaceUpgradeRolloutStrategy makes sure the machines that use the InPlace upgrade rollout strategy
+// run an OS supported by the in-place upgrader and are not managed by the cluster autoscaler.
+// validateIPv6OSFamily checks that IPv6 clusters don't use Bottlerocket nodes. Bottlerocket
+// ignores the kubelet node-ip arg needed to register the nodes with their IPv6 address.
+func validateIPv6OSFamily(vsphereClusterSpec *Spec) error {
+	if !vsphereClusterSpec.Cluster.Spec.ClusterNetwork.IsIPv6Primary() {
+		return nil
+	}
+
+	machineConfigs := []*anywherev1.VSphereMachineConfig{vsphereClusterSpec.controlPlaneMachineConfig()}
+	for _, wng := range vsphereClusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		machineConfigs = append(machineConfigs, vsphereClusterSpec.workerMachineConfig(wng))
+	}
+	for _, mc := range machineConfigs {
+		if mc.OSFamily() == anywherev1.Bottlerocket {
+			return fmt.Errorf("IPv6 cluster networks are not supported on the Bottlerocket OS family, VSphereMachineConfig %s", mc.Name)
+		}
+	}
+
+	return nil
+}
+
+func validateInPlaceUpgradeRolloutStrategy(vsphereClusterSpec *Spec) error {
+	cpStrategy := vsphereClusterSpec.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy
+	if cpStrategy != nil && cpStrategy.Type == anywherev1.InPlaceStrategyType {
+		if vsphereClusterSpec.controlPlaneMachineConfig().OSFamily() != anywherev1.Ubuntu {
+			return errors.New("InPlace upgrades are only supported on the Ubuntu OS family")
+		}
+	}
+
+	for _, wng := range vsphereClusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		if wng.UpgradeRolloutStrategy == nil || wng.UpgradeRolloutStrategy.Type != anywherev1.InPlaceStrategyType {
+			continue
+		}
+		if vsphereClusterSpec.workerMachineConfig(wng).OSFamily() != anywherev1.Ubuntu {
+			return errors.New("InPlace upgrades are only supported on the Ubuntu OS family")
+		}
+		if wng.AutoScalingConfiguration != nil {
+			return fmt.Errorf("autoscaler configuration not supported with InPlace upgrades for worker node group %s", wng.Name)
+		}
+	}
+
+	return nil
+}
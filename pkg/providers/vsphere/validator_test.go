@@ -12,6 +12,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/aws/eks-anywhere/internal/test"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/config"
@@ -682,6 +683,63 @@ func TestValidateBRHardDiskSize(t *testing.T) {
 	}
 }
 
+func TestValidateInPlaceUpgradeRolloutStrategy(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(*cluster.Spec)
+		wantErr string
+	}{
+		{
+			name:  "no strategy",
+			setup: func(*cluster.Spec) {},
+		},
+		{
+			name: "in place on ubuntu",
+			setup: func(s *cluster.Spec) {
+				s.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy = &v1alpha1.ControlPlaneUpgradeRolloutStrategy{Type: v1alpha1.InPlaceStrategyType}
+				s.Cluster.Spec.WorkerNodeGroupConfigurations[0].UpgradeRolloutStrategy = &v1alpha1.WorkerNodesUpgradeRolloutStrategy{Type: v1alpha1.InPlaceStrategyType}
+			},
+		},
+		{
+			name: "in place control plane on bottlerocket",
+			setup: func(s *cluster.Spec) {
+				s.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy = &v1alpha1.ControlPlaneUpgradeRolloutStrategy{Type: v1alpha1.InPlaceStrategyType}
+				s.VSphereMachineConfigs["test-cp"].Spec.OSFamily = v1alpha1.Bottlerocket
+			},
+			wantErr: "InPlace upgrades are only supported on the Ubuntu OS family",
+		},
+		{
+			name: "in place workers on bottlerocket",
+			setup: func(s *cluster.Spec) {
+				s.Cluster.Spec.WorkerNodeGroupConfigurations[0].UpgradeRolloutStrategy = &v1alpha1.WorkerNodesUpgradeRolloutStrategy{Type: v1alpha1.InPlaceStrategyType}
+				s.VSphereMachineConfigs["test-wn"].Spec.OSFamily = v1alpha1.Bottlerocket
+			},
+			wantErr: "InPlace upgrades are only supported on the Ubuntu OS family",
+		},
+		{
+			name: "in place workers with autoscaler",
+			setup: func(s *cluster.Spec) {
+				s.Cluster.Spec.WorkerNodeGroupConfigurations[0].UpgradeRolloutStrategy = &v1alpha1.WorkerNodesUpgradeRolloutStrategy{Type: v1alpha1.InPlaceStrategyType}
+				s.Cluster.Spec.WorkerNodeGroupConfigurations[0].AutoScalingConfiguration = &v1alpha1.AutoScalingConfiguration{MinCount: 1, MaxCount: 3}
+			},
+			wantErr: "autoscaler configuration not supported with InPlace upgrades for worker node group md-0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			clusterSpec := test.NewFullClusterSpec(t, "testdata/cluster_main.yaml")
+			tt.setup(clusterSpec)
+			err := validateInPlaceUpgradeRolloutStrategy(NewSpec(clusterSpec))
+			if tt.wantErr == "" {
+				g.Expect(err).To(Succeed())
+			} else {
+				g.Expect(err).To(MatchError(tt.wantErr))
+			}
+		})
+	}
+}
+
 func TestValidator_validateTemplates(t *testing.T) {
 	type template struct {
 		name string
@@ -856,3 +914,63 @@ func TestValidator_validateTemplates(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateIPv6OSFamily(t *testing.T) {
+	ipv6 := func(s *cluster.Spec) {
+		s.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks = []string{"fd00:10:244::/56"}
+		s.Cluster.Spec.ClusterNetwork.Services.CidrBlocks = []string{"fd00:10:96::/108"}
+	}
+	tests := []struct {
+		name    string
+		setup   func(*cluster.Spec)
+		wantErr string
+	}{
+		{
+			name: "ipv4 on bottlerocket",
+			setup: func(s *cluster.Spec) {
+				s.VSphereMachineConfigs["test-cp"].Spec.OSFamily = v1alpha1.Bottlerocket
+			},
+		},
+		{
+			name:  "ipv6 on ubuntu",
+			setup: ipv6,
+		},
+		{
+			name: "dual stack ipv4 first on bottlerocket",
+			setup: func(s *cluster.Spec) {
+				s.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks = []string{"192.168.0.0/16", "fd00:10:244::/56"}
+				s.Cluster.Spec.ClusterNetwork.Services.CidrBlocks = []string{"10.96.0.0/12", "fd00:10:96::/108"}
+				s.VSphereMachineConfigs["test-cp"].Spec.OSFamily = v1alpha1.Bottlerocket
+			},
+		},
+		{
+			name: "ipv6 control plane on bottlerocket",
+			setup: func(s *cluster.Spec) {
+				ipv6(s)
+				s.VSphereMachineConfigs["test-cp"].Spec.OSFamily = v1alpha1.Bottlerocket
+			},
+			wantErr: "IPv6 cluster networks are not supported on the Bottlerocket OS family, VSphereMachineConfig test-cp",
+		},
+		{
+			name: "ipv6 workers on bottlerocket",
+			setup: func(s *cluster.Spec) {
+				ipv6(s)
+				s.VSphereMachineConfigs["test-wn"].Spec.OSFamily = v1alpha1.Bottlerocket
+			},
+			wantErr: "IPv6 cluster networks are not supported on the Bottlerocket OS family, VSphereMachineConfig test-wn",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			clusterSpec := test.NewFullClusterSpec(t, "testdata/cluster_main.yaml")
+			tt.setup(clusterSpec)
+			err := validateIPv6OSFamily(NewSpec(clusterSpec))
+			if tt.wantErr == "" {
+				g.Expect(err).To(Succeed())
+			} else {
+				g.Expect(err).To(MatchError(tt.wantErr))
+			}
+		})
+	}
+}
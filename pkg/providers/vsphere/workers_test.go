@@ -106,20 +106,16 @@ func TestWorkersSpecUpgradeCluster(t *testing.T) {
 	// This will cause a change in the vsphere machine templates, which is immutable
 	spec.VSphereMachineConfigs["test-wn"].Spec.NumCPUs = 10
 
-	// This will cause a change in the kubeadmconfigtemplate which we also treat as immutable
+	// This will cause a change in the kubeadmconfigtemplate that is applied in place, so it keeps its name
 	spec.Cluster.Spec.WorkerNodeGroupConfigurations[0].Taints = []corev1.Taint{}
 	spec.Cluster.Spec.WorkerNodeGroupConfigurations[1].Taints = []corev1.Taint{}
 
 	expectedGroup1.MachineDeployment.Spec.Template.Spec.InfrastructureRef.Name = "test-md-0-2"
-	expectedGroup1.MachineDeployment.Spec.Template.Spec.Bootstrap.ConfigRef.Name = "test-md-0-2"
-	expectedGroup1.KubeadmConfigTemplate.Name = "test-md-0-2"
 	expectedGroup1.KubeadmConfigTemplate.Spec.Template.Spec.JoinConfiguration.NodeRegistration.Taints = []corev1.Taint{}
 	expectedGroup1.ProviderMachineTemplate.Name = "test-md-0-2"
 	expectedGroup1.ProviderMachineTemplate.Spec.Template.Spec.NumCPUs = 10
 
 	expectedGroup2.MachineDeployment.Spec.Template.Spec.InfrastructureRef.Name = "test-md-1-2"
-	expectedGroup2.MachineDeployment.Spec.Template.Spec.Bootstrap.ConfigRef.Name = "test-md-1-2"
-	expectedGroup2.KubeadmConfigTemplate.Name = "test-md-1-2"
 	expectedGroup2.KubeadmConfigTemplate.Spec.Template.Spec.JoinConfiguration.NodeRegistration.Taints = []corev1.Taint{}
 	expectedGroup2.ProviderMachineTemplate.Name = "test-md-1-2"
 	expectedGroup2.ProviderMachineTemplate.Spec.Template.Spec.NumCPUs = 10
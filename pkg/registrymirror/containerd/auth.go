@@ -0,0 +1,20 @@
+package containerd
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// AuthConfigPath is the path on the node of the containerd configuration
+// snippet holding the registry mirror credentials.
+const AuthConfigPath = "/etc/containerd/registry_auth.toml"
+
+// AuthConfig returns the containerd CRI plugin configuration that sets the credentials
+// used to authenticate against the registry mirror at mirrorBase.
+// The returned snippet is meant to be appended to the containerd config.toml file.
+func AuthConfig(mirrorBase, username, password string) string {
+	return fmt.Sprintf(`[plugins."io.containerd.grpc.v1.cri".registry.configs.%s.auth]
+  username = %s
+  password = %s
+`, strconv.Quote(mirrorBase), strconv.Quote(username), strconv.Quote(password))
+}
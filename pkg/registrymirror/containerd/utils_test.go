@@ -73,3 +73,12 @@ func TestToAPIEndpoints(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthConfig(t *testing.T) {
+	g := NewWithT(t)
+	want := `[plugins."io.containerd.grpc.v1.cri".registry.configs."1.2.3.4:443".auth]
+  username = "username"
+  password = "pass\"word"
+`
+	g.Expect(containerd.AuthConfig("1.2.3.4:443", "username", `pass"word`)).To(Equal(want))
+}
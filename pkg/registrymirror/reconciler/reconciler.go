@@ -0,0 +1,245 @@
+package reconciler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	anywhereCluster "github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
+	"github.com/aws/eks-anywhere/pkg/controller/clusters"
+	"github.com/aws/eks-anywhere/pkg/controller/reconcileutil"
+	"github.com/aws/eks-anywhere/pkg/nodeupgrader"
+	"github.com/aws/eks-anywhere/pkg/registrymirror"
+	"github.com/aws/eks-anywhere/pkg/registrymirror/containerd"
+)
+
+const (
+	// CredentialsHashAnnotation holds the hash of the registry mirror credentials applied to a cluster or a node.
+	CredentialsHashAnnotation = "anywhere.eks.amazonaws.com/registry-mirror-credentials-hash"
+
+	// CredentialsSecretName is the name of the secret created in the workload cluster
+	// with the credentials consumed by the node updater pods.
+	CredentialsSecretName = "registry-mirror-credentials"
+
+	credentialsHashLabel = "anywhere.eks.amazonaws.com/registry-mirror-credentials-hash"
+	requeueAfter         = 10 * time.Second
+	failureRequeueAfter  = time.Minute
+)
+
+// RemoteClientRegistry defines methods for remote cluster controller clients.
+type RemoteClientRegistry interface {
+	GetClient(ctx context.Context, cluster client.ObjectKey) (client.Client, error)
+}
+
+// Reconciler rotates the registry mirror credentials of the nodes of a cluster in place,
+// without rolling out new machines.
+type Reconciler struct {
+	client               client.Client
+	remoteClientRegistry RemoteClientRegistry
+}
+
+// New returns a new Reconciler.
+func New(client client.Client, remoteClientRegistry RemoteClientRegistry) *Reconciler {
+	return &Reconciler{
+		client:               client,
+		remoteClientRegistry: remoteClientRegistry,
+	}
+}
+
+// Reconcile makes sure the credentials stored in the registry-credentials secret are configured
+// in containerd (or the Bottlerocket API) on every node of the cluster. The hash of the applied credentials
+// is tracked in the cluster and node annotations and progress is reported through the
+// RegistryMirrorCredentialsRotated condition.
+// It uses a controller.Result to indicate when requeues are needed.
+func (r *Reconciler) Reconcile(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) (controller.Result, error) {
+	if !cluster.RegistryAuth() {
+		return controller.Result{}, nil
+	}
+
+	username, password, err := config.ReadCredentialsFromSecret(ctx, r.client)
+	if err != nil {
+		return controller.Result{}, err
+	}
+	hash := reconcileutil.ShortHash([]byte(username + ":" + password))
+
+	appliedHash, ok := cluster.Annotations[CredentialsHashAnnotation]
+	if !ok {
+		// Nodes created before the credentials were tracked have been bootstrapped with the current ones.
+		log.Info("Tracking registry mirror credentials for the first time")
+		clientutil.AddAnnotation(cluster, CredentialsHashAnnotation, hash)
+		conditions.MarkTrue(cluster, anywherev1.RegistryMirrorCredentialsRotatedCondition)
+		return controller.Result{}, nil
+	}
+
+	if appliedHash == hash {
+		conditions.MarkTrue(cluster, anywherev1.RegistryMirrorCredentialsRotatedCondition)
+		return controller.Result{}, nil
+	}
+
+	log.Info("Registry mirror credentials have changed, rotating them in place")
+	conditions.MarkFalse(cluster, anywherev1.RegistryMirrorCredentialsRotatedCondition, anywherev1.RegistryMirrorCredentialsRotationInProgressReason, clusterv1.ConditionSeverityInfo, "Waiting for control plane to be ready")
+
+	result, err := clusters.CheckControlPlaneReady(ctx, r.client, log, cluster)
+	if err != nil {
+		return controller.Result{}, errors.Wrap(err, "checking controlplane ready")
+	}
+	if result.Return() {
+		return result, nil
+	}
+
+	spec, err := anywhereCluster.BuildSpec(ctx, clientutil.NewKubeClient(r.client), cluster)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	mirror := registrymirror.FromCluster(cluster)
+	authConfig := containerd.AuthConfig(mirror.BaseRegistry, username, password)
+	bottlerocketSettings, err := bottlerocketCredentialsSettings(mirror.BaseRegistry, username, password)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	// New machines bootstrap containerd from this secret, so it needs to be updated
+	// before touching the existing nodes.
+	if err := reconcileutil.CreateOrUpdateSecret(ctx, r.client, authSecret(cluster.Name, authConfig)); err != nil {
+		return controller.Result{}, errors.Wrap(err, "updating registry mirror auth secret")
+	}
+
+	rClient, err := r.remoteClientRegistry.GetClient(ctx, controller.CapiClusterObjectKey(cluster))
+	if err != nil {
+		return controller.Result{}, errors.Wrap(err, "getting workload cluster's client to rotate registry mirror credentials")
+	}
+
+	if err := reconcileutil.EnsureNamespace(ctx, rClient, constants.EksaSystemNamespace); err != nil {
+		return controller.Result{}, err
+	}
+
+	if err := reconcileutil.CreateOrUpdateSecret(ctx, rClient, credentialsSecret(hash, authConfig, bottlerocketSettings)); err != nil {
+		return controller.Result{}, errors.Wrap(err, "updating registry mirror credentials secret in workload cluster")
+	}
+
+	nodes := &corev1.NodeList{}
+	if err := rClient.List(ctx, nodes); err != nil {
+		return controller.Result{}, errors.Wrap(err, "listing nodes in workload cluster")
+	}
+
+	progress, err := nodeUpdater(spec.RootVersionsBundle().Upgrader.Upgrader.VersionedImage(), hash).UpdateNodes(ctx, log, rClient, nodes.Items)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	if len(progress.Failed) > 0 {
+		conditions.MarkFalse(cluster, anywherev1.RegistryMirrorCredentialsRotatedCondition, anywherev1.RegistryMirrorCredentialsRotationFailedReason, clusterv1.ConditionSeverityError,
+			"Updating registry mirror credentials failed on nodes: %s", strings.Join(progress.Failed, ", "))
+		return controller.ResultWithRequeue(failureRequeueAfter), nil
+	}
+
+	if progress.Updated < progress.Total {
+		conditions.MarkFalse(cluster, anywherev1.RegistryMirrorCredentialsRotatedCondition, anywherev1.RegistryMirrorCredentialsRotationInProgressReason, clusterv1.ConditionSeverityInfo,
+			"%d of %d nodes have the new registry mirror credentials", progress.Updated, progress.Total)
+		return controller.ResultWithRequeue(requeueAfter), nil
+	}
+
+	log.Info("Registry mirror credentials rotated on all nodes")
+	clientutil.AddAnnotation(cluster, CredentialsHashAnnotation, hash)
+	conditions.MarkTrue(cluster, anywherev1.RegistryMirrorCredentialsRotatedCondition)
+
+	return controller.Result{}, nil
+}
+
+func nodeUpdater(image, hash string) reconcileutil.NodeUpdater {
+	return reconcileutil.NodeUpdater{
+		Description:    "registry credentials",
+		Hash:           hash,
+		HashAnnotation: CredentialsHashAnnotation,
+		HashLabel:      credentialsHashLabel,
+		PodName:        nodeupgrader.RegistryCredentialsPodName,
+		// Updating the credentials restarts containerd, which disrupts the node.
+		OneNodeAtATime: true,
+		Pod: func(node *corev1.Node) *corev1.Pod {
+			if isBottlerocket(node) {
+				return nodeupgrader.UpdateBottlerocketRegistryCredentialsPod(node.Name, image, CredentialsSecretName)
+			}
+			return nodeupgrader.UpdateContainerdRegistryCredentialsPod(node.Name, image, CredentialsSecretName)
+		},
+	}
+}
+
+func isBottlerocket(node *corev1.Node) bool {
+	return strings.Contains(strings.ToLower(node.Status.NodeInfo.OSImage), "bottlerocket")
+}
+
+func authSecret(clusterName, authConfig string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      registrymirror.AuthSecretName(clusterName),
+			Namespace: constants.EksaSystemNamespace,
+			Labels: map[string]string{
+				constants.ClusterctlMoveLabelName: "true",
+			},
+		},
+		Data: map[string][]byte{
+			nodeupgrader.ContainerdAuthConfigKey: []byte(authConfig),
+		},
+	}
+}
+
+func credentialsSecret(hash, authConfig string, bottlerocketSettings []byte) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      CredentialsSecretName,
+			Namespace: constants.EksaSystemNamespace,
+			Labels: map[string]string{
+				credentialsHashLabel: hash,
+			},
+		},
+		Data: map[string][]byte{
+			nodeupgrader.ContainerdAuthConfigKey: []byte(authConfig),
+			nodeupgrader.BottlerocketSettingsKey: bottlerocketSettings,
+		},
+	}
+}
+
+type bottlerocketRegistryCredential struct {
+	Registry string `json:"registry"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+func bottlerocketCredentialsSettings(registry, username, password string) ([]byte, error) {
+	settings := map[string]interface{}{
+		"settings": map[string]interface{}{
+			"container-registry": map[string]interface{}{
+				"credentials": []bottlerocketRegistryCredential{
+					{
+						Registry: registry,
+						Username: username,
+						Password: password,
+					},
+				},
+			},
+		},
+	}
+
+	b, err := json.Marshal(settings)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling bottlerocket registry credentials settings: %v", err)
+	}
+
+	return b, nil
+}
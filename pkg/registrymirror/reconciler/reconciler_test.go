@@ -0,0 +1,346 @@
+package reconciler_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	eksdv1 "github.com/aws/eks-distro-build-tooling/release/api/v1alpha1"
+	"github.com/go-logr/logr"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/nodeupgrader"
+	"github.com/aws/eks-anywhere/pkg/registrymirror"
+	"github.com/aws/eks-anywhere/pkg/registrymirror/reconciler"
+	"github.com/aws/eks-anywhere/pkg/registrymirror/reconciler/mocks"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+type reconcilerTest struct {
+	*WithT
+	ctx                  context.Context
+	cluster              *anywherev1.Cluster
+	client               client.Client
+	remoteClient         client.Client
+	remoteClientRegistry *mocks.MockRemoteClientRegistry
+	reconciler           *reconciler.Reconciler
+}
+
+func newReconcilerTest(t *testing.T, username, password string, nodes ...*corev1.Node) *reconcilerTest {
+	ctrl := gomock.NewController(t)
+	remoteClientRegistry := mocks.NewMockRemoteClientRegistry(ctrl)
+
+	bundle := test.Bundle()
+	version := test.DevEksaVersion()
+	cluster := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster",
+			Namespace: constants.EksaSystemNamespace,
+		},
+		Spec: anywherev1.ClusterSpec{
+			KubernetesVersion: "1.22",
+			BundlesRef: &anywherev1.BundlesRef{
+				Name:       bundle.Name,
+				Namespace:  bundle.Namespace,
+				APIVersion: bundle.APIVersion,
+			},
+			EksaVersion: &version,
+			RegistryMirrorConfiguration: &anywherev1.RegistryMirrorConfiguration{
+				Endpoint:     "1.2.3.4",
+				Port:         "443",
+				Authenticate: true,
+			},
+		},
+	}
+	kcp := test.KubeadmControlPlane(func(kcp *controlplanev1.KubeadmControlPlane) {
+		kcp.Name = cluster.Name
+		kcp.Spec.Version = "test"
+		kcp.Status = controlplanev1.KubeadmControlPlaneStatus{
+			Conditions: clusterv1.Conditions{
+				{
+					Type:               clusterapi.ReadyCondition,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(time.Now()),
+				},
+			},
+			Version: pointer.String("test"),
+		}
+	})
+	credentials := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "registry-credentials",
+			Namespace: constants.EksaSystemNamespace,
+		},
+		Data: map[string][]byte{
+			"username": []byte(username),
+			"password": []byte(password),
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	_ = releasev1.AddToScheme(scheme)
+	_ = eksdv1.AddToScheme(scheme)
+	_ = clusterv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+	_ = controlplanev1.AddToScheme(scheme)
+	_ = anywherev1.AddToScheme(scheme)
+
+	objs := []runtime.Object{bundle, test.EksdRelease("1-22"), test.EKSARelease(), kcp, credentials}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objs...).Build()
+
+	remoteObjs := make([]runtime.Object, 0, len(nodes))
+	for _, n := range nodes {
+		remoteObjs = append(remoteObjs, n)
+	}
+	remoteClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(remoteObjs...).Build()
+
+	return &reconcilerTest{
+		WithT:                NewWithT(t),
+		ctx:                  context.Background(),
+		cluster:              cluster,
+		client:               cl,
+		remoteClient:         remoteClient,
+		remoteClientRegistry: remoteClientRegistry,
+		reconciler:           reconciler.New(cl, remoteClientRegistry),
+	}
+}
+
+func (tt *reconcilerTest) updateCredentials(username, password string) {
+	secret := &corev1.Secret{}
+	tt.Expect(tt.client.Get(tt.ctx, client.ObjectKey{Name: "registry-credentials", Namespace: constants.EksaSystemNamespace}, secret)).To(Succeed())
+	secret.Data["username"] = []byte(username)
+	secret.Data["password"] = []byte(password)
+	tt.Expect(tt.client.Update(tt.ctx, secret)).To(Succeed())
+}
+
+func (tt *reconcilerTest) expectRemoteClient() {
+	tt.remoteClientRegistry.EXPECT().GetClient(tt.ctx, client.ObjectKey{Name: tt.cluster.Name, Namespace: constants.EksaSystemNamespace}).Return(tt.remoteClient, nil)
+}
+
+func (tt *reconcilerTest) setPodPhase(nodeName string, phase corev1.PodPhase) {
+	pod := &corev1.Pod{}
+	tt.Expect(tt.remoteClient.Get(tt.ctx, client.ObjectKey{Name: nodeupgrader.RegistryCredentialsPodName(nodeName), Namespace: constants.EksaSystemNamespace}, pod)).To(Succeed())
+	pod.Status.Phase = phase
+	tt.Expect(tt.remoteClient.Status().Update(tt.ctx, pod)).To(Succeed())
+}
+
+func (tt *reconcilerTest) expectCondition(status corev1.ConditionStatus, reason string) {
+	condition := conditions.Get(tt.cluster, anywherev1.RegistryMirrorCredentialsRotatedCondition)
+	tt.Expect(condition).ToNot(BeNil())
+	tt.Expect(condition.Status).To(Equal(status))
+	tt.Expect(condition.Reason).To(Equal(reason))
+}
+
+func node(name, osImage string) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Status: corev1.NodeStatus{
+			NodeInfo: corev1.NodeSystemInfo{
+				OSImage: osImage,
+			},
+			Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+			},
+		},
+	}
+}
+
+func controlPlaneNode(name, osImage string) *corev1.Node {
+	n := node(name, osImage)
+	n.Labels = map[string]string{"node-role.kubernetes.io/control-plane": ""}
+	return n
+}
+
+func (tt *reconcilerTest) setNodeReady(nodeName string, status corev1.ConditionStatus) {
+	n := &corev1.Node{}
+	tt.Expect(tt.remoteClient.Get(tt.ctx, client.ObjectKey{Name: nodeName}, n)).To(Succeed())
+	n.Status.Conditions = []corev1.NodeCondition{{Type: corev1.NodeReady, Status: status}}
+	tt.Expect(tt.remoteClient.Status().Update(tt.ctx, n)).To(Succeed())
+}
+
+func (tt *reconcilerTest) expectPod(nodeName string) *corev1.Pod {
+	pod := &corev1.Pod{}
+	tt.Expect(tt.remoteClient.Get(tt.ctx, client.ObjectKey{Name: nodeupgrader.RegistryCredentialsPodName(nodeName), Namespace: constants.EksaSystemNamespace}, pod)).To(Succeed())
+	return pod
+}
+
+func (tt *reconcilerTest) expectNoPod(nodeName string) {
+	err := tt.remoteClient.Get(tt.ctx, client.ObjectKey{Name: nodeupgrader.RegistryCredentialsPodName(nodeName), Namespace: constants.EksaSystemNamespace}, &corev1.Pod{})
+	tt.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+}
+
+func (tt *reconcilerTest) reconcileInProgress() {
+	tt.expectRemoteClient()
+	result, err := tt.reconciler.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result.Return()).To(BeTrue())
+	tt.expectCondition(corev1.ConditionFalse, anywherev1.RegistryMirrorCredentialsRotationInProgressReason)
+}
+
+func nullLog() logr.Logger {
+	return logr.New(logf.NullLogSink{})
+}
+
+func TestReconcileNoRegistryAuth(t *testing.T) {
+	tt := newReconcilerTest(t, "user", "pass")
+	tt.cluster.Spec.RegistryMirrorConfiguration.Authenticate = false
+
+	result, err := tt.reconciler.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+	tt.Expect(tt.cluster.Annotations).NotTo(HaveKey(reconciler.CredentialsHashAnnotation))
+	tt.Expect(conditions.Get(tt.cluster, anywherev1.RegistryMirrorCredentialsRotatedCondition)).To(BeNil())
+}
+
+func TestReconcileMissingCredentialsSecret(t *testing.T) {
+	tt := newReconcilerTest(t, "user", "pass")
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "registry-credentials",
+			Namespace: constants.EksaSystemNamespace,
+		},
+	}
+	tt.Expect(tt.client.Delete(tt.ctx, secret)).To(Succeed())
+
+	_, err := tt.reconciler.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).To(MatchError(ContainSubstring("fetching registry auth secret")))
+}
+
+func TestReconcileFirstTimeTracksCredentials(t *testing.T) {
+	tt := newReconcilerTest(t, "user", "pass")
+
+	result, err := tt.reconciler.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+	tt.Expect(tt.cluster.Annotations).To(HaveKey(reconciler.CredentialsHashAnnotation))
+	tt.Expect(conditions.IsTrue(tt.cluster, anywherev1.RegistryMirrorCredentialsRotatedCondition)).To(BeTrue())
+
+	// Reconciling again with the same credentials is a no-op
+	hash := tt.cluster.Annotations[reconciler.CredentialsHashAnnotation]
+	result, err = tt.reconciler.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+	tt.Expect(tt.cluster.Annotations[reconciler.CredentialsHashAnnotation]).To(Equal(hash))
+	tt.Expect(conditions.IsTrue(tt.cluster, anywherev1.RegistryMirrorCredentialsRotatedCondition)).To(BeTrue())
+}
+
+func TestReconcileRotateCredentials(t *testing.T) {
+	tt := newReconcilerTest(t, "user", "pass",
+		node("worker-node", "Bottlerocket OS 1.15.1 (vmware-k8s-1.27)"),
+		controlPlaneNode("cp-node", "Ubuntu 20.04.6 LTS"),
+	)
+	_, err := tt.reconciler.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	oldHash := tt.cluster.Annotations[reconciler.CredentialsHashAnnotation]
+
+	tt.updateCredentials("user", "new-pass")
+
+	tt.reconcileInProgress()
+	tt.Expect(tt.cluster.Annotations[reconciler.CredentialsHashAnnotation]).To(Equal(oldHash))
+
+	authSecret := &corev1.Secret{}
+	tt.Expect(tt.client.Get(tt.ctx, client.ObjectKey{Name: registrymirror.AuthSecretName(tt.cluster.Name), Namespace: constants.EksaSystemNamespace}, authSecret)).To(Succeed())
+	tt.Expect(string(authSecret.Data[nodeupgrader.ContainerdAuthConfigKey])).To(ContainSubstring(`password = "new-pass"`))
+
+	remoteSecret := &corev1.Secret{}
+	tt.Expect(tt.remoteClient.Get(tt.ctx, client.ObjectKey{Name: reconciler.CredentialsSecretName, Namespace: constants.EksaSystemNamespace}, remoteSecret)).To(Succeed())
+	tt.Expect(string(remoteSecret.Data[nodeupgrader.ContainerdAuthConfigKey])).To(ContainSubstring(`password = "new-pass"`))
+	tt.Expect(string(remoteSecret.Data[nodeupgrader.BottlerocketSettingsKey])).To(ContainSubstring(`"password":"new-pass"`))
+
+	// Control plane nodes are updated first and nodes are updated one at a time
+	cpPod := tt.expectPod("cp-node")
+	tt.Expect(cpPod.Spec.InitContainers[1].Args).To(ContainElement("sh"))
+	tt.expectNoPod("worker-node")
+
+	// The next node waits until the updated one is Ready again
+	tt.setPodPhase("cp-node", corev1.PodSucceeded)
+	tt.setNodeReady("cp-node", corev1.ConditionFalse)
+	tt.reconcileInProgress()
+	tt.expectNoPod("cp-node")
+	tt.expectNoPod("worker-node")
+
+	tt.reconcileInProgress()
+	tt.expectNoPod("worker-node")
+
+	tt.setNodeReady("cp-node", corev1.ConditionTrue)
+	tt.reconcileInProgress()
+	workerPod := tt.expectPod("worker-node")
+	tt.Expect(workerPod.Spec.InitContainers[1].Args).To(ContainElement("apiclient"))
+
+	tt.setPodPhase("worker-node", corev1.PodSucceeded)
+
+	tt.expectRemoteClient()
+	result, err := tt.reconciler.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+	tt.Expect(tt.cluster.Annotations[reconciler.CredentialsHashAnnotation]).NotTo(Equal(oldHash))
+	tt.Expect(conditions.IsTrue(tt.cluster, anywherev1.RegistryMirrorCredentialsRotatedCondition)).To(BeTrue())
+
+	pods := &corev1.PodList{}
+	tt.Expect(tt.remoteClient.List(tt.ctx, pods)).To(Succeed())
+	tt.Expect(pods.Items).To(BeEmpty())
+
+	nodes := &corev1.NodeList{}
+	tt.Expect(tt.remoteClient.List(tt.ctx, nodes)).To(Succeed())
+	for _, n := range nodes.Items {
+		tt.Expect(n.Annotations[reconciler.CredentialsHashAnnotation]).To(Equal(tt.cluster.Annotations[reconciler.CredentialsHashAnnotation]))
+	}
+}
+
+func TestReconcileRotateCredentialsPodFailed(t *testing.T) {
+	tt := newReconcilerTest(t, "user", "pass", node("cp-node", "Ubuntu 20.04.6 LTS"))
+	_, err := tt.reconciler.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+
+	tt.updateCredentials("user", "new-pass")
+
+	tt.expectRemoteClient()
+	_, err = tt.reconciler.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+
+	tt.setPodPhase("cp-node", corev1.PodFailed)
+
+	tt.expectRemoteClient()
+	result, err := tt.reconciler.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result.Return()).To(BeTrue())
+	tt.expectCondition(corev1.ConditionFalse, anywherev1.RegistryMirrorCredentialsRotationFailedReason)
+	tt.Expect(conditions.GetMessage(tt.cluster, anywherev1.RegistryMirrorCredentialsRotatedCondition)).To(ContainSubstring("cp-node"))
+
+	// The failed pod is removed so it gets retried in the next reconciliation
+	pods := &corev1.PodList{}
+	tt.Expect(tt.remoteClient.List(tt.ctx, pods)).To(Succeed())
+	tt.Expect(pods.Items).To(BeEmpty())
+}
+
+func TestReconcileRotateCredentialsRemoteClientError(t *testing.T) {
+	tt := newReconcilerTest(t, "user", "pass")
+	_, err := tt.reconciler.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+
+	tt.updateCredentials("new-user", "pass")
+
+	tt.remoteClientRegistry.EXPECT().GetClient(tt.ctx, gomock.AssignableToTypeOf(client.ObjectKey{})).Return(nil, errors.New("client error"))
+	_, err = tt.reconciler.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).To(MatchError(ContainSubstring("client error")))
+}
@@ -100,3 +100,9 @@ func (r *RegistryMirror) ReplaceRegistry(url string) string {
 	}
 	return url
 }
+
+// AuthSecretName returns the name of the secret holding the containerd registry mirror
+// auth configuration used to bootstrap the nodes of a cluster.
+func AuthSecretName(clusterName string) string {
+	return clusterName + "-registry-mirror-auth"
+}
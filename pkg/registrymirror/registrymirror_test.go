@@ -385,3 +385,8 @@ func TestReplaceRegistry(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthSecretName(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(registrymirror.AuthSecretName("my-cluster")).To(Equal("my-cluster-registry-mirror-auth"))
+}
@@ -0,0 +1,409 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/controller/reconcileutil"
+)
+
+const (
+	// DefaultHealthCheckTimeout is the time the canary health check has to pass when the upgrade
+	// policy doesn't configure one.
+	DefaultHealthCheckTimeout = 10 * time.Minute
+
+	hashLabel        = "anywhere.eks.amazonaws.com/upgrade-canary-hash"
+	requeueAfter     = 10 * time.Second
+	httpCheckTimeout = 10 * time.Second
+)
+
+// RemoteClientRegistry defines methods for remote cluster controller clients.
+type RemoteClientRegistry interface {
+	GetClient(ctx context.Context, cluster client.ObjectKey) (client.Client, error)
+}
+
+// Reconciler runs the health check of the canary node of a worker nodes upgrade and records
+// the result in the cluster status, so the rest of the worker nodes can be upgraded.
+type Reconciler struct {
+	client               client.Client
+	remoteClientRegistry RemoteClientRegistry
+	httpClient           *http.Client
+}
+
+// New returns a new Reconciler.
+func New(client client.Client, remoteClientRegistry RemoteClientRegistry) *Reconciler {
+	return &Reconciler{
+		client:               client,
+		remoteClientRegistry: remoteClientRegistry,
+		httpClient:           &http.Client{Timeout: httpCheckTimeout},
+	}
+}
+
+// Reconcile follows the canary node picked by the worker nodes reconciliation when the cluster has an
+// upgrade policy with a canary. Once the canary node has been upgraded, it runs the health check against it:
+// a Job scheduled on the node in the workload cluster or an HTTP probe. If the health check fails or doesn't
+// pass before the timeout, the canary is marked as failed and the upgrade stays paused until the
+// retry annotation is added to the cluster.
+// It uses a controller.Result to indicate when requeues are needed.
+func (r *Reconciler) Reconcile(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) (controller.Result, error) {
+	status := cluster.Status.UpgradeCanary
+	policy := cluster.Spec.UpgradePolicy
+	if status == nil || policy == nil || policy.Canary == nil {
+		return controller.Result{}, nil
+	}
+	log = log.WithValues("machineDeployment", status.MachineDeployment)
+
+	if _, ok := cluster.Annotations[anywherev1.RetryUpgradeCanaryAnnotation]; ok {
+		if status.Phase == anywherev1.UpgradeCanaryFailed {
+			log.Info("Retrying upgrade canary health check", "node", status.NodeName)
+			if policy.Canary.HealthCheck.Job != nil {
+				rClient, err := r.remoteClient(ctx, cluster)
+				if err != nil {
+					return controller.Result{}, err
+				}
+				if err := deleteJob(ctx, rClient, jobName(cluster)); err != nil {
+					return controller.Result{}, err
+				}
+			}
+			startHealthCheck(status, status.NodeName)
+		}
+		delete(cluster.Annotations, anywherev1.RetryUpgradeCanaryAnnotation)
+	}
+
+	switch status.Phase {
+	case anywherev1.UpgradeCanaryUpgrading:
+		return r.reconcileUpgrading(ctx, log, status)
+	case anywherev1.UpgradeCanaryHealthChecking:
+		return r.reconcileHealthCheck(ctx, log, cluster, status)
+	case anywherev1.UpgradeCanaryPassed:
+		return controller.Result{}, r.releaseMachineDeploymentUpgrade(ctx, status)
+	}
+
+	return controller.Result{}, nil
+}
+
+// reconcileUpgrading waits for the canary node to be upgraded and starts its health check.
+func (r *Reconciler) reconcileUpgrading(ctx context.Context, log logr.Logger, status *anywherev1.UpgradeCanaryStatus) (controller.Result, error) {
+	md := &clusterv1.MachineDeployment{}
+	err := r.client.Get(ctx, client.ObjectKey{Namespace: constants.EksaSystemNamespace, Name: status.MachineDeployment}, md)
+	if apierrors.IsNotFound(err) {
+		return controller.ResultWithRequeue(requeueAfter), nil
+	}
+	if err != nil {
+		return controller.Result{}, errors.Wrapf(err, "getting canary machine deployment %s", status.MachineDeployment)
+	}
+
+	var nodeName string
+	if md.Spec.Strategy != nil && md.Spec.Strategy.Type == clusterv1.InPlaceMachineDeploymentStrategyType {
+		nodeName, err = r.inPlaceUpgradedNode(ctx, md)
+	} else {
+		nodeName, err = r.rolledOutNode(ctx, md)
+	}
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	if nodeName == "" {
+		log.Info("Waiting for canary node to be upgraded")
+		return controller.ResultWithRequeue(requeueAfter), nil
+	}
+
+	log.Info("Canary node upgraded, starting health check", "node", nodeName)
+	startHealthCheck(status, nodeName)
+
+	return controller.ResultWithRequeue(requeueAfter), nil
+}
+
+// inPlaceUpgradedNode returns the name of the first node of the machine deployment upgraded in place.
+func (r *Reconciler) inPlaceUpgradedNode(ctx context.Context, md *clusterv1.MachineDeployment) (string, error) {
+	machines, err := r.machines(ctx, client.MatchingLabels{clusterv1.MachineDeploymentNameLabel: md.Name})
+	if err != nil {
+		return "", err
+	}
+
+	nodeUpgrades := &anywherev1.NodeUpgradeList{}
+	if err := r.client.List(ctx, nodeUpgrades, client.InNamespace(constants.EksaSystemNamespace)); err != nil {
+		return "", errors.Wrap(err, "listing node upgrades")
+	}
+
+	for _, u := range nodeUpgrades.Items {
+		m, ok := machines[u.Spec.Machine.Name]
+		if ok && u.Status.Completed && m.Status.NodeRef != nil {
+			return m.Status.NodeRef.Name, nil
+		}
+	}
+
+	return "", nil
+}
+
+// rolledOutNode returns the name of the first healthy node created by the machine set with the
+// current spec of the machine deployment.
+func (r *Reconciler) rolledOutNode(ctx context.Context, md *clusterv1.MachineDeployment) (string, error) {
+	machineSets := &clusterv1.MachineSetList{}
+	if err := r.client.List(ctx, machineSets,
+		client.InNamespace(md.Namespace),
+		client.MatchingLabels{clusterv1.MachineDeploymentNameLabel: md.Name},
+	); err != nil {
+		return "", errors.Wrapf(err, "listing machine sets for machine deployment %s", md.Name)
+	}
+
+	for _, ms := range machineSets.Items {
+		if !sameMachineSpec(ms.Spec.Template.Spec, md.Spec.Template.Spec) {
+			continue
+		}
+
+		machines, err := r.machines(ctx, client.MatchingLabels{clusterv1.MachineSetNameLabel: ms.Name})
+		if err != nil {
+			return "", err
+		}
+		for _, m := range machines {
+			if m.Status.NodeRef != nil && conditions.IsTrue(m, clusterv1.MachineNodeHealthyCondition) {
+				return m.Status.NodeRef.Name, nil
+			}
+		}
+	}
+
+	return "", nil
+}
+
+func (r *Reconciler) machines(ctx context.Context, selector client.MatchingLabels) (map[string]*clusterv1.Machine, error) {
+	machineList := &clusterv1.MachineList{}
+	if err := r.client.List(ctx, machineList, client.InNamespace(constants.EksaSystemNamespace), selector); err != nil {
+		return nil, errors.Wrap(err, "listing machines")
+	}
+
+	machines := make(map[string]*clusterv1.Machine, len(machineList.Items))
+	for i := range machineList.Items {
+		machines[machineList.Items[i].Name] = &machineList.Items[i]
+	}
+
+	return machines, nil
+}
+
+func (r *Reconciler) reconcileHealthCheck(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster, status *anywherev1.UpgradeCanaryStatus) (controller.Result, error) {
+	healthCheck := cluster.Spec.UpgradePolicy.Canary.HealthCheck
+
+	var passed bool
+	var failure string
+	var err error
+	if healthCheck.Job != nil {
+		passed, failure, err = r.runJob(ctx, cluster, status, healthCheck.Job)
+	} else if healthCheck.HTTP != nil {
+		passed, failure = r.probe(ctx, healthCheck.HTTP)
+	}
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	if passed {
+		log.Info("Canary node passed the health check", "node", status.NodeName)
+		status.Phase = anywherev1.UpgradeCanaryPassed
+		status.Message = ""
+		return controller.Result{}, r.releaseMachineDeploymentUpgrade(ctx, status)
+	}
+
+	// Jobs don't retry, so a failed job is final. HTTP probes retry until the timeout.
+	if failure != "" && healthCheck.Job != nil {
+		log.Info("Canary node failed the health check", "node", status.NodeName, "reason", failure)
+		status.Phase = anywherev1.UpgradeCanaryFailed
+		status.Message = failure
+		return controller.Result{}, nil
+	}
+
+	timeout := DefaultHealthCheckTimeout
+	if healthCheck.Timeout != nil {
+		timeout = healthCheck.Timeout.Duration
+	}
+	if status.HealthCheckStartTime != nil && time.Since(status.HealthCheckStartTime.Time) > timeout {
+		log.Info("Canary node health check timed out", "node", status.NodeName)
+		status.Phase = anywherev1.UpgradeCanaryFailed
+		status.Message = fmt.Sprintf("health check didn't pass after %s", timeout)
+		if failure != "" {
+			status.Message = fmt.Sprintf("%s: %s", status.Message, failure)
+		}
+		return controller.Result{}, nil
+	}
+
+	return controller.ResultWithRequeue(requeueAfter), nil
+}
+
+// runJob creates the health check job in the workload cluster, if it doesn't exist yet, and returns
+// if it has succeeded or the reason it failed.
+func (r *Reconciler) runJob(ctx context.Context, cluster *anywherev1.Cluster, status *anywherev1.UpgradeCanaryStatus, check *anywherev1.UpgradeCanaryJobCheck) (passed bool, failure string, err error) {
+	rClient, err := r.remoteClient(ctx, cluster)
+	if err != nil {
+		return false, "", err
+	}
+
+	job := &batchv1.Job{}
+	err = rClient.Get(ctx, client.ObjectKey{Namespace: constants.EksaSystemNamespace, Name: jobName(cluster)}, job)
+	if err == nil && !job.DeletionTimestamp.IsZero() {
+		// Still being deleted after a retry
+		return false, "", nil
+	}
+	if err == nil && job.Labels[hashLabel] != status.Hash {
+		// Left over from a previous upgrade
+		if err := deleteJob(ctx, rClient, job.Name); err != nil {
+			return false, "", err
+		}
+		return false, "", nil
+	}
+	if apierrors.IsNotFound(err) {
+		if err := reconcileutil.EnsureNamespace(ctx, rClient, constants.EksaSystemNamespace); err != nil {
+			return false, "", err
+		}
+		if err := rClient.Create(ctx, healthCheckJob(cluster, status, check)); err != nil {
+			return false, "", errors.Wrap(err, "creating upgrade canary health check job")
+		}
+		return false, "", nil
+	}
+	if err != nil {
+		return false, "", errors.Wrap(err, "getting upgrade canary health check job")
+	}
+
+	if job.Status.Succeeded > 0 {
+		return true, "", deleteJob(ctx, rClient, job.Name)
+	}
+	if job.Status.Failed > 0 {
+		return false, fmt.Sprintf("health check job %s/%s failed", job.Namespace, job.Name), nil
+	}
+
+	return false, "", nil
+}
+
+// probe sends a GET request to the health check URL and returns if it responded with a success status code.
+func (r *Reconciler) probe(ctx context.Context, check *anywherev1.UpgradeCanaryHTTPCheck) (passed bool, failure string) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, check.URL, nil)
+	if err != nil {
+		return false, err.Error()
+	}
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return false, err.Error()
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return false, fmt.Sprintf("health check %s returned status %d", check.URL, resp.StatusCode)
+	}
+
+	return true, ""
+}
+
+// releaseMachineDeploymentUpgrade removes the canary annotation from the in place upgrade of the canary
+// machine deployment, so the rest of its nodes are upgraded.
+func (r *Reconciler) releaseMachineDeploymentUpgrade(ctx context.Context, status *anywherev1.UpgradeCanaryStatus) error {
+	mdUpgrades := &anywherev1.MachineDeploymentUpgradeList{}
+	if err := r.client.List(ctx, mdUpgrades, client.InNamespace(constants.EksaSystemNamespace)); err != nil {
+		return errors.Wrap(err, "listing machine deployment upgrades")
+	}
+
+	for i := range mdUpgrades.Items {
+		u := &mdUpgrades.Items[i]
+		if u.Spec.MachineDeployment.Name != status.MachineDeployment {
+			continue
+		}
+		if _, ok := u.Annotations[anywherev1.UpgradeCanaryAnnotation]; !ok {
+			continue
+		}
+
+		patch := client.MergeFrom(u.DeepCopy())
+		delete(u.Annotations, anywherev1.UpgradeCanaryAnnotation)
+		if err := r.client.Patch(ctx, u, patch); err != nil {
+			return errors.Wrapf(err, "removing canary annotation from machine deployment upgrade %s", u.Name)
+		}
+	}
+
+	return nil
+}
+
+func (r *Reconciler) remoteClient(ctx context.Context, cluster *anywherev1.Cluster) (client.Client, error) {
+	rClient, err := r.remoteClientRegistry.GetClient(ctx, controller.CapiClusterObjectKey(cluster))
+	if err != nil {
+		return nil, errors.Wrap(err, "getting workload cluster's client to run upgrade canary health check")
+	}
+
+	return rClient, nil
+}
+
+func startHealthCheck(status *anywherev1.UpgradeCanaryStatus, nodeName string) {
+	now := metav1.Now()
+	status.Phase = anywherev1.UpgradeCanaryHealthChecking
+	status.NodeName = nodeName
+	status.HealthCheckStartTime = &now
+	status.Message = ""
+}
+
+func sameMachineSpec(a, b clusterv1.MachineSpec) bool {
+	if a.Version == nil || b.Version == nil || *a.Version != *b.Version {
+		return false
+	}
+	if a.Bootstrap.ConfigRef == nil || b.Bootstrap.ConfigRef == nil || a.Bootstrap.ConfigRef.Name != b.Bootstrap.ConfigRef.Name {
+		return false
+	}
+
+	return a.InfrastructureRef.Kind == b.InfrastructureRef.Kind && a.InfrastructureRef.Name == b.InfrastructureRef.Name
+}
+
+func jobName(cluster *anywherev1.Cluster) string {
+	return cluster.Name + "-upgrade-canary-check"
+}
+
+func healthCheckJob(cluster *anywherev1.Cluster, status *anywherev1.UpgradeCanaryStatus, check *anywherev1.UpgradeCanaryJobCheck) *batchv1.Job {
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName(cluster),
+			Namespace: constants.EksaSystemNamespace,
+			Labels: map[string]string{
+				hashLabel: status.Hash,
+			},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: pointer.Int32(0),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					NodeName:      status.NodeName,
+					RestartPolicy: corev1.RestartPolicyNever,
+					Tolerations: []corev1.Toleration{
+						{Operator: corev1.TolerationOpExists},
+					},
+					Containers: []corev1.Container{
+						{
+							Name:    "health-check",
+							Image:   check.Image,
+							Command: check.Command,
+							Args:    check.Args,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func deleteJob(ctx context.Context, c client.Client, name string) error {
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+		},
+	}
+	return reconcileutil.DeleteIgnoreNotFound(ctx, c, job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+}
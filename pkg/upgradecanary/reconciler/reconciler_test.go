@@ -0,0 +1,431 @@
+package reconciler_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/upgradecanary/reconciler"
+	"github.com/aws/eks-anywhere/pkg/upgradecanary/reconciler/mocks"
+)
+
+const (
+	mdName   = "my-cluster-md-0"
+	jobName  = "my-cluster-upgrade-canary-check"
+	nodeName = "node-1"
+)
+
+type reconcilerTest struct {
+	*WithT
+	ctx                  context.Context
+	cluster              *anywherev1.Cluster
+	remoteClientRegistry *mocks.MockRemoteClientRegistry
+	scheme               *runtime.Scheme
+}
+
+func newReconcilerTest(t *testing.T) *reconcilerTest {
+	ctrl := gomock.NewController(t)
+	scheme := runtime.NewScheme()
+	_ = clusterv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+	_ = batchv1.AddToScheme(scheme)
+	_ = anywherev1.AddToScheme(scheme)
+
+	return &reconcilerTest{
+		WithT:                NewWithT(t),
+		ctx:                  context.Background(),
+		remoteClientRegistry: mocks.NewMockRemoteClientRegistry(ctrl),
+		scheme:               scheme,
+		cluster: &anywherev1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-cluster",
+				Namespace: constants.EksaSystemNamespace,
+			},
+			Spec: anywherev1.ClusterSpec{
+				UpgradePolicy: &anywherev1.UpgradePolicy{
+					Canary: &anywherev1.UpgradeCanary{
+						HealthCheck: anywherev1.UpgradeCanaryHealthCheck{
+							Job: &anywherev1.UpgradeCanaryJobCheck{
+								Image:   "public.ecr.aws/my/check:v1",
+								Command: []string{"/check"},
+							},
+						},
+					},
+				},
+			},
+			Status: anywherev1.ClusterStatus{
+				UpgradeCanary: &anywherev1.UpgradeCanaryStatus{
+					MachineDeployment: mdName,
+					Hash:              "abc",
+					Phase:             anywherev1.UpgradeCanaryUpgrading,
+				},
+			},
+		},
+	}
+}
+
+func (tt *reconcilerTest) client(objs ...runtime.Object) client.Client {
+	return fake.NewClientBuilder().WithScheme(tt.scheme).WithRuntimeObjects(objs...).Build()
+}
+
+func (tt *reconcilerTest) expectRemoteClient(c client.Client) *gomock.Call {
+	return tt.remoteClientRegistry.EXPECT().GetClient(tt.ctx, client.ObjectKey{Name: "my-cluster", Namespace: constants.EksaSystemNamespace}).Return(c, nil)
+}
+
+func (tt *reconcilerTest) healthChecking() {
+	start := metav1.Now()
+	tt.cluster.Status.UpgradeCanary.Phase = anywherev1.UpgradeCanaryHealthChecking
+	tt.cluster.Status.UpgradeCanary.NodeName = nodeName
+	tt.cluster.Status.UpgradeCanary.HealthCheckStartTime = &start
+}
+
+func machineDeployment(strategy clusterv1.MachineDeploymentStrategyType) *clusterv1.MachineDeployment {
+	return &clusterv1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      mdName,
+			Namespace: constants.EksaSystemNamespace,
+		},
+		Spec: clusterv1.MachineDeploymentSpec{
+			Strategy: &clusterv1.MachineDeploymentStrategy{Type: strategy},
+			Template: clusterv1.MachineTemplateSpec{
+				Spec: machineSpec("md-0-2"),
+			},
+		},
+	}
+}
+
+func machineSpec(template string) clusterv1.MachineSpec {
+	return clusterv1.MachineSpec{
+		Version: pointer.String("v1.28.1-eks-1-28-5"),
+		Bootstrap: clusterv1.Bootstrap{
+			ConfigRef: &corev1.ObjectReference{Kind: "KubeadmConfigTemplate", Name: template},
+		},
+		InfrastructureRef: corev1.ObjectReference{Kind: "DockerMachineTemplate", Name: template},
+	}
+}
+
+func machineSet(name, template string) *clusterv1.MachineSet {
+	return &clusterv1.MachineSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+			Labels:    map[string]string{clusterv1.MachineDeploymentNameLabel: mdName},
+		},
+		Spec: clusterv1.MachineSetSpec{
+			Template: clusterv1.MachineTemplateSpec{
+				Spec: machineSpec(template),
+			},
+		},
+	}
+}
+
+func machine(name, machineSet, node string, healthy bool) *clusterv1.Machine {
+	m := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+			Labels: map[string]string{
+				clusterv1.MachineDeploymentNameLabel: mdName,
+				clusterv1.MachineSetNameLabel:        machineSet,
+			},
+		},
+		Status: clusterv1.MachineStatus{
+			NodeRef: &corev1.ObjectReference{Name: node},
+		},
+	}
+	if healthy {
+		m.Status.Conditions = clusterv1.Conditions{
+			{Type: clusterv1.MachineNodeHealthyCondition, Status: corev1.ConditionTrue},
+		}
+	}
+	return m
+}
+
+func nodeUpgrade(machine string, completed bool) *anywherev1.NodeUpgrade {
+	return &anywherev1.NodeUpgrade{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      machine + "-node-upgrader",
+			Namespace: constants.EksaSystemNamespace,
+		},
+		Spec: anywherev1.NodeUpgradeSpec{
+			Machine: corev1.ObjectReference{Name: machine, Namespace: constants.EksaSystemNamespace},
+		},
+		Status: anywherev1.NodeUpgradeStatus{
+			Completed: completed,
+		},
+	}
+}
+
+func job(hash string, status batchv1.JobStatus) *batchv1.Job {
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName,
+			Namespace: constants.EksaSystemNamespace,
+			Labels:    map[string]string{"anywhere.eks.amazonaws.com/upgrade-canary-hash": hash},
+		},
+		Status: status,
+	}
+}
+
+func mdUpgrade() *anywherev1.MachineDeploymentUpgrade {
+	return &anywherev1.MachineDeploymentUpgrade{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        mdName + "-md-upgrade",
+			Namespace:   constants.EksaSystemNamespace,
+			Annotations: map[string]string{anywherev1.UpgradeCanaryAnnotation: "true"},
+		},
+		Spec: anywherev1.MachineDeploymentUpgradeSpec{
+			MachineDeployment: corev1.ObjectReference{Name: mdName, Namespace: constants.EksaSystemNamespace},
+		},
+	}
+}
+
+func TestReconcileNoCanary(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.cluster.Spec.UpgradePolicy.Canary = nil
+	r := reconciler.New(tt.client(), tt.remoteClientRegistry)
+
+	tt.Expect(r.Reconcile(tt.ctx, logr.Discard(), tt.cluster)).To(Equal(controller.Result{}))
+	tt.Expect(tt.cluster.Status.UpgradeCanary.Phase).To(Equal(anywherev1.UpgradeCanaryUpgrading))
+}
+
+func TestReconcileRollingWaitingForNode(t *testing.T) {
+	tt := newReconcilerTest(t)
+	c := tt.client(
+		machineDeployment(clusterv1.RollingUpdateMachineDeploymentStrategyType),
+		machineSet("ms-old", "md-0-1"),
+		machineSet("ms-new", "md-0-2"),
+		machine("m-old", "ms-old", "node-old", true),
+		machine("m-new", "ms-new", nodeName, false),
+	)
+	r := reconciler.New(c, tt.remoteClientRegistry)
+
+	tt.Expect(r.Reconcile(tt.ctx, logr.Discard(), tt.cluster)).To(Equal(controller.ResultWithRequeue(10 * time.Second)))
+	tt.Expect(tt.cluster.Status.UpgradeCanary.Phase).To(Equal(anywherev1.UpgradeCanaryUpgrading))
+}
+
+func TestReconcileRollingNodeUpgraded(t *testing.T) {
+	tt := newReconcilerTest(t)
+	c := tt.client(
+		machineDeployment(clusterv1.RollingUpdateMachineDeploymentStrategyType),
+		machineSet("ms-old", "md-0-1"),
+		machineSet("ms-new", "md-0-2"),
+		machine("m-old", "ms-old", "node-old", true),
+		machine("m-new", "ms-new", nodeName, true),
+	)
+	r := reconciler.New(c, tt.remoteClientRegistry)
+
+	tt.Expect(r.Reconcile(tt.ctx, logr.Discard(), tt.cluster)).To(Equal(controller.ResultWithRequeue(10 * time.Second)))
+	tt.Expect(tt.cluster.Status.UpgradeCanary.Phase).To(Equal(anywherev1.UpgradeCanaryHealthChecking))
+	tt.Expect(tt.cluster.Status.UpgradeCanary.NodeName).To(Equal(nodeName))
+	tt.Expect(tt.cluster.Status.UpgradeCanary.HealthCheckStartTime).NotTo(BeNil())
+}
+
+func TestReconcileInPlaceNodeUpgraded(t *testing.T) {
+	tt := newReconcilerTest(t)
+	c := tt.client(
+		machineDeployment(clusterv1.InPlaceMachineDeploymentStrategyType),
+		machine("m-1", "ms", nodeName, true),
+		machine("m-2", "ms", "node-2", true),
+		nodeUpgrade("m-1", true),
+	)
+	r := reconciler.New(c, tt.remoteClientRegistry)
+
+	tt.Expect(r.Reconcile(tt.ctx, logr.Discard(), tt.cluster)).To(Equal(controller.ResultWithRequeue(10 * time.Second)))
+	tt.Expect(tt.cluster.Status.UpgradeCanary.Phase).To(Equal(anywherev1.UpgradeCanaryHealthChecking))
+	tt.Expect(tt.cluster.Status.UpgradeCanary.NodeName).To(Equal(nodeName))
+}
+
+func TestReconcileInPlaceNodeUpgrading(t *testing.T) {
+	tt := newReconcilerTest(t)
+	c := tt.client(
+		machineDeployment(clusterv1.InPlaceMachineDeploymentStrategyType),
+		machine("m-1", "ms", nodeName, true),
+		nodeUpgrade("m-1", false),
+	)
+	r := reconciler.New(c, tt.remoteClientRegistry)
+
+	tt.Expect(r.Reconcile(tt.ctx, logr.Discard(), tt.cluster)).To(Equal(controller.ResultWithRequeue(10 * time.Second)))
+	tt.Expect(tt.cluster.Status.UpgradeCanary.Phase).To(Equal(anywherev1.UpgradeCanaryUpgrading))
+}
+
+func TestReconcileJobCreated(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.healthChecking()
+	remoteClient := tt.client()
+	tt.expectRemoteClient(remoteClient)
+	r := reconciler.New(tt.client(), tt.remoteClientRegistry)
+
+	tt.Expect(r.Reconcile(tt.ctx, logr.Discard(), tt.cluster)).To(Equal(controller.ResultWithRequeue(10 * time.Second)))
+
+	j := &batchv1.Job{}
+	tt.Expect(remoteClient.Get(tt.ctx, client.ObjectKey{Namespace: constants.EksaSystemNamespace, Name: jobName}, j)).To(Succeed())
+	tt.Expect(j.Spec.Template.Spec.NodeName).To(Equal(nodeName))
+	tt.Expect(j.Spec.Template.Spec.RestartPolicy).To(Equal(corev1.RestartPolicyNever))
+	tt.Expect(j.Spec.BackoffLimit).To(Equal(pointer.Int32(0)))
+	tt.Expect(j.Spec.Template.Spec.Containers[0].Image).To(Equal("public.ecr.aws/my/check:v1"))
+	tt.Expect(j.Spec.Template.Spec.Containers[0].Command).To(Equal([]string{"/check"}))
+	tt.Expect(tt.cluster.Status.UpgradeCanary.Phase).To(Equal(anywherev1.UpgradeCanaryHealthChecking))
+}
+
+func TestReconcileJobSucceeded(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.healthChecking()
+	remoteClient := tt.client(job("abc", batchv1.JobStatus{Succeeded: 1}))
+	tt.expectRemoteClient(remoteClient)
+	c := tt.client(mdUpgrade())
+	r := reconciler.New(c, tt.remoteClientRegistry)
+
+	tt.Expect(r.Reconcile(tt.ctx, logr.Discard(), tt.cluster)).To(Equal(controller.Result{}))
+	tt.Expect(tt.cluster.Status.UpgradeCanary.Phase).To(Equal(anywherev1.UpgradeCanaryPassed))
+
+	err := remoteClient.Get(tt.ctx, client.ObjectKey{Namespace: constants.EksaSystemNamespace, Name: jobName}, &batchv1.Job{})
+	tt.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+	u := &anywherev1.MachineDeploymentUpgrade{}
+	tt.Expect(c.Get(tt.ctx, client.ObjectKey{Namespace: constants.EksaSystemNamespace, Name: mdName + "-md-upgrade"}, u)).To(Succeed())
+	tt.Expect(u.Annotations).NotTo(HaveKey(anywherev1.UpgradeCanaryAnnotation))
+}
+
+func TestReconcileJobFailed(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.healthChecking()
+	remoteClient := tt.client(job("abc", batchv1.JobStatus{Failed: 1}))
+	tt.expectRemoteClient(remoteClient)
+	r := reconciler.New(tt.client(), tt.remoteClientRegistry)
+
+	tt.Expect(r.Reconcile(tt.ctx, logr.Discard(), tt.cluster)).To(Equal(controller.Result{}))
+	tt.Expect(tt.cluster.Status.UpgradeCanary.Phase).To(Equal(anywherev1.UpgradeCanaryFailed))
+	tt.Expect(tt.cluster.Status.UpgradeCanary.Message).To(ContainSubstring("failed"))
+
+	// The failed job is kept for troubleshooting
+	tt.Expect(remoteClient.Get(tt.ctx, client.ObjectKey{Namespace: constants.EksaSystemNamespace, Name: jobName}, &batchv1.Job{})).To(Succeed())
+}
+
+func TestReconcileJobFromPreviousUpgrade(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.healthChecking()
+	remoteClient := tt.client(job("old", batchv1.JobStatus{Failed: 1}))
+	tt.expectRemoteClient(remoteClient)
+	r := reconciler.New(tt.client(), tt.remoteClientRegistry)
+
+	tt.Expect(r.Reconcile(tt.ctx, logr.Discard(), tt.cluster)).To(Equal(controller.ResultWithRequeue(10 * time.Second)))
+	tt.Expect(tt.cluster.Status.UpgradeCanary.Phase).To(Equal(anywherev1.UpgradeCanaryHealthChecking))
+
+	err := remoteClient.Get(tt.ctx, client.ObjectKey{Namespace: constants.EksaSystemNamespace, Name: jobName}, &batchv1.Job{})
+	tt.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+}
+
+func TestReconcileJobTimeout(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.healthChecking()
+	tt.cluster.Spec.UpgradePolicy.Canary.HealthCheck.Timeout = &metav1.Duration{Duration: time.Minute}
+	start := metav1.NewTime(time.Now().Add(-2 * time.Minute))
+	tt.cluster.Status.UpgradeCanary.HealthCheckStartTime = &start
+	remoteClient := tt.client(job("abc", batchv1.JobStatus{Active: 1}))
+	tt.expectRemoteClient(remoteClient)
+	r := reconciler.New(tt.client(), tt.remoteClientRegistry)
+
+	tt.Expect(r.Reconcile(tt.ctx, logr.Discard(), tt.cluster)).To(Equal(controller.Result{}))
+	tt.Expect(tt.cluster.Status.UpgradeCanary.Phase).To(Equal(anywherev1.UpgradeCanaryFailed))
+	tt.Expect(tt.cluster.Status.UpgradeCanary.Message).To(Equal("health check didn't pass after 1m0s"))
+}
+
+func TestReconcileRemoteClientError(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.healthChecking()
+	tt.remoteClientRegistry.EXPECT().GetClient(tt.ctx, gomock.Any()).Return(nil, errors.New("connection refused"))
+	r := reconciler.New(tt.client(), tt.remoteClientRegistry)
+
+	_, err := r.Reconcile(tt.ctx, logr.Discard(), tt.cluster)
+	tt.Expect(err).To(MatchError(ContainSubstring("connection refused")))
+}
+
+func TestReconcileHTTPPassed(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.healthChecking()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	tt.cluster.Spec.UpgradePolicy.Canary.HealthCheck = anywherev1.UpgradeCanaryHealthCheck{
+		HTTP: &anywherev1.UpgradeCanaryHTTPCheck{URL: server.URL},
+	}
+	r := reconciler.New(tt.client(), tt.remoteClientRegistry)
+
+	tt.Expect(r.Reconcile(tt.ctx, logr.Discard(), tt.cluster)).To(Equal(controller.Result{}))
+	tt.Expect(tt.cluster.Status.UpgradeCanary.Phase).To(Equal(anywherev1.UpgradeCanaryPassed))
+}
+
+func TestReconcileHTTPRetriesUntilTimeout(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.healthChecking()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	tt.cluster.Spec.UpgradePolicy.Canary.HealthCheck = anywherev1.UpgradeCanaryHealthCheck{
+		HTTP: &anywherev1.UpgradeCanaryHTTPCheck{URL: server.URL},
+	}
+	r := reconciler.New(tt.client(), tt.remoteClientRegistry)
+
+	tt.Expect(r.Reconcile(tt.ctx, logr.Discard(), tt.cluster)).To(Equal(controller.ResultWithRequeue(10 * time.Second)))
+	tt.Expect(tt.cluster.Status.UpgradeCanary.Phase).To(Equal(anywherev1.UpgradeCanaryHealthChecking))
+
+	start := metav1.NewTime(time.Now().Add(-11 * time.Minute))
+	tt.cluster.Status.UpgradeCanary.HealthCheckStartTime = &start
+	tt.Expect(r.Reconcile(tt.ctx, logr.Discard(), tt.cluster)).To(Equal(controller.Result{}))
+	tt.Expect(tt.cluster.Status.UpgradeCanary.Phase).To(Equal(anywherev1.UpgradeCanaryFailed))
+	tt.Expect(tt.cluster.Status.UpgradeCanary.Message).To(ContainSubstring("returned status 503"))
+}
+
+func TestReconcileRetry(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.healthChecking()
+	tt.cluster.Status.UpgradeCanary.Phase = anywherev1.UpgradeCanaryFailed
+	tt.cluster.Status.UpgradeCanary.Message = "health check job failed"
+	tt.cluster.Annotations = map[string]string{anywherev1.RetryUpgradeCanaryAnnotation: "true"}
+	remoteClient := tt.client(job("abc", batchv1.JobStatus{Failed: 1}))
+	tt.expectRemoteClient(remoteClient).Times(2)
+	r := reconciler.New(tt.client(), tt.remoteClientRegistry)
+
+	tt.Expect(r.Reconcile(tt.ctx, logr.Discard(), tt.cluster)).To(Equal(controller.ResultWithRequeue(10 * time.Second)))
+	tt.Expect(tt.cluster.Annotations).NotTo(HaveKey(anywherev1.RetryUpgradeCanaryAnnotation))
+	tt.Expect(tt.cluster.Status.UpgradeCanary.Phase).To(Equal(anywherev1.UpgradeCanaryHealthChecking))
+	tt.Expect(tt.cluster.Status.UpgradeCanary.NodeName).To(Equal(nodeName))
+	tt.Expect(tt.cluster.Status.UpgradeCanary.Message).To(BeEmpty())
+
+	// The failed job was replaced by a new one
+	j := &batchv1.Job{}
+	tt.Expect(remoteClient.Get(tt.ctx, client.ObjectKey{Namespace: constants.EksaSystemNamespace, Name: jobName}, j)).To(Succeed())
+	tt.Expect(j.Status.Failed).To(BeZero())
+}
+
+func TestReconcileFailedWaitsForRetry(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.healthChecking()
+	tt.cluster.Status.UpgradeCanary.Phase = anywherev1.UpgradeCanaryFailed
+	r := reconciler.New(tt.client(), tt.remoteClientRegistry)
+
+	tt.Expect(r.Reconcile(tt.ctx, logr.Discard(), tt.cluster)).To(Equal(controller.Result{}))
+	tt.Expect(tt.cluster.Status.UpgradeCanary.Phase).To(Equal(anywherev1.UpgradeCanaryFailed))
+}
@@ -66,6 +66,10 @@ func ValidateImmutableFields(ctx context.Context, k validations.KubectlClient, c
 		return fmt.Errorf("spec.clusterNetwork.cniConfig.cilium.skipUpgrade cannot be toggled off")
 	}
 
+	if nSpec.ClusterNetwork.KubeProxyReplaced() != oSpec.ClusterNetwork.KubeProxyReplaced() {
+		return fmt.Errorf("spec.clusterNetwork.cniConfig.cilium.kubeProxyReplacement is immutable")
+	}
+
 	if !nSpec.ProxyConfiguration.Equal(oSpec.ProxyConfiguration) {
 		return fmt.Errorf("spec.proxyConfiguration is immutable")
 	}
@@ -173,6 +177,50 @@ func ValidateGitOpsImmutableFields(ctx context.Context, k validations.KubectlCli
 			}
 		}
 
+		if prevGitOps.Spec.Gitlab != nil {
+			if clusterSpec.FluxConfig.Spec.Gitlab == nil {
+				return errors.New("fluxConfig spec.gitlab is immutable")
+			}
+
+			if prevGitOps.Spec.Gitlab.Repository != clusterSpec.FluxConfig.Spec.Gitlab.Repository {
+				return errors.New("fluxConfig spec.gitlab.repository is immutable")
+			}
+
+			if prevGitOps.Spec.Gitlab.Owner != clusterSpec.FluxConfig.Spec.Gitlab.Owner {
+				return errors.New("fluxConfig spec.gitlab.owner is immutable")
+			}
+
+			if prevGitOps.Spec.Gitlab.Hostname != clusterSpec.FluxConfig.Spec.Gitlab.Hostname {
+				return errors.New("fluxConfig spec.gitlab.hostname is immutable")
+			}
+
+			if prevGitOps.Spec.Gitlab.Personal != clusterSpec.FluxConfig.Spec.Gitlab.Personal {
+				return errors.New("fluxConfig spec.gitlab.personal is immutable")
+			}
+		}
+
+		if prevGitOps.Spec.BitbucketServer != nil {
+			if clusterSpec.FluxConfig.Spec.BitbucketServer == nil {
+				return errors.New("fluxConfig spec.bitbucketServer is immutable")
+			}
+
+			if prevGitOps.Spec.BitbucketServer.Repository != clusterSpec.FluxConfig.Spec.BitbucketServer.Repository {
+				return errors.New("fluxConfig spec.bitbucketServer.repository is immutable")
+			}
+
+			if prevGitOps.Spec.BitbucketServer.Owner != clusterSpec.FluxConfig.Spec.BitbucketServer.Owner {
+				return errors.New("fluxConfig spec.bitbucketServer.owner is immutable")
+			}
+
+			if prevGitOps.Spec.BitbucketServer.Hostname != clusterSpec.FluxConfig.Spec.BitbucketServer.Hostname {
+				return errors.New("fluxConfig spec.bitbucketServer.hostname is immutable")
+			}
+
+			if prevGitOps.Spec.BitbucketServer.Personal != clusterSpec.FluxConfig.Spec.BitbucketServer.Personal {
+				return errors.New("fluxConfig spec.bitbucketServer.personal is immutable")
+			}
+		}
+
 		if prevGitOps.Spec.Branch != clusterSpec.FluxConfig.Spec.Branch {
 			return errors.New("fluxConfig spec.branch is immutable")
 		}
@@ -183,6 +183,56 @@ func TestValidateGitOpsImmutableFieldsFluxConfig(t *testing.T) {
 			},
 			wantErr: "fluxConfig spec.github.personal is immutable",
 		},
+		{
+			name: "gitlab hostname diff",
+			new: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{
+					Gitlab: &v1alpha1.GitlabProviderConfig{
+						Hostname: "gitlab.example.com",
+					},
+				},
+			},
+			old: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{
+					Gitlab: &v1alpha1.GitlabProviderConfig{
+						Hostname: "gitlab.com",
+					},
+				},
+			},
+			wantErr: "fluxConfig spec.gitlab.hostname is immutable",
+		},
+		{
+			name: "gitlab provider removed",
+			new: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{
+					Github: &v1alpha1.GithubProviderConfig{},
+				},
+			},
+			old: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{
+					Gitlab: &v1alpha1.GitlabProviderConfig{},
+				},
+			},
+			wantErr: "fluxConfig spec.gitlab is immutable",
+		},
+		{
+			name: "bitbucket server owner diff",
+			new: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{
+					BitbucketServer: &v1alpha1.BitbucketServerProviderConfig{
+						Owner: "a",
+					},
+				},
+			},
+			old: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{
+					BitbucketServer: &v1alpha1.BitbucketServerProviderConfig{
+						Owner: "b",
+					},
+				},
+			},
+			wantErr: "fluxConfig spec.bitbucketServer.owner is immutable",
+		},
 		{
 			name: "branch diff",
 			new: &v1alpha1.FluxConfig{
@@ -301,6 +351,22 @@ func TestValidateImmutableFields(t *testing.T) {
 			},
 			ExpectedError: "spec.clusterNetwork.cniConfig.cilium.skipUpgrade cannot be toggled off",
 		},
+		{
+			Name: "Toggle Spec.ClusterNetwork.CNIConfig.Cilium.KubeProxyReplacement on",
+			ConfigureCurrent: func(current *v1alpha1.Cluster) {
+				current.Spec.ClusterNetwork.CNIConfig = &v1alpha1.CNIConfig{
+					Cilium: &v1alpha1.CiliumConfig{},
+				}
+			},
+			ConfigureDesired: func(desired *v1alpha1.Cluster) {
+				desired.Spec.ClusterNetwork.CNIConfig = &v1alpha1.CNIConfig{
+					Cilium: &v1alpha1.CiliumConfig{
+						KubeProxyReplacement: true,
+					},
+				}
+			},
+			ExpectedError: "spec.clusterNetwork.cniConfig.cilium.kubeProxyReplacement is immutable",
+		},
 	}
 
 	clstr := &types.Cluster{}
@@ -437,6 +437,20 @@ func (mr *MockGitOpsManagerMockRecorder) PauseClusterResourcesReconcile(arg0, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PauseClusterResourcesReconcile", reflect.TypeOf((*MockGitOpsManager)(nil).PauseClusterResourcesReconcile), arg0, arg1, arg2, arg3)
 }
 
+// ProposeGitEksaSpec mocks base method.
+func (m *MockGitOpsManager) ProposeGitEksaSpec(arg0 context.Context, arg1 *cluster.Spec, arg2 providers.DatacenterConfig, arg3 []providers.MachineConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProposeGitEksaSpec", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProposeGitEksaSpec indicates an expected call of ProposeGitEksaSpec.
+func (mr *MockGitOpsManagerMockRecorder) ProposeGitEksaSpec(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProposeGitEksaSpec", reflect.TypeOf((*MockGitOpsManager)(nil).ProposeGitEksaSpec), arg0, arg1, arg2, arg3)
+}
+
 // ResumeClusterResourcesReconcile mocks base method.
 func (m *MockGitOpsManager) ResumeClusterResourcesReconcile(arg0 context.Context, arg1 *types.Cluster, arg2 *cluster.Spec, arg3 providers.Provider) error {
 	m.ctrl.T.Helper()
@@ -19,7 +19,7 @@ func (s *pauseGitOpsReconcile) Run(ctx context.Context, commandContext *task.Com
 		return &workflows.CollectMgmtClusterDiagnosticsTask{}
 	}
 
-	return &upgradeCoreComponents{}
+	return &proposeGitOpsChanges{}
 }
 
 func (s *pauseGitOpsReconcile) Name() string {
@@ -33,6 +33,42 @@ func (s *pauseGitOpsReconcile) Checkpoint() *task.CompletedTask {
 }
 
 func (s *pauseGitOpsReconcile) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
+	return &proposeGitOpsChanges{}, nil
+}
+
+// proposeGitOpsChanges opens a pull request with the new cluster config in the git repo and waits for it to be merged
+// before any change is made to the cluster, if GitOps changes go through pull requests. If the pull request is not merged,
+// the GitOps reconciliations are resumed since neither the cluster nor the git repo have changed.
+type proposeGitOpsChanges struct{}
+
+// Run proposeGitOpsChanges opens the GitOps pull request and waits for it to be merged.
+func (s *proposeGitOpsChanges) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	datacenterConfig := commandContext.Provider.DatacenterConfig(commandContext.ClusterSpec)
+	machineConfigs := commandContext.Provider.MachineConfigs(commandContext.ClusterSpec)
+	err := commandContext.GitOpsManager.ProposeGitEksaSpec(ctx, commandContext.ClusterSpec, datacenterConfig, machineConfigs)
+	if err != nil {
+		commandContext.SetError(err)
+		logger.Info("Resuming GitOps cluster resources kustomization")
+		if err := commandContext.GitOpsManager.ResumeClusterResourcesReconcile(ctx, commandContext.ManagementCluster, commandContext.ClusterSpec, commandContext.Provider); err != nil {
+			logger.Error(err, "Resuming GitOps cluster resources kustomization")
+		}
+		return &workflows.CollectMgmtClusterDiagnosticsTask{}
+	}
+
+	return &upgradeCoreComponents{}
+}
+
+func (s *proposeGitOpsChanges) Name() string {
+	return "propose-gitops-changes"
+}
+
+func (s *proposeGitOpsChanges) Checkpoint() *task.CompletedTask {
+	return &task.CompletedTask{
+		Checkpoint: nil,
+	}
+}
+
+func (s *proposeGitOpsChanges) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
 	return &upgradeCoreComponents{}, nil
 }
 
@@ -24,6 +24,11 @@ func (s *preClusterUpgrade) Run(ctx context.Context, commandContext *task.Comman
 		}
 	}
 
+	if err := workflows.WriteClusterSnapshot(ctx, commandContext); err != nil {
+		commandContext.SetError(err)
+		return &workflows.CollectMgmtClusterDiagnosticsTask{}
+	}
+
 	logger.V(3).Info("Pausing workload clusters before upgrading management cluster")
 	err = commandContext.ClusterManager.PauseCAPIWorkloadClusters(ctx, commandContext.ManagementCluster)
 	if err != nil {
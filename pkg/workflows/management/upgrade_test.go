@@ -11,7 +11,12 @@ import (
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	dockerv1 "sigs.k8s.io/cluster-api/test/infrastructure/docker/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/aws/eks-anywhere/internal/test"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
@@ -101,7 +106,8 @@ func newUpgradeManagementTest(t *testing.T) *upgradeManagementTestSetup {
 	newClusterSpec := currentClusterSpec.DeepCopy()
 	newClusterSpec.Cluster.Annotations = nil
 
-	client := test.NewFakeKubeClient(currentClusterSpec.Cluster, currentClusterSpec.EKSARelease, currentClusterSpec.Bundles)
+	client := test.NewFakeKubeClient(append([]client.Object{currentClusterSpec.Cluster, currentClusterSpec.EKSARelease, currentClusterSpec.Bundles},
+		capiObjects("management")...)...)
 
 	return &upgradeManagementTestSetup{
 		t:                           t,
@@ -133,6 +139,8 @@ func newUpgradeManagementTest(t *testing.T) *upgradeManagementTestSetup {
 func newUpgradeManagementClusterTest(t *testing.T) *upgradeManagementTestSetup {
 	tt := newUpgradeManagementTest(t)
 	tt.managementCluster = &types.Cluster{Name: "management", KubeconfigFile: "kubeconfig"}
+	// The cluster snapshot is written to the management cluster folder.
+	t.Cleanup(func() { os.RemoveAll(tt.managementCluster.Name) })
 	return tt
 }
 
@@ -164,6 +172,19 @@ func (c *upgradeManagementTestSetup) expectPauseGitOpsReconcile(err error) {
 			c.ctx, c.managementCluster, c.newClusterSpec, c.provider,
 		).Return(err),
 	)
+	if err == nil {
+		c.expectProposeGitEksaSpec(nil)
+	}
+}
+
+func (c *upgradeManagementTestSetup) expectProposeGitEksaSpec(err error) {
+	c.expectDatacenterConfig()
+	c.expectMachineConfigs()
+	gomock.InOrder(
+		c.gitOpsManager.EXPECT().ProposeGitEksaSpec(
+			c.ctx, c.newClusterSpec, c.datacenterConfig, c.machineConfigs,
+		).Return(err),
+	)
 }
 
 func (c *upgradeManagementTestSetup) expectPreCoreComponentsUpgrade() {
@@ -207,12 +228,18 @@ func (c *upgradeManagementTestSetup) expectBackupManagementFromCluster(err error
 	gomock.InOrder(
 		c.clusterManager.EXPECT().BackupCAPI(c.ctx, c.managementCluster, c.managementStatePath, "").Return(err),
 	)
+	if err == nil {
+		c.expectBuildClientFromKubeconfig(nil)
+	}
 }
 
 func (c *upgradeManagementTestSetup) expectBackupManagementInfrastructureFromCluster(err error) {
 	gomock.InOrder(
 		c.clusterManager.EXPECT().BackupCAPIWaitForInfrastructure(c.ctx, c.managementCluster, c.managementStatePath, c.managementCluster.Name).Return(err),
 	)
+	if err == nil {
+		c.expectBuildClientFromKubeconfig(nil)
+	}
 }
 
 func (c *upgradeManagementTestSetup) expectPauseCAPIWorkloadClusters(err error) {
@@ -386,6 +413,46 @@ func TestUpgradeManagementRunPauseGitOpsReconcileUpgradeFailed(t *testing.T) {
 	}
 }
 
+func TestUpgradeManagementRunProposeGitEksaSpecFailed(t *testing.T) {
+	os.Unsetenv(features.CheckpointEnabledEnvVar)
+	features.ClearCache()
+	test := newUpgradeManagementClusterTest(t)
+	test.expectSetup()
+	test.expectPreflightValidationsToPass()
+	test.expectUpdateSecrets(nil)
+	test.expectEnsureManagementEtcdCAPIComponentsExist(nil)
+	test.gitOpsManager.EXPECT().PauseClusterResourcesReconcile(test.ctx, test.managementCluster, test.newClusterSpec, test.provider)
+	test.expectProposeGitEksaSpec(errors.New("pull request was closed without being merged"))
+	test.expectResumeGitOpsReconcile(nil)
+	test.expectSaveLogs()
+	test.expectWriteCheckpointFile()
+
+	err := test.run()
+	if err == nil {
+		t.Fatal("UpgradeManagement.Run() err = nil, want err not nil")
+	}
+}
+
+func TestUpgradeManagementRunProposeGitEksaSpecFailedResumeFailed(t *testing.T) {
+	os.Unsetenv(features.CheckpointEnabledEnvVar)
+	features.ClearCache()
+	test := newUpgradeManagementClusterTest(t)
+	test.expectSetup()
+	test.expectPreflightValidationsToPass()
+	test.expectUpdateSecrets(nil)
+	test.expectEnsureManagementEtcdCAPIComponentsExist(nil)
+	test.gitOpsManager.EXPECT().PauseClusterResourcesReconcile(test.ctx, test.managementCluster, test.newClusterSpec, test.provider)
+	test.expectProposeGitEksaSpec(errors.New("timed out waiting for pull request to be merged"))
+	test.expectResumeGitOpsReconcile(errors.New("resuming"))
+	test.expectSaveLogs()
+	test.expectWriteCheckpointFile()
+
+	err := test.run()
+	if err == nil || err.Error() != "timed out waiting for pull request to be merged" {
+		t.Fatalf("UpgradeManagement.Run() err = %v, want pull request error", err)
+	}
+}
+
 func TestUpgradeManagementRunFailedBackup(t *testing.T) {
 	os.Unsetenv(features.CheckpointEnabledEnvVar)
 	features.ClearCache()
@@ -755,7 +822,8 @@ func TestUpgradeManagementRunUpgradeCuratedPackagesSuccess(t *testing.T) {
 			Namespace: constants.EksaPackagesName,
 		},
 	}
-	tt.client = test.NewFakeKubeClient(tt.currentClusterSpec.Cluster, tt.currentClusterSpec.EKSARelease, tt.currentClusterSpec.Bundles, packagesManager)
+	tt.client = test.NewFakeKubeClient(append([]client.Object{tt.currentClusterSpec.Cluster, tt.currentClusterSpec.EKSARelease, tt.currentClusterSpec.Bundles, packagesManager},
+		capiObjects("management")...)...)
 	tt.expectSetup()
 	tt.expectPreflightValidationsToPass()
 	tt.expectUpdateSecrets(nil)
@@ -849,3 +917,31 @@ func TestTinkerbellUpgradeManagementRunSuccess(t *testing.T) {
 		t.Fatalf("UpgradeManagement.Run() err = %v, want err = nil", err)
 	}
 }
+
+// capiObjects returns the CAPI objects the upgrade saves in the cluster snapshot.
+func capiObjects(clusterName string) []client.Object {
+	template := &dockerv1.DockerMachineTemplate{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "DockerMachineTemplate",
+			APIVersion: dockerv1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      clusterName + "-control-plane-1",
+			Namespace: constants.EksaSystemNamespace,
+		},
+	}
+	kcp := test.KubeadmControlPlane(func(kcp *controlplanev1.KubeadmControlPlane) {
+		kcp.Name = clusterName
+		kcp.Spec.MachineTemplate.InfrastructureRef = corev1.ObjectReference{
+			Kind:       template.Kind,
+			APIVersion: template.APIVersion,
+			Name:       template.Name,
+		}
+	})
+	capiCluster := test.CAPICluster(func(c *clusterv1.Cluster) {
+		c.Name = clusterName
+		c.Spec.ControlPlaneRef = &corev1.ObjectReference{Name: kcp.Name}
+	})
+
+	return []client.Object{capiCluster, kcp, template}
+}
@@ -0,0 +1,34 @@
+package workflows
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/task"
+)
+
+// WriteClusterSnapshot saves the EKS-A and CAPI objects of the cluster being upgraded, as they were before the upgrade,
+// next to the CAPI backup. They are used by the rollback cluster command if the upgrade fails.
+func WriteClusterSnapshot(ctx context.Context, commandContext *task.CommandContext) error {
+	client, err := commandContext.ClientFactory.BuildClientFromKubeconfig(commandContext.ManagementCluster.KubeconfigFile)
+	if err != nil {
+		return fmt.Errorf("building client for cluster snapshot: %v", err)
+	}
+
+	snapshot := cluster.NewSnapshot(commandContext.CurrentClusterSpec)
+	snapshot.CAPI, err = cluster.NewSnapshotCAPI(ctx, client, commandContext.CurrentClusterSpec.Cluster)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Join(commandContext.ManagementCluster.Name, commandContext.BackupClusterStateDir)
+	if err := cluster.WriteSnapshot(dir, snapshot); err != nil {
+		return err
+	}
+
+	logger.Info(fmt.Sprintf("Cluster snapshot saved to %s, if the upgrade fails it can be rolled back with `eksctl anywhere rollback cluster --snapshot-dir %s`", dir, dir))
+	return nil
+}
@@ -20,6 +20,11 @@ func (s *preClusterUpgrade) Run(ctx context.Context, commandContext *task.Comman
 		return &workflows.CollectMgmtClusterDiagnosticsTask{}
 	}
 
+	if err := workflows.WriteClusterSnapshot(ctx, commandContext); err != nil {
+		commandContext.SetError(err)
+		return &workflows.CollectMgmtClusterDiagnosticsTask{}
+	}
+
 	return &upgradeCluster{}
 }
 
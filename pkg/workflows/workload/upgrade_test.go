@@ -9,12 +9,18 @@ import (
 	"time"
 
 	"github.com/golang/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	dockerv1 "sigs.k8s.io/cluster-api/test/infrastructure/docker/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/aws/eks-anywhere/internal/test"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/features"
 	writermocks "github.com/aws/eks-anywhere/pkg/filewriter/mocks"
 	"github.com/aws/eks-anywhere/pkg/providers"
@@ -82,7 +88,9 @@ func newUpgradeTest(t *testing.T) *upgradeTestSetup {
 		t.Setenv(e, "true")
 	}
 
-	client := test.NewFakeKubeClient()
+	client := test.NewFakeKubeClient(capiObjects("workload")...)
+	// The cluster snapshot is written to the management cluster folder.
+	t.Cleanup(func() { os.RemoveAll("management") })
 
 	return &upgradeTestSetup{
 		t:                t,
@@ -168,6 +176,9 @@ func (c *upgradeTestSetup) expectBackupWorkloadFromCluster(err error) {
 	gomock.InOrder(
 		c.clusterManager.EXPECT().BackupCAPI(c.ctx, c.clusterSpec.ManagementCluster, c.backupClusterStateDir, c.workloadCluster.Name).Return(err),
 	)
+	if err == nil {
+		c.expectBuildClientFromKubeconfig(nil)
+	}
 }
 
 func (c *upgradeTestSetup) expectSaveLogsManagement() {
@@ -291,7 +302,6 @@ func TestTinkerbellUpgradeRunUpgradeClientApplyServerSideFail(t *testing.T) {
 	features.ClearCache()
 	os.Setenv(features.UseControllerForCli, "true")
 	tt := newUpgradeTest(t)
-	tt.client = test.NewFakeKubeClientAlwaysError()
 	datacenterConfig := &v1alpha1.TinkerbellDatacenterConfig{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "my-datacenter-config",
@@ -310,6 +320,7 @@ func TestTinkerbellUpgradeRunUpgradeClientApplyServerSideFail(t *testing.T) {
 	tt.expectMachineConfigs()
 	tt.expectBackupWorkloadFromCluster(nil)
 	tt.expectUpgradeWorkloadCluster(nil)
+	tt.client = test.NewFakeKubeClientAlwaysError()
 	tt.expectBuildClientFromKubeconfig(nil)
 	tt.expectSaveLogsManagement()
 
@@ -385,3 +396,31 @@ func TestUpgradeRunWriteClusterConfigFail(t *testing.T) {
 		t.Fatalf("Upgrade.Run() err = %v, want err = nil", err)
 	}
 }
+
+// capiObjects returns the CAPI objects the upgrade saves in the cluster snapshot.
+func capiObjects(clusterName string) []client.Object {
+	template := &dockerv1.DockerMachineTemplate{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "DockerMachineTemplate",
+			APIVersion: dockerv1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      clusterName + "-control-plane-1",
+			Namespace: constants.EksaSystemNamespace,
+		},
+	}
+	kcp := test.KubeadmControlPlane(func(kcp *controlplanev1.KubeadmControlPlane) {
+		kcp.Name = clusterName
+		kcp.Spec.MachineTemplate.InfrastructureRef = corev1.ObjectReference{
+			Kind:       template.Kind,
+			APIVersion: template.APIVersion,
+			Name:       template.Name,
+		}
+	})
+	capiCluster := test.CAPICluster(func(c *clusterv1.Cluster) {
+		c.Name = clusterName
+		c.Spec.ControlPlaneRef = &corev1.ObjectReference{Name: kcp.Name}
+	})
+
+	return []client.Object{capiCluster, kcp, template}
+}